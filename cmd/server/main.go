@@ -5,6 +5,7 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	app "github.com/Arcadian-Sky/datakkeeper/internal/app/server"
 	"github.com/Arcadian-Sky/datakkeeper/internal/server/repository"
@@ -30,21 +31,22 @@ func main() {
 	parsed := settings.Parse()
 	ap.SetFlags(parsed)
 
-	//set db pg connect
+	//set db connect: postgres или встроенная SQLite по схеме DSN
 	// ap.Logger.Debug("parsed.PGDBSettings: ", parsed.DBPGSettings, "\n")
-	dbP, err := app.NewConnectionToPostgresDB(parsed.DBPGSettings, logg)
+	dbP, err := app.NewConnectionToDB(parsed.DBPGSettings, logg)
 	if err != nil {
 		ap.Logger.Fatal("failed to connect to db: " + err.Error())
 	}
 	ap.SetDBPG(dbP)
+	ap.SetDBDriver(app.DBDriver(parsed.DBPGSettings))
 
-	//set db filestore connect
-	// ap.Logger.Debug("parsed.MinIOSettings: ", parsed.Storage, "\n")
-	client, err := app.NewСonnectToMinIO(ap.Ctx, parsed.Storage, logg)
+	//set file repo: MinIO или каталоги на диске сервера
+	// ap.Logger.Debug("parsed.Storage: ", parsed.Storage, "\n")
+	frepo, err := ap.NewFileRepo(parsed.Storage)
 	if err != nil {
 		ap.Logger.Fatal("failed to connect to starage: " + err.Error())
 	}
-	ap.SetStorage(client)
+	ap.SetDBFileRepo(frepo)
 
 	defer ap.DBPG.Close()
 
@@ -54,24 +56,54 @@ func main() {
 	repo := repository.NewUserRepository(ap.DBPG, ap.Logger)
 	ap.SetUserRepo(repo)
 
-	//set data repo
-	repod := repository.NewDataRepository(ap.DBPG, ap.Logger)
+	//set data and sync repos
+	repod, reposync := ap.NewDataRepos()
 	ap.SetDataRepo(repod)
+	ap.SetSyncRepo(reposync)
+
+	//set session repo
+	repos := repository.NewSessionRepository(ap.DBPG, ap.Logger)
+	ap.SetSessionRepo(repos)
 
-	err = ap.MigrateDBPG()
+	//set metadata repo: метаданные файлов в MongoDB, если задан MG_DATABASE_URI
+	repom, err := ap.NewItemMetaRepo(parsed.DBMGSettings)
+	if err != nil {
+		ap.Logger.Fatal("failed to connect to mongodb: " + err.Error())
+	}
+	ap.SetItemMetaRepo(repom)
+	if ap.DBMG != nil {
+		defer ap.DBMG.Disconnect(context.Background())
+	}
+
+	//set upload repo
+	repoup := repository.NewUploadRepository(ap.DBPG, ap.Logger, parsed.Upload.Dir)
+	ap.SetUploadRepo(repoup)
+
+	err = ap.MigrateDB()
 	if err != nil {
 		ap.Logger.Fatal(err)
 	}
 
-	frepo := repository.NewFileRepository(ap.Storage, ap.Logger, &ap.Ctx)
-	ap.SetDBFileRepo(frepo)
+	err = ap.InitKeyring()
+	if err != nil {
+		ap.Logger.Fatal("failed to init jwt keys: " + err.Error())
+	}
+
+	go ap.RunTrashPurge(ap.Ctx, ap.Flags.Trash.Retention, ap.Flags.Trash.PurgeInterval)
+	go ap.RunUploadPurge(ap.Ctx, ap.Flags.Upload.MaxAge, time.Hour)
+	go ap.RunVersionExpiry(ap.Ctx, 24*time.Hour)
 
 	server, err := router.InitGRPCServer(
 		ap.Flags,
 		ap.Logger,
+		ap.Keys,
 		ap.GetFileRepo(),
 		ap.GetUserRepo(),
 		ap.GetDataRepo(),
+		ap.GetSessionRepo(),
+		ap.GetItemMetaRepo(),
+		ap.GetSyncRepo(),
+		ap.GetUploadRepo(),
 	)
 
 	go func() {
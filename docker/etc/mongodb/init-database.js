@@ -6,11 +6,11 @@ db.auth(
 db = db.getSiblingDB(process.env.MONGO_APP_DB)
 
 db.createUser({
-    user: process.env.MONGO_APP_USERNAME,
+    user: process.env.MONGO_APP_USER,
     pwd: process.env.MONGO_APP_PASSWORD,
     roles: [
         {
-            role: 'root',
+            role: 'readWrite',
             db: process.env.MONGO_APP_DB,
         },
     ],
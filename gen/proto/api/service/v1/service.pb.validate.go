@@ -68,6 +68,32 @@ func (m *Data) validate(all bool) error {
 
 	// no validation rules for Id
 
+	// no validation rules for KeyHash
+
+	// no validation rules for Note
+
+	// no validation rules for Binary
+
+	// no validation rules for CardHolder
+
+	// no validation rules for CardExpMonth
+
+	// no validation rules for CardExpYear
+
+	// no validation rules for CardCvv
+
+	// no validation rules for CardBank
+
+	// no validation rules for CardPin
+
+	// no validation rules for CardBrand
+
+	// no validation rules for Meta
+
+	// no validation rules for Tags
+
+	// no validation rules for Revision
+
 	if len(errors) > 0 {
 		return DataMultiError(errors)
 	}
@@ -171,6 +197,26 @@ func (m *FileItem) validate(all bool) error {
 
 	// no validation rules for Key
 
+	// no validation rules for Meta
+
+	// no validation rules for Tags
+
+	// no validation rules for Revision
+
+	// no validation rules for Size
+
+	// no validation rules for ContentType
+
+	// no validation rules for UploadedAt
+
+	// no validation rules for Sha256
+
+	// no validation rules for Device
+
+	// no validation rules for Description
+
+	// no validation rules for IsFolder
+
 	if len(errors) > 0 {
 		return FileItemMultiError(errors)
 	}
@@ -272,6 +318,10 @@ func (m *GetFileRequest) validate(all bool) error {
 
 	// no validation rules for Name
 
+	// no validation rules for VersionId
+
+	// no validation rules for Offset
+
 	if len(errors) > 0 {
 		return GetFileRequestMultiError(errors)
 	}
@@ -372,6 +422,24 @@ func (m *ListFileRequest) validate(all bool) error {
 
 	var errors []error
 
+	// no validation rules for Extensions
+
+	// no validation rules for Search
+
+	// no validation rules for Tags
+
+	// no validation rules for Sort
+
+	// no validation rules for Desc
+
+	// no validation rules for PageSize
+
+	// no validation rules for PageToken
+
+	// no validation rules for Browse
+
+	// no validation rules for Parent
+
 	if len(errors) > 0 {
 		return ListFileRequestMultiError(errors)
 	}
@@ -506,6 +574,8 @@ func (m *ListFileResponse) validate(all bool) error {
 
 	}
 
+	// no validation rules for NextPageToken
+
 	if len(errors) > 0 {
 		return ListFileResponseMultiError(errors)
 	}
@@ -687,22 +757,22 @@ var _ interface {
 	ErrorName() string
 } = FileChunkValidationError{}
 
-// Validate checks the field values on DeleteFileRequest with the rules defined
+// Validate checks the field values on InitUploadRequest with the rules defined
 // in the proto definition for this message. If any rules are violated, the
 // first error encountered is returned, or nil if there are no violations.
-func (m *DeleteFileRequest) Validate() error {
+func (m *InitUploadRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on DeleteFileRequest with the rules
+// ValidateAll checks the field values on InitUploadRequest with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// DeleteFileRequestMultiError, or nil if none found.
-func (m *DeleteFileRequest) ValidateAll() error {
+// InitUploadRequestMultiError, or nil if none found.
+func (m *InitUploadRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *DeleteFileRequest) validate(all bool) error {
+func (m *InitUploadRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
@@ -711,20 +781,24 @@ func (m *DeleteFileRequest) validate(all bool) error {
 
 	// no validation rules for Filename
 
+	// no validation rules for Size
+
+	// no validation rules for Sha256
+
 	if len(errors) > 0 {
-		return DeleteFileRequestMultiError(errors)
+		return InitUploadRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// DeleteFileRequestMultiError is an error wrapping multiple validation errors
-// returned by DeleteFileRequest.ValidateAll() if the designated constraints
+// InitUploadRequestMultiError is an error wrapping multiple validation errors
+// returned by InitUploadRequest.ValidateAll() if the designated constraints
 // aren't met.
-type DeleteFileRequestMultiError []error
+type InitUploadRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m DeleteFileRequestMultiError) Error() string {
+func (m InitUploadRequestMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -733,11 +807,11 @@ func (m DeleteFileRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m DeleteFileRequestMultiError) AllErrors() []error { return m }
+func (m InitUploadRequestMultiError) AllErrors() []error { return m }
 
-// DeleteFileRequestValidationError is the validation error returned by
-// DeleteFileRequest.Validate if the designated constraints aren't met.
-type DeleteFileRequestValidationError struct {
+// InitUploadRequestValidationError is the validation error returned by
+// InitUploadRequest.Validate if the designated constraints aren't met.
+type InitUploadRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -745,24 +819,24 @@ type DeleteFileRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e DeleteFileRequestValidationError) Field() string { return e.field }
+func (e InitUploadRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e DeleteFileRequestValidationError) Reason() string { return e.reason }
+func (e InitUploadRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e DeleteFileRequestValidationError) Cause() error { return e.cause }
+func (e InitUploadRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e DeleteFileRequestValidationError) Key() bool { return e.key }
+func (e InitUploadRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e DeleteFileRequestValidationError) ErrorName() string {
-	return "DeleteFileRequestValidationError"
+func (e InitUploadRequestValidationError) ErrorName() string {
+	return "InitUploadRequestValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e DeleteFileRequestValidationError) Error() string {
+func (e InitUploadRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -774,14 +848,14 @@ func (e DeleteFileRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sDeleteFileRequest.%s: %s%s",
+		"invalid %sInitUploadRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = DeleteFileRequestValidationError{}
+var _ error = InitUploadRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -789,47 +863,48 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = DeleteFileRequestValidationError{}
+} = InitUploadRequestValidationError{}
 
-// Validate checks the field values on UploadStatus with the rules defined in
-// the proto definition for this message. If any rules are violated, the first
-// error encountered is returned, or nil if there are no violations.
-func (m *UploadStatus) Validate() error {
+// Validate checks the field values on InitUploadResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *InitUploadResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on UploadStatus with the rules defined
-// in the proto definition for this message. If any rules are violated, the
-// result is a list of violation errors wrapped in UploadStatusMultiError, or
-// nil if none found.
-func (m *UploadStatus) ValidateAll() error {
+// ValidateAll checks the field values on InitUploadResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// InitUploadResponseMultiError, or nil if none found.
+func (m *InitUploadResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *UploadStatus) validate(all bool) error {
+func (m *InitUploadResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for Success
+	// no validation rules for UploadId
 
-	// no validation rules for Message
+	// no validation rules for Offset
 
 	if len(errors) > 0 {
-		return UploadStatusMultiError(errors)
+		return InitUploadResponseMultiError(errors)
 	}
 
 	return nil
 }
 
-// UploadStatusMultiError is an error wrapping multiple validation errors
-// returned by UploadStatus.ValidateAll() if the designated constraints aren't met.
-type UploadStatusMultiError []error
+// InitUploadResponseMultiError is an error wrapping multiple validation errors
+// returned by InitUploadResponse.ValidateAll() if the designated constraints
+// aren't met.
+type InitUploadResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m UploadStatusMultiError) Error() string {
+func (m InitUploadResponseMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -838,11 +913,11 @@ func (m UploadStatusMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m UploadStatusMultiError) AllErrors() []error { return m }
+func (m InitUploadResponseMultiError) AllErrors() []error { return m }
 
-// UploadStatusValidationError is the validation error returned by
-// UploadStatus.Validate if the designated constraints aren't met.
-type UploadStatusValidationError struct {
+// InitUploadResponseValidationError is the validation error returned by
+// InitUploadResponse.Validate if the designated constraints aren't met.
+type InitUploadResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -850,22 +925,24 @@ type UploadStatusValidationError struct {
 }
 
 // Field function returns field value.
-func (e UploadStatusValidationError) Field() string { return e.field }
+func (e InitUploadResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e UploadStatusValidationError) Reason() string { return e.reason }
+func (e InitUploadResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e UploadStatusValidationError) Cause() error { return e.cause }
+func (e InitUploadResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e UploadStatusValidationError) Key() bool { return e.key }
+func (e InitUploadResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e UploadStatusValidationError) ErrorName() string { return "UploadStatusValidationError" }
+func (e InitUploadResponseValidationError) ErrorName() string {
+	return "InitUploadResponseValidationError"
+}
 
 // Error satisfies the builtin error interface
-func (e UploadStatusValidationError) Error() string {
+func (e InitUploadResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -877,14 +954,14 @@ func (e UploadStatusValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sUploadStatus.%s: %s%s",
+		"invalid %sInitUploadResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = UploadStatusValidationError{}
+var _ error = InitUploadResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -892,73 +969,50 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = UploadStatusValidationError{}
+} = InitUploadResponseValidationError{}
 
-// Validate checks the field values on SaveDataRequest with the rules defined
-// in the proto definition for this message. If any rules are violated, the
-// first error encountered is returned, or nil if there are no violations.
-func (m *SaveDataRequest) Validate() error {
+// Validate checks the field values on UploadChunkRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *UploadChunkRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on SaveDataRequest with the rules
+// ValidateAll checks the field values on UploadChunkRequest with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// SaveDataRequestMultiError, or nil if none found.
-func (m *SaveDataRequest) ValidateAll() error {
+// UploadChunkRequestMultiError, or nil if none found.
+func (m *UploadChunkRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *SaveDataRequest) validate(all bool) error {
+func (m *UploadChunkRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	if all {
-		switch v := interface{}(m.GetData()).(type) {
-		case interface{ ValidateAll() error }:
-			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, SaveDataRequestValidationError{
-					field:  "Data",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		case interface{ Validate() error }:
-			if err := v.Validate(); err != nil {
-				errors = append(errors, SaveDataRequestValidationError{
-					field:  "Data",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		}
-	} else if v, ok := interface{}(m.GetData()).(interface{ Validate() error }); ok {
-		if err := v.Validate(); err != nil {
-			return SaveDataRequestValidationError{
-				field:  "Data",
-				reason: "embedded message failed validation",
-				cause:  err,
-			}
-		}
-	}
+	// no validation rules for UploadId
+
+	// no validation rules for Offset
+
+	// no validation rules for Data
 
 	if len(errors) > 0 {
-		return SaveDataRequestMultiError(errors)
+		return UploadChunkRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// SaveDataRequestMultiError is an error wrapping multiple validation errors
-// returned by SaveDataRequest.ValidateAll() if the designated constraints
+// UploadChunkRequestMultiError is an error wrapping multiple validation errors
+// returned by UploadChunkRequest.ValidateAll() if the designated constraints
 // aren't met.
-type SaveDataRequestMultiError []error
+type UploadChunkRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m SaveDataRequestMultiError) Error() string {
+func (m UploadChunkRequestMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -967,11 +1021,11 @@ func (m SaveDataRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m SaveDataRequestMultiError) AllErrors() []error { return m }
+func (m UploadChunkRequestMultiError) AllErrors() []error { return m }
 
-// SaveDataRequestValidationError is the validation error returned by
-// SaveDataRequest.Validate if the designated constraints aren't met.
-type SaveDataRequestValidationError struct {
+// UploadChunkRequestValidationError is the validation error returned by
+// UploadChunkRequest.Validate if the designated constraints aren't met.
+type UploadChunkRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -979,22 +1033,24 @@ type SaveDataRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e SaveDataRequestValidationError) Field() string { return e.field }
+func (e UploadChunkRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e SaveDataRequestValidationError) Reason() string { return e.reason }
+func (e UploadChunkRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e SaveDataRequestValidationError) Cause() error { return e.cause }
+func (e UploadChunkRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e SaveDataRequestValidationError) Key() bool { return e.key }
+func (e UploadChunkRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e SaveDataRequestValidationError) ErrorName() string { return "SaveDataRequestValidationError" }
+func (e UploadChunkRequestValidationError) ErrorName() string {
+	return "UploadChunkRequestValidationError"
+}
 
 // Error satisfies the builtin error interface
-func (e SaveDataRequestValidationError) Error() string {
+func (e UploadChunkRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1006,14 +1062,14 @@ func (e SaveDataRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sSaveDataRequest.%s: %s%s",
+		"invalid %sUploadChunkRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = SaveDataRequestValidationError{}
+var _ error = UploadChunkRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -1021,46 +1077,46 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = SaveDataRequestValidationError{}
+} = UploadChunkRequestValidationError{}
 
-// Validate checks the field values on GetDataRequest with the rules defined in
-// the proto definition for this message. If any rules are violated, the first
-// error encountered is returned, or nil if there are no violations.
-func (m *GetDataRequest) Validate() error {
+// Validate checks the field values on GetUploadOffsetRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetUploadOffsetRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on GetDataRequest with the rules defined
-// in the proto definition for this message. If any rules are violated, the
-// result is a list of violation errors wrapped in GetDataRequestMultiError,
-// or nil if none found.
-func (m *GetDataRequest) ValidateAll() error {
+// ValidateAll checks the field values on GetUploadOffsetRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetUploadOffsetRequestMultiError, or nil if none found.
+func (m *GetUploadOffsetRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *GetDataRequest) validate(all bool) error {
+func (m *GetUploadOffsetRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for Dataid
+	// no validation rules for UploadId
 
 	if len(errors) > 0 {
-		return GetDataRequestMultiError(errors)
+		return GetUploadOffsetRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// GetDataRequestMultiError is an error wrapping multiple validation errors
-// returned by GetDataRequest.ValidateAll() if the designated constraints
-// aren't met.
-type GetDataRequestMultiError []error
+// GetUploadOffsetRequestMultiError is an error wrapping multiple validation
+// errors returned by GetUploadOffsetRequest.ValidateAll() if the designated
+// constraints aren't met.
+type GetUploadOffsetRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m GetDataRequestMultiError) Error() string {
+func (m GetUploadOffsetRequestMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1069,11 +1125,11 @@ func (m GetDataRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m GetDataRequestMultiError) AllErrors() []error { return m }
+func (m GetUploadOffsetRequestMultiError) AllErrors() []error { return m }
 
-// GetDataRequestValidationError is the validation error returned by
-// GetDataRequest.Validate if the designated constraints aren't met.
-type GetDataRequestValidationError struct {
+// GetUploadOffsetRequestValidationError is the validation error returned by
+// GetUploadOffsetRequest.Validate if the designated constraints aren't met.
+type GetUploadOffsetRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1081,22 +1137,24 @@ type GetDataRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e GetDataRequestValidationError) Field() string { return e.field }
+func (e GetUploadOffsetRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e GetDataRequestValidationError) Reason() string { return e.reason }
+func (e GetUploadOffsetRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e GetDataRequestValidationError) Cause() error { return e.cause }
+func (e GetUploadOffsetRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e GetDataRequestValidationError) Key() bool { return e.key }
+func (e GetUploadOffsetRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e GetDataRequestValidationError) ErrorName() string { return "GetDataRequestValidationError" }
+func (e GetUploadOffsetRequestValidationError) ErrorName() string {
+	return "GetUploadOffsetRequestValidationError"
+}
 
 // Error satisfies the builtin error interface
-func (e GetDataRequestValidationError) Error() string {
+func (e GetUploadOffsetRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1108,14 +1166,14 @@ func (e GetDataRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sGetDataRequest.%s: %s%s",
+		"invalid %sGetUploadOffsetRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = GetDataRequestValidationError{}
+var _ error = GetUploadOffsetRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -1123,73 +1181,45 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = GetDataRequestValidationError{}
+} = GetUploadOffsetRequestValidationError{}
 
-// Validate checks the field values on GetDataResponse with the rules defined
-// in the proto definition for this message. If any rules are violated, the
-// first error encountered is returned, or nil if there are no violations.
-func (m *GetDataResponse) Validate() error {
+// Validate checks the field values on UploadOffset with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *UploadOffset) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on GetDataResponse with the rules
-// defined in the proto definition for this message. If any rules are
-// violated, the result is a list of violation errors wrapped in
-// GetDataResponseMultiError, or nil if none found.
-func (m *GetDataResponse) ValidateAll() error {
+// ValidateAll checks the field values on UploadOffset with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in UploadOffsetMultiError, or
+// nil if none found.
+func (m *UploadOffset) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *GetDataResponse) validate(all bool) error {
+func (m *UploadOffset) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	if all {
-		switch v := interface{}(m.GetData()).(type) {
-		case interface{ ValidateAll() error }:
-			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, GetDataResponseValidationError{
-					field:  "Data",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		case interface{ Validate() error }:
-			if err := v.Validate(); err != nil {
-				errors = append(errors, GetDataResponseValidationError{
-					field:  "Data",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		}
-	} else if v, ok := interface{}(m.GetData()).(interface{ Validate() error }); ok {
-		if err := v.Validate(); err != nil {
-			return GetDataResponseValidationError{
-				field:  "Data",
-				reason: "embedded message failed validation",
-				cause:  err,
-			}
-		}
-	}
+	// no validation rules for Offset
 
 	if len(errors) > 0 {
-		return GetDataResponseMultiError(errors)
+		return UploadOffsetMultiError(errors)
 	}
 
 	return nil
 }
 
-// GetDataResponseMultiError is an error wrapping multiple validation errors
-// returned by GetDataResponse.ValidateAll() if the designated constraints
-// aren't met.
-type GetDataResponseMultiError []error
+// UploadOffsetMultiError is an error wrapping multiple validation errors
+// returned by UploadOffset.ValidateAll() if the designated constraints aren't met.
+type UploadOffsetMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m GetDataResponseMultiError) Error() string {
+func (m UploadOffsetMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1198,11 +1228,11 @@ func (m GetDataResponseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m GetDataResponseMultiError) AllErrors() []error { return m }
+func (m UploadOffsetMultiError) AllErrors() []error { return m }
 
-// GetDataResponseValidationError is the validation error returned by
-// GetDataResponse.Validate if the designated constraints aren't met.
-type GetDataResponseValidationError struct {
+// UploadOffsetValidationError is the validation error returned by
+// UploadOffset.Validate if the designated constraints aren't met.
+type UploadOffsetValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1210,22 +1240,22 @@ type GetDataResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e GetDataResponseValidationError) Field() string { return e.field }
+func (e UploadOffsetValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e GetDataResponseValidationError) Reason() string { return e.reason }
+func (e UploadOffsetValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e GetDataResponseValidationError) Cause() error { return e.cause }
+func (e UploadOffsetValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e GetDataResponseValidationError) Key() bool { return e.key }
+func (e UploadOffsetValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e GetDataResponseValidationError) ErrorName() string { return "GetDataResponseValidationError" }
+func (e UploadOffsetValidationError) ErrorName() string { return "UploadOffsetValidationError" }
 
 // Error satisfies the builtin error interface
-func (e GetDataResponseValidationError) Error() string {
+func (e UploadOffsetValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1237,14 +1267,14 @@ func (e GetDataResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sGetDataResponse.%s: %s%s",
+		"invalid %sUploadOffset.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = GetDataResponseValidationError{}
+var _ error = UploadOffsetValidationError{}
 
 var _ interface {
 	Field() string
@@ -1252,46 +1282,46 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = GetDataResponseValidationError{}
+} = UploadOffsetValidationError{}
 
-// Validate checks the field values on ListDataRequest with the rules defined
-// in the proto definition for this message. If any rules are violated, the
-// first error encountered is returned, or nil if there are no violations.
-func (m *ListDataRequest) Validate() error {
+// Validate checks the field values on FinalizeUploadRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *FinalizeUploadRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on ListDataRequest with the rules
+// ValidateAll checks the field values on FinalizeUploadRequest with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// ListDataRequestMultiError, or nil if none found.
-func (m *ListDataRequest) ValidateAll() error {
+// FinalizeUploadRequestMultiError, or nil if none found.
+func (m *FinalizeUploadRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *ListDataRequest) validate(all bool) error {
+func (m *FinalizeUploadRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for Type
+	// no validation rules for UploadId
 
 	if len(errors) > 0 {
-		return ListDataRequestMultiError(errors)
+		return FinalizeUploadRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// ListDataRequestMultiError is an error wrapping multiple validation errors
-// returned by ListDataRequest.ValidateAll() if the designated constraints
-// aren't met.
-type ListDataRequestMultiError []error
+// FinalizeUploadRequestMultiError is an error wrapping multiple validation
+// errors returned by FinalizeUploadRequest.ValidateAll() if the designated
+// constraints aren't met.
+type FinalizeUploadRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m ListDataRequestMultiError) Error() string {
+func (m FinalizeUploadRequestMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1300,11 +1330,11 @@ func (m ListDataRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m ListDataRequestMultiError) AllErrors() []error { return m }
+func (m FinalizeUploadRequestMultiError) AllErrors() []error { return m }
 
-// ListDataRequestValidationError is the validation error returned by
-// ListDataRequest.Validate if the designated constraints aren't met.
-type ListDataRequestValidationError struct {
+// FinalizeUploadRequestValidationError is the validation error returned by
+// FinalizeUploadRequest.Validate if the designated constraints aren't met.
+type FinalizeUploadRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1312,22 +1342,24 @@ type ListDataRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e ListDataRequestValidationError) Field() string { return e.field }
+func (e FinalizeUploadRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e ListDataRequestValidationError) Reason() string { return e.reason }
+func (e FinalizeUploadRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e ListDataRequestValidationError) Cause() error { return e.cause }
+func (e FinalizeUploadRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e ListDataRequestValidationError) Key() bool { return e.key }
+func (e FinalizeUploadRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e ListDataRequestValidationError) ErrorName() string { return "ListDataRequestValidationError" }
+func (e FinalizeUploadRequestValidationError) ErrorName() string {
+	return "FinalizeUploadRequestValidationError"
+}
 
 // Error satisfies the builtin error interface
-func (e ListDataRequestValidationError) Error() string {
+func (e FinalizeUploadRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1339,14 +1371,14 @@ func (e ListDataRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sListDataRequest.%s: %s%s",
+		"invalid %sFinalizeUploadRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = ListDataRequestValidationError{}
+var _ error = FinalizeUploadRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -1354,78 +1386,50 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = ListDataRequestValidationError{}
+} = FinalizeUploadRequestValidationError{}
 
-// Validate checks the field values on ListDataResponse with the rules defined
+// Validate checks the field values on DeleteFileRequest with the rules defined
 // in the proto definition for this message. If any rules are violated, the
 // first error encountered is returned, or nil if there are no violations.
-func (m *ListDataResponse) Validate() error {
+func (m *DeleteFileRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on ListDataResponse with the rules
+// ValidateAll checks the field values on DeleteFileRequest with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// ListDataResponseMultiError, or nil if none found.
-func (m *ListDataResponse) ValidateAll() error {
+// DeleteFileRequestMultiError, or nil if none found.
+func (m *DeleteFileRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *ListDataResponse) validate(all bool) error {
+func (m *DeleteFileRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	for idx, item := range m.GetData() {
-		_, _ = idx, item
-
-		if all {
-			switch v := interface{}(item).(type) {
-			case interface{ ValidateAll() error }:
-				if err := v.ValidateAll(); err != nil {
-					errors = append(errors, ListDataResponseValidationError{
-						field:  fmt.Sprintf("Data[%v]", idx),
-						reason: "embedded message failed validation",
-						cause:  err,
-					})
-				}
-			case interface{ Validate() error }:
-				if err := v.Validate(); err != nil {
-					errors = append(errors, ListDataResponseValidationError{
-						field:  fmt.Sprintf("Data[%v]", idx),
-						reason: "embedded message failed validation",
-						cause:  err,
-					})
-				}
-			}
-		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
-			if err := v.Validate(); err != nil {
-				return ListDataResponseValidationError{
-					field:  fmt.Sprintf("Data[%v]", idx),
-					reason: "embedded message failed validation",
-					cause:  err,
-				}
-			}
-		}
+	// no validation rules for Filename
 
+	if m.ExpectedRevision != nil {
+		// no validation rules for ExpectedRevision
 	}
 
 	if len(errors) > 0 {
-		return ListDataResponseMultiError(errors)
+		return DeleteFileRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// ListDataResponseMultiError is an error wrapping multiple validation errors
-// returned by ListDataResponse.ValidateAll() if the designated constraints
+// DeleteFileRequestMultiError is an error wrapping multiple validation errors
+// returned by DeleteFileRequest.ValidateAll() if the designated constraints
 // aren't met.
-type ListDataResponseMultiError []error
+type DeleteFileRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m ListDataResponseMultiError) Error() string {
+func (m DeleteFileRequestMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1434,11 +1438,11 @@ func (m ListDataResponseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m ListDataResponseMultiError) AllErrors() []error { return m }
+func (m DeleteFileRequestMultiError) AllErrors() []error { return m }
 
-// ListDataResponseValidationError is the validation error returned by
-// ListDataResponse.Validate if the designated constraints aren't met.
-type ListDataResponseValidationError struct {
+// DeleteFileRequestValidationError is the validation error returned by
+// DeleteFileRequest.Validate if the designated constraints aren't met.
+type DeleteFileRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1446,22 +1450,24 @@ type ListDataResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e ListDataResponseValidationError) Field() string { return e.field }
+func (e DeleteFileRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e ListDataResponseValidationError) Reason() string { return e.reason }
+func (e DeleteFileRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e ListDataResponseValidationError) Cause() error { return e.cause }
+func (e DeleteFileRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e ListDataResponseValidationError) Key() bool { return e.key }
+func (e DeleteFileRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e ListDataResponseValidationError) ErrorName() string { return "ListDataResponseValidationError" }
+func (e DeleteFileRequestValidationError) ErrorName() string {
+	return "DeleteFileRequestValidationError"
+}
 
 // Error satisfies the builtin error interface
-func (e ListDataResponseValidationError) Error() string {
+func (e DeleteFileRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1473,14 +1479,14 @@ func (e ListDataResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sListDataResponse.%s: %s%s",
+		"invalid %sDeleteFileRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = ListDataResponseValidationError{}
+var _ error = DeleteFileRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -1488,46 +1494,156 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = ListDataResponseValidationError{}
+} = DeleteFileRequestValidationError{}
 
-// Validate checks the field values on DeleteDataRequest with the rules defined
+// Validate checks the field values on CreateFolderRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *CreateFolderRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CreateFolderRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// CreateFolderRequestMultiError, or nil if none found.
+func (m *CreateFolderRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CreateFolderRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Path
+
+	if len(errors) > 0 {
+		return CreateFolderRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// CreateFolderRequestMultiError is an error wrapping multiple validation
+// errors returned by CreateFolderRequest.ValidateAll() if the designated
+// constraints aren't met.
+type CreateFolderRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CreateFolderRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CreateFolderRequestMultiError) AllErrors() []error { return m }
+
+// CreateFolderRequestValidationError is the validation error returned by
+// CreateFolderRequest.Validate if the designated constraints aren't met.
+type CreateFolderRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CreateFolderRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CreateFolderRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CreateFolderRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CreateFolderRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CreateFolderRequestValidationError) ErrorName() string {
+	return "CreateFolderRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CreateFolderRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCreateFolderRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CreateFolderRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CreateFolderRequestValidationError{}
+
+// Validate checks the field values on RenameFileRequest with the rules defined
 // in the proto definition for this message. If any rules are violated, the
 // first error encountered is returned, or nil if there are no violations.
-func (m *DeleteDataRequest) Validate() error {
+func (m *RenameFileRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on DeleteDataRequest with the rules
+// ValidateAll checks the field values on RenameFileRequest with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// DeleteDataRequestMultiError, or nil if none found.
-func (m *DeleteDataRequest) ValidateAll() error {
+// RenameFileRequestMultiError, or nil if none found.
+func (m *RenameFileRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *DeleteDataRequest) validate(all bool) error {
+func (m *RenameFileRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for Dataid
+	// no validation rules for Filename
+
+	// no validation rules for NewName
+
+	if m.ExpectedRevision != nil {
+		// no validation rules for ExpectedRevision
+	}
 
 	if len(errors) > 0 {
-		return DeleteDataRequestMultiError(errors)
+		return RenameFileRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// DeleteDataRequestMultiError is an error wrapping multiple validation errors
-// returned by DeleteDataRequest.ValidateAll() if the designated constraints
+// RenameFileRequestMultiError is an error wrapping multiple validation errors
+// returned by RenameFileRequest.ValidateAll() if the designated constraints
 // aren't met.
-type DeleteDataRequestMultiError []error
+type RenameFileRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m DeleteDataRequestMultiError) Error() string {
+func (m RenameFileRequestMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1536,11 +1652,11 @@ func (m DeleteDataRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m DeleteDataRequestMultiError) AllErrors() []error { return m }
+func (m RenameFileRequestMultiError) AllErrors() []error { return m }
 
-// DeleteDataRequestValidationError is the validation error returned by
-// DeleteDataRequest.Validate if the designated constraints aren't met.
-type DeleteDataRequestValidationError struct {
+// RenameFileRequestValidationError is the validation error returned by
+// RenameFileRequest.Validate if the designated constraints aren't met.
+type RenameFileRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1548,24 +1664,24 @@ type DeleteDataRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e DeleteDataRequestValidationError) Field() string { return e.field }
+func (e RenameFileRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e DeleteDataRequestValidationError) Reason() string { return e.reason }
+func (e RenameFileRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e DeleteDataRequestValidationError) Cause() error { return e.cause }
+func (e RenameFileRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e DeleteDataRequestValidationError) Key() bool { return e.key }
+func (e RenameFileRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e DeleteDataRequestValidationError) ErrorName() string {
-	return "DeleteDataRequestValidationError"
+func (e RenameFileRequestValidationError) ErrorName() string {
+	return "RenameFileRequestValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e DeleteDataRequestValidationError) Error() string {
+func (e RenameFileRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1577,14 +1693,14 @@ func (e DeleteDataRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sDeleteDataRequest.%s: %s%s",
+		"invalid %sRenameFileRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = DeleteDataRequestValidationError{}
+var _ error = RenameFileRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -1592,4 +1708,3468 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = DeleteDataRequestValidationError{}
+} = RenameFileRequestValidationError{}
+
+// Validate checks the field values on MoveFileRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *MoveFileRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on MoveFileRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// MoveFileRequestMultiError, or nil if none found.
+func (m *MoveFileRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *MoveFileRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Filename
+
+	// no validation rules for Folder
+
+	if m.ExpectedRevision != nil {
+		// no validation rules for ExpectedRevision
+	}
+
+	if len(errors) > 0 {
+		return MoveFileRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// MoveFileRequestMultiError is an error wrapping multiple validation errors
+// returned by MoveFileRequest.ValidateAll() if the designated constraints
+// aren't met.
+type MoveFileRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m MoveFileRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m MoveFileRequestMultiError) AllErrors() []error { return m }
+
+// MoveFileRequestValidationError is the validation error returned by
+// MoveFileRequest.Validate if the designated constraints aren't met.
+type MoveFileRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e MoveFileRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e MoveFileRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e MoveFileRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e MoveFileRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e MoveFileRequestValidationError) ErrorName() string { return "MoveFileRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e MoveFileRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sMoveFileRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = MoveFileRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = MoveFileRequestValidationError{}
+
+// Validate checks the field values on UpdateFileMetaRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *UpdateFileMetaRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UpdateFileMetaRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UpdateFileMetaRequestMultiError, or nil if none found.
+func (m *UpdateFileMetaRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UpdateFileMetaRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Filename
+
+	// no validation rules for Meta
+
+	// no validation rules for Tags
+
+	// no validation rules for Description
+
+	if m.ExpectedRevision != nil {
+		// no validation rules for ExpectedRevision
+	}
+
+	if len(errors) > 0 {
+		return UpdateFileMetaRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// UpdateFileMetaRequestMultiError is an error wrapping multiple validation
+// errors returned by UpdateFileMetaRequest.ValidateAll() if the designated
+// constraints aren't met.
+type UpdateFileMetaRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UpdateFileMetaRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UpdateFileMetaRequestMultiError) AllErrors() []error { return m }
+
+// UpdateFileMetaRequestValidationError is the validation error returned by
+// UpdateFileMetaRequest.Validate if the designated constraints aren't met.
+type UpdateFileMetaRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UpdateFileMetaRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UpdateFileMetaRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UpdateFileMetaRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UpdateFileMetaRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UpdateFileMetaRequestValidationError) ErrorName() string {
+	return "UpdateFileMetaRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e UpdateFileMetaRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUpdateFileMetaRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UpdateFileMetaRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UpdateFileMetaRequestValidationError{}
+
+// Validate checks the field values on ListFileVersionsRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListFileVersionsRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListFileVersionsRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListFileVersionsRequestMultiError, or nil if none found.
+func (m *ListFileVersionsRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListFileVersionsRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Filename
+
+	if len(errors) > 0 {
+		return ListFileVersionsRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListFileVersionsRequestMultiError is an error wrapping multiple validation
+// errors returned by ListFileVersionsRequest.ValidateAll() if the designated
+// constraints aren't met.
+type ListFileVersionsRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListFileVersionsRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListFileVersionsRequestMultiError) AllErrors() []error { return m }
+
+// ListFileVersionsRequestValidationError is the validation error returned by
+// ListFileVersionsRequest.Validate if the designated constraints aren't met.
+type ListFileVersionsRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListFileVersionsRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListFileVersionsRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListFileVersionsRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListFileVersionsRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListFileVersionsRequestValidationError) ErrorName() string {
+	return "ListFileVersionsRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListFileVersionsRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListFileVersionsRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListFileVersionsRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListFileVersionsRequestValidationError{}
+
+// Validate checks the field values on FileVersion with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *FileVersion) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on FileVersion with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in FileVersionMultiError, or
+// nil if none found.
+func (m *FileVersion) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *FileVersion) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for VersionId
+
+	// no validation rules for Key
+
+	// no validation rules for Size
+
+	// no validation rules for ModifiedAt
+
+	// no validation rules for Latest
+
+	if len(errors) > 0 {
+		return FileVersionMultiError(errors)
+	}
+
+	return nil
+}
+
+// FileVersionMultiError is an error wrapping multiple validation errors
+// returned by FileVersion.ValidateAll() if the designated constraints aren't met.
+type FileVersionMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m FileVersionMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m FileVersionMultiError) AllErrors() []error { return m }
+
+// FileVersionValidationError is the validation error returned by
+// FileVersion.Validate if the designated constraints aren't met.
+type FileVersionValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e FileVersionValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e FileVersionValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e FileVersionValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e FileVersionValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e FileVersionValidationError) ErrorName() string { return "FileVersionValidationError" }
+
+// Error satisfies the builtin error interface
+func (e FileVersionValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sFileVersion.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = FileVersionValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = FileVersionValidationError{}
+
+// Validate checks the field values on ListFileVersionsResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListFileVersionsResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListFileVersionsResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListFileVersionsResponseMultiError, or nil if none found.
+func (m *ListFileVersionsResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListFileVersionsResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetVersions() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListFileVersionsResponseValidationError{
+						field:  fmt.Sprintf("Versions[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListFileVersionsResponseValidationError{
+						field:  fmt.Sprintf("Versions[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListFileVersionsResponseValidationError{
+					field:  fmt.Sprintf("Versions[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return ListFileVersionsResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListFileVersionsResponseMultiError is an error wrapping multiple validation
+// errors returned by ListFileVersionsResponse.ValidateAll() if the designated
+// constraints aren't met.
+type ListFileVersionsResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListFileVersionsResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListFileVersionsResponseMultiError) AllErrors() []error { return m }
+
+// ListFileVersionsResponseValidationError is the validation error returned by
+// ListFileVersionsResponse.Validate if the designated constraints aren't met.
+type ListFileVersionsResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListFileVersionsResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListFileVersionsResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListFileVersionsResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListFileVersionsResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListFileVersionsResponseValidationError) ErrorName() string {
+	return "ListFileVersionsResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListFileVersionsResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListFileVersionsResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListFileVersionsResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListFileVersionsResponseValidationError{}
+
+// Validate checks the field values on RestoreFileVersionRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *RestoreFileVersionRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RestoreFileVersionRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// RestoreFileVersionRequestMultiError, or nil if none found.
+func (m *RestoreFileVersionRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RestoreFileVersionRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Filename
+
+	// no validation rules for VersionId
+
+	if m.ExpectedRevision != nil {
+		// no validation rules for ExpectedRevision
+	}
+
+	if len(errors) > 0 {
+		return RestoreFileVersionRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// RestoreFileVersionRequestMultiError is an error wrapping multiple validation
+// errors returned by RestoreFileVersionRequest.ValidateAll() if the
+// designated constraints aren't met.
+type RestoreFileVersionRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RestoreFileVersionRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RestoreFileVersionRequestMultiError) AllErrors() []error { return m }
+
+// RestoreFileVersionRequestValidationError is the validation error returned by
+// RestoreFileVersionRequest.Validate if the designated constraints aren't met.
+type RestoreFileVersionRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RestoreFileVersionRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RestoreFileVersionRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RestoreFileVersionRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RestoreFileVersionRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RestoreFileVersionRequestValidationError) ErrorName() string {
+	return "RestoreFileVersionRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RestoreFileVersionRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRestoreFileVersionRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RestoreFileVersionRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RestoreFileVersionRequestValidationError{}
+
+// Validate checks the field values on UploadStatus with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *UploadStatus) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UploadStatus with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in UploadStatusMultiError, or
+// nil if none found.
+func (m *UploadStatus) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UploadStatus) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Success
+
+	// no validation rules for Message
+
+	if len(errors) > 0 {
+		return UploadStatusMultiError(errors)
+	}
+
+	return nil
+}
+
+// UploadStatusMultiError is an error wrapping multiple validation errors
+// returned by UploadStatus.ValidateAll() if the designated constraints aren't met.
+type UploadStatusMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UploadStatusMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UploadStatusMultiError) AllErrors() []error { return m }
+
+// UploadStatusValidationError is the validation error returned by
+// UploadStatus.Validate if the designated constraints aren't met.
+type UploadStatusValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UploadStatusValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UploadStatusValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UploadStatusValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UploadStatusValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UploadStatusValidationError) ErrorName() string { return "UploadStatusValidationError" }
+
+// Error satisfies the builtin error interface
+func (e UploadStatusValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUploadStatus.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UploadStatusValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UploadStatusValidationError{}
+
+// Validate checks the field values on SaveDataRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *SaveDataRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SaveDataRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// SaveDataRequestMultiError, or nil if none found.
+func (m *SaveDataRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SaveDataRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetData()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, SaveDataRequestValidationError{
+					field:  "Data",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, SaveDataRequestValidationError{
+					field:  "Data",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetData()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return SaveDataRequestValidationError{
+				field:  "Data",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return SaveDataRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// SaveDataRequestMultiError is an error wrapping multiple validation errors
+// returned by SaveDataRequest.ValidateAll() if the designated constraints
+// aren't met.
+type SaveDataRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SaveDataRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SaveDataRequestMultiError) AllErrors() []error { return m }
+
+// SaveDataRequestValidationError is the validation error returned by
+// SaveDataRequest.Validate if the designated constraints aren't met.
+type SaveDataRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SaveDataRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SaveDataRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SaveDataRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SaveDataRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SaveDataRequestValidationError) ErrorName() string { return "SaveDataRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e SaveDataRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSaveDataRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SaveDataRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SaveDataRequestValidationError{}
+
+// Validate checks the field values on GetDataRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *GetDataRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetDataRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in GetDataRequestMultiError,
+// or nil if none found.
+func (m *GetDataRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetDataRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Dataid
+
+	if len(errors) > 0 {
+		return GetDataRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetDataRequestMultiError is an error wrapping multiple validation errors
+// returned by GetDataRequest.ValidateAll() if the designated constraints
+// aren't met.
+type GetDataRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetDataRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetDataRequestMultiError) AllErrors() []error { return m }
+
+// GetDataRequestValidationError is the validation error returned by
+// GetDataRequest.Validate if the designated constraints aren't met.
+type GetDataRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetDataRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetDataRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetDataRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetDataRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetDataRequestValidationError) ErrorName() string { return "GetDataRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e GetDataRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetDataRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetDataRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetDataRequestValidationError{}
+
+// Validate checks the field values on GetDataResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *GetDataResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetDataResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetDataResponseMultiError, or nil if none found.
+func (m *GetDataResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetDataResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetData()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, GetDataResponseValidationError{
+					field:  "Data",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, GetDataResponseValidationError{
+					field:  "Data",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetData()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return GetDataResponseValidationError{
+				field:  "Data",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return GetDataResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetDataResponseMultiError is an error wrapping multiple validation errors
+// returned by GetDataResponse.ValidateAll() if the designated constraints
+// aren't met.
+type GetDataResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetDataResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetDataResponseMultiError) AllErrors() []error { return m }
+
+// GetDataResponseValidationError is the validation error returned by
+// GetDataResponse.Validate if the designated constraints aren't met.
+type GetDataResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetDataResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetDataResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetDataResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetDataResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetDataResponseValidationError) ErrorName() string { return "GetDataResponseValidationError" }
+
+// Error satisfies the builtin error interface
+func (e GetDataResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetDataResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetDataResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetDataResponseValidationError{}
+
+// Validate checks the field values on UpdateDataRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *UpdateDataRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UpdateDataRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UpdateDataRequestMultiError, or nil if none found.
+func (m *UpdateDataRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UpdateDataRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetData()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, UpdateDataRequestValidationError{
+					field:  "Data",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, UpdateDataRequestValidationError{
+					field:  "Data",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetData()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return UpdateDataRequestValidationError{
+				field:  "Data",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for ExpectedRevision
+
+	if len(errors) > 0 {
+		return UpdateDataRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// UpdateDataRequestMultiError is an error wrapping multiple validation errors
+// returned by UpdateDataRequest.ValidateAll() if the designated constraints
+// aren't met.
+type UpdateDataRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UpdateDataRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UpdateDataRequestMultiError) AllErrors() []error { return m }
+
+// UpdateDataRequestValidationError is the validation error returned by
+// UpdateDataRequest.Validate if the designated constraints aren't met.
+type UpdateDataRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UpdateDataRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UpdateDataRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UpdateDataRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UpdateDataRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UpdateDataRequestValidationError) ErrorName() string {
+	return "UpdateDataRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e UpdateDataRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUpdateDataRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UpdateDataRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UpdateDataRequestValidationError{}
+
+// Validate checks the field values on ListDataRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ListDataRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListDataRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListDataRequestMultiError, or nil if none found.
+func (m *ListDataRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListDataRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Type
+
+	// no validation rules for Types
+
+	// no validation rules for Search
+
+	// no validation rules for Tags
+
+	// no validation rules for Sort
+
+	// no validation rules for Desc
+
+	// no validation rules for PageSize
+
+	// no validation rules for PageToken
+
+	if len(errors) > 0 {
+		return ListDataRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListDataRequestMultiError is an error wrapping multiple validation errors
+// returned by ListDataRequest.ValidateAll() if the designated constraints
+// aren't met.
+type ListDataRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListDataRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListDataRequestMultiError) AllErrors() []error { return m }
+
+// ListDataRequestValidationError is the validation error returned by
+// ListDataRequest.Validate if the designated constraints aren't met.
+type ListDataRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListDataRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListDataRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListDataRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListDataRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListDataRequestValidationError) ErrorName() string { return "ListDataRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ListDataRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListDataRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListDataRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListDataRequestValidationError{}
+
+// Validate checks the field values on ListDataResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ListDataResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListDataResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListDataResponseMultiError, or nil if none found.
+func (m *ListDataResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListDataResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetData() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListDataResponseValidationError{
+						field:  fmt.Sprintf("Data[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListDataResponseValidationError{
+						field:  fmt.Sprintf("Data[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListDataResponseValidationError{
+					field:  fmt.Sprintf("Data[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for NextPageToken
+
+	if len(errors) > 0 {
+		return ListDataResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListDataResponseMultiError is an error wrapping multiple validation errors
+// returned by ListDataResponse.ValidateAll() if the designated constraints
+// aren't met.
+type ListDataResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListDataResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListDataResponseMultiError) AllErrors() []error { return m }
+
+// ListDataResponseValidationError is the validation error returned by
+// ListDataResponse.Validate if the designated constraints aren't met.
+type ListDataResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListDataResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListDataResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListDataResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListDataResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListDataResponseValidationError) ErrorName() string { return "ListDataResponseValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ListDataResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListDataResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListDataResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListDataResponseValidationError{}
+
+// Validate checks the field values on DeleteDataRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *DeleteDataRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DeleteDataRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// DeleteDataRequestMultiError, or nil if none found.
+func (m *DeleteDataRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DeleteDataRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Dataid
+
+	// no validation rules for ExpectedRevision
+
+	if len(errors) > 0 {
+		return DeleteDataRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// DeleteDataRequestMultiError is an error wrapping multiple validation errors
+// returned by DeleteDataRequest.ValidateAll() if the designated constraints
+// aren't met.
+type DeleteDataRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DeleteDataRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DeleteDataRequestMultiError) AllErrors() []error { return m }
+
+// DeleteDataRequestValidationError is the validation error returned by
+// DeleteDataRequest.Validate if the designated constraints aren't met.
+type DeleteDataRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DeleteDataRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DeleteDataRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DeleteDataRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DeleteDataRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DeleteDataRequestValidationError) ErrorName() string {
+	return "DeleteDataRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e DeleteDataRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDeleteDataRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DeleteDataRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DeleteDataRequestValidationError{}
+
+// Validate checks the field values on ListDataHistoryRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListDataHistoryRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListDataHistoryRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListDataHistoryRequestMultiError, or nil if none found.
+func (m *ListDataHistoryRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListDataHistoryRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Dataid
+
+	if len(errors) > 0 {
+		return ListDataHistoryRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListDataHistoryRequestMultiError is an error wrapping multiple validation
+// errors returned by ListDataHistoryRequest.ValidateAll() if the designated
+// constraints aren't met.
+type ListDataHistoryRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListDataHistoryRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListDataHistoryRequestMultiError) AllErrors() []error { return m }
+
+// ListDataHistoryRequestValidationError is the validation error returned by
+// ListDataHistoryRequest.Validate if the designated constraints aren't met.
+type ListDataHistoryRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListDataHistoryRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListDataHistoryRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListDataHistoryRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListDataHistoryRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListDataHistoryRequestValidationError) ErrorName() string {
+	return "ListDataHistoryRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListDataHistoryRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListDataHistoryRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListDataHistoryRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListDataHistoryRequestValidationError{}
+
+// Validate checks the field values on DataRevision with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *DataRevision) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DataRevision with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in DataRevisionMultiError, or
+// nil if none found.
+func (m *DataRevision) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DataRevision) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetData()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, DataRevisionValidationError{
+					field:  "Data",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, DataRevisionValidationError{
+					field:  "Data",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetData()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return DataRevisionValidationError{
+				field:  "Data",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for ChangedAt
+
+	if len(errors) > 0 {
+		return DataRevisionMultiError(errors)
+	}
+
+	return nil
+}
+
+// DataRevisionMultiError is an error wrapping multiple validation errors
+// returned by DataRevision.ValidateAll() if the designated constraints aren't met.
+type DataRevisionMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DataRevisionMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DataRevisionMultiError) AllErrors() []error { return m }
+
+// DataRevisionValidationError is the validation error returned by
+// DataRevision.Validate if the designated constraints aren't met.
+type DataRevisionValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DataRevisionValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DataRevisionValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DataRevisionValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DataRevisionValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DataRevisionValidationError) ErrorName() string { return "DataRevisionValidationError" }
+
+// Error satisfies the builtin error interface
+func (e DataRevisionValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDataRevision.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DataRevisionValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DataRevisionValidationError{}
+
+// Validate checks the field values on ListDataHistoryResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListDataHistoryResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListDataHistoryResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListDataHistoryResponseMultiError, or nil if none found.
+func (m *ListDataHistoryResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListDataHistoryResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetRevisions() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListDataHistoryResponseValidationError{
+						field:  fmt.Sprintf("Revisions[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListDataHistoryResponseValidationError{
+						field:  fmt.Sprintf("Revisions[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListDataHistoryResponseValidationError{
+					field:  fmt.Sprintf("Revisions[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return ListDataHistoryResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListDataHistoryResponseMultiError is an error wrapping multiple validation
+// errors returned by ListDataHistoryResponse.ValidateAll() if the designated
+// constraints aren't met.
+type ListDataHistoryResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListDataHistoryResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListDataHistoryResponseMultiError) AllErrors() []error { return m }
+
+// ListDataHistoryResponseValidationError is the validation error returned by
+// ListDataHistoryResponse.Validate if the designated constraints aren't met.
+type ListDataHistoryResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListDataHistoryResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListDataHistoryResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListDataHistoryResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListDataHistoryResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListDataHistoryResponseValidationError) ErrorName() string {
+	return "ListDataHistoryResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListDataHistoryResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListDataHistoryResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListDataHistoryResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListDataHistoryResponseValidationError{}
+
+// Validate checks the field values on RestoreDataRevisionRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *RestoreDataRevisionRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RestoreDataRevisionRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// RestoreDataRevisionRequestMultiError, or nil if none found.
+func (m *RestoreDataRevisionRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RestoreDataRevisionRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Dataid
+
+	// no validation rules for Revision
+
+	// no validation rules for ExpectedRevision
+
+	if len(errors) > 0 {
+		return RestoreDataRevisionRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// RestoreDataRevisionRequestMultiError is an error wrapping multiple
+// validation errors returned by RestoreDataRevisionRequest.ValidateAll() if
+// the designated constraints aren't met.
+type RestoreDataRevisionRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RestoreDataRevisionRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RestoreDataRevisionRequestMultiError) AllErrors() []error { return m }
+
+// RestoreDataRevisionRequestValidationError is the validation error returned
+// by RestoreDataRevisionRequest.Validate if the designated constraints aren't
+// met.
+type RestoreDataRevisionRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RestoreDataRevisionRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RestoreDataRevisionRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RestoreDataRevisionRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RestoreDataRevisionRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RestoreDataRevisionRequestValidationError) ErrorName() string {
+	return "RestoreDataRevisionRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RestoreDataRevisionRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRestoreDataRevisionRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RestoreDataRevisionRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RestoreDataRevisionRequestValidationError{}
+
+// Validate checks the field values on TrashItem with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *TrashItem) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on TrashItem with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in TrashItemMultiError, or nil
+// if none found.
+func (m *TrashItem) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *TrashItem) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetData()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, TrashItemValidationError{
+					field:  "Data",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, TrashItemValidationError{
+					field:  "Data",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetData()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return TrashItemValidationError{
+				field:  "Data",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetFile()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, TrashItemValidationError{
+					field:  "File",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, TrashItemValidationError{
+					field:  "File",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetFile()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return TrashItemValidationError{
+				field:  "File",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for DeletedAt
+
+	// no validation rules for PurgeAt
+
+	if len(errors) > 0 {
+		return TrashItemMultiError(errors)
+	}
+
+	return nil
+}
+
+// TrashItemMultiError is an error wrapping multiple validation errors returned
+// by TrashItem.ValidateAll() if the designated constraints aren't met.
+type TrashItemMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m TrashItemMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m TrashItemMultiError) AllErrors() []error { return m }
+
+// TrashItemValidationError is the validation error returned by
+// TrashItem.Validate if the designated constraints aren't met.
+type TrashItemValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e TrashItemValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e TrashItemValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e TrashItemValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e TrashItemValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e TrashItemValidationError) ErrorName() string { return "TrashItemValidationError" }
+
+// Error satisfies the builtin error interface
+func (e TrashItemValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sTrashItem.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = TrashItemValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = TrashItemValidationError{}
+
+// Validate checks the field values on ListTrashRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ListTrashRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListTrashRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListTrashRequestMultiError, or nil if none found.
+func (m *ListTrashRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListTrashRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return ListTrashRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListTrashRequestMultiError is an error wrapping multiple validation errors
+// returned by ListTrashRequest.ValidateAll() if the designated constraints
+// aren't met.
+type ListTrashRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListTrashRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListTrashRequestMultiError) AllErrors() []error { return m }
+
+// ListTrashRequestValidationError is the validation error returned by
+// ListTrashRequest.Validate if the designated constraints aren't met.
+type ListTrashRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListTrashRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListTrashRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListTrashRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListTrashRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListTrashRequestValidationError) ErrorName() string { return "ListTrashRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ListTrashRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListTrashRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListTrashRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListTrashRequestValidationError{}
+
+// Validate checks the field values on ListTrashResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ListTrashResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListTrashResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListTrashResponseMultiError, or nil if none found.
+func (m *ListTrashResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListTrashResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetItems() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListTrashResponseValidationError{
+						field:  fmt.Sprintf("Items[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListTrashResponseValidationError{
+						field:  fmt.Sprintf("Items[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListTrashResponseValidationError{
+					field:  fmt.Sprintf("Items[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return ListTrashResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListTrashResponseMultiError is an error wrapping multiple validation errors
+// returned by ListTrashResponse.ValidateAll() if the designated constraints
+// aren't met.
+type ListTrashResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListTrashResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListTrashResponseMultiError) AllErrors() []error { return m }
+
+// ListTrashResponseValidationError is the validation error returned by
+// ListTrashResponse.Validate if the designated constraints aren't met.
+type ListTrashResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListTrashResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListTrashResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListTrashResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListTrashResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListTrashResponseValidationError) ErrorName() string {
+	return "ListTrashResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListTrashResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListTrashResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListTrashResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListTrashResponseValidationError{}
+
+// Validate checks the field values on RestoreFromTrashRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *RestoreFromTrashRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RestoreFromTrashRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// RestoreFromTrashRequestMultiError, or nil if none found.
+func (m *RestoreFromTrashRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RestoreFromTrashRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Dataid
+
+	// no validation rules for Filename
+
+	if len(errors) > 0 {
+		return RestoreFromTrashRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// RestoreFromTrashRequestMultiError is an error wrapping multiple validation
+// errors returned by RestoreFromTrashRequest.ValidateAll() if the designated
+// constraints aren't met.
+type RestoreFromTrashRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RestoreFromTrashRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RestoreFromTrashRequestMultiError) AllErrors() []error { return m }
+
+// RestoreFromTrashRequestValidationError is the validation error returned by
+// RestoreFromTrashRequest.Validate if the designated constraints aren't met.
+type RestoreFromTrashRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RestoreFromTrashRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RestoreFromTrashRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RestoreFromTrashRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RestoreFromTrashRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RestoreFromTrashRequestValidationError) ErrorName() string {
+	return "RestoreFromTrashRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RestoreFromTrashRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRestoreFromTrashRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RestoreFromTrashRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RestoreFromTrashRequestValidationError{}
+
+// Validate checks the field values on SyncRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *SyncRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SyncRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in SyncRequestMultiError, or
+// nil if none found.
+func (m *SyncRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SyncRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for SinceRevision
+
+	// no validation rules for PageSize
+
+	if len(errors) > 0 {
+		return SyncRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// SyncRequestMultiError is an error wrapping multiple validation errors
+// returned by SyncRequest.ValidateAll() if the designated constraints aren't met.
+type SyncRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SyncRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SyncRequestMultiError) AllErrors() []error { return m }
+
+// SyncRequestValidationError is the validation error returned by
+// SyncRequest.Validate if the designated constraints aren't met.
+type SyncRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SyncRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SyncRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SyncRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SyncRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SyncRequestValidationError) ErrorName() string { return "SyncRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e SyncRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSyncRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SyncRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SyncRequestValidationError{}
+
+// Validate checks the field values on SyncResponse with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *SyncResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SyncResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in SyncResponseMultiError, or
+// nil if none found.
+func (m *SyncResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SyncResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Revision
+
+	// no validation rules for HasMore
+
+	for idx, item := range m.GetData() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, SyncResponseValidationError{
+						field:  fmt.Sprintf("Data[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, SyncResponseValidationError{
+						field:  fmt.Sprintf("Data[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return SyncResponseValidationError{
+					field:  fmt.Sprintf("Data[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for DeletedData
+
+	for idx, item := range m.GetFiles() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, SyncResponseValidationError{
+						field:  fmt.Sprintf("Files[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, SyncResponseValidationError{
+						field:  fmt.Sprintf("Files[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return SyncResponseValidationError{
+					field:  fmt.Sprintf("Files[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for DeletedFiles
+
+	if len(errors) > 0 {
+		return SyncResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// SyncResponseMultiError is an error wrapping multiple validation errors
+// returned by SyncResponse.ValidateAll() if the designated constraints aren't met.
+type SyncResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SyncResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SyncResponseMultiError) AllErrors() []error { return m }
+
+// SyncResponseValidationError is the validation error returned by
+// SyncResponse.Validate if the designated constraints aren't met.
+type SyncResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SyncResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SyncResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SyncResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SyncResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SyncResponseValidationError) ErrorName() string { return "SyncResponseValidationError" }
+
+// Error satisfies the builtin error interface
+func (e SyncResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSyncResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SyncResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SyncResponseValidationError{}
+
+// Validate checks the field values on WatchChangesRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *WatchChangesRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on WatchChangesRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// WatchChangesRequestMultiError, or nil if none found.
+func (m *WatchChangesRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *WatchChangesRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return WatchChangesRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// WatchChangesRequestMultiError is an error wrapping multiple validation
+// errors returned by WatchChangesRequest.ValidateAll() if the designated
+// constraints aren't met.
+type WatchChangesRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m WatchChangesRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m WatchChangesRequestMultiError) AllErrors() []error { return m }
+
+// WatchChangesRequestValidationError is the validation error returned by
+// WatchChangesRequest.Validate if the designated constraints aren't met.
+type WatchChangesRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e WatchChangesRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e WatchChangesRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e WatchChangesRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e WatchChangesRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e WatchChangesRequestValidationError) ErrorName() string {
+	return "WatchChangesRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e WatchChangesRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sWatchChangesRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = WatchChangesRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = WatchChangesRequestValidationError{}
+
+// Validate checks the field values on ChangeEvent with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *ChangeEvent) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ChangeEvent with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in ChangeEventMultiError, or
+// nil if none found.
+func (m *ChangeEvent) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ChangeEvent) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Op
+
+	// no validation rules for DataId
+
+	// no validation rules for FileName
+
+	if len(errors) > 0 {
+		return ChangeEventMultiError(errors)
+	}
+
+	return nil
+}
+
+// ChangeEventMultiError is an error wrapping multiple validation errors
+// returned by ChangeEvent.ValidateAll() if the designated constraints aren't met.
+type ChangeEventMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ChangeEventMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ChangeEventMultiError) AllErrors() []error { return m }
+
+// ChangeEventValidationError is the validation error returned by
+// ChangeEvent.Validate if the designated constraints aren't met.
+type ChangeEventValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ChangeEventValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ChangeEventValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ChangeEventValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ChangeEventValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ChangeEventValidationError) ErrorName() string { return "ChangeEventValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ChangeEventValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sChangeEvent.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ChangeEventValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ChangeEventValidationError{}
+
+// Validate checks the field values on Usage with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Usage) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Usage with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in UsageMultiError, or nil if none found.
+func (m *Usage) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Usage) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Bytes
+
+	// no validation rules for Files
+
+	// no validation rules for Records
+
+	if len(errors) > 0 {
+		return UsageMultiError(errors)
+	}
+
+	return nil
+}
+
+// UsageMultiError is an error wrapping multiple validation errors returned by
+// Usage.ValidateAll() if the designated constraints aren't met.
+type UsageMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UsageMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UsageMultiError) AllErrors() []error { return m }
+
+// UsageValidationError is the validation error returned by Usage.Validate if
+// the designated constraints aren't met.
+type UsageValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UsageValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UsageValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UsageValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UsageValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UsageValidationError) ErrorName() string { return "UsageValidationError" }
+
+// Error satisfies the builtin error interface
+func (e UsageValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUsage.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UsageValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UsageValidationError{}
+
+// Validate checks the field values on GetUsageRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *GetUsageRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetUsageRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetUsageRequestMultiError, or nil if none found.
+func (m *GetUsageRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetUsageRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return GetUsageRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetUsageRequestMultiError is an error wrapping multiple validation errors
+// returned by GetUsageRequest.ValidateAll() if the designated constraints
+// aren't met.
+type GetUsageRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetUsageRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetUsageRequestMultiError) AllErrors() []error { return m }
+
+// GetUsageRequestValidationError is the validation error returned by
+// GetUsageRequest.Validate if the designated constraints aren't met.
+type GetUsageRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetUsageRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetUsageRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetUsageRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetUsageRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetUsageRequestValidationError) ErrorName() string { return "GetUsageRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e GetUsageRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetUsageRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetUsageRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetUsageRequestValidationError{}
+
+// Validate checks the field values on GetUsageResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *GetUsageResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetUsageResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetUsageResponseMultiError, or nil if none found.
+func (m *GetUsageResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetUsageResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetUsed()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, GetUsageResponseValidationError{
+					field:  "Used",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, GetUsageResponseValidationError{
+					field:  "Used",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetUsed()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return GetUsageResponseValidationError{
+				field:  "Used",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetQuota()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, GetUsageResponseValidationError{
+					field:  "Quota",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, GetUsageResponseValidationError{
+					field:  "Quota",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetQuota()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return GetUsageResponseValidationError{
+				field:  "Quota",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return GetUsageResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetUsageResponseMultiError is an error wrapping multiple validation errors
+// returned by GetUsageResponse.ValidateAll() if the designated constraints
+// aren't met.
+type GetUsageResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetUsageResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetUsageResponseMultiError) AllErrors() []error { return m }
+
+// GetUsageResponseValidationError is the validation error returned by
+// GetUsageResponse.Validate if the designated constraints aren't met.
+type GetUsageResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetUsageResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetUsageResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetUsageResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetUsageResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetUsageResponseValidationError) ErrorName() string { return "GetUsageResponseValidationError" }
+
+// Error satisfies the builtin error interface
+func (e GetUsageResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetUsageResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetUsageResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetUsageResponseValidationError{}
@@ -19,13 +19,32 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	DataKeeperService_SaveData_FullMethodName    = "/proto.api.service.v1.DataKeeperService/SaveData"
-	DataKeeperService_GetDataList_FullMethodName = "/proto.api.service.v1.DataKeeperService/GetDataList"
-	DataKeeperService_DeleteData_FullMethodName  = "/proto.api.service.v1.DataKeeperService/DeleteData"
-	DataKeeperService_GetFileList_FullMethodName = "/proto.api.service.v1.DataKeeperService/GetFileList"
-	DataKeeperService_UploadFile_FullMethodName  = "/proto.api.service.v1.DataKeeperService/UploadFile"
-	DataKeeperService_GetFile_FullMethodName     = "/proto.api.service.v1.DataKeeperService/GetFile"
-	DataKeeperService_DeleteFile_FullMethodName  = "/proto.api.service.v1.DataKeeperService/DeleteFile"
+	DataKeeperService_SaveData_FullMethodName            = "/proto.api.service.v1.DataKeeperService/SaveData"
+	DataKeeperService_GetDataList_FullMethodName         = "/proto.api.service.v1.DataKeeperService/GetDataList"
+	DataKeeperService_GetData_FullMethodName             = "/proto.api.service.v1.DataKeeperService/GetData"
+	DataKeeperService_UpdateData_FullMethodName          = "/proto.api.service.v1.DataKeeperService/UpdateData"
+	DataKeeperService_DeleteData_FullMethodName          = "/proto.api.service.v1.DataKeeperService/DeleteData"
+	DataKeeperService_ListDataHistory_FullMethodName     = "/proto.api.service.v1.DataKeeperService/ListDataHistory"
+	DataKeeperService_RestoreDataRevision_FullMethodName = "/proto.api.service.v1.DataKeeperService/RestoreDataRevision"
+	DataKeeperService_GetFileList_FullMethodName         = "/proto.api.service.v1.DataKeeperService/GetFileList"
+	DataKeeperService_UploadFile_FullMethodName          = "/proto.api.service.v1.DataKeeperService/UploadFile"
+	DataKeeperService_InitUpload_FullMethodName          = "/proto.api.service.v1.DataKeeperService/InitUpload"
+	DataKeeperService_UploadChunk_FullMethodName         = "/proto.api.service.v1.DataKeeperService/UploadChunk"
+	DataKeeperService_GetUploadOffset_FullMethodName     = "/proto.api.service.v1.DataKeeperService/GetUploadOffset"
+	DataKeeperService_FinalizeUpload_FullMethodName      = "/proto.api.service.v1.DataKeeperService/FinalizeUpload"
+	DataKeeperService_GetFile_FullMethodName             = "/proto.api.service.v1.DataKeeperService/GetFile"
+	DataKeeperService_DeleteFile_FullMethodName          = "/proto.api.service.v1.DataKeeperService/DeleteFile"
+	DataKeeperService_UpdateFileMeta_FullMethodName      = "/proto.api.service.v1.DataKeeperService/UpdateFileMeta"
+	DataKeeperService_CreateFolder_FullMethodName        = "/proto.api.service.v1.DataKeeperService/CreateFolder"
+	DataKeeperService_RenameFile_FullMethodName          = "/proto.api.service.v1.DataKeeperService/RenameFile"
+	DataKeeperService_MoveFile_FullMethodName            = "/proto.api.service.v1.DataKeeperService/MoveFile"
+	DataKeeperService_ListFileVersions_FullMethodName    = "/proto.api.service.v1.DataKeeperService/ListFileVersions"
+	DataKeeperService_RestoreFileVersion_FullMethodName  = "/proto.api.service.v1.DataKeeperService/RestoreFileVersion"
+	DataKeeperService_ListTrash_FullMethodName           = "/proto.api.service.v1.DataKeeperService/ListTrash"
+	DataKeeperService_RestoreFromTrash_FullMethodName    = "/proto.api.service.v1.DataKeeperService/RestoreFromTrash"
+	DataKeeperService_Sync_FullMethodName                = "/proto.api.service.v1.DataKeeperService/Sync"
+	DataKeeperService_WatchChanges_FullMethodName        = "/proto.api.service.v1.DataKeeperService/WatchChanges"
+	DataKeeperService_GetUsage_FullMethodName            = "/proto.api.service.v1.DataKeeperService/GetUsage"
 )
 
 // DataKeeperServiceClient is the client API for DataKeeperService service.
@@ -37,12 +56,38 @@ type DataKeeperServiceClient interface {
 	// Хранение новых данных на сервере (кроме файлов)
 	SaveData(ctx context.Context, in *SaveDataRequest, opts ...grpc.CallOption) (*UploadStatus, error)
 	GetDataList(ctx context.Context, in *ListDataRequest, opts ...grpc.CallOption) (*ListDataResponse, error)
+	GetData(ctx context.Context, in *GetDataRequest, opts ...grpc.CallOption) (*GetDataResponse, error)
+	UpdateData(ctx context.Context, in *UpdateDataRequest, opts ...grpc.CallOption) (*UploadStatus, error)
 	DeleteData(ctx context.Context, in *DeleteDataRequest, opts ...grpc.CallOption) (*UploadStatus, error)
+	// Прежние версии записи и восстановление одной из них
+	ListDataHistory(ctx context.Context, in *ListDataHistoryRequest, opts ...grpc.CallOption) (*ListDataHistoryResponse, error)
+	RestoreDataRevision(ctx context.Context, in *RestoreDataRevisionRequest, opts ...grpc.CallOption) (*UploadStatus, error)
 	// Отправка файлов на сервер
 	GetFileList(ctx context.Context, in *ListFileRequest, opts ...grpc.CallOption) (*ListFileResponse, error)
 	UploadFile(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[FileChunk, UploadStatus], error)
+	// Загрузка с продолжением: InitUpload, части UploadChunk, FinalizeUpload с проверкой SHA-256
+	InitUpload(ctx context.Context, in *InitUploadRequest, opts ...grpc.CallOption) (*InitUploadResponse, error)
+	UploadChunk(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadChunkRequest, UploadOffset], error)
+	GetUploadOffset(ctx context.Context, in *GetUploadOffsetRequest, opts ...grpc.CallOption) (*UploadOffset, error)
+	FinalizeUpload(ctx context.Context, in *FinalizeUploadRequest, opts ...grpc.CallOption) (*UploadStatus, error)
 	GetFile(ctx context.Context, in *GetFileRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[FileChunk], error)
 	DeleteFile(ctx context.Context, in *DeleteFileRequest, opts ...grpc.CallOption) (*UploadStatus, error)
+	UpdateFileMeta(ctx context.Context, in *UpdateFileMetaRequest, opts ...grpc.CallOption) (*UploadStatus, error)
+	CreateFolder(ctx context.Context, in *CreateFolderRequest, opts ...grpc.CallOption) (*UploadStatus, error)
+	RenameFile(ctx context.Context, in *RenameFileRequest, opts ...grpc.CallOption) (*UploadStatus, error)
+	MoveFile(ctx context.Context, in *MoveFileRequest, opts ...grpc.CallOption) (*UploadStatus, error)
+	// Прежние версии файла и восстановление одной из них, скачивание версии - GetFile с version_id
+	ListFileVersions(ctx context.Context, in *ListFileVersionsRequest, opts ...grpc.CallOption) (*ListFileVersionsResponse, error)
+	RestoreFileVersion(ctx context.Context, in *RestoreFileVersionRequest, opts ...grpc.CallOption) (*UploadStatus, error)
+	// Удаленные записи и файлы, их восстановление
+	ListTrash(ctx context.Context, in *ListTrashRequest, opts ...grpc.CallOption) (*ListTrashResponse, error)
+	RestoreFromTrash(ctx context.Context, in *RestoreFromTrashRequest, opts ...grpc.CallOption) (*UploadStatus, error)
+	// Изменения записей и файлов после ревизии клиента
+	Sync(ctx context.Context, in *SyncRequest, opts ...grpc.CallOption) (*SyncResponse, error)
+	// Изменения пользователя по мере их появления, пока открыт поток
+	WatchChanges(ctx context.Context, in *WatchChangesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ChangeEvent], error)
+	// Занятое место и квоты, при превышении квоты сохранение отклоняется с RESOURCE_EXHAUSTED
+	GetUsage(ctx context.Context, in *GetUsageRequest, opts ...grpc.CallOption) (*GetUsageResponse, error)
 }
 
 type dataKeeperServiceClient struct {
@@ -73,6 +118,26 @@ func (c *dataKeeperServiceClient) GetDataList(ctx context.Context, in *ListDataR
 	return out, nil
 }
 
+func (c *dataKeeperServiceClient) GetData(ctx context.Context, in *GetDataRequest, opts ...grpc.CallOption) (*GetDataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDataResponse)
+	err := c.cc.Invoke(ctx, DataKeeperService_GetData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataKeeperServiceClient) UpdateData(ctx context.Context, in *UpdateDataRequest, opts ...grpc.CallOption) (*UploadStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UploadStatus)
+	err := c.cc.Invoke(ctx, DataKeeperService_UpdateData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *dataKeeperServiceClient) DeleteData(ctx context.Context, in *DeleteDataRequest, opts ...grpc.CallOption) (*UploadStatus, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(UploadStatus)
@@ -83,6 +148,26 @@ func (c *dataKeeperServiceClient) DeleteData(ctx context.Context, in *DeleteData
 	return out, nil
 }
 
+func (c *dataKeeperServiceClient) ListDataHistory(ctx context.Context, in *ListDataHistoryRequest, opts ...grpc.CallOption) (*ListDataHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDataHistoryResponse)
+	err := c.cc.Invoke(ctx, DataKeeperService_ListDataHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataKeeperServiceClient) RestoreDataRevision(ctx context.Context, in *RestoreDataRevisionRequest, opts ...grpc.CallOption) (*UploadStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UploadStatus)
+	err := c.cc.Invoke(ctx, DataKeeperService_RestoreDataRevision_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *dataKeeperServiceClient) GetFileList(ctx context.Context, in *ListFileRequest, opts ...grpc.CallOption) (*ListFileResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListFileResponse)
@@ -106,9 +191,52 @@ func (c *dataKeeperServiceClient) UploadFile(ctx context.Context, opts ...grpc.C
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type DataKeeperService_UploadFileClient = grpc.ClientStreamingClient[FileChunk, UploadStatus]
 
+func (c *dataKeeperServiceClient) InitUpload(ctx context.Context, in *InitUploadRequest, opts ...grpc.CallOption) (*InitUploadResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(InitUploadResponse)
+	err := c.cc.Invoke(ctx, DataKeeperService_InitUpload_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataKeeperServiceClient) UploadChunk(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadChunkRequest, UploadOffset], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DataKeeperService_ServiceDesc.Streams[1], DataKeeperService_UploadChunk_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[UploadChunkRequest, UploadOffset]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DataKeeperService_UploadChunkClient = grpc.ClientStreamingClient[UploadChunkRequest, UploadOffset]
+
+func (c *dataKeeperServiceClient) GetUploadOffset(ctx context.Context, in *GetUploadOffsetRequest, opts ...grpc.CallOption) (*UploadOffset, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UploadOffset)
+	err := c.cc.Invoke(ctx, DataKeeperService_GetUploadOffset_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataKeeperServiceClient) FinalizeUpload(ctx context.Context, in *FinalizeUploadRequest, opts ...grpc.CallOption) (*UploadStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UploadStatus)
+	err := c.cc.Invoke(ctx, DataKeeperService_FinalizeUpload_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *dataKeeperServiceClient) GetFile(ctx context.Context, in *GetFileRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[FileChunk], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &DataKeeperService_ServiceDesc.Streams[1], DataKeeperService_GetFile_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &DataKeeperService_ServiceDesc.Streams[2], DataKeeperService_GetFile_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -135,6 +263,125 @@ func (c *dataKeeperServiceClient) DeleteFile(ctx context.Context, in *DeleteFile
 	return out, nil
 }
 
+func (c *dataKeeperServiceClient) UpdateFileMeta(ctx context.Context, in *UpdateFileMetaRequest, opts ...grpc.CallOption) (*UploadStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UploadStatus)
+	err := c.cc.Invoke(ctx, DataKeeperService_UpdateFileMeta_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataKeeperServiceClient) CreateFolder(ctx context.Context, in *CreateFolderRequest, opts ...grpc.CallOption) (*UploadStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UploadStatus)
+	err := c.cc.Invoke(ctx, DataKeeperService_CreateFolder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataKeeperServiceClient) RenameFile(ctx context.Context, in *RenameFileRequest, opts ...grpc.CallOption) (*UploadStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UploadStatus)
+	err := c.cc.Invoke(ctx, DataKeeperService_RenameFile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataKeeperServiceClient) MoveFile(ctx context.Context, in *MoveFileRequest, opts ...grpc.CallOption) (*UploadStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UploadStatus)
+	err := c.cc.Invoke(ctx, DataKeeperService_MoveFile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataKeeperServiceClient) ListFileVersions(ctx context.Context, in *ListFileVersionsRequest, opts ...grpc.CallOption) (*ListFileVersionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFileVersionsResponse)
+	err := c.cc.Invoke(ctx, DataKeeperService_ListFileVersions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataKeeperServiceClient) RestoreFileVersion(ctx context.Context, in *RestoreFileVersionRequest, opts ...grpc.CallOption) (*UploadStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UploadStatus)
+	err := c.cc.Invoke(ctx, DataKeeperService_RestoreFileVersion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataKeeperServiceClient) ListTrash(ctx context.Context, in *ListTrashRequest, opts ...grpc.CallOption) (*ListTrashResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTrashResponse)
+	err := c.cc.Invoke(ctx, DataKeeperService_ListTrash_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataKeeperServiceClient) RestoreFromTrash(ctx context.Context, in *RestoreFromTrashRequest, opts ...grpc.CallOption) (*UploadStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UploadStatus)
+	err := c.cc.Invoke(ctx, DataKeeperService_RestoreFromTrash_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataKeeperServiceClient) Sync(ctx context.Context, in *SyncRequest, opts ...grpc.CallOption) (*SyncResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SyncResponse)
+	err := c.cc.Invoke(ctx, DataKeeperService_Sync_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataKeeperServiceClient) WatchChanges(ctx context.Context, in *WatchChangesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ChangeEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DataKeeperService_ServiceDesc.Streams[3], DataKeeperService_WatchChanges_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchChangesRequest, ChangeEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DataKeeperService_WatchChangesClient = grpc.ServerStreamingClient[ChangeEvent]
+
+func (c *dataKeeperServiceClient) GetUsage(ctx context.Context, in *GetUsageRequest, opts ...grpc.CallOption) (*GetUsageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUsageResponse)
+	err := c.cc.Invoke(ctx, DataKeeperService_GetUsage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DataKeeperServiceServer is the server API for DataKeeperService service.
 // All implementations should embed UnimplementedDataKeeperServiceServer
 // for forward compatibility.
@@ -144,12 +391,38 @@ type DataKeeperServiceServer interface {
 	// Хранение новых данных на сервере (кроме файлов)
 	SaveData(context.Context, *SaveDataRequest) (*UploadStatus, error)
 	GetDataList(context.Context, *ListDataRequest) (*ListDataResponse, error)
+	GetData(context.Context, *GetDataRequest) (*GetDataResponse, error)
+	UpdateData(context.Context, *UpdateDataRequest) (*UploadStatus, error)
 	DeleteData(context.Context, *DeleteDataRequest) (*UploadStatus, error)
+	// Прежние версии записи и восстановление одной из них
+	ListDataHistory(context.Context, *ListDataHistoryRequest) (*ListDataHistoryResponse, error)
+	RestoreDataRevision(context.Context, *RestoreDataRevisionRequest) (*UploadStatus, error)
 	// Отправка файлов на сервер
 	GetFileList(context.Context, *ListFileRequest) (*ListFileResponse, error)
 	UploadFile(grpc.ClientStreamingServer[FileChunk, UploadStatus]) error
+	// Загрузка с продолжением: InitUpload, части UploadChunk, FinalizeUpload с проверкой SHA-256
+	InitUpload(context.Context, *InitUploadRequest) (*InitUploadResponse, error)
+	UploadChunk(grpc.ClientStreamingServer[UploadChunkRequest, UploadOffset]) error
+	GetUploadOffset(context.Context, *GetUploadOffsetRequest) (*UploadOffset, error)
+	FinalizeUpload(context.Context, *FinalizeUploadRequest) (*UploadStatus, error)
 	GetFile(*GetFileRequest, grpc.ServerStreamingServer[FileChunk]) error
 	DeleteFile(context.Context, *DeleteFileRequest) (*UploadStatus, error)
+	UpdateFileMeta(context.Context, *UpdateFileMetaRequest) (*UploadStatus, error)
+	CreateFolder(context.Context, *CreateFolderRequest) (*UploadStatus, error)
+	RenameFile(context.Context, *RenameFileRequest) (*UploadStatus, error)
+	MoveFile(context.Context, *MoveFileRequest) (*UploadStatus, error)
+	// Прежние версии файла и восстановление одной из них, скачивание версии - GetFile с version_id
+	ListFileVersions(context.Context, *ListFileVersionsRequest) (*ListFileVersionsResponse, error)
+	RestoreFileVersion(context.Context, *RestoreFileVersionRequest) (*UploadStatus, error)
+	// Удаленные записи и файлы, их восстановление
+	ListTrash(context.Context, *ListTrashRequest) (*ListTrashResponse, error)
+	RestoreFromTrash(context.Context, *RestoreFromTrashRequest) (*UploadStatus, error)
+	// Изменения записей и файлов после ревизии клиента
+	Sync(context.Context, *SyncRequest) (*SyncResponse, error)
+	// Изменения пользователя по мере их появления, пока открыт поток
+	WatchChanges(*WatchChangesRequest, grpc.ServerStreamingServer[ChangeEvent]) error
+	// Занятое место и квоты, при превышении квоты сохранение отклоняется с RESOURCE_EXHAUSTED
+	GetUsage(context.Context, *GetUsageRequest) (*GetUsageResponse, error)
 }
 
 // UnimplementedDataKeeperServiceServer should be embedded to have
@@ -165,21 +438,78 @@ func (UnimplementedDataKeeperServiceServer) SaveData(context.Context, *SaveDataR
 func (UnimplementedDataKeeperServiceServer) GetDataList(context.Context, *ListDataRequest) (*ListDataResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetDataList not implemented")
 }
+func (UnimplementedDataKeeperServiceServer) GetData(context.Context, *GetDataRequest) (*GetDataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetData not implemented")
+}
+func (UnimplementedDataKeeperServiceServer) UpdateData(context.Context, *UpdateDataRequest) (*UploadStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateData not implemented")
+}
 func (UnimplementedDataKeeperServiceServer) DeleteData(context.Context, *DeleteDataRequest) (*UploadStatus, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteData not implemented")
 }
+func (UnimplementedDataKeeperServiceServer) ListDataHistory(context.Context, *ListDataHistoryRequest) (*ListDataHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDataHistory not implemented")
+}
+func (UnimplementedDataKeeperServiceServer) RestoreDataRevision(context.Context, *RestoreDataRevisionRequest) (*UploadStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreDataRevision not implemented")
+}
 func (UnimplementedDataKeeperServiceServer) GetFileList(context.Context, *ListFileRequest) (*ListFileResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetFileList not implemented")
 }
 func (UnimplementedDataKeeperServiceServer) UploadFile(grpc.ClientStreamingServer[FileChunk, UploadStatus]) error {
 	return status.Errorf(codes.Unimplemented, "method UploadFile not implemented")
 }
+func (UnimplementedDataKeeperServiceServer) InitUpload(context.Context, *InitUploadRequest) (*InitUploadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InitUpload not implemented")
+}
+func (UnimplementedDataKeeperServiceServer) UploadChunk(grpc.ClientStreamingServer[UploadChunkRequest, UploadOffset]) error {
+	return status.Errorf(codes.Unimplemented, "method UploadChunk not implemented")
+}
+func (UnimplementedDataKeeperServiceServer) GetUploadOffset(context.Context, *GetUploadOffsetRequest) (*UploadOffset, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUploadOffset not implemented")
+}
+func (UnimplementedDataKeeperServiceServer) FinalizeUpload(context.Context, *FinalizeUploadRequest) (*UploadStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FinalizeUpload not implemented")
+}
 func (UnimplementedDataKeeperServiceServer) GetFile(*GetFileRequest, grpc.ServerStreamingServer[FileChunk]) error {
 	return status.Errorf(codes.Unimplemented, "method GetFile not implemented")
 }
 func (UnimplementedDataKeeperServiceServer) DeleteFile(context.Context, *DeleteFileRequest) (*UploadStatus, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteFile not implemented")
 }
+func (UnimplementedDataKeeperServiceServer) UpdateFileMeta(context.Context, *UpdateFileMetaRequest) (*UploadStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateFileMeta not implemented")
+}
+func (UnimplementedDataKeeperServiceServer) CreateFolder(context.Context, *CreateFolderRequest) (*UploadStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateFolder not implemented")
+}
+func (UnimplementedDataKeeperServiceServer) RenameFile(context.Context, *RenameFileRequest) (*UploadStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenameFile not implemented")
+}
+func (UnimplementedDataKeeperServiceServer) MoveFile(context.Context, *MoveFileRequest) (*UploadStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveFile not implemented")
+}
+func (UnimplementedDataKeeperServiceServer) ListFileVersions(context.Context, *ListFileVersionsRequest) (*ListFileVersionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFileVersions not implemented")
+}
+func (UnimplementedDataKeeperServiceServer) RestoreFileVersion(context.Context, *RestoreFileVersionRequest) (*UploadStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreFileVersion not implemented")
+}
+func (UnimplementedDataKeeperServiceServer) ListTrash(context.Context, *ListTrashRequest) (*ListTrashResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTrash not implemented")
+}
+func (UnimplementedDataKeeperServiceServer) RestoreFromTrash(context.Context, *RestoreFromTrashRequest) (*UploadStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreFromTrash not implemented")
+}
+func (UnimplementedDataKeeperServiceServer) Sync(context.Context, *SyncRequest) (*SyncResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Sync not implemented")
+}
+func (UnimplementedDataKeeperServiceServer) WatchChanges(*WatchChangesRequest, grpc.ServerStreamingServer[ChangeEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchChanges not implemented")
+}
+func (UnimplementedDataKeeperServiceServer) GetUsage(context.Context, *GetUsageRequest) (*GetUsageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUsage not implemented")
+}
 func (UnimplementedDataKeeperServiceServer) testEmbeddedByValue() {}
 
 // UnsafeDataKeeperServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -236,6 +566,42 @@ func _DataKeeperService_GetDataList_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DataKeeperService_GetData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataKeeperServiceServer).GetData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataKeeperService_GetData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataKeeperServiceServer).GetData(ctx, req.(*GetDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataKeeperService_UpdateData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataKeeperServiceServer).UpdateData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataKeeperService_UpdateData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataKeeperServiceServer).UpdateData(ctx, req.(*UpdateDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DataKeeperService_DeleteData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteDataRequest)
 	if err := dec(in); err != nil {
@@ -254,6 +620,42 @@ func _DataKeeperService_DeleteData_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DataKeeperService_ListDataHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDataHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataKeeperServiceServer).ListDataHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataKeeperService_ListDataHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataKeeperServiceServer).ListDataHistory(ctx, req.(*ListDataHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataKeeperService_RestoreDataRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreDataRevisionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataKeeperServiceServer).RestoreDataRevision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataKeeperService_RestoreDataRevision_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataKeeperServiceServer).RestoreDataRevision(ctx, req.(*RestoreDataRevisionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DataKeeperService_GetFileList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListFileRequest)
 	if err := dec(in); err != nil {
@@ -279,6 +681,67 @@ func _DataKeeperService_UploadFile_Handler(srv interface{}, stream grpc.ServerSt
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type DataKeeperService_UploadFileServer = grpc.ClientStreamingServer[FileChunk, UploadStatus]
 
+func _DataKeeperService_InitUpload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InitUploadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataKeeperServiceServer).InitUpload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataKeeperService_InitUpload_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataKeeperServiceServer).InitUpload(ctx, req.(*InitUploadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataKeeperService_UploadChunk_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(DataKeeperServiceServer).UploadChunk(&grpc.GenericServerStream[UploadChunkRequest, UploadOffset]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DataKeeperService_UploadChunkServer = grpc.ClientStreamingServer[UploadChunkRequest, UploadOffset]
+
+func _DataKeeperService_GetUploadOffset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUploadOffsetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataKeeperServiceServer).GetUploadOffset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataKeeperService_GetUploadOffset_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataKeeperServiceServer).GetUploadOffset(ctx, req.(*GetUploadOffsetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataKeeperService_FinalizeUpload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FinalizeUploadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataKeeperServiceServer).FinalizeUpload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataKeeperService_FinalizeUpload_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataKeeperServiceServer).FinalizeUpload(ctx, req.(*FinalizeUploadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DataKeeperService_GetFile_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(GetFileRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -308,6 +771,197 @@ func _DataKeeperService_DeleteFile_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DataKeeperService_UpdateFileMeta_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateFileMetaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataKeeperServiceServer).UpdateFileMeta(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataKeeperService_UpdateFileMeta_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataKeeperServiceServer).UpdateFileMeta(ctx, req.(*UpdateFileMetaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataKeeperService_CreateFolder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateFolderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataKeeperServiceServer).CreateFolder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataKeeperService_CreateFolder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataKeeperServiceServer).CreateFolder(ctx, req.(*CreateFolderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataKeeperService_RenameFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenameFileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataKeeperServiceServer).RenameFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataKeeperService_RenameFile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataKeeperServiceServer).RenameFile(ctx, req.(*RenameFileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataKeeperService_MoveFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveFileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataKeeperServiceServer).MoveFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataKeeperService_MoveFile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataKeeperServiceServer).MoveFile(ctx, req.(*MoveFileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataKeeperService_ListFileVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFileVersionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataKeeperServiceServer).ListFileVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataKeeperService_ListFileVersions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataKeeperServiceServer).ListFileVersions(ctx, req.(*ListFileVersionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataKeeperService_RestoreFileVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreFileVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataKeeperServiceServer).RestoreFileVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataKeeperService_RestoreFileVersion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataKeeperServiceServer).RestoreFileVersion(ctx, req.(*RestoreFileVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataKeeperService_ListTrash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTrashRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataKeeperServiceServer).ListTrash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataKeeperService_ListTrash_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataKeeperServiceServer).ListTrash(ctx, req.(*ListTrashRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataKeeperService_RestoreFromTrash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreFromTrashRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataKeeperServiceServer).RestoreFromTrash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataKeeperService_RestoreFromTrash_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataKeeperServiceServer).RestoreFromTrash(ctx, req.(*RestoreFromTrashRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataKeeperService_Sync_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SyncRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataKeeperServiceServer).Sync(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataKeeperService_Sync_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataKeeperServiceServer).Sync(ctx, req.(*SyncRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataKeeperService_WatchChanges_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchChangesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DataKeeperServiceServer).WatchChanges(m, &grpc.GenericServerStream[WatchChangesRequest, ChangeEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DataKeeperService_WatchChangesServer = grpc.ServerStreamingServer[ChangeEvent]
+
+func _DataKeeperService_GetUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataKeeperServiceServer).GetUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataKeeperService_GetUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataKeeperServiceServer).GetUsage(ctx, req.(*GetUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // DataKeeperService_ServiceDesc is the grpc.ServiceDesc for DataKeeperService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -323,18 +977,86 @@ var DataKeeperService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetDataList",
 			Handler:    _DataKeeperService_GetDataList_Handler,
 		},
+		{
+			MethodName: "GetData",
+			Handler:    _DataKeeperService_GetData_Handler,
+		},
+		{
+			MethodName: "UpdateData",
+			Handler:    _DataKeeperService_UpdateData_Handler,
+		},
 		{
 			MethodName: "DeleteData",
 			Handler:    _DataKeeperService_DeleteData_Handler,
 		},
+		{
+			MethodName: "ListDataHistory",
+			Handler:    _DataKeeperService_ListDataHistory_Handler,
+		},
+		{
+			MethodName: "RestoreDataRevision",
+			Handler:    _DataKeeperService_RestoreDataRevision_Handler,
+		},
 		{
 			MethodName: "GetFileList",
 			Handler:    _DataKeeperService_GetFileList_Handler,
 		},
+		{
+			MethodName: "InitUpload",
+			Handler:    _DataKeeperService_InitUpload_Handler,
+		},
+		{
+			MethodName: "GetUploadOffset",
+			Handler:    _DataKeeperService_GetUploadOffset_Handler,
+		},
+		{
+			MethodName: "FinalizeUpload",
+			Handler:    _DataKeeperService_FinalizeUpload_Handler,
+		},
 		{
 			MethodName: "DeleteFile",
 			Handler:    _DataKeeperService_DeleteFile_Handler,
 		},
+		{
+			MethodName: "UpdateFileMeta",
+			Handler:    _DataKeeperService_UpdateFileMeta_Handler,
+		},
+		{
+			MethodName: "CreateFolder",
+			Handler:    _DataKeeperService_CreateFolder_Handler,
+		},
+		{
+			MethodName: "RenameFile",
+			Handler:    _DataKeeperService_RenameFile_Handler,
+		},
+		{
+			MethodName: "MoveFile",
+			Handler:    _DataKeeperService_MoveFile_Handler,
+		},
+		{
+			MethodName: "ListFileVersions",
+			Handler:    _DataKeeperService_ListFileVersions_Handler,
+		},
+		{
+			MethodName: "RestoreFileVersion",
+			Handler:    _DataKeeperService_RestoreFileVersion_Handler,
+		},
+		{
+			MethodName: "ListTrash",
+			Handler:    _DataKeeperService_ListTrash_Handler,
+		},
+		{
+			MethodName: "RestoreFromTrash",
+			Handler:    _DataKeeperService_RestoreFromTrash_Handler,
+		},
+		{
+			MethodName: "Sync",
+			Handler:    _DataKeeperService_Sync_Handler,
+		},
+		{
+			MethodName: "GetUsage",
+			Handler:    _DataKeeperService_GetUsage_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -342,11 +1064,21 @@ var DataKeeperService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _DataKeeperService_UploadFile_Handler,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "UploadChunk",
+			Handler:       _DataKeeperService_UploadChunk_Handler,
+			ClientStreams: true,
+		},
 		{
 			StreamName:    "GetFile",
 			Handler:       _DataKeeperService_GetFile_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "WatchChanges",
+			Handler:       _DataKeeperService_WatchChanges_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "proto/api/service/v1/service.proto",
 }
@@ -283,6 +283,277 @@ func (mr *MockDataKeeperService_UploadFileServerMockRecorder) SetTrailer(arg0 in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockDataKeeperService_UploadFileServer)(nil).SetTrailer), arg0)
 }
 
+// MockDataKeeperService_UploadChunkClient is a mock of DataKeeperService_UploadChunkClient interface.
+type MockDataKeeperService_UploadChunkClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockDataKeeperService_UploadChunkClientMockRecorder
+}
+
+// MockDataKeeperService_UploadChunkClientMockRecorder is the mock recorder for MockDataKeeperService_UploadChunkClient.
+type MockDataKeeperService_UploadChunkClientMockRecorder struct {
+	mock *MockDataKeeperService_UploadChunkClient
+}
+
+// NewMockDataKeeperService_UploadChunkClient creates a new mock instance.
+func NewMockDataKeeperService_UploadChunkClient(ctrl *gomock.Controller) *MockDataKeeperService_UploadChunkClient {
+	mock := &MockDataKeeperService_UploadChunkClient{ctrl: ctrl}
+	mock.recorder = &MockDataKeeperService_UploadChunkClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDataKeeperService_UploadChunkClient) EXPECT() *MockDataKeeperService_UploadChunkClientMockRecorder {
+	return m.recorder
+}
+
+// CloseAndRecv mocks base method.
+func (m *MockDataKeeperService_UploadChunkClient) CloseAndRecv() (*UploadOffset, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseAndRecv")
+	ret0, _ := ret[0].(*UploadOffset)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloseAndRecv indicates an expected call of CloseAndRecv.
+func (mr *MockDataKeeperService_UploadChunkClientMockRecorder) CloseAndRecv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseAndRecv", reflect.TypeOf((*MockDataKeeperService_UploadChunkClient)(nil).CloseAndRecv))
+}
+
+// CloseSend mocks base method.
+func (m *MockDataKeeperService_UploadChunkClient) CloseSend() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseSend")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseSend indicates an expected call of CloseSend.
+func (mr *MockDataKeeperService_UploadChunkClientMockRecorder) CloseSend() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseSend", reflect.TypeOf((*MockDataKeeperService_UploadChunkClient)(nil).CloseSend))
+}
+
+// Context mocks base method.
+func (m *MockDataKeeperService_UploadChunkClient) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockDataKeeperService_UploadChunkClientMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockDataKeeperService_UploadChunkClient)(nil).Context))
+}
+
+// Header mocks base method.
+func (m *MockDataKeeperService_UploadChunkClient) Header() (metadata.MD, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Header")
+	ret0, _ := ret[0].(metadata.MD)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Header indicates an expected call of Header.
+func (mr *MockDataKeeperService_UploadChunkClientMockRecorder) Header() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Header", reflect.TypeOf((*MockDataKeeperService_UploadChunkClient)(nil).Header))
+}
+
+// RecvMsg mocks base method.
+func (m *MockDataKeeperService_UploadChunkClient) RecvMsg(arg0 interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecvMsg", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockDataKeeperService_UploadChunkClientMockRecorder) RecvMsg(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockDataKeeperService_UploadChunkClient)(nil).RecvMsg), arg0)
+}
+
+// Send mocks base method.
+func (m *MockDataKeeperService_UploadChunkClient) Send(arg0 *UploadChunkRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockDataKeeperService_UploadChunkClientMockRecorder) Send(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockDataKeeperService_UploadChunkClient)(nil).Send), arg0)
+}
+
+// SendMsg mocks base method.
+func (m *MockDataKeeperService_UploadChunkClient) SendMsg(arg0 interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendMsg", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockDataKeeperService_UploadChunkClientMockRecorder) SendMsg(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockDataKeeperService_UploadChunkClient)(nil).SendMsg), arg0)
+}
+
+// Trailer mocks base method.
+func (m *MockDataKeeperService_UploadChunkClient) Trailer() metadata.MD {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trailer")
+	ret0, _ := ret[0].(metadata.MD)
+	return ret0
+}
+
+// Trailer indicates an expected call of Trailer.
+func (mr *MockDataKeeperService_UploadChunkClientMockRecorder) Trailer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockDataKeeperService_UploadChunkClient)(nil).Trailer))
+}
+
+// MockDataKeeperService_UploadChunkServer is a mock of DataKeeperService_UploadChunkServer interface.
+type MockDataKeeperService_UploadChunkServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockDataKeeperService_UploadChunkServerMockRecorder
+}
+
+// MockDataKeeperService_UploadChunkServerMockRecorder is the mock recorder for MockDataKeeperService_UploadChunkServer.
+type MockDataKeeperService_UploadChunkServerMockRecorder struct {
+	mock *MockDataKeeperService_UploadChunkServer
+}
+
+// NewMockDataKeeperService_UploadChunkServer creates a new mock instance.
+func NewMockDataKeeperService_UploadChunkServer(ctrl *gomock.Controller) *MockDataKeeperService_UploadChunkServer {
+	mock := &MockDataKeeperService_UploadChunkServer{ctrl: ctrl}
+	mock.recorder = &MockDataKeeperService_UploadChunkServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDataKeeperService_UploadChunkServer) EXPECT() *MockDataKeeperService_UploadChunkServerMockRecorder {
+	return m.recorder
+}
+
+// Context mocks base method.
+func (m *MockDataKeeperService_UploadChunkServer) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockDataKeeperService_UploadChunkServerMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockDataKeeperService_UploadChunkServer)(nil).Context))
+}
+
+// Recv mocks base method.
+func (m *MockDataKeeperService_UploadChunkServer) Recv() (*UploadOffset, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recv")
+	ret0, _ := ret[0].(*UploadOffset)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recv indicates an expected call of Recv.
+func (mr *MockDataKeeperService_UploadChunkServerMockRecorder) Recv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recv", reflect.TypeOf((*MockDataKeeperService_UploadChunkServer)(nil).Recv))
+}
+
+// RecvMsg mocks base method.
+func (m *MockDataKeeperService_UploadChunkServer) RecvMsg(arg0 interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecvMsg", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockDataKeeperService_UploadChunkServerMockRecorder) RecvMsg(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockDataKeeperService_UploadChunkServer)(nil).RecvMsg), arg0)
+}
+
+// SendAndClose mocks base method.
+func (m *MockDataKeeperService_UploadChunkServer) SendAndClose(arg0 *UploadChunkRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendAndClose", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendAndClose indicates an expected call of SendAndClose.
+func (mr *MockDataKeeperService_UploadChunkServerMockRecorder) SendAndClose(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendAndClose", reflect.TypeOf((*MockDataKeeperService_UploadChunkServer)(nil).SendAndClose), arg0)
+}
+
+// SendHeader mocks base method.
+func (m *MockDataKeeperService_UploadChunkServer) SendHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendHeader indicates an expected call of SendHeader.
+func (mr *MockDataKeeperService_UploadChunkServerMockRecorder) SendHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHeader", reflect.TypeOf((*MockDataKeeperService_UploadChunkServer)(nil).SendHeader), arg0)
+}
+
+// SendMsg mocks base method.
+func (m *MockDataKeeperService_UploadChunkServer) SendMsg(arg0 interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendMsg", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockDataKeeperService_UploadChunkServerMockRecorder) SendMsg(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockDataKeeperService_UploadChunkServer)(nil).SendMsg), arg0)
+}
+
+// SetHeader mocks base method.
+func (m *MockDataKeeperService_UploadChunkServer) SetHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHeader indicates an expected call of SetHeader.
+func (mr *MockDataKeeperService_UploadChunkServerMockRecorder) SetHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHeader", reflect.TypeOf((*MockDataKeeperService_UploadChunkServer)(nil).SetHeader), arg0)
+}
+
+// SetTrailer mocks base method.
+func (m *MockDataKeeperService_UploadChunkServer) SetTrailer(arg0 metadata.MD) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTrailer", arg0)
+}
+
+// SetTrailer indicates an expected call of SetTrailer.
+func (mr *MockDataKeeperService_UploadChunkServerMockRecorder) SetTrailer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockDataKeeperService_UploadChunkServer)(nil).SetTrailer), arg0)
+}
+
 // MockDataKeeperService_GetFileClient is a mock of DataKeeperService_GetFileClient interface.
 type MockDataKeeperService_GetFileClient struct {
 	ctrl     *gomock.Controller
@@ -525,67 +796,369 @@ func (mr *MockDataKeeperService_GetFileServerMockRecorder) SetTrailer(arg0 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockDataKeeperService_GetFileServer)(nil).SetTrailer), arg0)
 }
 
-// MockDataKeeperServiceClient is a mock of DataKeeperServiceClient interface.
-type MockDataKeeperServiceClient struct {
+// MockDataKeeperService_WatchChangesClient is a mock of DataKeeperService_WatchChangesClient interface.
+type MockDataKeeperService_WatchChangesClient struct {
 	ctrl     *gomock.Controller
-	recorder *MockDataKeeperServiceClientMockRecorder
+	recorder *MockDataKeeperService_WatchChangesClientMockRecorder
 }
 
-// MockDataKeeperServiceClientMockRecorder is the mock recorder for MockDataKeeperServiceClient.
-type MockDataKeeperServiceClientMockRecorder struct {
-	mock *MockDataKeeperServiceClient
+// MockDataKeeperService_WatchChangesClientMockRecorder is the mock recorder for MockDataKeeperService_WatchChangesClient.
+type MockDataKeeperService_WatchChangesClientMockRecorder struct {
+	mock *MockDataKeeperService_WatchChangesClient
 }
 
-// NewMockDataKeeperServiceClient creates a new mock instance.
-func NewMockDataKeeperServiceClient(ctrl *gomock.Controller) *MockDataKeeperServiceClient {
-	mock := &MockDataKeeperServiceClient{ctrl: ctrl}
-	mock.recorder = &MockDataKeeperServiceClientMockRecorder{mock}
+// NewMockDataKeeperService_WatchChangesClient creates a new mock instance.
+func NewMockDataKeeperService_WatchChangesClient(ctrl *gomock.Controller) *MockDataKeeperService_WatchChangesClient {
+	mock := &MockDataKeeperService_WatchChangesClient{ctrl: ctrl}
+	mock.recorder = &MockDataKeeperService_WatchChangesClientMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockDataKeeperServiceClient) EXPECT() *MockDataKeeperServiceClientMockRecorder {
+func (m *MockDataKeeperService_WatchChangesClient) EXPECT() *MockDataKeeperService_WatchChangesClientMockRecorder {
 	return m.recorder
 }
 
-// DeleteData mocks base method.
-func (m *MockDataKeeperServiceClient) DeleteData(ctx context.Context, in *DeleteDataRequest, opts ...grpc.CallOption) (*UploadStatus, error) {
+// CloseSend mocks base method.
+func (m *MockDataKeeperService_WatchChangesClient) CloseSend() error {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{ctx, in}
-	for _, a := range opts {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "DeleteData", varargs...)
-	ret0, _ := ret[0].(*UploadStatus)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "CloseSend")
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// DeleteData indicates an expected call of DeleteData.
-func (mr *MockDataKeeperServiceClientMockRecorder) DeleteData(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+// CloseSend indicates an expected call of CloseSend.
+func (mr *MockDataKeeperService_WatchChangesClientMockRecorder) CloseSend() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{ctx, in}, opts...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteData", reflect.TypeOf((*MockDataKeeperServiceClient)(nil).DeleteData), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseSend", reflect.TypeOf((*MockDataKeeperService_WatchChangesClient)(nil).CloseSend))
 }
 
-// DeleteFile mocks base method.
-func (m *MockDataKeeperServiceClient) DeleteFile(ctx context.Context, in *DeleteFileRequest, opts ...grpc.CallOption) (*UploadStatus, error) {
+// Context mocks base method.
+func (m *MockDataKeeperService_WatchChangesClient) Context() context.Context {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{ctx, in}
-	for _, a := range opts {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "DeleteFile", varargs...)
-	ret0, _ := ret[0].(*UploadStatus)
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockDataKeeperService_WatchChangesClientMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockDataKeeperService_WatchChangesClient)(nil).Context))
+}
+
+// Header mocks base method.
+func (m *MockDataKeeperService_WatchChangesClient) Header() (metadata.MD, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Header")
+	ret0, _ := ret[0].(metadata.MD)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// DeleteFile indicates an expected call of DeleteFile.
-func (mr *MockDataKeeperServiceClientMockRecorder) DeleteFile(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+// Header indicates an expected call of Header.
+func (mr *MockDataKeeperService_WatchChangesClientMockRecorder) Header() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{ctx, in}, opts...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFile", reflect.TypeOf((*MockDataKeeperServiceClient)(nil).DeleteFile), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Header", reflect.TypeOf((*MockDataKeeperService_WatchChangesClient)(nil).Header))
+}
+
+// Recv mocks base method.
+func (m *MockDataKeeperService_WatchChangesClient) Recv() (*ChangeEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recv")
+	ret0, _ := ret[0].(*ChangeEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recv indicates an expected call of Recv.
+func (mr *MockDataKeeperService_WatchChangesClientMockRecorder) Recv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recv", reflect.TypeOf((*MockDataKeeperService_WatchChangesClient)(nil).Recv))
+}
+
+// RecvMsg mocks base method.
+func (m *MockDataKeeperService_WatchChangesClient) RecvMsg(arg0 interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecvMsg", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockDataKeeperService_WatchChangesClientMockRecorder) RecvMsg(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockDataKeeperService_WatchChangesClient)(nil).RecvMsg), arg0)
+}
+
+// SendMsg mocks base method.
+func (m *MockDataKeeperService_WatchChangesClient) SendMsg(arg0 interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendMsg", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockDataKeeperService_WatchChangesClientMockRecorder) SendMsg(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockDataKeeperService_WatchChangesClient)(nil).SendMsg), arg0)
+}
+
+// Trailer mocks base method.
+func (m *MockDataKeeperService_WatchChangesClient) Trailer() metadata.MD {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trailer")
+	ret0, _ := ret[0].(metadata.MD)
+	return ret0
+}
+
+// Trailer indicates an expected call of Trailer.
+func (mr *MockDataKeeperService_WatchChangesClientMockRecorder) Trailer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockDataKeeperService_WatchChangesClient)(nil).Trailer))
+}
+
+// MockDataKeeperService_WatchChangesServer is a mock of DataKeeperService_WatchChangesServer interface.
+type MockDataKeeperService_WatchChangesServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockDataKeeperService_WatchChangesServerMockRecorder
+}
+
+// MockDataKeeperService_WatchChangesServerMockRecorder is the mock recorder for MockDataKeeperService_WatchChangesServer.
+type MockDataKeeperService_WatchChangesServerMockRecorder struct {
+	mock *MockDataKeeperService_WatchChangesServer
+}
+
+// NewMockDataKeeperService_WatchChangesServer creates a new mock instance.
+func NewMockDataKeeperService_WatchChangesServer(ctrl *gomock.Controller) *MockDataKeeperService_WatchChangesServer {
+	mock := &MockDataKeeperService_WatchChangesServer{ctrl: ctrl}
+	mock.recorder = &MockDataKeeperService_WatchChangesServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDataKeeperService_WatchChangesServer) EXPECT() *MockDataKeeperService_WatchChangesServerMockRecorder {
+	return m.recorder
+}
+
+// Context mocks base method.
+func (m *MockDataKeeperService_WatchChangesServer) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockDataKeeperService_WatchChangesServerMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockDataKeeperService_WatchChangesServer)(nil).Context))
+}
+
+// RecvMsg mocks base method.
+func (m *MockDataKeeperService_WatchChangesServer) RecvMsg(arg0 interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecvMsg", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockDataKeeperService_WatchChangesServerMockRecorder) RecvMsg(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockDataKeeperService_WatchChangesServer)(nil).RecvMsg), arg0)
+}
+
+// Send mocks base method.
+func (m *MockDataKeeperService_WatchChangesServer) Send(arg0 *ChangeEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockDataKeeperService_WatchChangesServerMockRecorder) Send(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockDataKeeperService_WatchChangesServer)(nil).Send), arg0)
+}
+
+// SendHeader mocks base method.
+func (m *MockDataKeeperService_WatchChangesServer) SendHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendHeader indicates an expected call of SendHeader.
+func (mr *MockDataKeeperService_WatchChangesServerMockRecorder) SendHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHeader", reflect.TypeOf((*MockDataKeeperService_WatchChangesServer)(nil).SendHeader), arg0)
+}
+
+// SendMsg mocks base method.
+func (m *MockDataKeeperService_WatchChangesServer) SendMsg(arg0 interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendMsg", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockDataKeeperService_WatchChangesServerMockRecorder) SendMsg(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockDataKeeperService_WatchChangesServer)(nil).SendMsg), arg0)
+}
+
+// SetHeader mocks base method.
+func (m *MockDataKeeperService_WatchChangesServer) SetHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHeader indicates an expected call of SetHeader.
+func (mr *MockDataKeeperService_WatchChangesServerMockRecorder) SetHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHeader", reflect.TypeOf((*MockDataKeeperService_WatchChangesServer)(nil).SetHeader), arg0)
+}
+
+// SetTrailer mocks base method.
+func (m *MockDataKeeperService_WatchChangesServer) SetTrailer(arg0 metadata.MD) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTrailer", arg0)
+}
+
+// SetTrailer indicates an expected call of SetTrailer.
+func (mr *MockDataKeeperService_WatchChangesServerMockRecorder) SetTrailer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockDataKeeperService_WatchChangesServer)(nil).SetTrailer), arg0)
+}
+
+// MockDataKeeperServiceClient is a mock of DataKeeperServiceClient interface.
+type MockDataKeeperServiceClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockDataKeeperServiceClientMockRecorder
+}
+
+// MockDataKeeperServiceClientMockRecorder is the mock recorder for MockDataKeeperServiceClient.
+type MockDataKeeperServiceClientMockRecorder struct {
+	mock *MockDataKeeperServiceClient
+}
+
+// NewMockDataKeeperServiceClient creates a new mock instance.
+func NewMockDataKeeperServiceClient(ctrl *gomock.Controller) *MockDataKeeperServiceClient {
+	mock := &MockDataKeeperServiceClient{ctrl: ctrl}
+	mock.recorder = &MockDataKeeperServiceClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDataKeeperServiceClient) EXPECT() *MockDataKeeperServiceClientMockRecorder {
+	return m.recorder
+}
+
+// CreateFolder mocks base method.
+func (m *MockDataKeeperServiceClient) CreateFolder(ctx context.Context, in *CreateFolderRequest, opts ...grpc.CallOption) (*UploadStatus, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateFolder", varargs...)
+	ret0, _ := ret[0].(*UploadStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFolder indicates an expected call of CreateFolder.
+func (mr *MockDataKeeperServiceClientMockRecorder) CreateFolder(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFolder", reflect.TypeOf((*MockDataKeeperServiceClient)(nil).CreateFolder), varargs...)
+}
+
+// DeleteData mocks base method.
+func (m *MockDataKeeperServiceClient) DeleteData(ctx context.Context, in *DeleteDataRequest, opts ...grpc.CallOption) (*UploadStatus, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteData", varargs...)
+	ret0, _ := ret[0].(*UploadStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteData indicates an expected call of DeleteData.
+func (mr *MockDataKeeperServiceClientMockRecorder) DeleteData(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteData", reflect.TypeOf((*MockDataKeeperServiceClient)(nil).DeleteData), varargs...)
+}
+
+// DeleteFile mocks base method.
+func (m *MockDataKeeperServiceClient) DeleteFile(ctx context.Context, in *DeleteFileRequest, opts ...grpc.CallOption) (*UploadStatus, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteFile", varargs...)
+	ret0, _ := ret[0].(*UploadStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteFile indicates an expected call of DeleteFile.
+func (mr *MockDataKeeperServiceClientMockRecorder) DeleteFile(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFile", reflect.TypeOf((*MockDataKeeperServiceClient)(nil).DeleteFile), varargs...)
+}
+
+// FinalizeUpload mocks base method.
+func (m *MockDataKeeperServiceClient) FinalizeUpload(ctx context.Context, in *FinalizeUploadRequest, opts ...grpc.CallOption) (*UploadStatus, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FinalizeUpload", varargs...)
+	ret0, _ := ret[0].(*UploadStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FinalizeUpload indicates an expected call of FinalizeUpload.
+func (mr *MockDataKeeperServiceClientMockRecorder) FinalizeUpload(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinalizeUpload", reflect.TypeOf((*MockDataKeeperServiceClient)(nil).FinalizeUpload), varargs...)
+}
+
+// GetData mocks base method.
+func (m *MockDataKeeperServiceClient) GetData(ctx context.Context, in *GetDataRequest, opts ...grpc.CallOption) (*GetDataResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetData", varargs...)
+	ret0, _ := ret[0].(*GetDataResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetData indicates an expected call of GetData.
+func (mr *MockDataKeeperServiceClientMockRecorder) GetData(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetData", reflect.TypeOf((*MockDataKeeperServiceClient)(nil).GetData), varargs...)
 }
 
 // GetDataList mocks base method.
@@ -595,57 +1168,277 @@ func (m *MockDataKeeperServiceClient) GetDataList(ctx context.Context, in *ListD
 	for _, a := range opts {
 		varargs = append(varargs, a)
 	}
-	ret := m.ctrl.Call(m, "GetDataList", varargs...)
-	ret0, _ := ret[0].(*ListDataResponse)
+	ret := m.ctrl.Call(m, "GetDataList", varargs...)
+	ret0, _ := ret[0].(*ListDataResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDataList indicates an expected call of GetDataList.
+func (mr *MockDataKeeperServiceClientMockRecorder) GetDataList(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDataList", reflect.TypeOf((*MockDataKeeperServiceClient)(nil).GetDataList), varargs...)
+}
+
+// GetFile mocks base method.
+func (m *MockDataKeeperServiceClient) GetFile(ctx context.Context, in *GetFileRequest, opts ...grpc.CallOption) (DataKeeperService_GetFileClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetFile", varargs...)
+	ret0, _ := ret[0].(DataKeeperService_GetFileClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFile indicates an expected call of GetFile.
+func (mr *MockDataKeeperServiceClientMockRecorder) GetFile(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFile", reflect.TypeOf((*MockDataKeeperServiceClient)(nil).GetFile), varargs...)
+}
+
+// GetFileList mocks base method.
+func (m *MockDataKeeperServiceClient) GetFileList(ctx context.Context, in *ListFileRequest, opts ...grpc.CallOption) (*ListFileResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetFileList", varargs...)
+	ret0, _ := ret[0].(*ListFileResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFileList indicates an expected call of GetFileList.
+func (mr *MockDataKeeperServiceClientMockRecorder) GetFileList(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFileList", reflect.TypeOf((*MockDataKeeperServiceClient)(nil).GetFileList), varargs...)
+}
+
+// GetUploadOffset mocks base method.
+func (m *MockDataKeeperServiceClient) GetUploadOffset(ctx context.Context, in *GetUploadOffsetRequest, opts ...grpc.CallOption) (*UploadOffset, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetUploadOffset", varargs...)
+	ret0, _ := ret[0].(*UploadOffset)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUploadOffset indicates an expected call of GetUploadOffset.
+func (mr *MockDataKeeperServiceClientMockRecorder) GetUploadOffset(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUploadOffset", reflect.TypeOf((*MockDataKeeperServiceClient)(nil).GetUploadOffset), varargs...)
+}
+
+// GetUsage mocks base method.
+func (m *MockDataKeeperServiceClient) GetUsage(ctx context.Context, in *GetUsageRequest, opts ...grpc.CallOption) (*GetUsageResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetUsage", varargs...)
+	ret0, _ := ret[0].(*GetUsageResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsage indicates an expected call of GetUsage.
+func (mr *MockDataKeeperServiceClientMockRecorder) GetUsage(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsage", reflect.TypeOf((*MockDataKeeperServiceClient)(nil).GetUsage), varargs...)
+}
+
+// InitUpload mocks base method.
+func (m *MockDataKeeperServiceClient) InitUpload(ctx context.Context, in *InitUploadRequest, opts ...grpc.CallOption) (*InitUploadResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "InitUpload", varargs...)
+	ret0, _ := ret[0].(*InitUploadResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InitUpload indicates an expected call of InitUpload.
+func (mr *MockDataKeeperServiceClientMockRecorder) InitUpload(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InitUpload", reflect.TypeOf((*MockDataKeeperServiceClient)(nil).InitUpload), varargs...)
+}
+
+// ListDataHistory mocks base method.
+func (m *MockDataKeeperServiceClient) ListDataHistory(ctx context.Context, in *ListDataHistoryRequest, opts ...grpc.CallOption) (*ListDataHistoryResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListDataHistory", varargs...)
+	ret0, _ := ret[0].(*ListDataHistoryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDataHistory indicates an expected call of ListDataHistory.
+func (mr *MockDataKeeperServiceClientMockRecorder) ListDataHistory(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDataHistory", reflect.TypeOf((*MockDataKeeperServiceClient)(nil).ListDataHistory), varargs...)
+}
+
+// ListFileVersions mocks base method.
+func (m *MockDataKeeperServiceClient) ListFileVersions(ctx context.Context, in *ListFileVersionsRequest, opts ...grpc.CallOption) (*ListFileVersionsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListFileVersions", varargs...)
+	ret0, _ := ret[0].(*ListFileVersionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFileVersions indicates an expected call of ListFileVersions.
+func (mr *MockDataKeeperServiceClientMockRecorder) ListFileVersions(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFileVersions", reflect.TypeOf((*MockDataKeeperServiceClient)(nil).ListFileVersions), varargs...)
+}
+
+// ListTrash mocks base method.
+func (m *MockDataKeeperServiceClient) ListTrash(ctx context.Context, in *ListTrashRequest, opts ...grpc.CallOption) (*ListTrashResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListTrash", varargs...)
+	ret0, _ := ret[0].(*ListTrashResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTrash indicates an expected call of ListTrash.
+func (mr *MockDataKeeperServiceClientMockRecorder) ListTrash(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrash", reflect.TypeOf((*MockDataKeeperServiceClient)(nil).ListTrash), varargs...)
+}
+
+// MoveFile mocks base method.
+func (m *MockDataKeeperServiceClient) MoveFile(ctx context.Context, in *MoveFileRequest, opts ...grpc.CallOption) (*UploadStatus, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "MoveFile", varargs...)
+	ret0, _ := ret[0].(*UploadStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveFile indicates an expected call of MoveFile.
+func (mr *MockDataKeeperServiceClientMockRecorder) MoveFile(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveFile", reflect.TypeOf((*MockDataKeeperServiceClient)(nil).MoveFile), varargs...)
+}
+
+// RenameFile mocks base method.
+func (m *MockDataKeeperServiceClient) RenameFile(ctx context.Context, in *RenameFileRequest, opts ...grpc.CallOption) (*UploadStatus, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RenameFile", varargs...)
+	ret0, _ := ret[0].(*UploadStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenameFile indicates an expected call of RenameFile.
+func (mr *MockDataKeeperServiceClientMockRecorder) RenameFile(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameFile", reflect.TypeOf((*MockDataKeeperServiceClient)(nil).RenameFile), varargs...)
+}
+
+// RestoreDataRevision mocks base method.
+func (m *MockDataKeeperServiceClient) RestoreDataRevision(ctx context.Context, in *RestoreDataRevisionRequest, opts ...grpc.CallOption) (*UploadStatus, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RestoreDataRevision", varargs...)
+	ret0, _ := ret[0].(*UploadStatus)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetDataList indicates an expected call of GetDataList.
-func (mr *MockDataKeeperServiceClientMockRecorder) GetDataList(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+// RestoreDataRevision indicates an expected call of RestoreDataRevision.
+func (mr *MockDataKeeperServiceClientMockRecorder) RestoreDataRevision(ctx, in interface{}, opts ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	varargs := append([]interface{}{ctx, in}, opts...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDataList", reflect.TypeOf((*MockDataKeeperServiceClient)(nil).GetDataList), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreDataRevision", reflect.TypeOf((*MockDataKeeperServiceClient)(nil).RestoreDataRevision), varargs...)
 }
 
-// GetFile mocks base method.
-func (m *MockDataKeeperServiceClient) GetFile(ctx context.Context, in *GetFileRequest, opts ...grpc.CallOption) (DataKeeperService_GetFileClient, error) {
+// RestoreFileVersion mocks base method.
+func (m *MockDataKeeperServiceClient) RestoreFileVersion(ctx context.Context, in *RestoreFileVersionRequest, opts ...grpc.CallOption) (*UploadStatus, error) {
 	m.ctrl.T.Helper()
 	varargs := []interface{}{ctx, in}
 	for _, a := range opts {
 		varargs = append(varargs, a)
 	}
-	ret := m.ctrl.Call(m, "GetFile", varargs...)
-	ret0, _ := ret[0].(DataKeeperService_GetFileClient)
+	ret := m.ctrl.Call(m, "RestoreFileVersion", varargs...)
+	ret0, _ := ret[0].(*UploadStatus)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetFile indicates an expected call of GetFile.
-func (mr *MockDataKeeperServiceClientMockRecorder) GetFile(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+// RestoreFileVersion indicates an expected call of RestoreFileVersion.
+func (mr *MockDataKeeperServiceClientMockRecorder) RestoreFileVersion(ctx, in interface{}, opts ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	varargs := append([]interface{}{ctx, in}, opts...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFile", reflect.TypeOf((*MockDataKeeperServiceClient)(nil).GetFile), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreFileVersion", reflect.TypeOf((*MockDataKeeperServiceClient)(nil).RestoreFileVersion), varargs...)
 }
 
-// GetFileList mocks base method.
-func (m *MockDataKeeperServiceClient) GetFileList(ctx context.Context, in *ListFileRequest, opts ...grpc.CallOption) (*ListFileResponse, error) {
+// RestoreFromTrash mocks base method.
+func (m *MockDataKeeperServiceClient) RestoreFromTrash(ctx context.Context, in *RestoreFromTrashRequest, opts ...grpc.CallOption) (*UploadStatus, error) {
 	m.ctrl.T.Helper()
 	varargs := []interface{}{ctx, in}
 	for _, a := range opts {
 		varargs = append(varargs, a)
 	}
-	ret := m.ctrl.Call(m, "GetFileList", varargs...)
-	ret0, _ := ret[0].(*ListFileResponse)
+	ret := m.ctrl.Call(m, "RestoreFromTrash", varargs...)
+	ret0, _ := ret[0].(*UploadStatus)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetFileList indicates an expected call of GetFileList.
-func (mr *MockDataKeeperServiceClientMockRecorder) GetFileList(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+// RestoreFromTrash indicates an expected call of RestoreFromTrash.
+func (mr *MockDataKeeperServiceClientMockRecorder) RestoreFromTrash(ctx, in interface{}, opts ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	varargs := append([]interface{}{ctx, in}, opts...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFileList", reflect.TypeOf((*MockDataKeeperServiceClient)(nil).GetFileList), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreFromTrash", reflect.TypeOf((*MockDataKeeperServiceClient)(nil).RestoreFromTrash), varargs...)
 }
 
 // SaveData mocks base method.
@@ -668,6 +1461,86 @@ func (mr *MockDataKeeperServiceClientMockRecorder) SaveData(ctx, in interface{},
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveData", reflect.TypeOf((*MockDataKeeperServiceClient)(nil).SaveData), varargs...)
 }
 
+// Sync mocks base method.
+func (m *MockDataKeeperServiceClient) Sync(ctx context.Context, in *SyncRequest, opts ...grpc.CallOption) (*SyncResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Sync", varargs...)
+	ret0, _ := ret[0].(*SyncResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Sync indicates an expected call of Sync.
+func (mr *MockDataKeeperServiceClientMockRecorder) Sync(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sync", reflect.TypeOf((*MockDataKeeperServiceClient)(nil).Sync), varargs...)
+}
+
+// UpdateData mocks base method.
+func (m *MockDataKeeperServiceClient) UpdateData(ctx context.Context, in *UpdateDataRequest, opts ...grpc.CallOption) (*UploadStatus, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateData", varargs...)
+	ret0, _ := ret[0].(*UploadStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateData indicates an expected call of UpdateData.
+func (mr *MockDataKeeperServiceClientMockRecorder) UpdateData(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateData", reflect.TypeOf((*MockDataKeeperServiceClient)(nil).UpdateData), varargs...)
+}
+
+// UpdateFileMeta mocks base method.
+func (m *MockDataKeeperServiceClient) UpdateFileMeta(ctx context.Context, in *UpdateFileMetaRequest, opts ...grpc.CallOption) (*UploadStatus, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateFileMeta", varargs...)
+	ret0, _ := ret[0].(*UploadStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateFileMeta indicates an expected call of UpdateFileMeta.
+func (mr *MockDataKeeperServiceClientMockRecorder) UpdateFileMeta(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFileMeta", reflect.TypeOf((*MockDataKeeperServiceClient)(nil).UpdateFileMeta), varargs...)
+}
+
+// UploadChunk mocks base method.
+func (m *MockDataKeeperServiceClient) UploadChunk(ctx context.Context, opts ...grpc.CallOption) (DataKeeperService_UploadChunkClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UploadChunk", varargs...)
+	ret0, _ := ret[0].(DataKeeperService_UploadChunkClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadChunk indicates an expected call of UploadChunk.
+func (mr *MockDataKeeperServiceClientMockRecorder) UploadChunk(ctx interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadChunk", reflect.TypeOf((*MockDataKeeperServiceClient)(nil).UploadChunk), varargs...)
+}
+
 // UploadFile mocks base method.
 func (m *MockDataKeeperServiceClient) UploadFile(ctx context.Context, opts ...grpc.CallOption) (DataKeeperService_UploadFileClient, error) {
 	m.ctrl.T.Helper()
@@ -688,6 +1561,26 @@ func (mr *MockDataKeeperServiceClientMockRecorder) UploadFile(ctx interface{}, o
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadFile", reflect.TypeOf((*MockDataKeeperServiceClient)(nil).UploadFile), varargs...)
 }
 
+// WatchChanges mocks base method.
+func (m *MockDataKeeperServiceClient) WatchChanges(ctx context.Context, in *WatchChangesRequest, opts ...grpc.CallOption) (DataKeeperService_WatchChangesClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WatchChanges", varargs...)
+	ret0, _ := ret[0].(DataKeeperService_WatchChangesClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WatchChanges indicates an expected call of WatchChanges.
+func (mr *MockDataKeeperServiceClientMockRecorder) WatchChanges(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchChanges", reflect.TypeOf((*MockDataKeeperServiceClient)(nil).WatchChanges), varargs...)
+}
+
 // MockDataKeeperServiceServer is a mock of DataKeeperServiceServer interface.
 type MockDataKeeperServiceServer struct {
 	ctrl     *gomock.Controller
@@ -711,6 +1604,21 @@ func (m *MockDataKeeperServiceServer) EXPECT() *MockDataKeeperServiceServerMockR
 	return m.recorder
 }
 
+// CreateFolder mocks base method.
+func (m *MockDataKeeperServiceServer) CreateFolder(ctx context.Context, in *CreateFolderRequest) (*UploadStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFolder", ctx, in)
+	ret0, _ := ret[0].(*UploadStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFolder indicates an expected call of CreateFolder.
+func (mr *MockDataKeeperServiceServerMockRecorder) CreateFolder(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFolder", reflect.TypeOf((*MockDataKeeperServiceServer)(nil).CreateFolder), ctx, in)
+}
+
 // DeleteData mocks base method.
 func (m *MockDataKeeperServiceServer) DeleteData(ctx context.Context, in *DeleteDataRequest) (*UploadStatus, error) {
 	m.ctrl.T.Helper()
@@ -741,6 +1649,36 @@ func (mr *MockDataKeeperServiceServerMockRecorder) DeleteFile(ctx, in interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFile", reflect.TypeOf((*MockDataKeeperServiceServer)(nil).DeleteFile), ctx, in)
 }
 
+// FinalizeUpload mocks base method.
+func (m *MockDataKeeperServiceServer) FinalizeUpload(ctx context.Context, in *FinalizeUploadRequest) (*UploadStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinalizeUpload", ctx, in)
+	ret0, _ := ret[0].(*UploadStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FinalizeUpload indicates an expected call of FinalizeUpload.
+func (mr *MockDataKeeperServiceServerMockRecorder) FinalizeUpload(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinalizeUpload", reflect.TypeOf((*MockDataKeeperServiceServer)(nil).FinalizeUpload), ctx, in)
+}
+
+// GetData mocks base method.
+func (m *MockDataKeeperServiceServer) GetData(ctx context.Context, in *GetDataRequest) (*GetDataResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetData", ctx, in)
+	ret0, _ := ret[0].(*GetDataResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetData indicates an expected call of GetData.
+func (mr *MockDataKeeperServiceServerMockRecorder) GetData(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetData", reflect.TypeOf((*MockDataKeeperServiceServer)(nil).GetData), ctx, in)
+}
+
 // GetDataList mocks base method.
 func (m *MockDataKeeperServiceServer) GetDataList(ctx context.Context, in *ListDataRequest) (*ListDataResponse, error) {
 	m.ctrl.T.Helper()
@@ -785,6 +1723,171 @@ func (mr *MockDataKeeperServiceServerMockRecorder) GetFileList(ctx, in interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFileList", reflect.TypeOf((*MockDataKeeperServiceServer)(nil).GetFileList), ctx, in)
 }
 
+// GetUploadOffset mocks base method.
+func (m *MockDataKeeperServiceServer) GetUploadOffset(ctx context.Context, in *GetUploadOffsetRequest) (*UploadOffset, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUploadOffset", ctx, in)
+	ret0, _ := ret[0].(*UploadOffset)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUploadOffset indicates an expected call of GetUploadOffset.
+func (mr *MockDataKeeperServiceServerMockRecorder) GetUploadOffset(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUploadOffset", reflect.TypeOf((*MockDataKeeperServiceServer)(nil).GetUploadOffset), ctx, in)
+}
+
+// GetUsage mocks base method.
+func (m *MockDataKeeperServiceServer) GetUsage(ctx context.Context, in *GetUsageRequest) (*GetUsageResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsage", ctx, in)
+	ret0, _ := ret[0].(*GetUsageResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsage indicates an expected call of GetUsage.
+func (mr *MockDataKeeperServiceServerMockRecorder) GetUsage(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsage", reflect.TypeOf((*MockDataKeeperServiceServer)(nil).GetUsage), ctx, in)
+}
+
+// InitUpload mocks base method.
+func (m *MockDataKeeperServiceServer) InitUpload(ctx context.Context, in *InitUploadRequest) (*InitUploadResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InitUpload", ctx, in)
+	ret0, _ := ret[0].(*InitUploadResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InitUpload indicates an expected call of InitUpload.
+func (mr *MockDataKeeperServiceServerMockRecorder) InitUpload(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InitUpload", reflect.TypeOf((*MockDataKeeperServiceServer)(nil).InitUpload), ctx, in)
+}
+
+// ListDataHistory mocks base method.
+func (m *MockDataKeeperServiceServer) ListDataHistory(ctx context.Context, in *ListDataHistoryRequest) (*ListDataHistoryResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDataHistory", ctx, in)
+	ret0, _ := ret[0].(*ListDataHistoryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDataHistory indicates an expected call of ListDataHistory.
+func (mr *MockDataKeeperServiceServerMockRecorder) ListDataHistory(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDataHistory", reflect.TypeOf((*MockDataKeeperServiceServer)(nil).ListDataHistory), ctx, in)
+}
+
+// ListFileVersions mocks base method.
+func (m *MockDataKeeperServiceServer) ListFileVersions(ctx context.Context, in *ListFileVersionsRequest) (*ListFileVersionsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFileVersions", ctx, in)
+	ret0, _ := ret[0].(*ListFileVersionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFileVersions indicates an expected call of ListFileVersions.
+func (mr *MockDataKeeperServiceServerMockRecorder) ListFileVersions(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFileVersions", reflect.TypeOf((*MockDataKeeperServiceServer)(nil).ListFileVersions), ctx, in)
+}
+
+// ListTrash mocks base method.
+func (m *MockDataKeeperServiceServer) ListTrash(ctx context.Context, in *ListTrashRequest) (*ListTrashResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTrash", ctx, in)
+	ret0, _ := ret[0].(*ListTrashResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTrash indicates an expected call of ListTrash.
+func (mr *MockDataKeeperServiceServerMockRecorder) ListTrash(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrash", reflect.TypeOf((*MockDataKeeperServiceServer)(nil).ListTrash), ctx, in)
+}
+
+// MoveFile mocks base method.
+func (m *MockDataKeeperServiceServer) MoveFile(ctx context.Context, in *MoveFileRequest) (*UploadStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveFile", ctx, in)
+	ret0, _ := ret[0].(*UploadStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveFile indicates an expected call of MoveFile.
+func (mr *MockDataKeeperServiceServerMockRecorder) MoveFile(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveFile", reflect.TypeOf((*MockDataKeeperServiceServer)(nil).MoveFile), ctx, in)
+}
+
+// RenameFile mocks base method.
+func (m *MockDataKeeperServiceServer) RenameFile(ctx context.Context, in *RenameFileRequest) (*UploadStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameFile", ctx, in)
+	ret0, _ := ret[0].(*UploadStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenameFile indicates an expected call of RenameFile.
+func (mr *MockDataKeeperServiceServerMockRecorder) RenameFile(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameFile", reflect.TypeOf((*MockDataKeeperServiceServer)(nil).RenameFile), ctx, in)
+}
+
+// RestoreDataRevision mocks base method.
+func (m *MockDataKeeperServiceServer) RestoreDataRevision(ctx context.Context, in *RestoreDataRevisionRequest) (*UploadStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreDataRevision", ctx, in)
+	ret0, _ := ret[0].(*UploadStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreDataRevision indicates an expected call of RestoreDataRevision.
+func (mr *MockDataKeeperServiceServerMockRecorder) RestoreDataRevision(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreDataRevision", reflect.TypeOf((*MockDataKeeperServiceServer)(nil).RestoreDataRevision), ctx, in)
+}
+
+// RestoreFileVersion mocks base method.
+func (m *MockDataKeeperServiceServer) RestoreFileVersion(ctx context.Context, in *RestoreFileVersionRequest) (*UploadStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreFileVersion", ctx, in)
+	ret0, _ := ret[0].(*UploadStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreFileVersion indicates an expected call of RestoreFileVersion.
+func (mr *MockDataKeeperServiceServerMockRecorder) RestoreFileVersion(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreFileVersion", reflect.TypeOf((*MockDataKeeperServiceServer)(nil).RestoreFileVersion), ctx, in)
+}
+
+// RestoreFromTrash mocks base method.
+func (m *MockDataKeeperServiceServer) RestoreFromTrash(ctx context.Context, in *RestoreFromTrashRequest) (*UploadStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreFromTrash", ctx, in)
+	ret0, _ := ret[0].(*UploadStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreFromTrash indicates an expected call of RestoreFromTrash.
+func (mr *MockDataKeeperServiceServerMockRecorder) RestoreFromTrash(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreFromTrash", reflect.TypeOf((*MockDataKeeperServiceServer)(nil).RestoreFromTrash), ctx, in)
+}
+
 // SaveData mocks base method.
 func (m *MockDataKeeperServiceServer) SaveData(ctx context.Context, in *SaveDataRequest) (*UploadStatus, error) {
 	m.ctrl.T.Helper()
@@ -800,6 +1903,65 @@ func (mr *MockDataKeeperServiceServerMockRecorder) SaveData(ctx, in interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveData", reflect.TypeOf((*MockDataKeeperServiceServer)(nil).SaveData), ctx, in)
 }
 
+// Sync mocks base method.
+func (m *MockDataKeeperServiceServer) Sync(ctx context.Context, in *SyncRequest) (*SyncResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sync", ctx, in)
+	ret0, _ := ret[0].(*SyncResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Sync indicates an expected call of Sync.
+func (mr *MockDataKeeperServiceServerMockRecorder) Sync(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sync", reflect.TypeOf((*MockDataKeeperServiceServer)(nil).Sync), ctx, in)
+}
+
+// UpdateData mocks base method.
+func (m *MockDataKeeperServiceServer) UpdateData(ctx context.Context, in *UpdateDataRequest) (*UploadStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateData", ctx, in)
+	ret0, _ := ret[0].(*UploadStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateData indicates an expected call of UpdateData.
+func (mr *MockDataKeeperServiceServerMockRecorder) UpdateData(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateData", reflect.TypeOf((*MockDataKeeperServiceServer)(nil).UpdateData), ctx, in)
+}
+
+// UpdateFileMeta mocks base method.
+func (m *MockDataKeeperServiceServer) UpdateFileMeta(ctx context.Context, in *UpdateFileMetaRequest) (*UploadStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFileMeta", ctx, in)
+	ret0, _ := ret[0].(*UploadStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateFileMeta indicates an expected call of UpdateFileMeta.
+func (mr *MockDataKeeperServiceServerMockRecorder) UpdateFileMeta(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFileMeta", reflect.TypeOf((*MockDataKeeperServiceServer)(nil).UpdateFileMeta), ctx, in)
+}
+
+// UploadChunk mocks base method.
+func (m *MockDataKeeperServiceServer) UploadChunk(server DataKeeperService_UploadChunkServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadChunk", server)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UploadChunk indicates an expected call of UploadChunk.
+func (mr *MockDataKeeperServiceServerMockRecorder) UploadChunk(server interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadChunk", reflect.TypeOf((*MockDataKeeperServiceServer)(nil).UploadChunk), server)
+}
+
 // UploadFile mocks base method.
 func (m *MockDataKeeperServiceServer) UploadFile(server DataKeeperService_UploadFileServer) error {
 	m.ctrl.T.Helper()
@@ -813,3 +1975,17 @@ func (mr *MockDataKeeperServiceServerMockRecorder) UploadFile(server interface{}
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadFile", reflect.TypeOf((*MockDataKeeperServiceServer)(nil).UploadFile), server)
 }
+
+// WatchChanges mocks base method.
+func (m *MockDataKeeperServiceServer) WatchChanges(blob *WatchChangesRequest, server DataKeeperService_WatchChangesServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchChanges", blob, server)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WatchChanges indicates an expected call of WatchChanges.
+func (mr *MockDataKeeperServiceServerMockRecorder) WatchChanges(blob, server interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchChanges", reflect.TypeOf((*MockDataKeeperServiceServer)(nil).WatchChanges), blob, server)
+}
@@ -54,6 +54,86 @@ func (mr *MockUserServiceClientMockRecorder) Authenticate(ctx, in interface{}, o
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockUserServiceClient)(nil).Authenticate), varargs...)
 }
 
+// CreateSession mocks base method.
+func (m *MockUserServiceClient) CreateSession(ctx context.Context, in *CreateSessionRequest, opts ...grpc.CallOption) (*CreateSessionResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateSession", varargs...)
+	ret0, _ := ret[0].(*CreateSessionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSession indicates an expected call of CreateSession.
+func (mr *MockUserServiceClientMockRecorder) CreateSession(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockUserServiceClient)(nil).CreateSession), varargs...)
+}
+
+// EndSession mocks base method.
+func (m *MockUserServiceClient) EndSession(ctx context.Context, in *EndSessionRequest, opts ...grpc.CallOption) (*EndSessionResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "EndSession", varargs...)
+	ret0, _ := ret[0].(*EndSessionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EndSession indicates an expected call of EndSession.
+func (mr *MockUserServiceClientMockRecorder) EndSession(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EndSession", reflect.TypeOf((*MockUserServiceClient)(nil).EndSession), varargs...)
+}
+
+// ListSessions mocks base method.
+func (m *MockUserServiceClient) ListSessions(ctx context.Context, in *ListSessionsRequest, opts ...grpc.CallOption) (*ListSessionsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListSessions", varargs...)
+	ret0, _ := ret[0].(*ListSessionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSessions indicates an expected call of ListSessions.
+func (mr *MockUserServiceClientMockRecorder) ListSessions(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSessions", reflect.TypeOf((*MockUserServiceClient)(nil).ListSessions), varargs...)
+}
+
+// RefreshSession mocks base method.
+func (m *MockUserServiceClient) RefreshSession(ctx context.Context, in *RefreshSessionRequest, opts ...grpc.CallOption) (*RefreshSessionResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RefreshSession", varargs...)
+	ret0, _ := ret[0].(*RefreshSessionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefreshSession indicates an expected call of RefreshSession.
+func (mr *MockUserServiceClientMockRecorder) RefreshSession(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshSession", reflect.TypeOf((*MockUserServiceClient)(nil).RefreshSession), varargs...)
+}
+
 // Register mocks base method.
 func (m *MockUserServiceClient) Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*RegisterResponse, error) {
 	m.ctrl.T.Helper()
@@ -112,6 +192,66 @@ func (mr *MockUserServiceServerMockRecorder) Authenticate(ctx, in interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockUserServiceServer)(nil).Authenticate), ctx, in)
 }
 
+// CreateSession mocks base method.
+func (m *MockUserServiceServer) CreateSession(ctx context.Context, in *CreateSessionRequest) (*CreateSessionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSession", ctx, in)
+	ret0, _ := ret[0].(*CreateSessionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSession indicates an expected call of CreateSession.
+func (mr *MockUserServiceServerMockRecorder) CreateSession(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockUserServiceServer)(nil).CreateSession), ctx, in)
+}
+
+// EndSession mocks base method.
+func (m *MockUserServiceServer) EndSession(ctx context.Context, in *EndSessionRequest) (*EndSessionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EndSession", ctx, in)
+	ret0, _ := ret[0].(*EndSessionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EndSession indicates an expected call of EndSession.
+func (mr *MockUserServiceServerMockRecorder) EndSession(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EndSession", reflect.TypeOf((*MockUserServiceServer)(nil).EndSession), ctx, in)
+}
+
+// ListSessions mocks base method.
+func (m *MockUserServiceServer) ListSessions(ctx context.Context, in *ListSessionsRequest) (*ListSessionsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSessions", ctx, in)
+	ret0, _ := ret[0].(*ListSessionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSessions indicates an expected call of ListSessions.
+func (mr *MockUserServiceServerMockRecorder) ListSessions(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSessions", reflect.TypeOf((*MockUserServiceServer)(nil).ListSessions), ctx, in)
+}
+
+// RefreshSession mocks base method.
+func (m *MockUserServiceServer) RefreshSession(ctx context.Context, in *RefreshSessionRequest) (*RefreshSessionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshSession", ctx, in)
+	ret0, _ := ret[0].(*RefreshSessionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefreshSession indicates an expected call of RefreshSession.
+func (mr *MockUserServiceServerMockRecorder) RefreshSession(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshSession", reflect.TypeOf((*MockUserServiceServer)(nil).RefreshSession), ctx, in)
+}
+
 // Register mocks base method.
 func (m *MockUserServiceServer) Register(ctx context.Context, in *RegisterRequest) (*RegisterResponse, error) {
 	m.ctrl.T.Helper()
@@ -1,13 +1,21 @@
 package client
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 
+	pbsrv "github.com/Arcadian-Sky/datakkeeper/gen/proto/api/service/v1"
 	"github.com/Arcadian-Sky/datakkeeper/internal/client"
 	"github.com/Arcadian-Sky/datakkeeper/internal/model"
 	"github.com/Arcadian-Sky/datakkeeper/internal/settings"
+	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 	"github.com/sirupsen/logrus"
 	"github.com/sqweek/dialog"
@@ -30,6 +38,10 @@ type Data struct {
 	sendLoginPassFormButtons *FormRegister
 	sendCardForm             *tview.Form
 	sendCardFormButtons      *FormRegister
+	sendNoteForm             *tview.Form
+	sendNoteFormButtons      *FormRegister
+	sendBinaryForm           *tview.Form
+	sendBinaryFormButtons    *FormRegister
 	list                     *tview.List
 	move                     *tview.List
 }
@@ -46,6 +58,13 @@ type App struct {
 	Conn                *grpc.ClientConn
 	storage             *client.MemStorage
 	log                 *logrus.Logger
+
+	// fileFolder - открытая в списке файлов папка, пустая - корень
+	fileFolder string
+
+	// refreshList перечитывает открытый список, если событие к нему относится
+	refreshList func(ev model.ChangeEvent)
+	stopWatch   context.CancelFunc
 }
 
 func NewEmptyApp() *App {
@@ -71,6 +90,10 @@ func NewEmptyApp() *App {
 			sendLoginPassFormButtons: &FormRegister{},
 			sendCardForm:             tview.NewForm(),
 			sendCardFormButtons:      &FormRegister{},
+			sendNoteForm:             tview.NewForm(),
+			sendNoteFormButtons:      &FormRegister{},
+			sendBinaryForm:           tview.NewForm(),
+			sendBinaryFormButtons:    &FormRegister{},
 		},
 	}
 }
@@ -107,7 +130,8 @@ func (app *App) initPersonInterfaces() {
 	app.person.authForm.SetBorder(true).SetTitle("Authorize").SetTitleAlign(tview.AlignLeft)
 	app.person.authForm.
 		AddInputField("Login", "", 20, nil, nil).
-		AddPasswordField("Password", "", 10, '*', nil)
+		AddPasswordField("Password", "", 10, '*', nil).
+		AddPasswordField("Master key", "", 20, '*', nil)
 
 	app.addAction(app.person.authForm, app.person.authFormButtons, "Save", app.actionAuth)
 	app.addAction(app.person.authForm, app.person.authFormButtons, "Switch to Register", app.actionSwitchToRegister)
@@ -117,7 +141,8 @@ func (app *App) initPersonInterfaces() {
 	app.person.registerForm.SetBorder(true).SetTitle("Enter some data").SetTitleAlign(tview.AlignLeft)
 	app.person.registerForm.
 		AddInputField("Login", "", 20, nil, nil).
-		AddPasswordField("Password", "", 10, '*', nil)
+		AddPasswordField("Password", "", 10, '*', nil).
+		AddPasswordField("Master key", "", 20, '*', nil)
 
 	app.addAction(app.person.registerForm, app.person.registerFormButtons, "Save", app.actionSaveRegisterForm)
 	app.addAction(app.person.registerForm, app.person.registerFormButtons, "Switch to Authorize", app.actionSwitchToAuth)
@@ -138,6 +163,8 @@ func (app *App) actionAuth() {
 		return
 	}
 	app.storage.Login = login
+	app.setMasterKey(app.person.authForm)
+	app.startWatch()
 	app.actionSwitchToMain()
 }
 
@@ -152,9 +179,21 @@ func (app *App) actionSaveRegisterForm() {
 		return
 	}
 	app.storage.Login = login
+	app.setMasterKey(app.person.registerForm)
+	app.startWatch()
 	app.actionSwitchToMain()
 }
 
+// Ключ шифрования получается из мастер-пароля и логина, сам пароль на сервер не передается
+func (app *App) setMasterKey(form *tview.Form) {
+	field, ok := form.GetFormItemByLabel("Master key").(*tview.InputField)
+	if !ok || field.GetText() == "" {
+		app.log.Info("Master key is not set, secrets can't be saved")
+		return
+	}
+	app.storage.SetMasterKey(field.GetText(), app.storage.MasterKeyDir)
+}
+
 func (app *App) actionSwitchToAuth() {
 	app.logView.Clear()
 	app.pages.SwitchToPage("auth")
@@ -195,10 +234,55 @@ func (app *App) actionSwitchToFileForm() {
 }
 
 func (app *App) actionSwitchToPerson() {
+	app.showUsage()
 	app.pages.SwitchToPage("person")
 	app.log.Trace("SwitchToPage person")
 }
 
+// showUsage fills the main page with used space and quotas of the user
+func (app *App) showUsage() {
+	form := app.person.Form
+	form.Clear(true)
+	used, quota, err := app.client.GetUsage()
+	if err != nil {
+		app.log.Info("Error client GetUsage: ", err)
+		return
+	}
+	count := func(n int64) string { return strconv.FormatInt(n, 10) }
+	form.
+		AddTextView("Storage", usageBar(used.Bytes, quota.Bytes, formatBytes), 0, 1, false, false).
+		AddTextView("Files", usageBar(used.Files, quota.Files, count), 0, 1, false, false).
+		AddTextView("Records", usageBar(used.Records, quota.Records, count), 0, 1, false, false)
+}
+
+// usageBarWidth - ширина полосы заполнения квоты в символах
+const usageBarWidth = 20
+
+// usageBar shows used part of the limit as [####----] used of limit, limit 0 - без ограничения
+func usageBar(used, limit int64, format func(int64) string) string {
+	if limit <= 0 {
+		return format(used) + " (no limit)"
+	}
+	filled := int(min(used*usageBarWidth/limit, usageBarWidth))
+	return fmt.Sprintf("[%s%s] %s of %s (%d%%)",
+		strings.Repeat("#", filled), strings.Repeat("-", usageBarWidth-filled),
+		format(used), format(limit), used*100/limit)
+}
+
+// formatBytes shows size in binary units: 1.5 KiB, 20.0 MiB
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return strconv.FormatInt(n, 10) + " B"
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
 func (app *App) actionSwitchToLogpassForm() {
 	app.pages.SwitchToPage("loginpassform")
 	app.log.Trace("SwitchToPage loginpassform")
@@ -209,18 +293,32 @@ func (app *App) actionSwitchToCardForm() {
 	app.log.Trace("SwitchToPage cardform")
 }
 
+func (app *App) actionSwitchToNoteForm() {
+	app.pages.SwitchToPage("noteform")
+	app.log.Trace("SwitchToPage noteform")
+}
+
+func (app *App) actionSwitchToBinaryForm() {
+	app.pages.SwitchToPage("binaryform")
+	app.log.Trace("SwitchToPage binaryform")
+}
+
 func (app *App) actionSwitchToSettings() {
 	app.pages.SwitchToPage("settings")
 	app.log.Trace("SwitchToPage settings")
 }
 
 func (app *App) appActionQuit() {
+	if app.stopWatch != nil {
+		app.stopWatch()
+	}
 	app.tapp.Stop()
 	app.log.Trace("Switch stop app")
 }
 
 func (app *App) appActionLoadFiles() {
 	app.logView.Clear()
+	app.fileFolder = ""
 	err := app.loadFiles()
 	if err != nil {
 		app.log.Info("Error loading data: ", err)
@@ -248,7 +346,7 @@ func (app *App) appActionSelectFiles(loadForm *tview.Form) func() {
 		}
 
 		// Устанавливаем выбранный файл в InputField
-		loadForm.GetFormItem(0).(*tview.InputField).SetText(filename)
+		loadForm.GetFormItemByLabel("File Path").(*tview.InputField).SetText(filename)
 	}
 }
 
@@ -257,8 +355,9 @@ func (app *App) appActionSendFiles(loadForm *tview.Form) func() {
 		app.logView.Clear()
 		app.log.Info("Sending data...")
 		filePath := loadForm.GetFormItem(0).(*tview.InputField).GetText()
+		folder := strings.Trim(loadForm.GetFormItemByLabel("Folder").(*tview.InputField).GetText(), " "+model.FolderSeparator)
 		app.log.Info("File path: ", filePath)
-		if err := app.client.UploadFile(filePath); err != nil {
+		if err := app.client.UploadFile(filePath, folder); err != nil {
 			app.log.Info(fmt.Println("Error uploading file:", err))
 		}
 	}
@@ -277,20 +376,177 @@ func (app *App) appActionSendLoginPass() {
 }
 
 func (app *App) appActionSendCard() {
-	domain := app.data.sendCardForm.GetFormItem(0).(*tview.InputField).GetText()
-	card := app.data.sendCardForm.GetFormItem(1).(*tview.InputField).GetText()
+	card := model.Data{
+		Title: app.data.sendCardForm.GetFormItemByLabel("Title").(*tview.InputField).GetText(),
+	}
+	if err := app.readCardForm(app.data.sendCardForm, &card); err != nil {
+		app.log.Info("Error card form: ", err)
+		return
+	}
 
-	app.log.Info(fmt.Printf("Title: %s, Card: %s\n", domain, card))
-	if err := app.client.SaveCard(domain, card); err != nil {
-		app.log.Info(fmt.Println("Error saving card:", err))
+	app.log.Info("Title: ", card.Title, ", Card: ", card.CardBrand, " *", lastDigits(card.Card))
+	if err := app.client.SaveCard(card); err != nil {
+		app.log.Info("Error client SaveCard: ", err)
 	}
 }
 
+func (app *App) appActionSendNote() {
+	title := app.data.sendNoteForm.GetFormItemByLabel("Title").(*tview.InputField).GetText()
+	note := app.data.sendNoteForm.GetFormItemByLabel("Note").(*tview.TextArea).GetText()
+
+	app.log.Info("Title: ", title, ", Note: ", len(note), " chars")
+	if err := app.client.SaveNote(title, note); err != nil {
+		app.log.Info("Error client SaveNote: ", err)
+	}
+}
+
+func (app *App) appActionSendBinary() {
+	title := app.data.sendBinaryForm.GetFormItemByLabel("Title").(*tview.InputField).GetText()
+	filePath := app.data.sendBinaryForm.GetFormItemByLabel("File Path").(*tview.InputField).GetText()
+
+	data, err := readBinary(filePath)
+	if err != nil {
+		app.log.Info("Error reading binary: ", err)
+		return
+	}
+	app.log.Info("Title: ", title, ", Size: ", len(data), " bytes")
+	if err := app.client.SaveBinary(title, data); err != nil {
+		app.log.Info("Error client SaveBinary: ", err)
+	}
+}
+
+// readBinary reads file, which is small enough to be stored as a binary record
+func readBinary(filePath string) ([]byte, error) {
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() > model.MaxBinarySize {
+		return nil, model.ErrBinaryTooLarge
+	}
+	return os.ReadFile(filePath)
+}
+
 func (app *App) checkInputCardField(textToCheck string, lastChar rune) bool {
 	// Allow only numbers and spaces in the card number field
 	return (lastChar >= '0' && lastChar <= '9') || lastChar == ' '
 }
 
+func (app *App) checkInputDigitsField(textToCheck string, lastChar rune) bool {
+	return lastChar >= '0' && lastChar <= '9'
+}
+
+func (app *App) checkInputExpiryField(textToCheck string, lastChar rune) bool {
+	return (lastChar >= '0' && lastChar <= '9') || lastChar == '/'
+}
+
+// addCardFields adds card fields to the form, brand is detected while the number is typed
+func (app *App) addCardFields(form *tview.Form, item model.Data) {
+	expiry := ""
+	if item.CardExpMonth != 0 {
+		expiry = fmt.Sprintf("%02d/%02d", item.CardExpMonth, item.CardExpYear%100)
+	}
+	brand := tview.NewTextView().
+		SetLabel("Brand").
+		SetSize(1, 0).
+		SetText(model.CardBrand(item.Card))
+
+	form.
+		AddInputField("Card Number", item.Card, 23, app.checkInputCardField, func(text string) {
+			brand.SetText(model.CardBrand(text))
+		}).
+		AddFormItem(brand).
+		AddInputField("Holder", item.CardHolder, 26, nil, nil).
+		AddInputField("Expiry (MM/YY)", expiry, 7, app.checkInputExpiryField, nil).
+		AddPasswordField("CVV", item.CardCVV, 4, '*', nil).
+		AddInputField("Bank", item.CardBank, 26, nil, nil).
+		AddPasswordField("PIN", item.CardPIN, 12, '*', nil)
+	form.GetFormItemByLabel("CVV").(*tview.InputField).SetAcceptanceFunc(app.checkInputDigitsField)
+	form.GetFormItemByLabel("PIN").(*tview.InputField).SetAcceptanceFunc(app.checkInputDigitsField)
+}
+
+// readCardForm reads and validates fields added by addCardFields, expired card is only a warning
+func (app *App) readCardForm(form *tview.Form, card *model.Data) error {
+	card.Card = model.NormalizeCardNumber(form.GetFormItemByLabel("Card Number").(*tview.InputField).GetText())
+	card.CardHolder = form.GetFormItemByLabel("Holder").(*tview.InputField).GetText()
+	card.CardCVV = form.GetFormItemByLabel("CVV").(*tview.InputField).GetText()
+	card.CardBank = form.GetFormItemByLabel("Bank").(*tview.InputField).GetText()
+	card.CardPIN = form.GetFormItemByLabel("PIN").(*tview.InputField).GetText()
+
+	var err error
+	card.CardExpMonth, card.CardExpYear, err = model.ParseCardExpiry(form.GetFormItemByLabel("Expiry (MM/YY)").(*tview.InputField).GetText())
+	if err != nil {
+		return err
+	}
+	if err := model.ValidateCard(*card); err != nil {
+		return err
+	}
+	card.CardBrand = model.CardBrand(card.Card)
+	if model.CardExpired(card.CardExpMonth, card.CardExpYear, time.Now()) {
+		app.log.Info("Warning: card is expired")
+	}
+
+	return nil
+}
+
+// addMetaFields adds tags as comma separated list and metadata as key=value lines
+func (app *App) addMetaFields(form *tview.Form, meta map[string]string, tags []string) {
+	form.
+		AddInputField("Tags", strings.Join(tags, ", "), 40, nil, nil).
+		AddTextArea("Meta", formatMeta(meta), 40, 4, 0, nil)
+}
+
+// readMetaForm reads and validates fields added by addMetaFields
+func (app *App) readMetaForm(form *tview.Form) (map[string]string, []string, error) {
+	tags := model.NormalizeTags(strings.Split(form.GetFormItemByLabel("Tags").(*tview.InputField).GetText(), ","))
+	meta, err := parseMeta(form.GetFormItemByLabel("Meta").(*tview.TextArea).GetText())
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := model.ValidateMeta(meta, tags); err != nil {
+		return nil, nil, err
+	}
+	return meta, tags, nil
+}
+
+// formatMeta returns metadata as sorted key=value lines
+func formatMeta(meta map[string]string) string {
+	lines := make([]string, 0, len(meta))
+	for k, v := range meta {
+		lines = append(lines, k+"="+v)
+	}
+	sort.Strings(lines)
+	return strings.Join(lines, "\n")
+}
+
+// parseMeta reads key=value lines, empty lines are skipped
+func parseMeta(text string) (map[string]string, error) {
+	var meta map[string]string
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		k, v, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("%w: line %q is not key=value", model.ErrMetaInvalid, line)
+		}
+		if meta == nil {
+			meta = make(map[string]string)
+		}
+		meta[strings.TrimSpace(k)] = strings.TrimSpace(v)
+	}
+	return meta, nil
+}
+
+// lastDigits returns last 4 digits of card number for logs
+func lastDigits(number string) string {
+	if len(number) < 4 {
+		return number
+	}
+	return number[len(number)-4:]
+}
+
 // Инициализация интерфейсов работы с данными
 func (app *App) initDataInterfaces() {
 	// Создаем интерфейс для отображения данных
@@ -305,7 +561,8 @@ func (app *App) initDataInterfaces() {
 		SetBorder(true)
 
 	app.data.loadForm.
-		AddInputField("File Path", "", 40, nil, nil)
+		AddInputField("File Path", "", 40, nil, nil).
+		AddInputField("Folder", "", 40, nil, nil)
 
 	app.addAction(app.data.loadForm, app.data.loadFormButtons, "Select File", app.appActionSelectFiles(app.data.loadForm))
 	app.addAction(app.data.loadForm, app.data.loadFormButtons, "Send", app.appActionSendFiles(app.data.loadForm))
@@ -328,11 +585,32 @@ func (app *App) initDataInterfaces() {
 		SetBorder(true)
 
 	app.data.sendCardForm.
-		AddInputField("Title", "", 20, nil, nil).
-		AddInputField("Card Number", "", 20, app.checkInputCardField, nil)
+		AddInputField("Title", "", 20, nil, nil)
+	app.addCardFields(app.data.sendCardForm, model.Data{})
 	app.addAction(app.data.sendCardForm, app.data.sendCardFormButtons, "Submit", app.appActionSendCard)
 	app.addAction(app.data.sendCardForm, app.data.sendCardFormButtons, "Cancel", app.actionSwitchToMain)
 
+	app.data.sendNoteForm.
+		SetTitle("Send Secure Note").
+		SetBorder(true)
+
+	app.data.sendNoteForm.
+		AddInputField("Title", "", 20, nil, nil).
+		AddTextArea("Note", "", 40, 6, 0, nil)
+	app.addAction(app.data.sendNoteForm, app.data.sendNoteFormButtons, "Submit", app.appActionSendNote)
+	app.addAction(app.data.sendNoteForm, app.data.sendNoteFormButtons, "Cancel", app.actionSwitchToMain)
+
+	app.data.sendBinaryForm.
+		SetTitle("Send Binary Entry").
+		SetBorder(true)
+
+	app.data.sendBinaryForm.
+		AddInputField("Title", "", 20, nil, nil).
+		AddInputField("File Path", "", 40, nil, nil)
+	app.addAction(app.data.sendBinaryForm, app.data.sendBinaryFormButtons, "Select File", app.appActionSelectFiles(app.data.sendBinaryForm))
+	app.addAction(app.data.sendBinaryForm, app.data.sendBinaryFormButtons, "Submit", app.appActionSendBinary)
+	app.addAction(app.data.sendBinaryForm, app.data.sendBinaryFormButtons, "Cancel", app.actionSwitchToMain)
+
 }
 
 func (app *App) iniSettings() {
@@ -361,6 +639,10 @@ func (app *App) initPages() {
 		AddItem("Save file", "Send file", '3', app.actionSwitchToFileForm).
 		AddItem("Save auth data", "Send data login and password for domain", '4', app.actionSwitchToLogpassForm).
 		AddItem("Save card data", "Send credit card number", '5', app.actionSwitchToCardForm).
+		AddItem("Save note", "Send secure text note", '6', app.actionSwitchToNoteForm).
+		AddItem("Save binary", "Send small binary data", '7', app.actionSwitchToBinaryForm).
+		AddItem("Sessions", "Signed in devices and logout", '8', app.appActionLoadSessions).
+		AddItem("Trash", "Restore deleted data and files", '9', app.appActionLoadTrash).
 		AddItem("Settings", "", 's', app.actionSwitchToSettings).
 		AddItem("Quit", "Close application", 'q', app.appActionQuit)
 
@@ -377,6 +659,8 @@ func (app *App) initPages() {
 	app.pages.AddPage("fileform", app.data.loadForm, true, false)
 	app.pages.AddPage("loginpassform", app.data.sendLoginPassForm, true, false)
 	app.pages.AddPage("cardform", app.data.sendCardForm, true, false)
+	app.pages.AddPage("noteform", app.data.sendNoteForm, true, false)
+	app.pages.AddPage("binaryform", app.data.sendBinaryForm, true, false)
 	app.pages.AddPage("settings", app.settingsForm, true, false)
 
 	// Check if token is valid
@@ -414,91 +698,423 @@ func isTokenValid() bool {
 
 // Getting data of type data
 func (app *App) loadData() error {
-	data, err := app.client.GetDataList()
+	return app.loadDataPage("")
+}
+
+// loadDataPage loads first page of records matching the search string
+func (app *App) loadDataPage(search string) error {
+	opts := parseListSearch(search, dataTypeNames)
+	data, next, err := app.client.ListData(opts)
 	if err != nil {
-		return fmt.Errorf("error client GetDataList: %v", err)
+		return fmt.Errorf("error client ListData: %v", err)
 	}
 
 	// Обновление интерфейса на основе полученных данных
-	app.updateDatalistPage(data)
+	app.updateDatalistPage(search, data, next)
+	app.refreshList = func(ev model.ChangeEvent) {
+		if ev.FileName != "" {
+			return
+		}
+		if err := app.loadDataPage(search); err != nil {
+			app.log.Info("Error loading data: ", err)
+		}
+	}
 	return nil
 }
 
 // Render list of type data
-func (app *App) updateDatalistPage(data []model.Data) {
-
-	list := tview.NewList()
-	// Создание кнопки "Назад"
-	list.AddItem("Back", "", 'q', app.actionSwitchToDataListWithClear)
+func (app *App) updateDatalistPage(search string, data []model.Data, next string) {
+	opts := parseListSearch(search, dataTypeNames)
+	app.showListPage(search, dataItems(app, data), next,
+		func(text string) {
+			if err := app.loadDataPage(text); err != nil {
+				app.log.Info("Error loading data: ", err)
+			}
+		},
+		func(token string) ([]listItem, string, error) {
+			opts.PageToken = token
+			data, next, err := app.client.ListData(opts)
+			return dataItems(app, data), next, err
+		})
+}
 
-	// Заполнение таблицы данными
+func dataItems(app *App, data []model.Data) []listItem {
+	items := make([]listItem, 0, len(data))
 	for _, item := range data {
-		list.AddItem(item.Title, item.Type, 0, func() {
+		secondary := item.Type
+		if len(item.Tags) > 0 {
+			secondary += " #" + strings.Join(item.Tags, " #")
+		}
+		items = append(items, listItem{main: item.Title, secondary: secondary, selected: func() {
 			app.logView.Clear()
 			// Переход к форме с действиями
 			app.createDetailForm(item)
-		})
-
+		}})
 	}
-
-	// Добавление Flex как страницы
-	app.pages.AddPage("datalistmove", list, true, false)
-	app.pages.SwitchToPage("datalistmove")
+	return items
 }
 
 // Getting data with type files
 func (app *App) loadFiles() error {
-	data, err := app.client.GetFileList()
+	return app.loadFilesPage("")
+}
+
+// loadFilesPage loads first page of files matching the search string
+func (app *App) loadFilesPage(search string) error {
+	data, next, err := app.client.ListFiles(app.fileListOptions(search))
 	if err != nil {
 		return fmt.Errorf("error creating stream: %v", err)
 	}
 
 	// Обновление интерфейса на основе полученных данных
-	app.updateFileDatalistPage(data)
+	app.updateFileDatalistPage(search, data, next)
+	app.refreshList = func(ev model.ChangeEvent) {
+		if ev.DataID != 0 {
+			return
+		}
+		if err := app.loadFilesPage(search); err != nil {
+			app.log.Info("Error loading data: ", err)
+		}
+	}
 	return nil
 }
 
+// fileListOptions returns options of the file list: without search the open folder is shown,
+// поиск идет по всем папкам
+func (app *App) fileListOptions(search string) client.ListOptions {
+	opts := parseListSearch(search, nil)
+	if strings.TrimSpace(search) == "" {
+		opts.Browse = true
+		opts.Parent = app.fileFolder
+	}
+	return opts
+}
+
+// reloadFiles shows the open folder again
+func (app *App) reloadFiles() {
+	if err := app.loadFilesPage(""); err != nil {
+		app.log.Info("Error loading data: ", err)
+	}
+}
+
 // List of data with type files
-func (app *App) updateFileDatalistPage(data []model.FileItem) {
+func (app *App) updateFileDatalistPage(search string, data []model.FileItem, next string) {
+	opts := app.fileListOptions(search)
+	items := fileItems(app, data, opts.Parent)
+	if opts.Browse {
+		items = append(app.folderItems(), items...)
+	}
+	app.showListPage(search, items, next,
+		func(text string) {
+			if err := app.loadFilesPage(text); err != nil {
+				app.log.Info("Error loading data: ", err)
+			}
+		},
+		func(token string) ([]listItem, string, error) {
+			opts.PageToken = token
+			data, next, err := app.client.ListFiles(opts)
+			return fileItems(app, data, opts.Parent), next, err
+		})
+}
+
+// fileItems makes list rows of files, names are shown relative to folder.
+// Папка открывается при выборе, ее имя заканчивается "/".
+func fileItems(app *App, data []model.FileItem, folder string) []listItem {
+	items := make([]listItem, 0, len(data))
+	for _, item := range data {
+		name := strings.TrimPrefix(item.Name, model.FolderPrefix(folder))
+		if item.IsFolder {
+			items = append(items, listItem{main: name + model.FolderSeparator, secondary: "folder", selected: app.appActionOpenFolder(item.Name)})
+			continue
+		}
+		secondary := item.Desc
+		if len(item.Tags) > 0 {
+			secondary = strings.TrimSpace(secondary + " #" + strings.Join(item.Tags, " #"))
+		}
+		items = append(items, listItem{main: name, secondary: secondary, selected: func() {
+			app.logView.Clear()
+			// Переход к форме с действиями
+			app.createMoveForm(item)
+		}})
+	}
+	return items
+}
 
+// folderItems - действия с открытой папкой над ее содержимым
+func (app *App) folderItems() []listItem {
+	var items []listItem
+	if app.fileFolder != "" {
+		folder := model.FileItem{Name: app.fileFolder, IsFolder: true}
+		items = append(items,
+			listItem{main: "..", secondary: model.FolderSeparator + app.fileFolder, selected: app.appActionOpenFolder(model.FolderOf(app.fileFolder))},
+			listItem{main: "Rename folder", selected: app.appActionRenameFile(folder)},
+			listItem{main: "Move folder", selected: app.appActionMoveFile(folder)},
+		)
+	}
+	return append(items,
+		listItem{main: "New folder", selected: app.appActionNewFolder},
+		listItem{main: "Upload here", selected: app.appActionUploadHere},
+	)
+}
+
+func (app *App) appActionOpenFolder(folder string) func() {
+	return func() {
+		app.logView.Clear()
+		app.fileFolder = folder
+		app.reloadFiles()
+	}
+}
+
+func (app *App) appActionNewFolder() {
+	app.logView.Clear()
+	app.createPathForm("New folder in /"+app.fileFolder, "Name", "", func(name string) {
+		path := model.JoinPath(app.fileFolder, name)
+		if err := app.client.CreateFolder(path); err != nil {
+			app.log.Info("Error client CreateFolder: ", err)
+			return
+		}
+		app.log.Info("Created folder: ", path, "\n")
+		app.reloadFiles()
+	})
+}
+
+// appActionUploadHere opens the upload form with the open folder
+func (app *App) appActionUploadHere() {
+	app.logView.Clear()
+	app.data.loadForm.GetFormItemByLabel("Folder").(*tview.InputField).SetText(app.fileFolder)
+	app.actionSwitchToFileForm()
+}
+
+// createPathForm asks for a file name or folder, Cancel returns to the file list
+func (app *App) createPathForm(title, label, value string, submit func(string)) {
+	form := tview.NewForm()
+	formRegister := &FormRegister{}
+	form.SetTitle(title).SetBorder(true)
+	form.AddInputField(label, value, 40, nil, nil)
+	app.addAction(form, formRegister, "Save", func() {
+		app.logView.Clear()
+		submit(strings.TrimSpace(form.GetFormItemByLabel(label).(*tview.InputField).GetText()))
+	})
+	app.addAction(form, formRegister, "Cancel", func() { app.pages.SwitchToPage("datalistmove") })
+
+	app.pages.AddPage("filepathform", form, true, false)
+	app.pages.SwitchToPage("filepathform")
+}
+
+// listItem - строка постраничного списка
+type listItem struct {
+	main      string
+	secondary string
+	selected  func()
+}
+
+const listMoreText = "Load more"
+
+// showListPage shows the list with a search box above it, '/' moves focus to the box and Enter starts new search.
+// Last item loads the next page while there is a next page token.
+func (app *App) showListPage(search string, items []listItem, next string, onSearch func(string), loadPage func(token string) ([]listItem, string, error)) {
 	list := tview.NewList()
 	// Создание кнопки "Назад"
 	list.AddItem("Back", "", 'q', app.actionSwitchToDataListWithClear)
 
-	// Заполнение таблицы данными
-	for _, item := range data {
-		list.AddItem(item.Name, item.Desc, 0, func() {
-			app.logView.Clear()
-			// Переход к форме с действиями
-			app.createMoveForm(item.Hash, item.Name, item.Desc)
+	var addPage func(items []listItem, next string)
+	addPage = func(items []listItem, next string) {
+		for _, it := range items {
+			list.AddItem(it.main, it.secondary, 0, it.selected)
+		}
+		if next == "" {
+			return
+		}
+		list.AddItem(listMoreText, "", 'm', func() {
+			items, following, err := loadPage(next)
+			if err != nil {
+				app.log.Info("Error loading data: ", err)
+				return
+			}
+			list.RemoveItem(list.GetItemCount() - 1)
+			addPage(items, following)
 		})
-
 	}
+	addPage(items, next)
+
+	searchField := tview.NewInputField().
+		SetLabel("Search: ").
+		SetText(search).
+		SetPlaceholder("text #tag type:name sort:title sort:-title")
+	searchField.SetDoneFunc(func(key tcell.Key) {
+		app.tapp.SetFocus(list)
+		if key == tcell.KeyEnter {
+			app.logView.Clear()
+			onSearch(searchField.GetText())
+		}
+	})
+	list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Rune() == '/' {
+			app.tapp.SetFocus(searchField)
+			return nil
+		}
+		return event
+	})
+
+	flex := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(searchField, 1, 0, false).
+		AddItem(list, 0, 1, true)
 
 	// Добавление Flex как страницы
-	app.pages.AddPage("datalistmove", list, true, false)
+	app.pages.AddPage("datalistmove", flex, true, false)
 	app.pages.SwitchToPage("datalistmove")
 }
 
+// watchRetry - пауза перед переподключением к ленте изменений
+var watchRetry = 5 * time.Second
+
+// startWatch opens the change feed of the logged in user, previous feed is closed
+func (app *App) startWatch() {
+	if app.stopWatch != nil {
+		app.stopWatch()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	app.stopWatch = cancel
+	go app.watchChanges(ctx)
+}
+
+// watchChanges keeps the change feed open until ctx is done.
+// События пропущенные за время обрыва не восстанавливаются, поэтому после переподключения список перечитывается целиком.
+func (app *App) watchChanges(ctx context.Context) {
+	reconnect := false
+	for {
+		if reconnect {
+			app.tapp.QueueUpdateDraw(func() { app.onChange(model.ChangeEvent{}) })
+		}
+		err := app.client.WatchChanges(ctx, func(ev model.ChangeEvent) {
+			app.tapp.QueueUpdateDraw(func() { app.onChange(ev) })
+		})
+		if ctx.Err() != nil {
+			return
+		}
+		app.log.Debug("Change feed is closed: ", err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(watchRetry):
+		}
+		reconnect = true
+	}
+}
+
+// onChange refreshes the open list page, pages with forms are left as is not to lose user input.
+// Empty event refreshes any list.
+func (app *App) onChange(ev model.ChangeEvent) {
+	if name, _ := app.pages.GetFrontPage(); name != "datalistmove" || app.refreshList == nil {
+		return
+	}
+	app.refreshList(ev)
+}
+
+// Короткие имена типов записей для поиска type:<name>
+var dataTypeNames = map[string]string{
+	"note":   pbsrv.DataType_DATA_TYPE_UNSPECIFIED.String(),
+	"binary": pbsrv.DataType_DATA_TYPE_TYPE_BINARY.String(),
+	"login":  pbsrv.DataType_DATA_TYPE_TYPE_LOGIN_PASSWORD.String(),
+	"card":   pbsrv.DataType_DATA_TYPE_TYPE_CREDIT_CARD.String(),
+}
+
+// parseListSearch splits search string to list options: #tag - tag filter, type:<name> - type filter,
+// sort:title and sort:-title - sort order, other words are the substring search.
+// Types are mapped by typeNames, nil map passes them as is (file extensions).
+func parseListSearch(search string, typeNames map[string]string) client.ListOptions {
+	var opts client.ListOptions
+	var words []string
+	for _, word := range strings.Fields(search) {
+		switch {
+		case strings.HasPrefix(word, "#") && len(word) > 1:
+			opts.Tags = append(opts.Tags, word[1:])
+		case strings.HasPrefix(word, "type:"):
+			name := strings.ToLower(strings.TrimPrefix(word, "type:"))
+			if typeNames != nil {
+				name = typeNames[name]
+			}
+			if name != "" {
+				opts.Types = append(opts.Types, name)
+			}
+		case word == "sort:title":
+			opts.ByTitle = true
+		case word == "sort:-title":
+			opts.ByTitle, opts.Desc = true, true
+		case word == "sort:-created":
+			opts.Desc = true
+		default:
+			words = append(words, word)
+		}
+	}
+	opts.Search = strings.Join(words, " ")
+	return opts
+}
+
 // Detail page of type file with actions
-func (app *App) createMoveForm(id string, name, desc string) {
+func (app *App) createMoveForm(item model.FileItem) {
 	// Создаем форму с действиями
 	actionForm := tview.NewForm()
 	actionFormRegister := &FormRegister{}
+	uploaded := ""
+	if !item.UploadedAt.IsZero() {
+		uploaded = item.UploadedAt.Format(time.DateTime)
+	}
 	actionForm.
-		AddTextView("ID", id, 0, 1, false, false).
-		AddTextView("Name", name, 0, 1, false, false).
-		AddTextView("Description", desc, 0, 1, false, false)
+		AddTextView("ID", item.Hash, 0, 1, false, false).
+		AddTextView("Name", item.Name, 0, 1, false, false).
+		AddTextView("Size", strconv.FormatInt(item.Size, 10), 0, 1, false, false).
+		AddTextView("Type", item.ContentType, 0, 1, false, false).
+		AddTextView("Uploaded", uploaded, 0, 1, false, false).
+		AddTextView("SHA-256", item.SHA256, 0, 1, false, false).
+		AddTextView("Device", item.Device, 0, 1, false, false).
+		AddInputField("Description", item.Desc, 40, nil, nil)
+	app.addMetaFields(actionForm, item.Meta, item.Tags)
 
 	app.addAction(actionForm, actionFormRegister, "Cancel", app.actionSwitchToDataListWithClear)
-	app.addAction(actionForm, actionFormRegister, "Get", app.appActionGetFiles(name, id))
-	app.addAction(actionForm, actionFormRegister, "Delete", app.appActionDeleteFiles(name, id))
+	app.addAction(actionForm, actionFormRegister, "Get", app.appActionGetFiles(item.Name, item.Hash))
+	app.addAction(actionForm, actionFormRegister, "Save meta", app.appActionUpdateFileMeta(actionForm, item))
+	app.addAction(actionForm, actionFormRegister, "Delete", app.appActionDeleteFiles(item))
+	app.addAction(actionForm, actionFormRegister, "Versions", app.appActionLoadFileVersions(item))
+	app.addAction(actionForm, actionFormRegister, "Rename", app.appActionRenameFile(item))
+	app.addAction(actionForm, actionFormRegister, "Move", app.appActionMoveFile(item))
 
 	// Устанавливаем форму как корневой элемент интерфейса
 	app.pages.AddPage("datalistmoveaction", actionForm, true, false)
 	app.pages.SwitchToPage("datalistmoveaction")
 }
 
+func (app *App) appActionUpdateFileMeta(form *tview.Form, item model.FileItem) func() {
+	return func() {
+		app.logView.Clear()
+		meta, tags, err := app.readMetaForm(form)
+		if err != nil {
+			app.log.Info("Error meta form: ", err)
+			return
+		}
+		desc := strings.TrimSpace(form.GetFormItemByLabel("Description").(*tview.InputField).GetText())
+		app.updateFileMeta(item.Name, item.Revision, desc, meta, tags)
+	}
+}
+
+func (app *App) updateFileMeta(name string, revision int64, desc string, meta map[string]string, tags []string) {
+	err := app.client.UpdateFileMeta(name, revision, desc, meta, tags)
+	var conflict *client.ConflictError
+	if errors.As(err, &conflict) && conflict.File != nil {
+		server := *conflict.File
+		app.showConflict("File "+name+" was changed on another device",
+			func() { app.updateFileMeta(name, server.Revision, desc, meta, tags) },
+			func() { app.createMoveForm(server) })
+		return
+	}
+	if err != nil {
+		app.log.Info("Error client UpdateFileMeta: ", err)
+		return
+	}
+	app.log.Info("Updated meta of: ", name, "\n")
+}
+
 func (app *App) appActionGetFiles(name string, id string) func() {
 	return func() {
 		app.logView.Clear()
@@ -512,19 +1128,174 @@ func (app *App) appActionGetFiles(name string, id string) func() {
 	}
 }
 
-func (app *App) appActionDeleteFiles(name string, id string) func() {
+func (app *App) appActionLoadFileVersions(item model.FileItem) func() {
 	return func() {
 		app.logView.Clear()
-		err := app.client.DeleteFile(name)
+		versions, err := app.client.ListFileVersions(item.Name)
 		if err != nil {
-			app.log.Info("Error client DeleteFile: ", err)
+			app.log.Info("Error client ListFileVersions: ", err)
 			return
 		}
-		app.log.Info("Deleted ID: ", id, "\n")
-		app.pages.SwitchToPage("datalist")
+		app.updateFileVersionsPage(item, versions)
 	}
 }
 
+// List of stored versions of the file
+func (app *App) updateFileVersionsPage(item model.FileItem, versions []model.FileVersion) {
+	list := tview.NewList()
+	list.AddItem("Back", "", 'q', func() {
+		app.logView.Clear()
+		app.createMoveForm(item)
+	})
+
+	for _, v := range versions {
+		title := v.ModifiedAt.Format(time.DateTime)
+		if v.Latest {
+			title += " (current)"
+		}
+		list.AddItem(title, fmt.Sprintf("%d bytes", v.Size), 0, func() {
+			app.logView.Clear()
+			app.createFileVersionForm(item, v)
+		})
+	}
+
+	app.pages.AddPage("fileversions", list, true, false)
+	app.pages.SwitchToPage("fileversions")
+}
+
+// Detail page of stored version of the file
+func (app *App) createFileVersionForm(item model.FileItem, v model.FileVersion) {
+	actionForm := tview.NewForm()
+	actionFormRegister := &FormRegister{}
+	actionForm.
+		AddTextView("Version", v.VersionID, 0, 1, false, false).
+		AddTextView("Uploaded", v.ModifiedAt.Format(time.DateTime), 0, 1, false, false).
+		AddTextView("Size", strconv.FormatInt(v.Size, 10), 0, 1, false, false)
+	app.addAction(actionForm, actionFormRegister, "Back", app.appActionLoadFileVersions(item))
+	app.addAction(actionForm, actionFormRegister, "Get", app.appActionGetFileVersion(item.Name, v.VersionID))
+	if !v.Latest {
+		app.addAction(actionForm, actionFormRegister, "Restore", app.appActionRestoreFileVersion(item, v.VersionID))
+	}
+
+	app.pages.AddPage("fileversion", actionForm, true, false)
+	app.pages.SwitchToPage("fileversion")
+}
+
+func (app *App) appActionGetFileVersion(name, versionID string) func() {
+	return func() {
+		app.logView.Clear()
+		if err := app.client.GetFileVersion(name, versionID); err != nil {
+			app.log.Info("Error client GetFileVersion: ", err)
+			return
+		}
+		app.log.Info("Got version ", versionID, " of ", name)
+	}
+}
+
+func (app *App) appActionRestoreFileVersion(item model.FileItem, versionID string) func() {
+	return func() {
+		app.logView.Clear()
+		app.restoreFileVersion(item, versionID)
+	}
+}
+
+// restoreFileVersion делает версию текущей, item.Revision - версия файла, которую видел пользователь
+func (app *App) restoreFileVersion(item model.FileItem, versionID string) {
+	err := app.client.RestoreFileVersion(item.Name, versionID, item.Revision)
+	var conflict *client.ConflictError
+	if errors.As(err, &conflict) && conflict.File != nil {
+		server := *conflict.File
+		app.showConflict("File "+item.Name+" was changed on another device",
+			func() {
+				item.Revision = server.Revision
+				app.restoreFileVersion(item, versionID)
+			},
+			func() { app.createMoveForm(server) })
+		return
+	}
+	if err != nil {
+		app.log.Info("Error client RestoreFileVersion: ", err)
+		return
+	}
+	app.log.Info("Restored version ", versionID, " of ", item.Name, "\n")
+	app.pages.SwitchToPage("datalist")
+}
+
+func (app *App) appActionRenameFile(item model.FileItem) func() {
+	return func() {
+		app.logView.Clear()
+		app.createPathForm("Rename "+item.Name, "New name", model.BaseName(item.Name), func(name string) {
+			app.moveFile(item, model.JoinPath(model.FolderOf(item.Name), name))
+		})
+	}
+}
+
+func (app *App) appActionMoveFile(item model.FileItem) func() {
+	return func() {
+		app.logView.Clear()
+		app.createPathForm("Move "+item.Name, "Folder", model.FolderOf(item.Name), func(folder string) {
+			app.moveFile(item, model.JoinPath(strings.Trim(folder, model.FolderSeparator), model.BaseName(item.Name)))
+		})
+	}
+}
+
+// moveFile renames the file or folder when to is in the same folder, otherwise moves it keeping the name.
+// item.Revision - версия файла, которую видел пользователь.
+func (app *App) moveFile(item model.FileItem, to string) {
+	var err error
+	if model.FolderOf(to) == model.FolderOf(item.Name) {
+		err = app.client.RenameFile(item.Name, model.BaseName(to), item.Revision)
+	} else {
+		err = app.client.MoveFile(item.Name, model.FolderOf(to), item.Revision)
+	}
+	var conflict *client.ConflictError
+	if errors.As(err, &conflict) && conflict.File != nil {
+		server := *conflict.File
+		app.showConflict("File "+item.Name+" was changed on another device",
+			func() {
+				item.Revision = server.Revision
+				app.moveFile(item, to)
+			},
+			func() { app.createMoveForm(server) })
+		return
+	}
+	if err != nil {
+		app.log.Info("Error client MoveFile: ", err)
+		return
+	}
+	app.log.Info("Moved ", item.Name, " to ", to, "\n")
+	// открытая папка переехала вместе с перемещенной
+	if item.IsFolder && model.InFolder(app.fileFolder, item.Name) {
+		app.fileFolder = to + strings.TrimPrefix(app.fileFolder, item.Name)
+	}
+	app.reloadFiles()
+}
+
+func (app *App) appActionDeleteFiles(item model.FileItem) func() {
+	return func() {
+		app.logView.Clear()
+		app.deleteFile(item.Name, item.Hash, item.Revision)
+	}
+}
+
+func (app *App) deleteFile(name string, id string, revision int64) {
+	err := app.client.DeleteFile(name, revision)
+	var conflict *client.ConflictError
+	if errors.As(err, &conflict) && conflict.File != nil {
+		server := *conflict.File
+		app.showConflict("File "+name+" was changed on another device",
+			func() { app.deleteFile(name, id, server.Revision) },
+			func() { app.createMoveForm(server) })
+		return
+	}
+	if err != nil {
+		app.log.Info("Error client DeleteFile: ", err)
+		return
+	}
+	app.log.Info("Moved to trash ID: ", id, "\n")
+	app.pages.SwitchToPage("datalist")
+}
+
 // Detail page of type data with actions
 func (app *App) createDetailForm(item model.Data) {
 	// Создаем форму с действиями
@@ -537,24 +1308,424 @@ func (app *App) createDetailForm(item model.Data) {
 		AddTextView("Card", item.Card, 0, 1, false, false).
 		AddTextView("Login", item.Login, 0, 1, false, false).
 		AddTextView("Pass", item.Password, 0, 1, false, false)
+	switch item.Type {
+	case pbsrv.DataType_DATA_TYPE_TYPE_CREDIT_CARD.String():
+		expiry := fmt.Sprintf("%02d/%d", item.CardExpMonth, item.CardExpYear)
+		if model.CardExpired(item.CardExpMonth, item.CardExpYear, time.Now()) {
+			expiry += " (expired)"
+		}
+		actionForm.
+			AddTextView("Brand", item.CardBrand, 0, 1, false, false).
+			AddTextView("Holder", item.CardHolder, 0, 1, false, false).
+			AddTextView("Expiry", expiry, 0, 1, false, false).
+			AddTextView("CVV", item.CardCVV, 0, 1, false, false).
+			AddTextView("Bank", item.CardBank, 0, 1, false, false).
+			AddTextView("PIN", item.CardPIN, 0, 1, false, false)
+	case pbsrv.DataType_DATA_TYPE_UNSPECIFIED.String():
+		actionForm.AddTextView("Note", item.Note, 0, 5, false, true)
+	case pbsrv.DataType_DATA_TYPE_TYPE_BINARY.String():
+		app.addAction(actionForm, actionFormRegister, "Save to file", app.appActionSaveBinary(item.ID))
+	}
+	if len(item.Tags) > 0 {
+		actionForm.AddTextView("Tags", strings.Join(item.Tags, ", "), 0, 1, false, false)
+	}
+	if len(item.Meta) > 0 {
+		actionForm.AddTextView("Meta", formatMeta(item.Meta), 0, len(item.Meta), false, true)
+	}
 	app.addAction(actionForm, actionFormRegister, "Cancel", app.actionSwitchToDataListWithClear)
-	app.addAction(actionForm, actionFormRegister, "Delete", app.appActionDeleteData(item.ID))
+	app.addAction(actionForm, actionFormRegister, "Edit", app.appActionEditData(item.ID))
+	app.addAction(actionForm, actionFormRegister, "History", app.appActionLoadHistory(item))
+	app.addAction(actionForm, actionFormRegister, "Delete", app.appActionDeleteData(item.ID, item.Revision))
 
 	// Устанавливаем форму как корневой элемент интерфейса
 	app.pages.AddPage("datalistmoveaction", actionForm, true, false)
 	app.pages.SwitchToPage("datalistmoveaction")
 }
 
-func (app *App) appActionDeleteData(id int64) func() {
+// Бинарные данные не приходят в списке, берем запись целиком и сохраняем в каталог файлов
+func (app *App) appActionSaveBinary(id int64) func() {
+	return func() {
+		app.logView.Clear()
+		item, err := app.client.GetData(id)
+		if err != nil {
+			app.log.Info("Error client GetData: ", err)
+			return
+		}
+		filePath := filepath.Join(app.storage.PfilesDir, filepath.Base(item.Title))
+		if err := os.WriteFile(filePath, item.Binary, 0600); err != nil {
+			app.log.Info("Error saving binary: ", err)
+			return
+		}
+		app.log.Info("Binary saved: ", filePath)
+	}
+}
+
+func (app *App) appActionEditData(id int64) func() {
+	return func() {
+		app.logView.Clear()
+		// Берем актуальную версию записи с сервера
+		item, err := app.client.GetData(id)
+		if err != nil {
+			app.log.Info("Error client GetData: ", err)
+			return
+		}
+		app.createEditForm(item)
+	}
+}
+
+// Edit page of type data
+func (app *App) createEditForm(item model.Data) {
+	editForm := tview.NewForm()
+	editFormRegister := &FormRegister{}
+	editForm.
+		SetTitle("Edit Entry").
+		SetBorder(true)
+
+	editForm.AddInputField("Title", item.Title, 20, nil, nil)
+	switch item.Type {
+	case pbsrv.DataType_DATA_TYPE_TYPE_CREDIT_CARD.String():
+		app.addCardFields(editForm, item)
+	case pbsrv.DataType_DATA_TYPE_TYPE_LOGIN_PASSWORD.String():
+		editForm.
+			AddInputField("Login", item.Login, 20, nil, nil).
+			AddPasswordField("Password", item.Password, 20, '*', nil)
+	case pbsrv.DataType_DATA_TYPE_UNSPECIFIED.String():
+		editForm.AddTextArea("Note", item.Note, 40, 6, 0, nil)
+	case pbsrv.DataType_DATA_TYPE_TYPE_BINARY.String():
+		// пустой путь - содержимое не меняется
+		editForm.AddInputField("File Path", "", 40, nil, nil)
+		app.addAction(editForm, editFormRegister, "Select File", app.appActionSelectFiles(editForm))
+	}
+	app.addMetaFields(editForm, item.Meta, item.Tags)
+
+	app.addAction(editForm, editFormRegister, "Save", app.appActionUpdateData(editForm, item))
+	app.addAction(editForm, editFormRegister, "Cancel", app.actionSwitchToDataListWithClear)
+
+	app.pages.AddPage("dataeditform", editForm, true, false)
+	app.pages.SwitchToPage("dataeditform")
+}
+
+func (app *App) appActionUpdateData(editForm *tview.Form, item model.Data) func() {
+	return func() {
+		app.logView.Clear()
+		// Меняем только поля, которые есть в форме
+		fields := map[string]*string{
+			"Title":    &item.Title,
+			"Login":    &item.Login,
+			"Password": &item.Password,
+		}
+		for label, value := range fields {
+			if field, ok := editForm.GetFormItemByLabel(label).(*tview.InputField); ok {
+				*value = field.GetText()
+			}
+		}
+		if item.Type == pbsrv.DataType_DATA_TYPE_TYPE_CREDIT_CARD.String() {
+			if err := app.readCardForm(editForm, &item); err != nil {
+				app.log.Info("Error card form: ", err)
+				return
+			}
+		}
+		if field, ok := editForm.GetFormItemByLabel("Note").(*tview.TextArea); ok {
+			item.Note = field.GetText()
+		}
+		if field, ok := editForm.GetFormItemByLabel("File Path").(*tview.InputField); ok && field.GetText() != "" {
+			data, err := readBinary(field.GetText())
+			if err != nil {
+				app.log.Info("Error reading binary: ", err)
+				return
+			}
+			item.Binary = data
+		}
+		if _, ok := editForm.GetFormItemByLabel("Tags").(*tview.InputField); ok {
+			meta, tags, err := app.readMetaForm(editForm)
+			if err != nil {
+				app.log.Info("Error meta form: ", err)
+				return
+			}
+			item.Meta, item.Tags = meta, tags
+		}
+
+		app.updateData(item)
+	}
+}
+
+// updateData сохраняет запись, item.Revision - версия, которую пользователь редактировал
+func (app *App) updateData(item model.Data) {
+	err := app.client.UpdateData(item)
+	var conflict *client.ConflictError
+	if errors.As(err, &conflict) && conflict.Data != nil {
+		server := *conflict.Data
+		app.showConflict(fmt.Sprintf("Entry %q was changed on another device", item.Title),
+			func() {
+				item.Revision = server.Revision
+				app.updateData(item)
+			},
+			func() { app.createEditForm(server) })
+		return
+	}
+	if err != nil {
+		app.log.Info("Error client UpdateData: ", err)
+		return
+	}
+	app.log.Info("Updated ID: ", item.ID, "\n")
+	app.pages.SwitchToPage("datalist")
+}
+
+func (app *App) appActionDeleteData(id, revision int64) func() {
 	return func() {
 		app.logView.Clear()
 		app.log.Info("Delete pressed ID: ", id, "\n")
-		err := app.client.Delete(id)
+		app.deleteData(id, revision)
+	}
+}
+
+func (app *App) deleteData(id, revision int64) {
+	err := app.client.Delete(id, revision)
+	var conflict *client.ConflictError
+	if errors.As(err, &conflict) && conflict.Data != nil {
+		server := *conflict.Data
+		app.showConflict(fmt.Sprintf("Entry %q was changed on another device", server.Title),
+			func() { app.deleteData(id, server.Revision) },
+			func() { app.createDetailForm(server) })
+		return
+	}
+	if err != nil {
+		app.log.Info("Error delete item: ", err)
+		return
+	}
+	app.pages.SwitchToPage("datalist")
+}
+
+func (app *App) appActionLoadHistory(item model.Data) func() {
+	return func() {
+		app.logView.Clear()
+		history, err := app.client.ListDataHistory(item.ID)
 		if err != nil {
-			app.log.Info("Error delete item: ", err)
+			app.log.Info("Error client ListDataHistory: ", err)
+			return
+		}
+		app.updateHistoryPage(item, history)
+	}
+}
+
+// List of previous versions of the record
+func (app *App) updateHistoryPage(item model.Data, history []model.DataRevision) {
+	list := tview.NewList()
+	list.AddItem("Back", "", 'q', func() {
+		app.logView.Clear()
+		app.createDetailForm(item)
+	})
+
+	for _, rev := range history {
+		list.AddItem(fmt.Sprintf("Version %d", rev.Data.Revision), "replaced "+rev.ChangedAt.Format(time.DateTime), 0, func() {
+			app.logView.Clear()
+			app.createRevisionForm(item, rev.Data)
+		})
+	}
+
+	app.pages.AddPage("datahistory", list, true, false)
+	app.pages.SwitchToPage("datahistory")
+}
+
+// Detail page of previous version of the record
+func (app *App) createRevisionForm(item model.Data, old model.Data) {
+	actionForm := tview.NewForm()
+	actionFormRegister := &FormRegister{}
+	actionForm.
+		AddTextView("Version", strconv.FormatInt(old.Revision, 10), 0, 1, false, false).
+		AddTextView("Name", old.Title, 0, 1, false, false).
+		AddTextView("Login", old.Login, 0, 1, false, false).
+		AddTextView("Pass", old.Password, 0, 1, false, false)
+	switch old.Type {
+	case pbsrv.DataType_DATA_TYPE_TYPE_CREDIT_CARD.String():
+		actionForm.
+			AddTextView("Card", old.Card, 0, 1, false, false).
+			AddTextView("Holder", old.CardHolder, 0, 1, false, false).
+			AddTextView("Expiry", fmt.Sprintf("%02d/%d", old.CardExpMonth, old.CardExpYear), 0, 1, false, false).
+			AddTextView("CVV", old.CardCVV, 0, 1, false, false)
+	case pbsrv.DataType_DATA_TYPE_UNSPECIFIED.String():
+		actionForm.AddTextView("Note", old.Note, 0, 5, false, true)
+	}
+	app.addAction(actionForm, actionFormRegister, "Back", app.appActionLoadHistory(item))
+	app.addAction(actionForm, actionFormRegister, "Restore", app.appActionRestoreRevision(item, old.Revision))
+
+	app.pages.AddPage("datahistoryrevision", actionForm, true, false)
+	app.pages.SwitchToPage("datahistoryrevision")
+}
+
+func (app *App) appActionRestoreRevision(item model.Data, revision int64) func() {
+	return func() {
+		app.logView.Clear()
+		app.restoreRevision(item, revision)
+	}
+}
+
+// restoreRevision возвращает прежнюю версию, item.Revision - версия, которую видел пользователь
+func (app *App) restoreRevision(item model.Data, revision int64) {
+	err := app.client.RestoreDataRevision(item.ID, revision, item.Revision)
+	var conflict *client.ConflictError
+	if errors.As(err, &conflict) && conflict.Data != nil {
+		server := *conflict.Data
+		app.showConflict(fmt.Sprintf("Entry %q was changed on another device", server.Title),
+			func() {
+				item.Revision = server.Revision
+				app.restoreRevision(item, revision)
+			},
+			func() { app.createDetailForm(server) })
+		return
+	}
+	if err != nil {
+		app.log.Info("Error client RestoreDataRevision: ", err)
+		return
+	}
+	app.log.Info("Restored version ", revision, " of ID: ", item.ID, "\n")
+	app.pages.SwitchToPage("datalist")
+}
+
+// showConflict спрашивает, чью версию оставить: keepMine повторяет изменение поверх версии сервера,
+// takeServer открывает копию сервера
+func (app *App) showConflict(text string, keepMine, takeServer func()) {
+	modal := tview.NewModal().
+		SetText(text).
+		AddButtons([]string{"Keep mine", "Take server", "Cancel"}).
+		SetDoneFunc(func(_ int, label string) {
+			app.pages.RemovePage("conflict")
+			switch label {
+			case "Keep mine":
+				keepMine()
+			case "Take server":
+				takeServer()
+			default:
+				app.pages.SwitchToPage("datalist")
+			}
+		})
+	app.pages.AddPage("conflict", modal, true, true)
+}
+
+func (app *App) appActionLoadTrash() {
+	app.logView.Clear()
+	items, err := app.client.ListTrash()
+	if err != nil {
+		app.log.Info("Error client ListTrash: ", err)
+		return
+	}
+	app.updateTrashPage(items)
+}
+
+// List of deleted records and files
+func (app *App) updateTrashPage(items []model.TrashItem) {
+	list := tview.NewList()
+	list.AddItem("Back", "", 'q', app.actionSwitchToMainWithClear)
+
+	for _, item := range items {
+		list.AddItem(trashTitle(item), "purged "+item.PurgeAt.Format(time.DateTime), 0, func() {
+			app.logView.Clear()
+			app.createTrashForm(item)
+		})
+	}
+
+	app.pages.AddPage("trash", list, true, false)
+	app.pages.SwitchToPage("trash")
+}
+
+func trashTitle(item model.TrashItem) string {
+	if item.Data != nil {
+		return "Data: " + item.Data.Title
+	}
+	return "File: " + item.File.Name
+}
+
+// Detail page of deleted item with restore action
+func (app *App) createTrashForm(item model.TrashItem) {
+	actionForm := tview.NewForm()
+	actionFormRegister := &FormRegister{}
+	actionForm.
+		AddTextView("Name", trashTitle(item), 0, 1, false, false).
+		AddTextView("Deleted", item.DeletedAt.Format(time.DateTime), 0, 1, false, false).
+		AddTextView("Purged", item.PurgeAt.Format(time.DateTime), 0, 1, false, false)
+	app.addAction(actionForm, actionFormRegister, "Back", app.appActionLoadTrash)
+	app.addAction(actionForm, actionFormRegister, "Restore", app.appActionRestoreFromTrash(item))
+
+	app.pages.AddPage("trashaction", actionForm, true, false)
+	app.pages.SwitchToPage("trashaction")
+}
+
+func (app *App) appActionRestoreFromTrash(item model.TrashItem) func() {
+	return func() {
+		app.logView.Clear()
+		if err := app.client.RestoreFromTrash(item); err != nil {
+			app.log.Info("Error client RestoreFromTrash: ", err)
+			return
+		}
+		// список перечитывается с очисткой лога, сообщение пишется после
+		app.appActionLoadTrash()
+		app.log.Info("Restored: ", trashTitle(item), "\n")
+	}
+}
+
+func (app *App) appActionLoadSessions() {
+	app.logView.Clear()
+	sessions, err := app.client.ListSessions()
+	if err != nil {
+		app.log.Info("Error client ListSessions: ", err)
+		return
+	}
+	app.updateSessionsPage(sessions)
+}
+
+// List of signed in devices
+func (app *App) updateSessionsPage(sessions []model.Session) {
+	list := tview.NewList()
+	list.AddItem("Back", "", 'q', app.actionSwitchToMainWithClear)
+	list.AddItem("Logout", "End current session", 'l', app.appActionEndSession(""))
+
+	for _, item := range sessions {
+		title := item.DeviceInfo
+		if item.ID == app.storage.SessionID {
+			title += " (current)"
+		}
+		list.AddItem(title, "since "+item.CreatedAt.Format(time.DateTime), 0, func() {
+			app.logView.Clear()
+			app.createSessionForm(item)
+		})
+	}
+
+	app.pages.AddPage("sessions", list, true, false)
+	app.pages.SwitchToPage("sessions")
+}
+
+// Detail page of session with actions
+func (app *App) createSessionForm(item model.Session) {
+	actionForm := tview.NewForm()
+	actionFormRegister := &FormRegister{}
+	actionForm.
+		AddTextView("ID", item.ID, 0, 1, false, false).
+		AddTextView("Device", item.DeviceInfo, 0, 1, false, false).
+		AddTextView("Created", item.CreatedAt.Format(time.DateTime), 0, 1, false, false).
+		AddTextView("Expires", item.ExpiresAt.Format(time.DateTime), 0, 1, false, false)
+	app.addAction(actionForm, actionFormRegister, "Cancel", app.appActionLoadSessions)
+	app.addAction(actionForm, actionFormRegister, "End session", app.appActionEndSession(item.ID))
+
+	app.pages.AddPage("sessionaction", actionForm, true, false)
+	app.pages.SwitchToPage("sessionaction")
+}
+
+func (app *App) appActionEndSession(id string) func() {
+	return func() {
+		app.logView.Clear()
+		current := id == "" || id == app.storage.SessionID
+		if err := app.client.EndSession(id); err != nil {
+			app.log.Info("Error client EndSession: ", err)
+			return
+		}
+		if current {
+			app.storage.Login = ""
+			app.storage.MasterKey = client.MasterKey{}
+			app.actionSwitchToAuth()
 			return
 		}
-		app.pages.SwitchToPage("datalist")
+		app.log.Info("Session ended: ", id)
+		app.appActionLoadSessions()
 	}
 }
 
@@ -1,14 +1,20 @@
 package client
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/Arcadian-Sky/datakkeeper/internal/client"
 	"github.com/Arcadian-Sky/datakkeeper/internal/model"
 	"github.com/Arcadian-Sky/datakkeeper/internal/settings"
 	"github.com/Arcadian-Sky/datakkeeper/mocks"
+	"github.com/gdamore/tcell/v2"
 	"github.com/golang/mock/gomock"
 	"github.com/rivo/tview"
 	"github.com/sirupsen/logrus"
@@ -97,8 +103,8 @@ func TestAppLoadData_Success(t *testing.T) {
 		{ID: 2, Title: "data2", Type: "type2", Card: "card2", Login: "login2", Password: "password2"},
 	}
 
-	// Настраиваем mock для успешного вызова GetDataList
-	mockClient.EXPECT().GetDataList().Return(mockData, nil)
+	// Настраиваем mock для успешного вызова ListData
+	mockClient.EXPECT().ListData(client.ListOptions{}).Return(mockData, "", nil)
 
 	// Создаем экземпляр приложения с mock клиентом
 	app := NewEmptyApp()
@@ -120,7 +126,7 @@ func TestAppLoadData_Error(t *testing.T) {
 	mockClient := mocks.NewMockGRPCClientInterface(ctrl)
 
 	// Настраиваем mock для возврата ошибки
-	mockClient.EXPECT().GetDataList().Return(nil, errors.New("client error"))
+	mockClient.EXPECT().ListData(gomock.Any()).Return(nil, "", errors.New("client error"))
 
 	// Создаем экземпляр приложения с mock клиентом
 	app := NewEmptyApp()
@@ -130,7 +136,7 @@ func TestAppLoadData_Error(t *testing.T) {
 	err := app.loadData()
 
 	// Проверяем, что возникла ожидаемая ошибка
-	assert.EqualError(t, err, "error client GetDataList: client error")
+	assert.EqualError(t, err, "error client ListData: client error")
 
 	// Убедимся, что updateDatalistPage не был вызван
 	assert.NotNil(t, err, "Expected an error but got nil")
@@ -150,7 +156,69 @@ func TestAppUpdateDatalistPage(t *testing.T) {
 	}
 
 	// Вызываем тестируемый метод
-	app.updateDatalistPage(mockData)
+	app.updateDatalistPage("", mockData, "")
+}
+
+func TestAppDatalistPage_SearchAndMore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockGRPCClientInterface(ctrl)
+	app := NewEmptyApp()
+	app.client = mockClient
+	app.log = logrus.New()
+
+	enter := tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone)
+	frontList := func() (*tview.InputField, *tview.List) {
+		_, page := app.pages.GetFrontPage()
+		flex := page.(*tview.Flex)
+		return flex.GetItem(0).(*tview.InputField), flex.GetItem(1).(*tview.List)
+	}
+
+	opts := client.ListOptions{Search: "mail", Tags: []string{"work"}, ByTitle: true}
+	mockClient.EXPECT().ListData(opts).
+		Return([]model.Data{{ID: 1, Title: "mail1"}}, "token", nil)
+	assert.NoError(t, app.loadDataPage("mail #work sort:title"))
+
+	search, list := frontList()
+	assert.Equal(t, "mail #work sort:title", search.GetText())
+	// Back, mail1, Load more
+	assert.Equal(t, 3, list.GetItemCount())
+	main, _ := list.GetItemText(2)
+	assert.Equal(t, listMoreText, main)
+
+	// следующая страница добавляется в конец списка с теми же фильтрами
+	next := opts
+	next.PageToken = "token"
+	mockClient.EXPECT().ListData(next).
+		Return([]model.Data{{ID: 2, Title: "mail2", Tags: []string{"work"}}}, "", nil)
+	list.SetCurrentItem(2)
+	list.InputHandler()(enter, func(tview.Primitive) {})
+	assert.Equal(t, 3, list.GetItemCount())
+	main, secondary := list.GetItemText(2)
+	assert.Equal(t, "mail2", main)
+	assert.Contains(t, secondary, "#work")
+
+	// Enter в строке поиска загружает первую страницу заново
+	mockClient.EXPECT().ListData(opts).Return(nil, "", nil)
+	search.InputHandler()(enter, func(tview.Primitive) {})
+	search, list = frontList()
+	assert.Equal(t, "mail #work sort:title", search.GetText())
+	assert.Equal(t, 1, list.GetItemCount())
+}
+
+func TestParseListSearch(t *testing.T) {
+	opts := parseListSearch("  bank  #salary #main type:card type:unknown sort:-title card", dataTypeNames)
+	assert.Equal(t, client.ListOptions{
+		Search:  "bank card",
+		Tags:    []string{"salary", "main"},
+		Types:   []string{"DATA_TYPE_TYPE_CREDIT_CARD"},
+		ByTitle: true,
+		Desc:    true,
+	}, opts)
+
+	opts = parseListSearch("type:JPG sort:-created #", nil)
+	assert.Equal(t, client.ListOptions{Types: []string{"jpg"}, Desc: true, Search: "#"}, opts)
 }
 
 func TestAppLoadFiles_Success(t *testing.T) {
@@ -166,8 +234,8 @@ func TestAppLoadFiles_Success(t *testing.T) {
 		{Name: "file2", Desc: "description2", Hash: "hash2"},
 	}
 
-	// Настраиваем mock для успешного вызова GetFileList
-	mockClient.EXPECT().GetFileList().Return(mockData, nil)
+	// Настраиваем mock для успешного вызова ListFiles
+	mockClient.EXPECT().ListFiles(client.ListOptions{Browse: true}).Return(mockData, "", nil)
 
 	// Создаем экземпляр приложения с mock клиентом
 	app := NewEmptyApp()
@@ -188,7 +256,7 @@ func TestAppLoadFiles_Error(t *testing.T) {
 	mockClient := mocks.NewMockGRPCClientInterface(ctrl)
 
 	// Настраиваем mock для возврата ошибки
-	mockClient.EXPECT().GetFileList().Return(nil, errors.New("stream error"))
+	mockClient.EXPECT().ListFiles(gomock.Any()).Return(nil, "", errors.New("stream error"))
 
 	// Создаем экземпляр приложения с mock клиентом
 	app := NewEmptyApp()
@@ -219,7 +287,7 @@ func TestAppUpdateFileDatalistPage(t *testing.T) {
 	}
 
 	// Вызываем метод обновления списка файлов
-	app.updateFileDatalistPage(data)
+	app.updateFileDatalistPage("", data, "")
 
 	// Проверяем, что страница была добавлена
 	pageNames := app.pages.GetPageNames(false)
@@ -227,6 +295,135 @@ func TestAppUpdateFileDatalistPage(t *testing.T) {
 
 }
 
+func TestApp_folderBrowser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockGRPCClientInterface(ctrl)
+	app := NewEmptyApp()
+	app.client = mockClient
+	app.log = logrus.New()
+	app.log.SetOutput(app.logView)
+	app.initDataInterfaces()
+	app.pages.AddPage("fileform", app.data.loadForm, true, false)
+
+	enter := tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone)
+	frontList := func() *tview.List {
+		_, page := app.pages.GetFrontPage()
+		return page.(*tview.Flex).GetItem(1).(*tview.List)
+	}
+	selectItem := func(list *tview.List, main string) {
+		for i := 0; i < list.GetItemCount(); i++ {
+			if text, _ := list.GetItemText(i); text == main {
+				list.SetCurrentItem(i)
+				list.InputHandler()(enter, func(tview.Primitive) {})
+				return
+			}
+		}
+		t.Fatalf("no item %q", main)
+	}
+
+	mockClient.EXPECT().ListFiles(client.ListOptions{Browse: true}).
+		Return([]model.FileItem{{Name: "work", IsFolder: true}, {Name: "a.txt"}}, "", nil)
+	app.appActionLoadFiles()
+	list := frontList()
+	// Back, New folder, Upload here, work/, a.txt
+	assert.Equal(t, 5, list.GetItemCount())
+
+	// папка открывается, имена показываются от нее
+	mockClient.EXPECT().ListFiles(client.ListOptions{Browse: true, Parent: "work"}).
+		Return([]model.FileItem{{Name: "work/b.txt"}}, "", nil)
+	selectItem(list, "work/")
+	assert.Equal(t, "work", app.fileFolder)
+	list = frontList()
+	main, _ := list.GetItemText(1)
+	assert.Equal(t, "..", main)
+	main, _ = list.GetItemText(list.GetItemCount() - 1)
+	assert.Equal(t, "b.txt", main)
+
+	// новая папка создается в открытой
+	selectItem(list, "New folder")
+	_, page := app.pages.GetFrontPage()
+	form := page.(*tview.Form)
+	form.GetFormItemByLabel("Name").(*tview.InputField).SetText("2024")
+	mockClient.EXPECT().CreateFolder("work/2024").Return(nil)
+	mockClient.EXPECT().ListFiles(client.ListOptions{Browse: true, Parent: "work"}).
+		Return([]model.FileItem{{Name: "work/2024", IsFolder: true}}, "", nil)
+	form.GetButton(0).InputHandler()(enter, func(tview.Primitive) {})
+	assert.Contains(t, app.logView.GetText(true), "Created folder: work/2024")
+
+	// загрузка в открытую папку
+	selectItem(frontList(), "Upload here")
+	name, _ := app.pages.GetFrontPage()
+	assert.Equal(t, "fileform", name)
+	assert.Equal(t, "work", app.data.loadForm.GetFormItemByLabel("Folder").(*tview.InputField).GetText())
+
+	// поиск идет по всем папкам
+	opts := client.ListOptions{Search: "b"}
+	mockClient.EXPECT().ListFiles(opts).Return([]model.FileItem{{Name: "work/b.txt"}}, "", nil)
+	assert.NoError(t, app.loadFilesPage("b"))
+	list = frontList()
+	assert.Equal(t, 2, list.GetItemCount())
+	main, _ = list.GetItemText(1)
+	assert.Equal(t, "work/b.txt", main)
+
+	mockClient.EXPECT().ListFiles(client.ListOptions{Browse: true}).Return(nil, "", nil)
+	app.appActionOpenFolder("")()
+	assert.Equal(t, 3, frontList().GetItemCount())
+}
+
+func TestApp_moveFile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockGRPCClientInterface(ctrl)
+	app := NewEmptyApp()
+	app.client = mockClient
+	app.log = logrus.New()
+	app.log.SetOutput(app.logView)
+	enter := tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone)
+	submit := func(label, text string) {
+		_, page := app.pages.GetFrontPage()
+		form := page.(*tview.Form)
+		form.GetFormItemByLabel(label).(*tview.InputField).SetText(text)
+		form.GetButton(0).InputHandler()(enter, func(tview.Primitive) {})
+	}
+
+	// формы заполнены текущим именем и папкой
+	item := model.FileItem{Name: "work/a.txt", Revision: 2}
+	app.appActionRenameFile(item)()
+	_, page := app.pages.GetFrontPage()
+	assert.Equal(t, "a.txt", page.(*tview.Form).GetFormItemByLabel("New name").(*tview.InputField).GetText())
+	app.appActionMoveFile(item)()
+	_, page = app.pages.GetFrontPage()
+	assert.Equal(t, "work", page.(*tview.Form).GetFormItemByLabel("Folder").(*tview.InputField).GetText())
+
+	// переименование в той же папке
+	server := model.FileItem{Name: "work/a.txt", Revision: 6}
+	mockClient.EXPECT().RenameFile("work/a.txt", "b.txt", int64(2)).Return(&client.ConflictError{File: &server})
+	app.moveFile(item, "work/b.txt")
+	mockClient.EXPECT().RenameFile("work/a.txt", "b.txt", int64(6)).Return(nil)
+	mockClient.EXPECT().ListFiles(gomock.Any()).Return(nil, "", nil)
+	pressConflictButton(t, app, 0)
+	assert.Contains(t, app.logView.GetText(true), "Moved work/a.txt to work/b.txt")
+
+	mockClient.EXPECT().MoveFile("work/a.txt", "", int64(2)).Return(nil)
+	mockClient.EXPECT().ListFiles(gomock.Any()).Return(nil, "", nil)
+	app.moveFile(item, "a.txt")
+
+	// открытая папка переезжает вместе с перемещенной
+	app.fileFolder = "work/2024"
+	app.appActionMoveFile(model.FileItem{Name: "work", IsFolder: true})()
+	mockClient.EXPECT().MoveFile("work", "archive", int64(0)).Return(nil)
+	mockClient.EXPECT().ListFiles(client.ListOptions{Browse: true, Parent: "archive/work/2024"}).Return(nil, "", nil)
+	submit("Folder", "/archive/")
+	assert.Equal(t, "archive/work/2024", app.fileFolder)
+
+	mockClient.EXPECT().RenameFile("work/a.txt", "c.txt", int64(2)).Return(errors.New("exists"))
+	app.moveFile(item, "work/c.txt")
+	assert.Contains(t, app.logView.GetText(true), "Error client MoveFile: exists")
+}
+
 func TestAppCreateMoveForm(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -238,16 +435,91 @@ func TestAppCreateMoveForm(t *testing.T) {
 	app.log = logrus.New()
 
 	// Входные данные для теста
-	id := "12345"
-	name := "testfile"
-	desc := "test description"
+	item := model.FileItem{
+		Hash: "12345",
+		Name: "testfile",
+		Desc: "test description",
+		Meta: map[string]string{"place": "Kazan", "author": "me"},
+		Tags: []string{"trip", "2024"},
+
+		Size:        20,
+		ContentType: "image/jpeg",
+		UploadedAt:  time.Date(2024, 8, 1, 10, 0, 0, 0, time.UTC),
+		SHA256:      "abc",
+		Device:      "phone",
+	}
 
 	// Вызываем метод создания формы
-	app.createMoveForm(id, name, desc)
+	app.createMoveForm(item)
 
 	// Проверяем, что форма была добавлена как страница
 	pageNames := app.pages.GetPageNames(false)
 	assert.Contains(t, pageNames, "datalistmoveaction")
+
+	_, page := app.pages.GetFrontPage()
+	form := page.(*tview.Form)
+	assert.Equal(t, "trip, 2024", form.GetFormItemByLabel("Tags").(*tview.InputField).GetText())
+	assert.Equal(t, "author=me\nplace=Kazan", form.GetFormItemByLabel("Meta").(*tview.TextArea).GetText())
+	assert.Equal(t, "test description", form.GetFormItemByLabel("Description").(*tview.InputField).GetText())
+	assert.Equal(t, "image/jpeg", form.GetFormItemByLabel("Type").(*tview.TextView).GetText(true))
+	assert.Equal(t, "phone", form.GetFormItemByLabel("Device").(*tview.TextView).GetText(true))
+}
+
+func TestApp_appActionUpdateFileMeta(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockGRPCClientInterface(ctrl)
+
+	app := NewEmptyApp()
+	app.client = mockClient
+	app.log = logrus.New()
+	app.log.SetOutput(app.logView)
+
+	t.Run("Success", func(t *testing.T) {
+		form := tview.NewForm().AddInputField("Description", " Summer trip ", 40, nil, nil)
+		app.addMetaFields(form, map[string]string{"place": "Kazan"}, []string{"trip", " 2024 ", ""})
+
+		mockClient.EXPECT().
+			UpdateFileMeta("photo.jpg", int64(4), "Summer trip", map[string]string{"place": "Kazan"}, []string{"2024", "trip"}).
+			Return(nil).Times(1)
+
+		app.appActionUpdateFileMeta(form, model.FileItem{Name: "photo.jpg", Revision: 4})()
+		assert.Contains(t, app.logView.GetText(true), "Updated meta of: photo.jpg")
+	})
+
+	t.Run("InvalidMeta", func(t *testing.T) {
+		form := tview.NewForm().
+			AddInputField("Tags", "", 40, nil, nil).
+			AddTextArea("Meta", "no separator", 40, 4, 0, nil)
+
+		app.appActionUpdateFileMeta(form, model.FileItem{Name: "photo.jpg", Revision: 4})()
+		assert.Contains(t, app.logView.GetText(true), "Error meta form")
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		form := tview.NewForm().AddInputField("Description", "", 40, nil, nil)
+		app.addMetaFields(form, nil, nil)
+
+		mockClient.EXPECT().UpdateFileMeta("photo.jpg", int64(4), "", nil, nil).Return(fmt.Errorf("meta error")).Times(1)
+
+		app.appActionUpdateFileMeta(form, model.FileItem{Name: "photo.jpg", Revision: 4})()
+		assert.Contains(t, app.logView.GetText(true), "Error client UpdateFileMeta: meta error")
+	})
+}
+
+func TestParseMeta(t *testing.T) {
+	meta, err := parseMeta(" site = example.com \n\nurl=https://a.b/?x=1")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"site": "example.com", "url": "https://a.b/?x=1"}, meta)
+	assert.Equal(t, "site=example.com\nurl=https://a.b/?x=1", formatMeta(meta))
+
+	meta, err = parseMeta("")
+	assert.NoError(t, err)
+	assert.Nil(t, meta)
+
+	_, err = parseMeta("site")
+	assert.ErrorIs(t, err, model.ErrMetaInvalid)
 }
 
 func TestAppInitialization(t *testing.T) {
@@ -359,6 +631,7 @@ func TestApp_acgtionSaveRegisterForm_Success(t *testing.T) {
 
 	// Mock the Register method to succeed
 	mockClient.EXPECT().Register("testuser", "password").Return(nil)
+	expectWatch(t, app, mockClient)
 
 	// Call the method
 	app.actionSaveRegisterForm()
@@ -412,6 +685,7 @@ func TestApp_actionAuth_Success(t *testing.T) {
 
 	// Expect the Authenticate method to be called with "testuser" and "password"
 	mockClient.EXPECT().Authenticate("testuser", "password").Return(nil)
+	expectWatch(t, app, mockClient)
 
 	// Call the method
 	app.actionAuth()
@@ -509,10 +783,11 @@ func TestApp_appActionSendFiles(t *testing.T) {
 	form := tview.NewForm()
 	inputField := tview.NewInputField().SetText("/path/to/file")
 	form.AddFormItem(inputField)
+	form.AddInputField("Folder", "docs/", 40, nil, nil)
 	app.data.loadForm = form
 
 	// Define the behavior of the mock client
-	mockClient.EXPECT().UploadFile("/path/to/file").Return(nil).Times(1)
+	mockClient.EXPECT().UploadFile("/path/to/file", "docs").Return(nil).Times(1)
 
 	// Call the method
 	action := app.appActionSendFiles(app.data.loadForm)
@@ -580,6 +855,16 @@ func TestApp_appActionSendLoginPass_Error(t *testing.T) {
 	assert.NotContains(t, logLines, "Error saving login pass")
 }
 
+// fillCardForm заполняет поля, добавленные addCardFields
+func fillCardForm(form *tview.Form, number, expiry string) {
+	form.GetFormItemByLabel("Card Number").(*tview.InputField).SetText(number)
+	form.GetFormItemByLabel("Holder").(*tview.InputField).SetText("IVAN IVANOV")
+	form.GetFormItemByLabel("Expiry (MM/YY)").(*tview.InputField).SetText(expiry)
+	form.GetFormItemByLabel("CVV").(*tview.InputField).SetText("123")
+	form.GetFormItemByLabel("Bank").(*tview.InputField).SetText("Bank")
+	form.GetFormItemByLabel("PIN").(*tview.InputField).SetText("1234")
+}
+
 func TestApp_appActionSendCard_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -591,15 +876,27 @@ func TestApp_appActionSendCard_Success(t *testing.T) {
 	app.client = mockClient
 	app.storage = client.NewMemStorage()
 	app.log = logrus.New()
+	app.initDataInterfaces()
 
-	// Set up the form and input fields
-	form := tview.NewForm()
-	form.AddFormItem(tview.NewInputField().SetText("domain"))
-	form.AddFormItem(tview.NewInputField().SetText("cardnumber"))
-	app.data.sendCardForm = form
+	form := app.data.sendCardForm
+	form.GetFormItemByLabel("Title").(*tview.InputField).SetText("domain")
+	fillCardForm(form, "4111 1111 1111 1111", "03/30")
+
+	// бренд определяется по мере ввода номера
+	assert.Equal(t, model.CardBrandVisa, form.GetFormItemByLabel("Brand").(*tview.TextView).GetText(true))
 
 	// Define the behavior of the mock client
-	mockClient.EXPECT().SaveCard("domain", "cardnumber").Return(nil).Times(1)
+	mockClient.EXPECT().SaveCard(model.Data{
+		Title:        "domain",
+		Card:         "4111111111111111",
+		CardHolder:   "IVAN IVANOV",
+		CardExpMonth: 3,
+		CardExpYear:  2030,
+		CardCVV:      "123",
+		CardBank:     "Bank",
+		CardPIN:      "1234",
+		CardBrand:    model.CardBrandVisa,
+	}).Return(nil).Times(1)
 
 	// Call the method
 	app.appActionSendCard()
@@ -608,33 +905,58 @@ func TestApp_appActionSendCard_Success(t *testing.T) {
 	assert.Equal(t, "", app.logView.GetText(true))
 }
 
-func TestApp_appActionSendCard_Error(t *testing.T) {
+func TestApp_appActionSendCard_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		number string
+		expiry string
+	}{
+		{"Luhn", "4111 1111 1111 1112", "03/30"},
+		{"Expiry", "4111 1111 1111 1111", "13/30"},
+		{"ExpiryFormat", "4111 1111 1111 1111", "0330"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// SaveCard не должен вызываться
+			mockClient := mocks.NewMockGRPCClientInterface(ctrl)
+
+			app := NewEmptyApp()
+			app.client = mockClient
+			app.storage = client.NewMemStorage()
+			app.log = logrus.New()
+			app.initDataInterfaces()
+
+			app.data.sendCardForm.GetFormItemByLabel("Title").(*tview.InputField).SetText("domain")
+			fillCardForm(app.data.sendCardForm, tt.number, tt.expiry)
+
+			app.appActionSendCard()
+		})
+	}
+}
+
+func TestApp_appActionSendCard_Expired(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	// Создаем mock клиента
 	mockClient := mocks.NewMockGRPCClientInterface(ctrl)
 
 	app := NewEmptyApp()
 	app.client = mockClient
 	app.storage = client.NewMemStorage()
 	app.log = logrus.New()
+	app.initDataInterfaces()
 
-	// Set up the form and input fields
-	form := tview.NewForm()
-	form.AddFormItem(tview.NewInputField().SetText("domain"))
-	form.AddFormItem(tview.NewInputField().SetText("cardnumber"))
-	app.data.sendCardForm = form
+	form := app.data.sendCardForm
+	form.GetFormItemByLabel("Title").(*tview.InputField).SetText("old")
+	fillCardForm(form, "5555 5555 5555 4444", "01/20")
 
-	// Define the behavior of the mock client
-	mockClient.EXPECT().SaveCard("domain", "cardnumber").Return(nil).Times(1)
+	// просроченная карта сохраняется, пользователь только предупреждается
+	mockClient.EXPECT().SaveCard(gomock.Any()).Return(nil).Times(1)
 
-	// Call the method
 	app.appActionSendCard()
-
-	logLines := app.logView.GetText(true)
-	fmt.Printf("logLines: %v\n", logLines)
-	assert.NotContains(t, logLines, "Error saving card")
 }
 
 func TestApp_checkInputCardField(t *testing.T) {
@@ -678,10 +1000,10 @@ func TestApp_actionDeleteData(t *testing.T) {
 	// Test for successful deletion
 	t.Run("Success", func(t *testing.T) {
 		// Setup the mock to return no error
-		mockClient.EXPECT().Delete(idToDelete).Return(nil).Times(1)
+		mockClient.EXPECT().Delete(idToDelete, int64(0)).Return(nil).Times(1)
 
 		// Create and call the action
-		action := app.appActionDeleteData(idToDelete)
+		action := app.appActionDeleteData(idToDelete, 0)
 		action()
 
 		logLines := app.logView.GetText(true)
@@ -691,10 +1013,10 @@ func TestApp_actionDeleteData(t *testing.T) {
 	// Test for failed deletion
 	t.Run("Failure", func(t *testing.T) {
 		// Setup the mock to return an error
-		mockClient.EXPECT().Delete(idToDelete).Return(fmt.Errorf("delete error")).Times(1)
+		mockClient.EXPECT().Delete(idToDelete, int64(0)).Return(fmt.Errorf("delete error")).Times(1)
 
 		// Create and call the action
-		action := app.appActionDeleteData(idToDelete)
+		action := app.appActionDeleteData(idToDelete, 0)
 		action()
 
 		logLines := app.logView.GetText(true)
@@ -766,23 +1088,23 @@ func TestApp_appActionDeleteFiles(t *testing.T) {
 	// Test for successful file deletion
 	t.Run("Success", func(t *testing.T) {
 		// Setup the mock to return no error
-		mockClient.EXPECT().DeleteFile(name).Return(nil).Times(1)
+		mockClient.EXPECT().DeleteFile(name, int64(0)).Return(nil).Times(1)
 
 		// Create and call the action
-		action := app.appActionDeleteFiles(name, id)
+		action := app.appActionDeleteFiles(model.FileItem{Name: name, Hash: id})
 		action()
 
 		// Check if the log view is cleared
-		assert.Contains(t, app.logView.GetText(true), "Deleted ID: 123")
+		assert.Contains(t, app.logView.GetText(true), "Moved to trash ID: 123")
 	})
 
 	// Test for failed file deletion
 	t.Run("Failure", func(t *testing.T) {
 		// Setup the mock to return an error
-		mockClient.EXPECT().DeleteFile(name).Return(fmt.Errorf("delete file error")).Times(1)
+		mockClient.EXPECT().DeleteFile(name, int64(0)).Return(fmt.Errorf("delete file error")).Times(1)
 
 		// Create and call the action
-		action := app.appActionDeleteFiles(name, id)
+		action := app.appActionDeleteFiles(model.FileItem{Name: name, Hash: id})
 		action()
 
 		// Verify the log message
@@ -925,10 +1247,35 @@ func TestApp_actionSwitchToPerson_Log(t *testing.T) {
 	app.log.SetOutput(app.logView)
 	app.log.SetLevel(logrus.TraceLevel)
 
+	mockClient.EXPECT().GetUsage().Return(model.Usage{Bytes: 512 << 20, Files: 3}, model.Quota{Bytes: 1 << 30, Files: 10}, nil)
 	app.actionSwitchToPerson()
 
 	logLines := app.logView.GetText(true)
 	assert.Contains(t, logLines, "SwitchToPage person")
+	form := app.person.Form
+	assert.Equal(t, "[##########----------] 512.0 MiB of 1.0 GiB (50%)", form.GetFormItemByLabel("Storage").(*tview.TextView).GetText(true))
+	assert.Equal(t, "[######--------------] 3 of 10 (30%)", form.GetFormItemByLabel("Files").(*tview.TextView).GetText(true))
+	assert.Equal(t, "0 (no limit)", form.GetFormItemByLabel("Records").(*tview.TextView).GetText(true))
+
+	mockClient.EXPECT().GetUsage().Return(model.Usage{}, model.Quota{}, errors.New("usage error"))
+	app.actionSwitchToPerson()
+	assert.Contains(t, app.logView.GetText(true), "Error client GetUsage: usage error")
+	assert.Equal(t, 0, form.GetFormItemCount())
+}
+
+func TestFormatBytes(t *testing.T) {
+	assert.Equal(t, "0 B", formatBytes(0))
+	assert.Equal(t, "1023 B", formatBytes(1023))
+	assert.Equal(t, "1.5 KiB", formatBytes(1536))
+	assert.Equal(t, "20.0 MiB", formatBytes(20<<20))
+	assert.Equal(t, "2.0 TiB", formatBytes(2<<40))
+}
+
+func TestUsageBar(t *testing.T) {
+	count := func(n int64) string { return strconv.FormatInt(n, 10) }
+	assert.Equal(t, "[--------------------] 0 of 10 (0%)", usageBar(0, 10, count))
+	// превышение после уменьшения квоты
+	assert.Equal(t, "[####################] 15 of 10 (150%)", usageBar(15, 10, count))
 }
 
 func TestApp_actionSwitchToLogpassForm_Log(t *testing.T) {
@@ -1050,7 +1397,7 @@ func TestApp_appActionLoadFiles_Success(t *testing.T) {
 		{Name: "file2", Desc: "description2", Hash: "hash2"},
 	}
 
-	mockClient.EXPECT().GetFileList().Return(mockData, nil)
+	mockClient.EXPECT().ListFiles(gomock.Any()).Return(mockData, "", nil)
 
 	app.appActionLoadFiles()
 
@@ -1073,7 +1420,7 @@ func TestApp_appActionLoadFiles_Error(t *testing.T) {
 	app.log.SetOutput(app.logView)
 	app.log.SetLevel(logrus.TraceLevel)
 
-	mockClient.EXPECT().GetFileList().Return(nil, errors.New("fail load files"))
+	mockClient.EXPECT().ListFiles(gomock.Any()).Return(nil, "", errors.New("fail load files"))
 
 	app.appActionLoadFiles()
 
@@ -1101,7 +1448,7 @@ func TestApp_appActionLoadData_Success(t *testing.T) {
 		{ID: 2, Title: "data2", Type: "type2", Card: "card2", Login: "login2", Password: "password2"},
 	}
 
-	mockClient.EXPECT().GetDataList().Return(mockData, nil)
+	mockClient.EXPECT().ListData(gomock.Any()).Return(mockData, "", nil)
 
 	app.appActionLoadData()
 
@@ -1124,7 +1471,7 @@ func TestApp_appActionLoadData_Error(t *testing.T) {
 	app.log.SetOutput(app.logView)
 	app.log.SetLevel(logrus.TraceLevel)
 
-	mockClient.EXPECT().GetDataList().Return(nil, errors.New("fail load data"))
+	mockClient.EXPECT().ListData(gomock.Any()).Return(nil, "", errors.New("fail load data"))
 
 	app.appActionLoadData()
 
@@ -1132,3 +1479,530 @@ func TestApp_appActionLoadData_Error(t *testing.T) {
 	fmt.Printf("logLines: %v\n", logLines)
 	assert.NotContains(t, logLines, "ActionLoadData: Data loaded")
 }
+
+func TestApp_appActionEditData(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockGRPCClientInterface(ctrl)
+
+	app := NewEmptyApp()
+	app.client = mockClient
+	app.log = logrus.New()
+	app.log.SetOutput(app.logView)
+
+	t.Run("Success", func(t *testing.T) {
+		mockClient.EXPECT().GetData(int64(1)).Return(model.Data{
+			ID:       1,
+			Title:    "example.com",
+			Type:     "DATA_TYPE_TYPE_LOGIN_PASSWORD",
+			Login:    "user",
+			Password: "pass",
+		}, nil).Times(1)
+
+		app.appActionEditData(1)()
+
+		pageNames := app.pages.GetPageNames(false)
+		assert.Contains(t, pageNames, "dataeditform", "Page 'dataeditform' should be added")
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		mockClient.EXPECT().GetData(int64(2)).Return(model.Data{}, fmt.Errorf("get error")).Times(1)
+
+		app.appActionEditData(2)()
+
+		logLines := app.logView.GetText(true)
+		assert.Contains(t, logLines, "Error client GetData: get error")
+	})
+}
+
+func TestApp_appActionUpdateData(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockGRPCClientInterface(ctrl)
+
+	app := NewEmptyApp()
+	app.client = mockClient
+	app.log = logrus.New()
+	app.log.SetOutput(app.logView)
+
+	item := model.Data{
+		ID:           1,
+		Title:        "Visa",
+		Type:         "DATA_TYPE_TYPE_CREDIT_CARD",
+		Card:         "4111111111111111",
+		CardExpMonth: 3,
+		CardExpYear:  2030,
+		CardBrand:    model.CardBrandVisa,
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		app.createEditForm(item)
+		editForm := tview.NewForm().
+			AddInputField("Title", "Mastercard", 20, nil, nil)
+		edited := item
+		edited.Card = "5555 5555 5555 4444"
+		app.addCardFields(editForm, edited)
+
+		mockClient.EXPECT().UpdateData(model.Data{
+			ID:           1,
+			Title:        "Mastercard",
+			Type:         "DATA_TYPE_TYPE_CREDIT_CARD",
+			Card:         "5555555555554444",
+			CardExpMonth: 3,
+			CardExpYear:  2030,
+			CardBrand:    model.CardBrandMastercard,
+		}).Return(nil).Times(1)
+
+		app.appActionUpdateData(editForm, item)()
+
+		logLines := app.logView.GetText(true)
+		assert.Contains(t, logLines, "Updated ID: 1")
+	})
+
+	t.Run("WithMeta", func(t *testing.T) {
+		editForm := tview.NewForm().
+			AddInputField("Title", "Visa", 20, nil, nil)
+		app.addCardFields(editForm, item)
+		app.addMetaFields(editForm, map[string]string{"limit": "1000"}, []string{"salary", "main"})
+
+		withMeta := item
+		withMeta.Meta = map[string]string{"limit": "1000"}
+		withMeta.Tags = []string{"main", "salary"}
+		mockClient.EXPECT().UpdateData(withMeta).Return(nil).Times(1)
+
+		app.appActionUpdateData(editForm, item)()
+	})
+
+	t.Run("InvalidCard", func(t *testing.T) {
+		editForm := tview.NewForm().
+			AddInputField("Title", "Visa", 20, nil, nil)
+		edited := item
+		edited.CardExpMonth = 13
+		app.addCardFields(editForm, edited)
+
+		app.appActionUpdateData(editForm, item)()
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		editForm := tview.NewForm().
+			AddInputField("Title", "Visa", 20, nil, nil)
+		app.addCardFields(editForm, item)
+
+		mockClient.EXPECT().UpdateData(item).Return(errors.New("update error")).Times(1)
+
+		app.appActionUpdateData(editForm, item)()
+
+		logLines := app.logView.GetText(true)
+		assert.Contains(t, logLines, "Error client UpdateData: update error")
+	})
+}
+
+func TestAppLoadSessions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockGRPCClientInterface(ctrl)
+	mockClient.EXPECT().ListSessions().Return([]model.Session{
+		{ID: "sid1", DeviceInfo: "laptop"},
+		{ID: "sid2", DeviceInfo: "phone"},
+	}, nil)
+
+	app := NewEmptyApp()
+	app.client = mockClient
+	app.log = logrus.New()
+	app.storage = client.NewMemStorage()
+	app.storage.SetSession("token", "refresh", "sid2")
+
+	app.appActionLoadSessions()
+
+	name, page := app.pages.GetFrontPage()
+	assert.Equal(t, "sessions", name)
+	list, ok := page.(*tview.List)
+	assert.True(t, ok)
+	// Back, Logout и два сеанса
+	assert.Equal(t, 4, list.GetItemCount())
+	main, _ := list.GetItemText(3)
+	assert.Equal(t, "phone (current)", main)
+}
+
+func TestAppEndSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockGRPCClientInterface(ctrl)
+
+	app := NewEmptyApp()
+	app.client = mockClient
+	app.log = logrus.New()
+	app.storage = client.NewMemStorage()
+	app.storage.SetSession("token", "refresh", "sid1")
+	app.storage.Login = "user"
+	app.pages.AddPage("auth", tview.NewBox(), true, false)
+
+	// чужой сеанс - список перезагружается
+	mockClient.EXPECT().EndSession("sid2").Return(nil)
+	mockClient.EXPECT().ListSessions().Return(nil, nil)
+	app.appActionEndSession("sid2")()
+	assert.Equal(t, "user", app.storage.Login)
+
+	// текущий сеанс - возвращаемся на авторизацию
+	mockClient.EXPECT().EndSession("").Return(nil)
+	app.appActionEndSession("")()
+	assert.Empty(t, app.storage.Login)
+	name, _ := app.pages.GetFrontPage()
+	assert.Equal(t, "auth", name)
+}
+
+func TestApp_actionAuth_MasterKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockGRPCClientInterface(ctrl)
+
+	app := NewEmptyApp()
+	app.client = mockClient
+	app.storage = client.NewMemStorage()
+	app.log = logrus.New()
+
+	app.person.authForm.AddInputField("Login", "testuser", 20, nil, nil)
+	app.person.authForm.AddInputField("Password", "password", 20, nil, nil)
+	app.person.authForm.AddPasswordField("Master key", "master", 20, '*', nil)
+
+	mockClient.EXPECT().Authenticate("testuser", "password").Return(nil)
+	expectWatch(t, app, mockClient)
+
+	app.actionAuth()
+
+	expected := &client.MasterKey{Key: "master"}
+	expected.Derive("testuser")
+	assert.True(t, app.storage.MasterKey.IsSet())
+	assert.Equal(t, expected.Hash(), app.storage.MasterKey.Hash())
+}
+
+func TestApp_appActionSendNote(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockGRPCClientInterface(ctrl)
+
+	app := NewEmptyApp()
+	app.client = mockClient
+	app.storage = client.NewMemStorage()
+	app.log = logrus.New()
+	app.initDataInterfaces()
+
+	app.data.sendNoteForm.GetFormItemByLabel("Title").(*tview.InputField).SetText("wifi")
+	app.data.sendNoteForm.GetFormItemByLabel("Note").(*tview.TextArea).SetText("ssid: home\npass: 123", false)
+
+	mockClient.EXPECT().SaveNote("wifi", "ssid: home\npass: 123").Return(nil).Times(1)
+
+	app.appActionSendNote()
+}
+
+func TestApp_appActionSendBinary(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockGRPCClientInterface(ctrl)
+
+	app := NewEmptyApp()
+	app.client = mockClient
+	app.storage = client.NewMemStorage()
+	app.log = logrus.New()
+	app.initDataInterfaces()
+
+	blob := []byte{0x00, 0x01, 0xfe, 0xff}
+	filePath := filepath.Join(t.TempDir(), "key.bin")
+	assert.NoError(t, os.WriteFile(filePath, blob, 0600))
+
+	app.data.sendBinaryForm.GetFormItemByLabel("Title").(*tview.InputField).SetText("ssh key")
+	app.data.sendBinaryForm.GetFormItemByLabel("File Path").(*tview.InputField).SetText(filePath)
+
+	mockClient.EXPECT().SaveBinary("ssh key", blob).Return(nil).Times(1)
+	app.appActionSendBinary()
+
+	// слишком большой файл не отправляется
+	large := filepath.Join(t.TempDir(), "large.bin")
+	assert.NoError(t, os.WriteFile(large, make([]byte, model.MaxBinarySize+1), 0600))
+	app.data.sendBinaryForm.GetFormItemByLabel("File Path").(*tview.InputField).SetText(large)
+	app.appActionSendBinary()
+}
+
+func TestApp_appActionSaveBinary(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockGRPCClientInterface(ctrl)
+
+	app := NewEmptyApp()
+	app.client = mockClient
+	app.storage = &client.MemStorage{PfilesDir: t.TempDir()}
+	app.log = logrus.New()
+
+	blob := []byte{0x00, 0x01, 0xfe, 0xff}
+	mockClient.EXPECT().GetData(int64(7)).Return(model.Data{ID: 7, Title: "key.bin", Binary: blob}, nil)
+
+	app.appActionSaveBinary(7)()
+
+	got, err := os.ReadFile(filepath.Join(app.storage.PfilesDir, "key.bin"))
+	assert.NoError(t, err)
+	assert.Equal(t, blob, got)
+}
+
+// expectWatch allows the change feed opened after login, the feed is blocked until it is stopped
+func expectWatch(t *testing.T, app *App, mockClient *mocks.MockGRPCClientInterface) {
+	t.Cleanup(func() {
+		if app.stopWatch != nil {
+			app.stopWatch()
+		}
+	})
+	mockClient.EXPECT().WatchChanges(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, _ func(model.ChangeEvent)) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}).AnyTimes()
+}
+
+func TestApp_onChange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockGRPCClientInterface(ctrl)
+	app := NewEmptyApp()
+	app.client = mockClient
+	app.log = logrus.New()
+
+	// список еще не открыт
+	app.onChange(model.ChangeEvent{Op: model.ChangeCreated, DataID: 1})
+
+	mockClient.EXPECT().ListData(client.ListOptions{Tags: []string{"work"}}).
+		Return([]model.Data{{ID: 1, Title: "first"}}, "", nil)
+	assert.NoError(t, app.loadDataPage("#work"))
+
+	// события файлов список записей не трогают
+	app.onChange(model.ChangeEvent{Op: model.ChangeCreated, FileName: "a.txt"})
+
+	mockClient.EXPECT().ListData(client.ListOptions{Tags: []string{"work"}}).
+		Return([]model.Data{{ID: 1, Title: "first"}, {ID: 2, Title: "second"}}, "", nil)
+	app.onChange(model.ChangeEvent{Op: model.ChangeCreated, DataID: 2})
+
+	_, front := app.pages.GetFrontPage()
+	list := front.(*tview.Flex).GetItem(1).(*tview.List)
+	assert.Equal(t, 3, list.GetItemCount(), "Back and two records")
+
+	// открыта форма, ввод пользователя не теряется
+	app.pages.SwitchToPage("main")
+	app.onChange(model.ChangeEvent{Op: model.ChangeDeleted, DataID: 2})
+}
+
+// pressConflictButton нажимает кнопку диалога конфликта версий
+func pressConflictButton(t *testing.T, app *App, index int) {
+	name, front := app.pages.GetFrontPage()
+	assert.Equal(t, "conflict", name)
+	modal := front.(*tview.Modal)
+	modal.SetFocus(index)
+	var focus func(p tview.Primitive)
+	focus = func(p tview.Primitive) { p.Focus(focus) }
+	modal.Focus(focus)
+	modal.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), focus)
+}
+
+func TestApp_updateData_Conflict(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockGRPCClientInterface(ctrl)
+	app := NewEmptyApp()
+	app.client = mockClient
+	app.log = logrus.New()
+	app.log.SetOutput(app.logView)
+	app.pages.AddPage("datalist", tview.NewBox(), true, false)
+
+	mine := model.Data{ID: 5, Title: "mine", Revision: 3}
+	server := model.Data{ID: 5, Title: "server", Revision: 8}
+
+	// Keep mine - повтор поверх версии сервера
+	mockClient.EXPECT().UpdateData(mine).Return(&client.ConflictError{Data: &server})
+	app.updateData(mine)
+	mockClient.EXPECT().UpdateData(model.Data{ID: 5, Title: "mine", Revision: 8}).Return(nil)
+	pressConflictButton(t, app, 0)
+	name, _ := app.pages.GetFrontPage()
+	assert.Equal(t, "datalist", name)
+
+	// Take server - открывается копия сервера
+	mockClient.EXPECT().UpdateData(mine).Return(&client.ConflictError{Data: &server})
+	app.updateData(mine)
+	pressConflictButton(t, app, 1)
+	name, front := app.pages.GetFrontPage()
+	assert.Equal(t, "dataeditform", name)
+	title := front.(*tview.Form).GetFormItemByLabel("Title").(*tview.InputField)
+	assert.Equal(t, "server", title.GetText())
+
+	// Cancel
+	mockClient.EXPECT().UpdateData(mine).Return(&client.ConflictError{Data: &server})
+	app.updateData(mine)
+	pressConflictButton(t, app, 2)
+	name, _ = app.pages.GetFrontPage()
+	assert.Equal(t, "datalist", name)
+	assert.False(t, app.pages.HasPage("conflict"))
+}
+
+func TestApp_deleteFile_Conflict(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockGRPCClientInterface(ctrl)
+	app := NewEmptyApp()
+	app.client = mockClient
+	app.log = logrus.New()
+	app.log.SetOutput(app.logView)
+	app.pages.AddPage("datalist", tview.NewBox(), true, false)
+
+	server := model.FileItem{Name: "a.txt", Hash: "h", Revision: 6}
+	mockClient.EXPECT().DeleteFile("a.txt", int64(2)).Return(&client.ConflictError{File: &server})
+	app.appActionDeleteFiles(model.FileItem{Name: "a.txt", Hash: "h", Revision: 2})()
+
+	mockClient.EXPECT().DeleteFile("a.txt", int64(6)).Return(nil)
+	pressConflictButton(t, app, 0)
+	assert.Contains(t, app.logView.GetText(true), "Moved to trash ID: h")
+}
+
+func TestApp_history(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockGRPCClientInterface(ctrl)
+	app := NewEmptyApp()
+	app.client = mockClient
+	app.log = logrus.New()
+	app.log.SetOutput(app.logView)
+	app.pages.AddPage("datalist", tview.NewBox(), true, false)
+
+	item := model.Data{ID: 5, Type: "DATA_TYPE_TYPE_LOGIN_PASSWORD", Title: "site", Password: "new", Revision: 9}
+	mockClient.EXPECT().ListDataHistory(int64(5)).Return([]model.DataRevision{
+		{Data: model.Data{ID: 5, Type: item.Type, Title: "site", Password: "old", Revision: 4}, ChangedAt: time.Now()},
+	}, nil)
+	app.appActionLoadHistory(item)()
+
+	name, front := app.pages.GetFrontPage()
+	assert.Equal(t, "datahistory", name)
+	list := front.(*tview.List)
+	assert.Equal(t, 2, list.GetItemCount(), "Back and one version")
+	main, _ := list.GetItemText(1)
+	assert.Equal(t, "Version 4", main)
+
+	list.SetCurrentItem(1)
+	list.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), func(tview.Primitive) {})
+	name, front = app.pages.GetFrontPage()
+	assert.Equal(t, "datahistoryrevision", name)
+	assert.Equal(t, "old", front.(*tview.Form).GetFormItemByLabel("Pass").(*tview.TextView).GetText(true))
+
+	// запись изменилась, пока открыта история - восстанавливаем поверх версии сервера
+	server := model.Data{ID: 5, Title: "site", Revision: 11}
+	mockClient.EXPECT().RestoreDataRevision(int64(5), int64(4), int64(9)).Return(&client.ConflictError{Data: &server})
+	app.appActionRestoreRevision(item, 4)()
+	mockClient.EXPECT().RestoreDataRevision(int64(5), int64(4), int64(11)).Return(nil)
+	pressConflictButton(t, app, 0)
+
+	assert.Contains(t, app.logView.GetText(true), "Restored version 4 of ID: 5")
+	name, _ = app.pages.GetFrontPage()
+	assert.Equal(t, "datalist", name)
+
+	mockClient.EXPECT().ListDataHistory(int64(5)).Return(nil, errors.New("history error"))
+	app.appActionLoadHistory(item)()
+	assert.Contains(t, app.logView.GetText(true), "Error client ListDataHistory: history error")
+}
+
+func TestApp_trash(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockGRPCClientInterface(ctrl)
+	app := NewEmptyApp()
+	app.client = mockClient
+	app.log = logrus.New()
+	app.log.SetOutput(app.logView)
+
+	deleted := time.Now()
+	note := model.TrashItem{Data: &model.Data{ID: 5, Title: "note", Deleted: true}, DeletedAt: deleted, PurgeAt: deleted.Add(time.Hour)}
+	file := model.TrashItem{File: &model.FileItem{Name: "a.txt", Deleted: true}, DeletedAt: deleted, PurgeAt: deleted.Add(time.Hour)}
+	mockClient.EXPECT().ListTrash().Return([]model.TrashItem{file, note}, nil)
+	app.appActionLoadTrash()
+
+	name, front := app.pages.GetFrontPage()
+	assert.Equal(t, "trash", name)
+	list := front.(*tview.List)
+	assert.Equal(t, 3, list.GetItemCount(), "Back and two items")
+	main, _ := list.GetItemText(1)
+	assert.Equal(t, "File: a.txt", main)
+
+	list.SetCurrentItem(2)
+	list.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), func(tview.Primitive) {})
+	name, front = app.pages.GetFrontPage()
+	assert.Equal(t, "trashaction", name)
+	assert.Equal(t, "Data: note", front.(*tview.Form).GetFormItemByLabel("Name").(*tview.TextView).GetText(true))
+
+	mockClient.EXPECT().RestoreFromTrash(note).Return(nil)
+	mockClient.EXPECT().ListTrash().Return([]model.TrashItem{file}, nil)
+	app.appActionRestoreFromTrash(note)()
+	assert.Contains(t, app.logView.GetText(true), "Restored: Data: note")
+	name, _ = app.pages.GetFrontPage()
+	assert.Equal(t, "trash", name)
+
+	mockClient.EXPECT().RestoreFromTrash(file).Return(errors.New("restore error"))
+	app.appActionRestoreFromTrash(file)()
+	assert.Contains(t, app.logView.GetText(true), "Error client RestoreFromTrash: restore error")
+}
+
+func TestApp_fileVersions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockGRPCClientInterface(ctrl)
+	app := NewEmptyApp()
+	app.client = mockClient
+	app.log = logrus.New()
+	app.log.SetOutput(app.logView)
+	app.pages.AddPage("datalist", tview.NewBox(), true, false)
+
+	item := model.FileItem{Name: "a.txt", Revision: 5}
+	mockClient.EXPECT().ListFileVersions("a.txt").Return([]model.FileVersion{
+		{VersionID: "v2", Size: 20, ModifiedAt: time.Now(), Latest: true},
+		{VersionID: "v1", Size: 10, ModifiedAt: time.Now()},
+	}, nil)
+	app.appActionLoadFileVersions(item)()
+
+	name, front := app.pages.GetFrontPage()
+	assert.Equal(t, "fileversions", name)
+	list := front.(*tview.List)
+	assert.Equal(t, 3, list.GetItemCount(), "Back and two versions")
+	main, _ := list.GetItemText(1)
+	assert.Contains(t, main, "(current)")
+
+	list.SetCurrentItem(2)
+	list.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), func(tview.Primitive) {})
+	name, front = app.pages.GetFrontPage()
+	assert.Equal(t, "fileversion", name)
+	form := front.(*tview.Form)
+	assert.Equal(t, "v1", form.GetFormItemByLabel("Version").(*tview.TextView).GetText(true))
+	assert.GreaterOrEqual(t, form.GetButtonIndex("Restore"), 0)
+
+	mockClient.EXPECT().GetFileVersion("a.txt", "v1").Return(nil)
+	app.appActionGetFileVersion("a.txt", "v1")()
+	assert.Contains(t, app.logView.GetText(true), "Got version v1 of a.txt")
+
+	// файл изменили на другом устройстве - восстанавливаем поверх версии сервера
+	mockClient.EXPECT().RestoreFileVersion("a.txt", "v1", int64(5)).Return(&client.ConflictError{File: &model.FileItem{Name: "a.txt", Revision: 8}})
+	app.appActionRestoreFileVersion(item, "v1")()
+	mockClient.EXPECT().RestoreFileVersion("a.txt", "v1", int64(8)).Return(nil)
+	pressConflictButton(t, app, 0)
+	assert.Contains(t, app.logView.GetText(true), "Restored version v1 of a.txt")
+	name, _ = app.pages.GetFrontPage()
+	assert.Equal(t, "datalist", name)
+
+	mockClient.EXPECT().ListFileVersions("a.txt").Return(nil, errors.New("versions error"))
+	app.appActionLoadFileVersions(item)()
+	assert.Contains(t, app.logView.GetText(true), "Error client ListFileVersions: versions error")
+}
@@ -3,9 +3,13 @@ package server
 import (
 	"context"
 	"database/sql"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/Arcadian-Sky/datakkeeper/internal/model"
 	"github.com/Arcadian-Sky/datakkeeper/internal/server/repository"
+	"github.com/Arcadian-Sky/datakkeeper/internal/server/router/jwtrule"
 	"github.com/Arcadian-Sky/datakkeeper/internal/settings"
 	"github.com/Arcadian-Sky/datakkeeper/migrations"
 	minioclient "github.com/Arcadian-Sky/datakkeeper/tools/client"
@@ -14,20 +18,31 @@ import (
 	"github.com/minio/minio-go/v7/pkg/credentials"
 	"github.com/pressly/goose/v3"
 	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/connstring"
 )
 
 type Workers struct {
 	userRepo repository.UserRepository
 	dataRepo repository.DataRepository
 	fileRepo repository.FileRepository
+	sessRepo repository.SessionRepository
+	metaRepo repository.ItemMetaRepository
+	syncRepo repository.SyncRepository
+	upRepo   repository.UploadRepository
 }
 type App struct {
 	Logger *logrus.Logger
-	DBPG   *sql.DB
-	// DBMG   *mongo.Client
+	// DBPG - postgres или встроенная SQLite, см. DBDriver
+	DBPG     *sql.DB
+	DBDriver string
+	// DBMG - MongoDB с метаданными файлов, nil без MG_DATABASE_URI
+	DBMG *mongo.Client
 	// Storage *minio.Client
 	Storage minioclient.MinioClient
 	Flags   *settings.InitedFlags
+	Keys    jwtrule.Keyring
 	Ctx     context.Context
 	CncF    context.CancelFunc
 	Workers *Workers
@@ -60,6 +75,14 @@ func (ap *App) SetDBPG(db *sql.DB) {
 	ap.DBPG = db
 }
 
+func (ap *App) SetDBDriver(driver string) {
+	ap.DBDriver = driver
+}
+
+func (ap *App) SetDBMG(client *mongo.Client) {
+	ap.DBMG = client
+}
+
 func (ap *App) SetStorage(db minioclient.MinioClient) {
 	ap.Storage = db
 }
@@ -86,6 +109,72 @@ func NewConnectionToPostgresDB(dsn string, logg *logrus.Logger) (*sql.DB, error)
 	return db, err
 }
 
+// Базы метаданных, выбираются схемой DSN
+const (
+	DBPostgres = "postgres"
+	DBSQLite   = "sqlite"
+)
+
+// sqliteScheme - DSN встроенной SQLite: sqlite://путь/к/файлу.db
+const sqliteScheme = "sqlite://"
+
+// DBDriver returns the database for the DSN: sqlite:// is the embedded SQLite, anything else is postgres
+func DBDriver(dsn string) string {
+	if strings.HasPrefix(dsn, sqliteScheme) {
+		return DBSQLite
+	}
+	return DBPostgres
+}
+
+// NewConnectionToDB connects to postgres or opens the embedded SQLite by the DSN scheme
+func NewConnectionToDB(dsn string, logg *logrus.Logger) (*sql.DB, error) {
+	if DBDriver(dsn) == DBSQLite {
+		return NewConnectionToSQLiteDB(dsn, logg)
+	}
+	return NewConnectionToPostgresDB(dsn, logg)
+}
+
+// Подключение к встроенной SQLite, файл базы создается при первом запуске
+func NewConnectionToSQLiteDB(dsn string, logg *logrus.Logger) (*sql.DB, error) {
+	db, err := repository.OpenSQLite(strings.TrimPrefix(dsn, sqliteScheme))
+	if err != nil {
+		logg.Error("failed to open the sqlite database", err)
+		return nil, err
+	}
+
+	if err = db.Ping(); err != nil {
+		db.Close()
+		logg.Error("failed to ping the sqlite database", err)
+		return nil, err
+	}
+
+	logg.Log(logrus.InfoLevel, "Successfully opened SQLite database")
+	return db, nil
+}
+
+// mongoDefaultDatabase - база метаданных, если она не указана в DSN
+const mongoDefaultDatabase = "datakeeper"
+
+// Подключение к MongoDB
+func NewConnectionToMongoDB(ctx context.Context, dsn string, logg *logrus.Logger) (*mongo.Client, error) {
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(dsn))
+	if err != nil {
+		logg.Error("failed to create a mongodb connection", err)
+		return nil, err
+	}
+
+	pingCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	if err = client.Ping(pingCtx, nil); err != nil {
+		client.Disconnect(ctx)
+		logg.Error("failed to ping the mongodb", err)
+		return nil, err
+	}
+
+	logg.Log(logrus.InfoLevel, "Successfully connected to MongoDB")
+	return client, nil
+}
+
 // Подключение к minio
 func NewСonnectToMinIO(ctx context.Context, settings settings.Storage, logg *logrus.Logger) (minioclient.MinioClient, error) {
 
@@ -114,6 +203,35 @@ func NewСonnectToMinIO(ctx context.Context, settings settings.Storage, logg *lo
 	return minioclient.NewMinioClient(client), nil
 }
 
+// NewFileRepo returns the file storage chosen by settings: MinIO or user directories on the server disk.
+// Хранилище содержимого MinIO подключается и без дедупликации: сохраненные с ней файлы ссылаются на него.
+func (ap *App) NewFileRepo(st settings.Storage) (repository.FileRepository, error) {
+	if st.Backend == settings.StorageLocal {
+		if st.Dedup {
+			ap.Logger.Warn("Deduplication is not supported by the local storage")
+		}
+		repo := repository.NewLocalFileRepository(st.Dir, ap.Logger)
+		if err := repo.Init(); err != nil {
+			return nil, err
+		}
+		ap.Logger.Info("Files are stored in ", st.Dir)
+		return repo, nil
+	}
+
+	client, err := NewСonnectToMinIO(ap.Ctx, st, ap.Logger)
+	if err != nil {
+		return nil, err
+	}
+	ap.SetStorage(client)
+	repo := repository.NewFileRepository(client, ap.Logger, &ap.Ctx)
+	blobs := repository.NewBlobRepository(ap.DBPG, client, ap.Logger)
+	if err := blobs.Init(ap.Ctx); err != nil {
+		return nil, err
+	}
+	repo.SetBlobs(blobs, st.Dedup)
+	return repo, nil
+}
+
 func (ap *App) SetDataRepo(dR repository.DataRepository) {
 	ap.Workers.dataRepo = dR
 }
@@ -130,12 +248,85 @@ func (ap *App) GetUserRepo() repository.UserRepository {
 	return ap.Workers.userRepo
 }
 
-func (ap *App) MigrateDBPG() error {
-	goose.SetBaseFS(migrations.Migrations)
+// Репозиторий сеансов пользователя
+func (ap *App) SetSessionRepo(sR repository.SessionRepository) {
+	ap.Workers.sessRepo = sR
+}
+func (ap *App) GetSessionRepo() repository.SessionRepository {
+	return ap.Workers.sessRepo
+}
+
+// Репозиторий пользовательских метаданных записей и файлов
+func (ap *App) SetItemMetaRepo(mR repository.ItemMetaRepository) {
+	ap.Workers.metaRepo = mR
+}
+func (ap *App) GetItemMetaRepo() repository.ItemMetaRepository {
+	return ap.Workers.metaRepo
+}
+
+// NewItemMetaRepo returns the metadata repository: SQL or, with mgDSN set, MongoDB for file metadata.
+// Метаданные записей остаются в SQL: по ним список записей фильтруется запросом к базе.
+func (ap *App) NewItemMetaRepo(mgDSN string) (repository.ItemMetaRepository, error) {
+	sqlRepo := repository.NewItemMetaRepository(ap.DBPG, ap.Logger)
+	if mgDSN == "" {
+		return sqlRepo, nil
+	}
+
+	cs, err := connstring.ParseAndValidate(mgDSN)
+	if err != nil {
+		return nil, err
+	}
+	dbName := cs.Database
+	if dbName == "" {
+		dbName = mongoDefaultDatabase
+	}
+
+	client, err := NewConnectionToMongoDB(ap.Ctx, mgDSN, ap.Logger)
+	if err != nil {
+		return nil, err
+	}
+	ap.SetDBMG(client)
+
+	mgRepo := repository.NewMongoItemMetaRepository(client.Database(dbName), ap.Logger)
+	ctx, cancel := context.WithTimeout(ap.Ctx, 10*time.Second)
+	defer cancel()
+	if err := mgRepo.Init(ctx); err != nil {
+		return nil, err
+	}
+	ap.Logger.Info("File metadata is stored in MongoDB database ", dbName)
+	return repository.NewKindItemMetaRepository(sqlRepo, model.MetaKindFile, mgRepo), nil
+}
+
+// Репозиторий ревизий для синхронизации клиентов
+func (ap *App) SetSyncRepo(sR repository.SyncRepository) {
+	ap.Workers.syncRepo = sR
+}
+func (ap *App) GetSyncRepo() repository.SyncRepository {
+	return ap.Workers.syncRepo
+}
+
+// Репозиторий загрузок файлов по частям
+func (ap *App) SetUploadRepo(uR repository.UploadRepository) {
+	ap.Workers.upRepo = uR
+}
+func (ap *App) GetUploadRepo() repository.UploadRepository {
+	return ap.Workers.upRepo
+}
+
+// MigrateDB applies the migrations of the database: postgres or the own set of SQLite
+func (ap *App) MigrateDB() error {
+	dialect, fsys, dir := "postgres", migrations.Migrations, "."
+	if ap.DBDriver == DBSQLite {
+		dialect, fsys, dir = "sqlite3", migrations.SQLite, "sqlite"
+	}
+	goose.SetBaseFS(fsys)
+	if err := goose.SetDialect(dialect); err != nil {
+		return err
+	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
-	err := goose.RunContext(ctx, "up", ap.DBPG, ".")
+	err := goose.RunContext(ctx, "up", ap.DBPG, dir)
 	if err != nil {
 		return err
 	}
@@ -143,6 +334,15 @@ func (ap *App) MigrateDBPG() error {
 	return nil
 }
 
+// NewDataRepos returns repositories of records and revisions for the database,
+// у SQLite свои запросы изменений: в ней нет изменяющих CTE
+func (ap *App) NewDataRepos() (repository.DataRepository, repository.SyncRepository) {
+	if ap.DBDriver == DBSQLite {
+		return repository.NewSQLiteDataRepository(ap.DBPG, ap.Logger), repository.NewSQLiteSyncRepository(ap.DBPG, ap.Logger)
+	}
+	return repository.NewDataRepository(ap.DBPG, ap.Logger), repository.NewSyncRepository(ap.DBPG, ap.Logger)
+}
+
 // Репозиторий по работе с документами
 func (ap *App) SetDBFileRepo(fR repository.FileRepository) {
 	ap.Workers.fileRepo = fR
@@ -150,3 +350,126 @@ func (ap *App) SetDBFileRepo(fR repository.FileRepository) {
 func (ap *App) GetFileRepo() repository.FileRepository {
 	return ap.Workers.fileRepo
 }
+
+// Ключи подписи JWT: постоянный ключ из настроек или ротация через postgres
+func (ap *App) InitKeyring() error {
+	if ap.Flags.JWT.Source != settings.JWTSourceDB {
+		if ap.Flags.JWT.Source == settings.JWTSourceGenerated {
+			ap.Logger.Warn("jwt signing key is generated on start, tokens will not survive restart")
+		}
+		ap.Keys = jwtrule.NewStaticKeyring(ap.Flags.SecretKey)
+		return nil
+	}
+
+	keys := jwtrule.NewRotatingKeyring(
+		repository.NewKeyRepository(ap.DBPG, ap.Logger),
+		ap.Flags.JWT.Rotation,
+		ap.Flags.JWT.Overlap,
+		ap.Logger,
+	)
+	ctx, cancel := context.WithTimeout(ap.Ctx, 5*time.Second)
+	defer cancel()
+	if err := keys.Load(ctx); err != nil {
+		return err
+	}
+	go keys.Run(ap.Ctx, time.Minute)
+
+	ap.Keys = keys
+	return nil
+}
+
+// PurgeTrash окончательно удаляет записи и файлы, пролежавшие в корзине дольше retention.
+// Ошибки по отдельным элементам только логируются, чтобы не останавливать очистку остальных.
+func (ap *App) PurgeTrash(ctx context.Context, retention time.Duration) error {
+	before := time.Now().Add(-retention)
+
+	data, err := ap.Workers.dataRepo.Purge(ctx, before)
+	if err != nil {
+		return err
+	}
+	for _, item := range data {
+		meta := &model.ItemMeta{UserID: item.UserID, Kind: model.MetaKindData, ItemID: strconv.FormatInt(item.DataID, 10)}
+		if err := ap.Workers.metaRepo.Delete(ctx, meta); err != nil {
+			ap.Logger.WithError(err).Error("failed to delete purged pdata metadata")
+		}
+	}
+
+	// файл отмечается стертым только после удаления из хранилища, иначе он остался бы там навсегда
+	files, err := ap.Workers.syncRepo.ExpiredFiles(ctx, before)
+	if err != nil {
+		return err
+	}
+	purged := 0
+	for _, item := range files {
+		if err := ap.Workers.fileRepo.PurgeFile(ctx, item.FileName, &model.User{ID: item.UserID}); err != nil {
+			ap.Logger.WithError(err).Error("failed to purge file")
+			continue
+		}
+		if err := ap.Workers.syncRepo.MarkFilePurged(ctx, item.UserID, item.FileName, before); err != nil {
+			ap.Logger.WithError(err).Error("failed to mark file purged")
+			continue
+		}
+		purged++
+		meta := &model.ItemMeta{UserID: item.UserID, Kind: model.MetaKindFile, ItemID: item.FileName}
+		if err := ap.Workers.metaRepo.Delete(ctx, meta); err != nil {
+			ap.Logger.WithError(err).Error("failed to delete purged file metadata")
+		}
+	}
+
+	if len(data)+purged > 0 {
+		ap.Logger.Infof("trash purged: %d records, %d files", len(data), purged)
+	}
+	return nil
+}
+
+// RunTrashPurge очищает корзину сразу и затем каждые interval, пока не отменен ctx
+func (ap *App) RunTrashPurge(ctx context.Context, retention, interval time.Duration) {
+	runEvery(ctx, interval, func() {
+		if err := ap.PurgeTrash(ctx, retention); err != nil {
+			ap.Logger.WithError(err).Error("failed to purge trash")
+		}
+	})
+}
+
+// RunVersionExpiry удаляет истекшие прежние версии файлов сразу и затем каждые interval, пока не отменен ctx
+func (ap *App) RunVersionExpiry(ctx context.Context, interval time.Duration) {
+	runEvery(ctx, interval, func() {
+		if err := ap.Workers.fileRepo.ExpireVersions(ctx, time.Now()); err != nil {
+			ap.Logger.WithError(err).Error("failed to expire file versions")
+		}
+	})
+}
+
+// PurgeUploads удаляет загрузки, которые не продолжались дольше maxAge
+func (ap *App) PurgeUploads(ctx context.Context, maxAge time.Duration) error {
+	sessions, err := ap.Workers.upRepo.PurgeExpired(ctx, time.Now().Add(-maxAge))
+	if err != nil {
+		return err
+	}
+	if len(sessions) > 0 {
+		ap.Logger.Infof("abandoned uploads purged: %d", len(sessions))
+	}
+	return nil
+}
+
+// RunUploadPurge удаляет брошенные загрузки сразу и затем каждые interval, пока не отменен ctx
+func (ap *App) RunUploadPurge(ctx context.Context, maxAge, interval time.Duration) {
+	runEvery(ctx, interval, func() {
+		if err := ap.PurgeUploads(ctx, maxAge); err != nil {
+			ap.Logger.WithError(err).Error("failed to purge uploads")
+		}
+	})
+}
+
+func runEvery(ctx context.Context, interval time.Duration, job func()) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		job()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -9,23 +9,67 @@ import (
 	"github.com/Arcadian-Sky/datakkeeper/internal/settings"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 type GRPCClientInterface interface {
 	Register(login, password string) error
 	Authenticate(login, password string) error
+	RefreshSession() error
+	ListSessions() ([]model.Session, error)
+	EndSession(sessionID string) error
 
 	GetDataList() ([]model.Data, error)
+	ListData(opts ListOptions) ([]model.Data, string, error)
 	SaveLoginPass(domain, login, pass string) error
-	SaveCard(title, card string) error
-	Delete(id int64) error
+	SaveCard(card model.Data) error
+	SaveNote(title, note string) error
+	SaveBinary(title string, data []byte) error
+	GetData(id int64) (model.Data, error)
+	UpdateData(data model.Data) error
+	Delete(id, revision int64) error
+	ListDataHistory(id int64) ([]model.DataRevision, error)
+	RestoreDataRevision(id, revision, expected int64) error
 
 	GetFileList() ([]model.FileItem, error)
-	DeleteFile(fileName string) error
-	UploadFile(filePath string) error
+	ListFiles(opts ListOptions) ([]model.FileItem, string, error)
+	DeleteFile(fileName string, revision int64) error
+	UpdateFileMeta(fileName string, revision int64, desc string, meta map[string]string, tags []string) error
+	UploadFile(filePath, folder string) error
 	GetFile(fileName string) error
+	ListFileVersions(fileName string) ([]model.FileVersion, error)
+	GetFileVersion(fileName, versionID string) error
+	RestoreFileVersion(fileName, versionID string, revision int64) error
+	CreateFolder(path string) error
+	RenameFile(fileName, newName string, revision int64) error
+	MoveFile(fileName, folder string, revision int64) error
+
+	ListTrash() ([]model.TrashItem, error)
+	RestoreFromTrash(item model.TrashItem) error
+
+	Sync(since int64) (*model.SyncChanges, error)
+	WatchChanges(ctx context.Context, onEvent func(model.ChangeEvent)) error
+
+	GetUsage() (model.Usage, model.Quota, error)
+}
+
+// ListOptions - фильтры и страница списков, PageToken берется из предыдущего ответа
+type ListOptions struct {
+	// Types - типы записей (имена DataType) или расширения файлов
+	Types []string
+	// Search - подстрока названия или тега без учета регистра
+	Search    string
+	Tags      []string
+	ByTitle   bool
+	Desc      bool
+	PageSize  int32
+	PageToken string
+	// Browse - только содержимое папки Parent, вложенные папки приходят отдельными элементами
+	Browse bool
+	Parent string
 }
 
 type GRPCClient struct {
@@ -61,8 +105,9 @@ func NewGclient(clientConfig settings.ClientConfig, mstorage *MemStorage, lg *lo
 var (
 	// we don't need to check the token for these methods.
 	SkipCheckMethods = map[string]struct{}{
-		"/proto.api.user.v1.UserService/Register":     {},
-		"/proto.api.user.v1.UserService/Authenticate": {},
+		"/proto.api.user.v1.UserService/Register":       {},
+		"/proto.api.user.v1.UserService/Authenticate":   {},
+		"/proto.api.user.v1.UserService/RefreshSession": {},
 	}
 )
 
@@ -76,12 +121,32 @@ func getUnaryClientInterceptor(mstorage *MemStorage) grpc.UnaryClientInterceptor
 		opts ...grpc.CallOption,
 	) error {
 		_, ok := SkipCheckMethods[method]
-		if !ok {
-			ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+mstorage.Token)
+		if ok {
+			return invoker(ctx, method, req, reply, cc, opts...)
 		}
 
-		return invoker(ctx, method, req, reply, cc, opts...)
+		err := invoker(metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+mstorage.Token), method, req, reply, cc, opts...)
+		if status.Code(err) != codes.Unauthenticated || mstorage.RefreshToken == "" {
+			return err
+		}
+
+		// токен истек - продлеваем сеанс и повторяем запрос один раз
+		if rerr := refreshSession(ctx, cc, invoker, mstorage); rerr != nil {
+			return err
+		}
+		return invoker(metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+mstorage.Token), method, req, reply, cc, opts...)
+	}
+}
+
+func refreshSession(ctx context.Context, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, mstorage *MemStorage) error {
+	req := &pb.RefreshSessionRequest{RefreshToken: mstorage.RefreshToken}
+	res := &pb.RefreshSessionResponse{}
+	err := invoker(ctx, pb.UserService_RefreshSession_FullMethodName, req, res, cc)
+	if err != nil {
+		return err
 	}
+	mstorage.SetSession(res.AuthToken, res.RefreshToken, mstorage.SessionID)
+	return nil
 }
 
 func getStreamClientInterceptor(mstorage *MemStorage) grpc.StreamClientInterceptor {
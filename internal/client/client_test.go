@@ -4,13 +4,16 @@ import (
 	"context"
 	"testing"
 
+	pb "github.com/Arcadian-Sky/datakkeeper/gen/proto/api/user/v1"
 	"github.com/Arcadian-Sky/datakkeeper/internal/settings"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 func TestNewGclient_Success(t *testing.T) {
@@ -121,6 +124,81 @@ func TestUnaryClientInterceptor_SkipToken(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+// Test that the interceptor refreshes an expired token once and repeats the call
+func TestUnaryClientInterceptor_RefreshesSession(t *testing.T) {
+	mockStorage := &MemStorage{
+		Token:        "expired-token",
+		RefreshToken: "refresh-token",
+		SessionID:    "sid1",
+	}
+
+	testMethod := "/TestService/Method"
+	SkipCheckMethods = map[string]struct{}{}
+
+	calls := 0
+	mockInvoker := func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		opts ...grpc.CallOption,
+	) error {
+		if method == pb.UserService_RefreshSession_FullMethodName {
+			assert.Equal(t, "refresh-token", req.(*pb.RefreshSessionRequest).RefreshToken)
+			res := reply.(*pb.RefreshSessionResponse)
+			res.AuthToken = "fresh-token"
+			res.RefreshToken = "next-refresh-token"
+			return nil
+		}
+		calls++
+		md, _ := metadata.FromOutgoingContext(ctx)
+		if md["authorization"][0] == "Bearer expired-token" {
+			return status.Error(codes.Unauthenticated, "token expired")
+		}
+		assert.Equal(t, []string{"Bearer fresh-token"}, md["authorization"])
+		return nil
+	}
+
+	interceptor := getUnaryClientInterceptor(mockStorage)
+
+	err := interceptor(context.Background(), testMethod, nil, nil, nil, mockInvoker)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, "fresh-token", mockStorage.Token)
+	assert.Equal(t, "next-refresh-token", mockStorage.RefreshToken)
+	assert.Equal(t, "sid1", mockStorage.SessionID)
+}
+
+// Test that the interceptor returns the original error when refresh is rejected
+func TestUnaryClientInterceptor_RefreshRejected(t *testing.T) {
+	mockStorage := &MemStorage{
+		Token:        "expired-token",
+		RefreshToken: "revoked-token",
+	}
+
+	testMethod := "/TestService/Method"
+	SkipCheckMethods = map[string]struct{}{}
+
+	mockInvoker := func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		opts ...grpc.CallOption,
+	) error {
+		if method == pb.UserService_RefreshSession_FullMethodName {
+			return status.Error(codes.Unauthenticated, "session revoked")
+		}
+		return status.Error(codes.Unauthenticated, "token expired")
+	}
+
+	interceptor := getUnaryClientInterceptor(mockStorage)
+
+	err := interceptor(context.Background(), testMethod, nil, nil, nil, mockInvoker)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	assert.Equal(t, "expired-token", mockStorage.Token)
+}
+
 // Test that the stream interceptor adds the authorization token when the method is not skipped
 func TestStreamClientInterceptor_AddsToken(t *testing.T) {
 	ctrl := gomock.NewController(t)
@@ -0,0 +1,48 @@
+package client
+
+import (
+	"fmt"
+
+	pbsrv "github.com/Arcadian-Sky/datakkeeper/gen/proto/api/service/v1"
+	"github.com/Arcadian-Sky/datakkeeper/internal/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ConflictError - запись или файл изменены на другом устройстве после того, как клиент их прочитал.
+// Data или File - текущая копия сервера, с ее Revision изменение можно повторить.
+type ConflictError struct {
+	Data *model.Data
+	File *model.FileItem
+}
+
+func (e *ConflictError) Error() string {
+	return model.ErrVersionConflict.Error()
+}
+
+// Is makes errors.Is(err, model.ErrVersionConflict) true
+func (e *ConflictError) Is(target error) bool {
+	return target == model.ErrVersionConflict
+}
+
+// conflictError converts ABORTED with the server copy in details to ConflictError, other errors are returned as is
+func (gc *GRPCClient) conflictError(err error) error {
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.Aborted {
+		return err
+	}
+	for _, d := range st.Details() {
+		switch current := d.(type) {
+		case *pbsrv.Data:
+			data, openErr := gc.openData(current)
+			if openErr != nil {
+				return fmt.Errorf("%w: %v", model.ErrVersionConflict, openErr)
+			}
+			return &ConflictError{Data: &data}
+		case *pbsrv.FileItem:
+			file := fileFromProto(current)
+			return &ConflictError{File: &file}
+		}
+	}
+	return err
+}
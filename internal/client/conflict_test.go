@@ -0,0 +1,79 @@
+package client
+
+import (
+	"errors"
+	"testing"
+
+	pbservice "github.com/Arcadian-Sky/datakkeeper/gen/proto/api/service/v1"
+	"github.com/Arcadian-Sky/datakkeeper/internal/model"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+func conflictStatus(t *testing.T, current protoadapt.MessageV1) error {
+	st, err := status.New(codes.Aborted, "conflict").WithDetails(current)
+	assert.NoError(t, err)
+	return st.Err()
+}
+
+func TestUpdateData_Conflict(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataClient := pbservice.NewMockDataKeeperServiceClient(ctrl)
+	client := &GRPCClient{
+		log:     logrus.New(),
+		Data:    mockDataClient,
+		Storage: keyStorage(),
+	}
+
+	mockDataClient.EXPECT().
+		UpdateData(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, in *pbservice.UpdateDataRequest, _ ...any) (*pbservice.UploadStatus, error) {
+			assert.Equal(t, int64(7), in.ExpectedRevision)
+			return nil, conflictStatus(t, &pbservice.Data{Id: 10, Title: "server copy", Revision: 9})
+		})
+
+	err := client.UpdateData(model.Data{ID: 10, Title: "my copy", Revision: 7})
+	assert.True(t, errors.Is(err, model.ErrVersionConflict))
+	var conflict *ConflictError
+	if assert.True(t, errors.As(err, &conflict)) {
+		assert.Equal(t, "server copy", conflict.Data.Title)
+		assert.Equal(t, int64(9), conflict.Data.Revision)
+		assert.Nil(t, conflict.File)
+	}
+}
+
+func TestDeleteFile_Conflict(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataClient := pbservice.NewMockDataKeeperServiceClient(ctrl)
+	client := &GRPCClient{
+		log:  logrus.New(),
+		Data: mockDataClient,
+	}
+
+	st, err := status.New(codes.Aborted, "conflict").WithDetails(&pbservice.FileItem{Name: "a.txt", Revision: 5})
+	assert.NoError(t, err)
+	mockDataClient.EXPECT().
+		DeleteFile(gomock.Any(), &pbservice.DeleteFileRequest{Filename: "a.txt", ExpectedRevision: proto.Int64(4)}).
+		Return(nil, st.Err())
+
+	err = client.DeleteFile("a.txt", 4)
+	var conflict *ConflictError
+	if assert.True(t, errors.As(err, &conflict)) {
+		assert.Equal(t, &model.FileItem{Name: "a.txt", Revision: 5}, conflict.File)
+	}
+
+	// ABORTED без копии сервера - обычная ошибка
+	mockDataClient.EXPECT().DeleteFile(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.Aborted, "minio"))
+	err = client.DeleteFile("a.txt", 0)
+	assert.Error(t, err)
+	assert.False(t, errors.Is(err, model.ErrVersionConflict))
+}
@@ -11,53 +11,84 @@ import (
 
 var period time.Duration = 5 * time.Second
 
+// GetDataList returns all records, pages are requested one by one
 func (gc *GRPCClient) GetDataList() ([]model.Data, error) {
+	var data []model.Data
+	opts := ListOptions{PageSize: model.MaxPageSize}
+	for {
+		page, next, err := gc.ListData(opts)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, page...)
+		if next == "" {
+			return data, nil
+		}
+		opts.PageToken = next
+	}
+}
+
+// ListData returns a page of records and token of the next page, empty on the last page
+func (gc *GRPCClient) ListData(opts ListOptions) ([]model.Data, string, error) {
 	var data []model.Data
 	if gc.Data == nil {
-		return data, fmt.Errorf("GRPC client is not initialized")
+		return data, "", fmt.Errorf("GRPC client is not initialized")
 	}
 
 	// Создаем контекст с таймаутом для запроса
 	ctx, cancel := context.WithTimeout(context.Background(), period)
 	defer cancel()
-	req := &pbsrv.ListDataRequest{}
+	req := &pbsrv.ListDataRequest{
+		Search:    opts.Search,
+		Tags:      opts.Tags,
+		Desc:      opts.Desc,
+		PageSize:  opts.PageSize,
+		PageToken: opts.PageToken,
+	}
+	for _, t := range opts.Types {
+		req.Types = append(req.Types, pbsrv.DataType(pbsrv.DataType_value[t]))
+	}
+	if opts.ByTitle {
+		req.Sort = pbsrv.ListSort_LIST_SORT_TITLE
+	}
 	// Отправляем запрос на сервер
 	res, err := gc.Data.GetDataList(ctx, req)
 	if err != nil {
 		gc.log.Debug("Error during get list of files : ", err)
-		return data, err
+		return data, "", err
 	}
 
 	gc.log.Trace(res)
 	for _, item := range res.Data {
-		data = append(data, model.Data{
-			ID:       item.Id,
-			Title:    item.Title,
-			Type:     item.Type.String(),
-			Login:    item.Login,
-			Card:     item.Card,
-			Password: item.Password,
-		})
+		d, err := gc.openData(item)
+		if err != nil {
+			gc.log.Debug("Error during decrypt data : ", err)
+			return nil, "", err
+		}
+		data = append(data, d)
 	}
 
-	return data, nil
+	return data, res.NextPageToken, nil
 }
 
 func (gc *GRPCClient) SaveLoginPass(domain, login, pass string) error {
 	if gc.Data == nil {
 		return fmt.Errorf("GRPC client is not initialized")
 	}
+	sealed, err := gc.sealData(model.Data{
+		Type:     pbsrv.DataType_DATA_TYPE_TYPE_LOGIN_PASSWORD.String(),
+		Password: pass,
+		Login:    login,
+		Title:    domain,
+	})
+	if err != nil {
+		return err
+	}
+
 	// Создаем контекст с таймаутом для запроса
 	ctx, cancel := context.WithTimeout(context.Background(), period)
 	defer cancel()
-	res, err := gc.Data.SaveData(ctx, &pbsrv.SaveDataRequest{
-		Data: &pbsrv.Data{
-			Type:     pbsrv.DataType_DATA_TYPE_TYPE_LOGIN_PASSWORD,
-			Password: pass,
-			Login:    login,
-			Title:    domain,
-		},
-	})
+	res, err := gc.Data.SaveData(ctx, &pbsrv.SaveDataRequest{Data: sealed})
 
 	if err != nil {
 		gc.log.Debug("Error during save file : ", err)
@@ -68,49 +99,262 @@ func (gc *GRPCClient) SaveLoginPass(domain, login, pass string) error {
 	return nil
 }
 
-func (gc *GRPCClient) SaveCard(title, card string) error {
+// SaveCard saves the card, its fields are checked by sealData before encryption
+func (gc *GRPCClient) SaveCard(card model.Data) error {
+	card.Type = pbsrv.DataType_DATA_TYPE_TYPE_CREDIT_CARD.String()
+	return gc.saveData(card)
+}
+
+// prepareCard normalizes the number, checks Luhn and expiry and fills the brand
+func prepareCard(card *model.Data) error {
+	card.Card = model.NormalizeCardNumber(card.Card)
+	if err := model.ValidateCard(*card); err != nil {
+		return err
+	}
+	card.CardBrand = model.CardBrand(card.Card)
+	return nil
+}
+
+// Сохранение текстовой заметки
+func (gc *GRPCClient) SaveNote(title, note string) error {
+	return gc.saveData(model.Data{
+		Type:  pbsrv.DataType_DATA_TYPE_UNSPECIFIED.String(),
+		Title: title,
+		Note:  note,
+	})
+}
+
+// Сохранение небольших бинарных данных, большие данные сохраняются файлами
+func (gc *GRPCClient) SaveBinary(title string, data []byte) error {
+	if len(data) > model.MaxBinarySize {
+		return model.ErrBinaryTooLarge
+	}
+	return gc.saveData(model.Data{
+		Type:   pbsrv.DataType_DATA_TYPE_TYPE_BINARY.String(),
+		Title:  title,
+		Binary: data,
+	})
+}
 
+func (gc *GRPCClient) saveData(data model.Data) error {
 	if gc.Data == nil {
 		return fmt.Errorf("GRPC client is not initialized")
 	}
+	sealed, err := gc.sealData(data)
+	if err != nil {
+		return err
+	}
 
 	// Создаем контекст с таймаутом для запроса
 	ctx, cancel := context.WithTimeout(context.Background(), period)
 	defer cancel()
-	res, err := gc.Data.SaveData(ctx, &pbsrv.SaveDataRequest{
-		Data: &pbsrv.Data{
-			Type:  pbsrv.DataType_DATA_TYPE_TYPE_CREDIT_CARD,
-			Card:  card,
-			Title: title,
-		},
-	})
+	res, err := gc.Data.SaveData(ctx, &pbsrv.SaveDataRequest{Data: sealed})
+	if err != nil {
+		gc.log.Debug("Error during save data : ", err)
+		return err
+	}
+	gc.log.Trace(res)
+
+	return nil
+}
 
+func (gc *GRPCClient) GetData(id int64) (model.Data, error) {
+	var data model.Data
+	if gc.Data == nil {
+		return data, fmt.Errorf("GRPC client is not initialized")
+	}
+
+	// Создаем контекст с таймаутом для запроса
+	ctx, cancel := context.WithTimeout(context.Background(), period)
+	defer cancel()
+	res, err := gc.Data.GetData(ctx, &pbsrv.GetDataRequest{Dataid: id})
+	if err != nil {
+		gc.log.Debug("Error during get data : ", err)
+		return data, err
+	}
+	gc.log.Trace(res)
+
+	item := res.GetData()
+	if item == nil {
+		return data, model.ErrEmptyResponse
+	}
+
+	return gc.openData(item)
+}
+
+// UpdateData saves the record if it was not changed since data.Revision, otherwise ConflictError is returned.
+// Нулевая Revision сохраняет запись без проверки.
+func (gc *GRPCClient) UpdateData(data model.Data) error {
+	if gc.Data == nil {
+		return fmt.Errorf("GRPC client is not initialized")
+	}
+	sealed, err := gc.sealData(data)
 	if err != nil {
-		gc.log.Debug("Error during save file : ", err)
 		return err
 	}
+
+	// Создаем контекст с таймаутом для запроса
+	ctx, cancel := context.WithTimeout(context.Background(), period)
+	defer cancel()
+	res, err := gc.Data.UpdateData(ctx, &pbsrv.UpdateDataRequest{Data: sealed, ExpectedRevision: data.Revision})
+	if err != nil {
+		gc.log.Debug("Error during update data : ", err)
+		return gc.conflictError(err)
+	}
 	gc.log.Trace(res)
 
 	return nil
 }
 
-func (gc *GRPCClient) Delete(id int64) error {
+// Delete removes the record if it was not changed since revision, 0 removes it without the check
+func (gc *GRPCClient) Delete(id, revision int64) error {
 	// Создаем контекст с таймаутом для запроса
 	ctx, cancel := context.WithTimeout(context.Background(), period)
 	defer cancel()
 
 	req := &pbsrv.DeleteDataRequest{
-		Dataid: id,
+		Dataid:           id,
+		ExpectedRevision: revision,
 	}
 
 	// Отправляем запрос на сервер
 	res, err := gc.Data.DeleteData(ctx, req)
 	if err != nil {
 		gc.log.Debug("Error during get list of files : ", err)
-		return err
+		return gc.conflictError(err)
 	}
 
 	gc.log.Trace(res)
 
 	return nil
 }
+
+// sealData шифрует секретные поля мастер-ключом, сервер получает только шифротекст.
+// Бренд и срок действия карты передаются открыто, чтобы сервер мог их проверить,
+// метаданные и теги тоже открыты, чтобы по ним можно было искать на сервере.
+// Шифротексты привязаны к типу и названию записи, см. RecordSealer.
+// Номер карты сервер проверить не может, поэтому Луна и срок действия проверяются здесь, до шифрования.
+func (gc *GRPCClient) sealData(data model.Data) (*pbsrv.Data, error) {
+	if gc.Storage == nil || !gc.Storage.MasterKey.IsSet() {
+		return nil, model.ErrMasterKeyNotSet
+	}
+	if data.Type == pbsrv.DataType_DATA_TYPE_TYPE_CREDIT_CARD.String() {
+		if err := prepareCard(&data); err != nil {
+			return nil, err
+		}
+	}
+	mk := &gc.Storage.MasterKey
+
+	item := &pbsrv.Data{
+		Id:           data.ID,
+		Type:         pbsrv.DataType(pbsrv.DataType_value[data.Type]),
+		Title:        data.Title,
+		KeyHash:      mk.Hash(),
+		CardExpMonth: data.CardExpMonth,
+		CardExpYear:  data.CardExpYear,
+		CardBrand:    data.CardBrand,
+		Meta:         data.Meta,
+		Tags:         model.NormalizeTags(data.Tags),
+	}
+	// тип привязывается в том виде, в каком его вернет сервер
+	rs, err := mk.NewRecordSealer(item.Type.String(), item.Title)
+	if err != nil {
+		return nil, err
+	}
+	if item.Login, err = rs.Seal("login", data.Login); err != nil {
+		return nil, err
+	}
+	if item.Password, err = rs.Seal("password", data.Password); err != nil {
+		return nil, err
+	}
+	if item.Card, err = rs.Seal("card", data.Card); err != nil {
+		return nil, err
+	}
+	if item.Note, err = rs.Seal("note", data.Note); err != nil {
+		return nil, err
+	}
+	if item.CardHolder, err = rs.Seal("card_holder", data.CardHolder); err != nil {
+		return nil, err
+	}
+	if item.CardCvv, err = rs.Seal("card_cvv", data.CardCVV); err != nil {
+		return nil, err
+	}
+	if item.CardBank, err = rs.Seal("card_bank", data.CardBank); err != nil {
+		return nil, err
+	}
+	if item.CardPin, err = rs.Seal("card_pin", data.CardPIN); err != nil {
+		return nil, err
+	}
+	if item.Binary, err = rs.SealBytes("binary", data.Binary); err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+// openData расшифровывает запись, записи без KeyHash сохранены до включения шифрования
+func (gc *GRPCClient) openData(item *pbsrv.Data) (model.Data, error) {
+	data := model.Data{
+		ID:           item.Id,
+		Title:        item.Title,
+		Type:         item.Type.String(),
+		Login:        item.Login,
+		Card:         item.Card,
+		Password:     item.Password,
+		Note:         item.Note,
+		Binary:       item.Binary,
+		CardHolder:   item.CardHolder,
+		CardExpMonth: item.CardExpMonth,
+		CardExpYear:  item.CardExpYear,
+		CardCVV:      item.CardCvv,
+		CardBank:     item.CardBank,
+		CardPIN:      item.CardPin,
+		CardBrand:    item.CardBrand,
+		Meta:         item.Meta,
+		Tags:         item.Tags,
+		Revision:     item.Revision,
+	}
+	if item.KeyHash == "" {
+		return data, nil
+	}
+	if gc.Storage == nil || !gc.Storage.MasterKey.IsSet() {
+		return model.Data{}, model.ErrMasterKeyNotSet
+	}
+	mk := &gc.Storage.MasterKey
+	if item.KeyHash != mk.Hash() {
+		return model.Data{}, model.ErrWrongMasterKey
+	}
+
+	ro := mk.NewRecordOpener(data.Type, data.Title)
+	var err error
+	if data.Login, err = ro.Open("login", item.Login); err != nil {
+		return model.Data{}, err
+	}
+	if data.Password, err = ro.Open("password", item.Password); err != nil {
+		return model.Data{}, err
+	}
+	if data.Card, err = ro.Open("card", item.Card); err != nil {
+		return model.Data{}, err
+	}
+	if data.Note, err = ro.Open("note", item.Note); err != nil {
+		return model.Data{}, err
+	}
+	if data.CardHolder, err = ro.Open("card_holder", item.CardHolder); err != nil {
+		return model.Data{}, err
+	}
+	if data.CardCVV, err = ro.Open("card_cvv", item.CardCvv); err != nil {
+		return model.Data{}, err
+	}
+	if data.CardBank, err = ro.Open("card_bank", item.CardBank); err != nil {
+		return model.Data{}, err
+	}
+	if data.CardPIN, err = ro.Open("card_pin", item.CardPin); err != nil {
+		return model.Data{}, err
+	}
+	if data.Binary, err = ro.OpenBytes("binary", item.Binary); err != nil {
+		return model.Data{}, err
+	}
+	data.KeyHash = item.KeyHash
+
+	return data, nil
+}
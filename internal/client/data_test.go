@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
 )
 
 func TestGetDataList_Success(t *testing.T) {
@@ -87,6 +89,55 @@ func TestGetDataList_Error(t *testing.T) {
 	assert.Len(t, dataList, 0)
 }
 
+func TestListData_Pages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataClient := pbservice.NewMockDataKeeperServiceClient(ctrl)
+	client := &GRPCClient{
+		log:  logrus.New(),
+		Data: mockDataClient,
+	}
+
+	mockDataClient.EXPECT().
+		GetDataList(gomock.Any(), &pbservice.ListDataRequest{
+			Types:     []pbservice.DataType{pbservice.DataType_DATA_TYPE_TYPE_CREDIT_CARD},
+			Search:    "bank",
+			Tags:      []string{"salary"},
+			Sort:      pbservice.ListSort_LIST_SORT_TITLE,
+			Desc:      true,
+			PageSize:  10,
+			PageToken: "token1",
+		}).
+		Return(&pbservice.ListDataResponse{Data: []*pbservice.Data{{Id: 1, Title: "t1"}}, NextPageToken: "token2"}, nil)
+
+	data, next, err := client.ListData(ListOptions{
+		Types:     []string{pbservice.DataType_DATA_TYPE_TYPE_CREDIT_CARD.String()},
+		Search:    "bank",
+		Tags:      []string{"salary"},
+		ByTitle:   true,
+		Desc:      true,
+		PageSize:  10,
+		PageToken: "token1",
+	})
+	assert.NoError(t, err)
+	assert.Len(t, data, 1)
+	assert.Equal(t, "token2", next)
+
+	// GetDataList собирает все страницы
+	gomock.InOrder(
+		mockDataClient.EXPECT().
+			GetDataList(gomock.Any(), &pbservice.ListDataRequest{PageSize: model.MaxPageSize}).
+			Return(&pbservice.ListDataResponse{Data: []*pbservice.Data{{Id: 1}}, NextPageToken: "next"}, nil),
+		mockDataClient.EXPECT().
+			GetDataList(gomock.Any(), &pbservice.ListDataRequest{PageSize: model.MaxPageSize, PageToken: "next"}).
+			Return(&pbservice.ListDataResponse{Data: []*pbservice.Data{{Id: 2}}}, nil),
+	)
+	all, err := client.GetDataList()
+	assert.NoError(t, err)
+	assert.Len(t, all, 2)
+}
+
 func TestSaveLoginPass_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -104,7 +155,7 @@ func TestSaveLoginPass_Success(t *testing.T) {
 	client := &GRPCClient{
 		log:     mockLogger,
 		Data:    mockDataClient,
-		Storage: nil, // Update if needed
+		Storage: keyStorage(),
 	}
 
 	// Call the method to test
@@ -131,7 +182,7 @@ func TestSaveLoginPass_Error(t *testing.T) {
 	client := &GRPCClient{
 		log:     mockLogger,
 		Data:    mockDataClient,
-		Storage: nil, // Update if needed
+		Storage: keyStorage(),
 	}
 
 	// Call the method to test
@@ -159,11 +210,11 @@ func TestSaveCard_Success(t *testing.T) {
 	client := &GRPCClient{
 		log:     mockLogger,
 		Data:    mockDataClient,
-		Storage: nil, // Update if needed
+		Storage: keyStorage(),
 	}
 
 	// Call the method to test
-	err := client.SaveCard("Visa", "4111111111111111")
+	err := client.SaveCard(model.Data{Title: "Visa", Card: "4111111111111111", CardExpMonth: 3, CardExpYear: 2030})
 
 	// Assertions
 	assert.NoError(t, err)
@@ -186,11 +237,11 @@ func TestSaveCard_Error(t *testing.T) {
 	client := &GRPCClient{
 		log:     mockLogger,
 		Data:    mockDataClient,
-		Storage: nil, // Update if needed
+		Storage: keyStorage(),
 	}
 
 	// Call the method to test
-	err := client.SaveCard("Visa", "4111111111111111")
+	err := client.SaveCard(model.Data{Title: "Visa", Card: "4111111111111111", CardExpMonth: 3, CardExpYear: 2030})
 
 	// Assertions
 	assert.Error(t, err)
@@ -218,7 +269,7 @@ func TestDelete_Success(t *testing.T) {
 	}
 
 	// Call the method to test
-	err := client.Delete(12345)
+	err := client.Delete(12345, 0)
 
 	// Assertions
 	assert.NoError(t, err)
@@ -245,9 +296,388 @@ func TestDelete_Error(t *testing.T) {
 	}
 
 	// Call the method to test
-	err := client.Delete(12345)
+	err := client.Delete(12345, 0)
 
 	// Assertions
 	assert.Error(t, err)
 	assert.EqualError(t, err, "test error")
 }
+
+func TestGetData_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataClient := pbservice.NewMockDataKeeperServiceClient(ctrl)
+	mockLogger := logrus.New()
+
+	mockDataClient.EXPECT().
+		GetData(gomock.Any(), &pbservice.GetDataRequest{Dataid: 12345}).
+		Return(&pbservice.GetDataResponse{
+			Data: &pbservice.Data{
+				Id:       12345,
+				Title:    "example.com",
+				Type:     pbservice.DataType_DATA_TYPE_TYPE_LOGIN_PASSWORD,
+				Login:    "user",
+				Password: "pass",
+			},
+		}, nil).
+		Times(1)
+
+	client := &GRPCClient{
+		log:  mockLogger,
+		Data: mockDataClient,
+	}
+
+	data, err := client.GetData(12345)
+
+	assert.NoError(t, err)
+	assert.Equal(t, model.Data{
+		ID:       12345,
+		Title:    "example.com",
+		Type:     "DATA_TYPE_TYPE_LOGIN_PASSWORD",
+		Login:    "user",
+		Password: "pass",
+	}, data)
+}
+
+func TestGetData_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataClient := pbservice.NewMockDataKeeperServiceClient(ctrl)
+	mockLogger := logrus.New()
+
+	mockDataClient.EXPECT().
+		GetData(gomock.Any(), gomock.Any()).
+		Return(nil, fmt.Errorf("test error")).
+		Times(1)
+
+	client := &GRPCClient{
+		log:  mockLogger,
+		Data: mockDataClient,
+	}
+
+	_, err := client.GetData(12345)
+
+	assert.EqualError(t, err, "test error")
+}
+
+func TestUpdateData_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataClient := pbservice.NewMockDataKeeperServiceClient(ctrl)
+	mockLogger := logrus.New()
+
+	mockDataClient.EXPECT().
+		UpdateData(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, req *pbservice.UpdateDataRequest, _ ...grpc.CallOption) (*pbservice.UploadStatus, error) {
+			assert.Equal(t, int64(12345), req.Data.Id)
+			assert.Equal(t, pbservice.DataType_DATA_TYPE_TYPE_CREDIT_CARD, req.Data.Type)
+			assert.NotEqual(t, "4111111111111111", req.Data.Card)
+			assert.Equal(t, model.CardBrandVisa, req.Data.CardBrand)
+			assert.Equal(t, keyStorage().MasterKey.Hash(), req.Data.KeyHash)
+			return &pbservice.UploadStatus{Success: true}, nil
+		}).
+		Times(1)
+
+	client := &GRPCClient{
+		log:     mockLogger,
+		Data:    mockDataClient,
+		Storage: keyStorage(),
+	}
+
+	err := client.UpdateData(model.Data{
+		ID:           12345,
+		Type:         "DATA_TYPE_TYPE_CREDIT_CARD",
+		Title:        "Visa",
+		Card:         "4111111111111111",
+		CardExpMonth: 3,
+		CardExpYear:  2030,
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestUpdateData_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataClient := pbservice.NewMockDataKeeperServiceClient(ctrl)
+	mockLogger := logrus.New()
+
+	mockDataClient.EXPECT().
+		UpdateData(gomock.Any(), gomock.Any()).
+		Return(nil, fmt.Errorf("test error")).
+		Times(1)
+
+	client := &GRPCClient{
+		log:     mockLogger,
+		Data:    mockDataClient,
+		Storage: keyStorage(),
+	}
+
+	err := client.UpdateData(model.Data{ID: 12345})
+
+	assert.EqualError(t, err, "test error")
+}
+
+// keyStorage returns storage with derived master key
+func keyStorage() *MemStorage {
+	storage := &MemStorage{Login: "user"}
+	storage.SetMasterKey("testKey", "")
+	return storage
+}
+
+func TestSaveLoginPass_NoMasterKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := &GRPCClient{
+		log:     logrus.New(),
+		Data:    pbservice.NewMockDataKeeperServiceClient(ctrl),
+		Storage: &MemStorage{},
+	}
+
+	err := client.SaveLoginPass("example.com", "user123", "pass123")
+	assert.ErrorIs(t, err, model.ErrMasterKeyNotSet)
+}
+
+func TestData_EncryptedRoundTrip(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataClient := pbservice.NewMockDataKeeperServiceClient(ctrl)
+	client := &GRPCClient{
+		log:     logrus.New(),
+		Data:    mockDataClient,
+		Storage: keyStorage(),
+	}
+
+	// сервер получает и хранит только шифротекст
+	var stored *pbservice.Data
+	mockDataClient.EXPECT().
+		SaveData(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, req *pbservice.SaveDataRequest, _ ...grpc.CallOption) (*pbservice.UploadStatus, error) {
+			stored = req.Data
+			stored.Id = 1
+			return &pbservice.UploadStatus{Success: true}, nil
+		})
+
+	assert.NoError(t, client.SaveLoginPass("example.com", "user123", "pass123"))
+	assert.Equal(t, "example.com", stored.Title)
+	assert.NotEqual(t, "user123", stored.Login)
+	assert.NotEqual(t, "pass123", stored.Password)
+	assert.Equal(t, client.Storage.MasterKey.Hash(), stored.KeyHash)
+
+	mockDataClient.EXPECT().
+		GetData(gomock.Any(), gomock.Any()).
+		Return(&pbservice.GetDataResponse{Data: stored}, nil)
+
+	data, err := client.GetData(1)
+	assert.NoError(t, err)
+	assert.Equal(t, "user123", data.Login)
+	assert.Equal(t, "pass123", data.Password)
+
+	// пароль, переставленный сервером из другой записи, не расшифровывается
+	bank, err := client.sealData(model.Data{Type: "DATA_TYPE_TYPE_LOGIN_PASSWORD", Title: "bank.com", Login: "user123", Password: "bankpass"})
+	assert.NoError(t, err)
+	swapped := &pbservice.Data{Id: 1, Type: stored.Type, Title: stored.Title, KeyHash: stored.KeyHash, Login: stored.Login, Password: bank.Password}
+	mockDataClient.EXPECT().
+		GetData(gomock.Any(), gomock.Any()).
+		Return(&pbservice.GetDataResponse{Data: swapped}, nil)
+	_, err = client.GetData(1)
+	assert.ErrorIs(t, err, model.ErrWrongMasterKey)
+
+	// другой мастер-ключ не расшифровывает данные
+	wrong := &MemStorage{Login: "user"}
+	wrong.SetMasterKey("wrongKey", "")
+	client.Storage = wrong
+
+	mockDataClient.EXPECT().
+		GetDataList(gomock.Any(), gomock.Any()).
+		Return(&pbservice.ListDataResponse{Data: []*pbservice.Data{stored}}, nil)
+
+	_, err = client.GetDataList()
+	assert.ErrorIs(t, err, model.ErrWrongMasterKey)
+}
+
+func TestUpdateData_MetaRoundTrip(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataClient := pbservice.NewMockDataKeeperServiceClient(ctrl)
+	client := &GRPCClient{
+		log:     logrus.New(),
+		Data:    mockDataClient,
+		Storage: keyStorage(),
+	}
+
+	// метаданные и теги уходят открыто, теги нормализуются
+	var stored *pbservice.Data
+	mockDataClient.EXPECT().
+		UpdateData(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, req *pbservice.UpdateDataRequest, _ ...grpc.CallOption) (*pbservice.UploadStatus, error) {
+			stored = req.Data
+			return &pbservice.UploadStatus{Success: true}, nil
+		})
+
+	err := client.UpdateData(model.Data{
+		ID:    1,
+		Type:  pbservice.DataType_DATA_TYPE_TYPE_LOGIN_PASSWORD.String(),
+		Title: "example.com",
+		Login: "user123",
+		Meta:  map[string]string{"url": "https://example.com"},
+		Tags:  []string{"work", " personal", "work"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"url": "https://example.com"}, stored.Meta)
+	assert.Equal(t, []string{"personal", "work"}, stored.Tags)
+
+	mockDataClient.EXPECT().
+		GetData(gomock.Any(), gomock.Any()).
+		Return(&pbservice.GetDataResponse{Data: stored}, nil)
+
+	data, err := client.GetData(1)
+	assert.NoError(t, err)
+	assert.Equal(t, "user123", data.Login)
+	assert.Equal(t, map[string]string{"url": "https://example.com"}, data.Meta)
+	assert.Equal(t, []string{"personal", "work"}, data.Tags)
+}
+
+func TestSaveNoteAndBinary_RoundTrip(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataClient := pbservice.NewMockDataKeeperServiceClient(ctrl)
+	client := &GRPCClient{
+		log:     logrus.New(),
+		Data:    mockDataClient,
+		Storage: keyStorage(),
+	}
+
+	var stored []*pbservice.Data
+	mockDataClient.EXPECT().
+		SaveData(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, req *pbservice.SaveDataRequest, _ ...grpc.CallOption) (*pbservice.UploadStatus, error) {
+			stored = append(stored, req.Data)
+			return &pbservice.UploadStatus{Success: true}, nil
+		}).
+		Times(2)
+
+	blob := []byte{0x00, 0xff, 0x10, 0x20}
+	assert.NoError(t, client.SaveNote("note", "my secret note"))
+	assert.NoError(t, client.SaveBinary("blob", blob))
+
+	assert.Equal(t, pbservice.DataType_DATA_TYPE_UNSPECIFIED, stored[0].Type)
+	assert.NotEqual(t, "my secret note", stored[0].Note)
+	assert.Equal(t, pbservice.DataType_DATA_TYPE_TYPE_BINARY, stored[1].Type)
+	assert.NotEqual(t, blob, stored[1].Binary)
+
+	for i, want := range []model.Data{
+		{Title: "note", Type: "DATA_TYPE_UNSPECIFIED", Note: "my secret note"},
+		{Title: "blob", Type: "DATA_TYPE_TYPE_BINARY", Binary: blob},
+	} {
+		mockDataClient.EXPECT().
+			GetData(gomock.Any(), gomock.Any()).
+			Return(&pbservice.GetDataResponse{Data: stored[i]}, nil)
+
+		got, err := client.GetData(0)
+		assert.NoError(t, err)
+		want.KeyHash = client.Storage.MasterKey.Hash()
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestSaveBinary_TooLarge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := &GRPCClient{
+		log:     logrus.New(),
+		Data:    pbservice.NewMockDataKeeperServiceClient(ctrl),
+		Storage: keyStorage(),
+	}
+
+	err := client.SaveBinary("blob", make([]byte, model.MaxBinarySize+1))
+	assert.ErrorIs(t, err, model.ErrBinaryTooLarge)
+}
+
+func TestSaveCard_RoundTrip(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataClient := pbservice.NewMockDataKeeperServiceClient(ctrl)
+	client := &GRPCClient{
+		log:     logrus.New(),
+		Data:    mockDataClient,
+		Storage: keyStorage(),
+	}
+
+	var stored *pbservice.Data
+	mockDataClient.EXPECT().
+		SaveData(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, req *pbservice.SaveDataRequest, _ ...grpc.CallOption) (*pbservice.UploadStatus, error) {
+			stored = req.Data
+			return &pbservice.UploadStatus{Success: true}, nil
+		})
+
+	card := model.Data{
+		Title:        "salary",
+		Card:         "2200 0000 0000 0004",
+		CardHolder:   "IVAN IVANOV",
+		CardExpMonth: 3,
+		CardExpYear:  2030,
+		CardCVV:      "123",
+		CardBank:     "Bank",
+		CardPIN:      "0000",
+	}
+	assert.NoError(t, client.SaveCard(card))
+
+	// бренд и срок действия открыты, остальное зашифровано
+	assert.Equal(t, pbservice.DataType_DATA_TYPE_TYPE_CREDIT_CARD, stored.Type)
+	assert.Equal(t, model.CardBrandMir, stored.CardBrand)
+	assert.Equal(t, int32(3), stored.CardExpMonth)
+	assert.Equal(t, int32(2030), stored.CardExpYear)
+	assert.NotContains(t, stored.Card, "2200")
+	assert.NotEqual(t, "IVAN IVANOV", stored.CardHolder)
+	assert.NotEqual(t, "123", stored.CardCvv)
+	assert.NotEqual(t, "Bank", stored.CardBank)
+	assert.NotEqual(t, "0000", stored.CardPin)
+
+	mockDataClient.EXPECT().
+		GetData(gomock.Any(), gomock.Any()).
+		Return(&pbservice.GetDataResponse{Data: stored}, nil)
+
+	got, err := client.GetData(0)
+	assert.NoError(t, err)
+	card.Type = "DATA_TYPE_TYPE_CREDIT_CARD"
+	card.Card = "2200000000000004"
+	card.CardBrand = model.CardBrandMir
+	card.KeyHash = client.Storage.MasterKey.Hash()
+	assert.Equal(t, card, got)
+}
+
+func TestSaveCard_Invalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := &GRPCClient{
+		log:     logrus.New(),
+		Data:    pbservice.NewMockDataKeeperServiceClient(ctrl),
+		Storage: keyStorage(),
+	}
+
+	err := client.SaveCard(model.Data{Title: "Visa", Card: "4111111111111112", CardExpMonth: 3, CardExpYear: 2030})
+	assert.ErrorIs(t, err, model.ErrCardNumberInvalid)
+
+	err = client.SaveCard(model.Data{Title: "Visa", Card: "4111111111111111"})
+	assert.ErrorIs(t, err, model.ErrCardExpiryInvalid)
+
+	err = client.UpdateData(model.Data{ID: 1, Type: "DATA_TYPE_TYPE_CREDIT_CARD", Card: "4111111111111111", CardExpMonth: 13, CardExpYear: 2030})
+	assert.ErrorIs(t, err, model.ErrCardExpiryInvalid)
+
+	// карту, сохраняемую в обход SaveCard, тоже проверяет sealData
+	err = client.saveData(model.Data{Type: "DATA_TYPE_TYPE_CREDIT_CARD", Card: "4111111111111112", CardExpMonth: 3, CardExpYear: 2030})
+	assert.ErrorIs(t, err, model.ErrCardNumberInvalid)
+}
@@ -12,36 +12,82 @@ import (
 	"github.com/Arcadian-Sky/datakkeeper/internal/model"
 )
 
-// Получение списка файлов
+// Получение списка всех файлов, страницы запрашиваются по очереди
 func (gc *GRPCClient) GetFileList() ([]model.FileItem, error) {
+	var data []model.FileItem
+	opts := ListOptions{PageSize: model.MaxPageSize}
+	for {
+		page, next, err := gc.ListFiles(opts)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, page...)
+		if next == "" {
+			return data, nil
+		}
+		opts.PageToken = next
+	}
+}
+
+// Получение страницы списка файлов и токена следующей страницы
+func (gc *GRPCClient) ListFiles(opts ListOptions) ([]model.FileItem, string, error) {
 	var data []model.FileItem
 	if gc.Data == nil {
-		return data, fmt.Errorf("GRPC client is not initialized")
+		return data, "", fmt.Errorf("GRPC client is not initialized")
 	}
 
 	// Создаем контекст с таймаутом для запроса
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
-	req := &pbsrv.ListFileRequest{}
+	req := &pbsrv.ListFileRequest{
+		Extensions: opts.Types,
+		Search:     opts.Search,
+		Tags:       opts.Tags,
+		Desc:       opts.Desc,
+		PageSize:   opts.PageSize,
+		PageToken:  opts.PageToken,
+		Browse:     opts.Browse,
+		Parent:     opts.Parent,
+	}
+	if opts.ByTitle {
+		req.Sort = pbsrv.ListSort_LIST_SORT_TITLE
+	}
 	// Отправляем запрос на сервер
 	res, err := gc.Data.GetFileList(ctx, req)
 	if err != nil {
 		gc.log.Debug("Error during get list of files : ", err)
-		return data, err
+		return data, "", err
 	}
 	gc.log.Trace(res)
 	for _, item := range res.Fileitem {
-		data = append(data, model.FileItem{
-			Hash: item.Key,
-			Name: item.Name,
-		})
+		data = append(data, fileFromProto(item))
 	}
 
-	return data, nil
+	return data, res.NextPageToken, nil
+}
+
+func fileFromProto(item *pbsrv.FileItem) model.FileItem {
+	res := model.FileItem{
+		Hash:        item.Key,
+		Name:        item.Name,
+		Desc:        item.Description,
+		Meta:        item.Meta,
+		Tags:        item.Tags,
+		Revision:    item.Revision,
+		Size:        item.Size,
+		ContentType: item.ContentType,
+		SHA256:      item.Sha256,
+		Device:      item.Device,
+		IsFolder:    item.IsFolder,
+	}
+	if item.UploadedAt != 0 {
+		res.UploadedAt = time.Unix(item.UploadedAt, 0)
+	}
+	return res
 }
 
-// Удаление файла
-func (gc *GRPCClient) DeleteFile(fileName string) error {
+// Удаление файла, revision - версия файла, которую видел пользователь
+func (gc *GRPCClient) DeleteFile(fileName string, revision int64) error {
 	if gc.Data == nil {
 		return fmt.Errorf("GRPC client is not initialized")
 	}
@@ -50,22 +96,57 @@ func (gc *GRPCClient) DeleteFile(fileName string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 	req := &pbsrv.DeleteFileRequest{
-		Filename: fileName,
+		Filename:         fileName,
+		ExpectedRevision: &revision,
 	}
 	// Отправляем запрос на сервер
 	res, err := gc.Data.DeleteFile(ctx, req)
 	if err != nil {
 		gc.log.Debug("Error during delete file : ", err)
+		return gc.conflictError(err)
+	}
+	gc.log.Trace(res)
+
+	return nil
+}
+
+// Замена описания, метаданных и тегов файла, пустые значения удаляют их
+func (gc *GRPCClient) UpdateFileMeta(fileName string, revision int64, desc string, meta map[string]string, tags []string) error {
+	if gc.Data == nil {
+		return fmt.Errorf("GRPC client is not initialized")
+	}
+	tags = model.NormalizeTags(tags)
+	if err := model.ValidateMeta(meta, tags); err != nil {
 		return err
 	}
+	if err := model.ValidateDescription(desc); err != nil {
+		return err
+	}
+
+	// Создаем контекст с таймаутом для запроса
+	ctx, cancel := context.WithTimeout(context.Background(), period)
+	defer cancel()
+	req := &pbsrv.UpdateFileMetaRequest{
+		Filename:         fileName,
+		Meta:             meta,
+		Tags:             tags,
+		ExpectedRevision: &revision,
+		Description:      desc,
+	}
+	res, err := gc.Data.UpdateFileMeta(ctx, req)
+	if err != nil {
+		gc.log.Debug("Error during update file meta : ", err)
+		return gc.conflictError(err)
+	}
 	gc.log.Trace(res)
 
 	return nil
 }
 
-// Отправка файлов на сервер
-func (gc *GRPCClient) UploadFile(filePath string) error {
-	fileName := filepath.Base(filePath)
+// Отправка файлов на сервер в папку folder (пустая - корень), содержимое шифруется на клиенте.
+// Загрузка идет частями: после обрыва связи повторный вызов продолжает ее с принятого сервером места.
+func (gc *GRPCClient) UploadFile(filePath, folder string) error {
+	fileName := model.JoinPath(folder, filepath.Base(filePath))
 	gc.log.Info("File name: ", fileName)
 	// Открываем файл для чтения
 	file, err := os.Open(filePath)
@@ -75,35 +156,40 @@ func (gc *GRPCClient) UploadFile(filePath string) error {
 	}
 	defer file.Close()
 
-	stream, err := gc.Data.UploadFile(context.Background())
-	if err != nil {
-		gc.log.Trace("error creating stream: ", err)
-		return fmt.Errorf("error creating stream: %v", err)
+	if gc.Storage == nil || !gc.Storage.MasterKey.IsSet() {
+		return model.ErrMasterKeyNotSet
 	}
 
-	// Read the file and send chunks
-	buffer := make([]byte, 1024)
-	for {
-		n, err := file.Read(buffer)
-		if err == io.EOF {
-			break
-		}
+	staged, err := gc.stageUpload(file)
+	if err != nil {
+		gc.log.Trace("Failed to encrypt file: ", err)
+		return err
+	}
 
-		if err != nil {
-			gc.log.Trace("Failed to read file: ", err)
-		}
+	ctx, cancel := context.WithTimeout(context.Background(), period)
+	defer cancel()
+	upload, err := gc.Data.InitUpload(ctx, &pbsrv.InitUploadRequest{
+		Filename: fileName,
+		Size:     staged.size,
+		Sha256:   staged.sha256,
+	})
+	if err != nil {
+		gc.log.Trace("Failed to start upload: ", err)
+		return err
+	}
 
-		if err := stream.Send(&pbsrv.FileChunk{
-			Data:     buffer[:n],
-			Filename: fileName,
-		}); err != nil {
-			gc.log.Trace("Failed to send chunk: ", err)
-		}
+	if err := gc.sendUpload(context.Background(), staged, upload.UploadId, upload.Offset); err != nil {
+		return err
 	}
-	// Close the stream and get the response
-	status, err := stream.CloseAndRecv()
+
+	// сервер проверяет хеш и сохраняет файл, для больших файлов это долго
+	status, err := gc.Data.FinalizeUpload(context.Background(), &pbsrv.FinalizeUploadRequest{UploadId: upload.UploadId})
 	if err != nil {
-		gc.log.Trace("Failed to receive response: ", err)
+		gc.log.Trace("Failed to finish upload: ", err)
+		return err
+	}
+	if err := os.Remove(staged.path); err != nil {
+		gc.log.Debug("Failed to remove upload copy: ", err)
 	}
 
 	gc.log.Info("Upload status:", status.Success, ", message: ", status.Message)
@@ -111,14 +197,95 @@ func (gc *GRPCClient) UploadFile(filePath string) error {
 	return nil
 }
 
+// chunkReader читает содержимое файла из потока FileChunk
+type chunkReader struct {
+	stream pbsrv.DataKeeperService_GetFileClient
+	buf    []byte
+}
+
+func (c *chunkReader) Read(p []byte) (int, error) {
+	for len(c.buf) == 0 {
+		chunk, err := c.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		c.buf = chunk.Data
+	}
+	n := copy(p, c.buf)
+	c.buf = c.buf[n:]
+	return n, nil
+}
+
+// Получение файла, зашифрованный файл расшифровывается при сохранении
 func (gc *GRPCClient) GetFile(fileName string) error {
+	return gc.downloadFile(&pbsrv.GetFileRequest{Name: fileName})
+}
+
+// GetFileVersion сохраняет прежнюю версию файла на место локальной копии
+func (gc *GRPCClient) GetFileVersion(fileName, versionID string) error {
+	return gc.downloadFile(&pbsrv.GetFileRequest{Name: fileName, VersionId: versionID})
+}
+
+// downloadFile сохраняет полученное в <имя>.part и расшифровывает его после получения всего файла.
+// Если скачивание оборвалось, следующий вызов запрашивает файл с конца .part.
+func (gc *GRPCClient) downloadFile(req *pbsrv.GetFileRequest) error {
+	fileName := req.Name
+	filePath := filepath.Join(gc.Storage.PfilesDir, fileName)
+	partPath := filePath + ".part"
+	if req.VersionId != "" {
+		partPath = filePath + "." + req.VersionId + ".part"
+	}
 
-	stream, err := gc.Data.GetFile(context.Background(), &pbsrv.GetFileRequest{Name: fileName})
+	// файлы из папок сохраняются в такие же локальные каталоги
+	if err := os.MkdirAll(filepath.Dir(filePath), 0o700); err != nil {
+		return err
+	}
+	part, err := os.OpenFile(partPath, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
 	if err != nil {
-		gc.log.Fatalf("Ошибка при вызове GetFile: %v", err)
+		gc.log.Trace("Не удалось создать файл: ", err)
+		return err
+	}
+	defer part.Close()
+	info, err := part.Stat()
+	if err != nil {
+		return err
+	}
+	req.Offset = info.Size()
+	if req.Offset > 0 {
+		gc.log.Info("Скачивание продолжается с байта ", req.Offset)
 	}
 
-	filePath := filepath.Join(gc.Storage.PfilesDir, fileName)
+	stream, err := gc.Data.GetFile(context.Background(), req)
+	if err != nil {
+		gc.log.Trace("Ошибка при вызове GetFile: ", err)
+		return err
+	}
+	// полученное до обрыва остается в .part
+	if _, err := io.Copy(part, &chunkReader{stream: stream}); err != nil {
+		gc.log.Trace("Ошибка при получении данных: ", err)
+		return err
+	}
+	if err := part.Close(); err != nil {
+		return err
+	}
+
+	err = gc.decryptPart(partPath, filePath)
+	// файл мог измениться на сервере между попытками, тогда .part не расшифруется и скачивается заново
+	os.Remove(partPath)
+	if err != nil {
+		return err
+	}
+	gc.log.Info("Файл успешно получен и сохранён:", filePath)
+	return nil
+}
+
+func (gc *GRPCClient) decryptPart(partPath, filePath string) error {
+	part, err := os.Open(partPath)
+	if err != nil {
+		return err
+	}
+	defer part.Close()
+
 	// Создаём файл для записи полученных данных
 	file, err := os.Create(filePath)
 	if err != nil {
@@ -127,22 +294,28 @@ func (gc *GRPCClient) GetFile(fileName string) error {
 	}
 	defer file.Close()
 
-	// Читаем поток данных и записываем в файл
-	for {
-		chunk, err := stream.Recv()
-		if err == io.EOF {
-			break
-		}
-		if err != nil {
-			gc.log.Trace("Ошибка при получении данных: ", err)
-			return err
-		}
-		_, err = file.Write(chunk.Data)
-		if err != nil {
-			gc.log.Trace("Не удалось записать в файл: ", err)
-			return err
-		}
+	err = gc.receiveFile(file, part)
+	if err != nil {
+		// недорасшифрованный файл не оставляем
+		file.Close()
+		os.Remove(filePath)
+		return err
+	}
+	return nil
+}
+
+func (gc *GRPCClient) receiveFile(w io.Writer, r io.Reader) error {
+	dec, encrypted, err := newDecryptReader(r, &gc.Storage.MasterKey)
+	if err != nil {
+		gc.log.Trace("Ошибка при получении данных: ", err)
+		return err
+	}
+	if !encrypted {
+		gc.log.Info("Файл сохранен без шифрования")
+	}
+	if _, err := io.Copy(w, dec); err != nil {
+		gc.log.Trace("Ошибка при получении данных: ", err)
+		return err
 	}
-	gc.log.Info("Файл успешно получен и сохранён:", filePath)
 	return nil
 }
@@ -1,16 +1,24 @@
 package client
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	pbservice "github.com/Arcadian-Sky/datakkeeper/gen/proto/api/service/v1"
+	"github.com/Arcadian-Sky/datakkeeper/internal/model"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
+	"golang.org/x/crypto/chacha20poly1305"
+	"google.golang.org/protobuf/proto"
 )
 
 func TestGetFileList_Success(t *testing.T) {
@@ -28,8 +36,16 @@ func TestGetFileList_Success(t *testing.T) {
 			Name: "fileName1",
 		},
 		{
-			Key:  "fileHash2",
-			Name: "fileName2",
+			Key:         "fileHash2",
+			Name:        "fileName2",
+			Meta:        map[string]string{"place": "Kazan"},
+			Tags:        []string{"trip"},
+			Size:        20,
+			ContentType: "image/jpeg",
+			UploadedAt:  1722506400,
+			Sha256:      "abc",
+			Device:      "phone",
+			Description: "Summer trip",
 		},
 	}
 
@@ -54,6 +70,15 @@ func TestGetFileList_Success(t *testing.T) {
 	assert.Equal(t, "fileName1", fileList[0].Name)
 	assert.Equal(t, "fileHash2", fileList[1].Hash)
 	assert.Equal(t, "fileName2", fileList[1].Name)
+	assert.Equal(t, map[string]string{"place": "Kazan"}, fileList[1].Meta)
+	assert.Equal(t, []string{"trip"}, fileList[1].Tags)
+	assert.Equal(t, int64(20), fileList[1].Size)
+	assert.Equal(t, "image/jpeg", fileList[1].ContentType)
+	assert.Equal(t, time.Unix(1722506400, 0), fileList[1].UploadedAt)
+	assert.Equal(t, "abc", fileList[1].SHA256)
+	assert.Equal(t, "phone", fileList[1].Device)
+	assert.Equal(t, "Summer trip", fileList[1].Desc)
+	assert.True(t, fileList[0].UploadedAt.IsZero())
 }
 
 func TestGetFileList_Error(t *testing.T) {
@@ -85,6 +110,43 @@ func TestGetFileList_Error(t *testing.T) {
 	assert.EqualError(t, err, "test error")
 }
 
+func TestListFiles(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataClient := pbservice.NewMockDataKeeperServiceClient(ctrl)
+	client := &GRPCClient{
+		log:  logrus.New(),
+		Data: mockDataClient,
+	}
+
+	mockDataClient.EXPECT().
+		GetFileList(gomock.Any(), &pbservice.ListFileRequest{
+			Extensions: []string{"jpg"},
+			Search:     "trip",
+			PageSize:   20,
+		}).
+		Return(&pbservice.ListFileResponse{
+			Fileitem:      []*pbservice.FileItem{{Key: "k", Name: "trip.jpg"}},
+			NextPageToken: "token",
+		}, nil)
+
+	files, next, err := client.ListFiles(ListOptions{Types: []string{"jpg"}, Search: "trip", PageSize: 20})
+	assert.NoError(t, err)
+	assert.Equal(t, []model.FileItem{{Hash: "k", Name: "trip.jpg"}}, files)
+	assert.Equal(t, "token", next)
+
+	mockDataClient.EXPECT().
+		GetFileList(gomock.Any(), &pbservice.ListFileRequest{Browse: true, Parent: "work"}).
+		Return(&pbservice.ListFileResponse{
+			Fileitem: []*pbservice.FileItem{{Name: "work/2024", IsFolder: true}, {Key: "k", Name: "work/a.txt"}},
+		}, nil)
+
+	files, _, err = client.ListFiles(ListOptions{Browse: true, Parent: "work"})
+	assert.NoError(t, err)
+	assert.Equal(t, []model.FileItem{{Name: "work/2024", IsFolder: true}, {Hash: "k", Name: "work/a.txt"}}, files)
+}
+
 func TestDeleteFile_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -106,7 +168,7 @@ func TestDeleteFile_Success(t *testing.T) {
 	}
 
 	// Call the method to test
-	err := client.DeleteFile("test-file")
+	err := client.DeleteFile("test-file", 0)
 
 	// Assertions
 	assert.NoError(t, err)
@@ -133,25 +195,71 @@ func TestDeleteFile_Error(t *testing.T) {
 	}
 
 	// Call the method to test
-	err := client.DeleteFile("test-file")
+	err := client.DeleteFile("test-file", 0)
 
 	// Assertions
 	assert.Error(t, err)
 	assert.EqualError(t, err, "test error")
 }
 
+func TestUpdateFileMeta(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataClient := pbservice.NewMockDataKeeperServiceClient(ctrl)
+	client := &GRPCClient{
+		log:  logrus.New(),
+		Data: mockDataClient,
+	}
+
+	mockDataClient.EXPECT().
+		UpdateFileMeta(gomock.Any(), &pbservice.UpdateFileMetaRequest{
+			Filename:         "photo.jpg",
+			Meta:             map[string]string{"place": "Kazan"},
+			Tags:             []string{"2024", "trip"},
+			ExpectedRevision: proto.Int64(3),
+			Description:      "Summer trip",
+		}).
+		Return(&pbservice.UploadStatus{Success: true}, nil).
+		Times(1)
+	err := client.UpdateFileMeta("photo.jpg", 3, "Summer trip", map[string]string{"place": "Kazan"}, []string{"trip ", "2024", "trip"})
+	assert.NoError(t, err)
+
+	// невалидные метаданные не уходят на сервер
+	err = client.UpdateFileMeta("photo.jpg", 0, "", map[string]string{"": "value"}, nil)
+	assert.ErrorIs(t, err, model.ErrMetaInvalid)
+	err = client.UpdateFileMeta("photo.jpg", 0, strings.Repeat("d", model.MaxDescLen+1), nil, nil)
+	assert.ErrorIs(t, err, model.ErrMetaInvalid)
+
+	mockDataClient.EXPECT().
+		UpdateFileMeta(gomock.Any(), gomock.Any()).
+		Return(nil, fmt.Errorf("test error")).
+		Times(1)
+	err = client.UpdateFileMeta("photo.jpg", 0, "", nil, nil)
+	assert.Error(t, err)
+}
+
 func TestUploadFile_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	// Create mock objects
 	mockDataClient := pbservice.NewMockDataKeeperServiceClient(ctrl)
-	mockStream := pbservice.NewMockDataKeeperService_UploadFileClient(ctrl)
+	mockStream := pbservice.NewMockDataKeeperService_UploadChunkClient(ctrl)
 	mockLogger := logrus.New()
 
 	// Mock behaviors
+	var size int64
 	mockDataClient.EXPECT().
-		UploadFile(gomock.Any()).
+		InitUpload(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, req *pbservice.InitUploadRequest, _ ...any) (*pbservice.InitUploadResponse, error) {
+			size = req.Size
+			assert.Len(t, req.Sha256, 64)
+			return &pbservice.InitUploadResponse{UploadId: "up1"}, nil
+		}).
+		Times(1)
+	mockDataClient.EXPECT().
+		UploadChunk(gomock.Any()).
 		Return(mockStream, nil).
 		Times(1)
 
@@ -161,6 +269,12 @@ func TestUploadFile_Success(t *testing.T) {
 		Times(1)
 	mockStream.EXPECT().
 		CloseAndRecv().
+		DoAndReturn(func() (*pbservice.UploadOffset, error) {
+			return &pbservice.UploadOffset{Offset: size}, nil
+		}).
+		Times(1)
+	mockDataClient.EXPECT().
+		FinalizeUpload(gomock.Any(), &pbservice.FinalizeUploadRequest{UploadId: "up1"}).
 		Return(&pbservice.UploadStatus{Success: true, Message: "Upload successful"}, nil).
 		Times(1)
 
@@ -176,17 +290,82 @@ func TestUploadFile_Success(t *testing.T) {
 		t.Fatalf("Failed to write to temp file: %v", err)
 	}
 
+	storage := keyStorage()
+	storage.PfilesDir = t.TempDir()
 	client := &GRPCClient{
 		log:     mockLogger,
 		Data:    mockDataClient,
-		Storage: nil, // Update if needed
+		Storage: storage,
 	}
 
 	// Call the method to test
-	err = client.UploadFile(tempFile.Name())
+	err = client.UploadFile(tempFile.Name(), "")
 
 	// Assertions
 	assert.NoError(t, err)
+	// после загрузки зашифрованная копия не нужна
+	staged, err := os.ReadDir(filepath.Join(storage.PfilesDir, ".uploads"))
+	assert.NoError(t, err)
+	assert.Empty(t, staged)
+}
+
+func TestUploadFile_Resume(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataClient := pbservice.NewMockDataKeeperServiceClient(ctrl)
+	mockStream := pbservice.NewMockDataKeeperService_UploadChunkClient(ctrl)
+	storage := keyStorage()
+	storage.PfilesDir = t.TempDir()
+	client := &GRPCClient{
+		log:     logrus.New(),
+		Data:    mockDataClient,
+		Storage: storage,
+	}
+
+	srcPath := filepath.Join(t.TempDir(), "big.bin")
+	assert.NoError(t, os.WriteFile(srcPath, bytes.Repeat([]byte("0123456789"), 300000), 0600))
+
+	// первая попытка обрывается после первой части
+	var first *pbservice.InitUploadRequest
+	var received []byte
+	mockDataClient.EXPECT().InitUpload(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, req *pbservice.InitUploadRequest, _ ...any) (*pbservice.InitUploadResponse, error) {
+			first = req
+			return &pbservice.InitUploadResponse{UploadId: "up1"}, nil
+		})
+	mockDataClient.EXPECT().UploadChunk(gomock.Any()).Return(mockStream, nil)
+	mockStream.EXPECT().Send(gomock.Any()).DoAndReturn(func(chunk *pbservice.UploadChunkRequest) error {
+		assert.Equal(t, int64(0), chunk.Offset)
+		received = append(received, chunk.Data...)
+		return nil
+	})
+	mockStream.EXPECT().Send(gomock.Any()).Return(io.EOF)
+	mockStream.EXPECT().CloseAndRecv().Return(nil, fmt.Errorf("connection lost"))
+
+	assert.Error(t, client.UploadFile(srcPath, ""))
+
+	// повтор шлет ту же зашифрованную копию с принятого места
+	mockDataClient.EXPECT().InitUpload(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, req *pbservice.InitUploadRequest, _ ...any) (*pbservice.InitUploadResponse, error) {
+			assert.Equal(t, first.Sha256, req.Sha256)
+			assert.Equal(t, first.Size, req.Size)
+			return &pbservice.InitUploadResponse{UploadId: "up1", Offset: int64(len(received))}, nil
+		})
+	mockDataClient.EXPECT().UploadChunk(gomock.Any()).Return(mockStream, nil)
+	mockStream.EXPECT().Send(gomock.Any()).DoAndReturn(func(chunk *pbservice.UploadChunkRequest) error {
+		assert.Equal(t, int64(len(received)), chunk.Offset)
+		received = append(received, chunk.Data...)
+		return nil
+	}).Times(2)
+	mockStream.EXPECT().CloseAndRecv().DoAndReturn(func() (*pbservice.UploadOffset, error) {
+		return &pbservice.UploadOffset{Offset: int64(len(received))}, nil
+	})
+	mockDataClient.EXPECT().FinalizeUpload(gomock.Any(), gomock.Any()).Return(&pbservice.UploadStatus{Success: true}, nil)
+
+	assert.NoError(t, client.UploadFile(srcPath, ""))
+	sum := sha256.Sum256(received)
+	assert.Equal(t, first.Sha256, hex.EncodeToString(sum[:]))
 }
 
 func TestUploadFile_OpenFileError(t *testing.T) {
@@ -204,7 +383,7 @@ func TestUploadFile_OpenFileError(t *testing.T) {
 	}
 
 	// Call the method to test with an invalid file path
-	err := client.UploadFile("/invalid/path/to/file")
+	err := client.UploadFile("/invalid/path/to/file", "")
 
 	// Assertions
 	assert.Error(t, err)
@@ -221,14 +400,20 @@ func TestUploadFile_StreamError(t *testing.T) {
 
 	// Mock behaviors
 	mockDataClient.EXPECT().
-		UploadFile(gomock.Any()).
+		InitUpload(gomock.Any(), gomock.Any()).
+		Return(&pbservice.InitUploadResponse{UploadId: "up1"}, nil).
+		Times(1)
+	mockDataClient.EXPECT().
+		UploadChunk(gomock.Any()).
 		Return(nil, fmt.Errorf("stream error")).
 		Times(1)
 
+	storage := keyStorage()
+	storage.PfilesDir = t.TempDir()
 	client := &GRPCClient{
 		log:     mockLogger,
 		Data:    mockDataClient,
-		Storage: nil, // Update if needed
+		Storage: storage,
 	}
 
 	// Create a temporary file for testing
@@ -244,7 +429,7 @@ func TestUploadFile_StreamError(t *testing.T) {
 	}
 
 	// Call the method to test
-	err = client.UploadFile(tempFile.Name())
+	err = client.UploadFile(tempFile.Name(), "")
 
 	// Assertions
 	assert.Error(t, err)
@@ -331,3 +516,105 @@ func TestGetFile_RecvError(t *testing.T) {
 	// Assertions
 	assert.Error(t, err)
 }
+
+func TestGetFile_Resume(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataClient := pbservice.NewMockDataKeeperServiceClient(ctrl)
+	mockStream := pbservice.NewMockDataKeeperService_GetFileClient(ctrl)
+	client := &GRPCClient{
+		log:     logrus.New(),
+		Data:    mockDataClient,
+		Storage: &MemStorage{PfilesDir: t.TempDir()},
+	}
+	filePath := filepath.Join(client.Storage.PfilesDir, "testfile.txt")
+
+	// обрыв после первой части
+	mockDataClient.EXPECT().GetFile(gomock.Any(), &pbservice.GetFileRequest{Name: "testfile.txt"}).Return(mockStream, nil)
+	mockStream.EXPECT().Recv().Return(&pbservice.FileChunk{Data: []byte("chunk1")}, nil)
+	mockStream.EXPECT().Recv().Return(nil, fmt.Errorf("connection lost"))
+
+	assert.Error(t, client.GetFile("testfile.txt"))
+	assert.NoFileExists(t, filePath)
+
+	mockDataClient.EXPECT().GetFile(gomock.Any(), &pbservice.GetFileRequest{Name: "testfile.txt", Offset: 6}).Return(mockStream, nil)
+	mockStream.EXPECT().Recv().Return(&pbservice.FileChunk{Data: []byte("chunk2")}, nil)
+	mockStream.EXPECT().Recv().Return(nil, io.EOF)
+
+	assert.NoError(t, client.GetFile("testfile.txt"))
+	content, err := os.ReadFile(filePath)
+	assert.NoError(t, err)
+	assert.Equal(t, "chunk1chunk2", string(content))
+	assert.NoFileExists(t, filePath+".part")
+}
+
+func TestUploadGetFile_Encrypted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataClient := pbservice.NewMockDataKeeperServiceClient(ctrl)
+	mockUpload := pbservice.NewMockDataKeeperService_UploadChunkClient(ctrl)
+	mockGet := pbservice.NewMockDataKeeperService_GetFileClient(ctrl)
+
+	storage := keyStorage()
+	storage.PfilesDir = t.TempDir()
+	client := &GRPCClient{
+		log:     logrus.New(),
+		Data:    mockDataClient,
+		Storage: storage,
+	}
+
+	content := bytes.Repeat([]byte("secret content "), 100000)
+	srcPath := filepath.Join(t.TempDir(), "secret.txt")
+	assert.NoError(t, os.WriteFile(srcPath, content, 0600))
+
+	// сервер получает только шифротекст
+	var sent []*pbservice.FileChunk
+	var received int64
+	mockDataClient.EXPECT().InitUpload(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, req *pbservice.InitUploadRequest, _ ...any) (*pbservice.InitUploadResponse, error) {
+			assert.Equal(t, "docs/secret.txt", req.Filename)
+			return &pbservice.InitUploadResponse{UploadId: "up1"}, nil
+		})
+	mockDataClient.EXPECT().UploadChunk(gomock.Any()).Return(mockUpload, nil)
+	mockUpload.EXPECT().Send(gomock.Any()).DoAndReturn(func(chunk *pbservice.UploadChunkRequest) error {
+		assert.False(t, bytes.Contains(chunk.Data, []byte("secret content")))
+		sent = append(sent, &pbservice.FileChunk{Data: append([]byte{}, chunk.Data...)})
+		received += int64(len(chunk.Data))
+		return nil
+	}).AnyTimes()
+	mockUpload.EXPECT().CloseAndRecv().DoAndReturn(func() (*pbservice.UploadOffset, error) {
+		return &pbservice.UploadOffset{Offset: received}, nil
+	})
+	mockDataClient.EXPECT().FinalizeUpload(gomock.Any(), gomock.Any()).Return(&pbservice.UploadStatus{Success: true}, nil)
+
+	assert.NoError(t, client.UploadFile(srcPath, "docs"))
+	assert.Greater(t, len(sent), 1)
+
+	mockDataClient.EXPECT().GetFile(gomock.Any(), gomock.Any()).Return(mockGet, nil)
+	for _, chunk := range sent {
+		mockGet.EXPECT().Recv().Return(chunk, nil)
+	}
+	mockGet.EXPECT().Recv().Return(nil, io.EOF)
+
+	// файл из папки сохраняется в такой же каталог
+	assert.NoError(t, client.GetFile("docs/secret.txt"))
+	got, err := os.ReadFile(filepath.Join(storage.PfilesDir, "docs", "secret.txt"))
+	assert.NoError(t, err)
+	assert.Equal(t, content, got)
+
+	// без последнего зашифрованного чанка файл не сохраняется
+	var all []byte
+	for _, chunk := range sent {
+		all = append(all, chunk.Data...)
+	}
+	last := len(content)%fileChunkSize + chacha20poly1305.Overhead
+	mockDataClient.EXPECT().GetFile(gomock.Any(), gomock.Any()).Return(mockGet, nil)
+	mockGet.EXPECT().Recv().Return(&pbservice.FileChunk{Data: all[:len(all)-last]}, nil)
+	mockGet.EXPECT().Recv().Return(nil, io.EOF)
+
+	assert.ErrorIs(t, client.GetFile("truncated.txt"), model.ErrFileTruncated)
+	assert.NoFileExists(t, filepath.Join(storage.PfilesDir, "truncated.txt"))
+	assert.NoFileExists(t, filepath.Join(storage.PfilesDir, "truncated.txt.part"))
+}
@@ -0,0 +1,257 @@
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"io"
+
+	"github.com/Arcadian-Sky/datakkeeper/internal/model"
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/hkdf"
+)
+
+// Формат зашифрованного файла:
+//
+//	header: magic(4) | len(keyID)(1) | keyID | salt(32)
+//	body:   chunk_0 | chunk_1 | ... | chunk_n
+//
+// Каждый чанк - ChaCha20-Poly1305 от fileChunkSize байт открытого текста (последний может быть короче).
+// Ключ файла выводится через HKDF из мастер-ключа и соли, поэтому nonce - это
+// счетчик чанка и флаг последнего чанка. Заголовок подписывается как additional data каждого чанка.
+// Отрезанный хвост файла обнаруживается: без флага последнего чанка расшифровка не проходит.
+//
+// Соль - HMAC-SHA256 содержимого на мастер-ключе (сходящееся шифрование, см. contentSalt):
+// одинаковые файлы пользователя дают одинаковый шифротекст, и сервер с DATAKEEPER_DEDUP хранит его один раз.
+// Ключ и nonce повторяются только для того же содержимого. Цена - сервер видит, какие файлы
+// и версии пользователя совпадают. Мастер-ключи пользователей разные, поэтому совпадение файлов
+// разных пользователей не видно, без мастер-ключа нельзя и проверить, хранит ли пользователь известный файл.
+const (
+	fileChunkSize = 64 * 1024
+	fileSaltSize  = 32
+)
+
+var fileMagic = []byte("DKF\x01")
+
+// fileKey returns per-file key derived from master key
+func (m *MasterKey) fileKey(salt []byte) ([]byte, error) {
+	if !m.IsSet() {
+		return nil, model.ErrMasterKeyNotSet
+	}
+	key := make([]byte, chacha20poly1305.KeySize)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, m.secret, salt, []byte("datakkeeper-file")), key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// contentSalt returns the salt of the file key for the content of r,
+// the same content of the user always gets the same salt
+func (m *MasterKey) contentSalt(r io.Reader) ([]byte, error) {
+	if !m.IsSet() {
+		return nil, model.ErrMasterKeyNotSet
+	}
+	mac := hmac.New(sha256.New, m.secret)
+	mac.Write([]byte("datakkeeper-file-salt"))
+	if _, err := io.Copy(mac, r); err != nil {
+		return nil, err
+	}
+	return mac.Sum(nil), nil
+}
+
+func chunkNonce(counter uint64, last bool) []byte {
+	nonce := make([]byte, chacha20poly1305.NonceSize)
+	binary.BigEndian.PutUint64(nonce[3:11], counter)
+	if last {
+		nonce[11] = 1
+	}
+	return nonce
+}
+
+type encryptWriter struct {
+	w       io.Writer
+	aead    cipher.AEAD
+	header  []byte
+	buf     []byte
+	counter uint64
+	closed  bool
+}
+
+// newEncryptWriter returns writer which encrypts everything written to it into w,
+// salt is contentSalt of the content. Close must be called to write the final chunk.
+func newEncryptWriter(w io.Writer, mk *MasterKey, salt []byte) (io.WriteCloser, error) {
+	if len(salt) != fileSaltSize {
+		return nil, errors.New("invalid file salt size")
+	}
+	key, err := mk.fileKey(salt)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := chacha20poly1305.New(key)
+	if err != nil {
+		return nil, err
+	}
+
+	keyID := mk.Hash()
+	header := make([]byte, 0, len(fileMagic)+1+len(keyID)+fileSaltSize)
+	header = append(header, fileMagic...)
+	header = append(header, byte(len(keyID)))
+	header = append(header, keyID...)
+	header = append(header, salt...)
+
+	return &encryptWriter{
+		w:      w,
+		aead:   aead,
+		header: header,
+		buf:    make([]byte, 0, fileChunkSize),
+	}, nil
+}
+
+func (e *encryptWriter) Write(p []byte) (int, error) {
+	if e.closed {
+		return 0, errors.New("write to closed encrypt writer")
+	}
+	n := 0
+	for len(p) > 0 {
+		// полный буфер сбрасывается только когда пришли новые данные,
+		// иначе он может оказаться последним чанком
+		if len(e.buf) == fileChunkSize {
+			if err := e.flush(false); err != nil {
+				return n, err
+			}
+		}
+		k := copy(e.buf[len(e.buf):fileChunkSize], p)
+		e.buf = e.buf[:len(e.buf)+k]
+		p = p[k:]
+		n += k
+	}
+	return n, nil
+}
+
+func (e *encryptWriter) flush(last bool) error {
+	var out []byte
+	// заголовок уходит вместе с первым чанком
+	if e.counter == 0 {
+		out = append(out, e.header...)
+	}
+	out = e.aead.Seal(out, chunkNonce(e.counter, last), e.buf, e.header)
+	e.counter++
+	e.buf = e.buf[:0]
+	_, err := e.w.Write(out)
+	return err
+}
+
+// Close writes the final chunk, it doesn't close underlying writer
+func (e *encryptWriter) Close() error {
+	if e.closed {
+		return nil
+	}
+	e.closed = true
+	return e.flush(true)
+}
+
+type decryptReader struct {
+	r       *bufio.Reader
+	aead    cipher.AEAD
+	header  []byte
+	chunk   []byte
+	out     []byte
+	plain   []byte
+	counter uint64
+	done    bool
+}
+
+// newDecryptReader returns reader of decrypted content of r.
+// Files uploaded before encryption was introduced are returned as is.
+func newDecryptReader(r io.Reader, mk *MasterKey) (io.Reader, bool, error) {
+	br := bufio.NewReaderSize(r, fileChunkSize+chacha20poly1305.Overhead+1)
+	magic, err := br.Peek(len(fileMagic))
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, false, err
+	}
+	if !bytes.Equal(magic, fileMagic) {
+		return br, false, nil
+	}
+
+	header := make([]byte, len(fileMagic)+1)
+	if _, err := io.ReadFull(br, header); err != nil {
+		return nil, true, model.ErrFileCorrupted
+	}
+	rest := make([]byte, int(header[len(fileMagic)])+fileSaltSize)
+	if _, err := io.ReadFull(br, rest); err != nil {
+		return nil, true, model.ErrFileCorrupted
+	}
+	header = append(header, rest...)
+
+	keyID := string(rest[:len(rest)-fileSaltSize])
+	if !mk.IsSet() {
+		return nil, true, model.ErrMasterKeyNotSet
+	}
+	if keyID != mk.Hash() {
+		return nil, true, model.ErrWrongMasterKey
+	}
+	key, err := mk.fileKey(rest[len(rest)-fileSaltSize:])
+	if err != nil {
+		return nil, true, err
+	}
+	aead, err := chacha20poly1305.New(key)
+	if err != nil {
+		return nil, true, err
+	}
+
+	return &decryptReader{
+		r:      br,
+		aead:   aead,
+		header: header,
+		chunk:  make([]byte, fileChunkSize+aead.Overhead()),
+		out:    make([]byte, 0, fileChunkSize),
+	}, true, nil
+}
+
+func (d *decryptReader) Read(p []byte) (int, error) {
+	for len(d.plain) == 0 {
+		if d.done {
+			return 0, io.EOF
+		}
+		if err := d.next(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, d.plain)
+	d.plain = d.plain[n:]
+	return n, nil
+}
+
+func (d *decryptReader) next() error {
+	n, err := io.ReadFull(d.r, d.chunk)
+	switch {
+	case errors.Is(err, io.EOF):
+		// поток закончился до последнего чанка
+		return model.ErrFileTruncated
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		d.done = true
+	case err != nil:
+		return err
+	default:
+		// полный чанк последний, если за ним ничего нет
+		if _, perr := d.r.Peek(1); errors.Is(perr, io.EOF) {
+			d.done = true
+		}
+	}
+
+	plain, err := d.aead.Open(d.out[:0], chunkNonce(d.counter, d.done), d.chunk[:n], d.header)
+	if err != nil {
+		// различаем обрезанный файл и поврежденный чанк
+		if _, lerr := d.aead.Open(nil, chunkNonce(d.counter, !d.done), d.chunk[:n], d.header); lerr == nil && d.done {
+			return model.ErrFileTruncated
+		}
+		return model.ErrFileCorrupted
+	}
+	d.counter++
+	d.plain = plain
+	return nil
+}
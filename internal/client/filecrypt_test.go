@@ -0,0 +1,135 @@
+package client
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"testing"
+
+	"github.com/Arcadian-Sky/datakkeeper/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+func encryptForTest(t *testing.T, mk *MasterKey, plain []byte) []byte {
+	var buf bytes.Buffer
+	salt, err := mk.contentSalt(bytes.NewReader(plain))
+	require.NoError(t, err)
+	enc, err := newEncryptWriter(&buf, mk, salt)
+	require.NoError(t, err)
+	// пишем кусками, не совпадающими с размером чанка
+	for len(plain) > 0 {
+		k := min(len(plain), 1000)
+		_, err = enc.Write(plain[:k])
+		require.NoError(t, err)
+		plain = plain[k:]
+	}
+	require.NoError(t, enc.Close())
+	return buf.Bytes()
+}
+
+func decryptForTest(mk *MasterKey, data []byte) ([]byte, error) {
+	dec, _, err := newDecryptReader(bytes.NewReader(data), mk)
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(dec)
+}
+
+func TestFileCrypt_RoundTrip(t *testing.T) {
+	mk := &keyStorage().MasterKey
+
+	for _, size := range []int{0, 1, fileChunkSize - 1, fileChunkSize, fileChunkSize + 1, 3 * fileChunkSize} {
+		plain := make([]byte, size)
+		_, _ = rand.Read(plain)
+
+		sealed := encryptForTest(t, mk, plain)
+		assert.False(t, size > 16 && bytes.Contains(sealed, plain[:16]))
+
+		got, err := decryptForTest(mk, sealed)
+		assert.NoError(t, err, "size %d", size)
+		assert.True(t, bytes.Equal(plain, got), "size %d", size)
+	}
+}
+
+func TestFileCrypt_Convergent(t *testing.T) {
+	mk := &keyStorage().MasterKey
+	plain := []byte("installer content")
+
+	// одинаковое содержимое шифруется одинаково, сервер может хранить его один раз
+	sealed := encryptForTest(t, mk, plain)
+	assert.Equal(t, sealed, encryptForTest(t, mk, plain))
+	assert.NotEqual(t, sealed, encryptForTest(t, mk, []byte("other content")))
+
+	// у другого пользователя другой мастер-ключ и другой шифротекст
+	other := &MemStorage{Login: "other"}
+	other.SetMasterKey("otherKey", "")
+	assert.NotEqual(t, sealed[len(sealed)-32:], encryptForTest(t, &other.MasterKey, plain)[len(sealed)-32:])
+}
+
+func TestFileCrypt_Truncated(t *testing.T) {
+	mk := &keyStorage().MasterKey
+	plain := make([]byte, 2*fileChunkSize+100)
+	sealed := encryptForTest(t, mk, plain)
+	headerLen := len(fileMagic) + 1 + len(mk.Hash()) + fileSaltSize
+	fullChunk := fileChunkSize + chacha20poly1305.Overhead
+
+	// отрезаны последние чанки целиком
+	_, err := decryptForTest(mk, sealed[:headerLen+2*fullChunk])
+	assert.ErrorIs(t, err, model.ErrFileTruncated)
+	_, err = decryptForTest(mk, sealed[:headerLen+fullChunk])
+	assert.ErrorIs(t, err, model.ErrFileTruncated)
+	_, err = decryptForTest(mk, sealed[:headerLen])
+	assert.ErrorIs(t, err, model.ErrFileTruncated)
+
+	// отрезана часть чанка
+	_, err = decryptForTest(mk, sealed[:len(sealed)-10])
+	assert.Error(t, err)
+
+	// дописаны данные после последнего чанка
+	_, err = decryptForTest(mk, append(append([]byte{}, sealed...), 1, 2, 3))
+	assert.ErrorIs(t, err, model.ErrFileCorrupted)
+}
+
+func TestFileCrypt_Tampered(t *testing.T) {
+	mk := &keyStorage().MasterKey
+	sealed := encryptForTest(t, mk, []byte("test content"))
+
+	// подмена соли в заголовке меняет ключ файла
+	tampered := append([]byte{}, sealed...)
+	tampered[len(fileMagic)+1+len(mk.Hash())] ^= 0xff
+	_, err := decryptForTest(mk, tampered)
+	assert.ErrorIs(t, err, model.ErrFileCorrupted)
+
+	tampered = append([]byte{}, sealed...)
+	tampered[len(tampered)-1] ^= 0xff
+	_, err = decryptForTest(mk, tampered)
+	assert.ErrorIs(t, err, model.ErrFileCorrupted)
+}
+
+func TestFileCrypt_WrongKey(t *testing.T) {
+	sealed := encryptForTest(t, &keyStorage().MasterKey, []byte("test content"))
+
+	wrong := &MemStorage{Login: "user"}
+	wrong.SetMasterKey("wrongKey", "")
+	_, err := decryptForTest(&wrong.MasterKey, sealed)
+	assert.ErrorIs(t, err, model.ErrWrongMasterKey)
+
+	_, err = decryptForTest(&MasterKey{}, sealed)
+	assert.ErrorIs(t, err, model.ErrMasterKeyNotSet)
+
+	_, err = (&MasterKey{}).contentSalt(bytes.NewReader(nil))
+	assert.ErrorIs(t, err, model.ErrMasterKeyNotSet)
+	_, err = newEncryptWriter(io.Discard, &MasterKey{}, make([]byte, fileSaltSize))
+	assert.ErrorIs(t, err, model.ErrMasterKeyNotSet)
+}
+
+func TestFileCrypt_Legacy(t *testing.T) {
+	dec, encrypted, err := newDecryptReader(bytes.NewReader([]byte("plain file")), &MasterKey{})
+	assert.NoError(t, err)
+	assert.False(t, encrypted)
+	got, err := io.ReadAll(dec)
+	assert.NoError(t, err)
+	assert.Equal(t, "plain file", string(got))
+}
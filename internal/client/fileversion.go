@@ -0,0 +1,63 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pbsrv "github.com/Arcadian-Sky/datakkeeper/gen/proto/api/service/v1"
+	"github.com/Arcadian-Sky/datakkeeper/internal/model"
+)
+
+// ListFileVersions returns stored versions of the file, newest first
+func (gc *GRPCClient) ListFileVersions(fileName string) ([]model.FileVersion, error) {
+	if gc.Data == nil {
+		return nil, fmt.Errorf("GRPC client is not initialized")
+	}
+
+	// Создаем контекст с таймаутом для запроса
+	ctx, cancel := context.WithTimeout(context.Background(), period)
+	defer cancel()
+	res, err := gc.Data.ListFileVersions(ctx, &pbsrv.ListFileVersionsRequest{Filename: fileName})
+	if err != nil {
+		gc.log.Debug("Error during get file versions : ", err)
+		return nil, err
+	}
+	gc.log.Trace(res)
+
+	versions := make([]model.FileVersion, 0, len(res.Versions))
+	for _, v := range res.Versions {
+		versions = append(versions, model.FileVersion{
+			VersionID:  v.VersionId,
+			Hash:       v.Key,
+			Size:       v.Size,
+			ModifiedAt: time.Unix(v.ModifiedAt, 0),
+			Latest:     v.Latest,
+		})
+	}
+	return versions, nil
+}
+
+// RestoreFileVersion makes the stored version the current content of the file.
+// revision - версия файла у клиента, при расхождении возвращается ConflictError.
+func (gc *GRPCClient) RestoreFileVersion(fileName, versionID string, revision int64) error {
+	if gc.Data == nil {
+		return fmt.Errorf("GRPC client is not initialized")
+	}
+
+	// Создаем контекст с таймаутом для запроса
+	ctx, cancel := context.WithTimeout(context.Background(), period)
+	defer cancel()
+	res, err := gc.Data.RestoreFileVersion(ctx, &pbsrv.RestoreFileVersionRequest{
+		Filename:         fileName,
+		VersionId:        versionID,
+		ExpectedRevision: &revision,
+	})
+	if err != nil {
+		gc.log.Debug("Error during restore file version : ", err)
+		return gc.conflictError(err)
+	}
+	gc.log.Trace(res)
+
+	return nil
+}
@@ -0,0 +1,98 @@
+package client
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	pbservice "github.com/Arcadian-Sky/datakkeeper/gen/proto/api/service/v1"
+	"github.com/Arcadian-Sky/datakkeeper/internal/model"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestListFileVersions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataClient := pbservice.NewMockDataKeeperServiceClient(ctrl)
+	client := &GRPCClient{
+		log:  logrus.New(),
+		Data: mockDataClient,
+	}
+
+	mockDataClient.EXPECT().
+		ListFileVersions(gomock.Any(), &pbservice.ListFileVersionsRequest{Filename: "a.txt"}).
+		Return(&pbservice.ListFileVersionsResponse{Versions: []*pbservice.FileVersion{
+			{VersionId: "v2", Key: "e2", Size: 20, ModifiedAt: 1714557600, Latest: true},
+			{VersionId: "v1", Key: "e1", Size: 10, ModifiedAt: 1714554000},
+		}}, nil)
+
+	versions, err := client.ListFileVersions("a.txt")
+	assert.NoError(t, err)
+	assert.Equal(t, []model.FileVersion{
+		{VersionID: "v2", Hash: "e2", Size: 20, ModifiedAt: time.Unix(1714557600, 0), Latest: true},
+		{VersionID: "v1", Hash: "e1", Size: 10, ModifiedAt: time.Unix(1714554000, 0)},
+	}, versions)
+
+	mockDataClient.EXPECT().ListFileVersions(gomock.Any(), gomock.Any()).Return(nil, errors.New("versions error"))
+	_, err = client.ListFileVersions("a.txt")
+	assert.EqualError(t, err, "versions error")
+}
+
+func TestGetFileVersion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataClient := pbservice.NewMockDataKeeperServiceClient(ctrl)
+	mockStream := pbservice.NewMockDataKeeperService_GetFileClient(ctrl)
+	dir := t.TempDir()
+	client := &GRPCClient{
+		log:     logrus.New(),
+		Data:    mockDataClient,
+		Storage: &MemStorage{PfilesDir: dir},
+	}
+
+	mockDataClient.EXPECT().
+		GetFile(gomock.Any(), &pbservice.GetFileRequest{Name: "a.txt", VersionId: "v1"}).
+		Return(mockStream, nil)
+	gomock.InOrder(
+		mockStream.EXPECT().Recv().Return(&pbservice.FileChunk{Data: []byte("old content")}, nil),
+		mockStream.EXPECT().Recv().Return(nil, io.EOF),
+	)
+
+	assert.NoError(t, client.GetFileVersion("a.txt", "v1"))
+	content, err := os.ReadFile(filepath.Join(dir, "a.txt"))
+	assert.NoError(t, err)
+	assert.Equal(t, "old content", string(content))
+}
+
+func TestRestoreFileVersion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataClient := pbservice.NewMockDataKeeperServiceClient(ctrl)
+	client := &GRPCClient{
+		log:  logrus.New(),
+		Data: mockDataClient,
+	}
+
+	mockDataClient.EXPECT().
+		RestoreFileVersion(gomock.Any(), &pbservice.RestoreFileVersionRequest{Filename: "a.txt", VersionId: "v1", ExpectedRevision: proto.Int64(5)}).
+		Return(&pbservice.UploadStatus{Success: true}, nil)
+	assert.NoError(t, client.RestoreFileVersion("a.txt", "v1", 5))
+
+	mockDataClient.EXPECT().
+		RestoreFileVersion(gomock.Any(), gomock.Any()).
+		Return(nil, conflictStatus(t, &pbservice.FileItem{Name: "a.txt", Revision: 7}))
+	err := client.RestoreFileVersion("a.txt", "v1", 5)
+	var conflict *ConflictError
+	if assert.True(t, errors.As(err, &conflict)) {
+		assert.Equal(t, int64(7), conflict.File.Revision)
+	}
+}
@@ -0,0 +1,79 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	pbsrv "github.com/Arcadian-Sky/datakkeeper/gen/proto/api/service/v1"
+	"github.com/Arcadian-Sky/datakkeeper/internal/model"
+)
+
+// CreateFolder creates an empty folder, path - полный путь папки через "/"
+func (gc *GRPCClient) CreateFolder(path string) error {
+	if gc.Data == nil {
+		return fmt.Errorf("GRPC client is not initialized")
+	}
+	if err := model.ValidateFileName(path); err != nil {
+		return err
+	}
+
+	// Создаем контекст с таймаутом для запроса
+	ctx, cancel := context.WithTimeout(context.Background(), period)
+	defer cancel()
+	res, err := gc.Data.CreateFolder(ctx, &pbsrv.CreateFolderRequest{Path: path})
+	if err != nil {
+		gc.log.Debug("Error during create folder : ", err)
+		return err
+	}
+	gc.log.Trace(res)
+
+	return nil
+}
+
+// RenameFile renames the file or folder inside its folder.
+// revision - версия файла у клиента, при расхождении возвращается ConflictError.
+func (gc *GRPCClient) RenameFile(fileName, newName string, revision int64) error {
+	if gc.Data == nil {
+		return fmt.Errorf("GRPC client is not initialized")
+	}
+
+	// Создаем контекст с таймаутом для запроса
+	ctx, cancel := context.WithTimeout(context.Background(), period)
+	defer cancel()
+	res, err := gc.Data.RenameFile(ctx, &pbsrv.RenameFileRequest{
+		Filename:         fileName,
+		NewName:          newName,
+		ExpectedRevision: &revision,
+	})
+	if err != nil {
+		gc.log.Debug("Error during rename file : ", err)
+		return gc.conflictError(err)
+	}
+	gc.log.Trace(res)
+
+	return nil
+}
+
+// MoveFile moves the file or folder into folder, пустая folder - корень.
+// revision - версия файла у клиента, при расхождении возвращается ConflictError.
+func (gc *GRPCClient) MoveFile(fileName, folder string, revision int64) error {
+	if gc.Data == nil {
+		return fmt.Errorf("GRPC client is not initialized")
+	}
+
+	// Создаем контекст с таймаутом для запроса
+	ctx, cancel := context.WithTimeout(context.Background(), period)
+	defer cancel()
+	res, err := gc.Data.MoveFile(ctx, &pbsrv.MoveFileRequest{
+		Filename:         fileName,
+		Folder:           folder,
+		ExpectedRevision: &revision,
+	})
+	if err != nil {
+		gc.log.Debug("Error during move file : ", err)
+		return gc.conflictError(err)
+	}
+	gc.log.Trace(res)
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package client
+
+import (
+	"errors"
+	"testing"
+
+	pbservice "github.com/Arcadian-Sky/datakkeeper/gen/proto/api/service/v1"
+	"github.com/Arcadian-Sky/datakkeeper/internal/model"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestCreateFolder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataClient := pbservice.NewMockDataKeeperServiceClient(ctrl)
+	client := &GRPCClient{
+		log:  logrus.New(),
+		Data: mockDataClient,
+	}
+
+	mockDataClient.EXPECT().
+		CreateFolder(gomock.Any(), &pbservice.CreateFolderRequest{Path: "work/2024"}).
+		Return(&pbservice.UploadStatus{Success: true}, nil)
+	assert.NoError(t, client.CreateFolder("work/2024"))
+
+	mockDataClient.EXPECT().CreateFolder(gomock.Any(), gomock.Any()).Return(nil, errors.New("folder exists"))
+	assert.EqualError(t, client.CreateFolder("work"), "folder exists")
+
+	// неверное имя не отправляется на сервер
+	assert.ErrorIs(t, client.CreateFolder("work/../x"), model.ErrFileNameInvalid)
+}
+
+func TestRenameFile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataClient := pbservice.NewMockDataKeeperServiceClient(ctrl)
+	client := &GRPCClient{
+		log:  logrus.New(),
+		Data: mockDataClient,
+	}
+
+	mockDataClient.EXPECT().
+		RenameFile(gomock.Any(), &pbservice.RenameFileRequest{Filename: "work/a.txt", NewName: "b.txt", ExpectedRevision: proto.Int64(5)}).
+		Return(&pbservice.UploadStatus{Success: true}, nil)
+	assert.NoError(t, client.RenameFile("work/a.txt", "b.txt", 5))
+
+	mockDataClient.EXPECT().
+		RenameFile(gomock.Any(), gomock.Any()).
+		Return(nil, conflictStatus(t, &pbservice.FileItem{Name: "work/a.txt", Revision: 7}))
+	err := client.RenameFile("work/a.txt", "b.txt", 5)
+	var conflict *ConflictError
+	if assert.True(t, errors.As(err, &conflict)) {
+		assert.Equal(t, int64(7), conflict.File.Revision)
+	}
+}
+
+func TestMoveFile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataClient := pbservice.NewMockDataKeeperServiceClient(ctrl)
+	client := &GRPCClient{
+		log:  logrus.New(),
+		Data: mockDataClient,
+	}
+
+	mockDataClient.EXPECT().
+		MoveFile(gomock.Any(), &pbservice.MoveFileRequest{Filename: "work/a.txt", Folder: "", ExpectedRevision: proto.Int64(5)}).
+		Return(&pbservice.UploadStatus{Success: true}, nil)
+	assert.NoError(t, client.MoveFile("work/a.txt", "", 5))
+
+	mockDataClient.EXPECT().MoveFile(gomock.Any(), gomock.Any()).Return(nil, errors.New("move error"))
+	assert.EqualError(t, client.MoveFile("a.txt", "work", 0), "move error")
+
+	assert.Error(t, (&GRPCClient{log: logrus.New()}).MoveFile("a.txt", "work", 0))
+}
@@ -0,0 +1,65 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pbsrv "github.com/Arcadian-Sky/datakkeeper/gen/proto/api/service/v1"
+	"github.com/Arcadian-Sky/datakkeeper/internal/model"
+)
+
+// ListDataHistory returns decrypted previous versions of the record, newest first
+func (gc *GRPCClient) ListDataHistory(id int64) ([]model.DataRevision, error) {
+	if gc.Data == nil {
+		return nil, fmt.Errorf("GRPC client is not initialized")
+	}
+
+	// Создаем контекст с таймаутом для запроса
+	ctx, cancel := context.WithTimeout(context.Background(), period)
+	defer cancel()
+	res, err := gc.Data.ListDataHistory(ctx, &pbsrv.ListDataHistoryRequest{Dataid: id})
+	if err != nil {
+		gc.log.Debug("Error during get data history : ", err)
+		return nil, err
+	}
+	gc.log.Trace(res)
+
+	history := make([]model.DataRevision, 0, len(res.Revisions))
+	for _, rev := range res.Revisions {
+		if rev.Data == nil {
+			return nil, model.ErrEmptyResponse
+		}
+		d, err := gc.openData(rev.Data)
+		if err != nil {
+			gc.log.Debug("Error during decrypt data : ", err)
+			return nil, err
+		}
+		history = append(history, model.DataRevision{Data: d, ChangedAt: time.Unix(rev.ChangedAt, 0)})
+	}
+	return history, nil
+}
+
+// RestoreDataRevision makes the content of the record at revision its current content.
+// expected - версия записи у клиента, при расхождении возвращается ConflictError, 0 - без проверки.
+func (gc *GRPCClient) RestoreDataRevision(id, revision, expected int64) error {
+	if gc.Data == nil {
+		return fmt.Errorf("GRPC client is not initialized")
+	}
+
+	// Создаем контекст с таймаутом для запроса
+	ctx, cancel := context.WithTimeout(context.Background(), period)
+	defer cancel()
+	res, err := gc.Data.RestoreDataRevision(ctx, &pbsrv.RestoreDataRevisionRequest{
+		Dataid:           id,
+		Revision:         revision,
+		ExpectedRevision: expected,
+	})
+	if err != nil {
+		gc.log.Debug("Error during restore data : ", err)
+		return gc.conflictError(err)
+	}
+	gc.log.Trace(res)
+
+	return nil
+}
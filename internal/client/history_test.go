@@ -0,0 +1,70 @@
+package client
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	pbservice "github.com/Arcadian-Sky/datakkeeper/gen/proto/api/service/v1"
+	"github.com/Arcadian-Sky/datakkeeper/internal/model"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListDataHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataClient := pbservice.NewMockDataKeeperServiceClient(ctrl)
+	client := &GRPCClient{
+		log:     logrus.New(),
+		Data:    mockDataClient,
+		Storage: keyStorage(),
+	}
+
+	sealed, err := client.sealData(model.Data{ID: 10, Type: "DATA_TYPE_TYPE_LOGIN_PASSWORD", Title: "site", Login: "me", Password: "old"})
+	assert.NoError(t, err)
+	sealed.Revision = 4
+	mockDataClient.EXPECT().
+		ListDataHistory(gomock.Any(), &pbservice.ListDataHistoryRequest{Dataid: 10}).
+		Return(&pbservice.ListDataHistoryResponse{Revisions: []*pbservice.DataRevision{{Data: sealed, ChangedAt: 1714557600}}}, nil)
+
+	history, err := client.ListDataHistory(10)
+	assert.NoError(t, err)
+	if assert.Len(t, history, 1) {
+		assert.Equal(t, "old", history[0].Data.Password)
+		assert.Equal(t, int64(4), history[0].Data.Revision)
+		assert.Equal(t, time.Unix(1714557600, 0), history[0].ChangedAt)
+	}
+
+	mockDataClient.EXPECT().ListDataHistory(gomock.Any(), gomock.Any()).Return(nil, errors.New("history error"))
+	_, err = client.ListDataHistory(10)
+	assert.EqualError(t, err, "history error")
+}
+
+func TestRestoreDataRevision(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataClient := pbservice.NewMockDataKeeperServiceClient(ctrl)
+	client := &GRPCClient{
+		log:     logrus.New(),
+		Data:    mockDataClient,
+		Storage: keyStorage(),
+	}
+
+	mockDataClient.EXPECT().
+		RestoreDataRevision(gomock.Any(), &pbservice.RestoreDataRevisionRequest{Dataid: 10, Revision: 4, ExpectedRevision: 6}).
+		Return(&pbservice.UploadStatus{Success: true}, nil)
+	assert.NoError(t, client.RestoreDataRevision(10, 4, 6))
+
+	mockDataClient.EXPECT().
+		RestoreDataRevision(gomock.Any(), gomock.Any()).
+		Return(nil, conflictStatus(t, &pbservice.Data{Id: 10, Title: "server copy", Revision: 8}))
+	err := client.RestoreDataRevision(10, 4, 6)
+	var conflict *ConflictError
+	if assert.True(t, errors.As(err, &conflict)) {
+		assert.Equal(t, int64(8), conflict.Data.Revision)
+	}
+}
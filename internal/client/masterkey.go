@@ -1,23 +1,268 @@
 package client
 
 import (
+	"bytes"
+	"crypto/rand"
 	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
 	"fmt"
+
+	"github.com/Arcadian-Sky/datakkeeper/internal/model"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+// Параметры argon2id, ключ получается один раз при вводе мастер-пароля
+const (
+	kdfTime    = 3
+	kdfMemory  = 64 * 1024
+	kdfThreads = 4
 )
 
+// sealVersion - первый байт шифротекста, позволит сменить схему без потери старых записей
+const sealVersion byte = 1
+
+// sealVersionRecord - шифротекст поля записи, привязанный к записи: version | record key | nonce | ciphertext.
+// Номер записи выдает сервер уже после шифрования, поэтому запись определяют ее тип, название
+// и случайный ключ записи, общий для всех ее полей
+const sealVersionRecord byte = 2
+
+// recordKeySize - длина случайного ключа записи
+const recordKeySize = 16
+
 // MasterKey represents master key
 type MasterKey struct {
 	Key     string
 	KeyPath string
 	KeyHash []byte
+	secret  []byte
 }
 
-// SetHash calculates key hash and stores it in KeyHash attribute
-func (m *MasterKey) SetHash() {
-	hash := sha256.Sum256([]byte(m.Key))
+// Derive calculates encryption key from Key with argon2id and stores its hash in KeyHash attribute.
+// Salt must be the same on every device of the user, so login is used.
+func (m *MasterKey) Derive(salt string) {
+	s := sha256.Sum256([]byte("datakkeeper:" + salt))
+	m.secret = argon2.IDKey([]byte(m.Key), s[:], kdfTime, kdfMemory, kdfThreads, chacha20poly1305.KeySize)
+
+	// на сервер уходит только хеш ключа, по нему нельзя восстановить сам ключ
+	hash := sha256.Sum256(append([]byte("datakkeeper-keyhash:"), m.secret...))
 	m.KeyHash = hash[:]
 }
 
+// IsSet reports whether encryption key was derived
+func (m *MasterKey) IsSet() bool {
+	return len(m.secret) != 0
+}
+
+// Hash returns KeyHash as it is stored on server
+func (m *MasterKey) Hash() string {
+	return hex.EncodeToString(m.KeyHash)
+}
+
+// Seal encrypts value with XChaCha20-Poly1305, field name is authenticated as additional data
+// so that ciphertexts of different fields can't be swapped
+func (m *MasterKey) Seal(field, value string) (string, error) {
+	out, err := m.SealBytes(field, []byte(value))
+	if err != nil || out == nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(out), nil
+}
+
+// Open decrypts value sealed by Seal
+func (m *MasterKey) Open(field, value string) (string, error) {
+	if !m.IsSet() {
+		return "", model.ErrMasterKeyNotSet
+	}
+	if value == "" {
+		return "", nil
+	}
+	raw, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return "", model.ErrWrongMasterKey
+	}
+	plain, err := m.OpenBytes(field, raw)
+	return string(plain), err
+}
+
+// SealBytes encrypts binary value, result is version | nonce | ciphertext
+func (m *MasterKey) SealBytes(field string, value []byte) ([]byte, error) {
+	if !m.IsSet() {
+		return nil, model.ErrMasterKeyNotSet
+	}
+	if len(value) == 0 {
+		return nil, nil
+	}
+	aead, err := chacha20poly1305.NewX(m.secret)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, 1+aead.NonceSize(), 1+aead.NonceSize()+len(value)+aead.Overhead())
+	out[0] = sealVersion
+	if _, err := rand.Read(out[1:]); err != nil {
+		return nil, err
+	}
+	return aead.Seal(out, out[1:], value, []byte(field)), nil
+}
+
+// OpenBytes decrypts value sealed by SealBytes
+func (m *MasterKey) OpenBytes(field string, value []byte) ([]byte, error) {
+	if !m.IsSet() {
+		return nil, model.ErrMasterKeyNotSet
+	}
+	if len(value) == 0 {
+		return nil, nil
+	}
+	aead, err := chacha20poly1305.NewX(m.secret)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(value) < 1+aead.NonceSize() || value[0] != sealVersion {
+		return nil, model.ErrWrongMasterKey
+	}
+	plain, err := aead.Open(nil, value[1:1+aead.NonceSize()], value[1+aead.NonceSize():], []byte(field))
+	if err != nil {
+		return nil, model.ErrWrongMasterKey
+	}
+
+	return plain, nil
+}
+
+// RecordSealer шифрует поля одной записи (sealVersionRecord). Тип, название и ключ записи входят
+// в дополнительные данные AEAD, поэтому сервер не может незаметно переставить шифротексты между записями
+type RecordSealer struct {
+	mk    *MasterKey
+	key   []byte
+	dtype string
+	title string
+}
+
+// NewRecordSealer creates sealer for the record with a fresh record key
+func (m *MasterKey) NewRecordSealer(dtype, title string) (*RecordSealer, error) {
+	if !m.IsSet() {
+		return nil, model.ErrMasterKeyNotSet
+	}
+	key := make([]byte, recordKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	return &RecordSealer{mk: m, key: key, dtype: dtype, title: title}, nil
+}
+
+// Seal encrypts field value of the record
+func (r *RecordSealer) Seal(field, value string) (string, error) {
+	out, err := r.SealBytes(field, []byte(value))
+	if err != nil || out == nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(out), nil
+}
+
+// SealBytes encrypts binary field value of the record
+func (r *RecordSealer) SealBytes(field string, value []byte) ([]byte, error) {
+	if len(value) == 0 {
+		return nil, nil
+	}
+	aead, err := chacha20poly1305.NewX(r.mk.secret)
+	if err != nil {
+		return nil, err
+	}
+
+	head := 1 + recordKeySize + aead.NonceSize()
+	out := make([]byte, head, head+len(value)+aead.Overhead())
+	out[0] = sealVersionRecord
+	copy(out[1:], r.key)
+	if _, err := rand.Read(out[1+recordKeySize : head]); err != nil {
+		return nil, err
+	}
+	return aead.Seal(out, out[1+recordKeySize:head], value, recordAD(field, r.dtype, r.title, r.key)), nil
+}
+
+// RecordOpener расшифровывает поля одной записи. Все поля должны быть одной версии и с одним ключом записи,
+// иначе в запись подложено поле из другой записи
+type RecordOpener struct {
+	mk      *MasterKey
+	dtype   string
+	title   string
+	version byte
+	key     []byte
+}
+
+// NewRecordOpener creates opener for the record with the given type and title
+func (m *MasterKey) NewRecordOpener(dtype, title string) *RecordOpener {
+	return &RecordOpener{mk: m, dtype: dtype, title: title}
+}
+
+// Open decrypts field value sealed by RecordSealer.Seal or, for old records, by MasterKey.Seal
+func (o *RecordOpener) Open(field, value string) (string, error) {
+	if !o.mk.IsSet() {
+		return "", model.ErrMasterKeyNotSet
+	}
+	if value == "" {
+		return "", nil
+	}
+	raw, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return "", model.ErrWrongMasterKey
+	}
+	plain, err := o.OpenBytes(field, raw)
+	return string(plain), err
+}
+
+// OpenBytes decrypts binary field value sealed by RecordSealer.SealBytes or MasterKey.SealBytes
+func (o *RecordOpener) OpenBytes(field string, value []byte) ([]byte, error) {
+	if !o.mk.IsSet() {
+		return nil, model.ErrMasterKeyNotSet
+	}
+	if len(value) == 0 {
+		return nil, nil
+	}
+	if o.version == 0 {
+		o.version = value[0]
+	}
+	if value[0] != o.version {
+		return nil, model.ErrWrongMasterKey
+	}
+	if o.version == sealVersion {
+		return o.mk.OpenBytes(field, value)
+	}
+
+	aead, err := chacha20poly1305.NewX(o.mk.secret)
+	if err != nil {
+		return nil, err
+	}
+	head := 1 + recordKeySize + aead.NonceSize()
+	if len(value) < head || value[0] != sealVersionRecord {
+		return nil, model.ErrWrongMasterKey
+	}
+	key := value[1 : 1+recordKeySize]
+	if o.key == nil {
+		o.key = bytes.Clone(key)
+	}
+	if !bytes.Equal(key, o.key) {
+		return nil, model.ErrWrongMasterKey
+	}
+	plain, err := aead.Open(nil, value[1+recordKeySize:head], value[head:], recordAD(field, o.dtype, o.title, key))
+	if err != nil {
+		return nil, model.ErrWrongMasterKey
+	}
+	return plain, nil
+}
+
+// recordAD - дополнительные данные поля записи, каждая часть с длиной, чтобы границы частей нельзя было сдвинуть
+func recordAD(field, dtype, title string, key []byte) []byte {
+	var ad []byte
+	for _, part := range [][]byte{[]byte(field), []byte(dtype), []byte(title), key} {
+		ad = binary.AppendUvarint(ad, uint64(len(part)))
+		ad = append(ad, part...)
+	}
+	return ad
+}
+
 // Str returns stirng representation of struct
 func (m *MasterKey) Str() string {
 	return fmt.Sprintf("<MasterKey key:'%s', keyPath:'%s' keyHash: '%x'>",
@@ -2,21 +2,135 @@ package client
 
 import (
 	"crypto/sha256"
+	"encoding/base64"
 	"fmt"
 	"testing"
 
+	"github.com/Arcadian-Sky/datakkeeper/internal/model"
 	"github.com/stretchr/testify/assert"
 )
 
-func TestMasterKey_SetHash(t *testing.T) {
-	key := "testKey"
-	expectedHash := sha256.Sum256([]byte(key))
+func TestMasterKey_Derive(t *testing.T) {
+	masterKey := &MasterKey{Key: "testKey"}
+	assert.False(t, masterKey.IsSet())
+
+	masterKey.Derive("user")
+	assert.True(t, masterKey.IsSet())
+	assert.Len(t, masterKey.KeyHash, sha256.Size)
+
+	// хеш не совпадает с простым sha256 пароля, по нему нельзя подобрать пароль быстрым перебором
+	plain := sha256.Sum256([]byte("testKey"))
+	assert.NotEqual(t, plain[:], masterKey.KeyHash)
+
+	// тот же пароль и логин на другом устройстве дают тот же ключ
+	same := &MasterKey{Key: "testKey"}
+	same.Derive("user")
+	assert.Equal(t, masterKey.Hash(), same.Hash())
+
+	other := &MasterKey{Key: "testKey"}
+	other.Derive("another")
+	assert.NotEqual(t, masterKey.Hash(), other.Hash())
+}
+
+func TestMasterKey_SealOpen(t *testing.T) {
+	masterKey := &MasterKey{Key: "testKey"}
+	masterKey.Derive("user")
+
+	sealed, err := masterKey.Seal("password", "secret")
+	assert.NoError(t, err)
+	assert.NotContains(t, sealed, "secret")
+
+	// одинаковые значения дают разный шифротекст
+	again, err := masterKey.Seal("password", "secret")
+	assert.NoError(t, err)
+	assert.NotEqual(t, sealed, again)
+
+	opened, err := masterKey.Open("password", sealed)
+	assert.NoError(t, err)
+	assert.Equal(t, "secret", opened)
+
+	// шифротекст нельзя перенести в другое поле
+	_, err = masterKey.Open("login", sealed)
+	assert.ErrorIs(t, err, model.ErrWrongMasterKey)
+
+	// подмененный шифротекст не расшифровывается
+	raw, _ := base64.StdEncoding.DecodeString(sealed)
+	raw[len(raw)-1] ^= 0xff
+	_, err = masterKey.Open("password", base64.StdEncoding.EncodeToString(raw))
+	assert.ErrorIs(t, err, model.ErrWrongMasterKey)
+
+	wrong := &MasterKey{Key: "wrongKey"}
+	wrong.Derive("user")
+	_, err = wrong.Open("password", sealed)
+	assert.ErrorIs(t, err, model.ErrWrongMasterKey)
+
+	empty, err := masterKey.Seal("card", "")
+	assert.NoError(t, err)
+	assert.Empty(t, empty)
+}
+
+func TestRecordSealer(t *testing.T) {
+	masterKey := &MasterKey{Key: "testKey"}
+	masterKey.Derive("user")
+	seal := func(title, field, value string) string {
+		rs, err := masterKey.NewRecordSealer("DATA_TYPE_TYPE_LOGIN_PASSWORD", title)
+		assert.NoError(t, err)
+		sealed, err := rs.Seal(field, value)
+		assert.NoError(t, err)
+		return sealed
+	}
+
+	rs, err := masterKey.NewRecordSealer("DATA_TYPE_TYPE_LOGIN_PASSWORD", "mail")
+	assert.NoError(t, err)
+	login, err := rs.Seal("login", "me")
+	assert.NoError(t, err)
+	password, err := rs.Seal("password", "secret")
+	assert.NoError(t, err)
+
+	ro := masterKey.NewRecordOpener("DATA_TYPE_TYPE_LOGIN_PASSWORD", "mail")
+	opened, err := ro.Open("login", login)
+	assert.NoError(t, err)
+	assert.Equal(t, "me", opened)
+	opened, err = ro.Open("password", password)
+	assert.NoError(t, err)
+	assert.Equal(t, "secret", opened)
+
+	// шифротекст привязан к типу и названию записи
+	_, err = masterKey.NewRecordOpener("DATA_TYPE_TYPE_CREDIT_CARD", "mail").Open("password", password)
+	assert.ErrorIs(t, err, model.ErrWrongMasterKey)
+	_, err = masterKey.NewRecordOpener("DATA_TYPE_TYPE_LOGIN_PASSWORD", "bank").Open("password", password)
+	assert.ErrorIs(t, err, model.ErrWrongMasterKey)
+
+	// поле из другой записи с тем же типом и названием не подходит к ключу записи
+	ro = masterKey.NewRecordOpener("DATA_TYPE_TYPE_LOGIN_PASSWORD", "mail")
+	_, err = ro.Open("login", login)
+	assert.NoError(t, err)
+	_, err = ro.Open("password", seal("mail", "password", "other"))
+	assert.ErrorIs(t, err, model.ErrWrongMasterKey)
+
+	// старые записи (sealVersion) открываются, но смешивать версии в одной записи нельзя
+	legacy, err := masterKey.Seal("password", "old")
+	assert.NoError(t, err)
+	opened, err = masterKey.NewRecordOpener("DATA_TYPE_TYPE_LOGIN_PASSWORD", "mail").Open("password", legacy)
+	assert.NoError(t, err)
+	assert.Equal(t, "old", opened)
+	ro = masterKey.NewRecordOpener("DATA_TYPE_TYPE_LOGIN_PASSWORD", "mail")
+	_, err = ro.Open("login", login)
+	assert.NoError(t, err)
+	_, err = ro.Open("password", legacy)
+	assert.ErrorIs(t, err, model.ErrWrongMasterKey)
+
+	_, err = (&MasterKey{}).NewRecordSealer("DATA_TYPE_TYPE_LOGIN_PASSWORD", "mail")
+	assert.ErrorIs(t, err, model.ErrMasterKeyNotSet)
+}
 
-	masterKey := &MasterKey{Key: key}
-	masterKey.SetHash()
+func TestMasterKey_NotSet(t *testing.T) {
+	masterKey := &MasterKey{}
 
-	// Verify that KeyHash is correctly set
-	assert.Equal(t, expectedHash[:], masterKey.KeyHash, "Expected KeyHash to be the SHA256 hash of Key")
+	_, err := masterKey.Seal("password", "secret")
+	assert.ErrorIs(t, err, model.ErrMasterKeyNotSet)
+	_, err = masterKey.Open("password", "secret")
+	assert.ErrorIs(t, err, model.ErrMasterKeyNotSet)
 }
 
 func TestMasterKey_Str(t *testing.T) {
@@ -12,6 +12,8 @@ type MemStorage struct {
 	Login        string
 	LastUpdate   time.Time
 	Token        string
+	RefreshToken string
+	SessionID    string
 	MasterKey    MasterKey
 	MasterKeyDir string
 	PfilesDir    string
@@ -22,11 +24,18 @@ func (m *MemStorage) SetToken(token string) {
 	m.Token = token
 }
 
-// SetMasterKey sets/updates MasterKey
+// SetSession sets/updates tokens of the current session
+func (m *MemStorage) SetSession(token, refreshToken, sessionID string) {
+	m.Token = token
+	m.RefreshToken = refreshToken
+	m.SessionID = sessionID
+}
+
+// SetMasterKey sets/updates MasterKey, key is derived with Login as salt
 func (m *MemStorage) SetMasterKey(key string, keyPath string) {
 	m.MasterKey.Key = key
 	m.MasterKey.KeyPath = keyPath
-	m.MasterKey.SetHash()
+	m.MasterKey.Derive(m.Login)
 }
 
 // NewMemStorage returns new MemStorage instance
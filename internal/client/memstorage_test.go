@@ -1,7 +1,6 @@
 package client
 
 import (
-	"crypto/sha256"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -18,10 +17,12 @@ func TestMemStorage_SetToken(t *testing.T) {
 }
 
 func TestMemStorage_SetMasterKey(t *testing.T) {
-	storage := &MemStorage{}
+	storage := &MemStorage{Login: "user"}
 	key := "testKey"
 	keyPath := "/path/to/key"
-	expectedHash := sha256.Sum256([]byte(key))
+	expected := &MasterKey{Key: key}
+	expected.Derive("user")
+	expectedHash := expected.KeyHash
 
 	storage.SetMasterKey(key, keyPath)
 
@@ -0,0 +1,96 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	pbsrv "github.com/Arcadian-Sky/datakkeeper/gen/proto/api/service/v1"
+	"github.com/Arcadian-Sky/datakkeeper/internal/model"
+)
+
+// Sync returns all changes after the since revision, pages are requested one by one.
+// Удаленные записи и файлы приходят с Deleted, Revision ответа сохраняется для следующего вызова.
+func (gc *GRPCClient) Sync(since int64) (*model.SyncChanges, error) {
+	if gc.Data == nil {
+		return nil, fmt.Errorf("GRPC client is not initialized")
+	}
+
+	res := &model.SyncChanges{Revision: since}
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), period)
+		page, err := gc.Data.Sync(ctx, &pbsrv.SyncRequest{SinceRevision: res.Revision, PageSize: model.MaxPageSize})
+		cancel()
+		if err != nil {
+			gc.log.Debug("Error during sync : ", err)
+			return nil, err
+		}
+		gc.log.Trace(page)
+
+		for _, item := range page.Data {
+			d, err := gc.openData(item)
+			if err != nil {
+				gc.log.Debug("Error during decrypt data : ", err)
+				return nil, err
+			}
+			res.Data = append(res.Data, d)
+		}
+		for _, id := range page.DeletedData {
+			res.Data = append(res.Data, model.Data{ID: id, Deleted: true})
+		}
+		for _, item := range page.Files {
+			res.Files = append(res.Files, fileFromProto(item))
+		}
+		for _, name := range page.DeletedFiles {
+			res.Files = append(res.Files, model.FileItem{Name: name, Deleted: true})
+		}
+
+		res.Revision = page.Revision
+		if !page.HasMore {
+			return res, nil
+		}
+	}
+}
+
+// WatchChanges calls onEvent for every change of the user until ctx is done or the stream breaks.
+// После обрыва часть событий может быть потеряна, поэтому после переподключения данные нужно перечитать.
+func (gc *GRPCClient) WatchChanges(ctx context.Context, onEvent func(model.ChangeEvent)) error {
+	if gc.Data == nil {
+		return fmt.Errorf("GRPC client is not initialized")
+	}
+
+	stream, err := gc.Data.WatchChanges(ctx, &pbsrv.WatchChangesRequest{})
+	if err != nil {
+		gc.log.Debug("Error during watch changes : ", err)
+		return err
+	}
+	for {
+		ev, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			gc.log.Debug("Error during watch changes : ", err)
+			return err
+		}
+		gc.log.Trace(ev)
+		onEvent(changeFromProto(ev))
+	}
+}
+
+func changeFromProto(ev *pbsrv.ChangeEvent) model.ChangeEvent {
+	res := model.ChangeEvent{DataID: ev.DataId, FileName: ev.FileName}
+	switch ev.Op {
+	case pbsrv.ChangeOp_CHANGE_OP_CREATED:
+		res.Op = model.ChangeCreated
+	case pbsrv.ChangeOp_CHANGE_OP_UPDATED:
+		res.Op = model.ChangeUpdated
+	case pbsrv.ChangeOp_CHANGE_OP_DELETED:
+		res.Op = model.ChangeDeleted
+	}
+	return res
+}
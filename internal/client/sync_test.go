@@ -0,0 +1,109 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	pbservice "github.com/Arcadian-Sky/datakkeeper/gen/proto/api/service/v1"
+	"github.com/Arcadian-Sky/datakkeeper/internal/model"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSync_Pages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataClient := pbservice.NewMockDataKeeperServiceClient(ctrl)
+	client := &GRPCClient{
+		log:  logrus.New(),
+		Data: mockDataClient,
+	}
+
+	gomock.InOrder(
+		mockDataClient.EXPECT().
+			Sync(gomock.Any(), &pbservice.SyncRequest{SinceRevision: 3, PageSize: model.MaxPageSize}).
+			Return(&pbservice.SyncResponse{
+				Revision:    5,
+				HasMore:     true,
+				Data:        []*pbservice.Data{{Id: 1, Title: "t1", Revision: 4}},
+				DeletedData: []int64{2},
+			}, nil),
+		mockDataClient.EXPECT().
+			Sync(gomock.Any(), &pbservice.SyncRequest{SinceRevision: 5, PageSize: model.MaxPageSize}).
+			Return(&pbservice.SyncResponse{
+				Revision:     8,
+				Files:        []*pbservice.FileItem{{Name: "a.txt", Revision: 6}},
+				DeletedFiles: []string{"b.txt"},
+			}, nil),
+	)
+
+	changes, err := client.Sync(3)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(8), changes.Revision)
+	assert.False(t, changes.HasMore)
+	assert.Equal(t, []model.Data{
+		{ID: 1, Title: "t1", Type: pbservice.DataType_DATA_TYPE_UNSPECIFIED.String(), Revision: 4},
+		{ID: 2, Deleted: true},
+	}, changes.Data)
+	assert.Equal(t, []model.FileItem{
+		{Name: "a.txt", Revision: 6},
+		{Name: "b.txt", Deleted: true},
+	}, changes.Files)
+}
+
+func TestSync_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataClient := pbservice.NewMockDataKeeperServiceClient(ctrl)
+	client := &GRPCClient{
+		log:  logrus.New(),
+		Data: mockDataClient,
+	}
+
+	mockDataClient.EXPECT().Sync(gomock.Any(), gomock.Any()).Return(nil, errors.New("sync error"))
+
+	_, err := client.Sync(0)
+	assert.Error(t, err)
+
+	_, err = (&GRPCClient{}).Sync(0)
+	assert.Error(t, err)
+}
+
+func TestWatchChanges(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataClient := pbservice.NewMockDataKeeperServiceClient(ctrl)
+	mockStream := pbservice.NewMockDataKeeperService_WatchChangesClient(ctrl)
+	client := &GRPCClient{
+		log:  logrus.New(),
+		Data: mockDataClient,
+	}
+
+	mockDataClient.EXPECT().WatchChanges(gomock.Any(), &pbservice.WatchChangesRequest{}).Return(mockStream, nil)
+	gomock.InOrder(
+		mockStream.EXPECT().Recv().Return(&pbservice.ChangeEvent{Op: pbservice.ChangeOp_CHANGE_OP_CREATED, DataId: 1}, nil),
+		mockStream.EXPECT().Recv().Return(&pbservice.ChangeEvent{Op: pbservice.ChangeOp_CHANGE_OP_DELETED, FileName: "a.txt"}, nil),
+		mockStream.EXPECT().Recv().Return(nil, io.EOF),
+	)
+
+	var got []model.ChangeEvent
+	err := client.WatchChanges(context.Background(), func(ev model.ChangeEvent) {
+		got = append(got, ev)
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []model.ChangeEvent{
+		{Op: model.ChangeCreated, DataID: 1},
+		{Op: model.ChangeDeleted, FileName: "a.txt"},
+	}, got)
+
+	mockDataClient.EXPECT().WatchChanges(gomock.Any(), gomock.Any()).Return(mockStream, nil)
+	mockStream.EXPECT().Recv().Return(nil, errors.New("unavailable"))
+	err = client.WatchChanges(context.Background(), func(model.ChangeEvent) {})
+	assert.Error(t, err)
+}
@@ -0,0 +1,82 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pbsrv "github.com/Arcadian-Sky/datakkeeper/gen/proto/api/service/v1"
+	"github.com/Arcadian-Sky/datakkeeper/internal/model"
+)
+
+// ListTrash returns deleted records and files that can still be restored, recently deleted first
+func (gc *GRPCClient) ListTrash() ([]model.TrashItem, error) {
+	if gc.Data == nil {
+		return nil, fmt.Errorf("GRPC client is not initialized")
+	}
+
+	// Создаем контекст с таймаутом для запроса
+	ctx, cancel := context.WithTimeout(context.Background(), period)
+	defer cancel()
+	res, err := gc.Data.ListTrash(ctx, &pbsrv.ListTrashRequest{})
+	if err != nil {
+		gc.log.Debug("Error during get trash : ", err)
+		return nil, err
+	}
+	gc.log.Trace(res)
+
+	items := make([]model.TrashItem, 0, len(res.Items))
+	for _, it := range res.Items {
+		item := model.TrashItem{
+			DeletedAt: time.Unix(it.DeletedAt, 0),
+			PurgeAt:   time.Unix(it.PurgeAt, 0),
+		}
+		switch {
+		case it.Data != nil:
+			d, err := gc.openData(it.Data)
+			if err != nil {
+				gc.log.Debug("Error during decrypt data : ", err)
+				return nil, err
+			}
+			d.Deleted = true
+			item.Data = &d
+		case it.File != nil:
+			file := fileFromProto(it.File)
+			file.Deleted = true
+			item.File = &file
+		default:
+			return nil, model.ErrEmptyResponse
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// RestoreFromTrash brings the record or the file of the item back
+func (gc *GRPCClient) RestoreFromTrash(item model.TrashItem) error {
+	if gc.Data == nil {
+		return fmt.Errorf("GRPC client is not initialized")
+	}
+
+	req := &pbsrv.RestoreFromTrashRequest{}
+	switch {
+	case item.Data != nil:
+		req.Dataid = item.Data.ID
+	case item.File != nil:
+		req.Filename = item.File.Name
+	default:
+		return model.ErrTrashItemNotFound
+	}
+
+	// Создаем контекст с таймаутом для запроса
+	ctx, cancel := context.WithTimeout(context.Background(), period)
+	defer cancel()
+	res, err := gc.Data.RestoreFromTrash(ctx, req)
+	if err != nil {
+		gc.log.Debug("Error during restore from trash : ", err)
+		return err
+	}
+	gc.log.Trace(res)
+
+	return nil
+}
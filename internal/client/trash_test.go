@@ -0,0 +1,72 @@
+package client
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	pbservice "github.com/Arcadian-Sky/datakkeeper/gen/proto/api/service/v1"
+	"github.com/Arcadian-Sky/datakkeeper/internal/model"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListTrash(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataClient := pbservice.NewMockDataKeeperServiceClient(ctrl)
+	client := &GRPCClient{
+		log:     logrus.New(),
+		Data:    mockDataClient,
+		Storage: keyStorage(),
+	}
+
+	sealed, err := client.sealData(model.Data{ID: 10, Type: "DATA_TYPE_TYPE_NOTE", Title: "note", Note: "secret"})
+	assert.NoError(t, err)
+	mockDataClient.EXPECT().
+		ListTrash(gomock.Any(), &pbservice.ListTrashRequest{}).
+		Return(&pbservice.ListTrashResponse{Items: []*pbservice.TrashItem{
+			{File: &pbservice.FileItem{Name: "a.txt", Revision: 5}, DeletedAt: 1714561200, PurgeAt: 1717153200},
+			{Data: sealed, DeletedAt: 1714557600, PurgeAt: 1717149600},
+		}}, nil)
+
+	items, err := client.ListTrash()
+	assert.NoError(t, err)
+	if assert.Len(t, items, 2) {
+		assert.Equal(t, "a.txt", items[0].File.Name)
+		assert.True(t, items[0].File.Deleted)
+		assert.Equal(t, time.Unix(1717153200, 0), items[0].PurgeAt)
+		assert.Equal(t, "secret", items[1].Data.Note)
+		assert.True(t, items[1].Data.Deleted)
+		assert.Equal(t, time.Unix(1714557600, 0), items[1].DeletedAt)
+	}
+
+	mockDataClient.EXPECT().ListTrash(gomock.Any(), gomock.Any()).Return(nil, errors.New("trash error"))
+	_, err = client.ListTrash()
+	assert.EqualError(t, err, "trash error")
+}
+
+func TestRestoreFromTrash(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataClient := pbservice.NewMockDataKeeperServiceClient(ctrl)
+	client := &GRPCClient{
+		log:  logrus.New(),
+		Data: mockDataClient,
+	}
+
+	mockDataClient.EXPECT().
+		RestoreFromTrash(gomock.Any(), &pbservice.RestoreFromTrashRequest{Dataid: 10}).
+		Return(&pbservice.UploadStatus{Success: true}, nil)
+	assert.NoError(t, client.RestoreFromTrash(model.TrashItem{Data: &model.Data{ID: 10}}))
+
+	mockDataClient.EXPECT().
+		RestoreFromTrash(gomock.Any(), &pbservice.RestoreFromTrashRequest{Filename: "a.txt"}).
+		Return(nil, errors.New("restore error"))
+	assert.EqualError(t, client.RestoreFromTrash(model.TrashItem{File: &model.FileItem{Name: "a.txt"}}), "restore error")
+
+	assert.ErrorIs(t, client.RestoreFromTrash(model.TrashItem{}), model.ErrTrashItemNotFound)
+}
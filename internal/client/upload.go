@@ -0,0 +1,163 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	pbsrv "github.com/Arcadian-Sky/datakkeeper/gen/proto/api/service/v1"
+)
+
+// uploadChunkSize - размер одной части загрузки
+const uploadChunkSize = 1024 * 1024
+
+// stagedUpload - зашифрованная копия файла, которая отправляется на сервер.
+// Для продолжения загрузки отправляется та же копия, пока загрузка не завершится,
+// чтобы не шифровать файл заново.
+type stagedUpload struct {
+	path   string
+	size   int64
+	sha256 string
+}
+
+// uploadsDir - каталог зашифрованных копий недогруженных файлов
+func (gc *GRPCClient) uploadsDir() string {
+	dir := os.TempDir()
+	if gc.Storage != nil && gc.Storage.PfilesDir != "" {
+		dir = gc.Storage.PfilesDir
+	}
+	return filepath.Join(dir, ".uploads")
+}
+
+// stageUpload encrypts the file into uploadsDir, the copy of the same unchanged file is reused
+func (gc *GRPCClient) stageUpload(file *os.File) (*stagedUpload, error) {
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	src, err := filepath.Abs(file.Name())
+	if err != nil {
+		return nil, err
+	}
+	key := sha256.Sum256([]byte(fmt.Sprintf("%s\x00%d\x00%d", src, info.Size(), info.ModTime().UnixNano())))
+	dir := gc.uploadsDir()
+	staged := &stagedUpload{path: filepath.Join(dir, hex.EncodeToString(key[:16])+".enc")}
+
+	if _, err := os.Stat(staged.path); err != nil {
+		if !os.IsNotExist(err) {
+			return nil, err
+		}
+		if err := gc.encryptTo(file, dir, staged.path); err != nil {
+			return nil, err
+		}
+	}
+
+	enc, err := os.Open(staged.path)
+	if err != nil {
+		return nil, err
+	}
+	defer enc.Close()
+	hash := sha256.New()
+	staged.size, err = io.Copy(hash, enc)
+	if err != nil {
+		return nil, err
+	}
+	staged.sha256 = hex.EncodeToString(hash.Sum(nil))
+	return staged, nil
+}
+
+// encryptTo writes encrypted file to path, the copy appears only when it is complete
+func (gc *GRPCClient) encryptTo(file *os.File, dir, path string) error {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, "staging-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	// содержимое читается дважды: соль ключа файла зависит от него
+	salt, err := gc.Storage.MasterKey.contentSalt(file)
+	if err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		tmp.Close()
+		return err
+	}
+	enc, err := newEncryptWriter(tmp, &gc.Storage.MasterKey, salt)
+	if err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := io.Copy(enc, file); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := enc.Close(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// sendUpload sends the staged copy starting from the offset already received by the server
+func (gc *GRPCClient) sendUpload(ctx context.Context, staged *stagedUpload, uploadID string, offset int64) error {
+	if offset >= staged.size {
+		return nil
+	}
+	gc.log.Info("Upload continues from byte ", offset, " of ", staged.size)
+
+	file, err := os.Open(staged.path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return err
+	}
+
+	stream, err := gc.Data.UploadChunk(ctx)
+	if err != nil {
+		gc.log.Trace("error creating stream: ", err)
+		return fmt.Errorf("error creating stream: %v", err)
+	}
+	buffer := make([]byte, uploadChunkSize)
+	for {
+		n, err := io.ReadFull(file, buffer)
+		if n > 0 {
+			chunk := &pbsrv.UploadChunkRequest{UploadId: uploadID, Offset: offset, Data: buffer[:n]}
+			if err := stream.Send(chunk); err != nil {
+				gc.log.Trace("Failed to send chunk: ", err)
+				break
+			}
+			offset += int64(n)
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	// ошибка отправки части приходит вместе с ответом
+	res, err := stream.CloseAndRecv()
+	if err != nil {
+		gc.log.Trace("Failed to receive response: ", err)
+		return err
+	}
+	if res.Offset != staged.size {
+		return fmt.Errorf("server received %d of %d bytes", res.Offset, staged.size)
+	}
+	return nil
+}
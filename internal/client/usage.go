@@ -0,0 +1,30 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	pbsrv "github.com/Arcadian-Sky/datakkeeper/gen/proto/api/service/v1"
+	"github.com/Arcadian-Sky/datakkeeper/internal/model"
+)
+
+// GetUsage returns space used by the user and the quota, 0 in the quota - без ограничения
+func (gc *GRPCClient) GetUsage() (model.Usage, model.Quota, error) {
+	if gc.Data == nil {
+		return model.Usage{}, model.Quota{}, fmt.Errorf("GRPC client is not initialized")
+	}
+
+	// Создаем контекст с таймаутом для запроса
+	ctx, cancel := context.WithTimeout(context.Background(), period)
+	defer cancel()
+	res, err := gc.Data.GetUsage(ctx, &pbsrv.GetUsageRequest{})
+	if err != nil {
+		gc.log.Debug("Error during get usage : ", err)
+		return model.Usage{}, model.Quota{}, err
+	}
+	gc.log.Trace(res)
+
+	used := model.Usage{Bytes: res.Used.GetBytes(), Files: res.Used.GetFiles(), Records: res.Used.GetRecords()}
+	quota := model.Quota{Bytes: res.Quota.GetBytes(), Files: res.Quota.GetFiles(), Records: res.Quota.GetRecords()}
+	return used, quota, nil
+}
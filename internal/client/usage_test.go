@@ -0,0 +1,38 @@
+package client
+
+import (
+	"errors"
+	"testing"
+
+	pbservice "github.com/Arcadian-Sky/datakkeeper/gen/proto/api/service/v1"
+	"github.com/Arcadian-Sky/datakkeeper/internal/model"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetUsage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataClient := pbservice.NewMockDataKeeperServiceClient(ctrl)
+	client := &GRPCClient{
+		log:  logrus.New(),
+		Data: mockDataClient,
+	}
+
+	mockDataClient.EXPECT().
+		GetUsage(gomock.Any(), &pbservice.GetUsageRequest{}).
+		Return(&pbservice.GetUsageResponse{
+			Used:  &pbservice.Usage{Bytes: 40, Files: 2, Records: 5},
+			Quota: &pbservice.Usage{Bytes: 100, Records: 10},
+		}, nil)
+	used, quota, err := client.GetUsage()
+	assert.NoError(t, err)
+	assert.Equal(t, model.Usage{Bytes: 40, Files: 2, Records: 5}, used)
+	assert.Equal(t, model.Quota{Bytes: 100, Records: 10}, quota)
+
+	mockDataClient.EXPECT().GetUsage(gomock.Any(), gomock.Any()).Return(nil, errors.New("usage error"))
+	_, _, err = client.GetUsage()
+	assert.EqualError(t, err, "usage error")
+}
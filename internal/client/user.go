@@ -4,9 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"runtime"
 	"time"
 
 	pb "github.com/Arcadian-Sky/datakkeeper/gen/proto/api/user/v1"
+	"github.com/Arcadian-Sky/datakkeeper/internal/model"
 )
 
 // Регистрация нового пользователя.
@@ -20,8 +23,9 @@ func (gc *GRPCClient) Register(login, password string) error {
 	defer cancel()
 	// Формируем запрос на регистрацию
 	req := &pb.RegisterRequest{
-		Login:    login,
-		Password: password,
+		Login:      login,
+		Password:   password,
+		DeviceInfo: deviceInfo(),
 	}
 
 	// Отправляем запрос на сервер
@@ -30,7 +34,7 @@ func (gc *GRPCClient) Register(login, password string) error {
 		gc.log.Debug("Error during registration:", err)
 		return err
 	}
-	gc.Storage.SetToken(res.AuthToken)
+	gc.Storage.SetSession(res.AuthToken, res.RefreshToken, res.SessionId)
 
 	// Обрабатываем ответ сервера
 	if res.Success {
@@ -53,8 +57,9 @@ func (gc *GRPCClient) Authenticate(login, password string) error {
 	defer cancel()
 	// Формируем запрос на регистрацию
 	req := &pb.AuthenticateRequest{
-		Login:    login,
-		Password: password,
+		Login:      login,
+		Password:   password,
+		DeviceInfo: deviceInfo(),
 	}
 
 	// Отправляем запрос на сервер
@@ -63,7 +68,7 @@ func (gc *GRPCClient) Authenticate(login, password string) error {
 		gc.log.Debug("Error during authrntificate: ", err)
 		return err
 	}
-	gc.Storage.SetToken(res.AuthToken)
+	gc.Storage.SetSession(res.AuthToken, res.RefreshToken, res.SessionId)
 
 	// Обрабатываем ответ сервера
 	if res.Success {
@@ -76,3 +81,83 @@ func (gc *GRPCClient) Authenticate(login, password string) error {
 
 	return nil
 }
+
+// Продление сеанса по refresh-токену.
+func (gc *GRPCClient) RefreshSession() error {
+	if gc.User == nil {
+		return fmt.Errorf("GRPC client is not initialized")
+	}
+
+	// Создаем контекст с таймаутом для запроса
+	ctx, cancel := context.WithTimeout(context.Background(), period)
+	defer cancel()
+	res, err := gc.User.RefreshSession(ctx, &pb.RefreshSessionRequest{
+		RefreshToken: gc.Storage.RefreshToken,
+	})
+	if err != nil {
+		gc.log.Debug("Error during refresh session: ", err)
+		return err
+	}
+	gc.Storage.SetSession(res.AuthToken, res.RefreshToken, gc.Storage.SessionID)
+
+	return nil
+}
+
+// Список активных сеансов пользователя.
+func (gc *GRPCClient) ListSessions() ([]model.Session, error) {
+	var sessions []model.Session
+	if gc.User == nil {
+		return sessions, fmt.Errorf("GRPC client is not initialized")
+	}
+
+	// Создаем контекст с таймаутом для запроса
+	ctx, cancel := context.WithTimeout(context.Background(), period)
+	defer cancel()
+	res, err := gc.User.ListSessions(ctx, &pb.ListSessionsRequest{})
+	if err != nil {
+		gc.log.Debug("Error during list sessions: ", err)
+		return sessions, err
+	}
+
+	for _, item := range res.Sessions {
+		sessions = append(sessions, model.Session{
+			ID:         item.SessionId,
+			DeviceInfo: item.DeviceInfo,
+			CreatedAt:  time.Unix(item.CreatedAt, 0),
+			ExpiresAt:  time.Unix(item.ExpiresAt, 0),
+		})
+	}
+
+	return sessions, nil
+}
+
+// Завершение сеанса, пустой sessionID - выход из текущего сеанса.
+func (gc *GRPCClient) EndSession(sessionID string) error {
+	if gc.User == nil {
+		return fmt.Errorf("GRPC client is not initialized")
+	}
+
+	// Создаем контекст с таймаутом для запроса
+	ctx, cancel := context.WithTimeout(context.Background(), period)
+	defer cancel()
+	res, err := gc.User.EndSession(ctx, &pb.EndSessionRequest{SessionId: sessionID})
+	if err != nil {
+		gc.log.Debug("Error during end session: ", err)
+		return err
+	}
+	gc.log.Trace(res)
+
+	if sessionID == "" || sessionID == gc.Storage.SessionID {
+		gc.Storage.SetSession("", "", "")
+	}
+
+	return nil
+}
+
+func deviceInfo() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	return fmt.Sprintf("%s (%s/%s)", host, runtime.GOOS, runtime.GOARCH)
+}
@@ -33,8 +33,9 @@ func TestGRPCClient_Register(t *testing.T) {
 	// Mock the Register method
 	mockUserClient.EXPECT().
 		Register(gomock.Any(), &pbuser.RegisterRequest{
-			Login:    login,
-			Password: password,
+			Login:      login,
+			Password:   password,
+			DeviceInfo: deviceInfo(),
 		}).
 		Return(&pbuser.RegisterResponse{
 			Success:   true,
@@ -71,8 +72,9 @@ func TestGRPCClient_Register_Failure(t *testing.T) {
 	// Mock the Register method to return an error
 	mockUserClient.EXPECT().
 		Register(gomock.Any(), &pbuser.RegisterRequest{
-			Login:    login,
-			Password: password,
+			Login:      login,
+			Password:   password,
+			DeviceInfo: deviceInfo(),
 		}).
 		Return(nil, status.Error(codes.Unknown, errorMessage)).
 		Times(1)
@@ -107,8 +109,9 @@ func TestGRPCClient_Authenticate(t *testing.T) {
 	// Mock the Authenticate method
 	mockUserClient.EXPECT().
 		Authenticate(gomock.Any(), &pbuser.AuthenticateRequest{
-			Login:    login,
-			Password: password,
+			Login:      login,
+			Password:   password,
+			DeviceInfo: deviceInfo(),
 		}).
 		Return(&pbuser.AuthenticateResponse{
 			Success:   true,
@@ -145,8 +148,9 @@ func TestGRPCClient_Authenticate_Failure(t *testing.T) {
 	// Mock the Authenticate method to return an error
 	mockUserClient.EXPECT().
 		Authenticate(gomock.Any(), &pbuser.AuthenticateRequest{
-			Login:    login,
-			Password: password,
+			Login:      login,
+			Password:   password,
+			DeviceInfo: deviceInfo(),
 		}).
 		Return(nil, status.Error(codes.Unauthenticated, errorMessage)).
 		Times(1)
@@ -158,3 +162,104 @@ func TestGRPCClient_Authenticate_Failure(t *testing.T) {
 	assert.Error(t, err, "Expected error from Authenticate method")
 	assert.Empty(t, storage.Token, "Expected storage token to be empty")
 }
+
+func TestGRPCClient_RefreshSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserClient := pbuser.NewMockUserServiceClient(ctrl)
+	storage := NewMemStorage()
+	storage.SetSession("old-token", "old-refresh", "sid1")
+
+	client := &GRPCClient{
+		User:    mockUserClient,
+		log:     logrus.New(),
+		Storage: storage,
+	}
+
+	mockUserClient.EXPECT().
+		RefreshSession(gomock.Any(), &pbuser.RefreshSessionRequest{RefreshToken: "old-refresh"}).
+		Return(&pbuser.RefreshSessionResponse{Success: true, AuthToken: "new-token", RefreshToken: "new-refresh"}, nil).
+		Times(1)
+
+	assert.NoError(t, client.RefreshSession())
+	assert.Equal(t, "new-token", storage.Token)
+	assert.Equal(t, "new-refresh", storage.RefreshToken)
+	assert.Equal(t, "sid1", storage.SessionID)
+
+	mockUserClient.EXPECT().
+		RefreshSession(gomock.Any(), gomock.Any()).
+		Return(nil, status.Error(codes.Unauthenticated, "session revoked")).
+		Times(1)
+
+	assert.Error(t, client.RefreshSession())
+	assert.Equal(t, "new-token", storage.Token)
+}
+
+func TestGRPCClient_ListSessions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserClient := pbuser.NewMockUserServiceClient(ctrl)
+	client := &GRPCClient{
+		User:    mockUserClient,
+		log:     logrus.New(),
+		Storage: NewMemStorage(),
+	}
+
+	mockUserClient.EXPECT().
+		ListSessions(gomock.Any(), &pbuser.ListSessionsRequest{}).
+		Return(&pbuser.ListSessionsResponse{Sessions: []*pbuser.Session{
+			{SessionId: "sid1", DeviceInfo: "laptop", CreatedAt: 1722506400, ExpiresAt: 1722510000},
+		}}, nil).
+		Times(1)
+
+	sessions, err := client.ListSessions()
+	assert.NoError(t, err)
+	assert.Len(t, sessions, 1)
+	assert.Equal(t, "sid1", sessions[0].ID)
+	assert.Equal(t, "laptop", sessions[0].DeviceInfo)
+	assert.Equal(t, int64(1722506400), sessions[0].CreatedAt.Unix())
+
+	mockUserClient.EXPECT().
+		ListSessions(gomock.Any(), gomock.Any()).
+		Return(nil, status.Error(codes.Internal, "db error")).
+		Times(1)
+
+	_, err = client.ListSessions()
+	assert.Error(t, err)
+}
+
+func TestGRPCClient_EndSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserClient := pbuser.NewMockUserServiceClient(ctrl)
+	storage := NewMemStorage()
+	storage.SetSession("token", "refresh", "sid1")
+
+	client := &GRPCClient{
+		User:    mockUserClient,
+		log:     logrus.New(),
+		Storage: storage,
+	}
+
+	// завершение чужого сеанса не трогает текущий
+	mockUserClient.EXPECT().
+		EndSession(gomock.Any(), &pbuser.EndSessionRequest{SessionId: "sid2"}).
+		Return(&pbuser.EndSessionResponse{Success: true}, nil).
+		Times(1)
+
+	assert.NoError(t, client.EndSession("sid2"))
+	assert.Equal(t, "token", storage.Token)
+
+	mockUserClient.EXPECT().
+		EndSession(gomock.Any(), &pbuser.EndSessionRequest{}).
+		Return(&pbuser.EndSessionResponse{Success: true}, nil).
+		Times(1)
+
+	assert.NoError(t, client.EndSession(""))
+	assert.Empty(t, storage.Token)
+	assert.Empty(t, storage.RefreshToken)
+	assert.Empty(t, storage.SessionID)
+}
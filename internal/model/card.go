@@ -0,0 +1,166 @@
+package model
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Бренды платежных систем, определяются по номеру карты на клиенте
+const (
+	CardBrandVisa       = "VISA"
+	CardBrandMastercard = "MASTERCARD"
+	CardBrandMir        = "MIR"
+	CardBrandAmex       = "AMEX"
+	CardBrandMaestro    = "MAESTRO"
+	CardBrandUnionPay   = "UNIONPAY"
+	CardBrandJCB        = "JCB"
+	CardBrandDiscover   = "DISCOVER"
+)
+
+// CardBrands - допустимые значения Data.CardBrand, пустая строка - бренд не определен
+var CardBrands = []string{
+	"",
+	CardBrandVisa,
+	CardBrandMastercard,
+	CardBrandMir,
+	CardBrandAmex,
+	CardBrandMaestro,
+	CardBrandUnionPay,
+	CardBrandJCB,
+	CardBrandDiscover,
+}
+
+// Допустимый диапазон года окончания действия карты
+const (
+	CardMinExpYear = 2000
+	CardMaxExpYear = 2100
+)
+
+type brandRange struct {
+	brand    string
+	from, to int // диапазон префиксов одной длины
+}
+
+// порядок важен: более узкие диапазоны проверяются раньше
+var brandRanges = []brandRange{
+	{CardBrandMir, 2200, 2204},
+	{CardBrandMastercard, 2221, 2720},
+	{CardBrandMastercard, 51, 55},
+	{CardBrandAmex, 34, 34},
+	{CardBrandAmex, 37, 37},
+	{CardBrandJCB, 3528, 3589},
+	{CardBrandDiscover, 6011, 6011},
+	{CardBrandDiscover, 644, 649},
+	{CardBrandDiscover, 65, 65},
+	{CardBrandUnionPay, 62, 62},
+	{CardBrandMaestro, 50, 50},
+	{CardBrandMaestro, 56, 69},
+	{CardBrandVisa, 4, 4},
+}
+
+// NormalizeCardNumber removes spaces and dashes people type between digit groups
+func NormalizeCardNumber(number string) string {
+	return strings.NewReplacer(" ", "", "-", "").Replace(number)
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// LuhnValid reports whether card number has valid length and Luhn checksum
+func LuhnValid(number string) bool {
+	number = NormalizeCardNumber(number)
+	if len(number) < 12 || len(number) > 19 || !isDigits(number) {
+		return false
+	}
+
+	sum := 0
+	double := false
+	for i := len(number) - 1; i >= 0; i-- {
+		d := int(number[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+
+	return sum%10 == 0
+}
+
+// CardBrand detects payment system by number prefix, returns empty string if it's unknown
+func CardBrand(number string) string {
+	number = NormalizeCardNumber(number)
+	if !isDigits(number) {
+		return ""
+	}
+	for _, r := range brandRanges {
+		size := len(strconv.Itoa(r.from))
+		if len(number) < size {
+			continue
+		}
+		prefix, _ := strconv.Atoi(number[:size])
+		if prefix >= r.from && prefix <= r.to {
+			return r.brand
+		}
+	}
+	return ""
+}
+
+// CardExpired reports whether card is expired at now, card is valid until the end of expiry month
+func CardExpired(month, year int32, now time.Time) bool {
+	end := time.Date(int(year), time.Month(month)+1, 1, 0, 0, 0, 0, time.UTC)
+	return !now.UTC().Before(end)
+}
+
+// ParseCardExpiry parses expiry in MM/YY or MM/YYYY form
+func ParseCardExpiry(s string) (int32, int32, error) {
+	parts := strings.Split(strings.TrimSpace(s), "/")
+	if len(parts) != 2 || !isDigits(parts[0]) || !isDigits(parts[1]) {
+		return 0, 0, fmt.Errorf("%w: expected MM/YY", ErrCardExpiryInvalid)
+	}
+	month, _ := strconv.Atoi(parts[0])
+	year, _ := strconv.Atoi(parts[1])
+	switch len(parts[1]) {
+	case 2:
+		year += 2000
+	case 4:
+	default:
+		return 0, 0, fmt.Errorf("%w: expected MM/YY", ErrCardExpiryInvalid)
+	}
+	if month < 1 || month > 12 || year < CardMinExpYear || year > CardMaxExpYear {
+		return 0, 0, ErrCardExpiryInvalid
+	}
+
+	return int32(month), int32(year), nil
+}
+
+// ValidateCard checks plain card fields before they are encrypted.
+// Expired card is not an error, it's up to the caller to warn about it.
+func ValidateCard(d Data) error {
+	if !LuhnValid(d.Card) {
+		return ErrCardNumberInvalid
+	}
+	if d.CardExpMonth < 1 || d.CardExpMonth > 12 ||
+		d.CardExpYear < CardMinExpYear || d.CardExpYear > CardMaxExpYear {
+		return ErrCardExpiryInvalid
+	}
+	if d.CardCVV != "" && (len(d.CardCVV) < 3 || len(d.CardCVV) > 4 || !isDigits(d.CardCVV)) {
+		return ErrCardCVVInvalid
+	}
+	if d.CardPIN != "" && (len(d.CardPIN) < 4 || len(d.CardPIN) > 12 || !isDigits(d.CardPIN)) {
+		return ErrCardPINInvalid
+	}
+
+	return nil
+}
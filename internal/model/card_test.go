@@ -0,0 +1,129 @@
+package model
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLuhnValid(t *testing.T) {
+	tests := []struct {
+		number string
+		want   bool
+	}{
+		{"4111 1111 1111 1111", true},
+		{"4111-1111-1111-1111", true},
+		{"5555555555554444", true},
+		{"2200000000000004", true},
+		{"378282246310005", true},
+		{"4111111111111112", false},
+		{"4111 1111 1111 111a", false},
+		{"42", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.number, func(t *testing.T) {
+			assert.Equal(t, tt.want, LuhnValid(tt.number))
+		})
+	}
+}
+
+func TestCardBrand(t *testing.T) {
+	tests := []struct {
+		number string
+		want   string
+	}{
+		{"4111 1111 1111 1111", CardBrandVisa},
+		{"5555555555554444", CardBrandMastercard},
+		{"2221000000000009", CardBrandMastercard},
+		{"2200000000000004", CardBrandMir},
+		{"378282246310005", CardBrandAmex},
+		{"3530111333300000", CardBrandJCB},
+		{"6011111111111117", CardBrandDiscover},
+		{"6200000000000005", CardBrandUnionPay},
+		{"6759649826438453", CardBrandMaestro},
+		{"9999999999999995", ""},
+		{"4", CardBrandVisa},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.number, func(t *testing.T) {
+			assert.Equal(t, tt.want, CardBrand(tt.number))
+		})
+	}
+}
+
+func TestCardExpired(t *testing.T) {
+	now := time.Date(2026, time.March, 15, 12, 0, 0, 0, time.UTC)
+
+	assert.False(t, CardExpired(3, 2026, now), "card is valid until the end of expiry month")
+	assert.False(t, CardExpired(12, 2030, now))
+	assert.True(t, CardExpired(2, 2026, now))
+	assert.True(t, CardExpired(12, 2025, now))
+	assert.True(t, CardExpired(3, 2026, time.Date(2026, time.April, 1, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestParseCardExpiry(t *testing.T) {
+	tests := []struct {
+		in        string
+		wantMonth int32
+		wantYear  int32
+		wantErr   bool
+	}{
+		{"03/27", 3, 2027, false},
+		{" 12/2030 ", 12, 2030, false},
+		{"13/27", 0, 0, true},
+		{"00/27", 0, 0, true},
+		{"0327", 0, 0, true},
+		{"03/270", 0, 0, true},
+		{"ab/cd", 0, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			month, year, err := ParseCardExpiry(tt.in)
+			if tt.wantErr {
+				assert.True(t, errors.Is(err, ErrCardExpiryInvalid))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantMonth, month)
+			assert.Equal(t, tt.wantYear, year)
+		})
+	}
+}
+
+func TestValidateCard(t *testing.T) {
+	valid := Data{
+		Card:         "4111 1111 1111 1111",
+		CardExpMonth: 3,
+		CardExpYear:  2027,
+		CardCVV:      "123",
+		CardPIN:      "1234",
+	}
+	assert.NoError(t, ValidateCard(valid))
+
+	expired := valid
+	expired.CardExpYear = 2001
+	assert.NoError(t, ValidateCard(expired), "expired card is only a warning")
+
+	tests := []struct {
+		name   string
+		modify func(d *Data)
+		want   error
+	}{
+		{"Luhn", func(d *Data) { d.Card = "4111111111111112" }, ErrCardNumberInvalid},
+		{"Month", func(d *Data) { d.CardExpMonth = 13 }, ErrCardExpiryInvalid},
+		{"Year", func(d *Data) { d.CardExpYear = 27 }, ErrCardExpiryInvalid},
+		{"CVV", func(d *Data) { d.CardCVV = "12" }, ErrCardCVVInvalid},
+		{"PIN", func(d *Data) { d.CardPIN = "12a4" }, ErrCardPINInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := valid
+			tt.modify(&d)
+			assert.Equal(t, tt.want, ValidateCard(d))
+		})
+	}
+}
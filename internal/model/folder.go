@@ -0,0 +1,73 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FolderSeparator разделяет папки в имени файла. Папка - префикс ключей объектов,
+// пустая папка хранится объектом-маркером с именем папки и завершающим разделителем.
+const FolderSeparator = "/"
+
+// FileMove - файл, перенесенный из From в To при переименовании или перемещении
+type FileMove struct {
+	From string
+	To   string
+}
+
+// ValidateFileName checks the path of a file or a folder: segments are separated by "/",
+// empty, "." and ".." segments are not allowed, the trash is reserved
+func ValidateFileName(name string) error {
+	if name == "" {
+		return fmt.Errorf("%w: name is not set", ErrFileNameInvalid)
+	}
+	if IsTrashName(name + FolderSeparator) {
+		return ErrFileNameReserved
+	}
+	for _, seg := range strings.Split(name, FolderSeparator) {
+		if seg == "" || seg == "." || seg == ".." {
+			return fmt.Errorf("%w: %q", ErrFileNameInvalid, name)
+		}
+	}
+	return nil
+}
+
+// FolderOf returns the folder of the file, "" for the root
+func FolderOf(name string) string {
+	i := strings.LastIndex(name, FolderSeparator)
+	if i < 0 {
+		return ""
+	}
+	return name[:i]
+}
+
+// BaseName returns the name of the file without its folder
+func BaseName(name string) string {
+	return name[strings.LastIndex(name, FolderSeparator)+1:]
+}
+
+// JoinPath returns the path of the name in the folder, empty folder is the root
+func JoinPath(folder, name string) string {
+	if folder == "" {
+		return name
+	}
+	return folder + FolderSeparator + name
+}
+
+// FolderPrefix returns the key prefix of objects in the folder, "" for the root
+func FolderPrefix(folder string) string {
+	if folder == "" {
+		return ""
+	}
+	return folder + FolderSeparator
+}
+
+// IsFolderKey reports whether the object key is a folder marker
+func IsFolderKey(key string) bool {
+	return strings.HasSuffix(key, FolderSeparator)
+}
+
+// InFolder reports whether name is the folder itself or lies inside it at any depth
+func InFolder(name, folder string) bool {
+	return name == folder || strings.HasPrefix(name, folder+FolderSeparator)
+}
@@ -0,0 +1,34 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateFileName(t *testing.T) {
+	for _, name := range []string{"report.pdf", "work/2024/report.pdf", ".trashy", "a b/c"} {
+		assert.NoError(t, ValidateFileName(name), name)
+	}
+	for _, name := range []string{"", "/report.pdf", "work/", "work//a", "./a", "work/../a", ".."} {
+		assert.ErrorIs(t, ValidateFileName(name), ErrFileNameInvalid, name)
+	}
+	assert.ErrorIs(t, ValidateFileName(".trash"), ErrFileNameReserved)
+	assert.ErrorIs(t, ValidateFileName(".trash/a.txt"), ErrFileNameReserved)
+}
+
+func TestFolderPaths(t *testing.T) {
+	assert.Equal(t, "work/2024", FolderOf("work/2024/report.pdf"))
+	assert.Equal(t, "", FolderOf("report.pdf"))
+	assert.Equal(t, "report.pdf", BaseName("work/2024/report.pdf"))
+	assert.Equal(t, "report.pdf", BaseName("report.pdf"))
+	assert.Equal(t, "work/report.pdf", JoinPath("work", "report.pdf"))
+	assert.Equal(t, "report.pdf", JoinPath("", "report.pdf"))
+	assert.Equal(t, "work/", FolderPrefix("work"))
+	assert.Equal(t, "", FolderPrefix(""))
+	assert.True(t, IsFolderKey("work/"))
+	assert.False(t, IsFolderKey("work"))
+	assert.True(t, InFolder("work/a.txt", "work"))
+	assert.True(t, InFolder("work", "work"))
+	assert.False(t, InFolder("workshop/a.txt", "work"))
+}
@@ -0,0 +1,123 @@
+package model
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"path"
+	"slices"
+	"strings"
+)
+
+// Порядок сортировки списков
+const (
+	ListSortDefault = ""      // записи по id (порядку создания), файлы по имени
+	ListSortTitle   = "title" // записи по названию, файлы по имени
+)
+
+// Размер страницы списков
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 500
+)
+
+// ListQuery - фильтры, сортировка и страница для GetDataList и GetFileList
+type ListQuery struct {
+	// Types - типы записей (dtype) или расширения файлов без точки, пустой - все
+	Types []string
+	// Search - подстрока названия или тега без учета регистра
+	Search string
+	// Matched - файлы, чьи метаданные нашел по Search полнотекстовый индекс, проходят фильтр Search
+	Matched map[string]bool
+	// Tags - теги, которые должны быть у элемента все одновременно, сравниваются точно
+	Tags []string
+	Sort string
+	Desc bool
+	// Limit - размер страницы
+	Limit int
+	// After - позиция, с которой продолжается список, nil - с начала
+	After *ListCursor
+	// Browse - только файлы и вложенные папки папки Parent ("" - корень), иначе все файлы одним списком
+	Browse bool
+	Parent string
+}
+
+// ListCursor - последний элемент предыдущей страницы
+type ListCursor struct {
+	Key string `json:"k,omitempty"`
+	ID  int64  `json:"i,omitempty"`
+}
+
+// PageLimit returns page size limited by MaxPageSize, zero gives DefaultPageSize
+func PageLimit(size int32) int {
+	switch {
+	case size <= 0:
+		return DefaultPageSize
+	case size > MaxPageSize:
+		return MaxPageSize
+	}
+	return int(size)
+}
+
+// EncodePageToken returns opaque token of the cursor, nil cursor gives empty token
+func EncodePageToken(c *ListCursor) string {
+	if c == nil {
+		return ""
+	}
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodePageToken parses token of EncodePageToken, empty token gives nil cursor
+func DecodePageToken(token string) (*ListCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrPageTokenInvalid
+	}
+	var c ListCursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, ErrPageTokenInvalid
+	}
+	return &c, nil
+}
+
+// FileExt returns lowercase extension of the file name without dot
+func FileExt(name string) string {
+	return strings.ToLower(strings.TrimPrefix(path.Ext(name), "."))
+}
+
+// MatchFile reports whether the file passes type, search and tag filters of the query
+func (q *ListQuery) MatchFile(item FileItem) bool {
+	if len(q.Types) > 0 && !containsFold(q.Types, FileExt(item.Name)) {
+		return false
+	}
+	for _, tag := range q.Tags {
+		if !slices.Contains(item.Tags, tag) {
+			return false
+		}
+	}
+	if q.Search == "" {
+		return true
+	}
+	search := strings.ToLower(q.Search)
+	if strings.Contains(strings.ToLower(item.Name), search) || q.Matched[item.Name] {
+		return true
+	}
+	for _, tag := range item.Tags {
+		if strings.Contains(strings.ToLower(tag), search) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsFold(list []string, s string) bool {
+	for _, it := range list {
+		if strings.EqualFold(it, s) {
+			return true
+		}
+	}
+	return false
+}
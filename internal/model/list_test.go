@@ -0,0 +1,58 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPageLimit(t *testing.T) {
+	assert.Equal(t, DefaultPageSize, PageLimit(0))
+	assert.Equal(t, DefaultPageSize, PageLimit(-5))
+	assert.Equal(t, 10, PageLimit(10))
+	assert.Equal(t, MaxPageSize, PageLimit(MaxPageSize+1))
+}
+
+func TestPageToken(t *testing.T) {
+	c, err := DecodePageToken("")
+	assert.NoError(t, err)
+	assert.Nil(t, c)
+	assert.Equal(t, "", EncodePageToken(nil))
+
+	token := EncodePageToken(&ListCursor{Key: "Mail", ID: 42})
+	c, err = DecodePageToken(token)
+	assert.NoError(t, err)
+	assert.Equal(t, &ListCursor{Key: "Mail", ID: 42}, c)
+
+	_, err = DecodePageToken("not base64!")
+	assert.ErrorIs(t, err, ErrPageTokenInvalid)
+	_, err = DecodePageToken("bm90IGpzb24")
+	assert.ErrorIs(t, err, ErrPageTokenInvalid)
+}
+
+func TestListQuery_MatchFile(t *testing.T) {
+	photo := FileItem{Name: "Trip/IMG_001.JPG", Tags: []string{"kazan", "summer"}}
+
+	tests := []struct {
+		name  string
+		query ListQuery
+		want  bool
+	}{
+		{"Empty", ListQuery{}, true},
+		{"Type", ListQuery{Types: []string{"jpg", "png"}}, true},
+		{"OtherType", ListQuery{Types: []string{"pdf"}}, false},
+		{"SearchName", ListQuery{Search: "img_0"}, true},
+		{"SearchTag", ListQuery{Search: "KAZ"}, true},
+		{"SearchMiss", ListQuery{Search: "moscow"}, false},
+		{"SearchMatched", ListQuery{Search: "museum", Matched: map[string]bool{"Trip/IMG_001.JPG": true}}, true},
+		{"MatchedOtherTag", ListQuery{Search: "museum", Tags: []string{"winter"}, Matched: map[string]bool{"Trip/IMG_001.JPG": true}}, false},
+		{"Tags", ListQuery{Tags: []string{"summer", "kazan"}}, true},
+		{"MissingTag", ListQuery{Tags: []string{"summer", "winter"}}, false},
+		{"TagIsExact", ListQuery{Tags: []string{"Kazan"}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.query.MatchFile(photo))
+		})
+	}
+}
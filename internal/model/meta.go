@@ -0,0 +1,92 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Виды объектов, к которым привязываются метаданные
+const (
+	MetaKindData = "data"
+	MetaKindFile = "file"
+)
+
+// MetaFieldWebsite - поле метаданных со связанным сайтом
+const MetaFieldWebsite = "website"
+
+// Ограничения метаданных, совпадают с правилами buf.validate в service.proto
+const (
+	MaxMetaFields   = 32
+	MaxMetaKeyLen   = 64
+	MaxMetaValueLen = 1024
+	MaxTags         = 32
+	MaxTagLen       = 64
+	MaxDescLen      = 1024
+)
+
+// ItemMeta - пользовательские ключ/значение, теги и описание записи или файла.
+// ItemID - id записи для MetaKindData и имя файла для MetaKindFile.
+type ItemMeta struct {
+	UserID      int64
+	Kind        string
+	ItemID      string
+	Fields      map[string]string
+	Tags        []string
+	Description string
+}
+
+// IsEmpty reports whether there is nothing to store
+func (m *ItemMeta) IsEmpty() bool {
+	return len(m.Fields) == 0 && len(m.Tags) == 0 && m.Description == ""
+}
+
+// NormalizeTags trims tags, drops empty and duplicate ones and sorts the rest
+func NormalizeTags(tags []string) []string {
+	seen := make(map[string]bool, len(tags))
+	var res []string
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		res = append(res, tag)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// ValidateMeta checks metadata limits
+func ValidateMeta(fields map[string]string, tags []string) error {
+	if len(fields) > MaxMetaFields {
+		return fmt.Errorf("%w: more than %d fields", ErrMetaInvalid, MaxMetaFields)
+	}
+	for k, v := range fields {
+		if k == "" || utf8.RuneCountInString(k) > MaxMetaKeyLen {
+			return fmt.Errorf("%w: key %q must be 1 to %d characters", ErrMetaInvalid, k, MaxMetaKeyLen)
+		}
+		if utf8.RuneCountInString(v) > MaxMetaValueLen {
+			return fmt.Errorf("%w: value of %q is longer than %d characters", ErrMetaInvalid, k, MaxMetaValueLen)
+		}
+	}
+	if len(tags) > MaxTags {
+		return fmt.Errorf("%w: more than %d tags", ErrMetaInvalid, MaxTags)
+	}
+	for _, tag := range tags {
+		if tag == "" || utf8.RuneCountInString(tag) > MaxTagLen {
+			return fmt.Errorf("%w: tag %q must be 1 to %d characters", ErrMetaInvalid, tag, MaxTagLen)
+		}
+	}
+
+	return nil
+}
+
+// ValidateDescription checks the length of the item description
+func ValidateDescription(desc string) error {
+	if utf8.RuneCountInString(desc) > MaxDescLen {
+		return fmt.Errorf("%w: description is longer than %d characters", ErrMetaInvalid, MaxDescLen)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeTags(t *testing.T) {
+	assert.Equal(t, []string{"bank", "work"}, NormalizeTags([]string{" work", "bank", "", "work ", "  "}))
+	assert.Nil(t, NormalizeTags(nil))
+}
+
+func TestValidateMeta(t *testing.T) {
+	manyFields := map[string]string{}
+	for i := 0; i <= MaxMetaFields; i++ {
+		manyFields[strings.Repeat("k", i+1)] = "v"
+	}
+	manyTags := make([]string, MaxTags+1)
+	for i := range manyTags {
+		manyTags[i] = "t"
+	}
+
+	tests := []struct {
+		name    string
+		fields  map[string]string
+		tags    []string
+		wantErr bool
+	}{
+		{name: "Empty"},
+		{name: "Valid", fields: map[string]string{"site": "example.com"}, tags: []string{"work"}},
+		{name: "EmptyKey", fields: map[string]string{"": "v"}, wantErr: true},
+		{name: "LongKey", fields: map[string]string{strings.Repeat("k", MaxMetaKeyLen+1): "v"}, wantErr: true},
+		{name: "LongValue", fields: map[string]string{"k": strings.Repeat("v", MaxMetaValueLen+1)}, wantErr: true},
+		{name: "ManyFields", fields: manyFields, wantErr: true},
+		{name: "EmptyTag", tags: []string{""}, wantErr: true},
+		{name: "LongTag", tags: []string{strings.Repeat("t", MaxTagLen+1)}, wantErr: true},
+		{name: "ManyTags", tags: manyTags, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateMeta(tt.fields, tt.tags)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrMetaInvalid)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateDescription(t *testing.T) {
+	assert.NoError(t, ValidateDescription(""))
+	assert.NoError(t, ValidateDescription(strings.Repeat("д", MaxDescLen)))
+	assert.ErrorIs(t, ValidateDescription(strings.Repeat("д", MaxDescLen+1)), ErrMetaInvalid)
+	assert.False(t, (&ItemMeta{Description: "notes"}).IsEmpty())
+}
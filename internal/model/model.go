@@ -14,6 +14,40 @@ var (
 	ErrPdataAlreatyEsists = errors.New("data already exists")
 	ErrNoToken            = errors.New("no JWT")
 	ErrPdataNotFound      = errors.New("data not found")
+	ErrUnknownKeyID       = errors.New("unknown signing key id")
+	ErrNoSigningKey       = errors.New("no active signing key")
+	ErrSessionNotFound    = errors.New("session not found")
+	ErrSessionRevoked     = errors.New("session is revoked or expired")
+	ErrKeyHashMismatch    = errors.New("data is encrypted with another master key")
+	ErrMasterKeyNotSet    = errors.New("master key is not set")
+	ErrWrongMasterKey     = errors.New("wrong master key")
+	ErrFileTruncated      = errors.New("encrypted file is truncated")
+	ErrFileCorrupted      = errors.New("encrypted file is corrupted")
+	ErrBinaryTooLarge     = errors.New("binary data is too large, save it as a file")
+	ErrCardNumberInvalid  = errors.New("card number is invalid")
+	ErrCardExpiryInvalid  = errors.New("card expiry date is invalid")
+	ErrCardCVVInvalid     = errors.New("card CVV must be 3 or 4 digits")
+	ErrCardPINInvalid     = errors.New("card PIN must be 4 to 12 digits")
+	ErrMetaInvalid        = errors.New("metadata is invalid")
+	ErrPageTokenInvalid   = errors.New("page token is invalid")
+	ErrVersionConflict    = errors.New("item was changed on another device")
+	ErrRevisionNotFound   = errors.New("revision not found in history")
+	ErrTrashItemNotFound  = errors.New("item not found in trash")
+	ErrFileNameReserved   = errors.New("file name is reserved")
+	ErrFileVersionMissing = errors.New("file version not found")
+	ErrUploadNotFound     = errors.New("upload session not found")
+	ErrUploadOffset       = errors.New("upload offset does not match received size")
+	ErrUploadTooLarge     = errors.New("upload is larger than declared size")
+	ErrUploadIncomplete   = errors.New("upload is not complete")
+	ErrUploadChecksum     = errors.New("upload checksum mismatch")
+	ErrUploadInvalid      = errors.New("upload parameters are invalid")
+	ErrUploadLimit        = errors.New("too many unfinished uploads")
+	ErrFileNameInvalid    = errors.New("file name is invalid")
+	ErrFileNotFound       = errors.New("file not found")
+	ErrFileExists         = errors.New("file or folder already exists")
+	ErrQuotaExceeded      = errors.New("storage quota exceeded")
+	ErrBlobNotFound       = errors.New("blob not found")
+	ErrBlobRemoving       = errors.New("blob is being removed")
 
 	ErrCreateBucketFailed = errors.New("failed to create bucket")
 	ErrCreateBucketExists = errors.New("bucket already exists")
@@ -36,6 +70,12 @@ var (
 	ErrIncFunds            = errors.New("insufficient funds")
 )
 
+// MaxBinarySize - ограничение размера бинарной записи, большие данные сохраняются файлами
+const MaxBinarySize = 1 << 20
+
+// DataHistoryLimit - сколько прежних версий записи хранится, более старые удаляются
+const DataHistoryLimit = 10
+
 // Jtoken - JWT token
 type Jtoken struct {
 	Token  string
@@ -44,9 +84,29 @@ type Jtoken struct {
 
 type Claims struct {
 	jwt.RegisteredClaims
-	UserID int64
-	Iat    int64
-	Exp    int64
+	UserID    int64
+	SessionID string
+	Iat       int64
+	Exp       int64
+}
+
+// SigningKey - ключ подписи JWT, kid передается в заголовке токена
+type SigningKey struct {
+	ID        string
+	Secret    []byte
+	CreatedAt time.Time
+	RetiredAt time.Time
+}
+
+// Session - сеанс пользователя на устройстве, хранится только хеш refresh-токена
+type Session struct {
+	ID          string
+	UserID      int64
+	RefreshHash string
+	DeviceInfo  string
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+	RevokedAt   time.Time
 }
 
 type User struct {
@@ -54,6 +114,7 @@ type User struct {
 	Login      string    `json:"login"`
 	Password   string    `json:"password"`
 	Bucket     string    `json:"bucket"`
+	KeyHash    string    `json:"key_hash"`
 	LastUpdate time.Time `json:"last_update"`
 }
 
@@ -65,11 +126,69 @@ type Data struct {
 	Card     string
 	Login    string
 	Password string
+	Note     string
+	Binary   []byte
 	KeyHash  string
+
+	// Реквизиты карты, Card - номер. Бренд и срок действия сервер видит открыто, остальное шифруется
+	CardHolder   string
+	CardExpMonth int32
+	CardExpYear  int32
+	CardCVV      string
+	CardBank     string
+	CardPIN      string
+	CardBrand    string
+
+	// Пользовательские метаданные, не шифруются
+	Meta map[string]string
+	Tags []string
+
+	// Revision - ревизия пользователя, на которой запись изменена последний раз, она же версия записи:
+	// изменение и удаление с ненулевой Revision проходят, только если запись с тех пор не менялась.
+	// Deleted - запись удалена и осталась только для синхронизации
+	Revision int64
+	Deleted  bool
+}
+
+// DataRevision - прежнее содержимое записи, Data.Revision - версия, которую оно имело.
+// Метаданные и теги в историю не попадают.
+type DataRevision struct {
+	Data      Data
+	ChangedAt time.Time // Когда содержимое было заменено
 }
 
+// FileItem - файл в хранилище. Size и SHA256 относятся к хранимому (зашифрованному) содержимому,
+// Desc - описание, которое пользователь задает вместе с метаданными.
 type FileItem struct {
-	Hash string
-	Name string
-	Desc string
+	Hash        string
+	Name        string
+	Desc        string
+	Meta        map[string]string
+	Tags        []string
+	Size        int64
+	ContentType string
+	UploadedAt  time.Time
+	SHA256      string
+	Device      string // Устройство, с которого загружен файл
+	IsFolder    bool   // Папка, Name - ее путь без завершающего "/"
+
+	// Заполняются только при синхронизации, см. Data
+	Revision int64
+	Deleted  bool
+}
+
+// FileVersion - версия объекта файла в хранилище, каждая загрузка создает новую.
+// Latest - текущее содержимое файла.
+type FileVersion struct {
+	VersionID  string
+	Hash       string
+	Size       int64
+	ModifiedAt time.Time
+	Latest     bool
+}
+
+// Blob - содержимое файла в хранилище с дедупликацией, одно на все файлы с тем же SHA-256
+type Blob struct {
+	SHA256 string
+	Size   int64
 }
@@ -0,0 +1,49 @@
+package model
+
+import "fmt"
+
+// Usage - занятое пользователем место. Удаленное в корзине занимает место до окончательной очистки.
+type Usage struct {
+	Bytes   int64
+	Files   int64
+	Records int64
+}
+
+// Quota - ограничения пользователя, 0 - без ограничения
+type Quota struct {
+	Bytes   int64
+	Files   int64
+	Records int64
+}
+
+// FreeBytes returns how many bytes can still be stored, -1 without limit
+func (q Quota) FreeBytes(u Usage) int64 {
+	if q.Bytes <= 0 {
+		return -1
+	}
+	return max(q.Bytes-u.Bytes, 0)
+}
+
+// CheckFile returns ErrQuotaExceeded if one more file of size doesn't fit, size -1 if it's unknown
+func (q Quota) CheckFile(u Usage, size int64) error {
+	if q.Files > 0 && u.Files >= q.Files {
+		return fmt.Errorf("%w: %d of %d files", ErrQuotaExceeded, u.Files, q.Files)
+	}
+	return q.CheckBytes(u, size)
+}
+
+// CheckBytes returns ErrQuotaExceeded if size more bytes don't fit, size -1 if it's unknown
+func (q Quota) CheckBytes(u Usage, size int64) error {
+	if free := q.FreeBytes(u); free >= 0 && (size > free || (size < 0 && free == 0)) {
+		return fmt.Errorf("%w: %d of %d bytes used", ErrQuotaExceeded, u.Bytes, q.Bytes)
+	}
+	return nil
+}
+
+// CheckRecord returns ErrQuotaExceeded if one more record doesn't fit
+func (q Quota) CheckRecord(u Usage) error {
+	if q.Records > 0 && u.Records >= q.Records {
+		return fmt.Errorf("%w: %d of %d records", ErrQuotaExceeded, u.Records, q.Records)
+	}
+	return nil
+}
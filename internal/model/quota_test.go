@@ -0,0 +1,39 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuota_CheckFile(t *testing.T) {
+	q := Quota{Bytes: 100, Files: 2}
+	assert.NoError(t, q.CheckFile(Usage{Bytes: 40, Files: 1}, 60))
+	assert.ErrorIs(t, q.CheckFile(Usage{Bytes: 40, Files: 1}, 61), ErrQuotaExceeded)
+	assert.ErrorIs(t, q.CheckFile(Usage{Files: 2}, 1), ErrQuotaExceeded)
+	// размер неизвестен, проверяется при получении
+	assert.NoError(t, q.CheckFile(Usage{Bytes: 99}, -1))
+	assert.ErrorIs(t, q.CheckFile(Usage{Bytes: 100}, -1), ErrQuotaExceeded)
+
+	assert.NoError(t, Quota{}.CheckFile(Usage{Bytes: 1 << 40, Files: 1 << 20}, 1<<30))
+}
+
+func TestQuota_CheckBytes(t *testing.T) {
+	q := Quota{Bytes: 100, Files: 2}
+	// число файлов не проверяется
+	assert.NoError(t, q.CheckBytes(Usage{Bytes: 40, Files: 2}, 60))
+	assert.ErrorIs(t, q.CheckBytes(Usage{Bytes: 40}, 61), ErrQuotaExceeded)
+	assert.NoError(t, Quota{Files: 1}.CheckBytes(Usage{Bytes: 1 << 40}, 1<<30))
+}
+
+func TestQuota_FreeBytes(t *testing.T) {
+	assert.Equal(t, int64(60), Quota{Bytes: 100}.FreeBytes(Usage{Bytes: 40}))
+	assert.Equal(t, int64(0), Quota{Bytes: 100}.FreeBytes(Usage{Bytes: 140}))
+	assert.Equal(t, int64(-1), Quota{}.FreeBytes(Usage{Bytes: 40}))
+}
+
+func TestQuota_CheckRecord(t *testing.T) {
+	assert.NoError(t, Quota{Records: 2}.CheckRecord(Usage{Records: 1}))
+	assert.ErrorIs(t, Quota{Records: 2}.CheckRecord(Usage{Records: 2}), ErrQuotaExceeded)
+	assert.NoError(t, Quota{Bytes: 1}.CheckRecord(Usage{Records: 100}))
+}
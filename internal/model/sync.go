@@ -0,0 +1,62 @@
+package model
+
+import "sort"
+
+// SyncChanges - изменения пользователя после ревизии клиента.
+// Data и Files содержат и удаленные объекты с Deleted, от удаленных записей остается только ID.
+type SyncChanges struct {
+	// Revision - ревизия, с которой клиент продолжает следующую синхронизацию
+	Revision int64
+	// HasMore - изменения не уместились в лимит, нужно повторить запрос с Revision
+	HasMore bool
+	Data    []Data
+	Files   []FileItem
+}
+
+// MergeSyncChanges keeps the oldest changes of data and files, both sorted by revision, within limit.
+// current is the user revision the changes were read at, it is returned when nothing is cut off.
+func MergeSyncChanges(current int64, data []Data, files []FileItem, limit int) *SyncChanges {
+	res := &SyncChanges{Revision: current}
+	type change struct {
+		rev  int64
+		data int
+		file int
+	}
+	changes := make([]change, 0, len(data)+len(files))
+	for i := range data {
+		changes = append(changes, change{rev: data[i].Revision, data: i, file: -1})
+	}
+	for i := range files {
+		changes = append(changes, change{rev: files[i].Revision, data: -1, file: i})
+	}
+	sort.SliceStable(changes, func(i, j int) bool { return changes[i].rev < changes[j].rev })
+
+	if len(changes) > limit {
+		changes = changes[:limit]
+		res.HasMore = true
+		res.Revision = changes[len(changes)-1].rev
+	}
+	for _, c := range changes {
+		if c.data >= 0 {
+			res.Data = append(res.Data, data[c.data])
+		} else {
+			res.Files = append(res.Files, files[c.file])
+		}
+	}
+	return res
+}
+
+// Операции в ленте изменений
+const (
+	ChangeCreated = "created"
+	ChangeUpdated = "updated"
+	ChangeDeleted = "deleted"
+)
+
+// ChangeEvent - событие ленты изменений пользователя, заполнен DataID записи или FileName файла.
+// Содержимое в событие не кладется, клиент перечитывает список или вызывает Sync.
+type ChangeEvent struct {
+	Op       string
+	DataID   int64
+	FileName string
+}
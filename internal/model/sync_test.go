@@ -0,0 +1,24 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeSyncChanges(t *testing.T) {
+	data := []Data{{ID: 1, Revision: 2}, {ID: 2, Revision: 5, Deleted: true}}
+	files := []FileItem{{Name: "a", Revision: 3}, {Name: "b", Revision: 6}}
+
+	all := MergeSyncChanges(6, data, files, 10)
+	assert.Equal(t, &SyncChanges{Revision: 6, Data: data, Files: files}, all)
+
+	part := MergeSyncChanges(6, data, files, 3)
+	assert.True(t, part.HasMore)
+	assert.Equal(t, int64(5), part.Revision)
+	assert.Equal(t, data, part.Data)
+	assert.Equal(t, files[:1], part.Files)
+
+	empty := MergeSyncChanges(6, nil, nil, 3)
+	assert.Equal(t, &SyncChanges{Revision: 6}, empty)
+}
@@ -0,0 +1,38 @@
+package model
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// TrashPrefix - префикс, под которым удаленные файлы лежат в бакете пользователя до окончательного удаления
+const TrashPrefix = ".trash/"
+
+// IsTrashName reports whether the object name belongs to the trash, such names can't be uploaded
+func IsTrashName(name string) bool {
+	return strings.HasPrefix(name, TrashPrefix)
+}
+
+// TrashItem - удаленная запись или файл, заполнено одно из Data и File.
+// Пока элемент в корзине, его можно восстановить, после PurgeAt он удаляется окончательно.
+type TrashItem struct {
+	Data      *Data
+	File      *FileItem
+	DeletedAt time.Time
+	PurgeAt   time.Time
+}
+
+// PurgedItem - запись (DataID) или файл (FileName), окончательно удаленные из корзины
+type PurgedItem struct {
+	UserID   int64
+	DataID   int64
+	FileName string
+}
+
+// SortTrash orders trash items by deletion time, recently deleted first
+func SortTrash(items []TrashItem) {
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].DeletedAt.After(items[j].DeletedAt)
+	})
+}
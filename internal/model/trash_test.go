@@ -0,0 +1,27 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsTrashName(t *testing.T) {
+	assert.True(t, IsTrashName(".trash/a.txt"))
+	assert.False(t, IsTrashName("a.txt"))
+	assert.False(t, IsTrashName("docs/.trash/a.txt"))
+}
+
+func TestSortTrash(t *testing.T) {
+	now := time.Now()
+	items := []TrashItem{
+		{Data: &Data{ID: 1}, DeletedAt: now.Add(-time.Hour)},
+		{File: &FileItem{Name: "a.txt"}, DeletedAt: now},
+		{Data: &Data{ID: 2}, DeletedAt: now.Add(-2 * time.Hour)},
+	}
+	SortTrash(items)
+	assert.Equal(t, "a.txt", items[0].File.Name)
+	assert.Equal(t, int64(1), items[1].Data.ID)
+	assert.Equal(t, int64(2), items[2].Data.ID)
+}
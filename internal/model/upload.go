@@ -0,0 +1,45 @@
+package model
+
+import (
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// UploadSession - загрузка файла по частям. Принятые части копятся на сервере,
+// поэтому после обрыва связи клиент продолжает с Offset, а не с начала.
+type UploadSession struct {
+	ID       string
+	UserID   int64
+	FileName string
+	// объявленный размер и SHA-256 (hex) всего содержимого, проверяются при завершении
+	Size   int64
+	SHA256 string
+	// сколько байт уже принято
+	Offset    int64
+	CreatedAt time.Time
+	// время последней принятой части, по нему удаляются брошенные загрузки
+	UpdatedAt time.Time
+}
+
+// Complete reports whether all declared bytes are received
+func (s *UploadSession) Complete() bool {
+	return s.Offset == s.Size
+}
+
+// ValidateUpload checks parameters of a new upload session
+func ValidateUpload(name string, size int64, sha string) error {
+	if name == "" {
+		return fmt.Errorf("%w: filename is not set", ErrUploadInvalid)
+	}
+	if err := ValidateFileName(name); err != nil {
+		return err
+	}
+	if size < 0 {
+		return fmt.Errorf("%w: size is negative", ErrUploadInvalid)
+	}
+	if b, err := hex.DecodeString(sha); err != nil || len(b) != 32 {
+		return fmt.Errorf("%w: sha256 must be 64 hex characters", ErrUploadInvalid)
+	}
+	return nil
+}
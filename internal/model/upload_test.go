@@ -0,0 +1,26 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateUpload(t *testing.T) {
+	sha := strings.Repeat("ab", 32)
+	assert.NoError(t, ValidateUpload("a.txt", 10, sha))
+	assert.NoError(t, ValidateUpload("empty.txt", 0, sha))
+	assert.ErrorIs(t, ValidateUpload("", 10, sha), ErrUploadInvalid)
+	assert.ErrorIs(t, ValidateUpload(".trash/a.txt", 10, sha), ErrFileNameReserved)
+	assert.ErrorIs(t, ValidateUpload("a.txt", -1, sha), ErrUploadInvalid)
+	assert.ErrorIs(t, ValidateUpload("a.txt", 10, "abc"), ErrUploadInvalid)
+	assert.ErrorIs(t, ValidateUpload("a.txt", 10, strings.Repeat("zz", 32)), ErrUploadInvalid)
+}
+
+func TestUploadSession_Complete(t *testing.T) {
+	s := UploadSession{Size: 10, Offset: 4}
+	assert.False(t, s.Complete())
+	s.Offset = 10
+	assert.True(t, s.Complete())
+}
@@ -0,0 +1,234 @@
+package repository
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/Arcadian-Sky/datakkeeper/internal/model"
+	"github.com/Arcadian-Sky/datakkeeper/tools/client"
+	"github.com/minio/minio-go/v7"
+	"github.com/sirupsen/logrus"
+)
+
+// blobBucket - общий бакет содержимого файлов, объекты называются SHA-256 содержимого
+const blobBucket = "blobs"
+
+// blobTempPrefix - содержимое, чья сумма еще считается
+const blobTempPrefix = "tmp/"
+
+type BlobRepository interface {
+	Put(ctx context.Context, sha256 string, size int64, r io.Reader) (model.Blob, error)
+	Ref(ctx context.Context, sha256 string) error
+	Unref(ctx context.Context, sha256 string) error
+	Get(ctx context.Context, sha256 string) (io.ReadSeekCloser, error)
+}
+
+// BlobRepo хранит содержимое файлов один раз на SHA-256 в общем бакете MinIO,
+// а число ссылок на него - в postgres. Ссылка - версия объекта файла пользователя.
+type BlobRepo struct {
+	db    *sql.DB
+	store client.MinioClient
+	log   *logrus.Logger
+}
+
+func NewBlobRepository(dbs *sql.DB, st client.MinioClient, lg *logrus.Logger) *BlobRepo {
+	return &BlobRepo{
+		db:    dbs,
+		store: st,
+		log:   lg,
+	}
+}
+
+// Init creates the blob bucket if it's missing
+func (r *BlobRepo) Init(ctx context.Context) error {
+	exists, err := r.store.BucketExists(ctx, blobBucket)
+	if err != nil {
+		return fmt.Errorf("failed to check blob bucket: %w", err)
+	}
+	if exists {
+		return nil
+	}
+	if err := r.store.MakeBucket(ctx, blobBucket, minio.MakeBucketOptions{}); err != nil {
+		return fmt.Errorf("failed to create blob bucket: %w", err)
+	}
+	return nil
+}
+
+// Put stores the content of r, size -1 if it's unknown, and adds a reference to it.
+// Если содержимое с суммой sha256 уже есть, r не читается. Без sha256 сумма считается при загрузке,
+// ErrUploadChecksum, если указанная сумма не совпала с содержимым, ErrBlobRemoving, если то же
+// содержимое сейчас удаляется.
+func (r *BlobRepo) Put(ctx context.Context, sum string, size int64, body io.Reader) (model.Blob, error) {
+	if sum != "" {
+		blob, err := r.addRef(ctx, sum)
+		if err == nil || !errors.Is(err, model.ErrBlobNotFound) {
+			return blob, err
+		}
+	}
+
+	// сумма известна только после чтения, поэтому содержимое сначала ложится под временным именем
+	tmp, err := blobTempKey()
+	if err != nil {
+		return model.Blob{}, err
+	}
+	hash := sha256.New()
+	info, err := r.store.PutObject(ctx, blobBucket, tmp, io.TeeReader(body, hash), size,
+		minio.PutObjectOptions{PartSize: uploadPartSize, ContentType: defaultContentType})
+	if err != nil {
+		return model.Blob{}, fmt.Errorf("failed to store blob: %w", err)
+	}
+	defer func() {
+		if err := r.store.RemoveObject(ctx, blobBucket, tmp, minio.RemoveObjectOptions{}); err != nil {
+			r.log.WithError(err).Error("Failed to remove temporary blob")
+		}
+	}()
+
+	blob := model.Blob{SHA256: hex.EncodeToString(hash.Sum(nil)), Size: info.Size}
+	if sum != "" && sum != blob.SHA256 {
+		return model.Blob{}, model.ErrUploadChecksum
+	}
+	// то же содержимое могли сохранить, пока читалось это
+	if stored, err := r.addRef(ctx, blob.SHA256); !errors.Is(err, model.ErrBlobNotFound) {
+		return stored, err
+	}
+
+	// объект копируется до вставки строки, чтобы добавившие ссылку после нее сразу его видели
+	if err := r.copyTemp(ctx, tmp, blob.SHA256); err != nil {
+		return model.Blob{}, err
+	}
+	// строка без ссылок - содержимое удаляется, скопированный объект может пропасть вместе с ним
+	query := `INSERT INTO blob (sha256, size, refs) VALUES ($1, $2, 1)
+		ON CONFLICT (sha256) DO UPDATE SET refs = blob.refs + 1 WHERE blob.refs > 0 RETURNING refs`
+	var refs int64
+	err = r.db.QueryRowContext(ctx, query, blob.SHA256, blob.Size).Scan(&refs)
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.Blob{}, model.ErrBlobRemoving
+	}
+	if err != nil {
+		r.log.WithError(err).Error("Failed to insert blob")
+		return model.Blob{}, err
+	}
+	if refs > 1 {
+		return blob, nil
+	}
+
+	// строка новая: Unref прежней строки мог удалить объект уже после копирования, но до удаления
+	// строки. Объект удаляется до строки, поэтому после вставки удалить его больше некому.
+	if err := r.restoreObject(ctx, tmp, blob.SHA256); err != nil {
+		if err := r.Unref(ctx, blob.SHA256); err != nil {
+			r.log.WithError(err).Error("Failed to remove blob reference")
+		}
+		return model.Blob{}, err
+	}
+	return blob, nil
+}
+
+// restoreObject copies the content from tmp again if the object sum is missing
+func (r *BlobRepo) restoreObject(ctx context.Context, tmp, sum string) error {
+	object, err := r.store.GetObject(ctx, blobBucket, sum, minio.GetObjectOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to store blob: %w", err)
+	}
+	_, err = object.Stat()
+	object.Close()
+	if err == nil {
+		return nil
+	}
+	if minio.ToErrorResponse(err).Code != "NoSuchKey" {
+		return fmt.Errorf("failed to store blob: %w", err)
+	}
+	return r.copyTemp(ctx, tmp, sum)
+}
+
+func (r *BlobRepo) copyTemp(ctx context.Context, tmp, sum string) error {
+	_, err := r.store.CopyObject(ctx,
+		minio.CopyDestOptions{Bucket: blobBucket, Object: sum},
+		minio.CopySrcOptions{Bucket: blobBucket, Object: tmp},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to store blob: %w", err)
+	}
+	return nil
+}
+
+// Ref adds a reference to the stored content, ErrBlobNotFound if there is no such content
+func (r *BlobRepo) Ref(ctx context.Context, sum string) error {
+	_, err := r.addRef(ctx, sum)
+	return err
+}
+
+func (r *BlobRepo) addRef(ctx context.Context, sum string) (model.Blob, error) {
+	blob := model.Blob{SHA256: sum}
+	// содержимое без ссылок удаляется, к нему ссылка не добавляется
+	err := r.db.QueryRowContext(ctx, `UPDATE blob SET refs = refs + 1 WHERE sha256 = $1 AND refs > 0 RETURNING size`, sum).
+		Scan(&blob.Size)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.Blob{}, model.ErrBlobNotFound
+		}
+		r.log.WithError(err).Error("Failed to add blob reference")
+		return model.Blob{}, err
+	}
+	return blob, nil
+}
+
+// Unref removes a reference, the content without references is deleted.
+// Строка без ссылок остается до удаления объекта и не дает добавить к нему новую ссылку,
+// поэтому объект удаляется без транзакции и блокировки строки на время запроса к MinIO.
+func (r *BlobRepo) Unref(ctx context.Context, sum string) error {
+	var refs int64
+	err := r.db.QueryRowContext(ctx, `UPDATE blob SET refs = refs - 1 WHERE sha256 = $1 AND refs > 0 RETURNING refs`, sum).
+		Scan(&refs)
+	if errors.Is(err, sql.ErrNoRows) {
+		r.log.Info("BlobRepo: reference to unknown blob ", sum)
+		return nil
+	}
+	if err != nil {
+		r.log.WithError(err).Error("Failed to remove blob reference")
+		return err
+	}
+	if refs > 0 {
+		return nil
+	}
+
+	// строка удаляется и при ошибке: оставшийся объект перезапишет загрузка того же содержимого
+	removeErr := r.store.RemoveObject(ctx, blobBucket, sum, minio.RemoveObjectOptions{})
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM blob WHERE sha256 = $1 AND refs = 0`, sum); err != nil {
+		r.log.WithError(err).Error("Failed to delete blob")
+		return err
+	}
+	if removeErr != nil {
+		return fmt.Errorf("failed to remove blob: %w", removeErr)
+	}
+	return nil
+}
+
+// Get opens the stored content, ErrBlobNotFound if there is no such content
+func (r *BlobRepo) Get(ctx context.Context, sum string) (io.ReadSeekCloser, error) {
+	object, err := r.store.GetObject(ctx, blobBucket, sum, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blob: %w", err)
+	}
+	if _, err := object.Stat(); err != nil {
+		object.Close()
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return nil, model.ErrBlobNotFound
+		}
+		return nil, fmt.Errorf("failed to get blob: %w", err)
+	}
+	return object, nil
+}
+
+func blobTempKey() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return blobTempPrefix + hex.EncodeToString(b), nil
+}
@@ -0,0 +1,256 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/Arcadian-Sky/datakkeeper/internal/model"
+	"github.com/Arcadian-Sky/datakkeeper/mocks"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/minio/minio-go/v7"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+// sha256 of "hello"
+const helloSHA256 = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+
+const (
+	addRefQuery = `UPDATE blob SET refs = refs \+ 1 WHERE sha256 = \$1 AND refs > 0 RETURNING size`
+	unrefQuery  = `UPDATE blob SET refs = refs - 1 WHERE sha256 = \$1 AND refs > 0 RETURNING refs`
+	insertQuery = `INSERT INTO blob \(sha256, size, refs\) VALUES \(\$1, \$2, 1\)`
+)
+
+// putTemp expects the content to be read into a temporary blob
+func putTemp(t *testing.T, mockMinio *mocks.MockMinioClient, content string) {
+	mockMinio.EXPECT().
+		PutObject(gomock.Any(), blobBucket, gomock.Any(), gomock.Any(), int64(-1), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, key string, r io.Reader, _ int64, _ minio.PutObjectOptions) (minio.UploadInfo, error) {
+			require.True(t, strings.HasPrefix(key, blobTempPrefix))
+			got, err := io.ReadAll(r)
+			require.NoError(t, err)
+			require.Equal(t, content, string(got))
+			return minio.UploadInfo{Key: key, Size: int64(len(got))}, nil
+		})
+	mockMinio.EXPECT().RemoveObject(gomock.Any(), blobBucket, gomock.Any(), minio.RemoveObjectOptions{}).Return(nil)
+}
+
+func TestBlobRepo_Put(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockMinio := mocks.NewMockMinioClient(ctrl)
+	mockMinioObject := mocks.NewMockMinioObject(ctrl)
+	r := NewBlobRepository(db, mockMinio, logrus.New())
+
+	t.Run("Stored", func(t *testing.T) {
+		// содержимое уже есть, повторно не читается
+		mock.ExpectQuery(addRefQuery).WithArgs(helloSHA256).WillReturnRows(sqlmock.NewRows([]string{"size"}).AddRow(5))
+		blob, err := r.Put(context.Background(), helloSHA256, 5, nil)
+		require.NoError(t, err)
+		require.Equal(t, model.Blob{SHA256: helloSHA256, Size: 5}, blob)
+	})
+
+	t.Run("New", func(t *testing.T) {
+		putTemp(t, mockMinio, "hello")
+		mock.ExpectQuery(addRefQuery).WithArgs(helloSHA256).WillReturnError(sql.ErrNoRows)
+		mockMinio.EXPECT().
+			CopyObject(gomock.Any(), minio.CopyDestOptions{Bucket: blobBucket, Object: helloSHA256}, gomock.Any()).
+			Return(minio.UploadInfo{}, nil)
+		mock.ExpectQuery(insertQuery).WithArgs(helloSHA256, 5).WillReturnRows(sqlmock.NewRows([]string{"refs"}).AddRow(1))
+		// у новой строки проверяется, что объект не удален прежним Unref
+		mockMinio.EXPECT().GetObject(gomock.Any(), blobBucket, helloSHA256, minio.GetObjectOptions{}).Return(mockMinioObject, nil)
+		mockMinioObject.EXPECT().Stat().Return(minio.ObjectInfo{Key: helloSHA256, Size: 5}, nil)
+		mockMinioObject.EXPECT().Close().Return(nil)
+
+		blob, err := r.Put(context.Background(), "", -1, strings.NewReader("hello"))
+		require.NoError(t, err)
+		require.Equal(t, model.Blob{SHA256: helloSHA256, Size: 5}, blob)
+	})
+
+	t.Run("InsertedMeanwhile", func(t *testing.T) {
+		// строку вставила параллельная загрузка, ее объект на месте
+		putTemp(t, mockMinio, "hello")
+		mock.ExpectQuery(addRefQuery).WithArgs(helloSHA256).WillReturnError(sql.ErrNoRows)
+		mockMinio.EXPECT().
+			CopyObject(gomock.Any(), minio.CopyDestOptions{Bucket: blobBucket, Object: helloSHA256}, gomock.Any()).
+			Return(minio.UploadInfo{}, nil)
+		mock.ExpectQuery(insertQuery).WithArgs(helloSHA256, 5).WillReturnRows(sqlmock.NewRows([]string{"refs"}).AddRow(2))
+
+		_, err := r.Put(context.Background(), "", -1, strings.NewReader("hello"))
+		require.NoError(t, err)
+	})
+
+	t.Run("UnrefDuringPut", func(t *testing.T) {
+		// последняя ссылка снимается между копированием объекта и вставкой строки:
+		// Unref удаляет скопированный объект и строку, новая строка копирует объект заново
+		mockMinio.EXPECT().RemoveObject(gomock.Any(), blobBucket, helloSHA256, minio.RemoveObjectOptions{}).Return(nil)
+		putTemp(t, mockMinio, "hello")
+		mock.ExpectQuery(addRefQuery).WithArgs(helloSHA256).WillReturnError(sql.ErrNoRows)
+		mock.ExpectQuery(unrefQuery).WithArgs(helloSHA256).WillReturnRows(sqlmock.NewRows([]string{"refs"}).AddRow(0))
+		mock.ExpectExec(`DELETE FROM blob WHERE sha256 = \$1 AND refs = 0`).WithArgs(helloSHA256).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(insertQuery).WithArgs(helloSHA256, 5).WillReturnRows(sqlmock.NewRows([]string{"refs"}).AddRow(1))
+		gomock.InOrder(
+			mockMinio.EXPECT().
+				CopyObject(gomock.Any(), minio.CopyDestOptions{Bucket: blobBucket, Object: helloSHA256}, gomock.Any()).
+				DoAndReturn(func(context.Context, minio.CopyDestOptions, minio.CopySrcOptions) (minio.UploadInfo, error) {
+					require.NoError(t, r.Unref(context.Background(), helloSHA256))
+					return minio.UploadInfo{}, nil
+				}),
+			mockMinio.EXPECT().GetObject(gomock.Any(), blobBucket, helloSHA256, minio.GetObjectOptions{}).Return(mockMinioObject, nil),
+			mockMinio.EXPECT().
+				CopyObject(gomock.Any(), minio.CopyDestOptions{Bucket: blobBucket, Object: helloSHA256}, gomock.Any()).
+				Return(minio.UploadInfo{}, nil),
+		)
+		mockMinioObject.EXPECT().Stat().Return(minio.ObjectInfo{}, minio.ErrorResponse{Code: "NoSuchKey"})
+		mockMinioObject.EXPECT().Close().Return(nil)
+
+		blob, err := r.Put(context.Background(), "", -1, strings.NewReader("hello"))
+		require.NoError(t, err)
+		require.Equal(t, model.Blob{SHA256: helloSHA256, Size: 5}, blob)
+	})
+
+	t.Run("RestoreFailed", func(t *testing.T) {
+		// без объекта ссылка не остается
+		mockMinio.EXPECT().RemoveObject(gomock.Any(), blobBucket, helloSHA256, minio.RemoveObjectOptions{}).Return(nil)
+		putTemp(t, mockMinio, "hello")
+		mock.ExpectQuery(addRefQuery).WithArgs(helloSHA256).WillReturnError(sql.ErrNoRows)
+		mockMinio.EXPECT().
+			CopyObject(gomock.Any(), minio.CopyDestOptions{Bucket: blobBucket, Object: helloSHA256}, gomock.Any()).
+			Return(minio.UploadInfo{}, nil)
+		mock.ExpectQuery(insertQuery).WithArgs(helloSHA256, 5).WillReturnRows(sqlmock.NewRows([]string{"refs"}).AddRow(1))
+		mockMinio.EXPECT().GetObject(gomock.Any(), blobBucket, helloSHA256, minio.GetObjectOptions{}).Return(mockMinioObject, nil)
+		mockMinioObject.EXPECT().Stat().Return(minio.ObjectInfo{}, minio.ErrorResponse{Code: "InternalError"})
+		mockMinioObject.EXPECT().Close().Return(nil)
+		mock.ExpectQuery(unrefQuery).WithArgs(helloSHA256).WillReturnRows(sqlmock.NewRows([]string{"refs"}).AddRow(0))
+		mock.ExpectExec(`DELETE FROM blob WHERE sha256 = \$1 AND refs = 0`).WithArgs(helloSHA256).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		_, err := r.Put(context.Background(), "", -1, strings.NewReader("hello"))
+		require.Error(t, err)
+	})
+
+	t.Run("Removing", func(t *testing.T) {
+		// строка без ссылок не обновляется, ее объект сейчас удаляется
+		putTemp(t, mockMinio, "hello")
+		mock.ExpectQuery(addRefQuery).WithArgs(helloSHA256).WillReturnError(sql.ErrNoRows)
+		mockMinio.EXPECT().
+			CopyObject(gomock.Any(), minio.CopyDestOptions{Bucket: blobBucket, Object: helloSHA256}, gomock.Any()).
+			Return(minio.UploadInfo{}, nil)
+		mock.ExpectQuery(insertQuery).WithArgs(helloSHA256, 5).WillReturnRows(sqlmock.NewRows([]string{"refs"}))
+
+		_, err := r.Put(context.Background(), "", -1, strings.NewReader("hello"))
+		require.ErrorIs(t, err, model.ErrBlobRemoving)
+	})
+
+	t.Run("StoredMeanwhile", func(t *testing.T) {
+		putTemp(t, mockMinio, "hello")
+		mock.ExpectQuery(addRefQuery).WithArgs(helloSHA256).WillReturnRows(sqlmock.NewRows([]string{"size"}).AddRow(5))
+
+		blob, err := r.Put(context.Background(), "", -1, strings.NewReader("hello"))
+		require.NoError(t, err)
+		require.Equal(t, helloSHA256, blob.SHA256)
+	})
+
+	t.Run("ChecksumMismatch", func(t *testing.T) {
+		sum := strings.Repeat("0", 64)
+		mock.ExpectQuery(addRefQuery).WithArgs(sum).WillReturnError(sql.ErrNoRows)
+		putTemp(t, mockMinio, "hello")
+
+		_, err := r.Put(context.Background(), sum, -1, strings.NewReader("hello"))
+		require.ErrorIs(t, err, model.ErrUploadChecksum)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBlobRepo_Ref(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+	r := NewBlobRepository(db, nil, logrus.New())
+
+	mock.ExpectQuery(addRefQuery).WithArgs("sum").WillReturnRows(sqlmock.NewRows([]string{"size"}).AddRow(5))
+	require.NoError(t, r.Ref(context.Background(), "sum"))
+
+	mock.ExpectQuery(addRefQuery).WithArgs("gone").WillReturnError(sql.ErrNoRows)
+	require.ErrorIs(t, r.Ref(context.Background(), "gone"), model.ErrBlobNotFound)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBlobRepo_Unref(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockMinio := mocks.NewMockMinioClient(ctrl)
+	r := NewBlobRepository(db, mockMinio, logrus.New())
+
+	t.Run("Referenced", func(t *testing.T) {
+		mock.ExpectQuery(unrefQuery).WithArgs("sum").WillReturnRows(sqlmock.NewRows([]string{"refs"}).AddRow(1))
+		require.NoError(t, r.Unref(context.Background(), "sum"))
+	})
+
+	t.Run("LastReference", func(t *testing.T) {
+		// без транзакции: строка без ссылок записана до запроса к MinIO
+		mock.ExpectQuery(unrefQuery).WithArgs("sum").WillReturnRows(sqlmock.NewRows([]string{"refs"}).AddRow(0))
+		mockMinio.EXPECT().RemoveObject(gomock.Any(), blobBucket, "sum", minio.RemoveObjectOptions{}).Return(nil)
+		mock.ExpectExec(`DELETE FROM blob WHERE sha256 = \$1 AND refs = 0`).WithArgs("sum").WillReturnResult(sqlmock.NewResult(0, 1))
+		require.NoError(t, r.Unref(context.Background(), "sum"))
+	})
+
+	t.Run("RemoveFailed", func(t *testing.T) {
+		// строка удаляется, оставшийся объект перезапишет новая загрузка
+		mock.ExpectQuery(unrefQuery).WithArgs("sum").WillReturnRows(sqlmock.NewRows([]string{"refs"}).AddRow(0))
+		mockMinio.EXPECT().RemoveObject(gomock.Any(), blobBucket, "sum", minio.RemoveObjectOptions{}).Return(minio.ErrorResponse{Code: "InternalError"})
+		mock.ExpectExec(`DELETE FROM blob WHERE sha256 = \$1 AND refs = 0`).WithArgs("sum").WillReturnResult(sqlmock.NewResult(0, 1))
+		require.Error(t, r.Unref(context.Background(), "sum"))
+	})
+
+	t.Run("Unknown", func(t *testing.T) {
+		mock.ExpectQuery(unrefQuery).WithArgs("gone").WillReturnError(sql.ErrNoRows)
+		require.NoError(t, r.Unref(context.Background(), "gone"))
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBlobRepo_Get(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockMinio := mocks.NewMockMinioClient(ctrl)
+	mockMinioObject := mocks.NewMockMinioObject(ctrl)
+	r := NewBlobRepository(nil, mockMinio, logrus.New())
+
+	mockMinio.EXPECT().GetObject(gomock.Any(), blobBucket, "sum", minio.GetObjectOptions{}).Return(mockMinioObject, nil)
+	mockMinioObject.EXPECT().Stat().Return(minio.ObjectInfo{Key: "sum", Size: 5}, nil)
+	object, err := r.Get(context.Background(), "sum")
+	require.NoError(t, err)
+	require.Equal(t, mockMinioObject, object)
+
+	mockMinio.EXPECT().GetObject(gomock.Any(), blobBucket, "gone", minio.GetObjectOptions{}).Return(mockMinioObject, nil)
+	mockMinioObject.EXPECT().Stat().Return(minio.ObjectInfo{}, minio.ErrorResponse{Code: "NoSuchKey"})
+	mockMinioObject.EXPECT().Close().Return(nil)
+	_, err = r.Get(context.Background(), "gone")
+	require.ErrorIs(t, err, model.ErrBlobNotFound)
+}
+
+func TestBlobRepo_Init(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockMinio := mocks.NewMockMinioClient(ctrl)
+	r := NewBlobRepository(nil, mockMinio, logrus.New())
+
+	mockMinio.EXPECT().BucketExists(gomock.Any(), blobBucket).Return(true, nil)
+	require.NoError(t, r.Init(context.Background()))
+
+	mockMinio.EXPECT().BucketExists(gomock.Any(), blobBucket).Return(false, nil)
+	mockMinio.EXPECT().MakeBucket(gomock.Any(), blobBucket, minio.MakeBucketOptions{}).Return(nil)
+	require.NoError(t, r.Init(context.Background()))
+}
@@ -0,0 +1,227 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"github.com/Arcadian-Sky/datakkeeper/internal/model"
+	"github.com/sirupsen/logrus"
+)
+
+// ItemMetaRepository хранит пользовательские метаданные записей и файлов отдельно от самих данных
+type ItemMetaRepository interface {
+	Set(ctx context.Context, meta *model.ItemMeta) error
+	Get(ctx context.Context, meta *model.ItemMeta) (*model.ItemMeta, error)
+	List(ctx context.Context, userID int64, kind string) (map[string]model.ItemMeta, error)
+	Delete(ctx context.Context, meta *model.ItemMeta) error
+	Rename(ctx context.Context, userID int64, kind, from, to string) error
+}
+
+// ItemMetaSearcher ищет элементы по тексту метаданных, его реализуют хранилища с полнотекстовым индексом
+type ItemMetaSearcher interface {
+	Search(ctx context.Context, userID int64, kind, text string) ([]string, error)
+}
+
+type ItemMetaRepo struct {
+	db  *sql.DB
+	log *logrus.Logger
+}
+
+func NewItemMetaRepository(dbm *sql.DB, lg *logrus.Logger) *ItemMetaRepo {
+	return &ItemMetaRepo{
+		db:  dbm,
+		log: lg,
+	}
+}
+
+// Set replaces metadata of the item, empty metadata removes the row
+func (r *ItemMetaRepo) Set(ctx context.Context, meta *model.ItemMeta) error {
+	if meta.IsEmpty() {
+		return r.Delete(ctx, meta)
+	}
+
+	fields, tags, err := marshalMeta(meta)
+	if err != nil {
+		return err
+	}
+	query := `INSERT INTO item_meta (user_id, kind, item_id, fields, tags, description) VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (user_id, kind, item_id) DO UPDATE SET fields = EXCLUDED.fields, tags = EXCLUDED.tags, description = EXCLUDED.description`
+	_, err = r.db.ExecContext(ctx, query, meta.UserID, meta.Kind, meta.ItemID, fields, tags, meta.Description)
+	if err != nil {
+		r.log.WithError(err).Error("Failed to set item meta")
+		return err
+	}
+	return nil
+}
+
+// Get returns metadata of the item, item without metadata gets empty one
+func (r *ItemMetaRepo) Get(ctx context.Context, meta *model.ItemMeta) (*model.ItemMeta, error) {
+	query := `SELECT fields, tags, description FROM item_meta WHERE user_id = $1 AND kind = $2 AND item_id = $3`
+	res := model.ItemMeta{UserID: meta.UserID, Kind: meta.Kind, ItemID: meta.ItemID}
+	var fields, tags []byte
+	err := r.db.QueryRowContext(ctx, query, meta.UserID, meta.Kind, meta.ItemID).Scan(&fields, &tags, &res.Description)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return &res, nil
+		}
+		r.log.WithError(err).Error("Failed to get item meta")
+		return nil, err
+	}
+	if err := unmarshalMeta(&res, fields, tags); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// List returns metadata of all user items of the kind by ItemID
+func (r *ItemMetaRepo) List(ctx context.Context, userID int64, kind string) (map[string]model.ItemMeta, error) {
+	query := `SELECT item_id, fields, tags, description FROM item_meta WHERE user_id = $1 AND kind = $2`
+	rows, err := r.db.QueryContext(ctx, query, userID, kind)
+	if err != nil {
+		r.log.WithError(err).Error("Failed to list item meta")
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make(map[string]model.ItemMeta)
+	for rows.Next() {
+		meta := model.ItemMeta{UserID: userID, Kind: kind}
+		var fields, tags []byte
+		if err := rows.Scan(&meta.ItemID, &fields, &tags, &meta.Description); err != nil {
+			r.log.WithError(err).Error("Failed to scan item meta")
+			return nil, err
+		}
+		if err := unmarshalMeta(&meta, fields, tags); err != nil {
+			return nil, err
+		}
+		res[meta.ItemID] = meta
+	}
+	if err := rows.Err(); err != nil {
+		r.log.WithError(err).Error("Error while iterating rows")
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (r *ItemMetaRepo) Delete(ctx context.Context, meta *model.ItemMeta) error {
+	query := `DELETE FROM item_meta WHERE user_id = $1 AND kind = $2 AND item_id = $3`
+	_, err := r.db.ExecContext(ctx, query, meta.UserID, meta.Kind, meta.ItemID)
+	if err != nil {
+		r.log.WithError(err).Error("Failed to delete item meta")
+		return err
+	}
+	return nil
+}
+
+// Rename moves metadata to the new ItemID, metadata left there by a deleted item is replaced
+func (r *ItemMetaRepo) Rename(ctx context.Context, userID int64, kind, from, to string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.log.WithError(err).Error("Failed to begin transaction")
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `DELETE FROM item_meta WHERE user_id = $1 AND kind = $2 AND item_id = $3`
+	if _, err := tx.ExecContext(ctx, query, userID, kind, to); err != nil {
+		r.log.WithError(err).Error("Failed to delete item meta")
+		return err
+	}
+	// параметры по порядку: SQLite нумерует $N по первому появлению в запросе
+	query = `UPDATE item_meta SET item_id = $1 WHERE user_id = $2 AND kind = $3 AND item_id = $4`
+	if _, err := tx.ExecContext(ctx, query, to, userID, kind, from); err != nil {
+		r.log.WithError(err).Error("Failed to rename item meta")
+		return err
+	}
+	return tx.Commit()
+}
+
+func marshalMeta(meta *model.ItemMeta) ([]byte, []byte, error) {
+	fields := meta.Fields
+	if fields == nil {
+		fields = map[string]string{}
+	}
+	tags := meta.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	f, err := json.Marshal(fields)
+	if err != nil {
+		return nil, nil, err
+	}
+	t, err := json.Marshal(tags)
+	if err != nil {
+		return nil, nil, err
+	}
+	return f, t, nil
+}
+
+func unmarshalMeta(meta *model.ItemMeta, fields, tags []byte) error {
+	if err := json.Unmarshal(fields, &meta.Fields); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(tags, &meta.Tags); err != nil {
+		return err
+	}
+	if len(meta.Fields) == 0 {
+		meta.Fields = nil
+	}
+	if len(meta.Tags) == 0 {
+		meta.Tags = nil
+	}
+	return nil
+}
+
+// KindItemMetaRepo направляет метаданные одного вида в отдельное хранилище, остальные - в основное
+type KindItemMetaRepo struct {
+	def   ItemMetaRepository
+	kind  string
+	store ItemMetaRepository
+}
+
+func NewKindItemMetaRepository(def ItemMetaRepository, kind string, store ItemMetaRepository) *KindItemMetaRepo {
+	return &KindItemMetaRepo{
+		def:   def,
+		kind:  kind,
+		store: store,
+	}
+}
+
+func (r *KindItemMetaRepo) repo(kind string) ItemMetaRepository {
+	if kind == r.kind {
+		return r.store
+	}
+	return r.def
+}
+
+func (r *KindItemMetaRepo) Set(ctx context.Context, meta *model.ItemMeta) error {
+	return r.repo(meta.Kind).Set(ctx, meta)
+}
+
+func (r *KindItemMetaRepo) Get(ctx context.Context, meta *model.ItemMeta) (*model.ItemMeta, error) {
+	return r.repo(meta.Kind).Get(ctx, meta)
+}
+
+func (r *KindItemMetaRepo) List(ctx context.Context, userID int64, kind string) (map[string]model.ItemMeta, error) {
+	return r.repo(kind).List(ctx, userID, kind)
+}
+
+func (r *KindItemMetaRepo) Delete(ctx context.Context, meta *model.ItemMeta) error {
+	return r.repo(meta.Kind).Delete(ctx, meta)
+}
+
+func (r *KindItemMetaRepo) Rename(ctx context.Context, userID int64, kind, from, to string) error {
+	return r.repo(kind).Rename(ctx, userID, kind, from, to)
+}
+
+// Search ищет в хранилище вида, если оно умеет полнотекстовый поиск, иначе возвращает пустой результат
+func (r *KindItemMetaRepo) Search(ctx context.Context, userID int64, kind, text string) ([]string, error) {
+	searcher, ok := r.repo(kind).(ItemMetaSearcher)
+	if !ok {
+		return nil, nil
+	}
+	return searcher.Search(ctx, userID, kind, text)
+}
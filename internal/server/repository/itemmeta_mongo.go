@@ -0,0 +1,233 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/Arcadian-Sky/datakkeeper/internal/model"
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// mongoItemMetaCollection - коллекция метаданных в MongoDB
+const mongoItemMetaCollection = "item_meta"
+
+// MongoItemMetaRepo хранит метаданные в MongoDB. Поля лежат массивом ключ/значение,
+// чтобы их значения вместе с тегами, описанием и связанным сайтом попали в текстовый индекс для Search.
+type MongoItemMetaRepo struct {
+	coll *mongo.Collection
+	log  *logrus.Logger
+}
+
+type mongoItemMeta struct {
+	UserID      int64            `bson:"user_id"`
+	Kind        string           `bson:"kind"`
+	ItemID      string           `bson:"item_id"`
+	Fields      []mongoMetaField `bson:"fields"`
+	Tags        []string         `bson:"tags"`
+	Description string           `bson:"description"`
+	// Website - значение поля MetaFieldWebsite, в текстовом индексе весит больше остальных полей
+	Website string `bson:"website,omitempty"`
+}
+
+type mongoMetaField struct {
+	Key   string `bson:"key"`
+	Value string `bson:"value"`
+}
+
+func NewMongoItemMetaRepository(dbm *mongo.Database, lg *logrus.Logger) *MongoItemMetaRepo {
+	return &MongoItemMetaRepo{
+		coll: dbm.Collection(mongoItemMetaCollection),
+		log:  lg,
+	}
+}
+
+// Init creates indexes: the item key, tags of the user and the text index for Search
+func (r *MongoItemMetaRepo) Init(ctx context.Context) error {
+	_, err := r.coll.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "kind", Value: 1}, {Key: "item_id", Value: 1}},
+			Options: options.Index().SetName("item_key").SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "kind", Value: 1}, {Key: "tags", Value: 1}},
+			Options: options.Index().SetName("item_tags"),
+		},
+		{
+			Keys: bson.D{
+				{Key: "description", Value: "text"}, {Key: "tags", Value: "text"},
+				{Key: "fields.value", Value: "text"}, {Key: "website", Value: "text"},
+			},
+			// без стемминга: метаданные пишут на разных языках
+			Options: options.Index().SetName("item_text").SetDefaultLanguage("none").
+				SetWeights(bson.D{{Key: "tags", Value: 5}, {Key: "website", Value: 5}}),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create item meta indexes: %w", err)
+	}
+	return nil
+}
+
+// Set replaces metadata of the item, empty metadata removes the document
+func (r *MongoItemMetaRepo) Set(ctx context.Context, meta *model.ItemMeta) error {
+	if meta.IsEmpty() {
+		return r.Delete(ctx, meta)
+	}
+
+	doc := toMongoItemMeta(meta)
+	_, err := r.coll.ReplaceOne(ctx, itemKey(meta.UserID, meta.Kind, meta.ItemID), doc, options.Replace().SetUpsert(true))
+	if err != nil {
+		r.log.WithError(err).Error("Failed to set item meta")
+		return err
+	}
+	return nil
+}
+
+// Get returns metadata of the item, item without metadata gets empty one
+func (r *MongoItemMetaRepo) Get(ctx context.Context, meta *model.ItemMeta) (*model.ItemMeta, error) {
+	var doc mongoItemMeta
+	err := r.coll.FindOne(ctx, itemKey(meta.UserID, meta.Kind, meta.ItemID)).Decode(&doc)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return &model.ItemMeta{UserID: meta.UserID, Kind: meta.Kind, ItemID: meta.ItemID}, nil
+		}
+		r.log.WithError(err).Error("Failed to get item meta")
+		return nil, err
+	}
+	res := doc.itemMeta()
+	return &res, nil
+}
+
+// List returns metadata of all user items of the kind by ItemID
+func (r *MongoItemMetaRepo) List(ctx context.Context, userID int64, kind string) (map[string]model.ItemMeta, error) {
+	return r.find(ctx, bson.D{{Key: "user_id", Value: userID}, {Key: "kind", Value: kind}})
+}
+
+func (r *MongoItemMetaRepo) Delete(ctx context.Context, meta *model.ItemMeta) error {
+	_, err := r.coll.DeleteOne(ctx, itemKey(meta.UserID, meta.Kind, meta.ItemID))
+	if err != nil {
+		r.log.WithError(err).Error("Failed to delete item meta")
+		return err
+	}
+	return nil
+}
+
+// Rename moves metadata to the new ItemID, metadata left there by a deleted item is replaced.
+// Без транзакции: у одиночного сервера MongoDB их нет, сбой между шагами оставит метаданные под старым именем.
+func (r *MongoItemMetaRepo) Rename(ctx context.Context, userID int64, kind, from, to string) error {
+	if _, err := r.coll.DeleteOne(ctx, itemKey(userID, kind, to)); err != nil {
+		r.log.WithError(err).Error("Failed to delete item meta")
+		return err
+	}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "item_id", Value: to}}}}
+	if _, err := r.coll.UpdateOne(ctx, itemKey(userID, kind, from), update); err != nil {
+		r.log.WithError(err).Error("Failed to rename item meta")
+		return err
+	}
+	return nil
+}
+
+// Search returns ItemIDs of user items of the kind whose tags, description or field values contain
+// the words of text, best matches first
+func (r *MongoItemMetaRepo) Search(ctx context.Context, userID int64, kind, text string) ([]string, error) {
+	filter := bson.D{
+		{Key: "user_id", Value: userID}, {Key: "kind", Value: kind},
+		{Key: "$text", Value: bson.D{{Key: "$search", Value: text}}},
+	}
+	opts := options.Find().
+		SetProjection(bson.D{{Key: "item_id", Value: 1}, {Key: "score", Value: bson.D{{Key: "$meta", Value: "textScore"}}}}).
+		SetSort(bson.D{{Key: "score", Value: bson.D{{Key: "$meta", Value: "textScore"}}}})
+	cur, err := r.coll.Find(ctx, filter, opts)
+	if err != nil {
+		r.log.WithError(err).Error("Failed to search item meta")
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var ids []string
+	for cur.Next(ctx) {
+		var doc mongoItemMeta
+		if err := cur.Decode(&doc); err != nil {
+			r.log.WithError(err).Error("Failed to decode item meta")
+			return nil, err
+		}
+		ids = append(ids, doc.ItemID)
+	}
+	if err := cur.Err(); err != nil {
+		r.log.WithError(err).Error("Error while iterating item meta")
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (r *MongoItemMetaRepo) find(ctx context.Context, filter bson.D) (map[string]model.ItemMeta, error) {
+	cur, err := r.coll.Find(ctx, filter)
+	if err != nil {
+		r.log.WithError(err).Error("Failed to list item meta")
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	res := make(map[string]model.ItemMeta)
+	for cur.Next(ctx) {
+		var doc mongoItemMeta
+		if err := cur.Decode(&doc); err != nil {
+			r.log.WithError(err).Error("Failed to decode item meta")
+			return nil, err
+		}
+		res[doc.ItemID] = doc.itemMeta()
+	}
+	if err := cur.Err(); err != nil {
+		r.log.WithError(err).Error("Error while iterating item meta")
+		return nil, err
+	}
+	return res, nil
+}
+
+func itemKey(userID int64, kind, itemID string) bson.D {
+	return bson.D{{Key: "user_id", Value: userID}, {Key: "kind", Value: kind}, {Key: "item_id", Value: itemID}}
+}
+
+func toMongoItemMeta(meta *model.ItemMeta) mongoItemMeta {
+	doc := mongoItemMeta{
+		UserID:      meta.UserID,
+		Kind:        meta.Kind,
+		ItemID:      meta.ItemID,
+		Fields:      make([]mongoMetaField, 0, len(meta.Fields)),
+		Tags:        meta.Tags,
+		Description: meta.Description,
+		Website:     meta.Fields[model.MetaFieldWebsite],
+	}
+	if doc.Tags == nil {
+		doc.Tags = []string{}
+	}
+	for k, v := range meta.Fields {
+		doc.Fields = append(doc.Fields, mongoMetaField{Key: k, Value: v})
+	}
+	sort.Slice(doc.Fields, func(i, j int) bool { return doc.Fields[i].Key < doc.Fields[j].Key })
+	return doc
+}
+
+func (doc *mongoItemMeta) itemMeta() model.ItemMeta {
+	meta := model.ItemMeta{
+		UserID:      doc.UserID,
+		Kind:        doc.Kind,
+		ItemID:      doc.ItemID,
+		Description: doc.Description,
+	}
+	if len(doc.Fields) > 0 {
+		meta.Fields = make(map[string]string, len(doc.Fields))
+		for _, f := range doc.Fields {
+			meta.Fields[f.Key] = f.Value
+		}
+	}
+	if len(doc.Tags) > 0 {
+		meta.Tags = doc.Tags
+	}
+	return meta
+}
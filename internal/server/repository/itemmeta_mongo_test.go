@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Arcadian-Sky/datakkeeper/internal/model"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestMongoItemMetaRepo(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	ctx := context.Background()
+	ns := "datakeeper." + mongoItemMetaCollection
+
+	mt.Run("Init", func(mt *mtest.T) {
+		r := NewMongoItemMetaRepository(mt.DB, logrus.New())
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+		require.NoError(mt, r.Init(ctx))
+
+		cmd := mt.GetStartedEvent().Command
+		indexes, err := cmd.LookupErr("indexes")
+		require.NoError(mt, err)
+		values, err := indexes.Array().Values()
+		require.NoError(mt, err)
+		require.Len(mt, values, 3)
+		text := values[2].Document()
+		require.Equal(mt, "item_text", text.Lookup("name").StringValue())
+		require.Equal(mt, "none", text.Lookup("default_language").StringValue())
+
+		mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 85, Message: "index options conflict"}))
+		require.Error(mt, r.Init(ctx))
+	})
+
+	mt.Run("Set", func(mt *mtest.T) {
+		r := NewMongoItemMetaRepository(mt.DB, logrus.New())
+		meta := &model.ItemMeta{
+			UserID: 1, Kind: model.MetaKindFile, ItemID: "a.txt", Tags: []string{"work"},
+			Fields: map[string]string{model.MetaFieldWebsite: "example.com", "note": "draft"},
+		}
+		mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}))
+		require.NoError(mt, r.Set(ctx, meta))
+
+		cmd := mt.GetStartedEvent().Command
+		require.Equal(mt, "update", cmd.Index(0).Key())
+		updates, err := cmd.Lookup("updates").Array().Values()
+		require.NoError(mt, err)
+		update := updates[0].Document()
+		require.True(mt, update.Lookup("upsert").Boolean())
+		doc := update.Lookup("u").Document()
+		require.Equal(mt, "example.com", doc.Lookup("website").StringValue())
+		fields, err := doc.Lookup("fields").Array().Values()
+		require.NoError(mt, err)
+		require.Len(mt, fields, 2)
+		require.Equal(mt, "note", fields[0].Document().Lookup("key").StringValue())
+
+		// пустые метаданные удаляют документ
+		mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}))
+		require.NoError(mt, r.Set(ctx, &model.ItemMeta{UserID: 1, Kind: model.MetaKindFile, ItemID: "a.txt"}))
+		require.Equal(mt, "delete", mt.GetStartedEvent().CommandName)
+
+		mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 11000, Message: "duplicate key"}))
+		require.Error(mt, r.Set(ctx, meta))
+	})
+
+	mt.Run("Get", func(mt *mtest.T) {
+		r := NewMongoItemMetaRepository(mt.DB, logrus.New())
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, ns, mtest.FirstBatch, bson.D{
+			{Key: "user_id", Value: int64(1)}, {Key: "kind", Value: model.MetaKindFile}, {Key: "item_id", Value: "a.txt"},
+			{Key: "fields", Value: bson.A{bson.D{{Key: "key", Value: "note"}, {Key: "value", Value: "draft"}}}},
+			{Key: "tags", Value: bson.A{"work"}}, {Key: "description", Value: "report"},
+		}))
+		got, err := r.Get(ctx, &model.ItemMeta{UserID: 1, Kind: model.MetaKindFile, ItemID: "a.txt"})
+		require.NoError(mt, err)
+		require.Equal(mt, &model.ItemMeta{
+			UserID: 1, Kind: model.MetaKindFile, ItemID: "a.txt",
+			Fields: map[string]string{"note": "draft"}, Tags: []string{"work"}, Description: "report",
+		}, got)
+
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, ns, mtest.FirstBatch))
+		got, err = r.Get(ctx, &model.ItemMeta{UserID: 1, Kind: model.MetaKindFile, ItemID: "b.txt"})
+		require.NoError(mt, err)
+		require.True(mt, got.IsEmpty())
+		require.Equal(mt, "b.txt", got.ItemID)
+	})
+
+	mt.Run("List", func(mt *mtest.T) {
+		r := NewMongoItemMetaRepository(mt.DB, logrus.New())
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, ns, mtest.FirstBatch,
+			bson.D{{Key: "user_id", Value: int64(1)}, {Key: "kind", Value: model.MetaKindFile}, {Key: "item_id", Value: "a.txt"}, {Key: "tags", Value: bson.A{"work"}}},
+			bson.D{{Key: "user_id", Value: int64(1)}, {Key: "kind", Value: model.MetaKindFile}, {Key: "item_id", Value: "b.txt"}, {Key: "description", Value: "note"}},
+		))
+		list, err := r.List(ctx, 1, model.MetaKindFile)
+		require.NoError(mt, err)
+		require.Len(mt, list, 2)
+		require.Equal(mt, []string{"work"}, list["a.txt"].Tags)
+		require.Equal(mt, "note", list["b.txt"].Description)
+
+		mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 13, Message: "unauthorized"}))
+		_, err = r.List(ctx, 1, model.MetaKindFile)
+		require.Error(mt, err)
+	})
+
+	mt.Run("Rename", func(mt *mtest.T) {
+		r := NewMongoItemMetaRepository(mt.DB, logrus.New())
+		mt.AddMockResponses(
+			mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}),
+			mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}, bson.E{Key: "nModified", Value: 1}),
+		)
+		require.NoError(mt, r.Rename(ctx, 1, model.MetaKindFile, "a.txt", "b.txt"))
+		require.Equal(mt, "delete", mt.GetStartedEvent().CommandName)
+		require.Equal(mt, "update", mt.GetStartedEvent().CommandName)
+
+		mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 13, Message: "unauthorized"}))
+		require.Error(mt, r.Rename(ctx, 1, model.MetaKindFile, "a.txt", "b.txt"))
+	})
+
+	mt.Run("Search", func(mt *mtest.T) {
+		r := NewMongoItemMetaRepository(mt.DB, logrus.New())
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, ns, mtest.FirstBatch,
+			bson.D{{Key: "item_id", Value: "b.txt"}, {Key: "score", Value: 1.5}},
+			bson.D{{Key: "item_id", Value: "a.txt"}, {Key: "score", Value: 0.75}},
+		))
+		ids, err := r.Search(ctx, 1, model.MetaKindFile, "report")
+		require.NoError(mt, err)
+		require.Equal(mt, []string{"b.txt", "a.txt"}, ids)
+
+		filter := mt.GetStartedEvent().Command.Lookup("filter").Document()
+		require.Equal(mt, "report", filter.Lookup("$text", "$search").StringValue())
+		require.Equal(mt, int64(1), filter.Lookup("user_id").Int64())
+	})
+}
+
+func TestKindItemMetaRepo(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	ctx := context.Background()
+
+	mt.Run("route by kind", func(mt *mtest.T) {
+		db := newTestSQLite(mt.T)
+		userID := newTestSQLiteUser(mt.T, db, "user")
+		r := NewKindItemMetaRepository(NewItemMetaRepository(db, logrus.New()), model.MetaKindFile,
+			NewMongoItemMetaRepository(mt.DB, logrus.New()))
+
+		// метаданные записей остаются в SQL
+		data := &model.ItemMeta{UserID: userID, Kind: model.MetaKindData, ItemID: "1", Tags: []string{"work"}}
+		require.NoError(mt, r.Set(ctx, data))
+		got, err := r.Get(ctx, data)
+		require.NoError(mt, err)
+		require.Equal(mt, []string{"work"}, got.Tags)
+		ids, err := r.Search(ctx, userID, model.MetaKindData, "work")
+		require.NoError(mt, err)
+		require.Nil(mt, ids)
+
+		mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}))
+		require.NoError(mt, r.Set(ctx, &model.ItemMeta{UserID: userID, Kind: model.MetaKindFile, ItemID: "a.txt", Tags: []string{"work"}}))
+		require.Equal(mt, "update", mt.GetStartedEvent().CommandName)
+		list, err := NewItemMetaRepository(db, logrus.New()).List(ctx, userID, model.MetaKindFile)
+		require.NoError(mt, err)
+		require.Empty(mt, list)
+
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "datakeeper."+mongoItemMetaCollection, mtest.FirstBatch,
+			bson.D{{Key: "item_id", Value: "a.txt"}}))
+		ids, err = r.Search(ctx, userID, model.MetaKindFile, "work")
+		require.NoError(mt, err)
+		require.Equal(mt, []string{"a.txt"}, ids)
+	})
+}
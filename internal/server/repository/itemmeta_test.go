@@ -0,0 +1,244 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/Arcadian-Sky/datakkeeper/internal/model"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestItemMetaRepo_Set(t *testing.T) {
+	logg := logrus.New()
+	upsert := `INSERT INTO item_meta \(user_id, kind, item_id, fields, tags, description\) VALUES \(\$1, \$2, \$3, \$4, \$5, \$6\) ON CONFLICT \(user_id, kind, item_id\) DO UPDATE SET fields = EXCLUDED.fields, tags = EXCLUDED.tags, description = EXCLUDED.description`
+	del := `DELETE FROM item_meta WHERE user_id = \$1 AND kind = \$2 AND item_id = \$3`
+
+	tests := []struct {
+		name    string
+		mock    func(mock sqlmock.Sqlmock)
+		meta    *model.ItemMeta
+		wantErr error
+	}{
+		{
+			name: "Upsert",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(upsert).
+					WithArgs(1, model.MetaKindData, "10", []byte(`{"site":"example.com"}`), []byte(`["work"]`), "").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			meta: &model.ItemMeta{UserID: 1, Kind: model.MetaKindData, ItemID: "10",
+				Fields: map[string]string{"site": "example.com"}, Tags: []string{"work"}},
+		},
+		{
+			name: "OnlyTags",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(upsert).
+					WithArgs(1, model.MetaKindFile, "photo.jpg", []byte(`{}`), []byte(`["trip"]`), "").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			meta: &model.ItemMeta{UserID: 1, Kind: model.MetaKindFile, ItemID: "photo.jpg", Tags: []string{"trip"}},
+		},
+		{
+			name: "OnlyDescription",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(upsert).
+					WithArgs(1, model.MetaKindFile, "photo.jpg", []byte(`{}`), []byte(`[]`), "sea trip").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			meta: &model.ItemMeta{UserID: 1, Kind: model.MetaKindFile, ItemID: "photo.jpg", Description: "sea trip"},
+		},
+		{
+			name: "EmptyDeletes",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(del).
+					WithArgs(1, model.MetaKindData, "10").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			meta: &model.ItemMeta{UserID: 1, Kind: model.MetaKindData, ItemID: "10"},
+		},
+		{
+			name: "ExecError",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(upsert).WillReturnError(sql.ErrConnDone)
+			},
+			meta:    &model.ItemMeta{UserID: 1, Kind: model.MetaKindData, ItemID: "10", Tags: []string{"work"}},
+			wantErr: sql.ErrConnDone,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			tt.mock(mock)
+
+			r := NewItemMetaRepository(db, logg)
+			err = r.Set(context.Background(), tt.meta)
+			require.ErrorIs(t, err, tt.wantErr)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestItemMetaRepo_Get(t *testing.T) {
+	logg := logrus.New()
+	query := `SELECT fields, tags, description FROM item_meta WHERE user_id = \$1 AND kind = \$2 AND item_id = \$3`
+
+	tests := []struct {
+		name    string
+		mock    func(mock sqlmock.Sqlmock)
+		want    *model.ItemMeta
+		wantErr error
+	}{
+		{
+			name: "Success",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WithArgs(1, model.MetaKindData, "10").
+					WillReturnRows(sqlmock.NewRows([]string{"fields", "tags", "description"}).AddRow([]byte(`{"site":"example.com"}`), []byte(`["work"]`), "bank"))
+			},
+			want: &model.ItemMeta{UserID: 1, Kind: model.MetaKindData, ItemID: "10",
+				Fields: map[string]string{"site": "example.com"}, Tags: []string{"work"}, Description: "bank"},
+		},
+		{
+			name: "NoMeta",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WithArgs(1, model.MetaKindData, "10").WillReturnError(sql.ErrNoRows)
+			},
+			want: &model.ItemMeta{UserID: 1, Kind: model.MetaKindData, ItemID: "10"},
+		},
+		{
+			name: "QueryError",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WithArgs(1, model.MetaKindData, "10").WillReturnError(sql.ErrConnDone)
+			},
+			wantErr: sql.ErrConnDone,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			tt.mock(mock)
+
+			r := NewItemMetaRepository(db, logg)
+			got, err := r.Get(context.Background(), &model.ItemMeta{UserID: 1, Kind: model.MetaKindData, ItemID: "10"})
+			require.ErrorIs(t, err, tt.wantErr)
+			require.Equal(t, tt.want, got)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestItemMetaRepo_List(t *testing.T) {
+	logg := logrus.New()
+	query := `SELECT item_id, fields, tags, description FROM item_meta WHERE user_id = \$1 AND kind = \$2`
+
+	t.Run("Success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(query).WithArgs(1, model.MetaKindFile).
+			WillReturnRows(sqlmock.NewRows([]string{"item_id", "fields", "tags", "description"}).
+				AddRow("a.txt", []byte(`{"k":"v"}`), []byte(`[]`), "").
+				AddRow("b.txt", []byte(`{}`), []byte(`["t1","t2"]`), "notes"))
+
+		r := NewItemMetaRepository(db, logg)
+		got, err := r.List(context.Background(), 1, model.MetaKindFile)
+		require.NoError(t, err)
+		require.Equal(t, map[string]model.ItemMeta{
+			"a.txt": {UserID: 1, Kind: model.MetaKindFile, ItemID: "a.txt", Fields: map[string]string{"k": "v"}},
+			"b.txt": {UserID: 1, Kind: model.MetaKindFile, ItemID: "b.txt", Tags: []string{"t1", "t2"}, Description: "notes"},
+		}, got)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("BadJSON", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(query).WithArgs(1, model.MetaKindFile).
+			WillReturnRows(sqlmock.NewRows([]string{"item_id", "fields", "tags", "description"}).
+				AddRow("a.txt", []byte(`not json`), []byte(`[]`), ""))
+
+		r := NewItemMetaRepository(db, logg)
+		_, err = r.List(context.Background(), 1, model.MetaKindFile)
+		require.Error(t, err)
+	})
+
+	t.Run("IterationError", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		rows := sqlmock.NewRows([]string{"item_id", "fields", "tags", "description"}).
+			AddRow("a.txt", []byte(`{}`), []byte(`[]`), "")
+		rows.RowError(0, fmt.Errorf("row iteration error"))
+		mock.ExpectQuery(query).WithArgs(1, model.MetaKindFile).WillReturnRows(rows)
+
+		r := NewItemMetaRepository(db, logg)
+		_, err = r.List(context.Background(), 1, model.MetaKindFile)
+		require.Error(t, err)
+	})
+}
+
+func TestItemMetaRepo_Delete(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec(`DELETE FROM item_meta WHERE user_id = \$1 AND kind = \$2 AND item_id = \$3`).
+		WithArgs(1, model.MetaKindFile, "a.txt").
+		WillReturnError(sql.ErrConnDone)
+
+	r := NewItemMetaRepository(db, logrus.New())
+	err = r.Delete(context.Background(), &model.ItemMeta{UserID: 1, Kind: model.MetaKindFile, ItemID: "a.txt"})
+	require.ErrorIs(t, err, sql.ErrConnDone)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestItemMetaRepo_Rename(t *testing.T) {
+	del := `DELETE FROM item_meta WHERE user_id = \$1 AND kind = \$2 AND item_id = \$3`
+	update := `UPDATE item_meta SET item_id = \$1 WHERE user_id = \$2 AND kind = \$3 AND item_id = \$4`
+
+	t.Run("Success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		// метаданные удаленного файла с новым именем заменяются
+		mock.ExpectExec(del).WithArgs(1, model.MetaKindFile, "work/a.txt").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(update).WithArgs("work/a.txt", 1, model.MetaKindFile, "a.txt").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		r := NewItemMetaRepository(db, logrus.New())
+		require.NoError(t, r.Rename(context.Background(), 1, model.MetaKindFile, "a.txt", "work/a.txt"))
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("UpdateError", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(del).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(update).WillReturnError(sql.ErrConnDone)
+		mock.ExpectRollback()
+
+		r := NewItemMetaRepository(db, logrus.New())
+		require.ErrorIs(t, r.Rename(context.Background(), 1, model.MetaKindFile, "a.txt", "work/a.txt"), sql.ErrConnDone)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
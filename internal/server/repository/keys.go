@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/hex"
+	"time"
+
+	"github.com/Arcadian-Sky/datakkeeper/internal/model"
+	"github.com/sirupsen/logrus"
+)
+
+type KeyRepository interface {
+	GetKeys(ctx context.Context, since time.Time) ([]model.SigningKey, error)
+	Rotate(ctx context.Context, key *model.SigningKey) error
+}
+
+type KeyRepo struct {
+	db  *sql.DB
+	log *logrus.Logger
+}
+
+func NewKeyRepository(dbk *sql.DB, lg *logrus.Logger) *KeyRepo {
+	return &KeyRepo{
+		db:  dbk,
+		log: lg,
+	}
+}
+
+// GetKeys returns active keys and keys retired after since, newest first
+func (k *KeyRepo) GetKeys(ctx context.Context, since time.Time) ([]model.SigningKey, error) {
+	query := `SELECT kid, secret, created_at, retired_at FROM jwt_key WHERE retired_at IS NULL OR retired_at > $1 ORDER BY created_at DESC`
+	rows, err := k.db.QueryContext(ctx, query, since.UTC())
+	if err != nil {
+		k.log.WithError(err).Error("Failed to get jwt keys")
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []model.SigningKey
+	for rows.Next() {
+		var key model.SigningKey
+		var secret string
+		var retired sql.NullTime
+		if err := rows.Scan(&key.ID, &secret, &key.CreatedAt, &retired); err != nil {
+			k.log.WithError(err).Error("Failed to scan jwt key")
+			return nil, err
+		}
+		key.Secret, err = hex.DecodeString(secret)
+		if err != nil {
+			return nil, err
+		}
+		if retired.Valid {
+			key.RetiredAt = retired.Time
+		}
+		keys = append(keys, key)
+	}
+
+	if err := rows.Err(); err != nil {
+		k.log.WithError(err).Error("Error while iterating rows")
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+// Rotate retires current keys and stores the new one in a single transaction
+func (k *KeyRepo) Rotate(ctx context.Context, key *model.SigningKey) error {
+	tx, err := k.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	now := time.Now().UTC()
+	_, err = tx.ExecContext(ctx, `UPDATE jwt_key SET retired_at = $1 WHERE retired_at IS NULL`, now)
+	if err != nil {
+		k.log.WithError(err).Error("Failed to retire jwt keys")
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `INSERT INTO jwt_key (kid, secret, created_at) VALUES ($1, $2, $3)`,
+		key.ID, hex.EncodeToString(key.Secret), now)
+	if err != nil {
+		k.log.WithError(err).Error("Failed to insert jwt key")
+		return err
+	}
+	key.CreatedAt = now
+
+	return tx.Commit()
+}
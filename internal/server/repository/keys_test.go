@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/Arcadian-Sky/datakkeeper/internal/model"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyRepo_GetKeys(t *testing.T) {
+	logg := logrus.New()
+	query := `SELECT kid, secret, created_at, retired_at FROM jwt_key WHERE retired_at IS NULL OR retired_at > \$1 ORDER BY created_at DESC`
+	created := time.Date(2024, 8, 1, 10, 0, 0, 0, time.UTC)
+	retired := created.Add(time.Hour)
+
+	tests := []struct {
+		name    string
+		mock    func(mock sqlmock.Sqlmock)
+		want    []model.SigningKey
+		wantErr bool
+	}{
+		{
+			name: "Success",
+			mock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"kid", "secret", "created_at", "retired_at"}).
+					AddRow("new", hex.EncodeToString([]byte("new-secret")), retired, nil).
+					AddRow("old", hex.EncodeToString([]byte("old-secret")), created, retired)
+				mock.ExpectQuery(query).WithArgs(sqlmock.AnyArg()).WillReturnRows(rows)
+			},
+			want: []model.SigningKey{
+				{ID: "new", Secret: []byte("new-secret"), CreatedAt: retired},
+				{ID: "old", Secret: []byte("old-secret"), CreatedAt: created, RetiredAt: retired},
+			},
+		},
+		{
+			name: "BadSecret",
+			mock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"kid", "secret", "created_at", "retired_at"}).
+					AddRow("new", "not-hex", created, nil)
+				mock.ExpectQuery(query).WithArgs(sqlmock.AnyArg()).WillReturnRows(rows)
+			},
+			wantErr: true,
+		},
+		{
+			name: "QueryError",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WithArgs(sqlmock.AnyArg()).WillReturnError(sql.ErrConnDone)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			tt.mock(mock)
+
+			r := NewKeyRepository(db, logg)
+
+			got, err := r.GetKeys(context.Background(), time.Now())
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestKeyRepo_Rotate(t *testing.T) {
+	logg := logrus.New()
+	key := &model.SigningKey{ID: "kid1", Secret: []byte("secret")}
+
+	t.Run("Success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE jwt_key SET retired_at = \$1 WHERE retired_at IS NULL`).
+			WithArgs(sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`INSERT INTO jwt_key \(kid, secret, created_at\) VALUES \(\$1, \$2, \$3\)`).
+			WithArgs("kid1", hex.EncodeToString([]byte("secret")), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		r := NewKeyRepository(db, logg)
+		require.NoError(t, r.Rotate(context.Background(), key))
+		require.False(t, key.CreatedAt.IsZero())
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("InsertError", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE jwt_key SET retired_at = \$1 WHERE retired_at IS NULL`).
+			WithArgs(sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`INSERT INTO jwt_key`).
+			WillReturnError(sql.ErrConnDone)
+		mock.ExpectRollback()
+
+		r := NewKeyRepository(db, logg)
+		require.ErrorIs(t, r.Rotate(context.Background(), key), sql.ErrConnDone)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,656 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Arcadian-Sky/datakkeeper/internal/model"
+	"github.com/minio/minio-go/v7"
+	"github.com/sirupsen/logrus"
+)
+
+// Служебные каталоги LocalFileRepo рядом с каталогами пользователей
+const (
+	localMetaDir = ".meta"
+	localTempDir = ".tmp"
+)
+
+// LocalFileRepo хранит файлы в каталогах пользователей на диске сервера: <dir>/bucketuid<id>/<имя файла>.
+// Загрузка пишется во временный файл и переименовывается, читающий видит прежнее или новое содержимое целиком.
+// Прежние версии файлов не хранятся, текущая версия - единственная. Дедупликация не поддерживается.
+type LocalFileRepo struct {
+	dir string
+	log *logrus.Logger
+}
+
+// localMeta - то, что MinIO хранит в метаданных объекта, см. model.FileItem
+type localMeta struct {
+	ContentType string `json:"content_type"`
+	SHA256      string `json:"sha256"`
+	Device      string `json:"device"`
+}
+
+func NewLocalFileRepository(dir string, lg *logrus.Logger) *LocalFileRepo {
+	return &LocalFileRepo{
+		dir: dir,
+		log: lg,
+	}
+}
+
+// Init creates the storage directory if it's missing
+func (l *LocalFileRepo) Init() error {
+	if err := os.MkdirAll(l.dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create storage directory: %w", err)
+	}
+	return nil
+}
+
+func (l *LocalFileRepo) CreateContainer(ctx context.Context, user *model.User) (model.User, error) {
+	if user.ID == 0 {
+		return *user, model.ErrCreateBucketNoUser
+	}
+	bucketName := "bucketuid" + strconv.Itoa(int(user.ID))
+
+	if err := l.Init(); err != nil {
+		l.log.WithError(err).Error("Failed to create bucket ", bucketName)
+		return *user, model.ErrCreateBucketFailed
+	}
+	if err := os.Mkdir(filepath.Join(l.dir, bucketName), 0o700); err != nil {
+		if errors.Is(err, fs.ErrExist) {
+			l.log.Error("LocalFileRepo: Bucket " + bucketName + " already exists")
+			return *user, model.ErrCreateBucketExists
+		}
+		l.log.WithError(err).Error("Failed to create bucket ", bucketName)
+		return *user, model.ErrCreateBucketFailed
+	}
+	user.Bucket = bucketName
+	return *user, nil
+}
+
+func (l *LocalFileRepo) GetFile(ctx context.Context, fileName string, user *model.User) (io.ReadSeekCloser, error) {
+	return l.GetFileVersion(ctx, fileName, "", user)
+}
+
+// GetFileVersion opens the file, versionID must be empty or the one of the current version
+func (l *LocalFileRepo) GetFileVersion(ctx context.Context, fileName, versionID string, user *model.User) (io.ReadSeekCloser, error) {
+	if user.ID == 0 {
+		return nil, model.ErrCreateBucketNoUser
+	}
+	name, err := l.path(user, fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(name)
+	if err != nil {
+		if versionID != "" && errors.Is(err, fs.ErrNotExist) {
+			return nil, model.ErrFileVersionMissing
+		}
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	info, err := file.Stat()
+	if err == nil && info.IsDir() {
+		err = fmt.Errorf("%s is a folder", fileName)
+	}
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	if versionID != "" && versionID != localVersion(info) {
+		file.Close()
+		return nil, model.ErrFileVersionMissing
+	}
+	return file, nil
+}
+
+// DeleteFile moves the file to the trash, it can be restored until PurgeFile.
+// ErrFileNotFound, если файла нет.
+func (l *LocalFileRepo) DeleteFile(ctx context.Context, fileName string, user *model.User) error {
+	if err := l.rename(user, fileName, model.TrashPrefix+fileName); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return model.ErrFileNotFound
+		}
+		return fmt.Errorf("failed to delete file: %w", err)
+	}
+	return nil
+}
+
+// RestoreFile moves the file back from the trash, ErrTrashItemNotFound if it's not there.
+// ErrFileExists, если имя занято файлом или папкой, загруженными после удаления.
+func (l *LocalFileRepo) RestoreFile(ctx context.Context, fileName string, user *model.User) error {
+	src, err := l.path(user, model.TrashPrefix+fileName)
+	if err != nil {
+		return err
+	}
+	dst, err := l.path(user, fileName)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(src); errors.Is(err, fs.ErrNotExist) {
+		return model.ErrTrashItemNotFound
+	}
+	if _, err := os.Stat(dst); !errors.Is(err, fs.ErrNotExist) {
+		if err != nil {
+			return fmt.Errorf("failed to restore file: %w", err)
+		}
+		return model.ErrFileExists
+	}
+	if err := l.rename(user, model.TrashPrefix+fileName, fileName); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return model.ErrTrashItemNotFound
+		}
+		return fmt.Errorf("failed to restore file: %w", err)
+	}
+	return nil
+}
+
+// PurgeFile removes the trash copy of the file for good
+func (l *LocalFileRepo) PurgeFile(ctx context.Context, fileName string, user *model.User) error {
+	if err := l.remove(user, model.TrashPrefix+fileName); err != nil {
+		return fmt.Errorf("failed to purge file: %w", err)
+	}
+	return nil
+}
+
+// UploadFile writes the content of r to a temporary file and renames it to file.Name, file.Size -1 if it's unknown.
+// Новый файл с тем же именем убирает его из корзины.
+func (l *LocalFileRepo) UploadFile(ctx context.Context, user *model.User, file *model.FileItem, r io.Reader) error {
+	if user.ID <= 0 {
+		return model.ErrNoUserBucket
+	}
+	name, err := l.path(user, file.Name)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := l.writeTemp(r, file.Size)
+	if err != nil {
+		return fmt.Errorf("failed to upload file: %w", err)
+	}
+	defer os.Remove(tmp)
+	if err := os.MkdirAll(filepath.Dir(name), 0o700); err != nil {
+		return fmt.Errorf("failed to upload file: %w", err)
+	}
+	if err := os.Rename(tmp, name); err != nil {
+		return fmt.Errorf("failed to upload file: %w", err)
+	}
+
+	meta := localMeta{ContentType: fileContentType(file.Name, file.ContentType), SHA256: file.SHA256, Device: file.Device}
+	if err := l.writeMeta(user, file.Name, meta); err != nil {
+		l.log.Info("LocalFileRepo: failed to save file metadata: ", err)
+	}
+	if err := l.remove(user, model.TrashPrefix+file.Name); err != nil {
+		l.log.Info("LocalFileRepo: failed to remove trash copy: ", err)
+	}
+	return nil
+}
+
+// writeTemp writes r to a new file in the temporary directory and returns its path
+func (l *LocalFileRepo) writeTemp(r io.Reader, size int64) (string, error) {
+	dir := filepath.Join(l.dir, localTempDir)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", err
+	}
+	tmp, err := os.CreateTemp(dir, "upload-*")
+	if err != nil {
+		return "", err
+	}
+	n, err := io.Copy(tmp, r)
+	if err == nil && size >= 0 && n != size {
+		err = fmt.Errorf("received %d of %d bytes", n, size)
+	}
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return "", err
+	}
+	return tmp.Name(), nil
+}
+
+// ListFileVersions returns the current version of the file, previous versions are not kept
+func (l *LocalFileRepo) ListFileVersions(ctx context.Context, fileName string, user *model.User) ([]model.FileVersion, error) {
+	if user.ID <= 0 {
+		return nil, model.ErrCreateBucketNoUser
+	}
+	name, err := l.path(user, fileName)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return []model.FileVersion{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list file versions: %w", err)
+	}
+	if info.IsDir() {
+		return []model.FileVersion{}, nil
+	}
+	return []model.FileVersion{{
+		VersionID:  localVersion(info),
+		Hash:       l.readMeta(user, fileName).SHA256,
+		Size:       info.Size(),
+		ModifiedAt: info.ModTime(),
+		Latest:     true,
+	}}, nil
+}
+
+// RestoreFileVersion accepts only the current version, there is nothing to restore
+func (l *LocalFileRepo) RestoreFileVersion(ctx context.Context, fileName, versionID string, user *model.User) error {
+	versions, err := l.ListFileVersions(ctx, fileName, user)
+	if err != nil {
+		return err
+	}
+	if len(versions) == 0 || versions[0].VersionID != versionID {
+		return model.ErrFileVersionMissing
+	}
+	return nil
+}
+
+// CreateFolder creates the directory of the folder, ErrFileExists if there is a file with this name
+func (l *LocalFileRepo) CreateFolder(ctx context.Context, folder string, user *model.User) error {
+	if user.ID <= 0 {
+		return model.ErrNoUserBucket
+	}
+	name, err := l.path(user, folder)
+	if err != nil {
+		return err
+	}
+	if info, err := os.Stat(name); err == nil && !info.IsDir() {
+		return model.ErrFileExists
+	}
+	if err := os.MkdirAll(name, 0o700); err != nil {
+		return fmt.Errorf("failed to create folder: %w", err)
+	}
+	return nil
+}
+
+// MoveFile renames the file or the folder with all its content and returns moved files, empty folders
+// are returned as folder keys like in FileRepo. Каталог переносится одним переименованием.
+// ErrFileNotFound, если переносить нечего, ErrFileExists, если новое имя занято.
+func (l *LocalFileRepo) MoveFile(ctx context.Context, from, to string, user *model.User) ([]model.FileMove, error) {
+	if user.ID <= 0 {
+		return nil, model.ErrNoUserBucket
+	}
+	src, err := l.path(user, from)
+	if err != nil {
+		return nil, err
+	}
+	dst, err := l.path(user, to)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(src); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, model.ErrFileNotFound
+		}
+		return nil, fmt.Errorf("failed to move file: %w", err)
+	}
+	if _, err := os.Stat(dst); !errors.Is(err, fs.ErrNotExist) {
+		if err != nil {
+			return nil, fmt.Errorf("failed to move file: %w", err)
+		}
+		return nil, model.ErrFileExists
+	}
+
+	keys, err := l.keys(src, from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to move file: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0o700); err != nil {
+		return nil, fmt.Errorf("failed to move file: %w", err)
+	}
+	if err := os.Rename(src, dst); err != nil {
+		return nil, fmt.Errorf("failed to move file: %w", err)
+	}
+
+	moves := make([]model.FileMove, 0, len(keys))
+	for _, key := range keys {
+		move := model.FileMove{From: key, To: to + strings.TrimPrefix(key, from)}
+		moves = append(moves, move)
+		if model.IsFolderKey(key) {
+			continue
+		}
+		if err := l.moveMeta(user, move.From, move.To); err != nil {
+			l.log.Info("LocalFileRepo: failed to move file metadata: ", err)
+		}
+		// перенесенный файл заменяет удаленный с тем же именем, как и новая загрузка
+		if err := l.remove(user, model.TrashPrefix+move.To); err != nil {
+			l.log.Info("LocalFileRepo: failed to remove trash copy: ", err)
+		}
+	}
+	return moves, nil
+}
+
+// keys returns the key of the file or keys of files and empty folders inside the folder name
+func (l *LocalFileRepo) keys(path, name string) ([]string, error) {
+	var keys []string
+	err := filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		key := name + filepath.ToSlash(strings.TrimPrefix(p, path))
+		if !d.IsDir() {
+			keys = append(keys, key)
+			return nil
+		}
+		entries, err := os.ReadDir(p)
+		if err != nil {
+			return err
+		}
+		if len(entries) == 0 {
+			keys = append(keys, key+model.FolderSeparator)
+		}
+		return nil
+	})
+	return keys, err
+}
+
+// Usage returns size and count of stored files including the trash
+func (l *LocalFileRepo) Usage(ctx context.Context, user *model.User) (model.Usage, error) {
+	if user.ID <= 0 {
+		return model.Usage{}, model.ErrNoUserBucket
+	}
+	var usage model.Usage
+	err := filepath.WalkDir(l.bucketDir(user), func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		usage.Bytes += info.Size()
+		usage.Files++
+		return nil
+	})
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return model.Usage{}, fmt.Errorf("failed to get usage: %w", err)
+	}
+	return usage, nil
+}
+
+// ExpireVersions does nothing, previous versions are not kept
+func (l *LocalFileRepo) ExpireVersions(ctx context.Context, now time.Time) error {
+	return nil
+}
+
+// MovedUsage returns the part of Usage added by moving from, файлы переименовываются без копий
+func (l *LocalFileRepo) MovedUsage(ctx context.Context, from string, user *model.User) (model.Usage, error) {
+	if user.ID <= 0 {
+		return model.Usage{}, model.ErrNoUserBucket
+	}
+	return model.Usage{}, nil
+}
+
+// ReplacedUsage returns the part of Usage released when a new file is uploaded as fileName,
+// версий нет, поэтому освобождается и место текущего файла, и его копии в корзине
+func (l *LocalFileRepo) ReplacedUsage(ctx context.Context, fileName string, user *model.User) (model.Usage, error) {
+	if user.ID <= 0 {
+		return model.Usage{}, model.ErrNoUserBucket
+	}
+	var usage model.Usage
+	for _, key := range []string{fileName, model.TrashPrefix + fileName} {
+		name, err := l.path(user, key)
+		if err != nil {
+			return model.Usage{}, err
+		}
+		info, err := os.Stat(name)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return model.Usage{}, fmt.Errorf("failed to get usage: %w", err)
+		}
+		if info.IsDir() {
+			continue
+		}
+		usage.Bytes += info.Size()
+		usage.Files++
+	}
+	return usage, nil
+}
+
+// GetFileList returns a page of files matching the query like FileRepo.GetFileList.
+// Каталог читается целиком и сортируется по именам, как листинг MinIO.
+func (l *LocalFileRepo) GetFileList(ctx context.Context, user *model.User, q model.ListQuery, meta map[string]model.ItemMeta) ([]model.FileItem, *model.ListCursor, error) {
+	if user.ID <= 0 {
+		return nil, nil, model.ErrCreateBucketNoUser
+	}
+
+	prefix := ""
+	var objects []minio.ObjectInfo
+	var err error
+	if q.Browse {
+		prefix = model.FolderPrefix(q.Parent)
+		objects, err = l.listFolder(user, prefix)
+	} else {
+		objects, err = l.listAll(user)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list files: %w", err)
+	}
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Key < objects[j].Key })
+
+	objectCh := make(chan minio.ObjectInfo, len(objects))
+	for _, object := range objects {
+		if q.After != nil && !q.Desc && object.Key <= q.After.Key {
+			continue
+		}
+		objectCh <- object
+	}
+	close(objectCh)
+
+	return listPage(objectCh, q, prefix, meta, l.log)
+}
+
+// listAll returns all files of the user except the trash
+func (l *LocalFileRepo) listAll(user *model.User) ([]minio.ObjectInfo, error) {
+	root := l.bucketDir(user)
+	var objects []minio.ObjectInfo
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(strings.TrimPrefix(p, root+string(filepath.Separator)))
+		if d.IsDir() {
+			if model.IsTrashName(key + model.FolderSeparator) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		objects = append(objects, l.object(user, key, info))
+		return nil
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	return objects, err
+}
+
+// listFolder returns files and folders directly inside the folder prefix, folders have keys with trailing "/"
+func (l *LocalFileRepo) listFolder(user *model.User, prefix string) ([]minio.ObjectInfo, error) {
+	dir, err := l.path(user, prefix)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	objects := make([]minio.ObjectInfo, 0, len(entries))
+	for _, entry := range entries {
+		key := prefix + entry.Name()
+		if entry.IsDir() {
+			objects = append(objects, minio.ObjectInfo{Key: key + model.FolderSeparator})
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, l.object(user, key, info))
+	}
+	return objects, nil
+}
+
+// object describes the file like the listed MinIO object, so FileRepo listing rules apply to it
+func (l *LocalFileRepo) object(user *model.User, key string, info fs.FileInfo) minio.ObjectInfo {
+	meta := l.readMeta(user, key)
+	return minio.ObjectInfo{
+		Key:          key,
+		ETag:         meta.SHA256,
+		Size:         info.Size(),
+		LastModified: info.ModTime(),
+		ContentType:  fileContentType(key, meta.ContentType),
+		VersionID:    localVersion(info),
+		UserMetadata: map[string]string{
+			objectMetaSHA256: meta.SHA256,
+			objectMetaDevice: url.QueryEscape(meta.Device),
+		},
+	}
+}
+
+// localVersion - версия файла на диске, меняется с каждой загрузкой
+func localVersion(info fs.FileInfo) string {
+	return strconv.FormatInt(info.ModTime().UnixNano(), 10)
+}
+
+func (l *LocalFileRepo) bucketDir(user *model.User) string {
+	return filepath.Join(l.dir, "bucketuid"+strconv.Itoa(int(user.ID)))
+}
+
+// path returns the path of the key in the user directory, keys leading out of it are invalid
+func (l *LocalFileRepo) path(user *model.User, key string) (string, error) {
+	root := l.bucketDir(user)
+	name := filepath.Join(root, filepath.FromSlash(key))
+	if name != root && !strings.HasPrefix(name, root+string(filepath.Separator)) {
+		return "", fmt.Errorf("%w: %q", model.ErrFileNameInvalid, key)
+	}
+	return name, nil
+}
+
+// rename moves the file with its metadata, the file at to is replaced. Папки так не переносятся, см. MoveFile.
+func (l *LocalFileRepo) rename(user *model.User, from, to string) error {
+	src, err := l.path(user, from)
+	if err != nil {
+		return err
+	}
+	dst, err := l.path(user, to)
+	if err != nil {
+		return err
+	}
+	info, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		return fmt.Errorf("%s is a folder", from)
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0o700); err != nil {
+		return err
+	}
+	if err := os.Rename(src, dst); err != nil {
+		return err
+	}
+	if err := l.moveMeta(user, from, to); err != nil {
+		l.log.Info("LocalFileRepo: failed to move file metadata: ", err)
+	}
+	return nil
+}
+
+// remove deletes the file with its metadata, a missing file is not an error
+func (l *LocalFileRepo) remove(user *model.User, key string) error {
+	name, err := l.path(user, key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(name); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	if err := os.Remove(l.metaPath(user, key)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// metaPath - метаданные лежат отдельно от файлов пользователя под хешем имени файла
+func (l *LocalFileRepo) metaPath(user *model.User, key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(l.dir, localMetaDir, filepath.Base(l.bucketDir(user)), hex.EncodeToString(sum[:])+".json")
+}
+
+// readMeta returns metadata of the file, empty if it's missing
+func (l *LocalFileRepo) readMeta(user *model.User, key string) localMeta {
+	var meta localMeta
+	data, err := os.ReadFile(l.metaPath(user, key))
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			l.log.Info("LocalFileRepo: failed to read file metadata: ", err)
+		}
+		return meta
+	}
+	if err := json.Unmarshal(data, &meta); err != nil {
+		l.log.Info("LocalFileRepo: failed to decode file metadata: ", err)
+	}
+	return meta
+}
+
+func (l *LocalFileRepo) writeMeta(user *model.User, key string, meta localMeta) error {
+	data, err := json.Marshal(meta)
+	if err != nil {
+		return err
+	}
+	name := l.metaPath(user, key)
+	if err := os.MkdirAll(filepath.Dir(name), 0o700); err != nil {
+		return err
+	}
+	tmp, err := l.writeTemp(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp)
+	return os.Rename(tmp, name)
+}
+
+func (l *LocalFileRepo) moveMeta(user *model.User, from, to string) error {
+	err := os.Rename(l.metaPath(user, from), l.metaPath(user, to))
+	if errors.Is(err, fs.ErrNotExist) {
+		// у файла без метаданных не должно остаться чужих
+		err = os.Remove(l.metaPath(user, to))
+	}
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,246 @@
+package repository
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Arcadian-Sky/datakkeeper/internal/model"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLocalRepo(t *testing.T) (*LocalFileRepo, *model.User) {
+	l := NewLocalFileRepository(t.TempDir(), logrus.New())
+	user := &model.User{ID: 1}
+	_, err := l.CreateContainer(context.Background(), user)
+	require.NoError(t, err)
+	return l, user
+}
+
+func uploadLocal(t *testing.T, l *LocalFileRepo, user *model.User, name, content string) {
+	file := &model.FileItem{Name: name, Size: int64(len(content)), SHA256: "sum-" + name, Device: "my phone"}
+	require.NoError(t, l.UploadFile(context.Background(), user, file, strings.NewReader(content)))
+}
+
+func readLocal(t *testing.T, l *LocalFileRepo, user *model.User, name string) string {
+	file, err := l.GetFile(context.Background(), name, user)
+	require.NoError(t, err)
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestLocalFileRepo_CreateContainer(t *testing.T) {
+	l, user := newTestLocalRepo(t)
+	require.Equal(t, "bucketuid1", user.Bucket)
+	require.DirExists(t, filepath.Join(l.dir, "bucketuid1"))
+
+	_, err := l.CreateContainer(context.Background(), user)
+	require.ErrorIs(t, err, model.ErrCreateBucketExists)
+	_, err = l.CreateContainer(context.Background(), &model.User{})
+	require.ErrorIs(t, err, model.ErrCreateBucketNoUser)
+}
+
+func TestLocalFileRepo_UploadFile(t *testing.T) {
+	l, user := newTestLocalRepo(t)
+
+	uploadLocal(t, l, user, "docs/a.txt", "first")
+	require.Equal(t, "first", readLocal(t, l, user, "docs/a.txt"))
+	require.FileExists(t, filepath.Join(l.dir, "bucketuid1", "docs", "a.txt"))
+
+	uploadLocal(t, l, user, "docs/a.txt", "second")
+	require.Equal(t, "second", readLocal(t, l, user, "docs/a.txt"))
+
+	// недогруженное содержимое не заменяет файл
+	short := &model.FileItem{Name: "docs/a.txt", Size: 100}
+	require.Error(t, l.UploadFile(context.Background(), user, short, strings.NewReader("third")))
+	require.Equal(t, "second", readLocal(t, l, user, "docs/a.txt"))
+	entries, err := os.ReadDir(filepath.Join(l.dir, localTempDir))
+	require.NoError(t, err)
+	require.Empty(t, entries)
+
+	require.ErrorIs(t, l.UploadFile(context.Background(), &model.User{}, short, nil), model.ErrNoUserBucket)
+	_, err = l.GetFile(context.Background(), "missing.txt", user)
+	require.Error(t, err)
+	_, err = l.GetFile(context.Background(), "../bucketuid2/a.txt", user)
+	require.ErrorIs(t, err, model.ErrFileNameInvalid)
+}
+
+func TestLocalFileRepo_GetFileList(t *testing.T) {
+	l, user := newTestLocalRepo(t)
+	uploadLocal(t, l, user, "b.txt", "bb")
+	uploadLocal(t, l, user, "a.txt", "a")
+	uploadLocal(t, l, user, "docs/c.txt", "ccc")
+	require.NoError(t, l.CreateFolder(context.Background(), "empty", user))
+	require.NoError(t, l.DeleteFile(context.Background(), "b.txt", user))
+	uploadLocal(t, l, user, "b.txt", "bb")
+	require.NoError(t, l.DeleteFile(context.Background(), "a.txt", user))
+
+	meta := map[string]model.ItemMeta{"b.txt": {Description: "note"}}
+	files, cursor, err := l.GetFileList(context.Background(), user, model.ListQuery{}, meta)
+	require.NoError(t, err)
+	require.Nil(t, cursor)
+	require.Len(t, files, 2)
+	require.Equal(t, "b.txt", files[0].Name)
+	require.Equal(t, int64(2), files[0].Size)
+	require.Equal(t, "note", files[0].Desc)
+	require.Equal(t, "sum-b.txt", files[0].SHA256)
+	require.Equal(t, "my phone", files[0].Device)
+	require.Equal(t, "text/plain; charset=utf-8", files[0].ContentType)
+	require.Equal(t, "docs/c.txt", files[1].Name)
+
+	// страницы по одному файлу
+	files, cursor, err = l.GetFileList(context.Background(), user, model.ListQuery{Limit: 1}, nil)
+	require.NoError(t, err)
+	require.Equal(t, "b.txt", files[0].Name)
+	require.NotNil(t, cursor)
+	files, cursor, err = l.GetFileList(context.Background(), user, model.ListQuery{Limit: 1, After: cursor}, nil)
+	require.NoError(t, err)
+	require.Equal(t, "docs/c.txt", files[0].Name)
+	require.Nil(t, cursor)
+
+	files, _, err = l.GetFileList(context.Background(), user, model.ListQuery{Browse: true}, nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"b.txt", "docs", "empty"}, fileNames(files))
+	require.True(t, files[1].IsFolder)
+
+	files, _, err = l.GetFileList(context.Background(), user, model.ListQuery{Browse: true, Parent: "docs"}, nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"docs/c.txt"}, fileNames(files))
+}
+
+func fileNames(files []model.FileItem) []string {
+	names := make([]string, 0, len(files))
+	for _, file := range files {
+		names = append(names, file.Name)
+	}
+	return names
+}
+
+func TestLocalFileRepo_Trash(t *testing.T) {
+	l, user := newTestLocalRepo(t)
+	uploadLocal(t, l, user, "a.txt", "a")
+
+	require.NoError(t, l.DeleteFile(context.Background(), "a.txt", user))
+	_, err := l.GetFile(context.Background(), "a.txt", user)
+	require.Error(t, err)
+	require.ErrorIs(t, l.DeleteFile(context.Background(), "a.txt", user), model.ErrFileNotFound)
+
+	require.NoError(t, l.RestoreFile(context.Background(), "a.txt", user))
+	require.Equal(t, "a", readLocal(t, l, user, "a.txt"))
+	require.ErrorIs(t, l.RestoreFile(context.Background(), "a.txt", user), model.ErrTrashItemNotFound)
+
+	require.NoError(t, l.DeleteFile(context.Background(), "a.txt", user))
+	require.NoError(t, l.PurgeFile(context.Background(), "a.txt", user))
+	require.ErrorIs(t, l.RestoreFile(context.Background(), "a.txt", user), model.ErrTrashItemNotFound)
+	require.NoError(t, l.PurgeFile(context.Background(), "a.txt", user))
+
+	// новая загрузка убирает удаленный файл из корзины
+	uploadLocal(t, l, user, "b.txt", "b")
+	require.NoError(t, l.DeleteFile(context.Background(), "b.txt", user))
+	uploadLocal(t, l, user, "b.txt", "new")
+	require.ErrorIs(t, l.RestoreFile(context.Background(), "b.txt", user), model.ErrTrashItemNotFound)
+
+	// загруженный после удаления файл не перезаписывается
+	uploadLocal(t, l, user, "c.txt", "c")
+	require.NoError(t, l.DeleteFile(context.Background(), "c.txt", user))
+	require.NoError(t, os.MkdirAll(filepath.Join(l.bucketDir(user), "c.txt"), 0o700))
+	require.ErrorIs(t, l.RestoreFile(context.Background(), "c.txt", user), model.ErrFileExists)
+}
+
+func TestLocalFileRepo_Versions(t *testing.T) {
+	l, user := newTestLocalRepo(t)
+	uploadLocal(t, l, user, "a.txt", "abc")
+
+	versions, err := l.ListFileVersions(context.Background(), "a.txt", user)
+	require.NoError(t, err)
+	require.Len(t, versions, 1)
+	require.True(t, versions[0].Latest)
+	require.Equal(t, int64(3), versions[0].Size)
+	require.Equal(t, "sum-a.txt", versions[0].Hash)
+
+	file, err := l.GetFileVersion(context.Background(), "a.txt", versions[0].VersionID, user)
+	require.NoError(t, err)
+	file.Close()
+	_, err = l.GetFileVersion(context.Background(), "a.txt", "old", user)
+	require.ErrorIs(t, err, model.ErrFileVersionMissing)
+
+	require.NoError(t, l.RestoreFileVersion(context.Background(), "a.txt", versions[0].VersionID, user))
+	require.ErrorIs(t, l.RestoreFileVersion(context.Background(), "a.txt", "old", user), model.ErrFileVersionMissing)
+
+	versions, err = l.ListFileVersions(context.Background(), "missing.txt", user)
+	require.NoError(t, err)
+	require.Empty(t, versions)
+}
+
+func TestLocalFileRepo_MoveFile(t *testing.T) {
+	l, user := newTestLocalRepo(t)
+	uploadLocal(t, l, user, "docs/a.txt", "a")
+	uploadLocal(t, l, user, "docs/sub/b.txt", "b")
+	require.NoError(t, l.CreateFolder(context.Background(), "docs/empty", user))
+	uploadLocal(t, l, user, "c.txt", "c")
+
+	require.ErrorIs(t, l.CreateFolder(context.Background(), "c.txt", user), model.ErrFileExists)
+
+	moves, err := l.MoveFile(context.Background(), "docs", "archive/docs", user)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []model.FileMove{
+		{From: "docs/a.txt", To: "archive/docs/a.txt"},
+		{From: "docs/empty/", To: "archive/docs/empty/"},
+		{From: "docs/sub/b.txt", To: "archive/docs/sub/b.txt"},
+	}, moves)
+	require.Equal(t, "b", readLocal(t, l, user, "archive/docs/sub/b.txt"))
+
+	// метаданные переезжают вместе с файлом
+	files, _, err := l.GetFileList(context.Background(), user, model.ListQuery{Browse: true, Parent: "archive/docs"}, nil)
+	require.NoError(t, err)
+	require.Equal(t, "sum-docs/a.txt", files[0].SHA256)
+
+	moves, err = l.MoveFile(context.Background(), "c.txt", "d.txt", user)
+	require.NoError(t, err)
+	require.Equal(t, []model.FileMove{{From: "c.txt", To: "d.txt"}}, moves)
+
+	_, err = l.MoveFile(context.Background(), "c.txt", "e.txt", user)
+	require.ErrorIs(t, err, model.ErrFileNotFound)
+	_, err = l.MoveFile(context.Background(), "d.txt", "archive", user)
+	require.ErrorIs(t, err, model.ErrFileExists)
+}
+
+func TestLocalFileRepo_Usage(t *testing.T) {
+	l, user := newTestLocalRepo(t)
+	uploadLocal(t, l, user, "a.txt", "abc")
+	uploadLocal(t, l, user, "docs/b.txt", "de")
+	require.NoError(t, l.DeleteFile(context.Background(), "a.txt", user))
+
+	usage, err := l.Usage(context.Background(), user)
+	require.NoError(t, err)
+	require.Equal(t, model.Usage{Bytes: 5, Files: 2}, usage)
+
+	usage, err = l.Usage(context.Background(), &model.User{ID: 2})
+	require.NoError(t, err)
+	require.Equal(t, model.Usage{}, usage)
+}
+
+func TestLocalFileRepo_ReplacedUsage(t *testing.T) {
+	l, user := newTestLocalRepo(t)
+	uploadLocal(t, l, user, "a.txt", "abc")
+	uploadLocal(t, l, user, "docs/b.txt", "de")
+	require.NoError(t, l.DeleteFile(context.Background(), "docs/b.txt", user))
+
+	usage, err := l.ReplacedUsage(context.Background(), "a.txt", user)
+	require.NoError(t, err)
+	require.Equal(t, model.Usage{Bytes: 3, Files: 1}, usage)
+
+	usage, err = l.ReplacedUsage(context.Background(), "docs/b.txt", user)
+	require.NoError(t, err)
+	require.Equal(t, model.Usage{Bytes: 2, Files: 1}, usage)
+
+	usage, err = l.ReplacedUsage(context.Background(), "docs", user)
+	require.NoError(t, err)
+	require.Equal(t, model.Usage{}, usage)
+}
@@ -3,6 +3,12 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/Arcadian-Sky/datakkeeper/internal/model"
 	"github.com/sirupsen/logrus"
@@ -11,11 +17,39 @@ import (
 var (
 	DataTypeCARD    = "CARD"
 	DataTypeLOGPASS = "LOGPASS"
+	DataTypeTEXT    = "TEXT"
+	DataTypeBINARY  = "BINARY"
 )
 
+// dataColumns - общие для чтения колонки metadata, порядок совпадает с dataFields
+const dataColumns = `id, dtype, title, card_number, login, password, note, key_hash,
+	card_holder, card_exp_month, card_exp_year, card_cvv, card_bank, card_pin, card_brand, revision`
+
+// dataFields returns pointers to data fields for scanning dataColumns
+func dataFields(data *model.Data) []any {
+	return []any{
+		&data.ID, &data.Type, &data.Title, &data.Card, &data.Login, &data.Password, &data.Note, &data.KeyHash,
+		&data.CardHolder, &data.CardExpMonth, &data.CardExpYear, &data.CardCVV, &data.CardBank, &data.CardPIN, &data.CardBrand,
+		&data.Revision,
+	}
+}
+
+// nextRevision - CTE, увеличивающий счетчик ревизий пользователя, строка пользователя блокируется
+// до конца транзакции, поэтому ревизии одного пользователя фиксируются по порядку
+const nextRevision = `WITH rev AS (UPDATE "user" SET revision = revision + 1 WHERE id = $%d RETURNING revision) `
+
 type DataRepository interface {
 	Save(ctx context.Context, data *model.Data) (int64, error)
-	GetList(ctx context.Context, user *model.User) ([]model.Data, error)
+	GetList(ctx context.Context, user *model.User, query model.ListQuery) ([]model.Data, *model.ListCursor, error)
+	Get(ctx context.Context, data *model.Data) (*model.Data, error)
+	Update(ctx context.Context, data *model.Data) error
+	Delete(ctx context.Context, data *model.Data) error
+	History(ctx context.Context, data *model.Data) ([]model.DataRevision, error)
+	Restore(ctx context.Context, data *model.Data, revision int64) error
+	ListTrash(ctx context.Context, userID int64) ([]model.TrashItem, error)
+	RestoreDeleted(ctx context.Context, data *model.Data) error
+	Purge(ctx context.Context, before time.Time) ([]model.PurgedItem, error)
+	Count(ctx context.Context, userID int64) (int64, error)
 }
 
 type DataRepo struct {
@@ -31,39 +65,434 @@ func NewDataRepository(dbd *sql.DB, lg *logrus.Logger) *DataRepo {
 	return p
 }
 
+// Save inserts the record with the next revision of the user
 func (d *DataRepo) Save(ctx context.Context, data *model.Data) (int64, error) {
-	// Insert new user
-	insertQuery := `INSERT INTO "metadata" (dtype, user_id, title, card_number, login, password) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
-	err := d.db.QueryRowContext(ctx, insertQuery, data.Type, data.UserID, data.Title, data.Card, data.Login, data.Password).Scan(&data.ID)
+	insertQuery := fmt.Sprintf(nextRevision, 2) + `INSERT INTO "metadata" (dtype, user_id, title, card_number, login, password, note, bin_data, key_hash,
+		card_holder, card_exp_month, card_exp_year, card_cvv, card_bank, card_pin, card_brand, revision)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, (SELECT revision FROM rev)) RETURNING id, revision`
+	err := d.db.QueryRowContext(ctx, insertQuery, data.Type, data.UserID, data.Title, data.Card, data.Login, data.Password, data.Note, data.Binary, data.KeyHash,
+		data.CardHolder, data.CardExpMonth, data.CardExpYear, data.CardCVV, data.CardBank, data.CardPIN, data.CardBrand).Scan(&data.ID, &data.Revision)
 	if err != nil {
 		return 0, err
 	}
 	return data.ID, nil
 }
 
-func (d *DataRepo) GetList(ctx context.Context, user *model.User) ([]model.Data, error) {
-	query := `SELECT id, dtype, title, card_number, login, password FROM metadata WHERE user_id = $1 ORDER BY id`
-	rows, err := d.db.QueryContext(ctx, query, user.ID)
+// GetList returns a page of records without binary content, it's loaded by Get.
+// Next cursor is nil on the last page.
+func (d *DataRepo) GetList(ctx context.Context, user *model.User, q model.ListQuery) ([]model.Data, *model.ListCursor, error) {
+	return d.list(ctx, user, q, pgListDialect)
+}
+
+func (d *DataRepo) list(ctx context.Context, user *model.User, q model.ListQuery, dl listDialect) ([]model.Data, *model.ListCursor, error) {
+	limit := q.Limit
+	if limit <= 0 {
+		limit = model.DefaultPageSize
+	}
+	query, args := dataListQuery(user.ID, q, limit, dl)
+	rows, err := d.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		d.log.WithError(err).Error("Failed to get metadata")
-		return nil, err
+		return nil, nil, err
 	}
 	defer rows.Close()
 
 	var datalist []model.Data
 	for rows.Next() {
 		var data model.Data
-		if err := rows.Scan(&data.ID, &data.Type, &data.Title, &data.Card, &data.Login, &data.Password); err != nil {
+		if err := rows.Scan(dataFields(&data)...); err != nil {
 			d.log.WithError(err).Error("Failed to scan data")
-			return nil, err
+			return nil, nil, err
 		}
 		datalist = append(datalist, data)
 	}
 
+	if err := rows.Err(); err != nil {
+		d.log.WithError(err).Error("Error while iterating rows")
+		return nil, nil, err
+	}
+
+	// запрашивается на одну строку больше, лишняя строка значит, что есть следующая страница
+	if len(datalist) <= limit {
+		return datalist, nil, nil
+	}
+	datalist = datalist[:limit]
+	last := datalist[limit-1]
+	next := &model.ListCursor{ID: last.ID}
+	if q.Sort == model.ListSortTitle {
+		next.Key = last.Title
+	}
+	return datalist, next, nil
+}
+
+// listDialect - части запроса списка записей, которые postgres и SQLite пишут по-разному
+type listDialect struct {
+	param  string // префикс нумерованного параметра
+	itemID string // id записи m как item_id метаданных
+	// hasTags - условие, что у метаданных im есть все теги
+	hasTags func(tags []string, arg func(v any) string) string
+	// like - регистронезависимое совпадение с шаблоном, tagLike - то же для любого тега im
+	like, tagLike string
+}
+
+var pgListDialect = listDialect{
+	param:  "$",
+	itemID: "m.id::text",
+	hasTags: func(tags []string, arg func(v any) string) string {
+		b, _ := json.Marshal(tags)
+		return "im.tags @> " + arg(string(b)) + "::jsonb"
+	},
+	like:    "%s ILIKE %s",
+	tagLike: "EXISTS (SELECT 1 FROM jsonb_array_elements_text(im.tags) AS t(tag) WHERE t.tag ILIKE %s)",
+}
+
+// dataListQuery builds GetList query, tags are taken from item_meta of the record
+func dataListQuery(userID int64, q model.ListQuery, limit int, dl listDialect) (string, []any) {
+	args := []any{userID}
+	arg := func(v any) string {
+		args = append(args, v)
+		return dl.param + strconv.Itoa(len(args))
+	}
+
+	where := []string{"m.user_id = " + dl.param + "1", "m.deleted_at IS NULL"}
+	if len(q.Types) > 0 {
+		types := make([]string, len(q.Types))
+		for i, t := range q.Types {
+			types[i] = arg(t)
+		}
+		where = append(where, "m.dtype IN ("+strings.Join(types, ", ")+")")
+	}
+	itemMeta := `SELECT 1 FROM item_meta im WHERE im.user_id = m.user_id AND im.kind = '` + model.MetaKindData + `' AND im.item_id = ` + dl.itemID
+	if len(q.Tags) > 0 {
+		where = append(where, "EXISTS ("+itemMeta+" AND "+dl.hasTags(q.Tags, arg)+")")
+	}
+	if q.Search != "" {
+		search := arg("%" + escapeLike(q.Search) + "%")
+		where = append(where, "("+fmt.Sprintf(dl.like, "m.title", search)+" OR EXISTS ("+itemMeta+
+			" AND "+fmt.Sprintf(dl.tagLike, search)+"))")
+	}
+
+	cmp, dir := ">", "ASC"
+	if q.Desc {
+		cmp, dir = "<", "DESC"
+	}
+	order := "m.id " + dir
+	if q.Sort == model.ListSortTitle {
+		order = "m.title " + dir + ", m.id " + dir
+		if q.After != nil {
+			where = append(where, "(m.title, m.id) "+cmp+" ("+arg(q.After.Key)+", "+arg(q.After.ID)+")")
+		}
+	} else if q.After != nil {
+		where = append(where, "m.id "+cmp+" "+arg(q.After.ID))
+	}
+
+	query := `SELECT ` + dataColumns + ` FROM metadata m WHERE ` + strings.Join(where, " AND ") +
+		` ORDER BY ` + order + ` LIMIT ` + arg(limit+1)
+	return query, args
+}
+
+// escapeLike escapes LIKE wildcards, so search is a plain substring
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// Get returns the record only if it belongs to data.UserID
+func (d *DataRepo) Get(ctx context.Context, data *model.Data) (*model.Data, error) {
+	query := `SELECT ` + dataColumns + `, bin_data FROM metadata WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL`
+	res := model.Data{UserID: data.UserID}
+	err := d.db.QueryRowContext(ctx, query, data.ID, data.UserID).
+		Scan(append(dataFields(&res), &res.Binary)...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrPdataNotFound
+		}
+		d.log.WithError(err).Error("Failed to get metadata")
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// Update changes the record fields only if it belongs to data.UserID, the record gets the next revision
+// and the previous content goes to the history.
+// Non-zero data.Revision is the expected version, on success it is replaced with the new one.
+func (d *DataRepo) Update(ctx context.Context, data *model.Data) error {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := d.replace(ctx, tx, data); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return d.missReason(ctx, tx, data)
+		}
+		return err
+	}
+	return tx.Commit()
+}
+
+// replace archives the current content of the record and writes data over it,
+// history beyond DataHistoryLimit is removed. sql.ErrNoRows means no record with the expected version.
+func (d *DataRepo) replace(ctx context.Context, tx *sql.Tx, data *model.Data) error {
+	// FOR UPDATE держит строку до конца транзакции, версия не может поменяться между копированием и изменением
+	archive := `INSERT INTO metadata_history (metadata_id, user_id, revision, title, card_number, login, password, note, bin_data, key_hash,
+		card_holder, card_exp_month, card_exp_year, card_cvv, card_bank, card_pin, card_brand)
+		SELECT id, user_id, revision, title, card_number, login, password, note, bin_data, key_hash,
+		card_holder, card_exp_month, card_exp_year, card_cvv, card_bank, card_pin, card_brand
+		FROM metadata WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL AND ($3::bigint = 0 OR revision = $3)
+		FOR UPDATE`
+	res, err := tx.ExecContext(ctx, archive, data.ID, data.UserID, data.Revision)
+	if err != nil {
+		d.log.WithError(err).Error("Failed to archive metadata")
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	query := fmt.Sprintf(nextRevision, 16) + `UPDATE metadata SET title = $1, card_number = $2, login = $3, password = $4, note = $5, bin_data = $6, key_hash = $7,
+		card_holder = $8, card_exp_month = $9, card_exp_year = $10, card_cvv = $11, card_bank = $12, card_pin = $13, card_brand = $14,
+		revision = (SELECT revision FROM rev)
+		WHERE id = $15 AND user_id = $16
+		RETURNING revision`
+	var rev int64
+	err = tx.QueryRowContext(ctx, query, data.Title, data.Card, data.Login, data.Password, data.Note, data.Binary, data.KeyHash,
+		data.CardHolder, data.CardExpMonth, data.CardExpYear, data.CardCVV, data.CardBank, data.CardPIN, data.CardBrand,
+		data.ID, data.UserID).Scan(&rev)
+	if err != nil {
+		d.log.WithError(err).Error("Failed to update metadata")
+		return err
+	}
+
+	trim := `DELETE FROM metadata_history WHERE metadata_id = $1 AND revision <=
+		(SELECT revision FROM metadata_history WHERE metadata_id = $1 ORDER BY revision DESC OFFSET $2 LIMIT 1)`
+	if _, err := tx.ExecContext(ctx, trim, data.ID, model.DataHistoryLimit); err != nil {
+		d.log.WithError(err).Error("Failed to trim metadata history")
+		return err
+	}
+	data.Revision = rev
+
+	return nil
+}
+
+// History returns previous versions of the record, newest first, without binary content
+func (d *DataRepo) History(ctx context.Context, data *model.Data) ([]model.DataRevision, error) {
+	query := `SELECT h.revision, m.dtype, h.title, h.card_number, h.login, h.password, h.note, h.key_hash,
+		h.card_holder, h.card_exp_month, h.card_exp_year, h.card_cvv, h.card_bank, h.card_pin, h.card_brand, h.changed_at
+		FROM metadata_history h JOIN metadata m ON m.id = h.metadata_id
+		WHERE h.metadata_id = $1 AND h.user_id = $2 AND m.deleted_at IS NULL
+		ORDER BY h.revision DESC`
+	rows, err := d.db.QueryContext(ctx, query, data.ID, data.UserID)
+	if err != nil {
+		d.log.WithError(err).Error("Failed to get metadata history")
+		return nil, err
+	}
+	defer rows.Close()
+
+	var history []model.DataRevision
+	for rows.Next() {
+		item := model.DataRevision{Data: model.Data{ID: data.ID, UserID: data.UserID}}
+		v := &item.Data
+		err := rows.Scan(&v.Revision, &v.Type, &v.Title, &v.Card, &v.Login, &v.Password, &v.Note, &v.KeyHash,
+			&v.CardHolder, &v.CardExpMonth, &v.CardExpYear, &v.CardCVV, &v.CardBank, &v.CardPIN, &v.CardBrand, &item.ChangedAt)
+		if err != nil {
+			d.log.WithError(err).Error("Failed to scan metadata history")
+			return nil, err
+		}
+		history = append(history, item)
+	}
+
 	if err := rows.Err(); err != nil {
 		d.log.WithError(err).Error("Error while iterating rows")
 		return nil, err
 	}
 
-	return datalist, nil
+	return history, nil
+}
+
+// Restore brings back the content the record had at revision, the replaced content goes to the history as by Update.
+// Non-zero data.Revision is the expected version. On success data holds the restored record with the new revision.
+func (d *DataRepo) Restore(ctx context.Context, data *model.Data, revision int64) error {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	restored, err := d.revisionContent(ctx, tx, data, revision)
+	if err != nil {
+		return err
+	}
+	if err := d.replace(ctx, tx, &restored); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return d.missReason(ctx, tx, data)
+		}
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	*data = restored
+
+	return nil
+}
+
+// revisionContent reads the content the record had at revision from the history,
+// the result keeps the expected version data.Revision
+func (d *DataRepo) revisionContent(ctx context.Context, tx *sql.Tx, data *model.Data, revision int64) (model.Data, error) {
+	query := `SELECT title, card_number, login, password, note, bin_data, key_hash,
+		card_holder, card_exp_month, card_exp_year, card_cvv, card_bank, card_pin, card_brand
+		FROM metadata_history WHERE metadata_id = $1 AND user_id = $2 AND revision = $3`
+	res := model.Data{ID: data.ID, UserID: data.UserID, Revision: data.Revision}
+	v := &res
+	err := tx.QueryRowContext(ctx, query, data.ID, data.UserID, revision).
+		Scan(&v.Title, &v.Card, &v.Login, &v.Password, &v.Note, &v.Binary, &v.KeyHash,
+			&v.CardHolder, &v.CardExpMonth, &v.CardExpYear, &v.CardCVV, &v.CardBank, &v.CardPIN, &v.CardBrand)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.Data{}, model.ErrRevisionNotFound
+		}
+		d.log.WithError(err).Error("Failed to get metadata history")
+		return model.Data{}, err
+	}
+	return res, nil
+}
+
+// Delete moves the record to the trash only if it belongs to data.UserID.
+// Content and history stay until Purge, the tombstone gets the next revision for syncing other devices.
+// Non-zero data.Revision is the expected version.
+func (d *DataRepo) Delete(ctx context.Context, data *model.Data) error {
+	query := fmt.Sprintf(nextRevision, 2) + `UPDATE metadata SET deleted_at = now(), revision = (SELECT revision FROM rev)
+		WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL AND ($3::bigint = 0 OR revision = $3)`
+	res, err := d.db.ExecContext(ctx, query, data.ID, data.UserID, data.Revision)
+	if err != nil {
+		d.log.WithError(err).Error("Failed to delete metadata")
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return d.missReason(ctx, d.db, data)
+	}
+
+	return nil
+}
+
+// ListTrash returns deleted records of the user that are not purged yet, recently deleted first
+func (d *DataRepo) ListTrash(ctx context.Context, userID int64) ([]model.TrashItem, error) {
+	query := `SELECT ` + dataColumns + `, deleted_at FROM metadata
+		WHERE user_id = $1 AND deleted_at IS NOT NULL AND NOT purged ORDER BY deleted_at DESC`
+	rows, err := d.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		d.log.WithError(err).Error("Failed to get metadata trash")
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []model.TrashItem
+	for rows.Next() {
+		data := &model.Data{UserID: userID, Deleted: true}
+		item := model.TrashItem{Data: data}
+		if err := rows.Scan(append(dataFields(data), &item.DeletedAt)...); err != nil {
+			d.log.WithError(err).Error("Failed to scan data")
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		d.log.WithError(err).Error("Error while iterating rows")
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// RestoreDeleted takes the record out of the trash, it gets the next revision and syncs as changed
+func (d *DataRepo) RestoreDeleted(ctx context.Context, data *model.Data) error {
+	query := fmt.Sprintf(nextRevision, 2) + `UPDATE metadata SET deleted_at = NULL, revision = (SELECT revision FROM rev)
+		WHERE id = $1 AND user_id = $2 AND deleted_at IS NOT NULL AND NOT purged
+		RETURNING revision`
+	err := d.db.QueryRowContext(ctx, query, data.ID, data.UserID).Scan(&data.Revision)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.ErrTrashItemNotFound
+		}
+		d.log.WithError(err).Error("Failed to restore metadata")
+		return err
+	}
+	return nil
+}
+
+// Purge erases content and history of records deleted before the time, tombstones stay for syncing
+func (d *DataRepo) Purge(ctx context.Context, before time.Time) ([]model.PurgedItem, error) {
+	query := `WITH purged AS (UPDATE metadata SET purged = true,
+		title = '', card_number = '', login = '', password = '', note = '', bin_data = NULL,
+		card_holder = '', card_exp_month = 0, card_exp_year = 0, card_cvv = '', card_bank = '', card_pin = '', card_brand = ''
+		WHERE deleted_at < $1 AND NOT purged
+		RETURNING id, user_id),
+		history AS (DELETE FROM metadata_history WHERE metadata_id IN (SELECT id FROM purged))
+		SELECT id, user_id FROM purged`
+	rows, err := d.db.QueryContext(ctx, query, before)
+	if err != nil {
+		d.log.WithError(err).Error("Failed to purge metadata")
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []model.PurgedItem
+	for rows.Next() {
+		var item model.PurgedItem
+		if err := rows.Scan(&item.DataID, &item.UserID); err != nil {
+			d.log.WithError(err).Error("Failed to scan purged metadata")
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		d.log.WithError(err).Error("Error while iterating rows")
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// Count returns the number of stored records of the user, records in the trash are counted until purged
+func (d *DataRepo) Count(ctx context.Context, userID int64) (int64, error) {
+	query := `SELECT COUNT(*) FROM metadata WHERE user_id = $1 AND NOT purged`
+	var count int64
+	if err := d.db.QueryRowContext(ctx, query, userID).Scan(&count); err != nil {
+		d.log.WithError(err).Error("Failed to count metadata")
+		return 0, err
+	}
+	return count, nil
+}
+
+// missReason tells why a write with the expected version matched no record:
+// the record is gone or it has another version by now.
+// Внутри транзакции запись проверяется через нее же, а не другим соединением, пока транзакция держит блокировку.
+func (d *DataRepo) missReason(ctx context.Context, q rowQuerier, data *model.Data) error {
+	if data.Revision == 0 {
+		return model.ErrPdataNotFound
+	}
+	query := `SELECT revision FROM metadata WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL`
+	var rev int64
+	err := q.QueryRowContext(ctx, query, data.ID, data.UserID).Scan(&rev)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.ErrPdataNotFound
+		}
+		d.log.WithError(err).Error("Failed to get metadata revision")
+		return err
+	}
+	return model.ErrVersionConflict
 }
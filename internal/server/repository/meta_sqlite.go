@@ -0,0 +1,296 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/Arcadian-Sky/datakkeeper/internal/model"
+	"github.com/sirupsen/logrus"
+)
+
+// SQLiteDataRepo - DataRepository во встроенной SQLite. Изменения увеличивают ревизию пользователя
+// отдельным запросом в своей транзакции. Каждый метод интерфейса объявлен здесь: чтение, чьи запросы
+// переносимы, явно передается DataRepo, а новый метод интерфейса без реализации для SQLite не соберется.
+type SQLiteDataRepo struct {
+	db  *sql.DB
+	log *logrus.Logger
+	// common выполняет запросы DataRepo, которые работают на SQLite без изменений
+	common *DataRepo
+}
+
+func NewSQLiteDataRepository(dbd *sql.DB, lg *logrus.Logger) *SQLiteDataRepo {
+	return &SQLiteDataRepo{
+		db:     dbd,
+		log:    lg,
+		common: NewDataRepository(dbd, lg),
+	}
+}
+
+// Get returns the record only if it belongs to data.UserID, as DataRepo.Get
+func (d *SQLiteDataRepo) Get(ctx context.Context, data *model.Data) (*model.Data, error) {
+	return d.common.Get(ctx, data)
+}
+
+// History returns previous versions of the record, as DataRepo.History
+func (d *SQLiteDataRepo) History(ctx context.Context, data *model.Data) ([]model.DataRevision, error) {
+	return d.common.History(ctx, data)
+}
+
+// ListTrash returns deleted records of the user that are not purged yet, as DataRepo.ListTrash
+func (d *SQLiteDataRepo) ListTrash(ctx context.Context, userID int64) ([]model.TrashItem, error) {
+	return d.common.ListTrash(ctx, userID)
+}
+
+// Count returns the number of stored records of the user, as DataRepo.Count
+func (d *SQLiteDataRepo) Count(ctx context.Context, userID int64) (int64, error) {
+	return d.common.Count(ctx, userID)
+}
+
+// Save inserts the record with the next revision of the user
+func (d *SQLiteDataRepo) Save(ctx context.Context, data *model.Data) (int64, error) {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	rev, err := sqliteNextRevision(ctx, tx, data.UserID, 1)
+	if err != nil {
+		return 0, err
+	}
+	insertQuery := `INSERT INTO metadata (dtype, user_id, title, card_number, login, password, note, bin_data, key_hash,
+		card_holder, card_exp_month, card_exp_year, card_cvv, card_bank, card_pin, card_brand, revision)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING id`
+	err = tx.QueryRowContext(ctx, insertQuery, data.Type, data.UserID, data.Title, data.Card, data.Login, data.Password, data.Note, data.Binary, data.KeyHash,
+		data.CardHolder, data.CardExpMonth, data.CardExpYear, data.CardCVV, data.CardBank, data.CardPIN, data.CardBrand, rev).Scan(&data.ID)
+	if err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	data.Revision = rev
+	return data.ID, nil
+}
+
+// GetList returns a page of records without binary content, it's loaded by Get.
+// Next cursor is nil on the last page.
+func (d *SQLiteDataRepo) GetList(ctx context.Context, user *model.User, q model.ListQuery) ([]model.Data, *model.ListCursor, error) {
+	return d.common.list(ctx, user, q, sqliteListDialect)
+}
+
+// Update changes the record fields only if it belongs to data.UserID, as DataRepo.Update
+func (d *SQLiteDataRepo) Update(ctx context.Context, data *model.Data) error {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := d.replace(ctx, tx, data); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return d.common.missReason(ctx, tx, data)
+		}
+		return err
+	}
+	return tx.Commit()
+}
+
+// replace archives the current content of the record and writes data over it, as DataRepo.replace.
+// Блокировка строки не нужна: транзакция SQLite держит запись во всю базу.
+func (d *SQLiteDataRepo) replace(ctx context.Context, tx *sql.Tx, data *model.Data) error {
+	archive := `INSERT INTO metadata_history (metadata_id, user_id, revision, title, card_number, login, password, note, bin_data, key_hash,
+		card_holder, card_exp_month, card_exp_year, card_cvv, card_bank, card_pin, card_brand)
+		SELECT id, user_id, revision, title, card_number, login, password, note, bin_data, key_hash,
+		card_holder, card_exp_month, card_exp_year, card_cvv, card_bank, card_pin, card_brand
+		FROM metadata WHERE id = ?1 AND user_id = ?2 AND deleted_at IS NULL AND (?3 = 0 OR revision = ?3)`
+	res, err := tx.ExecContext(ctx, archive, data.ID, data.UserID, data.Revision)
+	if err != nil {
+		d.log.WithError(err).Error("Failed to archive metadata")
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	rev, err := sqliteNextRevision(ctx, tx, data.UserID, 1)
+	if err != nil {
+		d.log.WithError(err).Error("Failed to update metadata")
+		return err
+	}
+	query := `UPDATE metadata SET title = ?, card_number = ?, login = ?, password = ?, note = ?, bin_data = ?, key_hash = ?,
+		card_holder = ?, card_exp_month = ?, card_exp_year = ?, card_cvv = ?, card_bank = ?, card_pin = ?, card_brand = ?,
+		revision = ?
+		WHERE id = ? AND user_id = ?`
+	_, err = tx.ExecContext(ctx, query, data.Title, data.Card, data.Login, data.Password, data.Note, data.Binary, data.KeyHash,
+		data.CardHolder, data.CardExpMonth, data.CardExpYear, data.CardCVV, data.CardBank, data.CardPIN, data.CardBrand,
+		rev, data.ID, data.UserID)
+	if err != nil {
+		d.log.WithError(err).Error("Failed to update metadata")
+		return err
+	}
+
+	trim := `DELETE FROM metadata_history WHERE metadata_id = ?1 AND revision <=
+		(SELECT revision FROM metadata_history WHERE metadata_id = ?1 ORDER BY revision DESC LIMIT 1 OFFSET ?2)`
+	if _, err := tx.ExecContext(ctx, trim, data.ID, model.DataHistoryLimit); err != nil {
+		d.log.WithError(err).Error("Failed to trim metadata history")
+		return err
+	}
+	data.Revision = rev
+
+	return nil
+}
+
+// Restore brings back the content the record had at revision, as DataRepo.Restore
+func (d *SQLiteDataRepo) Restore(ctx context.Context, data *model.Data, revision int64) error {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	restored, err := d.common.revisionContent(ctx, tx, data, revision)
+	if err != nil {
+		return err
+	}
+	if err := d.replace(ctx, tx, &restored); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return d.common.missReason(ctx, tx, data)
+		}
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	*data = restored
+
+	return nil
+}
+
+// Delete moves the record to the trash only if it belongs to data.UserID, as DataRepo.Delete
+func (d *SQLiteDataRepo) Delete(ctx context.Context, data *model.Data) error {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// ревизия без подходящей записи откатывается вместе с транзакцией
+	rev, err := sqliteNextRevision(ctx, tx, data.UserID, 1)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return d.common.missReason(ctx, tx, data)
+		}
+		d.log.WithError(err).Error("Failed to delete metadata")
+		return err
+	}
+	query := `UPDATE metadata SET deleted_at = ?1, revision = ?2
+		WHERE id = ?3 AND user_id = ?4 AND deleted_at IS NULL AND (?5 = 0 OR revision = ?5)`
+	res, err := tx.ExecContext(ctx, query, time.Now(), rev, data.ID, data.UserID, data.Revision)
+	if err != nil {
+		d.log.WithError(err).Error("Failed to delete metadata")
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return d.common.missReason(ctx, tx, data)
+	}
+
+	return tx.Commit()
+}
+
+// RestoreDeleted takes the record out of the trash, it gets the next revision and syncs as changed
+func (d *SQLiteDataRepo) RestoreDeleted(ctx context.Context, data *model.Data) error {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	rev, err := sqliteNextRevision(ctx, tx, data.UserID, 1)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.ErrTrashItemNotFound
+		}
+		d.log.WithError(err).Error("Failed to restore metadata")
+		return err
+	}
+	query := `UPDATE metadata SET deleted_at = NULL, revision = ?
+		WHERE id = ? AND user_id = ? AND deleted_at IS NOT NULL AND NOT purged`
+	res, err := tx.ExecContext(ctx, query, rev, data.ID, data.UserID)
+	if err != nil {
+		d.log.WithError(err).Error("Failed to restore metadata")
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return model.ErrTrashItemNotFound
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	data.Revision = rev
+	return nil
+}
+
+// Purge erases content and history of records deleted before the time, tombstones stay for syncing
+func (d *SQLiteDataRepo) Purge(ctx context.Context, before time.Time) ([]model.PurgedItem, error) {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := `UPDATE metadata SET purged = true,
+		title = '', card_number = '', login = '', password = '', note = '', bin_data = NULL,
+		card_holder = '', card_exp_month = 0, card_exp_year = 0, card_cvv = '', card_bank = '', card_pin = '', card_brand = ''
+		WHERE deleted_at < ? AND NOT purged
+		RETURNING id, user_id`
+	rows, err := tx.QueryContext(ctx, query, before)
+	if err != nil {
+		d.log.WithError(err).Error("Failed to purge metadata")
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []model.PurgedItem
+	for rows.Next() {
+		var item model.PurgedItem
+		if err := rows.Scan(&item.DataID, &item.UserID); err != nil {
+			d.log.WithError(err).Error("Failed to scan purged metadata")
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		d.log.WithError(err).Error("Error while iterating rows")
+		return nil, err
+	}
+	rows.Close()
+
+	// у стертой записи истории не бывает: изменить можно только запись вне корзины
+	query = `DELETE FROM metadata_history WHERE metadata_id IN (SELECT id FROM metadata WHERE purged)`
+	if _, err := tx.ExecContext(ctx, query); err != nil {
+		d.log.WithError(err).Error("Failed to purge metadata history")
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Arcadian-Sky/datakkeeper/internal/model"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSQLiteData(t *testing.T) (*SQLiteDataRepo, int64) {
+	db := newTestSQLite(t)
+	return NewSQLiteDataRepository(db, logrus.New()), newTestSQLiteUser(t, db, "user")
+}
+
+func saveSQLiteData(t *testing.T, d *SQLiteDataRepo, userID int64, dtype, title string) *model.Data {
+	data := &model.Data{UserID: userID, Type: dtype, Title: title, Binary: []byte(title)}
+	_, err := d.Save(context.Background(), data)
+	require.NoError(t, err)
+	return data
+}
+
+func TestSQLiteDataRepo_Save(t *testing.T) {
+	d, userID := newTestSQLiteData(t)
+	ctx := context.Background()
+
+	first := saveSQLiteData(t, d, userID, DataTypeTEXT, "first")
+	second := saveSQLiteData(t, d, userID, DataTypeBINARY, "second")
+	require.Equal(t, int64(1), first.Revision)
+	require.Equal(t, int64(2), second.Revision)
+
+	got, err := d.Get(ctx, &model.Data{ID: second.ID, UserID: userID})
+	require.NoError(t, err)
+	require.Equal(t, "second", got.Title)
+	require.Equal(t, []byte("second"), got.Binary)
+	require.Equal(t, int64(2), got.Revision)
+
+	_, err = d.Get(ctx, &model.Data{ID: second.ID, UserID: userID + 1})
+	require.ErrorIs(t, err, model.ErrPdataNotFound)
+
+	// тип проверяется схемой вместо enum
+	_, err = d.Save(ctx, &model.Data{UserID: userID, Type: "UNKNOWN"})
+	require.Error(t, err)
+	_, err = d.Save(ctx, &model.Data{UserID: userID + 1, Type: DataTypeTEXT})
+	require.Error(t, err)
+
+	count, err := d.Count(ctx, userID)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+}
+
+func TestSQLiteDataRepo_GetList(t *testing.T) {
+	d, userID := newTestSQLiteData(t)
+	ctx := context.Background()
+	meta := NewItemMetaRepository(d.db, logrus.New())
+
+	mail := saveSQLiteData(t, d, userID, DataTypeLOGPASS, "Mail")
+	bank := saveSQLiteData(t, d, userID, DataTypeCARD, "bank")
+	saveSQLiteData(t, d, userID, DataTypeTEXT, "50% off")
+	for id, tags := range map[int64][]string{mail.ID: {"work", "daily"}, bank.ID: {"work", "money"}} {
+		item := &model.ItemMeta{UserID: userID, Kind: model.MetaKindData, ItemID: strconv.FormatInt(id, 10), Tags: tags}
+		require.NoError(t, meta.Set(ctx, item))
+	}
+
+	titles := func(q model.ListQuery) []string {
+		list, _, err := d.GetList(ctx, &model.User{ID: userID}, q)
+		require.NoError(t, err)
+		res := make([]string, 0, len(list))
+		for _, data := range list {
+			res = append(res, data.Title)
+		}
+		return res
+	}
+
+	require.Equal(t, []string{"Mail", "bank", "50% off"}, titles(model.ListQuery{}))
+	require.Equal(t, []string{"Mail", "bank"}, titles(model.ListQuery{Tags: []string{"work"}}))
+	require.Equal(t, []string{"bank"}, titles(model.ListQuery{Tags: []string{"work", "money"}}))
+	require.Equal(t, []string{"bank"}, titles(model.ListQuery{Types: []string{DataTypeCARD}}))
+	// поиск без учета регистра по названию и тегам, % ищется как текст
+	require.Equal(t, []string{"Mail"}, titles(model.ListQuery{Search: "MAIL"}))
+	require.Equal(t, []string{"bank"}, titles(model.ListQuery{Search: "mon"}))
+	require.Equal(t, []string{"50% off"}, titles(model.ListQuery{Search: "0%"}))
+
+	list, next, err := d.GetList(ctx, &model.User{ID: userID}, model.ListQuery{Sort: model.ListSortTitle, Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	require.Equal(t, &model.ListCursor{Key: "Mail", ID: mail.ID}, next)
+	list, next, err = d.GetList(ctx, &model.User{ID: userID}, model.ListQuery{Sort: model.ListSortTitle, Limit: 2, After: next})
+	require.NoError(t, err)
+	require.Nil(t, next)
+	require.Equal(t, "bank", list[0].Title)
+}
+
+func TestSQLiteDataRepo_UpdateRestore(t *testing.T) {
+	d, userID := newTestSQLiteData(t)
+	ctx := context.Background()
+	data := saveSQLiteData(t, d, userID, DataTypeTEXT, "v1")
+
+	update := &model.Data{ID: data.ID, UserID: userID, Title: "v2", Revision: data.Revision}
+	require.NoError(t, d.Update(ctx, update))
+	require.Equal(t, int64(2), update.Revision)
+
+	// устаревшая версия и чужая запись
+	stale := &model.Data{ID: data.ID, UserID: userID, Title: "v3", Revision: data.Revision}
+	require.ErrorIs(t, d.Update(ctx, stale), model.ErrVersionConflict)
+	require.ErrorIs(t, d.Update(ctx, &model.Data{ID: data.ID, UserID: userID + 1}), model.ErrPdataNotFound)
+
+	history, err := d.History(ctx, data)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	require.Equal(t, "v1", history[0].Data.Title)
+	require.Equal(t, int64(1), history[0].Data.Revision)
+
+	restore := &model.Data{ID: data.ID, UserID: userID}
+	require.NoError(t, d.Restore(ctx, restore, 1))
+	require.Equal(t, "v1", restore.Title)
+	require.Equal(t, int64(3), restore.Revision)
+	require.ErrorIs(t, d.Restore(ctx, restore, 42), model.ErrRevisionNotFound)
+
+	// история ограничена DataHistoryLimit
+	for i := 0; i < model.DataHistoryLimit+2; i++ {
+		require.NoError(t, d.Update(ctx, &model.Data{ID: data.ID, UserID: userID, Title: "v" + strconv.Itoa(i)}))
+	}
+	history, err = d.History(ctx, data)
+	require.NoError(t, err)
+	require.Len(t, history, model.DataHistoryLimit)
+}
+
+func TestSQLiteDataRepo_Trash(t *testing.T) {
+	d, userID := newTestSQLiteData(t)
+	ctx := context.Background()
+	data := saveSQLiteData(t, d, userID, DataTypeTEXT, "note")
+	require.NoError(t, d.Update(ctx, &model.Data{ID: data.ID, UserID: userID, Title: "note 2"}))
+
+	require.ErrorIs(t, d.Delete(ctx, &model.Data{ID: data.ID, UserID: userID, Revision: 1}), model.ErrVersionConflict)
+	require.ErrorIs(t, d.Delete(ctx, &model.Data{ID: data.ID, UserID: userID + 1}), model.ErrPdataNotFound)
+	require.NoError(t, d.Delete(ctx, &model.Data{ID: data.ID, UserID: userID}))
+	require.ErrorIs(t, d.Delete(ctx, &model.Data{ID: data.ID, UserID: userID}), model.ErrPdataNotFound)
+
+	trash, err := d.ListTrash(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, trash, 1)
+	require.Equal(t, int64(3), trash[0].Data.Revision)
+	require.WithinDuration(t, time.Now(), trash[0].DeletedAt, time.Minute)
+
+	restored := &model.Data{ID: data.ID, UserID: userID}
+	require.NoError(t, d.RestoreDeleted(ctx, restored))
+	require.Equal(t, int64(4), restored.Revision)
+	require.ErrorIs(t, d.RestoreDeleted(ctx, restored), model.ErrTrashItemNotFound)
+
+	require.NoError(t, d.Delete(ctx, &model.Data{ID: data.ID, UserID: userID}))
+	purged, err := d.Purge(ctx, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	require.Empty(t, purged)
+	purged, err = d.Purge(ctx, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, []model.PurgedItem{{DataID: data.ID, UserID: userID}}, purged)
+
+	var history int
+	require.NoError(t, d.db.QueryRow(`SELECT COUNT(*) FROM metadata_history`).Scan(&history))
+	require.Zero(t, history)
+	require.ErrorIs(t, d.RestoreDeleted(ctx, restored), model.ErrTrashItemNotFound)
+	count, err := d.Count(ctx, userID)
+	require.NoError(t, err)
+	require.Zero(t, count)
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"regexp"
 	"testing"
+	"time"
 
 	"github.com/Arcadian-Sky/datakkeeper/internal/model"
 	"github.com/DATA-DOG/go-sqlmock"
@@ -12,6 +14,10 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+// колонки dataColumns для sqlmock.NewRows
+var dataRowColumns = []string{"id", "dtype", "title", "card_number", "login", "password", "note", "key_hash",
+	"card_holder", "card_exp_month", "card_exp_year", "card_cvv", "card_bank", "card_pin", "card_brand", "revision"}
+
 func TestDataRepo_Save(t *testing.T) {
 
 	logg := logrus.New()
@@ -30,20 +36,31 @@ func TestDataRepo_Save(t *testing.T) {
 		{
 			name: "Success",
 			mock: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(`INSERT INTO "metadata" \(dtype, user_id, title, card_number, login, password\) VALUES \(\$1, \$2, \$3, \$4, \$5, \$6\) RETURNING id`).
-					WithArgs("type1", 1, "title1", "card1", "login1", "password1").
-					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(123))
+				mock.ExpectQuery(regexp.QuoteMeta(`WITH rev AS (UPDATE "user" SET revision = revision + 1 WHERE id = $2 RETURNING revision) `+
+					`INSERT INTO "metadata" (dtype, user_id, title, card_number, login, password, note, bin_data, key_hash, card_holder, card_exp_month, card_exp_year, card_cvv, card_bank, card_pin, card_brand, revision) `+
+					`VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, (SELECT revision FROM rev)) RETURNING id, revision`)).
+					WithArgs("type1", 1, "title1", "card1", "login1", "password1", "", []byte(nil), "hash1",
+						"holder1", int32(3), int32(2027), "cvv1", "bank1", "pin1", "VISA").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "revision"}).AddRow(123, 5))
 
 			},
 			args: args{
 				ctx: context.Background(),
 				data: &model.Data{
-					Type:     "type1",
-					UserID:   1,
-					Title:    "title1",
-					Card:     "card1",
-					Login:    "login1",
-					Password: "password1",
+					Type:         "type1",
+					UserID:       1,
+					Title:        "title1",
+					Card:         "card1",
+					Login:        "login1",
+					Password:     "password1",
+					KeyHash:      "hash1",
+					CardHolder:   "holder1",
+					CardExpMonth: 3,
+					CardExpYear:  2027,
+					CardCVV:      "cvv1",
+					CardBank:     "bank1",
+					CardPIN:      "pin1",
+					CardBrand:    "VISA",
 				},
 			},
 			want:    123,
@@ -54,9 +71,12 @@ func TestDataRepo_Save(t *testing.T) {
 			mock: func(mock sqlmock.Sqlmock) {
 
 				// Настроить ожидание запроса и его параметры
-				mock.ExpectQuery(`INSERT INTO "metadata" \(dtype, user_id, title, card_number, login, password\) VALUES \(\$1, \$2, \$3, \$4, \$5, \$6\) RETURNING id`).
-					WithArgs("type1", 1, "title1", "card1", "login1", "password1").
-					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectQuery(regexp.QuoteMeta(`WITH rev AS (UPDATE "user" SET revision = revision + 1 WHERE id = $2 RETURNING revision) `+
+					`INSERT INTO "metadata" (dtype, user_id, title, card_number, login, password, note, bin_data, key_hash, card_holder, card_exp_month, card_exp_year, card_cvv, card_bank, card_pin, card_brand, revision) `+
+					`VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, (SELECT revision FROM rev)) RETURNING id, revision`)).
+					WithArgs("type1", 1, "title1", "card1", "login1", "password1", "", []byte(nil), "hash1",
+						"", int32(0), int32(0), "", "", "", "").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "revision"}))
 				// WillReturnError(sql.ErrConnDone)
 
 			},
@@ -69,6 +89,7 @@ func TestDataRepo_Save(t *testing.T) {
 					Card:     "card1",
 					Login:    "login1",
 					Password: "password1",
+					KeyHash:  "hash1",
 				},
 			},
 			want:    0,
@@ -102,6 +123,7 @@ func TestDataRepo_Save(t *testing.T) {
 
 func TestDataRepo_GetList(t *testing.T) {
 	logg := logrus.New()
+	listQuery := regexp.QuoteMeta(`SELECT ` + dataColumns + ` FROM metadata m WHERE m.user_id = $1 AND m.deleted_at IS NULL ORDER BY m.id ASC LIMIT $2`)
 
 	type args struct {
 		ctx  context.Context
@@ -118,12 +140,12 @@ func TestDataRepo_GetList(t *testing.T) {
 			name: "Success",
 			mock: func(mock sqlmock.Sqlmock) {
 				// Настройка мока для успешного выполнения запроса
-				rows := sqlmock.NewRows([]string{"id", "dtype", "title", "card_number", "login", "password"}).
-					AddRow(1, "type1", "title1", "card1", "login1", "password1").
-					AddRow(2, "type2", "title2", "card2", "login2", "password2")
+				rows := sqlmock.NewRows(dataRowColumns).
+					AddRow(1, "type1", "title1", "card1", "login1", "password1", "", "hash1", "holder1", 3, 2027, "cvv1", "bank1", "pin1", "VISA", 1).
+					AddRow(2, "type2", "title2", "card2", "login2", "password2", "", "hash1", "", 0, 0, "", "", "", "", 1)
 
-				mock.ExpectQuery(`SELECT id, dtype, title, card_number, login, password FROM metadata WHERE user_id = \$1 ORDER BY id`).
-					WithArgs(1).
+				mock.ExpectQuery(listQuery).
+					WithArgs(1, model.DefaultPageSize+1).
 					WillReturnRows(rows)
 			},
 			args: args{
@@ -134,12 +156,21 @@ func TestDataRepo_GetList(t *testing.T) {
 			},
 			want: []model.Data{
 				{
-					ID:       1,
-					Type:     "type1",
-					Title:    "title1",
-					Card:     "card1",
-					Login:    "login1",
-					Password: "password1",
+					ID:           1,
+					Type:         "type1",
+					Title:        "title1",
+					Card:         "card1",
+					Login:        "login1",
+					Password:     "password1",
+					KeyHash:      "hash1",
+					CardHolder:   "holder1",
+					CardExpMonth: 3,
+					CardExpYear:  2027,
+					CardCVV:      "cvv1",
+					CardBank:     "bank1",
+					CardPIN:      "pin1",
+					CardBrand:    "VISA",
+					Revision:     1,
 				},
 				{
 					ID:       2,
@@ -148,6 +179,8 @@ func TestDataRepo_GetList(t *testing.T) {
 					Card:     "card2",
 					Login:    "login2",
 					Password: "password2",
+					KeyHash:  "hash1",
+					Revision: 1,
 				},
 			},
 			wantErr: false,
@@ -156,8 +189,8 @@ func TestDataRepo_GetList(t *testing.T) {
 			name: "QueryError",
 			mock: func(mock sqlmock.Sqlmock) {
 				// Настройка мока для вызова ошибки запроса
-				mock.ExpectQuery(`SELECT id, dtype, title, card_number, login, password FROM metadata WHERE user_id = \$1 ORDER BY id`).
-					WithArgs(1).
+				mock.ExpectQuery(listQuery).
+					WithArgs(1, model.DefaultPageSize+1).
 					WillReturnError(sql.ErrConnDone)
 			},
 			args: args{
@@ -173,11 +206,11 @@ func TestDataRepo_GetList(t *testing.T) {
 			name: "ScanError",
 			mock: func(mock sqlmock.Sqlmock) {
 				// Настройка мока для успешного выполнения запроса, но с ошибкой сканирования
-				rows := sqlmock.NewRows([]string{"id", "dtype", "title", "card_number", "login", "password"}).
-					AddRow("wrong_type", "type1", "title1", "card1", "login1", "password1") // Wrong type for `id`
+				rows := sqlmock.NewRows(dataRowColumns).
+					AddRow("wrong_type", "type1", "title1", "card1", "login1", "password1", "", "hash1", "", 0, 0, "", "", "", "", 1) // Wrong type for `id`
 
-				mock.ExpectQuery(`SELECT id, dtype, title, card_number, login, password FROM metadata WHERE user_id = \$1 ORDER BY id`).
-					WithArgs(1).
+				mock.ExpectQuery(listQuery).
+					WithArgs(1, model.DefaultPageSize+1).
 					WillReturnRows(rows)
 			},
 			args: args{
@@ -193,11 +226,11 @@ func TestDataRepo_GetList(t *testing.T) {
 			name: "IterationError",
 			mock: func(mock sqlmock.Sqlmock) {
 				// Настройка мока для успешного выполнения запроса, но с ошибкой итерации
-				rows := sqlmock.NewRows([]string{"id", "dtype", "title", "card_number", "login", "password"}).
-					AddRow(1, "type1", "title1", "card1", "login1", "password1")
+				rows := sqlmock.NewRows(dataRowColumns).
+					AddRow(1, "type1", "title1", "card1", "login1", "password1", "", "hash1", "", 0, 0, "", "", "", "", 1)
 
-				mock.ExpectQuery(`SELECT id, dtype, title, card_number, login, password FROM metadata WHERE user_id = \$1 ORDER BY id`).
-					WithArgs(1).
+				mock.ExpectQuery(listQuery).
+					WithArgs(1, model.DefaultPageSize+1).
 					WillReturnRows(rows)
 
 				rows.RowError(0, fmt.Errorf("row iteration error"))
@@ -225,12 +258,530 @@ func TestDataRepo_GetList(t *testing.T) {
 
 			r := &DataRepo{db: db, log: logg}
 
-			got, err := r.GetList(tt.args.ctx, tt.args.user)
+			got, next, err := r.GetList(tt.args.ctx, tt.args.user, model.ListQuery{})
 			if (err != nil) != tt.wantErr {
 				t.Errorf("DataRepo.GetList() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
 			require.ElementsMatch(t, tt.want, got, "DataRepo.GetList() = %v, want %v", got, tt.want)
+			require.Nil(t, next)
+		})
+	}
+}
+
+func TestDataRepo_GetListQuery(t *testing.T) {
+	itemMeta := `SELECT 1 FROM item_meta im WHERE im.user_id = m.user_id AND im.kind = 'data' AND im.item_id = m.id::text`
+
+	tests := []struct {
+		name      string
+		query     model.ListQuery
+		wantQuery string
+		wantArgs  []any
+	}{
+		{
+			name:      "Default",
+			query:     model.ListQuery{},
+			wantQuery: `SELECT ` + dataColumns + ` FROM metadata m WHERE m.user_id = $1 AND m.deleted_at IS NULL ORDER BY m.id ASC LIMIT $2`,
+			wantArgs:  []any{int64(1), 11},
+		},
+		{
+			name:  "Filters",
+			query: model.ListQuery{Types: []string{"CARD", "LOGPASS"}, Tags: []string{"work"}, Search: "50%_off"},
+			wantQuery: `SELECT ` + dataColumns + ` FROM metadata m WHERE m.user_id = $1 AND m.deleted_at IS NULL AND m.dtype IN ($2, $3)` +
+				` AND EXISTS (` + itemMeta + ` AND im.tags @> $4::jsonb)` +
+				` AND (m.title ILIKE $5 OR EXISTS (` + itemMeta +
+				` AND EXISTS (SELECT 1 FROM jsonb_array_elements_text(im.tags) AS t(tag) WHERE t.tag ILIKE $5)))` +
+				` ORDER BY m.id ASC LIMIT $6`,
+			wantArgs: []any{int64(1), "CARD", "LOGPASS", `["work"]`, `%50\%\_off%`, 11},
+		},
+		{
+			name:      "AfterID",
+			query:     model.ListQuery{Desc: true, After: &model.ListCursor{ID: 40}},
+			wantQuery: `SELECT ` + dataColumns + ` FROM metadata m WHERE m.user_id = $1 AND m.deleted_at IS NULL AND m.id < $2 ORDER BY m.id DESC LIMIT $3`,
+			wantArgs:  []any{int64(1), int64(40), 11},
+		},
+		{
+			name:      "AfterTitle",
+			query:     model.ListQuery{Sort: model.ListSortTitle, After: &model.ListCursor{Key: "mail", ID: 40}},
+			wantQuery: `SELECT ` + dataColumns + ` FROM metadata m WHERE m.user_id = $1 AND m.deleted_at IS NULL AND (m.title, m.id) > ($2, $3) ORDER BY m.title ASC, m.id ASC LIMIT $4`,
+			wantArgs:  []any{int64(1), "mail", int64(40), 11},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args := dataListQuery(1, tt.query, 10, pgListDialect)
+			require.Equal(t, tt.wantQuery, query)
+			require.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
+
+func TestDataRepo_GetListNextPage(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	rows := sqlmock.NewRows(dataRowColumns).
+		AddRow(3, "TEXT", "a", "", "", "", "", "", "", 0, 0, "", "", "", "", 1).
+		AddRow(7, "TEXT", "b", "", "", "", "", "", "", 0, 0, "", "", "", "", 1).
+		AddRow(9, "TEXT", "c", "", "", "", "", "", "", 0, 0, "", "", "", "", 1)
+	mock.ExpectQuery(regexp.QuoteMeta(`ORDER BY m.title ASC, m.id ASC LIMIT $2`)).
+		WithArgs(1, 3).
+		WillReturnRows(rows)
+
+	r := NewDataRepository(db, logrus.New())
+	got, next, err := r.GetList(context.Background(), &model.User{ID: 1}, model.ListQuery{Sort: model.ListSortTitle, Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	require.Equal(t, &model.ListCursor{Key: "b", ID: 7}, next)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDataRepo_Delete(t *testing.T) {
+	logg := logrus.New()
+	// удаление переносит запись в корзину: надгробие с новой ревизией, содержимое остается до очистки
+	deleteQuery := regexp.QuoteMeta(`WITH rev AS (UPDATE "user" SET revision = revision + 1 WHERE id = $2 RETURNING revision) ` +
+		`UPDATE metadata SET deleted_at = now(), revision = (SELECT revision FROM rev) ` +
+		`WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL AND ($3::bigint = 0 OR revision = $3)`)
+
+	tests := []struct {
+		name    string
+		mock    func(mock sqlmock.Sqlmock)
+		data    *model.Data
+		wantErr error
+	}{
+		{
+			name: "Success",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(deleteQuery).
+					WithArgs(10, 1, 0).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			data:    &model.Data{ID: 10, UserID: 1},
+			wantErr: nil,
+		},
+		{
+			name: "NotOwner",
+			mock: func(mock sqlmock.Sqlmock) {
+				// запись принадлежит другому пользователю — ни одна строка не удалена
+				mock.ExpectExec(deleteQuery).
+					WithArgs(10, 2, 0).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			data:    &model.Data{ID: 10, UserID: 2},
+			wantErr: model.ErrPdataNotFound,
+		},
+		{
+			name: "Conflict",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(deleteQuery).
+					WithArgs(10, 1, 7).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(regexp.QuoteMeta(`SELECT revision FROM metadata WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL`)).
+					WithArgs(10, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"revision"}).AddRow(9))
+			},
+			data:    &model.Data{ID: 10, UserID: 1, Revision: 7},
+			wantErr: model.ErrVersionConflict,
+		},
+		{
+			name: "ExecError",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(deleteQuery).
+					WithArgs(10, 1, 0).
+					WillReturnError(sql.ErrConnDone)
+			},
+			data:    &model.Data{ID: 10, UserID: 1},
+			wantErr: sql.ErrConnDone,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			tt.mock(mock)
+
+			r := &DataRepo{db: db, log: logg}
+
+			err = r.Delete(context.Background(), tt.data)
+			require.ErrorIs(t, err, tt.wantErr)
+			require.NoError(t, mock.ExpectationsWereMet())
 		})
 	}
 }
+
+func TestDataRepo_ListTrash(t *testing.T) {
+	logg := logrus.New()
+	query := regexp.QuoteMeta(`SELECT ` + dataColumns + `, deleted_at FROM metadata ` +
+		`WHERE user_id = $1 AND deleted_at IS NOT NULL AND NOT purged ORDER BY deleted_at DESC`)
+	deleted := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery(query).WithArgs(1).WillReturnRows(sqlmock.NewRows(append(dataRowColumns, "deleted_at")).
+		AddRow(10, "LOGPASS", "site", "", "login", "pass", "", "hash1", "", 0, 0, "", "", "", "", 7, deleted))
+
+	r := &DataRepo{db: db, log: logg}
+	items, err := r.ListTrash(context.Background(), 1)
+	require.NoError(t, err)
+	require.Equal(t, []model.TrashItem{{
+		Data: &model.Data{ID: 10, UserID: 1, Type: "LOGPASS", Title: "site", Login: "login", Password: "pass",
+			KeyHash: "hash1", Revision: 7, Deleted: true},
+		DeletedAt: deleted,
+	}}, items)
+
+	mock.ExpectQuery(query).WithArgs(1).WillReturnError(sql.ErrConnDone)
+	_, err = r.ListTrash(context.Background(), 1)
+	require.ErrorIs(t, err, sql.ErrConnDone)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDataRepo_RestoreDeleted(t *testing.T) {
+	logg := logrus.New()
+	query := regexp.QuoteMeta(`WITH rev AS (UPDATE "user" SET revision = revision + 1 WHERE id = $2 RETURNING revision) ` +
+		`UPDATE metadata SET deleted_at = NULL, revision = (SELECT revision FROM rev) ` +
+		`WHERE id = $1 AND user_id = $2 AND deleted_at IS NOT NULL AND NOT purged RETURNING revision`)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+	r := &DataRepo{db: db, log: logg}
+
+	mock.ExpectQuery(query).WithArgs(10, 1).WillReturnRows(sqlmock.NewRows([]string{"revision"}).AddRow(12))
+	data := &model.Data{ID: 10, UserID: 1}
+	require.NoError(t, r.RestoreDeleted(context.Background(), data))
+	require.Equal(t, int64(12), data.Revision)
+
+	// запись не в корзине, уже очищена или чужая
+	mock.ExpectQuery(query).WithArgs(10, 2).WillReturnError(sql.ErrNoRows)
+	require.ErrorIs(t, r.RestoreDeleted(context.Background(), &model.Data{ID: 10, UserID: 2}), model.ErrTrashItemNotFound)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDataRepo_Purge(t *testing.T) {
+	logg := logrus.New()
+	query := regexp.QuoteMeta(`WITH purged AS (UPDATE metadata SET purged = true, ` +
+		`title = '', card_number = '', login = '', password = '', note = '', bin_data = NULL, ` +
+		`card_holder = '', card_exp_month = 0, card_exp_year = 0, card_cvv = '', card_bank = '', card_pin = '', card_brand = '' ` +
+		`WHERE deleted_at < $1 AND NOT purged RETURNING id, user_id), ` +
+		`history AS (DELETE FROM metadata_history WHERE metadata_id IN (SELECT id FROM purged)) ` +
+		`SELECT id, user_id FROM purged`)
+	before := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+	r := &DataRepo{db: db, log: logg}
+
+	mock.ExpectQuery(query).WithArgs(before).WillReturnRows(sqlmock.NewRows([]string{"id", "user_id"}).AddRow(10, 1).AddRow(11, 2))
+	items, err := r.Purge(context.Background(), before)
+	require.NoError(t, err)
+	require.Equal(t, []model.PurgedItem{{UserID: 1, DataID: 10}, {UserID: 2, DataID: 11}}, items)
+
+	mock.ExpectQuery(query).WithArgs(before).WillReturnError(sql.ErrConnDone)
+	_, err = r.Purge(context.Background(), before)
+	require.ErrorIs(t, err, sql.ErrConnDone)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDataRepo_Count(t *testing.T) {
+	query := regexp.QuoteMeta(`SELECT COUNT(*) FROM metadata WHERE user_id = $1 AND NOT purged`)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+	r := &DataRepo{db: db, log: logrus.New()}
+
+	mock.ExpectQuery(query).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(7))
+	count, err := r.Count(context.Background(), 1)
+	require.NoError(t, err)
+	require.Equal(t, int64(7), count)
+
+	mock.ExpectQuery(query).WithArgs(1).WillReturnError(sql.ErrConnDone)
+	_, err = r.Count(context.Background(), 1)
+	require.ErrorIs(t, err, sql.ErrConnDone)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDataRepo_Get(t *testing.T) {
+	logg := logrus.New()
+	query := regexp.QuoteMeta(`SELECT ` + dataColumns + `, bin_data FROM metadata WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL`)
+
+	tests := []struct {
+		name    string
+		mock    func(mock sqlmock.Sqlmock)
+		data    *model.Data
+		want    *model.Data
+		wantErr error
+	}{
+		{
+			name: "Success",
+			mock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(append(dataRowColumns, "bin_data")).
+					AddRow(10, "LOGPASS", "title1", "", "login1", "password1", "", "hash1", "", 0, 0, "", "", "", "", 1, []byte(nil))
+				mock.ExpectQuery(query).WithArgs(10, 1).WillReturnRows(rows)
+			},
+			data: &model.Data{ID: 10, UserID: 1},
+			want: &model.Data{
+				ID:       10,
+				UserID:   1,
+				Type:     "LOGPASS",
+				Title:    "title1",
+				Login:    "login1",
+				Password: "password1",
+				KeyHash:  "hash1",
+				Revision: 1,
+			},
+		},
+		{
+			name: "NotOwner",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WithArgs(10, 2).WillReturnError(sql.ErrNoRows)
+			},
+			data:    &model.Data{ID: 10, UserID: 2},
+			wantErr: model.ErrPdataNotFound,
+		},
+		{
+			name: "QueryError",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WithArgs(10, 1).WillReturnError(sql.ErrConnDone)
+			},
+			data:    &model.Data{ID: 10, UserID: 1},
+			wantErr: sql.ErrConnDone,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			tt.mock(mock)
+
+			r := &DataRepo{db: db, log: logg}
+
+			got, err := r.Get(context.Background(), tt.data)
+			require.ErrorIs(t, err, tt.wantErr)
+			require.Equal(t, tt.want, got)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestDataRepo_Update(t *testing.T) {
+	logg := logrus.New()
+	archiveQuery := regexp.QuoteMeta(`INSERT INTO metadata_history (metadata_id, user_id, revision, title, card_number, login, password, note, bin_data, key_hash, ` +
+		`card_holder, card_exp_month, card_exp_year, card_cvv, card_bank, card_pin, card_brand) ` +
+		`SELECT id, user_id, revision, title, card_number, login, password, note, bin_data, key_hash, ` +
+		`card_holder, card_exp_month, card_exp_year, card_cvv, card_bank, card_pin, card_brand ` +
+		`FROM metadata WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL AND ($3::bigint = 0 OR revision = $3) FOR UPDATE`)
+	query := regexp.QuoteMeta(`WITH rev AS (UPDATE "user" SET revision = revision + 1 WHERE id = $16 RETURNING revision) ` +
+		`UPDATE metadata SET title = $1, card_number = $2, login = $3, password = $4, note = $5, bin_data = $6, key_hash = $7, card_holder = $8, card_exp_month = $9, card_exp_year = $10, card_cvv = $11, card_bank = $12, card_pin = $13, card_brand = $14, ` +
+		`revision = (SELECT revision FROM rev) WHERE id = $15 AND user_id = $16 RETURNING revision`)
+	trimQuery := regexp.QuoteMeta(`DELETE FROM metadata_history WHERE metadata_id = $1 AND revision <= ` +
+		`(SELECT revision FROM metadata_history WHERE metadata_id = $1 ORDER BY revision DESC OFFSET $2 LIMIT 1)`)
+	revQuery := regexp.QuoteMeta(`SELECT revision FROM metadata WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL`)
+
+	tests := []struct {
+		name    string
+		mock    func(mock sqlmock.Sqlmock)
+		data    *model.Data
+		wantRev int64
+		wantErr error
+	}{
+		{
+			name: "Success",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(archiveQuery).WithArgs(10, 1, 0).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(query).
+					WithArgs("title1", "", "login1", "password1", "", []byte(nil), "hash1", "", int32(0), int32(0), "", "", "", "", 10, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"revision"}).AddRow(8))
+				mock.ExpectExec(trimQuery).WithArgs(10, model.DataHistoryLimit).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+			data:    &model.Data{ID: 10, UserID: 1, Title: "title1", Login: "login1", Password: "password1", KeyHash: "hash1"},
+			wantRev: 8,
+		},
+		{
+			name: "ExpectedVersion",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(archiveQuery).WithArgs(10, 1, 7).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(query).
+					WithArgs("title1", "", "login1", "password1", "", []byte(nil), "hash1", "", int32(0), int32(0), "", "", "", "", 10, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"revision"}).AddRow(8))
+				mock.ExpectExec(trimQuery).WithArgs(10, model.DataHistoryLimit).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			data:    &model.Data{ID: 10, UserID: 1, Title: "title1", Login: "login1", Password: "password1", KeyHash: "hash1", Revision: 7},
+			wantRev: 8,
+		},
+		{
+			name: "NotOwner",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(archiveQuery).WithArgs(10, 2, 0).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			data:    &model.Data{ID: 10, UserID: 2, Title: "title1", Login: "login1", Password: "password1", KeyHash: "hash1"},
+			wantErr: model.ErrPdataNotFound,
+		},
+		{
+			name: "Conflict",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(archiveQuery).WithArgs(10, 1, 7).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(revQuery).WithArgs(10, 1).WillReturnRows(sqlmock.NewRows([]string{"revision"}).AddRow(9))
+				mock.ExpectRollback()
+			},
+			data:    &model.Data{ID: 10, UserID: 1, Title: "title1", Revision: 7},
+			wantRev: 7,
+			wantErr: model.ErrVersionConflict,
+		},
+		{
+			name: "ExpectedVersionNotFound",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(archiveQuery).WithArgs(10, 1, 7).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(revQuery).WithArgs(10, 1).WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			data:    &model.Data{ID: 10, UserID: 1, Title: "title1", Revision: 7},
+			wantRev: 7,
+			wantErr: model.ErrPdataNotFound,
+		},
+		{
+			name: "ExecError",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(archiveQuery).WithArgs(10, 1, 0).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(query).
+					WithArgs("title1", "", "login1", "password1", "", []byte(nil), "hash1", "", int32(0), int32(0), "", "", "", "", 10, 1).
+					WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
+			},
+			data:    &model.Data{ID: 10, UserID: 1, Title: "title1", Login: "login1", Password: "password1", KeyHash: "hash1"},
+			wantErr: sql.ErrConnDone,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			tt.mock(mock)
+
+			r := &DataRepo{db: db, log: logg}
+
+			err = r.Update(context.Background(), tt.data)
+			require.ErrorIs(t, err, tt.wantErr)
+			require.Equal(t, tt.wantRev, tt.data.Revision)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestDataRepo_History(t *testing.T) {
+	logg := logrus.New()
+	query := regexp.QuoteMeta(`SELECT h.revision, m.dtype, h.title, h.card_number, h.login, h.password, h.note, h.key_hash, ` +
+		`h.card_holder, h.card_exp_month, h.card_exp_year, h.card_cvv, h.card_bank, h.card_pin, h.card_brand, h.changed_at ` +
+		`FROM metadata_history h JOIN metadata m ON m.id = h.metadata_id ` +
+		`WHERE h.metadata_id = $1 AND h.user_id = $2 AND m.deleted_at IS NULL ORDER BY h.revision DESC`)
+	columns := []string{"revision", "dtype", "title", "card_number", "login", "password", "note", "key_hash",
+		"card_holder", "card_exp_month", "card_exp_year", "card_cvv", "card_bank", "card_pin", "card_brand", "changed_at"}
+	changed := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery(query).WithArgs(10, 1).WillReturnRows(sqlmock.NewRows(columns).
+		AddRow(5, "LOGPASS", "site", "", "login", "old2", "", "hash1", "", 0, 0, "", "", "", "", changed).
+		AddRow(3, "LOGPASS", "site", "", "login", "old1", "", "hash1", "", 0, 0, "", "", "", "", changed))
+
+	r := &DataRepo{db: db, log: logg}
+	history, err := r.History(context.Background(), &model.Data{ID: 10, UserID: 1})
+	require.NoError(t, err)
+	require.Equal(t, []model.DataRevision{
+		{Data: model.Data{ID: 10, UserID: 1, Revision: 5, Type: "LOGPASS", Title: "site", Login: "login", Password: "old2", KeyHash: "hash1"}, ChangedAt: changed},
+		{Data: model.Data{ID: 10, UserID: 1, Revision: 3, Type: "LOGPASS", Title: "site", Login: "login", Password: "old1", KeyHash: "hash1"}, ChangedAt: changed},
+	}, history)
+
+	mock.ExpectQuery(query).WithArgs(10, 1).WillReturnError(sql.ErrConnDone)
+	_, err = r.History(context.Background(), &model.Data{ID: 10, UserID: 1})
+	require.ErrorIs(t, err, sql.ErrConnDone)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDataRepo_Restore(t *testing.T) {
+	logg := logrus.New()
+	historyQuery := regexp.QuoteMeta(`SELECT title, card_number, login, password, note, bin_data, key_hash, ` +
+		`card_holder, card_exp_month, card_exp_year, card_cvv, card_bank, card_pin, card_brand ` +
+		`FROM metadata_history WHERE metadata_id = $1 AND user_id = $2 AND revision = $3`)
+	columns := []string{"title", "card_number", "login", "password", "note", "bin_data", "key_hash",
+		"card_holder", "card_exp_month", "card_exp_year", "card_cvv", "card_bank", "card_pin", "card_brand"}
+
+	t.Run("Success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(historyQuery).WithArgs(10, 1, 3).WillReturnRows(sqlmock.NewRows(columns).
+			AddRow("site", "", "login", "old1", "", nil, "hash1", "", 0, 0, "", "", "", ""))
+		mock.ExpectExec(`INSERT INTO metadata_history`).WithArgs(10, 1, 6).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(`UPDATE metadata SET`).
+			WithArgs("site", "", "login", "old1", "", []byte(nil), "hash1", "", int32(0), int32(0), "", "", "", "", 10, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"revision"}).AddRow(7))
+		mock.ExpectExec(`DELETE FROM metadata_history`).WithArgs(10, model.DataHistoryLimit).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		r := &DataRepo{db: db, log: logg}
+		data := &model.Data{ID: 10, UserID: 1, Revision: 6}
+		require.NoError(t, r.Restore(context.Background(), data, 3))
+		require.Equal(t, &model.Data{ID: 10, UserID: 1, Title: "site", Login: "login", Password: "old1", KeyHash: "hash1", Revision: 7}, data)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("RevisionNotFound", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(historyQuery).WithArgs(10, 1, 3).WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
+
+		r := &DataRepo{db: db, log: logg}
+		require.ErrorIs(t, r.Restore(context.Background(), &model.Data{ID: 10, UserID: 1}, 3), model.ErrRevisionNotFound)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Conflict", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(historyQuery).WithArgs(10, 1, 3).WillReturnRows(sqlmock.NewRows(columns).
+			AddRow("site", "", "login", "old1", "", nil, "hash1", "", 0, 0, "", "", "", ""))
+		mock.ExpectExec(`INSERT INTO metadata_history`).WithArgs(10, 1, 6).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(`SELECT revision FROM metadata`).WithArgs(10, 1).WillReturnRows(sqlmock.NewRows([]string{"revision"}).AddRow(8))
+		mock.ExpectRollback()
+
+		r := &DataRepo{db: db, log: logg}
+		data := &model.Data{ID: 10, UserID: 1, Revision: 6}
+		require.ErrorIs(t, r.Restore(context.Background(), data, 3), model.ErrVersionConflict)
+		require.Equal(t, int64(6), data.Revision)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -1,24 +1,59 @@
 package repository
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
-	"os"
-	"path/filepath"
+	"mime"
+	"net/url"
+	"path"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/Arcadian-Sky/datakkeeper/internal/model"
 	"github.com/Arcadian-Sky/datakkeeper/tools/client"
 	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/lifecycle"
 	"github.com/sirupsen/logrus"
 )
 
+// uploadPartSize - часть multipart загрузки в MinIO, ограничивает память на одну загрузку
+const uploadPartSize = 16 * 1024 * 1024
+
+// Пользовательские метаданные объекта файла, см. model.FileItem
+const (
+	objectMetaSHA256 = "sha256"
+	objectMetaDevice = "device"
+	// при дедупликации объект файла пустой: содержимое в BlobRepository под этой суммой
+	objectMetaBlob = "blob"
+	objectMetaSize = "size"
+)
+
+// defaultContentType - тип файла с неизвестным расширением
+const defaultContentType = "application/octet-stream"
+
+// folderContentType - тип объекта-маркера пустой папки
+const folderContentType = "application/x-directory"
+
 type FileRepository interface {
-	GetFile(ctx context.Context, fileID string, user *model.User) (*os.File, error)
-	GetFileList(ctx context.Context, user *model.User) ([]model.FileItem, error)
+	GetFile(ctx context.Context, fileID string, user *model.User) (io.ReadSeekCloser, error)
+	GetFileList(ctx context.Context, user *model.User, query model.ListQuery, meta map[string]model.ItemMeta) ([]model.FileItem, *model.ListCursor, error)
 	DeleteFile(ctx context.Context, fileID string, user *model.User) error
-	UploadFile(ctx context.Context, user *model.User, objectName string, file *os.File) error
+	RestoreFile(ctx context.Context, fileID string, user *model.User) error
+	PurgeFile(ctx context.Context, fileID string, user *model.User) error
+	UploadFile(ctx context.Context, user *model.User, file *model.FileItem, r io.Reader) error
+	ListFileVersions(ctx context.Context, fileID string, user *model.User) ([]model.FileVersion, error)
+	GetFileVersion(ctx context.Context, fileID, versionID string, user *model.User) (io.ReadSeekCloser, error)
+	RestoreFileVersion(ctx context.Context, fileID, versionID string, user *model.User) error
+	CreateFolder(ctx context.Context, folder string, user *model.User) error
+	MoveFile(ctx context.Context, from, to string, user *model.User) ([]model.FileMove, error)
+	Usage(ctx context.Context, user *model.User) (model.Usage, error)
+	ReplacedUsage(ctx context.Context, fileName string, user *model.User) (model.Usage, error)
+	MovedUsage(ctx context.Context, from string, user *model.User) (model.Usage, error)
+	ExpireVersions(ctx context.Context, now time.Time) error
 	CreateContainer(ctx context.Context, user *model.User) (model.User, error)
 
 	// Save(ctx context.Context, user model.User, data model.Data) (int64, error)
@@ -30,6 +65,9 @@ type FileRepo struct {
 	log      *logrus.Logger
 	ctx      *context.Context
 	location string
+	// содержимое файлов по SHA-256, новые загрузки попадают туда только при dedup
+	blobs BlobRepository
+	dedup bool
 }
 
 func NewFileRepository(st client.MinioClient, lg *logrus.Logger, ct *context.Context) *FileRepo {
@@ -42,6 +80,13 @@ func NewFileRepository(st client.MinioClient, lg *logrus.Logger, ct *context.Con
 	return p
 }
 
+// SetBlobs sets the storage of deduplicated content. Ссылки на уже сохраненное содержимое
+// читаются и учитываются и без dedup, поэтому хранилище нужно и после выключения дедупликации.
+func (f *FileRepo) SetBlobs(blobs BlobRepository, dedup bool) {
+	f.blobs = blobs
+	f.dedup = dedup
+}
+
 func (f *FileRepo) CreateContainer(ctx context.Context, user *model.User) (model.User, error) {
 	if user.ID == 0 {
 		return *user, model.ErrCreateBucketNoUser
@@ -63,53 +108,137 @@ func (f *FileRepo) CreateContainer(ctx context.Context, user *model.User) (model
 		user.Bucket = bucketName
 		f.log.Log(logrus.DebugLevel, "FileRepo: Successfully created bucket ", bucketName, "\n")
 	}
+	// без версий ListFileVersions и RestoreFileVersion не работают, поэтому бакет без них не отдается
+	if err := f.db.EnableVersioning(*f.ctx, bucketName); err != nil {
+		f.log.Log(logrus.ErrorLevel, "FileRepo: Failed to enable versioning of bucket ", bucketName, ":", err, "\n")
+		return *user, fmt.Errorf("%w: failed to enable versioning: %v", model.ErrCreateBucketFailed, err)
+	}
+	if err := f.db.SetBucketLifecycle(*f.ctx, bucketName, versionsLifecycle(f.blobs == nil)); err != nil {
+		f.log.Log(logrus.ErrorLevel, "FileRepo: Failed to set lifecycle of bucket ", bucketName, ":", err, "\n")
+		return *user, fmt.Errorf("%w: failed to set lifecycle: %v", model.ErrCreateBucketFailed, err)
+	}
 
 	return *user, nil
 }
 
-func (f *FileRepo) GetFile(ctx context.Context, fileID string, user *model.User) (*os.File, error) {
-	if user.ID == 0 {
-		return nil, model.ErrCreateBucketNoUser
+// noncurrentVersionDays - сколько дней хранятся прежние версии файлов
+const noncurrentVersionDays = 30
+
+// versionsLifecycle expires noncurrent versions after noncurrentVersionDays and removes
+// delete markers left without versions, otherwise every overwrite is kept forever.
+// Правило не снимает ссылки версий на BlobRepository, поэтому с ним прежние версии
+// удаляются только без хранилища содержимого, иначе их удаляет ExpireVersions.
+func versionsLifecycle(expireNoncurrent bool) *lifecycle.Configuration {
+	rule := lifecycle.Rule{
+		ID:         "expire-noncurrent-versions",
+		Status:     "Enabled",
+		RuleFilter: lifecycle.Filter{Prefix: ""},
+		Expiration: lifecycle.Expiration{DeleteMarker: true},
 	}
+	if expireNoncurrent {
+		rule.NoncurrentVersionExpiration = lifecycle.NoncurrentVersionExpiration{
+			NoncurrentDays: lifecycle.ExpirationDays(noncurrentVersionDays),
+		}
+	}
+	return &lifecycle.Configuration{Rules: []lifecycle.Rule{rule}}
+}
 
-	bucketName := "bucketuid" + strconv.Itoa(int(user.ID))
-
-	// Create a temporary file to store the downloaded file
-	tempFile, err := os.CreateTemp("", "minio_file_*.tmp")
+// ExpireVersions removes versions that have been noncurrent for noncurrentVersionDays at now
+// together with their references to the content, nothing to do without BlobRepository.
+// Бакетам, созданным до хранилища содержимого, заменяется правило versionsLifecycle.
+func (f *FileRepo) ExpireVersions(ctx context.Context, now time.Time) error {
+	if f.blobs == nil {
+		return nil
+	}
+	buckets, err := f.db.ListBuckets(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create temp file: %v", err)
+		return fmt.Errorf("failed to expire versions: %w", err)
+	}
+	before := now.AddDate(0, 0, -noncurrentVersionDays)
+	for _, bucket := range buckets {
+		if !strings.HasPrefix(bucket.Name, "bucketuid") {
+			continue
+		}
+		if err := f.db.SetBucketLifecycle(ctx, bucket.Name, versionsLifecycle(false)); err != nil {
+			return fmt.Errorf("failed to expire versions: %w", err)
+		}
+		if err := f.expireBucketVersions(ctx, bucket.Name, before); err != nil {
+			return fmt.Errorf("failed to expire versions: %w", err)
+		}
 	}
+	return nil
+}
 
-	defer func() {
-		if err != nil {
-			os.Remove(tempFile.Name()) // Clean up on error
+// expireBucketVersions removes versions that became noncurrent before before.
+// Версии ключа идут подряд от новых к старым, версия стала прежней, когда появилась следующая.
+func (f *FileRepo) expireBucketVersions(ctx context.Context, bucketName string, before time.Time) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	objectCh := f.db.ListObjects(ctx, bucketName, minio.ListObjectsOptions{Recursive: true, WithVersions: true, WithMetadata: true})
+
+	var key string
+	var replacedAt time.Time
+	for object := range objectCh {
+		if object.Err != nil {
+			return object.Err
 		}
-	}()
+		if object.Key != key {
+			key, replacedAt = object.Key, time.Time{}
+		}
+		if !object.IsLatest && replacedAt.Before(before) {
+			if err := f.removeVersion(ctx, bucketName, object); err != nil {
+				return err
+			}
+		}
+		replacedAt = object.LastModified
+	}
+	return nil
+}
+
+func (f *FileRepo) GetFile(ctx context.Context, fileID string, user *model.User) (io.ReadSeekCloser, error) {
+	return f.GetFileVersion(ctx, fileID, "", user)
+}
+
+// GetFileVersion opens the version of the file, empty versionID is the current one.
+// Содержимое читается из MinIO по мере чтения, на диск сервера ничего не пишется.
+func (f *FileRepo) GetFileVersion(ctx context.Context, fileID, versionID string, user *model.User) (io.ReadSeekCloser, error) {
+	if user.ID == 0 {
+		return nil, model.ErrCreateBucketNoUser
+	}
+
+	bucketName := "bucketuid" + strconv.Itoa(int(user.ID))
 
 	// Get the object from MinIO
-	object, err := f.db.GetObject(ctx, bucketName, fileID, minio.GetObjectOptions{})
+	object, err := f.db.GetObject(ctx, bucketName, fileID, minio.GetObjectOptions{VersionID: versionID})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get object from minio: %v", err)
 	}
-	defer object.Close()
-
-	// Write the object to the temporary file
-	if _, err = io.Copy(tempFile, object); err != nil {
-		return nil, fmt.Errorf("failed to write object to temp file: %v", err)
+	// MinIO отдает ошибки объекта только при первом обращении к нему
+	info, err := object.Stat()
+	if err != nil {
+		object.Close()
+		if versionID != "" && isNoSuchVersion(err) {
+			return nil, model.ErrFileVersionMissing
+		}
+		return nil, fmt.Errorf("failed to get object from minio: %v", err)
 	}
-
-	// Ensure the file is properly closed and ready for use
-	if err := tempFile.Close(); err != nil {
-		return nil, fmt.Errorf("failed to close temp file: %v", err)
+	if blob := objectMeta(info, objectMetaBlob); blob != "" {
+		object.Close()
+		return f.getBlob(ctx, blob)
 	}
 
-	// Reopen the file for reading
-	reopenedFile, err := os.Open(filepath.Clean(tempFile.Name()))
+	return object, nil
+}
+
+func (f *FileRepo) getBlob(ctx context.Context, blob string) (io.ReadSeekCloser, error) {
+	if f.blobs == nil {
+		return nil, fmt.Errorf("failed to get blob %s: blob storage is not set", blob)
+	}
+	content, err := f.blobs.Get(ctx, blob)
 	if err != nil {
-		return nil, fmt.Errorf("failed to reopen temp file: %v", err)
+		return nil, fmt.Errorf("failed to get blob %s: %w", blob, err)
 	}
-
-	return reopenedFile, nil
+	return content, nil
 }
 
 // Операции с объектами
@@ -119,65 +248,590 @@ func (f *FileRepo) GetFile(ctx context.Context, fileID string, user *model.User)
 // 	}
 // }()
 
+// DeleteFile moves the file to the trash, it can be restored until PurgeFile.
+// ErrFileNotFound, если файла нет.
 func (f *FileRepo) DeleteFile(ctx context.Context, fileName string, user *model.User) error {
 	bucketName := "bucketuid" + strconv.Itoa(int(user.ID))
 
-	err := f.db.RemoveObject(ctx, bucketName, fileName, minio.RemoveObjectOptions{
-		ForceDelete: true,
-	})
+	if err := f.moveObject(ctx, bucketName, fileName, model.TrashPrefix+fileName); err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return model.ErrFileNotFound
+		}
+		return fmt.Errorf("failed to delete file: %w", err)
+	}
+
+	return nil
+}
+
+// RestoreFile moves the file back from the trash, ErrTrashItemNotFound if it's not there.
+// ErrFileExists, если имя занято файлом или папкой, загруженными после удаления.
+func (f *FileRepo) RestoreFile(ctx context.Context, fileName string, user *model.User) error {
+	bucketName := "bucketuid" + strconv.Itoa(int(user.ID))
+
+	existing, err := f.listKeys(ctx, bucketName, fileName)
+	if err != nil {
+		return fmt.Errorf("failed to restore file: %w", err)
+	}
+	if len(existing) > 0 {
+		return model.ErrFileExists
+	}
+	err = f.copyObject(ctx,
+		minio.CopyDestOptions{Bucket: bucketName, Object: fileName},
+		minio.CopySrcOptions{Bucket: bucketName, Object: model.TrashPrefix + fileName},
+	)
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return model.ErrTrashItemNotFound
+		}
+		return fmt.Errorf("failed to restore file: %w", err)
+	}
+	// копия в корзине больше не нужна вместе со всеми ее версиями
+	if err := f.removeVersions(ctx, bucketName, model.TrashPrefix+fileName, true); err != nil {
+		return fmt.Errorf("failed to restore file: %w", err)
+	}
+
+	return nil
+}
+
+// PurgeFile removes the trash copy of the file and all versions of the file for good.
+// Версии под исходным именем не трогаются, если файл успели загрузить заново.
+func (f *FileRepo) PurgeFile(ctx context.Context, fileName string, user *model.User) error {
+	bucketName := "bucketuid" + strconv.Itoa(int(user.ID))
+
+	if err := f.removeVersions(ctx, bucketName, model.TrashPrefix+fileName, true); err != nil {
+		return fmt.Errorf("failed to purge file: %w", err)
+	}
+	if err := f.removeVersions(ctx, bucketName, fileName, false); err != nil {
+		return fmt.Errorf("failed to purge file: %w", err)
+	}
+
+	return nil
+}
+
+// removeVersions removes all versions of the key, a live key is kept unless force is set.
+// Удаленная версия больше не ссылается на свое содержимое в BlobRepository.
+func (f *FileRepo) removeVersions(ctx context.Context, bucketName, key string, force bool) error {
+	versions, err := f.objectVersions(ctx, bucketName, key)
 	if err != nil {
-		return fmt.Errorf("failed to delete bucket: %w", err)
+		return err
+	}
+	if len(versions) > 0 && !versions[0].IsDeleteMarker && !force {
+		return nil
+	}
+	for _, v := range versions {
+		if err := f.removeVersion(ctx, bucketName, v); err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
+// removeVersion removes the version of the object and its reference to the content
+func (f *FileRepo) removeVersion(ctx context.Context, bucketName string, v minio.ObjectInfo) error {
+	if err := f.db.RemoveObject(ctx, bucketName, v.Key, minio.RemoveObjectOptions{VersionID: v.VersionID}); err != nil {
+		return err
+	}
+	if blob := objectMeta(v, objectMetaBlob); blob != "" && f.blobs != nil {
+		if err := f.blobs.Unref(ctx, blob); err != nil {
+			// лишняя ссылка только оставляет содержимое в хранилище
+			f.log.Info("FileRepo: failed to remove blob reference: ", err)
+		}
+	}
 	return nil
 }
 
-func (f *FileRepo) GetFileList(ctx context.Context, user *model.User) ([]model.FileItem, error) {
+// ListFileVersions returns stored versions of the file, newest first
+func (f *FileRepo) ListFileVersions(ctx context.Context, fileName string, user *model.User) ([]model.FileVersion, error) {
 	if user.ID <= 0 {
 		return nil, model.ErrCreateBucketNoUser
 	}
+	bucketName := "bucketuid" + strconv.Itoa(int(user.ID))
+
+	objects, err := f.objectVersions(ctx, bucketName, fileName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list file versions: %w", err)
+	}
+	versions := make([]model.FileVersion, 0, len(objects))
+	for _, object := range objects {
+		if object.IsDeleteMarker {
+			continue
+		}
+		versions = append(versions, model.FileVersion{
+			VersionID:  object.VersionID,
+			Hash:       object.ETag,
+			Size:       objectSize(object),
+			ModifiedAt: object.LastModified,
+			Latest:     object.IsLatest,
+		})
+	}
+	return versions, nil
+}
+
+// RestoreFileVersion copies the version of the file over it, the copy becomes the newest version.
+// ErrFileVersionMissing, если такой версии нет или файл удален.
+func (f *FileRepo) RestoreFileVersion(ctx context.Context, fileName, versionID string, user *model.User) error {
+	if user.ID <= 0 {
+		return model.ErrCreateBucketNoUser
+	}
+	bucketName := "bucketuid" + strconv.Itoa(int(user.ID))
+
+	// удаленный файл версией не восстанавливается, для этого есть корзина
+	versions, err := f.objectVersions(ctx, bucketName, fileName)
+	if err != nil {
+		return fmt.Errorf("failed to restore file version: %w", err)
+	}
+	if len(versions) == 0 || versions[0].IsDeleteMarker ||
+		!slices.ContainsFunc(versions, func(v minio.ObjectInfo) bool { return v.VersionID == versionID && !v.IsDeleteMarker }) {
+		return model.ErrFileVersionMissing
+	}
+
+	err = f.copyObject(ctx,
+		minio.CopyDestOptions{Bucket: bucketName, Object: fileName},
+		minio.CopySrcOptions{Bucket: bucketName, Object: fileName, VersionID: versionID},
+	)
+	if err != nil {
+		if isNoSuchVersion(err) {
+			return model.ErrFileVersionMissing
+		}
+		return fmt.Errorf("failed to restore file version: %w", err)
+	}
+
+	return nil
+}
+
+// CreateFolder stores the marker of the empty folder, a folder with files is shown without it.
+// ErrFileExists, если с таким именем уже есть файл.
+func (f *FileRepo) CreateFolder(ctx context.Context, folder string, user *model.User) error {
+	if user.ID <= 0 {
+		return model.ErrNoUserBucket
+	}
+	bucketName := "bucketuid" + strconv.Itoa(int(user.ID))
+
+	keys, err := f.listKeys(ctx, bucketName, folder)
+	if err != nil {
+		return fmt.Errorf("failed to create folder: %w", err)
+	}
+	if slices.Contains(keys, folder) {
+		return model.ErrFileExists
+	}
+	_, err = f.db.PutObject(ctx, bucketName, folder+model.FolderSeparator, bytes.NewReader(nil), 0,
+		minio.PutObjectOptions{ContentType: folderContentType})
+	if err != nil {
+		return fmt.Errorf("failed to create folder: %w", err)
+	}
+	return nil
+}
+
+// MoveFile renames the file or the folder with all its content and returns moved files.
+// Прежние версии остаются под старыми именами, переносится только текущее содержимое,
+// оно тоже остается прежней версией и занимает место, см. MovedUsage.
+// ErrFileNotFound, если переносить нечего, ErrFileExists, если новое имя занято.
+func (f *FileRepo) MoveFile(ctx context.Context, from, to string, user *model.User) ([]model.FileMove, error) {
+	if user.ID <= 0 {
+		return nil, model.ErrNoUserBucket
+	}
+	bucketName := "bucketuid" + strconv.Itoa(int(user.ID))
+
+	keys, err := f.listKeys(ctx, bucketName, from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to move file: %w", err)
+	}
+	if len(keys) == 0 {
+		return nil, model.ErrFileNotFound
+	}
+	existing, err := f.listKeys(ctx, bucketName, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to move file: %w", err)
+	}
+	if len(existing) > 0 {
+		return nil, model.ErrFileExists
+	}
+
+	// при ошибке возвращаются уже перенесенные файлы
+	moves := make([]model.FileMove, 0, len(keys))
+	for _, key := range keys {
+		move := model.FileMove{From: key, To: to + strings.TrimPrefix(key, from)}
+		if err := f.moveObject(ctx, bucketName, move.From, move.To); err != nil {
+			return moves, fmt.Errorf("failed to move file: %w", err)
+		}
+		moves = append(moves, move)
+		// перенесенный файл заменяет удаленный с тем же именем, как и новая загрузка
+		if err := f.removeVersions(ctx, bucketName, model.TrashPrefix+move.To, true); err != nil {
+			f.log.Info("FileRepo: failed to remove trash copy: ", err)
+		}
+	}
+	return moves, nil
+}
+
+// Usage returns size and count of stored files including the trash, records are counted by DataRepository.
+// Прежние версии занимают место до истечения, см. versionsLifecycle, и учитываются в Bytes, но не в Files.
+func (f *FileRepo) Usage(ctx context.Context, user *model.User) (model.Usage, error) {
+	if user.ID <= 0 {
+		return model.Usage{}, model.ErrNoUserBucket
+	}
+	bucketName := "bucketuid" + strconv.Itoa(int(user.ID))
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	objectCh := f.db.ListObjects(ctx, bucketName, minio.ListObjectsOptions{Recursive: true, WithMetadata: true, WithVersions: true})
+
+	var usage model.Usage
+	for object := range objectCh {
+		if object.Err != nil {
+			return model.Usage{}, fmt.Errorf("failed to get usage: %w", object.Err)
+		}
+		if object.IsDeleteMarker || model.IsFolderKey(object.Key) {
+			continue
+		}
+		usage.Bytes += objectSize(object)
+		if object.IsLatest {
+			usage.Files++
+		}
+	}
+	return usage, nil
+}
+
+// ReplacedUsage returns the part of Usage released when a new file is uploaded as fileName:
+// the current file stops counting as a separate file and the trash copy is removed with all its versions.
+// Байты текущего файла не освобождаются, он остается прежней версией.
+func (f *FileRepo) ReplacedUsage(ctx context.Context, fileName string, user *model.User) (model.Usage, error) {
+	if user.ID <= 0 {
+		return model.Usage{}, model.ErrNoUserBucket
+	}
+	bucketName := "bucketuid" + strconv.Itoa(int(user.ID))
+
+	var usage model.Usage
+	for _, key := range []string{fileName, model.TrashPrefix + fileName} {
+		versions, err := f.objectVersions(ctx, bucketName, key)
+		if err != nil {
+			return model.Usage{}, fmt.Errorf("failed to get usage: %w", err)
+		}
+		for _, object := range versions {
+			if object.IsDeleteMarker {
+				continue
+			}
+			if object.IsLatest {
+				usage.Files++
+			}
+			if key != fileName {
+				usage.Bytes += objectSize(object)
+			}
+		}
+	}
+	return usage, nil
+}
 
+// MovedUsage returns the part of Usage added by moving the file or the folder from:
+// текущее содержимое остается под старыми именами прежними версиями, число файлов не меняется.
+func (f *FileRepo) MovedUsage(ctx context.Context, from string, user *model.User) (model.Usage, error) {
+	if user.ID <= 0 {
+		return model.Usage{}, model.ErrNoUserBucket
+	}
 	bucketName := "bucketuid" + strconv.Itoa(int(user.ID))
 
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	objectCh := f.db.ListObjects(ctx, bucketName, minio.ListObjectsOptions{Prefix: from, Recursive: true, WithMetadata: true})
+
+	var usage model.Usage
+	for object := range objectCh {
+		if object.Err != nil {
+			return model.Usage{}, fmt.Errorf("failed to get usage: %w", object.Err)
+		}
+		if !model.InFolder(object.Key, from) || model.IsFolderKey(object.Key) {
+			continue
+		}
+		usage.Bytes += objectSize(object)
+	}
+	return usage, nil
+}
+
+// listKeys returns the key of the file name and keys of all objects in the folder name
+func (f *FileRepo) listKeys(ctx context.Context, bucketName, name string) ([]string, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	objectCh := f.db.ListObjects(ctx, bucketName, minio.ListObjectsOptions{Prefix: name, Recursive: true})
+
+	var keys []string
+	for object := range objectCh {
+		if object.Err != nil {
+			return nil, object.Err
+		}
+		// префикс совпадает и с более длинными именами
+		if model.InFolder(object.Key, name) {
+			keys = append(keys, object.Key)
+		}
+	}
+	return keys, nil
+}
+
+// objectVersions lists versions and delete markers of exactly the key, newest first
+func (f *FileRepo) objectVersions(ctx context.Context, bucketName, key string) ([]minio.ObjectInfo, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
 	objectCh := f.db.ListObjects(ctx, bucketName, minio.ListObjectsOptions{
-		Prefix:    "",
-		Recursive: true,
+		Prefix:       key,
+		WithVersions: true,
+		WithMetadata: true,
 	})
 
+	var versions []minio.ObjectInfo
+	for object := range objectCh {
+		if object.Err != nil {
+			return nil, object.Err
+		}
+		// префикс совпадает и с более длинными именами
+		if object.Key != key {
+			continue
+		}
+		versions = append(versions, object)
+	}
+	return versions, nil
+}
+
+// objectMeta returns user metadata of the listed object, MinIO returns keys with X-Amz-Meta- prefix
+func objectMeta(object minio.ObjectInfo, key string) string {
+	for k, v := range object.UserMetadata {
+		if strings.TrimPrefix(strings.ToLower(k), "x-amz-meta-") == key {
+			return v
+		}
+	}
+	return ""
+}
+
+// objectSize returns the size of the file content, the object of a deduplicated file is empty
+func objectSize(object minio.ObjectInfo) int64 {
+	if objectMeta(object, objectMetaBlob) == "" {
+		return object.Size
+	}
+	size, err := strconv.ParseInt(objectMeta(object, objectMetaSize), 10, 64)
+	if err != nil {
+		return object.Size
+	}
+	return size
+}
+
+func isNoSuchVersion(err error) bool {
+	code := minio.ToErrorResponse(err).Code
+	return code == "NoSuchVersion" || code == "NoSuchKey"
+}
+
+// moveObject copies the object on the server side and removes the source
+func (f *FileRepo) moveObject(ctx context.Context, bucketName, from, to string) error {
+	err := f.copyObject(ctx,
+		minio.CopyDestOptions{Bucket: bucketName, Object: to},
+		minio.CopySrcOptions{Bucket: bucketName, Object: from},
+	)
+	if err != nil {
+		return err
+	}
+	return f.db.RemoveObject(ctx, bucketName, from, minio.RemoveObjectOptions{})
+}
+
+// copyObject copies the object on the server side, the copy of a deduplicated file is one more reference
+// to its content. Ссылка добавляется до копирования: при сбое лишняя ссылка безопаснее недостающей.
+func (f *FileRepo) copyObject(ctx context.Context, dst minio.CopyDestOptions, src minio.CopySrcOptions) error {
+	var blob string
+	if f.blobs != nil {
+		object, err := f.db.GetObject(ctx, src.Bucket, src.Object, minio.GetObjectOptions{VersionID: src.VersionID})
+		if err != nil {
+			return err
+		}
+		info, err := object.Stat()
+		object.Close()
+		if err != nil {
+			return err
+		}
+		blob = objectMeta(info, objectMetaBlob)
+		if blob != "" {
+			if err := f.blobs.Ref(ctx, blob); err != nil {
+				return err
+			}
+		}
+	}
+
+	if _, err := f.db.CopyObject(ctx, dst, src); err != nil {
+		if blob != "" {
+			if err := f.blobs.Unref(ctx, blob); err != nil {
+				f.log.Info("FileRepo: failed to remove blob reference: ", err)
+			}
+		}
+		return err
+	}
+	return nil
+}
+
+// GetFileList returns a page of files matching the query, meta is attached to files by name before filtering.
+// MinIO lists objects sorted by name, so the listing is read sequentially and only the page is kept in memory.
+// В режиме Browse вложенные папки Parent возвращаются элементами с IsFolder.
+func (f *FileRepo) GetFileList(ctx context.Context, user *model.User, q model.ListQuery, meta map[string]model.ItemMeta) ([]model.FileItem, *model.ListCursor, error) {
+	if user.ID <= 0 {
+		return nil, nil, model.ErrCreateBucketNoUser
+	}
+
+	bucketName := "bucketuid" + strconv.Itoa(int(user.ID))
+
+	// листинг останавливается, как только страница набрана
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	opts := minio.ListObjectsOptions{
+		Prefix:       "",
+		Recursive:    true,
+		WithMetadata: true,
+	}
+	if q.Browse {
+		// без Recursive вложенные папки приходят общими префиксами
+		opts.Prefix, opts.Recursive = model.FolderPrefix(q.Parent), false
+	}
+	if q.After != nil && !q.Desc {
+		opts.StartAfter = q.After.Key
+	}
+	objectCh := f.db.ListObjects(ctx, bucketName, opts)
+
+	objects, cursor, err := listPage(objectCh, q, opts.Prefix, meta, f.log)
+	if err != nil {
+		return nil, nil, err
+	}
+	return objects, cursor, nil
+}
+
+// listPage reads objects listed by key until the page of files matching the query is collected,
+// prefix - папка листинга Browse. Для q.Desc листинг читается до курсора, в памяти держится одна страница.
+// Ошибка листинга возвращается, а не пропускается, иначе страница молча обрезалась бы.
+func listPage(objectCh <-chan minio.ObjectInfo, q model.ListQuery, prefix string, meta map[string]model.ItemMeta, log *logrus.Logger) ([]model.FileItem, *model.ListCursor, error) {
+	limit := q.Limit
+	if limit <= 0 {
+		limit = model.DefaultPageSize
+	}
+
+	// на одну запись больше страницы, лишняя значит, что есть следующая страница
 	var objects []model.FileItem
 	for object := range objectCh {
 		if object.Err != nil {
-			fmt.Println(object.Err)
+			log.WithError(object.Err).Error("Failed to list objects")
+			return nil, nil, fmt.Errorf("failed to list objects: %w", object.Err)
+		}
+		if q.Desc && q.After != nil && object.Key >= q.After.Key {
+			break
+		}
+		// маркер самой папки и маркеры папок в общем списке не показываются
+		if model.IsTrashName(object.Key) || object.Key == prefix || (!q.Browse && model.IsFolderKey(object.Key)) {
 			continue
 		}
-		objects = append(objects, model.FileItem{
-			Hash: object.ETag,
-			Name: object.Key,
-		})
+		item := fileItem(object, meta)
+		if !q.MatchFile(item) {
+			continue
+		}
+		objects = append(objects, item)
+		if !q.Desc && len(objects) > limit {
+			break
+		}
+		// в обратном порядке нужны последние записи перед курсором, держим только окно размером со страницу
+		if q.Desc && len(objects) > limit+1 {
+			objects = objects[1:]
+		}
+	}
+	if q.Desc {
+		slices.Reverse(objects)
 	}
 
-	f.log.Info(objects)
+	if len(objects) <= limit {
+		return objects, nil, nil
+	}
+	objects = objects[:limit]
+	last := objects[limit-1]
+	key := last.Name
+	if last.IsFolder {
+		key += model.FolderSeparator
+	}
+	return objects, &model.ListCursor{Key: key}, nil
+}
 
-	return objects, nil
+// fileItem converts the listed object, a key with trailing "/" is a folder
+func fileItem(object minio.ObjectInfo, meta map[string]model.ItemMeta) model.FileItem {
+	if model.IsFolderKey(object.Key) {
+		return model.FileItem{Name: strings.TrimSuffix(object.Key, model.FolderSeparator), IsFolder: true}
+	}
+	device, _ := url.QueryUnescape(objectMeta(object, objectMetaDevice))
+	return model.FileItem{
+		Hash:        object.ETag,
+		Name:        object.Key,
+		Desc:        meta[object.Key].Description,
+		Meta:        meta[object.Key].Fields,
+		Tags:        meta[object.Key].Tags,
+		Size:        objectSize(object),
+		ContentType: object.ContentType,
+		UploadedAt:  object.LastModified,
+		SHA256:      objectMeta(object, objectMetaSHA256),
+		Device:      device,
+	}
 }
 
-// UploadFile uploads a file to a MinIO bucket.
-func (f *FileRepo) UploadFile(ctx context.Context, user *model.User, objectName string, file *os.File) error {
+// UploadFile uploads the content of r to a MinIO bucket as file.Name, file.Size -1 if it's unknown.
+// SHA256 и Device сохраняются в метаданных объекта, тип без ContentType определяется по расширению.
+// Неизвестный размер загружается multipart частями uploadPartSize, в памяти держится одна часть.
+// При дедупликации содержимое сохраняется в BlobRepository, а объект файла только ссылается на него.
+func (f *FileRepo) UploadFile(ctx context.Context, user *model.User, file *model.FileItem, r io.Reader) error {
 	if user.ID <= 0 {
 		return model.ErrNoUserBucket
 	}
 
 	bucketName := "bucketuid" + strconv.Itoa(int(user.ID))
+	objectName := file.Name
+
+	opts := minio.PutObjectOptions{
+		PartSize:    uploadPartSize,
+		ContentType: fileContentType(file.Name, file.ContentType),
+		UserMetadata: map[string]string{
+			objectMetaSHA256: file.SHA256,
+			// значения метаданных передаются заголовками, имя устройства может быть не ASCII
+			objectMetaDevice: url.QueryEscape(file.Device),
+		},
+	}
+
+	size := file.Size
+	var blob model.Blob
+	if f.dedup && f.blobs != nil {
+		var err error
+		blob, err = f.blobs.Put(ctx, file.SHA256, file.Size, r)
+		if err != nil {
+			return fmt.Errorf("failed to upload file to MinIO: %w", err)
+		}
+		opts.UserMetadata[objectMetaSHA256] = blob.SHA256
+		opts.UserMetadata[objectMetaBlob] = blob.SHA256
+		opts.UserMetadata[objectMetaSize] = strconv.FormatInt(blob.Size, 10)
+		r, size = bytes.NewReader(nil), 0
+	}
 
 	// Upload the file
-	_, err := f.db.PutObject(ctx, bucketName, objectName, file, -1, minio.PutObjectOptions{})
+	_, err := f.db.PutObject(ctx, bucketName, objectName, r, size, opts)
 	if err != nil {
+		if blob.SHA256 != "" {
+			if err := f.blobs.Unref(ctx, blob.SHA256); err != nil {
+				f.log.Info("FileRepo: failed to remove blob reference: ", err)
+			}
+		}
 		return fmt.Errorf("failed to upload file to MinIO: %w", err)
 	}
+	// новый файл с тем же именем убирает его из корзины, как и ревизия файла
+	if err := f.removeVersions(ctx, bucketName, model.TrashPrefix+objectName, true); err != nil {
+		f.log.Info("FileRepo: failed to remove trash copy: ", err)
+	}
 
 	return nil
 }
 
+// fileContentType returns contentType, a file without it gets the type by the extension of the name
+func fileContentType(name, contentType string) string {
+	if contentType == "" {
+		contentType = mime.TypeByExtension(path.Ext(name))
+	}
+	if contentType == "" {
+		contentType = defaultContentType
+	}
+	return contentType
+}
+
 // func (f *FileRepo) Save(ctx context.Context, user model.User, data model.Data) (int64, error) {
 
 // 	// Проверка существования бакета, создание, если он не существует
@@ -10,13 +10,17 @@ import (
 	"path/filepath"
 	"reflect"
 	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/Arcadian-Sky/datakkeeper/internal/model"
 	"github.com/Arcadian-Sky/datakkeeper/mocks"
 	"github.com/golang/mock/gomock"
 	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/lifecycle"
 	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
 )
 
 // func NewMinioStorage() (*minio.Client, error) {
@@ -158,6 +162,8 @@ func TestFileRepo_CreateContainer(t *testing.T) {
 			setupMocks: func() {
 				bucketName := "bucketuid" + strconv.Itoa(123)
 				mockMinio.EXPECT().MakeBucket(gomock.Any(), bucketName, gomock.Any()).Return(nil)
+				mockMinio.EXPECT().EnableVersioning(gomock.Any(), bucketName).Return(nil)
+				mockMinio.EXPECT().SetBucketLifecycle(gomock.Any(), bucketName, versionsLifecycle(true)).Return(nil)
 			},
 			want: model.User{
 				ID:     123,
@@ -165,6 +171,82 @@ func TestFileRepo_CreateContainer(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			// правило не снимает ссылки на содержимое, прежние версии удаляет ExpireVersions
+			name: "CreateBucketWithBlobs",
+			f: &FileRepo{
+				db:       mockMinio,
+				log:      mockLogger,
+				ctx:      &ctx,
+				location: "us-east-1",
+				blobs:    mocks.NewMockBlobRepository(ctrl),
+			},
+			args: args{
+				ctx:  &ctx,
+				user: &model.User{ID: 123},
+			},
+			setupMocks: func() {
+				bucketName := "bucketuid" + strconv.Itoa(123)
+				mockMinio.EXPECT().MakeBucket(gomock.Any(), bucketName, gomock.Any()).Return(nil)
+				mockMinio.EXPECT().EnableVersioning(gomock.Any(), bucketName).Return(nil)
+				mockMinio.EXPECT().
+					SetBucketLifecycle(gomock.Any(), bucketName, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, config *lifecycle.Configuration) error {
+						require.Len(t, config.Rules, 1)
+						require.True(t, config.Rules[0].NoncurrentVersionExpiration.IsDaysNull())
+						require.True(t, bool(config.Rules[0].Expiration.DeleteMarker))
+						return nil
+					})
+			},
+			want: model.User{
+				ID:     123,
+				Bucket: "bucketuid123",
+			},
+			wantErr: false,
+		},
+		{
+			name: "VersioningFailed",
+			f: &FileRepo{
+				db:       mockMinio,
+				log:      mockLogger,
+				ctx:      &ctx,
+				location: "us-east-1",
+			},
+			args: args{
+				ctx:  &ctx,
+				user: &model.User{ID: 123},
+			},
+			setupMocks: func() {
+				bucketName := "bucketuid" + strconv.Itoa(123)
+				mockMinio.EXPECT().MakeBucket(gomock.Any(), bucketName, gomock.Any()).Return(nil)
+				mockMinio.EXPECT().EnableVersioning(gomock.Any(), bucketName).Return(errors.New("not implemented"))
+			},
+			want:        model.User{ID: 123, Bucket: "bucketuid123"},
+			wantErr:     true,
+			expectedErr: model.ErrCreateBucketFailed,
+		},
+		{
+			name: "LifecycleFailed",
+			f: &FileRepo{
+				db:       mockMinio,
+				log:      mockLogger,
+				ctx:      &ctx,
+				location: "us-east-1",
+			},
+			args: args{
+				ctx:  &ctx,
+				user: &model.User{ID: 123},
+			},
+			setupMocks: func() {
+				bucketName := "bucketuid" + strconv.Itoa(123)
+				mockMinio.EXPECT().MakeBucket(gomock.Any(), bucketName, gomock.Any()).Return(nil)
+				mockMinio.EXPECT().EnableVersioning(gomock.Any(), bucketName).Return(nil)
+				mockMinio.EXPECT().SetBucketLifecycle(gomock.Any(), bucketName, gomock.Any()).Return(errors.New("access denied"))
+			},
+			want:        model.User{ID: 123, Bucket: "bucketuid123"},
+			wantErr:     true,
+			expectedErr: model.ErrCreateBucketFailed,
+		},
 		{
 			name: "BucketCreationFailed",
 			f: &FileRepo{
@@ -227,7 +309,7 @@ func TestFileRepo_GetFile(t *testing.T) {
 		f           *FileRepo
 		args        args
 		setupMocks  func()
-		want        *os.File
+		want        io.ReadSeekCloser
 		wantErr     bool
 		expectedErr bool
 	}{
@@ -311,25 +393,34 @@ func TestFileRepo_GetFile(t *testing.T) {
 				bucketName := "bucketuid" + strconv.Itoa(123)
 				// mock := io.NopCloser(bytes.NewReader([]byte("test content")))
 				mockMinio.EXPECT().GetObject(gomock.Any(), bucketName, "file123", gomock.Any()).Return(mockMinioObject, nil)
-				mockMinioObject.EXPECT().Read(gomock.Any()).Return(1, nil)
-				mockMinioObject.EXPECT().Close()
-
-				// Mock sequential reads for the object
-				gomock.InOrder(
-					mockMinioObject.EXPECT().Read(gomock.Any()).DoAndReturn(func(p []byte) (int, error) {
-						copy(p, "test data")
-						return len("test data"), nil // First call returns data
-					}),
-					mockMinioObject.EXPECT().Read(gomock.Any()).Return(0, io.EOF), // Second call returns EOF
-					// mockMinioObject.EXPECT().Close().Return(nil),
-				)
-				// mockMinioObject.EXPECT().Close().Return(nil)
-
+				mockMinioObject.EXPECT().Stat().Return(minio.ObjectInfo{Key: "file123", Size: 9}, nil)
 			},
-			want:        nil,
+			want:        mockMinioObject,
 			wantErr:     false,
 			expectedErr: false,
 		},
+		{
+			name: "NoSuchKey",
+			f: &FileRepo{
+				db:       mockMinio,
+				log:      mockLogger,
+				ctx:      &ctx,
+				location: "us-east-1",
+			},
+			args: args{
+				ctx:    &ctx,
+				fileID: "file123",
+				user:   &model.User{ID: 123},
+			},
+			setupMocks: func() {
+				mockMinio.EXPECT().GetObject(gomock.Any(), "bucketuid123", "file123", gomock.Any()).Return(mockMinioObject, nil)
+				mockMinioObject.EXPECT().Stat().Return(minio.ObjectInfo{}, minio.ErrorResponse{Code: "NoSuchKey"})
+				mockMinioObject.EXPECT().Close()
+			},
+			want:        nil,
+			wantErr:     true,
+			expectedErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -343,18 +434,45 @@ func TestFileRepo_GetFile(t *testing.T) {
 			if (err != nil) && !tt.expectedErr {
 				t.Errorf("FileRepo.GetFile() error = %v, expectedErr %v", err, tt.expectedErr)
 			}
-			if !tt.wantErr && got != nil {
-				if _, err := os.Stat(got.Name()); os.IsNotExist(err) {
-					t.Errorf("FileRepo.GetFile() = file does not exist")
-				} else {
-					os.Remove(got.Name()) // Clean up after test
-				}
+			// объект отдается как есть, без копии на диске
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("FileRepo.GetFile() = %v, want %v", got, tt.want)
 			}
 
 		})
 	}
 }
 
+func TestFileRepo_GetFileVersion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMinio := mocks.NewMockMinioClient(ctrl)
+	mockMinioObject := mocks.NewMockMinioObject(ctrl)
+	f := &FileRepo{db: mockMinio, log: logrus.New()}
+	user := &model.User{ID: 123}
+
+	mockMinio.EXPECT().
+		GetObject(gomock.Any(), "bucketuid123", "file123", minio.GetObjectOptions{VersionID: "v1"}).
+		Return(mockMinioObject, nil)
+	mockMinioObject.EXPECT().Stat().Return(minio.ObjectInfo{Key: "file123", VersionID: "v1"}, nil)
+	mockMinioObject.EXPECT().Read(gomock.Any()).DoAndReturn(func(p []byte) (int, error) {
+		return copy(p, "old data"), io.EOF
+	})
+	file, err := f.GetFileVersion(context.Background(), "file123", "v1", user)
+	require.NoError(t, err)
+	data, err := io.ReadAll(file)
+	require.NoError(t, err)
+	require.Equal(t, "old data", string(data))
+
+	// MinIO отдает ошибку версии при первом обращении к объекту
+	mockMinio.EXPECT().GetObject(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockMinioObject, nil)
+	mockMinioObject.EXPECT().Stat().Return(minio.ObjectInfo{}, minio.ErrorResponse{Code: "NoSuchVersion"})
+	mockMinioObject.EXPECT().Close()
+	_, err = f.GetFileVersion(context.Background(), "file123", "v0", user)
+	require.ErrorIs(t, err, model.ErrFileVersionMissing)
+}
+
 func TestFileRepo_DeleteFile(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -394,8 +512,14 @@ func TestFileRepo_DeleteFile(t *testing.T) {
 			setupMocks: func() {
 				bucketName := "bucketuid" + strconv.Itoa(123)
 
+				// файл переносится в корзину
 				mockMinio.EXPECT().
-					RemoveObject(gomock.Any(), bucketName, "file123", gomock.Any()).
+					CopyObject(gomock.Any(),
+						minio.CopyDestOptions{Bucket: bucketName, Object: ".trash/file123"},
+						minio.CopySrcOptions{Bucket: bucketName, Object: "file123"}).
+					Return(minio.UploadInfo{}, nil)
+				mockMinio.EXPECT().
+					RemoveObject(gomock.Any(), bucketName, "file123", minio.RemoveObjectOptions{}).
 					Return(nil) // Успешное удаление
 			},
 			wantErr: false,
@@ -416,6 +540,9 @@ func TestFileRepo_DeleteFile(t *testing.T) {
 			setupMocks: func() {
 				bucketName := "bucketuid" + strconv.Itoa(123)
 
+				mockMinio.EXPECT().
+					CopyObject(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(minio.UploadInfo{}, nil)
 				mockMinio.EXPECT().
 					RemoveObject(gomock.Any(), bucketName, "file123", gomock.Any()).
 					Return(fmt.Errorf("mock remove object error")) // Возврат ошибки
@@ -434,6 +561,238 @@ func TestFileRepo_DeleteFile(t *testing.T) {
 			}
 		})
 	}
+
+	f := &FileRepo{db: mockMinio, log: mockLogger, ctx: &ctx}
+	mockMinio.EXPECT().
+		CopyObject(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(minio.UploadInfo{}, minio.ErrorResponse{Code: "NoSuchKey"})
+	require.ErrorIs(t, f.DeleteFile(ctx, "missing", &model.User{ID: 123}), model.ErrFileNotFound)
+}
+
+func TestFileRepo_RestoreFile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMinio := mocks.NewMockMinioClient(ctrl)
+	f := &FileRepo{db: mockMinio, log: logrus.New()}
+	user := &model.User{ID: 123}
+	free := func() {
+		mockMinio.EXPECT().
+			ListObjects(gomock.Any(), "bucketuid123", minio.ListObjectsOptions{Prefix: "file123", Recursive: true}).
+			Return(objectsChan(minio.ObjectInfo{Key: "file1234"}))
+	}
+
+	free()
+	mockMinio.EXPECT().
+		CopyObject(gomock.Any(),
+			minio.CopyDestOptions{Bucket: "bucketuid123", Object: "file123"},
+			minio.CopySrcOptions{Bucket: "bucketuid123", Object: ".trash/file123"}).
+		Return(minio.UploadInfo{}, nil)
+	mockMinio.EXPECT().
+		ListObjects(gomock.Any(), "bucketuid123", minio.ListObjectsOptions{Prefix: ".trash/file123", WithVersions: true, WithMetadata: true}).
+		Return(objectsChan(
+			minio.ObjectInfo{Key: ".trash/file123", VersionID: "v2", IsLatest: true},
+			minio.ObjectInfo{Key: ".trash/file123.bak", VersionID: "v9"},
+			minio.ObjectInfo{Key: ".trash/file123", VersionID: "v1"},
+		))
+	mockMinio.EXPECT().
+		RemoveObject(gomock.Any(), "bucketuid123", ".trash/file123", minio.RemoveObjectOptions{VersionID: "v2"}).
+		Return(nil)
+	mockMinio.EXPECT().
+		RemoveObject(gomock.Any(), "bucketuid123", ".trash/file123", minio.RemoveObjectOptions{VersionID: "v1"}).
+		Return(nil)
+	require.NoError(t, f.RestoreFile(context.Background(), "file123", user))
+
+	free()
+	mockMinio.EXPECT().
+		CopyObject(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(minio.UploadInfo{}, minio.ErrorResponse{Code: "NoSuchKey"})
+	require.ErrorIs(t, f.RestoreFile(context.Background(), "file123", user), model.ErrTrashItemNotFound)
+
+	// имя занято файлом, загруженным после удаления
+	mockMinio.EXPECT().
+		ListObjects(gomock.Any(), "bucketuid123", minio.ListObjectsOptions{Prefix: "file123", Recursive: true}).
+		Return(objectsChan(minio.ObjectInfo{Key: "file123"}))
+	require.ErrorIs(t, f.RestoreFile(context.Background(), "file123", user), model.ErrFileExists)
+
+	free()
+	mockMinio.EXPECT().
+		CopyObject(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(minio.UploadInfo{}, errors.New("copy error"))
+	err := f.RestoreFile(context.Background(), "file123", user)
+	require.Error(t, err)
+	require.NotErrorIs(t, err, model.ErrTrashItemNotFound)
+}
+
+func TestFileRepo_ExpireVersions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMinio := mocks.NewMockMinioClient(ctrl)
+	mockBlobs := mocks.NewMockBlobRepository(ctrl)
+	f := &FileRepo{db: mockMinio, log: logrus.New()}
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	old, recent := now.AddDate(0, 0, -40), now.AddDate(0, 0, -10)
+
+	// без хранилища содержимого версии удаляет правило жизненного цикла
+	require.NoError(t, f.ExpireVersions(context.Background(), now))
+
+	f.blobs = mockBlobs
+	mockMinio.EXPECT().ListBuckets(gomock.Any()).Return([]minio.BucketInfo{{Name: "blobs"}, {Name: "bucketuid1"}}, nil)
+	mockMinio.EXPECT().SetBucketLifecycle(gomock.Any(), "bucketuid1", versionsLifecycle(false)).Return(nil)
+	mockMinio.EXPECT().
+		ListObjects(gomock.Any(), "bucketuid1", minio.ListObjectsOptions{Recursive: true, WithVersions: true, WithMetadata: true}).
+		Return(objectsChan(
+			// a.txt заменен недавно, его прежняя версия еще хранится, а предыдущая истекла
+			minio.ObjectInfo{Key: "a.txt", VersionID: "a3", IsLatest: true, LastModified: recent},
+			minio.ObjectInfo{Key: "a.txt", VersionID: "a2", LastModified: old,
+				UserMetadata: minio.StringMap{"X-Amz-Meta-Blob": "sum2"}},
+			minio.ObjectInfo{Key: "a.txt", VersionID: "a1", LastModified: old.AddDate(0, 0, -1),
+				UserMetadata: minio.StringMap{"X-Amz-Meta-Blob": "sum1"}},
+			// b.txt удален давно, удаленная версия истекла
+			minio.ObjectInfo{Key: "b.txt", VersionID: "m1", IsLatest: true, IsDeleteMarker: true, LastModified: old},
+			minio.ObjectInfo{Key: "b.txt", VersionID: "b1", LastModified: old.AddDate(0, 0, -1)},
+			minio.ObjectInfo{Key: "c.txt", VersionID: "c1", IsLatest: true, LastModified: old},
+		))
+	mockMinio.EXPECT().RemoveObject(gomock.Any(), "bucketuid1", "a.txt", minio.RemoveObjectOptions{VersionID: "a1"}).Return(nil)
+	mockBlobs.EXPECT().Unref(gomock.Any(), "sum1").Return(nil)
+	mockMinio.EXPECT().RemoveObject(gomock.Any(), "bucketuid1", "b.txt", minio.RemoveObjectOptions{VersionID: "b1"}).Return(nil)
+	require.NoError(t, f.ExpireVersions(context.Background(), now))
+
+	mockMinio.EXPECT().ListBuckets(gomock.Any()).Return([]minio.BucketInfo{{Name: "bucketuid1"}}, nil)
+	mockMinio.EXPECT().SetBucketLifecycle(gomock.Any(), "bucketuid1", gomock.Any()).Return(errors.New("access denied"))
+	require.Error(t, f.ExpireVersions(context.Background(), now))
+}
+
+func TestFileRepo_PurgeFile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMinio := mocks.NewMockMinioClient(ctrl)
+	f := &FileRepo{db: mockMinio, log: logrus.New()}
+	user := &model.User{ID: 123}
+
+	t.Run("Deleted", func(t *testing.T) {
+		mockMinio.EXPECT().
+			ListObjects(gomock.Any(), "bucketuid123", minio.ListObjectsOptions{Prefix: ".trash/file123", WithVersions: true, WithMetadata: true}).
+			Return(objectsChan(minio.ObjectInfo{Key: ".trash/file123", VersionID: "t1", IsLatest: true}))
+		mockMinio.EXPECT().
+			RemoveObject(gomock.Any(), "bucketuid123", ".trash/file123", minio.RemoveObjectOptions{VersionID: "t1"}).
+			Return(nil)
+		// под исходным именем лежит маркер удаления и прежние версии
+		mockMinio.EXPECT().
+			ListObjects(gomock.Any(), "bucketuid123", minio.ListObjectsOptions{Prefix: "file123", WithVersions: true, WithMetadata: true}).
+			Return(objectsChan(
+				minio.ObjectInfo{Key: "file123", VersionID: "m1", IsLatest: true, IsDeleteMarker: true},
+				minio.ObjectInfo{Key: "file123", VersionID: "v1"},
+			))
+		mockMinio.EXPECT().
+			RemoveObject(gomock.Any(), "bucketuid123", "file123", minio.RemoveObjectOptions{VersionID: "m1"}).
+			Return(nil)
+		mockMinio.EXPECT().
+			RemoveObject(gomock.Any(), "bucketuid123", "file123", minio.RemoveObjectOptions{VersionID: "v1"}).
+			Return(nil)
+		require.NoError(t, f.PurgeFile(context.Background(), "file123", user))
+	})
+
+	t.Run("UploadedAgain", func(t *testing.T) {
+		mockMinio.EXPECT().
+			ListObjects(gomock.Any(), "bucketuid123", minio.ListObjectsOptions{Prefix: ".trash/file123", WithVersions: true, WithMetadata: true}).
+			Return(objectsChan())
+		mockMinio.EXPECT().
+			ListObjects(gomock.Any(), "bucketuid123", minio.ListObjectsOptions{Prefix: "file123", WithVersions: true, WithMetadata: true}).
+			Return(objectsChan(minio.ObjectInfo{Key: "file123", VersionID: "v2", IsLatest: true}))
+		require.NoError(t, f.PurgeFile(context.Background(), "file123", user))
+	})
+
+	t.Run("RemoveError", func(t *testing.T) {
+		mockMinio.EXPECT().
+			ListObjects(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(objectsChan(minio.ObjectInfo{Key: ".trash/file123", VersionID: "t1"}))
+		mockMinio.EXPECT().RemoveObject(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("remove error"))
+		require.Error(t, f.PurgeFile(context.Background(), "file123", user))
+	})
+}
+
+func TestFileRepo_ListFileVersions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMinio := mocks.NewMockMinioClient(ctrl)
+	f := &FileRepo{db: mockMinio, log: logrus.New()}
+	modified := time.Unix(1714557600, 0)
+
+	mockMinio.EXPECT().
+		ListObjects(gomock.Any(), "bucketuid123", minio.ListObjectsOptions{Prefix: "file123", WithVersions: true, WithMetadata: true}).
+		Return(objectsChan(
+			minio.ObjectInfo{Key: "file123", VersionID: "v2", ETag: "e2", Size: 20, LastModified: modified, IsLatest: true},
+			minio.ObjectInfo{Key: "file123", VersionID: "m1", IsDeleteMarker: true},
+			minio.ObjectInfo{Key: "file123", VersionID: "v1", ETag: "e1", Size: 10},
+		))
+	versions, err := f.ListFileVersions(context.Background(), "file123", &model.User{ID: 123})
+	require.NoError(t, err)
+	require.Equal(t, []model.FileVersion{
+		{VersionID: "v2", Hash: "e2", Size: 20, ModifiedAt: modified, Latest: true},
+		{VersionID: "v1", Hash: "e1", Size: 10},
+	}, versions)
+
+	mockMinio.EXPECT().
+		ListObjects(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(objectsChan(minio.ObjectInfo{Err: errors.New("list error")}))
+	_, err = f.ListFileVersions(context.Background(), "file123", &model.User{ID: 123})
+	require.Error(t, err)
+
+	_, err = f.ListFileVersions(context.Background(), "file123", &model.User{})
+	require.ErrorIs(t, err, model.ErrCreateBucketNoUser)
+}
+
+func TestFileRepo_RestoreFileVersion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMinio := mocks.NewMockMinioClient(ctrl)
+	f := &FileRepo{db: mockMinio, log: logrus.New()}
+	user := &model.User{ID: 123}
+
+	listing := minio.ListObjectsOptions{Prefix: "file123", WithVersions: true, WithMetadata: true}
+
+	mockMinio.EXPECT().
+		ListObjects(gomock.Any(), "bucketuid123", listing).
+		Return(objectsChan(
+			minio.ObjectInfo{Key: "file123", VersionID: "v2", IsLatest: true},
+			minio.ObjectInfo{Key: "file123", VersionID: "v1"},
+		))
+	mockMinio.EXPECT().
+		CopyObject(gomock.Any(),
+			minio.CopyDestOptions{Bucket: "bucketuid123", Object: "file123"},
+			minio.CopySrcOptions{Bucket: "bucketuid123", Object: "file123", VersionID: "v1"}).
+		Return(minio.UploadInfo{}, nil)
+	require.NoError(t, f.RestoreFileVersion(context.Background(), "file123", "v1", user))
+
+	// неизвестная версия
+	mockMinio.EXPECT().
+		ListObjects(gomock.Any(), "bucketuid123", listing).
+		Return(objectsChan(minio.ObjectInfo{Key: "file123", VersionID: "v2", IsLatest: true}))
+	require.ErrorIs(t, f.RestoreFileVersion(context.Background(), "file123", "v0", user), model.ErrFileVersionMissing)
+
+	// файл удален
+	mockMinio.EXPECT().
+		ListObjects(gomock.Any(), "bucketuid123", listing).
+		Return(objectsChan(
+			minio.ObjectInfo{Key: "file123", VersionID: "m1", IsLatest: true, IsDeleteMarker: true},
+			minio.ObjectInfo{Key: "file123", VersionID: "v1"},
+		))
+	require.ErrorIs(t, f.RestoreFileVersion(context.Background(), "file123", "v1", user), model.ErrFileVersionMissing)
+}
+
+// objectsChan returns a closed channel with the objects, as ListObjects does after listing
+func objectsChan(objects ...minio.ObjectInfo) <-chan minio.ObjectInfo {
+	ch := make(chan minio.ObjectInfo, len(objects))
+	for _, object := range objects {
+		ch <- object
+	}
+	close(ch)
+	return ch
 }
 
 func TestFileRepo_GetFileList(t *testing.T) {
@@ -446,6 +805,7 @@ func TestFileRepo_GetFileList(t *testing.T) {
 	mockMinio := mocks.NewMockMinioClient(ctrl)
 	// mockMinioObject := mocks.NewMockMinioObject(ctrl)
 	mockLogger := logrus.New()
+	uploaded := time.Date(2024, 8, 1, 10, 0, 0, 0, time.UTC)
 
 	type args struct {
 		ctx  *context.Context
@@ -475,8 +835,10 @@ func TestFileRepo_GetFileList(t *testing.T) {
 				bucketName := "bucketuid" + strconv.Itoa(123)
 
 				// Создаем канал для эмуляции возвращаемых объектов
-				objectCh := make(chan minio.ObjectInfo, 2)
-				objectCh <- minio.ObjectInfo{Key: "file1.txt", ETag: "etag1"}
+				objectCh := make(chan minio.ObjectInfo, 3)
+				objectCh <- minio.ObjectInfo{Key: ".trash/file0.txt", ETag: "etag0"} // корзина в список не попадает
+				objectCh <- minio.ObjectInfo{Key: "file1.txt", ETag: "etag1", Size: 10, ContentType: "text/plain", LastModified: uploaded,
+					UserMetadata: minio.StringMap{"X-Amz-Meta-Sha256": "abc", "X-Amz-Meta-Device": "my+phone"}}
 				objectCh <- minio.ObjectInfo{Key: "file2.txt", ETag: "etag2"}
 				close(objectCh)
 
@@ -485,7 +847,7 @@ func TestFileRepo_GetFileList(t *testing.T) {
 					Return(objectCh)
 			},
 			want: []model.FileItem{
-				{Hash: "etag1", Name: "file1.txt"},
+				{Hash: "etag1", Name: "file1.txt", Size: 10, ContentType: "text/plain", UploadedAt: uploaded, SHA256: "abc", Device: "my phone"},
 				{Hash: "etag2", Name: "file2.txt"},
 			},
 			wantErr: false,
@@ -521,8 +883,9 @@ func TestFileRepo_GetFileList(t *testing.T) {
 			setupMocks: func() {
 				bucketName := "bucketuid" + strconv.Itoa(123)
 
-				// Эмулируем ошибку во время получения объекта
-				objectCh := make(chan minio.ObjectInfo, 1)
+				// Эмулируем ошибку посреди листинга: страница не должна молча обрезаться
+				objectCh := make(chan minio.ObjectInfo, 2)
+				objectCh <- minio.ObjectInfo{Key: "file1.txt", ETag: "etag1"}
 				objectCh <- minio.ObjectInfo{Err: fmt.Errorf("mock test")}
 				close(objectCh)
 
@@ -531,7 +894,7 @@ func TestFileRepo_GetFileList(t *testing.T) {
 					Return(objectCh)
 			},
 			want:    nil,
-			wantErr: false, // Ошибки не будет, просто файл не добавится в список
+			wantErr: true,
 		},
 	}
 
@@ -539,7 +902,7 @@ func TestFileRepo_GetFileList(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setupMocks()
 
-			got, err := tt.f.GetFileList(*tt.args.ctx, tt.args.user)
+			got, _, err := tt.f.GetFileList(*tt.args.ctx, tt.args.user, model.ListQuery{}, nil)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("FileRepo.GetFileList() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -562,6 +925,315 @@ func TestFileRepo_GetFileList(t *testing.T) {
 	}
 }
 
+func TestFileRepo_GetFileListPage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMinio := mocks.NewMockMinioClient(ctrl)
+	ctx := context.Background()
+	f := NewFileRepository(mockMinio, logrus.New(), &ctx)
+	user := &model.User{ID: 123}
+
+	// MinIO отдает объекты по возрастанию имени, начиная после StartAfter
+	listing := func(startAfter string, keys ...string) {
+		mockMinio.EXPECT().
+			ListObjects(gomock.Any(), "bucketuid123", minio.ListObjectsOptions{Recursive: true, WithMetadata: true, StartAfter: startAfter}).
+			DoAndReturn(func(ctx context.Context, _ string, _ minio.ListObjectsOptions) <-chan minio.ObjectInfo {
+				ch := make(chan minio.ObjectInfo)
+				go func() {
+					defer close(ch)
+					for _, k := range keys {
+						select {
+						case ch <- minio.ObjectInfo{Key: k, ETag: "etag-" + k}:
+						case <-ctx.Done():
+							return
+						}
+					}
+				}()
+				return ch
+			})
+	}
+	names := func(items []model.FileItem) []string {
+		var res []string
+		for _, it := range items {
+			res = append(res, it.Name)
+		}
+		return res
+	}
+	meta := map[string]model.ItemMeta{
+		"b.jpg": {Tags: []string{"trip"}},
+		"d.jpg": {Tags: []string{"trip"}, Fields: map[string]string{"place": "Kazan"}},
+	}
+
+	t.Run("Asc", func(t *testing.T) {
+		listing("", "a.txt", "b.jpg", "c.txt", "d.jpg")
+		got, next, err := f.GetFileList(ctx, user, model.ListQuery{Limit: 2}, meta)
+		require.NoError(t, err)
+		require.Equal(t, []string{"a.txt", "b.jpg"}, names(got))
+		require.Equal(t, []string{"trip"}, got[1].Tags)
+		require.Equal(t, &model.ListCursor{Key: "b.jpg"}, next)
+
+		listing("b.jpg", "c.txt", "d.jpg")
+		got, next, err = f.GetFileList(ctx, user, model.ListQuery{Limit: 2, After: next}, meta)
+		require.NoError(t, err)
+		require.Equal(t, []string{"c.txt", "d.jpg"}, names(got))
+		require.Nil(t, next)
+	})
+
+	t.Run("Desc", func(t *testing.T) {
+		listing("", "a.txt", "b.jpg", "c.txt", "d.jpg")
+		got, next, err := f.GetFileList(ctx, user, model.ListQuery{Limit: 3, Desc: true}, meta)
+		require.NoError(t, err)
+		require.Equal(t, []string{"d.jpg", "c.txt", "b.jpg"}, names(got))
+		require.Equal(t, &model.ListCursor{Key: "b.jpg"}, next)
+
+		listing("", "a.txt", "b.jpg", "c.txt", "d.jpg")
+		got, next, err = f.GetFileList(ctx, user, model.ListQuery{Limit: 3, Desc: true, After: next}, meta)
+		require.NoError(t, err)
+		require.Equal(t, []string{"a.txt"}, names(got))
+		require.Nil(t, next)
+	})
+
+	t.Run("Filters", func(t *testing.T) {
+		listing("", "a.txt", "b.jpg", "c.txt", "d.jpg")
+		got, next, err := f.GetFileList(ctx, user, model.ListQuery{Types: []string{"jpg"}, Tags: []string{"trip"}}, meta)
+		require.NoError(t, err)
+		require.Equal(t, []string{"b.jpg", "d.jpg"}, names(got))
+		require.Equal(t, map[string]string{"place": "Kazan"}, got[1].Meta)
+		require.Nil(t, next)
+	})
+}
+
+func TestFileRepo_GetFileListBrowse(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMinio := mocks.NewMockMinioClient(ctrl)
+	ctx := context.Background()
+	f := NewFileRepository(mockMinio, logrus.New(), &ctx)
+	user := &model.User{ID: 123}
+
+	// без Recursive вложенные папки приходят общими префиксами, маркер самой папки - объектом
+	mockMinio.EXPECT().
+		ListObjects(gomock.Any(), "bucketuid123", minio.ListObjectsOptions{Prefix: "work/", WithMetadata: true}).
+		Return(objectsChan(
+			minio.ObjectInfo{Key: "work/"},
+			minio.ObjectInfo{Key: "work/2024/"},
+			minio.ObjectInfo{Key: "work/a.txt", ETag: "e1"},
+			minio.ObjectInfo{Key: "work/b.txt", ETag: "e2"},
+		))
+	got, next, err := f.GetFileList(ctx, user, model.ListQuery{Browse: true, Parent: "work", Limit: 2}, nil)
+	require.NoError(t, err)
+	require.Equal(t, []model.FileItem{{Name: "work/2024", IsFolder: true}, {Hash: "e1", Name: "work/a.txt"}}, got)
+	require.Equal(t, &model.ListCursor{Key: "work/a.txt"}, next)
+
+	mockMinio.EXPECT().
+		ListObjects(gomock.Any(), "bucketuid123", minio.ListObjectsOptions{WithMetadata: true}).
+		Return(objectsChan(minio.ObjectInfo{Key: ".trash/"}, minio.ObjectInfo{Key: "work/"}))
+	got, next, err = f.GetFileList(ctx, user, model.ListQuery{Browse: true, Limit: 1}, nil)
+	require.NoError(t, err)
+	require.Equal(t, []model.FileItem{{Name: "work", IsFolder: true}}, got)
+	require.Nil(t, next)
+
+	// в общем списке маркеры пустых папок не показываются
+	mockMinio.EXPECT().
+		ListObjects(gomock.Any(), "bucketuid123", minio.ListObjectsOptions{Recursive: true, WithMetadata: true}).
+		Return(objectsChan(minio.ObjectInfo{Key: "empty/"}, minio.ObjectInfo{Key: "work/a.txt", ETag: "e1"}))
+	got, _, err = f.GetFileList(ctx, user, model.ListQuery{}, nil)
+	require.NoError(t, err)
+	require.Equal(t, []model.FileItem{{Hash: "e1", Name: "work/a.txt"}}, got)
+}
+
+func TestFileRepo_CreateFolder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMinio := mocks.NewMockMinioClient(ctrl)
+	ctx := context.Background()
+	f := NewFileRepository(mockMinio, logrus.New(), &ctx)
+	user := &model.User{ID: 123}
+	listing := minio.ListObjectsOptions{Prefix: "work", Recursive: true}
+
+	mockMinio.EXPECT().ListObjects(gomock.Any(), "bucketuid123", listing).
+		Return(objectsChan(minio.ObjectInfo{Key: "workshop.txt"}))
+	mockMinio.EXPECT().
+		PutObject(gomock.Any(), "bucketuid123", "work/", gomock.Any(), int64(0), minio.PutObjectOptions{ContentType: folderContentType}).
+		Return(minio.UploadInfo{}, nil)
+	require.NoError(t, f.CreateFolder(ctx, "work", user))
+
+	mockMinio.EXPECT().ListObjects(gomock.Any(), "bucketuid123", listing).
+		Return(objectsChan(minio.ObjectInfo{Key: "work"}))
+	require.ErrorIs(t, f.CreateFolder(ctx, "work", user), model.ErrFileExists)
+
+	require.ErrorIs(t, f.CreateFolder(ctx, "work", &model.User{}), model.ErrNoUserBucket)
+}
+
+func TestFileRepo_Usage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMinio := mocks.NewMockMinioClient(ctrl)
+	ctx := context.Background()
+	f := NewFileRepository(mockMinio, logrus.New(), &ctx)
+	user := &model.User{ID: 123}
+	listing := minio.ListObjectsOptions{Recursive: true, WithMetadata: true, WithVersions: true}
+
+	mockMinio.EXPECT().ListObjects(gomock.Any(), "bucketuid123", listing).
+		Return(objectsChan(
+			minio.ObjectInfo{Key: ".trash/old.txt", Size: 5, IsLatest: true},
+			minio.ObjectInfo{Key: "a.txt", Size: 10, IsLatest: true},
+			// прежняя версия занимает место, но не считается файлом
+			minio.ObjectInfo{Key: "a.txt", Size: 7},
+			minio.ObjectInfo{Key: "deleted.txt", IsDeleteMarker: true, IsLatest: true},
+			minio.ObjectInfo{Key: "deleted.txt", Size: 3},
+			minio.ObjectInfo{Key: "work/", IsLatest: true},
+			minio.ObjectInfo{Key: "work/b.txt", Size: 20, IsLatest: true},
+		))
+	usage, err := f.Usage(ctx, user)
+	require.NoError(t, err)
+	require.Equal(t, model.Usage{Bytes: 45, Files: 3}, usage)
+
+	mockMinio.EXPECT().ListObjects(gomock.Any(), "bucketuid123", listing).
+		Return(objectsChan(minio.ObjectInfo{Err: errors.New("minio error")}))
+	_, err = f.Usage(ctx, user)
+	require.Error(t, err)
+
+	_, err = f.Usage(ctx, &model.User{})
+	require.ErrorIs(t, err, model.ErrNoUserBucket)
+}
+
+func TestFileRepo_ReplacedUsage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMinio := mocks.NewMockMinioClient(ctrl)
+	ctx := context.Background()
+	f := NewFileRepository(mockMinio, logrus.New(), &ctx)
+	user := &model.User{ID: 123}
+	versions := func(key string, objects ...minio.ObjectInfo) *gomock.Call {
+		return mockMinio.EXPECT().
+			ListObjects(gomock.Any(), "bucketuid123", minio.ListObjectsOptions{Prefix: key, WithVersions: true, WithMetadata: true}).
+			Return(objectsChan(objects...))
+	}
+
+	t.Run("Overwrite", func(t *testing.T) {
+		versions("a.txt",
+			minio.ObjectInfo{Key: "a.txt", Size: 10, IsLatest: true},
+			minio.ObjectInfo{Key: "a.txt", Size: 7},
+			minio.ObjectInfo{Key: "a.txt.bak", Size: 1, IsLatest: true},
+		)
+		versions(".trash/a.txt")
+		usage, err := f.ReplacedUsage(ctx, "a.txt", user)
+		require.NoError(t, err)
+		require.Equal(t, model.Usage{Files: 1}, usage)
+	})
+
+	t.Run("Trash", func(t *testing.T) {
+		versions("a.txt", minio.ObjectInfo{Key: "a.txt", IsDeleteMarker: true, IsLatest: true})
+		versions(".trash/a.txt",
+			minio.ObjectInfo{Key: ".trash/a.txt", Size: 10, IsLatest: true},
+			minio.ObjectInfo{Key: ".trash/a.txt", Size: 7},
+		)
+		usage, err := f.ReplacedUsage(ctx, "a.txt", user)
+		require.NoError(t, err)
+		require.Equal(t, model.Usage{Bytes: 17, Files: 1}, usage)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		versions("a.txt", minio.ObjectInfo{Err: errors.New("minio error")})
+		_, err := f.ReplacedUsage(ctx, "a.txt", user)
+		require.Error(t, err)
+	})
+}
+
+func TestFileRepo_MovedUsage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMinio := mocks.NewMockMinioClient(ctrl)
+	ctx := context.Background()
+	f := NewFileRepository(mockMinio, logrus.New(), &ctx)
+	user := &model.User{ID: 123}
+
+	mockMinio.EXPECT().
+		ListObjects(gomock.Any(), "bucketuid123", minio.ListObjectsOptions{Prefix: "work", Recursive: true, WithMetadata: true}).
+		Return(objectsChan(
+			minio.ObjectInfo{Key: "work/"},
+			minio.ObjectInfo{Key: "work/a.txt", Size: 10},
+			minio.ObjectInfo{Key: "work/b.txt", Size: 0, UserMetadata: minio.StringMap{"X-Amz-Meta-Blob": "abc", "X-Amz-Meta-Size": "7"}},
+			minio.ObjectInfo{Key: "workshop.txt", Size: 100},
+		))
+	usage, err := f.MovedUsage(ctx, "work", user)
+	require.NoError(t, err)
+	require.Equal(t, model.Usage{Bytes: 17}, usage)
+
+	mockMinio.EXPECT().
+		ListObjects(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(objectsChan(minio.ObjectInfo{Err: errors.New("minio error")}))
+	_, err = f.MovedUsage(ctx, "work", user)
+	require.Error(t, err)
+
+	_, err = f.MovedUsage(ctx, "work", &model.User{})
+	require.ErrorIs(t, err, model.ErrNoUserBucket)
+}
+
+func TestFileRepo_MoveFile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMinio := mocks.NewMockMinioClient(ctrl)
+	ctx := context.Background()
+	f := NewFileRepository(mockMinio, logrus.New(), &ctx)
+	user := &model.User{ID: 123}
+	listing := func(prefix string, keys ...string) {
+		objects := make([]minio.ObjectInfo, 0, len(keys))
+		for _, k := range keys {
+			objects = append(objects, minio.ObjectInfo{Key: k})
+		}
+		mockMinio.EXPECT().
+			ListObjects(gomock.Any(), "bucketuid123", minio.ListObjectsOptions{Prefix: prefix, Recursive: true}).
+			Return(objectsChan(objects...))
+	}
+	move := func(from, to string) {
+		mockMinio.EXPECT().
+			CopyObject(gomock.Any(), minio.CopyDestOptions{Bucket: "bucketuid123", Object: to}, minio.CopySrcOptions{Bucket: "bucketuid123", Object: from}).
+			Return(minio.UploadInfo{}, nil)
+		mockMinio.EXPECT().RemoveObject(gomock.Any(), "bucketuid123", from, minio.RemoveObjectOptions{}).Return(nil)
+		mockMinio.EXPECT().
+			ListObjects(gomock.Any(), "bucketuid123", minio.ListObjectsOptions{Prefix: ".trash/" + to, WithVersions: true, WithMetadata: true}).
+			Return(objectsChan())
+	}
+
+	t.Run("Folder", func(t *testing.T) {
+		listing("work", "work/", "work/a.txt", "work/2024/b.txt", "workshop.txt")
+		listing("archive/work")
+		move("work/", "archive/work/")
+		move("work/a.txt", "archive/work/a.txt")
+		move("work/2024/b.txt", "archive/work/2024/b.txt")
+
+		moves, err := f.MoveFile(ctx, "work", "archive/work", user)
+		require.NoError(t, err)
+		require.Equal(t, []model.FileMove{
+			{From: "work/", To: "archive/work/"},
+			{From: "work/a.txt", To: "archive/work/a.txt"},
+			{From: "work/2024/b.txt", To: "archive/work/2024/b.txt"},
+		}, moves)
+	})
+
+	t.Run("Exists", func(t *testing.T) {
+		listing("a.txt", "a.txt")
+		listing("b.txt", "b.txt")
+		_, err := f.MoveFile(ctx, "a.txt", "b.txt", user)
+		require.ErrorIs(t, err, model.ErrFileExists)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		listing("a.txt", "a.txt.bak")
+		_, err := f.MoveFile(ctx, "a.txt", "b.txt", user)
+		require.ErrorIs(t, err, model.ErrFileNotFound)
+	})
+}
+
 func TestFileRepo_UploadFile(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -580,8 +1252,18 @@ func TestFileRepo_UploadFile(t *testing.T) {
 			"testfile.txt",
 			gomock.Any(),
 			int64(-1), //-1 означает неизвестный размер)
-			gomock.Any(),
+			minio.PutObjectOptions{
+				PartSize:     uploadPartSize,
+				ContentType:  "text/plain; charset=utf-8",
+				UserMetadata: map[string]string{"sha256": "abc", "device": "my+phone"},
+			},
 		).Return(minio.UploadInfo{}, nil)
+	mockMinioClient.EXPECT().
+		ListObjects(gomock.Any(), "bucketuid1", minio.ListObjectsOptions{Prefix: ".trash/testfile.txt", WithVersions: true, WithMetadata: true}).
+		Return(objectsChan(minio.ObjectInfo{Key: ".trash/testfile.txt", VersionID: "v1"}))
+	mockMinioClient.EXPECT().
+		RemoveObject(gomock.Any(), "bucketuid1", ".trash/testfile.txt", minio.RemoveObjectOptions{VersionID: "v1"}).
+		Return(nil)
 
 	type args struct {
 		ctx        *context.Context
@@ -635,7 +1317,8 @@ func TestFileRepo_UploadFile(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setupMocks()
-			err := tt.f.UploadFile(*tt.args.ctx, tt.args.user, tt.args.objectName, tt.args.file)
+			file := &model.FileItem{Name: tt.args.objectName, Size: -1, SHA256: "abc", Device: "my phone"}
+			err := tt.f.UploadFile(*tt.args.ctx, tt.args.user, file, tt.args.file)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("FileRepo.UploadFile() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -643,6 +1326,107 @@ func TestFileRepo_UploadFile(t *testing.T) {
 	}
 }
 
+func TestFileRepo_Dedup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMinio := mocks.NewMockMinioClient(ctrl)
+	mockMinioObject := mocks.NewMockMinioObject(ctrl)
+	mockBlobs := mocks.NewMockBlobRepository(ctrl)
+	f := NewFileRepository(mockMinio, logrus.New(), nil)
+	f.SetBlobs(mockBlobs, true)
+	user := &model.User{ID: 1}
+	pointer := map[string]string{"X-Amz-Meta-Blob": "sum", "X-Amz-Meta-Size": "5"}
+
+	t.Run("Upload", func(t *testing.T) {
+		content := strings.NewReader("hello")
+		mockBlobs.EXPECT().Put(gomock.Any(), "", int64(-1), content).Return(model.Blob{SHA256: "sum", Size: 5}, nil)
+		// объект файла пустой, содержимое только в хранилище блобов
+		mockMinio.EXPECT().
+			PutObject(gomock.Any(), "bucketuid1", "a.txt", gomock.Any(), int64(0), minio.PutObjectOptions{
+				PartSize:     uploadPartSize,
+				ContentType:  "text/plain; charset=utf-8",
+				UserMetadata: map[string]string{"sha256": "sum", "device": "", "blob": "sum", "size": "5"},
+			}).Return(minio.UploadInfo{}, nil)
+		mockMinio.EXPECT().
+			ListObjects(gomock.Any(), "bucketuid1", minio.ListObjectsOptions{Prefix: ".trash/a.txt", WithVersions: true, WithMetadata: true}).
+			Return(objectsChan())
+		require.NoError(t, f.UploadFile(context.Background(), user, &model.FileItem{Name: "a.txt", Size: -1}, content))
+	})
+
+	t.Run("UploadFailed", func(t *testing.T) {
+		mockBlobs.EXPECT().Put(gomock.Any(), "sum", int64(5), gomock.Any()).Return(model.Blob{SHA256: "sum", Size: 5}, nil)
+		mockMinio.EXPECT().PutObject(gomock.Any(), "bucketuid1", "a.txt", gomock.Any(), int64(0), gomock.Any()).
+			Return(minio.UploadInfo{}, errors.New("put error"))
+		mockBlobs.EXPECT().Unref(gomock.Any(), "sum").Return(nil)
+		require.Error(t, f.UploadFile(context.Background(), user, &model.FileItem{Name: "a.txt", Size: 5, SHA256: "sum"}, nil))
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		blob := mocks.NewMockMinioObject(ctrl)
+		mockMinio.EXPECT().GetObject(gomock.Any(), "bucketuid1", "a.txt", minio.GetObjectOptions{}).Return(mockMinioObject, nil)
+		mockMinioObject.EXPECT().Stat().Return(minio.ObjectInfo{Key: "a.txt", UserMetadata: pointer}, nil)
+		mockMinioObject.EXPECT().Close().Return(nil)
+		mockBlobs.EXPECT().Get(gomock.Any(), "sum").Return(blob, nil)
+
+		got, err := f.GetFile(context.Background(), "a.txt", user)
+		require.NoError(t, err)
+		require.Equal(t, blob, got)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		// копия в корзине - еще одна ссылка на содержимое
+		mockMinio.EXPECT().GetObject(gomock.Any(), "bucketuid1", "a.txt", minio.GetObjectOptions{}).Return(mockMinioObject, nil)
+		mockMinioObject.EXPECT().Stat().Return(minio.ObjectInfo{Key: "a.txt", UserMetadata: pointer}, nil)
+		mockMinioObject.EXPECT().Close().Return(nil)
+		mockBlobs.EXPECT().Ref(gomock.Any(), "sum").Return(nil)
+		mockMinio.EXPECT().CopyObject(gomock.Any(), gomock.Any(), gomock.Any()).Return(minio.UploadInfo{}, nil)
+		mockMinio.EXPECT().RemoveObject(gomock.Any(), "bucketuid1", "a.txt", minio.RemoveObjectOptions{}).Return(nil)
+		require.NoError(t, f.DeleteFile(context.Background(), "a.txt", user))
+	})
+
+	t.Run("CopyFailed", func(t *testing.T) {
+		mockMinio.EXPECT().GetObject(gomock.Any(), "bucketuid1", "a.txt", minio.GetObjectOptions{}).Return(mockMinioObject, nil)
+		mockMinioObject.EXPECT().Stat().Return(minio.ObjectInfo{Key: "a.txt", UserMetadata: pointer}, nil)
+		mockMinioObject.EXPECT().Close().Return(nil)
+		mockBlobs.EXPECT().Ref(gomock.Any(), "sum").Return(nil)
+		mockMinio.EXPECT().CopyObject(gomock.Any(), gomock.Any(), gomock.Any()).Return(minio.UploadInfo{}, errors.New("copy error"))
+		mockBlobs.EXPECT().Unref(gomock.Any(), "sum").Return(nil)
+		require.Error(t, f.DeleteFile(context.Background(), "a.txt", user))
+	})
+
+	t.Run("Purge", func(t *testing.T) {
+		// каждая удаленная версия снимает свою ссылку, обычный объект ссылок не держит
+		mockMinio.EXPECT().
+			ListObjects(gomock.Any(), "bucketuid1", minio.ListObjectsOptions{Prefix: ".trash/a.txt", WithVersions: true, WithMetadata: true}).
+			Return(objectsChan(minio.ObjectInfo{Key: ".trash/a.txt", VersionID: "t1", UserMetadata: pointer}))
+		mockMinio.EXPECT().
+			ListObjects(gomock.Any(), "bucketuid1", minio.ListObjectsOptions{Prefix: "a.txt", WithVersions: true, WithMetadata: true}).
+			Return(objectsChan(
+				minio.ObjectInfo{Key: "a.txt", VersionID: "m1", IsDeleteMarker: true, IsLatest: true},
+				minio.ObjectInfo{Key: "a.txt", VersionID: "v2", UserMetadata: pointer},
+				minio.ObjectInfo{Key: "a.txt", VersionID: "v1"},
+			))
+		mockMinio.EXPECT().RemoveObject(gomock.Any(), "bucketuid1", gomock.Any(), gomock.Any()).Return(nil).Times(4)
+		mockBlobs.EXPECT().Unref(gomock.Any(), "sum").Return(nil).Times(2)
+		require.NoError(t, f.PurgeFile(context.Background(), "a.txt", user))
+	})
+
+	t.Run("Versions", func(t *testing.T) {
+		mockMinio.EXPECT().
+			ListObjects(gomock.Any(), "bucketuid1", minio.ListObjectsOptions{Prefix: "a.txt", WithVersions: true, WithMetadata: true}).
+			Return(objectsChan(
+				minio.ObjectInfo{Key: "a.txt", VersionID: "v2", IsLatest: true, UserMetadata: pointer},
+				minio.ObjectInfo{Key: "a.txt", VersionID: "v1", Size: 3},
+			))
+		versions, err := f.ListFileVersions(context.Background(), "a.txt", user)
+		require.NoError(t, err)
+		require.Len(t, versions, 2)
+		require.Equal(t, int64(5), versions[0].Size)
+		require.Equal(t, int64(3), versions[1].Size)
+	})
+}
+
 // Вспомогательная функция для создания тестового файла в директории tmp
 func createTestFile(t *testing.T, fileName string) *os.File {
 	tmpDir := filepath.Join("tmp")
@@ -0,0 +1,183 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/Arcadian-Sky/datakkeeper/internal/model"
+	"github.com/sirupsen/logrus"
+)
+
+type SessionRepository interface {
+	Create(ctx context.Context, session *model.Session) error
+	Get(ctx context.Context, session *model.Session) (*model.Session, error)
+	GetByRefresh(ctx context.Context, refreshHash string) (*model.Session, error)
+	Refresh(ctx context.Context, session *model.Session, oldHash string) error
+	RevokeReused(ctx context.Context, refreshHash string) (bool, error)
+	IsActive(ctx context.Context, sid string) (bool, error)
+	List(ctx context.Context, user *model.User) ([]model.Session, error)
+	Revoke(ctx context.Context, session *model.Session) error
+}
+
+type SessionRepo struct {
+	db  *sql.DB
+	log *logrus.Logger
+}
+
+func NewSessionRepository(dbs *sql.DB, lg *logrus.Logger) *SessionRepo {
+	return &SessionRepo{
+		db:  dbs,
+		log: lg,
+	}
+}
+
+func (r *SessionRepo) Create(ctx context.Context, session *model.Session) error {
+	session.CreatedAt = time.Now().UTC()
+	query := `INSERT INTO "session" (id, user_id, refresh_hash, device_info, created_at, expires_at) VALUES ($1, $2, $3, $4, $5, $6)`
+	_, err := r.db.ExecContext(ctx, query, session.ID, session.UserID, session.RefreshHash, session.DeviceInfo, session.CreatedAt, session.ExpiresAt.UTC())
+	if err != nil {
+		r.log.WithError(err).Error("Failed to create session")
+		return err
+	}
+	return nil
+}
+
+// Get returns active session by id only if it belongs to session.UserID
+func (r *SessionRepo) Get(ctx context.Context, session *model.Session) (*model.Session, error) {
+	query := `SELECT device_info, created_at, expires_at FROM "session" WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL AND expires_at > $3`
+	res := model.Session{ID: session.ID, UserID: session.UserID}
+	err := r.db.QueryRowContext(ctx, query, session.ID, session.UserID, time.Now().UTC()).
+		Scan(&res.DeviceInfo, &res.CreatedAt, &res.ExpiresAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrSessionNotFound
+		}
+		r.log.WithError(err).Error("Failed to get session")
+		return nil, err
+	}
+	return &res, nil
+}
+
+// GetByRefresh returns active session by refresh token hash
+func (r *SessionRepo) GetByRefresh(ctx context.Context, refreshHash string) (*model.Session, error) {
+	query := `SELECT id, user_id, device_info, created_at, expires_at FROM "session" WHERE refresh_hash = $1 AND revoked_at IS NULL AND expires_at > $2`
+	session := model.Session{RefreshHash: refreshHash}
+	err := r.db.QueryRowContext(ctx, query, refreshHash, time.Now().UTC()).
+		Scan(&session.ID, &session.UserID, &session.DeviceInfo, &session.CreatedAt, &session.ExpiresAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrSessionRevoked
+		}
+		r.log.WithError(err).Error("Failed to get session")
+		return nil, err
+	}
+	return &session, nil
+}
+
+// Refresh swaps refresh token hash, oldHash guards against a concurrent reuse of the same token.
+// Старый хеш запоминается, чтобы его повторное предъявление завершило сеанс, см. RevokeReused
+func (r *SessionRepo) Refresh(ctx context.Context, session *model.Session, oldHash string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `UPDATE "session" SET refresh_hash = $1, expires_at = $2 WHERE id = $3 AND refresh_hash = $4 AND revoked_at IS NULL`
+	res, err := tx.ExecContext(ctx, query, session.RefreshHash, session.ExpiresAt.UTC(), session.ID, oldHash)
+	if err != nil {
+		r.log.WithError(err).Error("Failed to refresh session")
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return model.ErrSessionRevoked
+	}
+	query = `INSERT INTO session_refresh_used (refresh_hash, session_id) VALUES ($1, $2)`
+	if _, err := tx.ExecContext(ctx, query, oldHash, session.ID); err != nil {
+		r.log.WithError(err).Error("Failed to refresh session")
+		return err
+	}
+	return tx.Commit()
+}
+
+// RevokeReused ends the session whose already exchanged refresh token is presented again,
+// it reports whether the token was such one
+func (r *SessionRepo) RevokeReused(ctx context.Context, refreshHash string) (bool, error) {
+	var sid string
+	err := r.db.QueryRowContext(ctx, `SELECT session_id FROM session_refresh_used WHERE refresh_hash = $1`, refreshHash).Scan(&sid)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		r.log.WithError(err).Error("Failed to check refresh token")
+		return false, err
+	}
+	query := `UPDATE "session" SET revoked_at = $1 WHERE id = $2 AND revoked_at IS NULL`
+	if _, err := r.db.ExecContext(ctx, query, time.Now().UTC(), sid); err != nil {
+		r.log.WithError(err).Error("Failed to revoke session")
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *SessionRepo) IsActive(ctx context.Context, sid string) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM "session" WHERE id = $1 AND revoked_at IS NULL AND expires_at > $2)`
+	var active bool
+	err := r.db.QueryRowContext(ctx, query, sid, time.Now().UTC()).Scan(&active)
+	if err != nil {
+		r.log.WithError(err).Error("Failed to check session")
+		return false, err
+	}
+	return active, nil
+}
+
+func (r *SessionRepo) List(ctx context.Context, user *model.User) ([]model.Session, error) {
+	query := `SELECT id, device_info, created_at, expires_at FROM "session" WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > $2 ORDER BY created_at`
+	rows, err := r.db.QueryContext(ctx, query, user.ID, time.Now().UTC())
+	if err != nil {
+		r.log.WithError(err).Error("Failed to get sessions")
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sessions []model.Session
+	for rows.Next() {
+		session := model.Session{UserID: user.ID}
+		if err := rows.Scan(&session.ID, &session.DeviceInfo, &session.CreatedAt, &session.ExpiresAt); err != nil {
+			r.log.WithError(err).Error("Failed to scan session")
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.log.WithError(err).Error("Error while iterating rows")
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+// Revoke ends the session only if it belongs to session.UserID
+func (r *SessionRepo) Revoke(ctx context.Context, session *model.Session) error {
+	query := `UPDATE "session" SET revoked_at = $1 WHERE id = $2 AND user_id = $3 AND revoked_at IS NULL`
+	res, err := r.db.ExecContext(ctx, query, time.Now().UTC(), session.ID, session.UserID)
+	if err != nil {
+		r.log.WithError(err).Error("Failed to revoke session")
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return model.ErrSessionNotFound
+	}
+	return nil
+}
@@ -0,0 +1,292 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/Arcadian-Sky/datakkeeper/internal/model"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionRepo_Create(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	session := &model.Session{ID: "sid1", UserID: 1, RefreshHash: "hash", DeviceInfo: "laptop", ExpiresAt: time.Now().Add(time.Hour)}
+	mock.ExpectExec(`INSERT INTO "session" \(id, user_id, refresh_hash, device_info, created_at, expires_at\) VALUES \(\$1, \$2, \$3, \$4, \$5, \$6\)`).
+		WithArgs("sid1", 1, "hash", "laptop", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	r := NewSessionRepository(db, logrus.New())
+	require.NoError(t, r.Create(context.Background(), session))
+	require.False(t, session.CreatedAt.IsZero())
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSessionRepo_GetByRefresh(t *testing.T) {
+	logg := logrus.New()
+	query := `SELECT id, user_id, device_info, created_at, expires_at FROM "session" WHERE refresh_hash = \$1 AND revoked_at IS NULL AND expires_at > \$2`
+	created := time.Date(2024, 8, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		mock    func(mock sqlmock.Sqlmock)
+		want    *model.Session
+		wantErr error
+	}{
+		{
+			name: "Success",
+			mock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "user_id", "device_info", "created_at", "expires_at"}).
+					AddRow("sid1", 1, "laptop", created, created.Add(time.Hour))
+				mock.ExpectQuery(query).WithArgs("hash", sqlmock.AnyArg()).WillReturnRows(rows)
+			},
+			want: &model.Session{
+				ID:          "sid1",
+				UserID:      1,
+				RefreshHash: "hash",
+				DeviceInfo:  "laptop",
+				CreatedAt:   created,
+				ExpiresAt:   created.Add(time.Hour),
+			},
+		},
+		{
+			name: "RevokedOrUnknown",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WithArgs("hash", sqlmock.AnyArg()).WillReturnError(sql.ErrNoRows)
+			},
+			wantErr: model.ErrSessionRevoked,
+		},
+		{
+			name: "QueryError",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).WithArgs("hash", sqlmock.AnyArg()).WillReturnError(sql.ErrConnDone)
+			},
+			wantErr: sql.ErrConnDone,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			tt.mock(mock)
+
+			r := NewSessionRepository(db, logg)
+			got, err := r.GetByRefresh(context.Background(), "hash")
+			require.ErrorIs(t, err, tt.wantErr)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSessionRepo_Refresh(t *testing.T) {
+	logg := logrus.New()
+	query := `UPDATE "session" SET refresh_hash = \$1, expires_at = \$2 WHERE id = \$3 AND refresh_hash = \$4 AND revoked_at IS NULL`
+	used := `INSERT INTO session_refresh_used \(refresh_hash, session_id\) VALUES \(\$1, \$2\)`
+	session := &model.Session{ID: "sid1", RefreshHash: "new-hash", ExpiresAt: time.Now().Add(time.Hour)}
+
+	tests := []struct {
+		name    string
+		mock    func(mock sqlmock.Sqlmock)
+		wantErr error
+	}{
+		{
+			name: "Success",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(query).WithArgs("new-hash", sqlmock.AnyArg(), "sid1", "old-hash").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(used).WithArgs("old-hash", "sid1").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "AlreadyUsed",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(query).WithArgs("new-hash", sqlmock.AnyArg(), "sid1", "old-hash").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			wantErr: model.ErrSessionRevoked,
+		},
+		{
+			name: "ExecError",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(query).WithArgs("new-hash", sqlmock.AnyArg(), "sid1", "old-hash").
+					WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
+			},
+			wantErr: sql.ErrConnDone,
+		},
+		{
+			name: "UsedError",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(query).WithArgs("new-hash", sqlmock.AnyArg(), "sid1", "old-hash").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(used).WithArgs("old-hash", "sid1").WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
+			},
+			wantErr: sql.ErrConnDone,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			tt.mock(mock)
+
+			r := NewSessionRepository(db, logg)
+			require.ErrorIs(t, r.Refresh(context.Background(), session, "old-hash"), tt.wantErr)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSessionRepo_RevokeReused(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+	r := NewSessionRepository(db, logrus.New())
+	ctx := context.Background()
+	query := `SELECT session_id FROM session_refresh_used WHERE refresh_hash = \$1`
+
+	mock.ExpectQuery(query).WithArgs("fresh").WillReturnError(sql.ErrNoRows)
+	reused, err := r.RevokeReused(ctx, "fresh")
+	require.NoError(t, err)
+	require.False(t, reused)
+
+	mock.ExpectQuery(query).WithArgs("old").WillReturnRows(sqlmock.NewRows([]string{"session_id"}).AddRow("sid1"))
+	mock.ExpectExec(`UPDATE "session" SET revoked_at = \$1 WHERE id = \$2 AND revoked_at IS NULL`).
+		WithArgs(sqlmock.AnyArg(), "sid1").WillReturnResult(sqlmock.NewResult(0, 1))
+	reused, err = r.RevokeReused(ctx, "old")
+	require.NoError(t, err)
+	require.True(t, reused)
+
+	mock.ExpectQuery(query).WithArgs("old").WillReturnError(sql.ErrConnDone)
+	_, err = r.RevokeReused(ctx, "old")
+	require.ErrorIs(t, err, sql.ErrConnDone)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSessionRepo_Get(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+	r := NewSessionRepository(db, logrus.New())
+	query := `SELECT device_info, created_at, expires_at FROM "session" WHERE id = \$1 AND user_id = \$2 AND revoked_at IS NULL AND expires_at > \$3`
+	created := time.Date(2024, 8, 1, 10, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(query).WithArgs("sid1", 1, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"device_info", "created_at", "expires_at"}).AddRow("phone", created, created.Add(time.Hour)))
+	got, err := r.Get(context.Background(), &model.Session{ID: "sid1", UserID: 1})
+	require.NoError(t, err)
+	require.Equal(t, &model.Session{ID: "sid1", UserID: 1, DeviceInfo: "phone", CreatedAt: created, ExpiresAt: created.Add(time.Hour)}, got)
+
+	mock.ExpectQuery(query).WithArgs("sid1", 2, sqlmock.AnyArg()).WillReturnError(sql.ErrNoRows)
+	_, err = r.Get(context.Background(), &model.Session{ID: "sid1", UserID: 2})
+	require.ErrorIs(t, err, model.ErrSessionNotFound)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSessionRepo_IsActive(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	query := `SELECT EXISTS \(SELECT 1 FROM "session" WHERE id = \$1 AND revoked_at IS NULL AND expires_at > \$2\)`
+	mock.ExpectQuery(query).WithArgs("sid1", sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectQuery(query).WithArgs("sid2", sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectQuery(query).WithArgs("sid3", sqlmock.AnyArg()).
+		WillReturnError(sql.ErrConnDone)
+
+	r := NewSessionRepository(db, logrus.New())
+
+	active, err := r.IsActive(context.Background(), "sid1")
+	require.NoError(t, err)
+	require.True(t, active)
+
+	active, err = r.IsActive(context.Background(), "sid2")
+	require.NoError(t, err)
+	require.False(t, active)
+
+	_, err = r.IsActive(context.Background(), "sid3")
+	require.Error(t, err)
+}
+
+func TestSessionRepo_List(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	created := time.Date(2024, 8, 1, 10, 0, 0, 0, time.UTC)
+	rows := sqlmock.NewRows([]string{"id", "device_info", "created_at", "expires_at"}).
+		AddRow("sid1", "laptop", created, created.Add(time.Hour)).
+		AddRow("sid2", "phone", created, created.Add(time.Hour))
+	mock.ExpectQuery(`SELECT id, device_info, created_at, expires_at FROM "session" WHERE user_id = \$1 AND revoked_at IS NULL AND expires_at > \$2 ORDER BY created_at`).
+		WithArgs(1, sqlmock.AnyArg()).
+		WillReturnRows(rows)
+
+	r := NewSessionRepository(db, logrus.New())
+	got, err := r.List(context.Background(), &model.User{ID: 1})
+	require.NoError(t, err)
+	require.Equal(t, []model.Session{
+		{ID: "sid1", UserID: 1, DeviceInfo: "laptop", CreatedAt: created, ExpiresAt: created.Add(time.Hour)},
+		{ID: "sid2", UserID: 1, DeviceInfo: "phone", CreatedAt: created, ExpiresAt: created.Add(time.Hour)},
+	}, got)
+}
+
+func TestSessionRepo_Revoke(t *testing.T) {
+	logg := logrus.New()
+	query := `UPDATE "session" SET revoked_at = \$1 WHERE id = \$2 AND user_id = \$3 AND revoked_at IS NULL`
+
+	tests := []struct {
+		name    string
+		mock    func(mock sqlmock.Sqlmock)
+		wantErr error
+	}{
+		{
+			name: "Success",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).WithArgs(sqlmock.AnyArg(), "sid1", 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "OtherUser",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).WithArgs(sqlmock.AnyArg(), "sid1", 1).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: model.ErrSessionNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			tt.mock(mock)
+
+			r := NewSessionRepository(db, logg)
+			require.ErrorIs(t, r.Revoke(context.Background(), &model.Session{ID: "sid1", UserID: 1}), tt.wantErr)
+		})
+	}
+}
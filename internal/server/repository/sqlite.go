@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// sqliteDriverName - драйвер встроенной SQLite, который пишет время в UTC
+const sqliteDriverName = "sqlite3_utc"
+
+// sqliteParams - параметры подключения по умолчанию: внешние ключи для ON DELETE CASCADE,
+// WAL для чтения во время записи, ожидание блокировки и транзакции, сразу берущие блокировку на запись
+const sqliteParams = "_foreign_keys=1&_journal_mode=WAL&_busy_timeout=5000&_txlock=immediate"
+
+func init() {
+	sql.Register(sqliteDriverName, &sqliteDriver{})
+}
+
+// rowQuerier - *sql.DB или *sql.Tx для запросов, общих для postgres и SQLite.
+// Объявлен не в meta.go, чтобы mockgen не создавал мок для внутреннего интерфейса.
+type rowQuerier interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+type sqliteDriver struct {
+	sqlite3.SQLiteDriver
+}
+
+func (d *sqliteDriver) Open(dsn string) (driver.Conn, error) {
+	conn, err := d.SQLiteDriver.Open(dsn)
+	if err != nil {
+		return nil, err
+	}
+	return sqliteConn{conn}, nil
+}
+
+// sqliteConn переводит время параметров в UTC. SQLite хранит время текстом со смещением,
+// и сравнение строк с разными часовыми поясами дало бы неверный порядок.
+type sqliteConn struct {
+	driver.Conn
+}
+
+func (c sqliteConn) CheckNamedValue(nv *driver.NamedValue) error {
+	if t, ok := nv.Value.(time.Time); ok {
+		nv.Value = t.UTC()
+		return nil
+	}
+	return driver.ErrSkip
+}
+
+func (c sqliteConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	return c.Conn.(driver.ConnBeginTx).BeginTx(ctx, opts)
+}
+
+func (c sqliteConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	return c.Conn.(driver.ConnPrepareContext).PrepareContext(ctx, query)
+}
+
+func (c sqliteConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	return c.Conn.(driver.ExecerContext).ExecContext(ctx, query, args)
+}
+
+func (c sqliteConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	return c.Conn.(driver.QueryerContext).QueryContext(ctx, query, args)
+}
+
+func (c sqliteConn) Ping(ctx context.Context) error {
+	return c.Conn.(driver.Pinger).Ping(ctx)
+}
+
+// OpenSQLite opens the SQLite database file, the directory is created if it's missing.
+// Параметры после ? дополняют sqliteParams и имеют приоритет.
+func OpenSQLite(dsn string) (*sql.DB, error) {
+	path, params, _ := strings.Cut(dsn, "?")
+	if path == "" {
+		return nil, fmt.Errorf("sqlite database path is empty")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create sqlite database directory: %w", err)
+	}
+	if params != "" {
+		params += "&"
+	}
+	return sql.Open(sqliteDriverName, path+"?"+params+sqliteParams)
+}
+
+// sqliteNextRevision increases the revision counter of the user by n and returns the new value.
+// Заменяет CTE nextRevision: в SQLite нет изменяющих CTE. Транзакция на запись в SQLite одна на базу,
+// поэтому ревизии одного пользователя фиксируются по порядку.
+func sqliteNextRevision(ctx context.Context, tx *sql.Tx, userID, n int64) (int64, error) {
+	var rev int64
+	err := tx.QueryRowContext(ctx, `UPDATE "user" SET revision = revision + ?1 WHERE id = ?2 RETURNING revision`, n, userID).
+		Scan(&rev)
+	return rev, err
+}
+
+// sqliteListDialect - список записей для SQLite: теги метаданных хранятся JSON-текстом,
+// LIKE в SQLite без учета регистра только для ASCII
+var sqliteListDialect = listDialect{
+	param:  "?",
+	itemID: "CAST(m.id AS TEXT)",
+	hasTags: func(tags []string, arg func(v any) string) string {
+		conds := make([]string, len(tags))
+		for i, tag := range tags {
+			conds[i] = "EXISTS (SELECT 1 FROM json_each(im.tags) WHERE value = " + arg(tag) + ")"
+		}
+		return strings.Join(conds, " AND ")
+	},
+	like:    `%s LIKE %s ESCAPE '\'`,
+	tagLike: `EXISTS (SELECT 1 FROM json_each(im.tags) t WHERE t.value LIKE %s ESCAPE '\')`,
+}
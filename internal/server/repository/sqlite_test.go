@@ -0,0 +1,263 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Arcadian-Sky/datakkeeper/internal/model"
+	"github.com/Arcadian-Sky/datakkeeper/migrations"
+	"github.com/Arcadian-Sky/datakkeeper/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/minio/minio-go/v7"
+	"github.com/pressly/goose/v3"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestSQLite opens a migrated SQLite database in a temporary directory
+func newTestSQLite(t *testing.T) *sql.DB {
+	db, err := OpenSQLite(filepath.Join(t.TempDir(), "db", "datakeeper.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	goose.SetBaseFS(migrations.SQLite)
+	goose.SetLogger(goose.NopLogger())
+	require.NoError(t, goose.SetDialect("sqlite3"))
+	require.NoError(t, goose.Up(db, "sqlite"))
+	return db
+}
+
+// newTestSQLiteUser registers a user in the SQLite database
+func newTestSQLiteUser(t *testing.T, db *sql.DB, login string) int64 {
+	id, err := NewUserRepository(db, logrus.New()).Register(context.Background(), &model.User{Login: login, Password: "secret"})
+	require.NoError(t, err)
+	return id
+}
+
+func TestOpenSQLite(t *testing.T) {
+	_, err := OpenSQLite("")
+	require.Error(t, err)
+
+	db := newTestSQLite(t)
+	var fk int
+	require.NoError(t, db.QueryRow(`PRAGMA foreign_keys`).Scan(&fk))
+	require.Equal(t, 1, fk)
+}
+
+// Запросы остальных репозиториев переносимы и работают на SQLite без изменений
+func TestSQLite_UserRepo(t *testing.T) {
+	db := newTestSQLite(t)
+	r := NewUserRepository(db, logrus.New())
+	ctx := context.Background()
+
+	id := newTestSQLiteUser(t, db, "user")
+	_, err := r.Register(ctx, &model.User{Login: "user", Password: "other"})
+	require.ErrorIs(t, err, model.ErrLoginAlreadyTaken)
+
+	user, err := r.Auth(ctx, &model.User{Login: "user", Password: "secret"})
+	require.NoError(t, err)
+	require.Equal(t, id, user.ID)
+	_, err = r.Auth(ctx, &model.User{Login: "user", Password: "wrong"})
+	require.ErrorIs(t, err, model.ErrInvalidLoginAndPass)
+
+	user.LastUpdate = time.Now()
+	_, err = r.SetLastUpdate(ctx, user)
+	require.NoError(t, err)
+
+	user.KeyHash = "hash"
+	require.NoError(t, r.BindKeyHash(ctx, user))
+	require.NoError(t, r.BindKeyHash(ctx, user))
+	user.KeyHash = "other"
+	require.ErrorIs(t, r.BindKeyHash(ctx, user), model.ErrKeyHashMismatch)
+}
+
+func TestSQLite_SessionRepo(t *testing.T) {
+	db := newTestSQLite(t)
+	r := NewSessionRepository(db, logrus.New())
+	ctx := context.Background()
+	userID := newTestSQLiteUser(t, db, "user")
+
+	// срок задается в местном времени, сравнивается в UTC
+	local := time.FixedZone("UTC+5", 5*60*60)
+	session := &model.Session{ID: "sid", UserID: userID, RefreshHash: "refresh", ExpiresAt: time.Now().Add(time.Hour).In(local)}
+	require.NoError(t, r.Create(ctx, session))
+	expired := &model.Session{ID: "old", UserID: userID, RefreshHash: "old", ExpiresAt: time.Now().Add(-time.Minute).In(local)}
+	require.NoError(t, r.Create(ctx, expired))
+
+	got, err := r.GetByRefresh(ctx, "refresh")
+	require.NoError(t, err)
+	require.Equal(t, "sid", got.ID)
+	require.WithinDuration(t, session.ExpiresAt, got.ExpiresAt, time.Microsecond)
+
+	active, err := r.IsActive(ctx, "sid")
+	require.NoError(t, err)
+	require.True(t, active)
+	active, err = r.IsActive(ctx, "old")
+	require.NoError(t, err)
+	require.False(t, active)
+
+	sessions, err := r.List(ctx, &model.User{ID: userID})
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+
+	got, err = r.Get(ctx, &model.Session{ID: "sid", UserID: userID})
+	require.NoError(t, err)
+	require.Equal(t, session.DeviceInfo, got.DeviceInfo)
+
+	// обмененный refresh-токен, предъявленный снова, завершает сеанс
+	session.RefreshHash = "refresh2"
+	require.NoError(t, r.Refresh(ctx, session, "refresh"))
+	reused, err := r.RevokeReused(ctx, "refresh")
+	require.NoError(t, err)
+	require.True(t, reused)
+	_, err = r.GetByRefresh(ctx, "refresh2")
+	require.ErrorIs(t, err, model.ErrSessionRevoked)
+
+	require.ErrorIs(t, r.Revoke(ctx, session), model.ErrSessionNotFound)
+	active, err = r.IsActive(ctx, "sid")
+	require.NoError(t, err)
+	require.False(t, active)
+}
+
+func TestSQLite_ItemMetaRepo(t *testing.T) {
+	db := newTestSQLite(t)
+	r := NewItemMetaRepository(db, logrus.New())
+	ctx := context.Background()
+	userID := newTestSQLiteUser(t, db, "user")
+
+	meta := &model.ItemMeta{UserID: userID, Kind: model.MetaKindFile, ItemID: "a.txt", Tags: []string{"work"}, Description: "note"}
+	require.NoError(t, r.Set(ctx, meta))
+	require.NoError(t, r.Set(ctx, &model.ItemMeta{UserID: userID, Kind: model.MetaKindFile, ItemID: "b.txt", Tags: []string{"old"}}))
+
+	require.NoError(t, r.Rename(ctx, userID, model.MetaKindFile, "a.txt", "b.txt"))
+	list, err := r.List(ctx, userID, model.MetaKindFile)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	require.Equal(t, []string{"work"}, list["b.txt"].Tags)
+	require.Equal(t, "note", list["b.txt"].Description)
+
+	require.NoError(t, r.Delete(ctx, &model.ItemMeta{UserID: userID, Kind: model.MetaKindFile, ItemID: "b.txt"}))
+	got, err := r.Get(ctx, &model.ItemMeta{UserID: userID, Kind: model.MetaKindFile, ItemID: "b.txt"})
+	require.NoError(t, err)
+	require.True(t, got.IsEmpty())
+}
+
+func TestSQLite_KeyRepo(t *testing.T) {
+	db := newTestSQLite(t)
+	r := NewKeyRepository(db, logrus.New())
+	ctx := context.Background()
+
+	require.NoError(t, r.Rotate(ctx, &model.SigningKey{ID: "k1", Secret: []byte("one")}))
+	require.NoError(t, r.Rotate(ctx, &model.SigningKey{ID: "k2", Secret: []byte("two")}))
+
+	keys, err := r.GetKeys(ctx, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+	require.Equal(t, "k2", keys[0].ID)
+	require.Equal(t, []byte("one"), keys[1].Secret)
+	require.False(t, keys[1].RetiredAt.IsZero())
+
+	keys, err = r.GetKeys(ctx, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+}
+
+func TestSQLite_UploadRepo(t *testing.T) {
+	db := newTestSQLite(t)
+	r := NewUploadRepository(db, logrus.New(), t.TempDir())
+	ctx := context.Background()
+	userID := newTestSQLiteUser(t, db, "user")
+
+	session := &model.UploadSession{ID: "first", UserID: userID, FileName: "a.txt", Size: 5, SHA256: helloSHA256}
+	require.NoError(t, r.Create(ctx, session))
+	again := &model.UploadSession{ID: "second", UserID: userID, FileName: "a.txt", Size: 5, SHA256: helloSHA256}
+	require.NoError(t, r.Create(ctx, again))
+	require.Equal(t, "first", again.ID)
+
+	got, err := r.Get(ctx, "first", userID)
+	require.NoError(t, err)
+	require.Equal(t, "a.txt", got.FileName)
+
+	other := &model.UploadSession{ID: "other", UserID: userID, FileName: "b.txt", Size: 7, SHA256: helloSHA256}
+	require.NoError(t, r.Create(ctx, other))
+	count, size, err := r.Pending(ctx, session)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+	require.Equal(t, int64(7), size)
+
+	// принятая часть продлевает сессию, старая по времени создания не удаляется
+	_, err = db.ExecContext(ctx, `UPDATE upload_session SET created_at = $1, updated_at = $2`,
+		time.Now().Add(-time.Hour).UTC(), time.Now().Add(-time.Hour).UTC())
+	require.NoError(t, err)
+	require.NoError(t, r.Append(ctx, got, 0, []byte("he")))
+	purged, err := r.PurgeExpired(ctx, time.Now().Add(-time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, []model.UploadSession{{ID: "other", UserID: userID, FileName: "b.txt"}}, purged)
+
+	purged, err = r.PurgeExpired(ctx, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	require.Len(t, purged, 1)
+}
+
+// Счетчик ссылок дедупликации хранится в той же базе, что и метаданные
+func TestSQLite_BlobRepo(t *testing.T) {
+	db := newTestSQLite(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockMinio := mocks.NewMockMinioClient(ctrl)
+	r := NewBlobRepository(db, mockMinio, logrus.New())
+	ctx := context.Background()
+	refs := func() int64 {
+		var n int64
+		require.NoError(t, db.QueryRowContext(ctx, `SELECT refs FROM blob WHERE sha256 = $1`, helloSHA256).Scan(&n))
+		return n
+	}
+
+	mockMinioObject := mocks.NewMockMinioObject(ctrl)
+	putTemp(t, mockMinio, "hello")
+	mockMinio.EXPECT().
+		CopyObject(gomock.Any(), minio.CopyDestOptions{Bucket: blobBucket, Object: helloSHA256}, gomock.Any()).
+		Return(minio.UploadInfo{}, nil)
+	mockMinio.EXPECT().GetObject(gomock.Any(), blobBucket, helloSHA256, minio.GetObjectOptions{}).Return(mockMinioObject, nil)
+	mockMinioObject.EXPECT().Stat().Return(minio.ObjectInfo{Key: helloSHA256}, nil)
+	mockMinioObject.EXPECT().Close().Return(nil)
+	blob, err := r.Put(ctx, "", -1, strings.NewReader("hello"))
+	require.NoError(t, err)
+	require.Equal(t, model.Blob{SHA256: helloSHA256, Size: 5}, blob)
+
+	// то же содержимое не читается повторно
+	blob, err = r.Put(ctx, helloSHA256, 5, nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(5), blob.Size)
+	require.NoError(t, r.Ref(ctx, helloSHA256))
+	require.Equal(t, int64(3), refs())
+	require.ErrorIs(t, r.Ref(ctx, "gone"), model.ErrBlobNotFound)
+
+	require.NoError(t, r.Unref(ctx, helloSHA256))
+	require.NoError(t, r.Unref(ctx, helloSHA256))
+	require.Equal(t, int64(1), refs())
+
+	// к содержимому без ссылок новая ссылка не добавляется, пока оно удаляется
+	_, err = db.ExecContext(ctx, `UPDATE blob SET refs = 0`)
+	require.NoError(t, err)
+	require.ErrorIs(t, r.Ref(ctx, helloSHA256), model.ErrBlobNotFound)
+	putTemp(t, mockMinio, "hello")
+	mockMinio.EXPECT().
+		CopyObject(gomock.Any(), minio.CopyDestOptions{Bucket: blobBucket, Object: helloSHA256}, gomock.Any()).
+		Return(minio.UploadInfo{}, nil)
+	_, err = r.Put(ctx, "", -1, strings.NewReader("hello"))
+	require.ErrorIs(t, err, model.ErrBlobRemoving)
+	_, err = db.ExecContext(ctx, `UPDATE blob SET refs = 1`)
+	require.NoError(t, err)
+
+	mockMinio.EXPECT().RemoveObject(gomock.Any(), blobBucket, helloSHA256, minio.RemoveObjectOptions{}).Return(nil)
+	require.NoError(t, r.Unref(ctx, helloSHA256))
+	var count int
+	require.NoError(t, db.QueryRowContext(ctx, `SELECT COUNT(*) FROM blob`).Scan(&count))
+	require.Zero(t, count)
+	require.NoError(t, r.Unref(ctx, helloSHA256))
+}
@@ -0,0 +1,273 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Arcadian-Sky/datakkeeper/internal/model"
+	"github.com/sirupsen/logrus"
+)
+
+// SyncRepository ведет ревизии файлов и отдает изменения пользователя для синхронизации клиентов.
+// Ревизии записей ведет DataRepository, счетчик ревизий у записей и файлов общий.
+// Ревизия файла служит и его версией, у файла без ревизии версия 0.
+type SyncRepository interface {
+	TouchFile(ctx context.Context, userID int64, name string) (int64, error)
+	DeleteFile(ctx context.Context, userID int64, name string) (int64, error)
+	MoveFile(ctx context.Context, userID int64, from, to string) (int64, error)
+	FileRevision(ctx context.Context, userID int64, name string) (int64, error)
+	FileRevisions(ctx context.Context, userID int64) (map[string]int64, error)
+	Changes(ctx context.Context, userID, since int64, limit int) (*model.SyncChanges, error)
+	ListTrash(ctx context.Context, userID int64) ([]model.TrashItem, error)
+	ExpiredFiles(ctx context.Context, before time.Time) ([]model.PurgedItem, error)
+	MarkFilePurged(ctx context.Context, userID int64, name string, before time.Time) error
+}
+
+type SyncRepo struct {
+	db  *sql.DB
+	log *logrus.Logger
+}
+
+func NewSyncRepository(dbs *sql.DB, lg *logrus.Logger) *SyncRepo {
+	return &SyncRepo{
+		db:  dbs,
+		log: lg,
+	}
+}
+
+// TouchFile marks the file as changed and returns its new revision
+func (r *SyncRepo) TouchFile(ctx context.Context, userID int64, name string) (int64, error) {
+	return r.setFile(ctx, userID, name, false)
+}
+
+// DeleteFile leaves a tombstone of the file and returns its revision, the file stays in the trash until MarkFilePurged
+func (r *SyncRepo) DeleteFile(ctx context.Context, userID int64, name string) (int64, error) {
+	return r.setFile(ctx, userID, name, true)
+}
+
+// MoveFile leaves a tombstone of the old name without putting it to the trash and marks the new name as changed,
+// returns the revision of the new name. Ревизия увеличивается на два, чтобы у изменений были разные номера
+// и страница синхронизации не разделила их.
+func (r *SyncRepo) MoveFile(ctx context.Context, userID int64, from, to string) (int64, error) {
+	query := `WITH rev AS (UPDATE "user" SET revision = revision + 2 WHERE id = $1 RETURNING revision),
+		old AS (UPDATE file_revision SET revision = (SELECT revision FROM rev) - 1, deleted = true, deleted_at = NULL
+			WHERE user_id = $1 AND name = $2)
+		INSERT INTO file_revision (user_id, name, revision, deleted, deleted_at)
+		VALUES ($1, $3, (SELECT revision FROM rev), false, NULL)
+		ON CONFLICT (user_id, name) DO UPDATE SET revision = EXCLUDED.revision, deleted = false,
+		deleted_at = NULL, purged = false
+		RETURNING revision`
+	var rev int64
+	err := r.db.QueryRowContext(ctx, query, userID, from, to).Scan(&rev)
+	if err != nil {
+		r.log.WithError(err).Error("Failed to move file revision")
+		return 0, err
+	}
+	return rev, nil
+}
+
+func (r *SyncRepo) setFile(ctx context.Context, userID int64, name string, deleted bool) (int64, error) {
+	// deleted_at отсчитывает срок хранения в корзине, новая загрузка или восстановление его сбрасывают
+	query := fmt.Sprintf(nextRevision, 1) + `INSERT INTO file_revision (user_id, name, revision, deleted, deleted_at)
+		VALUES ($1, $2, (SELECT revision FROM rev), $3, CASE WHEN $3 THEN now() END)
+		ON CONFLICT (user_id, name) DO UPDATE SET revision = EXCLUDED.revision, deleted = EXCLUDED.deleted,
+		deleted_at = EXCLUDED.deleted_at, purged = false
+		RETURNING revision`
+	var rev int64
+	err := r.db.QueryRowContext(ctx, query, userID, name, deleted).Scan(&rev)
+	if err != nil {
+		r.log.WithError(err).Error("Failed to set file revision")
+		return 0, err
+	}
+	return rev, nil
+}
+
+// FileRevision returns the current version of the file, deleted and unknown files have 0
+func (r *SyncRepo) FileRevision(ctx context.Context, userID int64, name string) (int64, error) {
+	query := `SELECT revision FROM file_revision WHERE user_id = $1 AND name = $2 AND NOT deleted`
+	var rev int64
+	err := r.db.QueryRowContext(ctx, query, userID, name).Scan(&rev)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+		r.log.WithError(err).Error("Failed to get file revision")
+		return 0, err
+	}
+	return rev, nil
+}
+
+// FileRevisions returns versions of all existing user files by name
+func (r *SyncRepo) FileRevisions(ctx context.Context, userID int64) (map[string]int64, error) {
+	query := `SELECT name, revision FROM file_revision WHERE user_id = $1 AND NOT deleted`
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		r.log.WithError(err).Error("Failed to list file revisions")
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make(map[string]int64)
+	for rows.Next() {
+		var name string
+		var rev int64
+		if err := rows.Scan(&name, &rev); err != nil {
+			r.log.WithError(err).Error("Failed to scan file revision")
+			return nil, err
+		}
+		res[name] = rev
+	}
+	if err := rows.Err(); err != nil {
+		r.log.WithError(err).Error("Error while iterating rows")
+		return nil, err
+	}
+	return res, nil
+}
+
+// ListTrash returns deleted files of the user that are not purged yet, recently deleted first
+func (r *SyncRepo) ListTrash(ctx context.Context, userID int64) ([]model.TrashItem, error) {
+	query := `SELECT name, revision, deleted_at FROM file_revision
+		WHERE user_id = $1 AND deleted AND NOT purged AND deleted_at IS NOT NULL ORDER BY deleted_at DESC`
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		r.log.WithError(err).Error("Failed to get file trash")
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []model.TrashItem
+	for rows.Next() {
+		file := &model.FileItem{Deleted: true}
+		item := model.TrashItem{File: file}
+		if err := rows.Scan(&file.Name, &file.Revision, &item.DeletedAt); err != nil {
+			r.log.WithError(err).Error("Failed to scan file trash")
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		r.log.WithError(err).Error("Error while iterating rows")
+		return nil, err
+	}
+	return items, nil
+}
+
+// ExpiredFiles returns files deleted before the time that are not purged yet.
+// Файл отмечается стертым через MarkFilePurged только после удаления из хранилища,
+// поэтому неудачно стертый файл попадет и в следующую очистку.
+func (r *SyncRepo) ExpiredFiles(ctx context.Context, before time.Time) ([]model.PurgedItem, error) {
+	query := `SELECT user_id, name FROM file_revision WHERE deleted AND NOT purged AND deleted_at < $1`
+	rows, err := r.db.QueryContext(ctx, query, before)
+	if err != nil {
+		r.log.WithError(err).Error("Failed to get expired files")
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []model.PurgedItem
+	for rows.Next() {
+		var item model.PurgedItem
+		if err := rows.Scan(&item.UserID, &item.FileName); err != nil {
+			r.log.WithError(err).Error("Failed to scan expired file")
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		r.log.WithError(err).Error("Error while iterating rows")
+		return nil, err
+	}
+	return items, nil
+}
+
+// MarkFilePurged marks the file removed from the storage as purged, the tombstone stays for syncing.
+// Файл, восстановленный или удаленный заново после ExpiredFiles, не отмечается.
+func (r *SyncRepo) MarkFilePurged(ctx context.Context, userID int64, name string, before time.Time) error {
+	query := `UPDATE file_revision SET purged = true
+		WHERE user_id = $1 AND name = $2 AND deleted AND NOT purged AND deleted_at < $3`
+	if _, err := r.db.ExecContext(ctx, query, userID, name, before); err != nil {
+		r.log.WithError(err).Error("Failed to purge file revision")
+		return err
+	}
+	return nil
+}
+
+// Changes returns data and files changed after since, at most limit of them.
+// Ревизия пользователя читается первой: строка пользователя блокируется до фиксации изменения,
+// поэтому все изменения до прочитанной ревизии уже видны и ничего не пропускается.
+func (r *SyncRepo) Changes(ctx context.Context, userID, since int64, limit int) (*model.SyncChanges, error) {
+	var current int64
+	err := r.db.QueryRowContext(ctx, `SELECT revision FROM "user" WHERE id = $1`, userID).Scan(&current)
+	if err != nil {
+		r.log.WithError(err).Error("Failed to get user revision")
+		return nil, err
+	}
+	if current <= since {
+		return &model.SyncChanges{Revision: current}, nil
+	}
+
+	data, err := r.dataChanges(ctx, userID, since, current, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	files, err := r.fileChanges(ctx, userID, since, current, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	return model.MergeSyncChanges(current, data, files, limit), nil
+}
+
+func (r *SyncRepo) dataChanges(ctx context.Context, userID, since, until int64, limit int) ([]model.Data, error) {
+	query := `SELECT ` + dataColumns + `, bin_data, deleted_at IS NOT NULL FROM metadata
+		WHERE user_id = $1 AND revision > $2 AND revision <= $3 ORDER BY revision LIMIT $4`
+	rows, err := r.db.QueryContext(ctx, query, userID, since, until, limit)
+	if err != nil {
+		r.log.WithError(err).Error("Failed to get data changes")
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []model.Data
+	for rows.Next() {
+		data := model.Data{UserID: userID}
+		if err := rows.Scan(append(dataFields(&data), &data.Binary, &data.Deleted)...); err != nil {
+			r.log.WithError(err).Error("Failed to scan data changes")
+			return nil, err
+		}
+		res = append(res, data)
+	}
+	if err := rows.Err(); err != nil {
+		r.log.WithError(err).Error("Error while iterating rows")
+		return nil, err
+	}
+	return res, nil
+}
+
+func (r *SyncRepo) fileChanges(ctx context.Context, userID, since, until int64, limit int) ([]model.FileItem, error) {
+	query := `SELECT name, revision, deleted FROM file_revision
+		WHERE user_id = $1 AND revision > $2 AND revision <= $3 ORDER BY revision LIMIT $4`
+	rows, err := r.db.QueryContext(ctx, query, userID, since, until, limit)
+	if err != nil {
+		r.log.WithError(err).Error("Failed to get file changes")
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []model.FileItem
+	for rows.Next() {
+		var file model.FileItem
+		if err := rows.Scan(&file.Name, &file.Revision, &file.Deleted); err != nil {
+			r.log.WithError(err).Error("Failed to scan file changes")
+			return nil, err
+		}
+		res = append(res, file)
+	}
+	if err := rows.Err(); err != nil {
+		r.log.WithError(err).Error("Error while iterating rows")
+		return nil, err
+	}
+	return res, nil
+}
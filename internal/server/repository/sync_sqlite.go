@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/Arcadian-Sky/datakkeeper/internal/model"
+	"github.com/sirupsen/logrus"
+)
+
+// SQLiteSyncRepo - SyncRepository во встроенной SQLite. Как и в SQLiteDataRepo, каждый метод объявлен здесь,
+// переносимое чтение явно передается SyncRepo.
+type SQLiteSyncRepo struct {
+	db  *sql.DB
+	log *logrus.Logger
+	// common выполняет запросы SyncRepo, которые работают на SQLite без изменений
+	common *SyncRepo
+}
+
+func NewSQLiteSyncRepository(dbs *sql.DB, lg *logrus.Logger) *SQLiteSyncRepo {
+	return &SQLiteSyncRepo{
+		db:     dbs,
+		log:    lg,
+		common: NewSyncRepository(dbs, lg),
+	}
+}
+
+// FileRevision returns the current version of the file, as SyncRepo.FileRevision
+func (r *SQLiteSyncRepo) FileRevision(ctx context.Context, userID int64, name string) (int64, error) {
+	return r.common.FileRevision(ctx, userID, name)
+}
+
+// FileRevisions returns versions of all existing user files by name, as SyncRepo.FileRevisions
+func (r *SQLiteSyncRepo) FileRevisions(ctx context.Context, userID int64) (map[string]int64, error) {
+	return r.common.FileRevisions(ctx, userID)
+}
+
+// Changes returns data and files changed after since, as SyncRepo.Changes.
+// Транзакции SQLite пишут по очереди, поэтому прочитанная ревизия пользователя так же ничего не пропускает.
+func (r *SQLiteSyncRepo) Changes(ctx context.Context, userID, since int64, limit int) (*model.SyncChanges, error) {
+	return r.common.Changes(ctx, userID, since, limit)
+}
+
+// ListTrash returns deleted files of the user that are not purged yet, as SyncRepo.ListTrash
+func (r *SQLiteSyncRepo) ListTrash(ctx context.Context, userID int64) ([]model.TrashItem, error) {
+	return r.common.ListTrash(ctx, userID)
+}
+
+// ExpiredFiles returns files deleted before the time that are not purged yet, as SyncRepo.ExpiredFiles
+func (r *SQLiteSyncRepo) ExpiredFiles(ctx context.Context, before time.Time) ([]model.PurgedItem, error) {
+	return r.common.ExpiredFiles(ctx, before)
+}
+
+// MarkFilePurged marks the file removed from the storage as purged, as SyncRepo.MarkFilePurged
+func (r *SQLiteSyncRepo) MarkFilePurged(ctx context.Context, userID int64, name string, before time.Time) error {
+	return r.common.MarkFilePurged(ctx, userID, name, before)
+}
+
+// TouchFile marks the file as changed and returns its new revision
+func (r *SQLiteSyncRepo) TouchFile(ctx context.Context, userID int64, name string) (int64, error) {
+	return r.setFile(ctx, userID, name, false)
+}
+
+// DeleteFile leaves a tombstone of the file and returns its revision, the file stays in the trash until MarkFilePurged
+func (r *SQLiteSyncRepo) DeleteFile(ctx context.Context, userID int64, name string) (int64, error) {
+	return r.setFile(ctx, userID, name, true)
+}
+
+// MoveFile leaves a tombstone of the old name and marks the new name as changed, as SyncRepo.MoveFile
+func (r *SQLiteSyncRepo) MoveFile(ctx context.Context, userID int64, from, to string) (int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	rev, err := sqliteNextRevision(ctx, tx, userID, 2)
+	if err != nil {
+		r.log.WithError(err).Error("Failed to move file revision")
+		return 0, err
+	}
+	query := `UPDATE file_revision SET revision = ?, deleted = true, deleted_at = NULL WHERE user_id = ? AND name = ?`
+	if _, err := tx.ExecContext(ctx, query, rev-1, userID, from); err != nil {
+		r.log.WithError(err).Error("Failed to move file revision")
+		return 0, err
+	}
+	query = `INSERT INTO file_revision (user_id, name, revision, deleted, deleted_at) VALUES (?, ?, ?, false, NULL)
+		ON CONFLICT (user_id, name) DO UPDATE SET revision = excluded.revision, deleted = false,
+		deleted_at = NULL, purged = false`
+	if _, err := tx.ExecContext(ctx, query, userID, to, rev); err != nil {
+		r.log.WithError(err).Error("Failed to move file revision")
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return rev, nil
+}
+
+func (r *SQLiteSyncRepo) setFile(ctx context.Context, userID int64, name string, deleted bool) (int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	rev, err := sqliteNextRevision(ctx, tx, userID, 1)
+	if err != nil {
+		r.log.WithError(err).Error("Failed to set file revision")
+		return 0, err
+	}
+	// deleted_at отсчитывает срок хранения в корзине, новая загрузка или восстановление его сбрасывают
+	var deletedAt any
+	if deleted {
+		deletedAt = time.Now()
+	}
+	query := `INSERT INTO file_revision (user_id, name, revision, deleted, deleted_at) VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (user_id, name) DO UPDATE SET revision = excluded.revision, deleted = excluded.deleted,
+		deleted_at = excluded.deleted_at, purged = false`
+	if _, err := tx.ExecContext(ctx, query, userID, name, rev, deleted, deletedAt); err != nil {
+		r.log.WithError(err).Error("Failed to set file revision")
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return rev, nil
+}
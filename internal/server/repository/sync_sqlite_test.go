@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Arcadian-Sky/datakkeeper/internal/model"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLiteSyncRepo(t *testing.T) {
+	db := newTestSQLite(t)
+	r := NewSQLiteSyncRepository(db, logrus.New())
+	d := NewSQLiteDataRepository(db, logrus.New())
+	ctx := context.Background()
+	userID := newTestSQLiteUser(t, db, "user")
+
+	rev, err := r.TouchFile(ctx, userID, "a.txt")
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rev)
+	// счетчик ревизий общий с записями
+	data := &model.Data{UserID: userID, Type: DataTypeTEXT, Title: "note"}
+	_, err = d.Save(ctx, data)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), data.Revision)
+
+	rev, err = r.MoveFile(ctx, userID, "a.txt", "b.txt")
+	require.NoError(t, err)
+	require.Equal(t, int64(4), rev)
+	revs, err := r.FileRevisions(ctx, userID)
+	require.NoError(t, err)
+	require.Equal(t, map[string]int64{"b.txt": 4}, revs)
+
+	changes, err := r.Changes(ctx, userID, 1, 10)
+	require.NoError(t, err)
+	require.Equal(t, int64(4), changes.Revision)
+	require.Len(t, changes.Data, 1)
+	require.Len(t, changes.Files, 2)
+
+	rev, err = r.DeleteFile(ctx, userID, "b.txt")
+	require.NoError(t, err)
+	require.Equal(t, int64(5), rev)
+	rev, err = r.FileRevision(ctx, userID, "b.txt")
+	require.NoError(t, err)
+	require.Zero(t, rev)
+
+	// переименованный файл не попадает в корзину
+	trash, err := r.ListTrash(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, trash, 1)
+	require.Equal(t, "b.txt", trash[0].File.Name)
+
+	before := time.Now().Add(time.Minute)
+	expired, err := r.ExpiredFiles(ctx, before)
+	require.NoError(t, err)
+	require.Equal(t, []model.PurgedItem{{UserID: userID, FileName: "b.txt"}}, expired)
+	// пока файл не отмечен стертым, он остается в корзине и в следующей очистке
+	trash, err = r.ListTrash(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, trash, 1)
+	require.NoError(t, r.MarkFilePurged(ctx, userID, "b.txt", before))
+	expired, err = r.ExpiredFiles(ctx, before)
+	require.NoError(t, err)
+	require.Empty(t, expired)
+
+	// новая загрузка возвращает файл из стертых
+	rev, err = r.TouchFile(ctx, userID, "b.txt")
+	require.NoError(t, err)
+	require.Equal(t, int64(6), rev)
+	trash, err = r.ListTrash(ctx, userID)
+	require.NoError(t, err)
+	require.Empty(t, trash)
+
+	_, err = r.TouchFile(ctx, userID+1, "c.txt")
+	require.Error(t, err)
+}
@@ -0,0 +1,226 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/Arcadian-Sky/datakkeeper/internal/model"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncRepo_SetFile(t *testing.T) {
+	query := regexp.QuoteMeta(`WITH rev AS (UPDATE "user" SET revision = revision + 1 WHERE id = $1 RETURNING revision) ` +
+		`INSERT INTO file_revision (user_id, name, revision, deleted, deleted_at) VALUES ($1, $2, (SELECT revision FROM rev), $3, CASE WHEN $3 THEN now() END) ` +
+		`ON CONFLICT (user_id, name) DO UPDATE SET revision = EXCLUDED.revision, deleted = EXCLUDED.deleted, ` +
+		`deleted_at = EXCLUDED.deleted_at, purged = false RETURNING revision`)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery(query).WithArgs(1, "a.txt", false).WillReturnRows(sqlmock.NewRows([]string{"revision"}).AddRow(7))
+	mock.ExpectQuery(query).WithArgs(1, "a.txt", true).WillReturnRows(sqlmock.NewRows([]string{"revision"}).AddRow(8))
+	mock.ExpectQuery(query).WithArgs(1, "b.txt", true).WillReturnError(sql.ErrConnDone)
+
+	r := NewSyncRepository(db, logrus.New())
+	rev, err := r.TouchFile(context.Background(), 1, "a.txt")
+	require.NoError(t, err)
+	require.Equal(t, int64(7), rev)
+
+	rev, err = r.DeleteFile(context.Background(), 1, "a.txt")
+	require.NoError(t, err)
+	require.Equal(t, int64(8), rev)
+
+	_, err = r.DeleteFile(context.Background(), 1, "b.txt")
+	require.ErrorIs(t, err, sql.ErrConnDone)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSyncRepo_MoveFile(t *testing.T) {
+	query := regexp.QuoteMeta(`WITH rev AS (UPDATE "user" SET revision = revision + 2 WHERE id = $1 RETURNING revision), ` +
+		`old AS (UPDATE file_revision SET revision = (SELECT revision FROM rev) - 1, deleted = true, deleted_at = NULL ` +
+		`WHERE user_id = $1 AND name = $2) ` +
+		`INSERT INTO file_revision (user_id, name, revision, deleted, deleted_at) VALUES ($1, $3, (SELECT revision FROM rev), false, NULL) ` +
+		`ON CONFLICT (user_id, name) DO UPDATE SET revision = EXCLUDED.revision, deleted = false, ` +
+		`deleted_at = NULL, purged = false RETURNING revision`)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery(query).WithArgs(1, "a.txt", "work/a.txt").WillReturnRows(sqlmock.NewRows([]string{"revision"}).AddRow(9))
+	mock.ExpectQuery(query).WithArgs(1, "b.txt", "work/b.txt").WillReturnError(sql.ErrConnDone)
+
+	r := NewSyncRepository(db, logrus.New())
+	rev, err := r.MoveFile(context.Background(), 1, "a.txt", "work/a.txt")
+	require.NoError(t, err)
+	require.Equal(t, int64(9), rev)
+
+	_, err = r.MoveFile(context.Background(), 1, "b.txt", "work/b.txt")
+	require.ErrorIs(t, err, sql.ErrConnDone)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSyncRepo_Changes(t *testing.T) {
+	revQuery := regexp.QuoteMeta(`SELECT revision FROM "user" WHERE id = $1`)
+	dataQuery := regexp.QuoteMeta(`SELECT ` + dataColumns + `, bin_data, deleted_at IS NOT NULL FROM metadata ` +
+		`WHERE user_id = $1 AND revision > $2 AND revision <= $3 ORDER BY revision LIMIT $4`)
+	fileQuery := regexp.QuoteMeta(`SELECT name, revision, deleted FROM file_revision ` +
+		`WHERE user_id = $1 AND revision > $2 AND revision <= $3 ORDER BY revision LIMIT $4`)
+	dataRows := func() *sqlmock.Rows {
+		return sqlmock.NewRows(append(dataRowColumns, "bin_data", "deleted"))
+	}
+
+	t.Run("UpToDate", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(revQuery).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"revision"}).AddRow(5))
+
+		r := NewSyncRepository(db, logrus.New())
+		got, err := r.Changes(context.Background(), 1, 5, 10)
+		require.NoError(t, err)
+		require.Equal(t, &model.SyncChanges{Revision: 5}, got)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Merged", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(revQuery).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"revision"}).AddRow(9))
+		mock.ExpectQuery(dataQuery).WithArgs(1, 2, 9, 3).WillReturnRows(dataRows().
+			AddRow(10, "TEXT", "note", "", "", "", "sealed", "hash", "", 0, 0, "", "", "", "", 3, []byte(nil), false).
+			AddRow(11, "TEXT", "", "", "", "", "", "hash", "", 0, 0, "", "", "", "", 6, []byte(nil), true))
+		mock.ExpectQuery(fileQuery).WithArgs(1, 2, 9, 3).WillReturnRows(sqlmock.NewRows([]string{"name", "revision", "deleted"}).
+			AddRow("a.txt", 4, false).
+			AddRow("b.txt", 8, true))
+
+		r := NewSyncRepository(db, logrus.New())
+		got, err := r.Changes(context.Background(), 1, 2, 2)
+		require.NoError(t, err)
+		require.True(t, got.HasMore)
+		require.Equal(t, int64(4), got.Revision)
+		require.Len(t, got.Data, 1)
+		require.Equal(t, int64(10), got.Data[0].ID)
+		require.Equal(t, []model.FileItem{{Name: "a.txt", Revision: 4}}, got.Files)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("QueryError", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(revQuery).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"revision"}).AddRow(9))
+		mock.ExpectQuery(dataQuery).WillReturnError(sql.ErrConnDone)
+
+		r := NewSyncRepository(db, logrus.New())
+		_, err = r.Changes(context.Background(), 1, 2, 2)
+		require.ErrorIs(t, err, sql.ErrConnDone)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestSyncRepo_FileRevision(t *testing.T) {
+	query := regexp.QuoteMeta(`SELECT revision FROM file_revision WHERE user_id = $1 AND name = $2 AND NOT deleted`)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery(query).WithArgs(1, "a.txt").WillReturnRows(sqlmock.NewRows([]string{"revision"}).AddRow(4))
+	mock.ExpectQuery(query).WithArgs(1, "b.txt").WillReturnError(sql.ErrNoRows)
+	mock.ExpectQuery(query).WithArgs(1, "c.txt").WillReturnError(sql.ErrConnDone)
+
+	r := NewSyncRepository(db, logrus.New())
+	rev, err := r.FileRevision(context.Background(), 1, "a.txt")
+	require.NoError(t, err)
+	require.Equal(t, int64(4), rev)
+
+	rev, err = r.FileRevision(context.Background(), 1, "b.txt")
+	require.NoError(t, err)
+	require.Zero(t, rev)
+
+	_, err = r.FileRevision(context.Background(), 1, "c.txt")
+	require.ErrorIs(t, err, sql.ErrConnDone)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSyncRepo_FileRevisions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT name, revision FROM file_revision WHERE user_id = $1 AND NOT deleted`)).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "revision"}).AddRow("a.txt", 4).AddRow("b.txt", 6))
+
+	r := NewSyncRepository(db, logrus.New())
+	got, err := r.FileRevisions(context.Background(), 1)
+	require.NoError(t, err)
+	require.Equal(t, map[string]int64{"a.txt": 4, "b.txt": 6}, got)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSyncRepo_ListTrash(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	deleted := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT name, revision, deleted_at FROM file_revision ` +
+		`WHERE user_id = $1 AND deleted AND NOT purged AND deleted_at IS NOT NULL ORDER BY deleted_at DESC`)).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "revision", "deleted_at"}).AddRow("a.txt", 9, deleted))
+
+	r := NewSyncRepository(db, logrus.New())
+	got, err := r.ListTrash(context.Background(), 1)
+	require.NoError(t, err)
+	require.Equal(t, []model.TrashItem{{File: &model.FileItem{Name: "a.txt", Revision: 9, Deleted: true}, DeletedAt: deleted}}, got)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSyncRepo_ExpiredFiles(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	before := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	query := regexp.QuoteMeta(`SELECT user_id, name FROM file_revision WHERE deleted AND NOT purged AND deleted_at < $1`)
+	mock.ExpectQuery(query).WithArgs(before).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "name"}).AddRow(1, "a.txt").AddRow(2, "b.txt"))
+	mock.ExpectQuery(query).WithArgs(before).WillReturnError(sql.ErrConnDone)
+
+	r := NewSyncRepository(db, logrus.New())
+	got, err := r.ExpiredFiles(context.Background(), before)
+	require.NoError(t, err)
+	require.Equal(t, []model.PurgedItem{{UserID: 1, FileName: "a.txt"}, {UserID: 2, FileName: "b.txt"}}, got)
+
+	_, err = r.ExpiredFiles(context.Background(), before)
+	require.ErrorIs(t, err, sql.ErrConnDone)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSyncRepo_MarkFilePurged(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	before := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	query := regexp.QuoteMeta(`UPDATE file_revision SET purged = true WHERE user_id = $1 AND name = $2 AND deleted AND NOT purged AND deleted_at < $3`)
+	mock.ExpectExec(query).WithArgs(1, "a.txt", before).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(query).WithArgs(1, "a.txt", before).WillReturnError(sql.ErrConnDone)
+
+	r := NewSyncRepository(db, logrus.New())
+	require.NoError(t, r.MarkFilePurged(context.Background(), 1, "a.txt", before))
+	require.ErrorIs(t, r.MarkFilePurged(context.Background(), 1, "a.txt", before), sql.ErrConnDone)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,217 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/Arcadian-Sky/datakkeeper/internal/model"
+	"github.com/sirupsen/logrus"
+)
+
+type UploadRepository interface {
+	Create(ctx context.Context, session *model.UploadSession) error
+	Get(ctx context.Context, id string, userID int64) (*model.UploadSession, error)
+	Pending(ctx context.Context, session *model.UploadSession) (int64, int64, error)
+	Append(ctx context.Context, session *model.UploadSession, offset int64, data []byte) error
+	Open(session *model.UploadSession) (*os.File, error)
+	Delete(ctx context.Context, session *model.UploadSession) error
+	PurgeExpired(ctx context.Context, before time.Time) ([]model.UploadSession, error)
+}
+
+// UploadRepo хранит сессии загрузки в postgres, а принятые байты - файлами в dir
+type UploadRepo struct {
+	db  *sql.DB
+	log *logrus.Logger
+	dir string
+	// части одной сессии дописываются по очереди
+	locks sync.Map
+}
+
+func NewUploadRepository(dbs *sql.DB, lg *logrus.Logger, dir string) *UploadRepo {
+	return &UploadRepo{
+		db:  dbs,
+		log: lg,
+		dir: dir,
+	}
+}
+
+func (r *UploadRepo) path(id string) string {
+	return filepath.Join(r.dir, id+".part")
+}
+
+func (r *UploadRepo) lock(id string) func() {
+	mu, _ := r.locks.LoadOrStore(id, &sync.Mutex{})
+	mu.(*sync.Mutex).Lock()
+	return mu.(*sync.Mutex).Unlock
+}
+
+// received returns how many bytes of the session are stored
+func (r *UploadRepo) received(id string) (int64, error) {
+	st, err := os.Stat(r.path(id))
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return st.Size(), nil
+}
+
+// Create starts a session, for the same file (name, size and checksum) the existing session is continued:
+// session.ID is replaced with its id and Offset is set to the bytes already received
+func (r *UploadRepo) Create(ctx context.Context, session *model.UploadSession) error {
+	session.CreatedAt = time.Now().UTC()
+	session.UpdatedAt = session.CreatedAt
+	query := `INSERT INTO upload_session (id, user_id, filename, size, sha256, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (user_id, filename, size, sha256) DO UPDATE SET updated_at = EXCLUDED.updated_at
+		RETURNING id`
+	err := r.db.QueryRowContext(ctx, query, session.ID, session.UserID, session.FileName, session.Size, session.SHA256,
+		session.CreatedAt, session.UpdatedAt).Scan(&session.ID)
+	if err != nil {
+		r.log.WithError(err).Error("Failed to create upload session")
+		return err
+	}
+	session.Offset, err = r.received(session.ID)
+	return err
+}
+
+// Get returns the session of the user with the received size as Offset
+func (r *UploadRepo) Get(ctx context.Context, id string, userID int64) (*model.UploadSession, error) {
+	query := `SELECT filename, size, sha256, created_at, updated_at FROM upload_session WHERE id = $1 AND user_id = $2`
+	session := model.UploadSession{ID: id, UserID: userID}
+	err := r.db.QueryRowContext(ctx, query, id, userID).
+		Scan(&session.FileName, &session.Size, &session.SHA256, &session.CreatedAt, &session.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrUploadNotFound
+		}
+		r.log.WithError(err).Error("Failed to get upload session")
+		return nil, err
+	}
+	session.Offset, err = r.received(id)
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// Pending returns the number and the declared size of other unfinished sessions of the user,
+// the session of the same file is not counted, it's continued by Create
+func (r *UploadRepo) Pending(ctx context.Context, session *model.UploadSession) (int64, int64, error) {
+	query := `SELECT count(*), COALESCE(SUM(size), 0) FROM upload_session
+		WHERE user_id = $1 AND NOT (filename = $2 AND size = $3 AND sha256 = $4)`
+	var count, size int64
+	err := r.db.QueryRowContext(ctx, query, session.UserID, session.FileName, session.Size, session.SHA256).Scan(&count, &size)
+	if err != nil {
+		r.log.WithError(err).Error("Failed to count upload sessions")
+		return 0, 0, err
+	}
+	return count, size, nil
+}
+
+// Append writes data at offset, which must be equal to the received size.
+// On success session.Offset is moved past data and the session is marked active, PurgeExpired counts from it.
+func (r *UploadRepo) Append(ctx context.Context, session *model.UploadSession, offset int64, data []byte) error {
+	defer r.lock(session.ID)()
+
+	received, err := r.received(session.ID)
+	if err != nil {
+		return err
+	}
+	session.Offset = received
+	if offset != received {
+		return fmt.Errorf("%w: expected offset %d", model.ErrUploadOffset, received)
+	}
+	if received+int64(len(data)) > session.Size {
+		return model.ErrUploadTooLarge
+	}
+
+	if err := os.MkdirAll(r.dir, 0o700); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(r.path(session.ID), os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	n, err := file.Write(data)
+	session.Offset += int64(n)
+	if cerr := file.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+
+	session.UpdatedAt = time.Now().UTC()
+	_, err = r.db.ExecContext(ctx, `UPDATE upload_session SET updated_at = $1 WHERE id = $2`, session.UpdatedAt, session.ID)
+	if err != nil {
+		r.log.WithError(err).Error("Failed to touch upload session")
+		return err
+	}
+	return nil
+}
+
+// Open returns received content of the session
+func (r *UploadRepo) Open(session *model.UploadSession) (*os.File, error) {
+	// для пустого файла не приходит ни одной части
+	if err := os.MkdirAll(r.dir, 0o700); err != nil {
+		return nil, err
+	}
+	return os.OpenFile(r.path(session.ID), os.O_RDONLY|os.O_CREATE, 0o600)
+}
+
+// Delete removes the session and its received content
+func (r *UploadRepo) Delete(ctx context.Context, session *model.UploadSession) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM upload_session WHERE id = $1`, session.ID)
+	if err != nil {
+		r.log.WithError(err).Error("Failed to delete upload session")
+		return err
+	}
+	return r.remove(session.ID)
+}
+
+func (r *UploadRepo) remove(id string) error {
+	defer r.locks.Delete(id)
+	if err := os.Remove(r.path(id)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// PurgeExpired removes sessions without received parts since before
+func (r *UploadRepo) PurgeExpired(ctx context.Context, before time.Time) ([]model.UploadSession, error) {
+	query := `DELETE FROM upload_session WHERE updated_at < $1 RETURNING id, user_id, filename`
+	rows, err := r.db.QueryContext(ctx, query, before.UTC())
+	if err != nil {
+		r.log.WithError(err).Error("Failed to purge upload sessions")
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sessions []model.UploadSession
+	for rows.Next() {
+		var session model.UploadSession
+		if err := rows.Scan(&session.ID, &session.UserID, &session.FileName); err != nil {
+			r.log.WithError(err).Error("Failed to scan upload session")
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+	if err := rows.Err(); err != nil {
+		r.log.WithError(err).Error("Error while iterating rows")
+		return nil, err
+	}
+
+	for _, session := range sessions {
+		if err := r.remove(session.ID); err != nil {
+			r.log.WithError(err).Error("Failed to remove upload content")
+		}
+	}
+	return sessions, nil
+}
@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Arcadian-Sky/datakkeeper/internal/model"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUploadRepo_Create(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	dir := t.TempDir()
+	query := `INSERT INTO upload_session \(id, user_id, filename, size, sha256, created_at, updated_at\) VALUES \(\$1, \$2, \$3, \$4, \$5, \$6, \$7\)`
+	mock.ExpectQuery(query).WithArgs("new", 1, "a.txt", 10, "sha", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("new"))
+	mock.ExpectExec(`UPDATE upload_session SET updated_at = \$1 WHERE id = \$2`).WithArgs(sqlmock.AnyArg(), "new").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	// та же загрузка продолжает прежнюю сессию
+	mock.ExpectQuery(query).WithArgs("other", 1, "a.txt", 10, "sha", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("new"))
+	mock.ExpectQuery(query).WillReturnError(sql.ErrConnDone)
+
+	r := NewUploadRepository(db, logrus.New(), dir)
+
+	session := &model.UploadSession{ID: "new", UserID: 1, FileName: "a.txt", Size: 10, SHA256: "sha"}
+	require.NoError(t, r.Create(context.Background(), session))
+	require.Equal(t, int64(0), session.Offset)
+	require.NoError(t, r.Append(context.Background(), session, 0, []byte("1234")))
+
+	session = &model.UploadSession{ID: "other", UserID: 1, FileName: "a.txt", Size: 10, SHA256: "sha"}
+	require.NoError(t, r.Create(context.Background(), session))
+	require.Equal(t, "new", session.ID)
+	require.Equal(t, int64(4), session.Offset)
+
+	require.ErrorIs(t, r.Create(context.Background(), &model.UploadSession{ID: "x"}), sql.ErrConnDone)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUploadRepo_Get(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "up1.part"), []byte("123"), 0o600))
+	created := time.Date(2024, 8, 1, 10, 0, 0, 0, time.UTC)
+	updated := created.Add(time.Hour)
+	query := `SELECT filename, size, sha256, created_at, updated_at FROM upload_session WHERE id = \$1 AND user_id = \$2`
+	mock.ExpectQuery(query).WithArgs("up1", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"filename", "size", "sha256", "created_at", "updated_at"}).AddRow("a.txt", 10, "sha", created, updated))
+	mock.ExpectQuery(query).WithArgs("up1", 2).WillReturnError(sql.ErrNoRows)
+
+	r := NewUploadRepository(db, logrus.New(), dir)
+	got, err := r.Get(context.Background(), "up1", 1)
+	require.NoError(t, err)
+	require.Equal(t, &model.UploadSession{ID: "up1", UserID: 1, FileName: "a.txt", Size: 10, SHA256: "sha", Offset: 3, CreatedAt: created, UpdatedAt: updated}, got)
+
+	_, err = r.Get(context.Background(), "up1", 2)
+	require.ErrorIs(t, err, model.ErrUploadNotFound)
+}
+
+func TestUploadRepo_Pending(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	query := `SELECT count\(\*\), COALESCE\(SUM\(size\), 0\) FROM upload_session\s+WHERE user_id = \$1 AND NOT \(filename = \$2 AND size = \$3 AND sha256 = \$4\)`
+	mock.ExpectQuery(query).WithArgs(1, "a.txt", 10, "sha").
+		WillReturnRows(sqlmock.NewRows([]string{"count", "sum"}).AddRow(2, 300))
+	mock.ExpectQuery(query).WillReturnError(sql.ErrConnDone)
+
+	r := NewUploadRepository(db, logrus.New(), t.TempDir())
+	session := &model.UploadSession{UserID: 1, FileName: "a.txt", Size: 10, SHA256: "sha"}
+	count, size, err := r.Pending(context.Background(), session)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+	require.Equal(t, int64(300), size)
+
+	_, _, err = r.Pending(context.Background(), session)
+	require.ErrorIs(t, err, sql.ErrConnDone)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUploadRepo_AppendOpen(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	// каждая принятая часть продлевает жизнь сессии
+	touch := `UPDATE upload_session SET updated_at = \$1 WHERE id = \$2`
+	mock.ExpectExec(touch).WithArgs(sqlmock.AnyArg(), "up1").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(touch).WithArgs(sqlmock.AnyArg(), "up1").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	r := NewUploadRepository(db, logrus.New(), filepath.Join(t.TempDir(), "uploads"))
+	session := &model.UploadSession{ID: "up1", Size: 6}
+	ctx := context.Background()
+
+	require.NoError(t, r.Append(ctx, session, 0, []byte("abc")))
+	require.Equal(t, int64(3), session.Offset)
+	require.False(t, session.UpdatedAt.IsZero())
+	// повтор уже принятой части
+	require.ErrorIs(t, r.Append(ctx, session, 0, []byte("abc")), model.ErrUploadOffset)
+	require.ErrorIs(t, r.Append(ctx, session, 3, []byte("defg")), model.ErrUploadTooLarge)
+	require.NoError(t, r.Append(ctx, session, 3, []byte("def")))
+	require.True(t, session.Complete())
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	file, err := r.Open(session)
+	require.NoError(t, err)
+	defer file.Close()
+	got, err := io.ReadAll(file)
+	require.NoError(t, err)
+	require.Equal(t, "abcdef", string(got))
+
+	empty, err := r.Open(&model.UploadSession{ID: "empty"})
+	require.NoError(t, err)
+	defer empty.Close()
+	got, err = io.ReadAll(empty)
+	require.NoError(t, err)
+	require.Empty(t, got)
+}
+
+func TestUploadRepo_Delete(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	dir := t.TempDir()
+	mock.ExpectExec(`UPDATE upload_session SET updated_at = \$1 WHERE id = \$2`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`DELETE FROM upload_session WHERE id = \$1`).WithArgs("up1").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	r := NewUploadRepository(db, logrus.New(), dir)
+	session := &model.UploadSession{ID: "up1", Size: 3}
+	require.NoError(t, r.Append(context.Background(), session, 0, []byte("abc")))
+	require.NoError(t, r.Delete(context.Background(), session))
+	require.NoFileExists(t, filepath.Join(dir, "up1.part"))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUploadRepo_PurgeExpired(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "up1.part"), []byte("123"), 0o600))
+	mock.ExpectQuery(`DELETE FROM upload_session WHERE updated_at < \$1 RETURNING id, user_id, filename`).
+		WithArgs(sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "filename"}).AddRow("up1", 1, "a.txt").AddRow("up2", 2, "b.txt"))
+
+	r := NewUploadRepository(db, logrus.New(), dir)
+	got, err := r.PurgeExpired(context.Background(), time.Now())
+	require.NoError(t, err)
+	require.Equal(t, []model.UploadSession{{ID: "up1", UserID: 1, FileName: "a.txt"}, {ID: "up2", UserID: 2, FileName: "b.txt"}}, got)
+	require.NoFileExists(t, filepath.Join(dir, "up1.part"))
+}
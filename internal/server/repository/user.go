@@ -15,6 +15,7 @@ type UserRepository interface {
 	Register(ctx context.Context, user *model.User) (int64, error)
 	Auth(ctx context.Context, user *model.User) (*model.User, error)
 	SetLastUpdate(ctx context.Context, user *model.User) (*model.User, error)
+	BindKeyHash(ctx context.Context, user *model.User) error
 }
 
 type UserRepo struct {
@@ -90,3 +91,23 @@ func (r *UserRepo) SetLastUpdate(ctx context.Context, user *model.User) (*model.
 
 	return user, nil
 }
+
+// BindKeyHash - первая зашифрованная запись закрепляет за пользователем хеш мастер-ключа,
+// последующие записи с другим хешем отклоняются
+func (r *UserRepo) BindKeyHash(ctx context.Context, user *model.User) error {
+	query := `UPDATE "user" SET key_hash = $1 WHERE id = $2 AND (key_hash IS NULL OR key_hash = $1)`
+	res, err := r.db.ExecContext(ctx, query, user.KeyHash, user.ID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return model.ErrKeyHashMismatch
+	}
+
+	return nil
+}
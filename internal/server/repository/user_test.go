@@ -351,3 +351,52 @@ func TestUserRepo_SetLastUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestUserRepo_BindKeyHash(t *testing.T) {
+	logg := logrus.New()
+	query := `UPDATE "user" SET key_hash = \$1 WHERE id = \$2 AND \(key_hash IS NULL OR key_hash = \$1\)`
+
+	tests := []struct {
+		name    string
+		mock    func(mock sqlmock.Sqlmock)
+		wantErr error
+	}{
+		{
+			name: "FirstOrSameKey",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).WithArgs("hash1", 1).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "AnotherKey",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).WithArgs("hash1", 1).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: model.ErrKeyHashMismatch,
+		},
+		{
+			name: "ExecError",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(query).WithArgs("hash1", 1).WillReturnError(sql.ErrConnDone)
+			},
+			wantErr: sql.ErrConnDone,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			tt.mock(mock)
+
+			r := &UserRepo{db: db, log: logg}
+			err = r.BindKeyHash(context.Background(), &model.User{ID: 1, KeyHash: "hash1"})
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,88 @@
+// Package changefeed рассылает события изменений открытым потокам WatchChanges одного пользователя.
+// Хаб живет в памяти процесса, события других экземпляров сервера сюда не попадают.
+package changefeed
+
+import (
+	"sync"
+
+	"github.com/Arcadian-Sky/datakkeeper/internal/model"
+)
+
+// SubscriberBuffer - сколько событий ждет медленного подписчика, после этого подписка закрывается
+const SubscriberBuffer = 64
+
+type subscriber struct {
+	ch chan model.ChangeEvent
+}
+
+type Hub struct {
+	mu     sync.Mutex
+	subs   map[int64]map[*subscriber]struct{}
+	closed bool
+}
+
+func NewHub() *Hub {
+	return &Hub{subs: make(map[int64]map[*subscriber]struct{})}
+}
+
+// Subscribe returns events of the user and a function to unsubscribe.
+// The channel is closed on unsubscribe, on Close and when the subscriber falls behind.
+func (h *Hub) Subscribe(userID int64) (<-chan model.ChangeEvent, func()) {
+	sub := &subscriber{ch: make(chan model.ChangeEvent, SubscriberBuffer)}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		close(sub.ch)
+		return sub.ch, func() {}
+	}
+	if h.subs[userID] == nil {
+		h.subs[userID] = make(map[*subscriber]struct{})
+	}
+	h.subs[userID][sub] = struct{}{}
+
+	return sub.ch, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		h.remove(userID, sub)
+	}
+}
+
+// Publish sends the event to all subscribers of the user without blocking
+func (h *Hub) Publish(userID int64, ev model.ChangeEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for sub := range h.subs[userID] {
+		select {
+		case sub.ch <- ev:
+		default:
+			// подписчик пропустил бы событие, закрываем, чтобы клиент переподключился и перечитал данные
+			h.remove(userID, sub)
+		}
+	}
+}
+
+// Close closes all subscriptions, so streams are finished before the server stops
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for userID, subs := range h.subs {
+		for sub := range subs {
+			h.remove(userID, sub)
+		}
+	}
+	h.closed = true
+}
+
+// remove must be called with h.mu held
+func (h *Hub) remove(userID int64, sub *subscriber) {
+	subs := h.subs[userID]
+	if _, ok := subs[sub]; !ok {
+		return
+	}
+	delete(subs, sub)
+	close(sub.ch)
+	if len(subs) == 0 {
+		delete(h.subs, userID)
+	}
+}
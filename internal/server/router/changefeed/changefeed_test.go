@@ -0,0 +1,53 @@
+package changefeed
+
+import (
+	"testing"
+
+	"github.com/Arcadian-Sky/datakkeeper/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHub_Publish(t *testing.T) {
+	h := NewHub()
+	events, cancel := h.Subscribe(1)
+	other, cancelOther := h.Subscribe(2)
+	defer cancelOther()
+
+	ev := model.ChangeEvent{Op: model.ChangeCreated, DataID: 10}
+	h.Publish(1, ev)
+	assert.Equal(t, ev, <-events)
+	assert.Empty(t, other, "events of another user are not delivered")
+
+	cancel()
+	_, ok := <-events
+	assert.False(t, ok)
+	cancel()
+	h.Publish(1, ev)
+}
+
+func TestHub_SlowSubscriber(t *testing.T) {
+	h := NewHub()
+	events, cancel := h.Subscribe(1)
+	defer cancel()
+
+	for i := 0; i <= SubscriberBuffer; i++ {
+		h.Publish(1, model.ChangeEvent{Op: model.ChangeUpdated, DataID: int64(i)})
+	}
+	n := 0
+	for range events {
+		n++
+	}
+	assert.Equal(t, SubscriberBuffer, n, "overflowed subscription is closed after buffered events")
+}
+
+func TestHub_Close(t *testing.T) {
+	h := NewHub()
+	events, _ := h.Subscribe(1)
+	h.Close()
+	_, ok := <-events
+	assert.False(t, ok)
+
+	late, _ := h.Subscribe(1)
+	_, ok = <-late
+	assert.False(t, ok)
+}
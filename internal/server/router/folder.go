@@ -0,0 +1,103 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	pbservice "github.com/Arcadian-Sky/datakkeeper/gen/proto/api/service/v1"
+	"github.com/Arcadian-Sky/datakkeeper/internal/model"
+	"github.com/Arcadian-Sky/datakkeeper/internal/server/router/jwtrule"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CreateFolder creates an empty folder, folders of uploaded files appear without it
+func (s *GRPCServer) CreateFolder(ctx context.Context, in *pbservice.CreateFolderRequest) (*pbservice.UploadStatus, error) {
+	uID := jwtrule.GetUserIDFromCTX(ctx)
+	s.log.Trace("uID: ", uID)
+
+	if err := model.ValidateFileName(in.Path); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := s.reposervice.CreateFolder(ctx, in.Path, &model.User{ID: uID}); err != nil {
+		if errors.Is(err, model.ErrFileExists) {
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		}
+		e := fmt.Sprintf("failed to create folder: %v", err)
+		s.log.Info(e)
+		return nil, status.Error(codes.Internal, e)
+	}
+
+	return &pbservice.UploadStatus{Success: true, Message: "folder was created"}, nil
+}
+
+// RenameFile renames the file or the folder, it stays in the same folder
+func (s *GRPCServer) RenameFile(ctx context.Context, in *pbservice.RenameFileRequest) (*pbservice.UploadStatus, error) {
+	if strings.Contains(in.NewName, model.FolderSeparator) {
+		return nil, status.Error(codes.InvalidArgument, "new_name must not contain "+model.FolderSeparator)
+	}
+	return s.moveFile(ctx, in.Filename, model.JoinPath(model.FolderOf(in.Filename), in.NewName), in.ExpectedRevision)
+}
+
+// MoveFile moves the file or the folder with all its content to another folder
+func (s *GRPCServer) MoveFile(ctx context.Context, in *pbservice.MoveFileRequest) (*pbservice.UploadStatus, error) {
+	return s.moveFile(ctx, in.Filename, model.JoinPath(in.Folder, model.BaseName(in.Filename)), in.ExpectedRevision)
+}
+
+// moveFile moves objects in the storage, then metadata and revisions of every moved file.
+// Другие устройства видят перенос как удаление старого имени и появление нового.
+func (s *GRPCServer) moveFile(ctx context.Context, from, to string, expected *int64) (*pbservice.UploadStatus, error) {
+	uID := jwtrule.GetUserIDFromCTX(ctx)
+	s.log.Trace("uID: ", uID)
+
+	if err := model.ValidateFileName(from); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := model.ValidateFileName(to); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if model.InFolder(to, from) {
+		return nil, status.Error(codes.InvalidArgument, "folder can't be moved into itself")
+	}
+	if err := s.checkFileRevision(ctx, uID, from, expected); err != nil {
+		return nil, err
+	}
+	if err := s.checkMoveQuota(ctx, uID, from); err != nil {
+		return nil, err
+	}
+
+	moves, err := s.reposervice.MoveFile(ctx, from, to, &model.User{ID: uID})
+	// уже перенесенные файлы отмечаются и при ошибке, иначе другие устройства их потеряют
+	for _, move := range moves {
+		if model.IsFolderKey(move.From) {
+			continue
+		}
+		if err := s.repometa.Rename(ctx, uID, model.MetaKindFile, move.From, move.To); err != nil {
+			e := fmt.Sprintf("failed to move file metadata: %v", err)
+			s.log.Info(e)
+			return nil, status.Error(codes.Internal, e)
+		}
+		if _, err := s.reposync.MoveFile(ctx, uID, move.From, move.To); err != nil {
+			e := fmt.Sprintf("failed to set file revision: %v", err)
+			s.log.Info(e)
+			return nil, status.Error(codes.Internal, e)
+		}
+		s.changes.Publish(uID, model.ChangeEvent{Op: model.ChangeDeleted, FileName: move.From})
+		s.changes.Publish(uID, model.ChangeEvent{Op: model.ChangeCreated, FileName: move.To})
+	}
+	if err != nil {
+		switch {
+		case errors.Is(err, model.ErrFileNotFound):
+			return nil, status.Error(codes.NotFound, err.Error())
+		case errors.Is(err, model.ErrFileExists):
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		}
+		e := fmt.Sprintf("failed to move file: %v", err)
+		s.log.Info(e)
+		return nil, status.Error(codes.Internal, e)
+	}
+
+	return &pbservice.UploadStatus{Success: true, Message: "file was moved"}, nil
+}
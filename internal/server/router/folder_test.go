@@ -0,0 +1,125 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	pbservice "github.com/Arcadian-Sky/datakkeeper/gen/proto/api/service/v1"
+	"github.com/Arcadian-Sky/datakkeeper/internal/model"
+	"github.com/Arcadian-Sky/datakkeeper/internal/server/router/jwtrule"
+	"github.com/Arcadian-Sky/datakkeeper/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestGRPCServer_CreateFolder(t *testing.T) {
+	server := createTestMockServer(t)
+	ctx := jwtrule.SetUserIDToCTX(context.Background(), 1)
+	repo := server.reposervice.(*mocks.MockFileRepository)
+
+	repo.EXPECT().CreateFolder(gomock.Any(), "work/2024", &model.User{ID: 1}).Return(nil)
+	res, err := server.CreateFolder(ctx, &pbservice.CreateFolderRequest{Path: "work/2024"})
+	assert.NoError(t, err)
+	assert.True(t, res.Success)
+
+	repo.EXPECT().CreateFolder(gomock.Any(), "work", &model.User{ID: 1}).Return(model.ErrFileExists)
+	_, err = server.CreateFolder(ctx, &pbservice.CreateFolderRequest{Path: "work"})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	repo.EXPECT().CreateFolder(gomock.Any(), "work", &model.User{ID: 1}).Return(errors.New("minio error"))
+	_, err = server.CreateFolder(ctx, &pbservice.CreateFolderRequest{Path: "work"})
+	assert.Equal(t, codes.Internal, status.Code(err))
+
+	for _, path := range []string{"", "work/", "../work", ".trash"} {
+		_, err = server.CreateFolder(ctx, &pbservice.CreateFolderRequest{Path: path})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), path)
+	}
+}
+
+func TestGRPCServer_RenameFile(t *testing.T) {
+	server := createTestMockServer(t)
+	ctx := jwtrule.SetUserIDToCTX(context.Background(), 1)
+	events, unsubscribe := server.changes.Subscribe(1)
+	defer unsubscribe()
+
+	t.Run("Success", func(t *testing.T) {
+		server.reposync.(*mocks.MockSyncRepository).EXPECT().FileRevision(gomock.Any(), int64(1), "work/a.txt").Return(int64(3), nil)
+		server.reposervice.(*mocks.MockFileRepository).EXPECT().
+			MoveFile(gomock.Any(), "work/a.txt", "work/b.txt", &model.User{ID: 1}).
+			Return([]model.FileMove{{From: "work/a.txt", To: "work/b.txt"}}, nil)
+		server.repometa.(*mocks.MockItemMetaRepository).EXPECT().Rename(gomock.Any(), int64(1), model.MetaKindFile, "work/a.txt", "work/b.txt").Return(nil)
+		server.reposync.(*mocks.MockSyncRepository).EXPECT().MoveFile(gomock.Any(), int64(1), "work/a.txt", "work/b.txt").Return(int64(5), nil)
+
+		res, err := server.RenameFile(ctx, &pbservice.RenameFileRequest{Filename: "work/a.txt", NewName: "b.txt", ExpectedRevision: proto.Int64(3)})
+		assert.NoError(t, err)
+		assert.True(t, res.Success)
+		assert.Equal(t, model.ChangeEvent{Op: model.ChangeDeleted, FileName: "work/a.txt"}, <-events)
+		assert.Equal(t, model.ChangeEvent{Op: model.ChangeCreated, FileName: "work/b.txt"}, <-events)
+	})
+
+	t.Run("Conflict", func(t *testing.T) {
+		server.reposync.(*mocks.MockSyncRepository).EXPECT().FileRevision(gomock.Any(), int64(1), "a.txt").Return(int64(4), nil)
+		server.repometa.(*mocks.MockItemMetaRepository).EXPECT().Get(gomock.Any(), gomock.Any()).Return(&model.ItemMeta{}, nil)
+
+		_, err := server.RenameFile(ctx, &pbservice.RenameFileRequest{Filename: "a.txt", NewName: "b.txt", ExpectedRevision: proto.Int64(3)})
+		assert.Equal(t, codes.Aborted, status.Code(err))
+	})
+
+	t.Run("InvalidName", func(t *testing.T) {
+		for _, name := range []string{"", "sub/b.txt", ".."} {
+			_, err := server.RenameFile(ctx, &pbservice.RenameFileRequest{Filename: "a.txt", NewName: name})
+			assert.Equal(t, codes.InvalidArgument, status.Code(err), name)
+		}
+	})
+}
+
+func TestGRPCServer_MoveFile(t *testing.T) {
+	server := createTestMockServer(t)
+	ctx := jwtrule.SetUserIDToCTX(context.Background(), 1)
+	repo := server.reposervice.(*mocks.MockFileRepository)
+
+	t.Run("Folder", func(t *testing.T) {
+		repo.EXPECT().
+			MoveFile(gomock.Any(), "work", "archive/work", &model.User{ID: 1}).
+			Return([]model.FileMove{
+				{From: "work/", To: "archive/work/"},
+				{From: "work/a.txt", To: "archive/work/a.txt"},
+			}, nil)
+		// маркер папки не файл, метаданных и ревизии у него нет
+		server.repometa.(*mocks.MockItemMetaRepository).EXPECT().Rename(gomock.Any(), int64(1), model.MetaKindFile, "work/a.txt", "archive/work/a.txt").Return(nil)
+		server.reposync.(*mocks.MockSyncRepository).EXPECT().MoveFile(gomock.Any(), int64(1), "work/a.txt", "archive/work/a.txt").Return(int64(5), nil)
+
+		_, err := server.MoveFile(ctx, &pbservice.MoveFileRequest{Filename: "work", Folder: "archive"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("PartialMove", func(t *testing.T) {
+		repo.EXPECT().
+			MoveFile(gomock.Any(), "work/a.txt", "a.txt", &model.User{ID: 1}).
+			Return([]model.FileMove{{From: "work/a.txt", To: "a.txt"}}, errors.New("minio error"))
+		server.repometa.(*mocks.MockItemMetaRepository).EXPECT().Rename(gomock.Any(), int64(1), model.MetaKindFile, "work/a.txt", "a.txt").Return(nil)
+		server.reposync.(*mocks.MockSyncRepository).EXPECT().MoveFile(gomock.Any(), int64(1), "work/a.txt", "a.txt").Return(int64(5), nil)
+
+		_, err := server.MoveFile(ctx, &pbservice.MoveFileRequest{Filename: "work/a.txt"})
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		repo.EXPECT().MoveFile(gomock.Any(), "a.txt", "work/a.txt", gomock.Any()).Return(nil, model.ErrFileNotFound)
+		_, err := server.MoveFile(ctx, &pbservice.MoveFileRequest{Filename: "a.txt", Folder: "work"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+
+		repo.EXPECT().MoveFile(gomock.Any(), "a.txt", "work/a.txt", gomock.Any()).Return(nil, model.ErrFileExists)
+		_, err = server.MoveFile(ctx, &pbservice.MoveFileRequest{Filename: "a.txt", Folder: "work"})
+		assert.Equal(t, codes.AlreadyExists, status.Code(err))
+
+		_, err = server.MoveFile(ctx, &pbservice.MoveFileRequest{Filename: "work", Folder: "work/2024"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		_, err = server.MoveFile(ctx, &pbservice.MoveFileRequest{Filename: "a.txt", Folder: ".trash"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
@@ -17,13 +17,20 @@ var (
 	SkipCheckMethods = map[string]struct{}{
 		"/proto.api.user.v1.UserService/Register":     {},
 		"/proto.api.user.v1.UserService/Authenticate": {},
+		// refresh-токен проверяется в самом методе
+		"/proto.api.user.v1.UserService/RefreshSession": {},
 	}
 	PostProcessMethods = map[string]struct{}{
 		"/proto.api.service.v1.DataKeeperService/UploadFile": {},
 	}
 )
 
-func UnaryInterceptor(log *logrus.Logger, secretKey string) grpc.UnaryServerInterceptor {
+// SessionChecker сообщает, не отозван ли сеанс, к которому привязан токен
+type SessionChecker interface {
+	IsActive(ctx context.Context, sid string) (bool, error)
+}
+
+func UnaryInterceptor(log *logrus.Logger, keys jwtrule.Keyring, sessions SessionChecker) grpc.UnaryServerInterceptor {
 	return func(
 		ctx context.Context,
 		req interface{},
@@ -33,15 +40,15 @@ func UnaryInterceptor(log *logrus.Logger, secretKey string) grpc.UnaryServerInte
 
 		log.Trace("--> unary interceptor: ", info.FullMethod)
 
-		preProcess(ctx, info.FullMethod, log, secretKey)
+		preProcess(ctx, info.FullMethod, log)
 
-		jwToken, err := checkAuth(&ctx, log, secretKey, info.FullMethod, nil)
+		jwToken, err := checkAuth(&ctx, log, keys, sessions, info.FullMethod, nil)
 		if err != nil {
 			return ctx, err
 		} else if jwToken == nil {
 			resp, err = handler(ctx, req)
 		} else {
-			resp, err = handler(jwtrule.SetUserIDToCTX(ctx, int(jwToken.Claims.UserID)), req)
+			resp, err = handler(withClaims(ctx, jwToken), req)
 		}
 
 		postProcess(ctx, info.FullMethod, log, err)
@@ -51,7 +58,7 @@ func UnaryInterceptor(log *logrus.Logger, secretKey string) grpc.UnaryServerInte
 	}
 }
 
-func StreamInterceptor(log *logrus.Logger, secretKey string) grpc.StreamServerInterceptor {
+func StreamInterceptor(log *logrus.Logger, keys jwtrule.Keyring, sessions SessionChecker) grpc.StreamServerInterceptor {
 	return func(
 		srv interface{},
 		ss grpc.ServerStream,
@@ -61,14 +68,14 @@ func StreamInterceptor(log *logrus.Logger, secretKey string) grpc.StreamServerIn
 		ctx := ss.Context()
 		log.Trace("--> stream interceptor: ", info.FullMethod)
 
-		preProcess(ctx, info.FullMethod, log, secretKey)
+		preProcess(ctx, info.FullMethod, log)
 
-		jwToken, err := checkAuth(&ctx, log, secretKey, info.FullMethod, nil)
+		jwToken, err := checkAuth(&ctx, log, keys, sessions, info.FullMethod, nil)
 		log.Trace("--> err: ", err)
 		if err != nil {
 			return err
 		} else if jwToken != nil {
-			ctx = jwtrule.SetUserIDToCTX(ctx, int(jwToken.Claims.UserID))
+			ctx = withClaims(ctx, jwToken)
 			ss = &serverStreamWithContext{ServerStream: ss, ctx: ctx}
 		}
 		log.Trace("--> jwToken: ", jwToken.Claims.UserID)
@@ -95,9 +102,15 @@ func (s *serverStreamWithContext) Context() context.Context {
 	return s.ctx
 }
 
-func checkAuth(ctx *context.Context, log *logrus.Logger, secretKey string, method string, validateFunc func(tokenString string, key string) (model.Jtoken, error)) (*model.Jtoken, error) {
+// withClaims puts user and session ids from the token to the context
+func withClaims(ctx context.Context, jwToken *model.Jtoken) context.Context {
+	ctx = jwtrule.SetUserIDToCTX(ctx, int(jwToken.Claims.UserID))
+	return jwtrule.SetSessionIDToCTX(ctx, jwToken.Claims.SessionID)
+}
+
+func checkAuth(ctx *context.Context, log *logrus.Logger, keys jwtrule.Keyring, sessions SessionChecker, method string, validateFunc func(tokenString string, keys jwtrule.Keyring) (model.Jtoken, error)) (*model.Jtoken, error) {
 	if validateFunc == nil {
-		validateFunc = jwtrule.Validate
+		validateFunc = jwtrule.ValidateWithKeyring
 	}
 	log.Trace("--> interceptor: ", method)
 	// check for method, which doesn't need to be intercepted
@@ -115,17 +128,31 @@ func checkAuth(ctx *context.Context, log *logrus.Logger, secretKey string, metho
 	}
 
 	log.Trace("--> interceptor: check")
-	jwToken, err := validateFunc(token, secretKey)
+	jwToken, err := validateFunc(token, keys)
 	if err != nil {
 		log.Trace("--> interceptor: invalid auth token: ", err)
 		return nil, status.Errorf(codes.Unauthenticated, "invalid auth token: %v", err)
 	}
 	log.Trace("--> interceptor UID: ", jwToken.Claims.UserID)
 
+	if sessions != nil {
+		if jwToken.Claims.SessionID == "" {
+			return nil, status.Error(codes.Unauthenticated, "auth token is not bound to a session")
+		}
+		active, err := sessions.IsActive(*ctx, jwToken.Claims.SessionID)
+		if err != nil {
+			log.Trace("--> interceptor: session check failed: ", err)
+			return nil, status.Errorf(codes.Internal, "failed to check session: %v", err)
+		}
+		if !active {
+			return nil, status.Error(codes.Unauthenticated, model.ErrSessionRevoked.Error())
+		}
+	}
+
 	return &jwToken, nil
 }
 
-func preProcess(ctx context.Context, info string, log *logrus.Logger, _ string) {
+func preProcess(ctx context.Context, info string, log *logrus.Logger) {
 	log.Trace("--> interceptor: before executing:", info)
 	userID := ctx.Value("userID")
 	if userID != nil {
@@ -25,7 +25,7 @@ func TestUnaryInterceptor_NoAuthSuccess(t *testing.T) {
 	log := logrus.New()
 	secretKey := "test-secret"
 
-	interceptor := UnaryInterceptor(log, secretKey)
+	interceptor := UnaryInterceptor(log, jwtrule.NewStaticKeyring(secretKey), nil)
 	// Создаем мокаем контекст с JWT токеном
 	jwToken, err := jwtrule.Generate(123, secretKey)
 	assert.NoError(t, err)
@@ -61,7 +61,7 @@ func TestUnaryInterceptor_AuthCheckSuccess(t *testing.T) {
 	md := metadata.New(map[string]string{"authorization": "bearer " + jwToken.Token})
 	ctx := metadata.NewIncomingContext(context.Background(), md)
 
-	interceptor := UnaryInterceptor(log, secretKey)
+	interceptor := UnaryInterceptor(log, jwtrule.NewStaticKeyring(secretKey), nil)
 
 	info := &grpc.UnaryServerInfo{
 		FullMethod: "/proto.api.service.v1.DataKeeperService/GetFile",
@@ -85,7 +85,7 @@ func TestUnaryInterceptor_AuthFail(t *testing.T) {
 	log := logrus.New()
 	secretKey := "test-secret"
 
-	interceptor := UnaryInterceptor(log, secretKey)
+	interceptor := UnaryInterceptor(log, jwtrule.NewStaticKeyring(secretKey), nil)
 
 	// Мокаем контекст без аутентификации
 	ctx := context.Background()
@@ -115,9 +115,9 @@ func Test_checkAuth(t *testing.T) {
 		args struct {
 			ctx          *context.Context
 			log          *logrus.Logger
-			secretKey    string
+			keys         jwtrule.Keyring
 			method       string
-			validateFunc func(tokenString string, key string) (model.Jtoken, error)
+			validateFunc func(tokenString string, keys jwtrule.Keyring) (model.Jtoken, error)
 		}
 		want    *model.Jtoken
 		wantErr bool
@@ -127,15 +127,15 @@ func Test_checkAuth(t *testing.T) {
 			args: struct {
 				ctx          *context.Context
 				log          *logrus.Logger
-				secretKey    string
+				keys         jwtrule.Keyring
 				method       string
-				validateFunc func(tokenString string, key string) (model.Jtoken, error)
+				validateFunc func(tokenString string, keys jwtrule.Keyring) (model.Jtoken, error)
 			}{
-				ctx:       contextWithToken("bearer valid-token"),
-				log:       logrus.New(),
-				secretKey: "test-secret",
-				method:    "/proto.api.service.v1.DataKeeperService/GetFile",
-				validateFunc: func(tokenString, secretKey string) (model.Jtoken, error) {
+				ctx:    contextWithToken("bearer valid-token"),
+				log:    logrus.New(),
+				keys:   jwtrule.NewStaticKeyring("test-secret"),
+				method: "/proto.api.service.v1.DataKeeperService/GetFile",
+				validateFunc: func(tokenString string, keys jwtrule.Keyring) (model.Jtoken, error) {
 					if tokenString == "valid-token" {
 						return mockedToken, nil
 					}
@@ -150,15 +150,15 @@ func Test_checkAuth(t *testing.T) {
 			args: struct {
 				ctx          *context.Context
 				log          *logrus.Logger
-				secretKey    string
+				keys         jwtrule.Keyring
 				method       string
-				validateFunc func(tokenString string, key string) (model.Jtoken, error)
+				validateFunc func(tokenString string, keys jwtrule.Keyring) (model.Jtoken, error)
 			}{
-				ctx:       contextWithToken(""),
-				log:       logrus.New(),
-				secretKey: "test-secret",
-				method:    "/proto.api.service.v1.DataKeeperService/GetFile",
-				validateFunc: func(tokenString, key string) (model.Jtoken, error) {
+				ctx:    contextWithToken(""),
+				log:    logrus.New(),
+				keys:   jwtrule.NewStaticKeyring("test-secret"),
+				method: "/proto.api.service.v1.DataKeeperService/GetFile",
+				validateFunc: func(tokenString string, keys jwtrule.Keyring) (model.Jtoken, error) {
 					return model.Jtoken{}, status.Error(codes.Unauthenticated, "no token")
 				},
 			},
@@ -170,15 +170,15 @@ func Test_checkAuth(t *testing.T) {
 			args: struct {
 				ctx          *context.Context
 				log          *logrus.Logger
-				secretKey    string
+				keys         jwtrule.Keyring
 				method       string
-				validateFunc func(tokenString string, key string) (model.Jtoken, error)
+				validateFunc func(tokenString string, keys jwtrule.Keyring) (model.Jtoken, error)
 			}{
-				ctx:       contextWithToken("bearer invalid-token"),
-				log:       logrus.New(),
-				secretKey: "test-secret",
-				method:    "/proto.api.service.v1.DataKeeperService/GetFile",
-				validateFunc: func(tokenString, secretKey string) (model.Jtoken, error) {
+				ctx:    contextWithToken("bearer invalid-token"),
+				log:    logrus.New(),
+				keys:   jwtrule.NewStaticKeyring("test-secret"),
+				method: "/proto.api.service.v1.DataKeeperService/GetFile",
+				validateFunc: func(tokenString string, keys jwtrule.Keyring) (model.Jtoken, error) {
 					return model.Jtoken{}, status.Error(codes.Unauthenticated, "invalid token")
 				},
 			},
@@ -190,13 +190,13 @@ func Test_checkAuth(t *testing.T) {
 			args: struct {
 				ctx          *context.Context
 				log          *logrus.Logger
-				secretKey    string
+				keys         jwtrule.Keyring
 				method       string
-				validateFunc func(tokenString string, key string) (model.Jtoken, error)
+				validateFunc func(tokenString string, keys jwtrule.Keyring) (model.Jtoken, error)
 			}{
 				ctx:          contextWithToken("bearer some-token"),
 				log:          logrus.New(),
-				secretKey:    "test-secret",
+				keys:         jwtrule.NewStaticKeyring("test-secret"),
 				method:       "/proto.api.user.v1.UserService/Register",
 				validateFunc: nil, // Use default validation function
 			},
@@ -207,7 +207,7 @@ func Test_checkAuth(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := checkAuth(tt.args.ctx, tt.args.log, tt.args.secretKey, tt.args.method, tt.args.validateFunc)
+			got, err := checkAuth(tt.args.ctx, tt.args.log, tt.args.keys, nil, tt.args.method, tt.args.validateFunc)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("checkAuth() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -220,6 +220,41 @@ func Test_checkAuth(t *testing.T) {
 }
 
 // Helper function to create a context with metadata
+type fakeSessions struct {
+	active map[string]bool
+	err    error
+}
+
+func (f fakeSessions) IsActive(_ context.Context, sid string) (bool, error) {
+	return f.active[sid], f.err
+}
+
+func Test_checkAuth_Sessions(t *testing.T) {
+	validate := func(tokenString string, keys jwtrule.Keyring) (model.Jtoken, error) {
+		return model.Jtoken{Claims: model.Claims{UserID: 123, SessionID: tokenString}}, nil
+	}
+	method := "/proto.api.service.v1.DataKeeperService/GetFile"
+
+	tests := []struct {
+		name     string
+		token    string
+		sessions fakeSessions
+		wantCode codes.Code
+	}{
+		{name: "Active", token: "sid1", sessions: fakeSessions{active: map[string]bool{"sid1": true}}, wantCode: codes.OK},
+		{name: "Revoked", token: "sid2", sessions: fakeSessions{active: map[string]bool{"sid1": true}}, wantCode: codes.Unauthenticated},
+		{name: "NoSessionClaim", token: "", sessions: fakeSessions{}, wantCode: codes.Unauthenticated},
+		{name: "StoreError", token: "sid1", sessions: fakeSessions{err: assert.AnError}, wantCode: codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := checkAuth(contextWithToken("bearer "+tt.token), logrus.New(), nil, tt.sessions, method, validate)
+			assert.Equal(t, tt.wantCode, status.Code(err))
+		})
+	}
+}
+
 func contextWithToken(token string) *context.Context {
 	md := metadata.New(map[string]string{"authorization": token})
 	ctx := metadata.NewIncomingContext(context.Background(), md)
@@ -259,7 +294,7 @@ func Test_preProcess(t *testing.T) {
 			var logOutput bytes.Buffer
 			log.SetOutput(&logOutput)
 
-			preProcess(tt.args.ctx, tt.args.info, log, "")
+			preProcess(tt.args.ctx, tt.args.info, log)
 
 			// Check log output
 			assert.Contains(t, logOutput.String(), "--> interceptor: before executing:")
@@ -406,7 +441,7 @@ func TestStreamInterceptor(t *testing.T) {
 	}
 
 	// Create an instance of StreamInterceptor
-	interceptor := StreamInterceptor(logger, secretKey)
+	interceptor := StreamInterceptor(logger, jwtrule.NewStaticKeyring(secretKey), nil)
 
 	// Call the interceptor
 	err = interceptor(
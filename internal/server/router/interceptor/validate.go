@@ -0,0 +1,74 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+
+	"github.com/bufbuild/protovalidate-go"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// MessageValidator проверяет сообщение по правилам buf.validate из proto, реализуется *protovalidate.Validator
+type MessageValidator interface {
+	Validate(msg proto.Message) error
+}
+
+// ValidateUnaryInterceptor checks the request with the buf.validate rules before the handler
+func ValidateUnaryInterceptor(v MessageValidator) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		if err := validateMessage(v, req); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// ValidateStreamInterceptor checks every received message of the stream with the buf.validate rules
+func ValidateStreamInterceptor(v MessageValidator) grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		return handler(srv, &validatingServerStream{ServerStream: ss, v: v})
+	}
+}
+
+// validatingServerStream проверяет сообщения клиента при чтении
+type validatingServerStream struct {
+	grpc.ServerStream
+	v MessageValidator
+}
+
+// RecvMsg reads the message and checks it
+func (s *validatingServerStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return validateMessage(s.v, m)
+}
+
+func validateMessage(v MessageValidator, m interface{}) error {
+	msg, ok := m.(proto.Message)
+	if !ok {
+		return nil
+	}
+	err := v.Validate(msg)
+	if err == nil {
+		return nil
+	}
+	var verr *protovalidate.ValidationError
+	if errors.As(err, &verr) {
+		return status.Error(codes.InvalidArgument, verr.Error())
+	}
+	return status.Errorf(codes.Internal, "failed to validate request: %v", err)
+}
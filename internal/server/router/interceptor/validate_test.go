@@ -0,0 +1,107 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	pbservice "github.com/Arcadian-Sky/datakkeeper/gen/proto/api/service/v1"
+	"github.com/Arcadian-Sky/datakkeeper/internal/model"
+	"github.com/bufbuild/protovalidate-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestValidateUnaryInterceptor(t *testing.T) {
+	v, err := protovalidate.New()
+	require.NoError(t, err)
+	interceptor := ValidateUnaryInterceptor(v)
+
+	card := func() *pbservice.Data {
+		return &pbservice.Data{
+			Type:         pbservice.DataType_DATA_TYPE_TYPE_CREDIT_CARD,
+			Card:         "sealed number",
+			CardExpMonth: 12,
+			CardExpYear:  2030,
+			CardBrand:    model.CardBrandMir,
+		}
+	}
+
+	tests := []struct {
+		name string
+		req  interface{}
+		want codes.Code
+	}{
+		{name: "Card", req: &pbservice.SaveDataRequest{Data: card()}, want: codes.OK},
+		{name: "NotProto", req: "raw", want: codes.OK},
+		{name: "CardNumberRequired", req: &pbservice.SaveDataRequest{Data: func() *pbservice.Data { d := card(); d.Card = ""; return d }()}, want: codes.InvalidArgument},
+		{name: "CardExpiryRequired", req: &pbservice.SaveDataRequest{Data: func() *pbservice.Data { d := card(); d.CardExpYear = 0; return d }()}, want: codes.InvalidArgument},
+		{name: "ExpMonthRange", req: &pbservice.SaveDataRequest{Data: func() *pbservice.Data { d := card(); d.CardExpMonth = 13; return d }()}, want: codes.InvalidArgument},
+		{name: "ExpYearRange", req: &pbservice.SaveDataRequest{Data: func() *pbservice.Data { d := card(); d.CardExpYear = 30; return d }()}, want: codes.InvalidArgument},
+		{name: "UnknownBrand", req: &pbservice.SaveDataRequest{Data: func() *pbservice.Data { d := card(); d.CardBrand = "BITCOIN"; return d }()}, want: codes.InvalidArgument},
+		{name: "InvalidMeta", req: &pbservice.SaveDataRequest{Data: &pbservice.Data{Meta: map[string]string{"": "v"}}}, want: codes.InvalidArgument},
+		{name: "ExpectedRevision", req: &pbservice.UpdateDataRequest{Data: card(), ExpectedRevision: -1}, want: codes.InvalidArgument},
+		{name: "ListPageSize", req: &pbservice.ListFileRequest{PageSize: model.MaxPageSize + 1}, want: codes.InvalidArgument},
+		{name: "ListTags", req: &pbservice.ListDataRequest{Tags: make([]string, 33)}, want: codes.InvalidArgument},
+		{name: "SyncRevision", req: &pbservice.SyncRequest{SinceRevision: -1}, want: codes.InvalidArgument},
+		{name: "RestoreRevision", req: &pbservice.RestoreDataRevisionRequest{Dataid: 10}, want: codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				called = true
+				return "ok", nil
+			}
+			_, err := interceptor(context.Background(), tt.req, &grpc.UnaryServerInfo{}, handler)
+			assert.Equal(t, tt.want, status.Code(err))
+			assert.Equal(t, tt.want == codes.OK, called)
+		})
+	}
+}
+
+// fakeValidator возвращает заданную ошибку для любого сообщения
+type fakeValidator struct{ err error }
+
+func (f fakeValidator) Validate(proto.Message) error { return f.err }
+
+func TestValidateUnaryInterceptor_InternalError(t *testing.T) {
+	interceptor := ValidateUnaryInterceptor(fakeValidator{err: errors.New("compilation failed")})
+	_, err := interceptor(context.Background(), &pbservice.SyncRequest{}, &grpc.UnaryServerInfo{},
+		func(ctx context.Context, req interface{}) (interface{}, error) { return nil, nil })
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
+
+// recvStream отдает сообщения из очереди
+type recvStream struct {
+	grpc.ServerStream
+	msgs []proto.Message
+}
+
+func (s *recvStream) RecvMsg(m interface{}) error {
+	proto.Merge(m.(proto.Message), s.msgs[0])
+	s.msgs = s.msgs[1:]
+	return nil
+}
+
+func TestValidateStreamInterceptor(t *testing.T) {
+	v, err := protovalidate.New()
+	require.NoError(t, err)
+	interceptor := ValidateStreamInterceptor(v)
+
+	stream := &recvStream{msgs: []proto.Message{
+		&pbservice.UploadChunkRequest{UploadId: "u1"},
+		&pbservice.UploadChunkRequest{UploadId: ""},
+	}}
+	err = interceptor(nil, stream, &grpc.StreamServerInfo{}, func(srv interface{}, ss grpc.ServerStream) error {
+		var chunk pbservice.UploadChunkRequest
+		require.NoError(t, ss.RecvMsg(&chunk))
+		chunk.Reset()
+		return ss.RecvMsg(&chunk)
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
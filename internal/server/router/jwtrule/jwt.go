@@ -12,32 +12,68 @@ import (
 type ctxKey string
 
 var (
-	CtxKeyUserID ctxKey = "userID"
+	CtxKeyUserID    ctxKey = "userID"
+	CtxKeySessionID ctxKey = "sessionID"
+)
+
+const (
+	// TokenTTL - время жизни токена аутентификации
+	TokenTTL = time.Minute * 60
+	// RefreshTTL - время жизни сеанса без обновления
+	RefreshTTL = time.Hour * 24 * 30
 )
 
 // Generate generates new JWT token
 func Generate(userid int64, key string) (model.Jtoken, error) {
+	return GenerateWithKeyring(userid, NewStaticKeyring(key))
+}
+
+// GenerateWithKeyring generates new JWT token signed by the current key, kid is put to the header
+func GenerateWithKeyring(userid int64, keys Keyring) (model.Jtoken, error) {
+	return GenerateForSession(userid, "", keys)
+}
+
+// GenerateForSession generates new JWT token bound to the session
+func GenerateForSession(userid int64, sid string, keys Keyring) (model.Jtoken, error) {
+	key, err := keys.Signing()
+	if err != nil {
+		return model.Jtoken{}, err
+	}
 	now := time.Now()
-	claims := model.Claims{UserID: userid, Iat: now.Unix(),
-		Exp: now.Add(time.Minute * 60).Unix()}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+	claims := model.Claims{UserID: userid, SessionID: sid, Iat: now.Unix(),
+		Exp: now.Add(TokenTTL).Unix()}
+	mapClaims := jwt.MapClaims{
 		"id":  claims.UserID,
 		"iat": claims.Iat,
 		"exp": claims.Exp,
-	})
-	tokenString, err := token.SignedString([]byte(key))
+	}
+	if sid != "" {
+		mapClaims["sid"] = sid
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, mapClaims)
+	token.Header["kid"] = key.ID
+	tokenString, err := token.SignedString(key.Secret)
 	return model.Jtoken{Claims: claims, Token: tokenString}, err
 }
 
 // Validate checks JWT token and converts to structs.Jtoken
 func Validate(tokenString string, key string) (model.Jtoken, error) {
+	return ValidateWithKeyring(tokenString, NewStaticKeyring(key))
+}
+
+// ValidateWithKeyring checks JWT token with the key found by kid
+func ValidateWithKeyring(tokenString string, keys Keyring) (model.Jtoken, error) {
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
 		// Don't forget to validate the alg is what you expect:
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
-		return []byte(key), nil
+		kid, _ := token.Header["kid"].(string)
+		return keys.Verification(kid)
 	})
+	if token == nil {
+		return model.Jtoken{}, err
+	}
 
 	if claimsMap, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
 		claims := model.Claims{
@@ -45,6 +81,7 @@ func Validate(tokenString string, key string) (model.Jtoken, error) {
 			Iat:    int64(claimsMap["iat"].(float64)),
 			Exp:    int64(claimsMap["exp"].(float64)),
 		}
+		claims.SessionID, _ = claimsMap["sid"].(string)
 		jtoken := model.Jtoken{Token: tokenString, Claims: claims}
 
 		return jtoken, nil
@@ -59,6 +96,17 @@ func SetUserIDToCTX(ctx context.Context, value int) context.Context {
 	return context.WithValue(ctx, CtxKeyUserID, value)
 }
 
+// SetSessionIDToCTX add sessionID to the context.
+func SetSessionIDToCTX(ctx context.Context, sid string) context.Context {
+	return context.WithValue(ctx, CtxKeySessionID, sid)
+}
+
+// GetSessionIDFromCTX returns sessionID or empty string
+func GetSessionIDFromCTX(ctx context.Context) string {
+	sid, _ := ctx.Value(CtxKeySessionID).(string)
+	return sid
+}
+
 // Получаем значение из контекста
 func GetUserIDFromCTX(ctx context.Context) int64 {
 	if iUserID, ok := ctx.Value(CtxKeyUserID).(int); ok {
@@ -138,6 +138,41 @@ func generateTestToken(userID int64, key string) string {
 	return tokenString
 }
 
+// Helper function to generate a test JWT token with kid in the header
+func generateTestTokenWithKid(userID int64, kid, key string) string {
+	now := time.Now()
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"id":  userID,
+		"iat": now.Unix(),
+		"exp": now.Add(time.Minute * 60).Unix(),
+	})
+	token.Header["kid"] = kid
+	tokenString, _ := token.SignedString([]byte(key))
+	return tokenString
+}
+
+func TestGenerate_KidHeader(t *testing.T) {
+	keys := NewStaticKeyring("test-secret-key")
+	key, _ := keys.Signing()
+
+	got, err := Generate(12345, "test-secret-key")
+	assert.NoError(t, err)
+
+	token, _, err := new(jwt.Parser).ParseUnverified(got.Token, jwt.MapClaims{})
+	assert.NoError(t, err)
+	assert.Equal(t, key.ID, token.Header["kid"])
+}
+
+func TestValidate_UnknownKid(t *testing.T) {
+	_, err := Validate(generateTestTokenWithKid(12345, "other", "test-secret-key"), "test-secret-key")
+	assert.Error(t, err)
+}
+
+func TestValidate_Malformed(t *testing.T) {
+	_, err := Validate("invalid-token", "test-secret-key")
+	assert.Error(t, err)
+}
+
 // TestSetUserIDToCTX tests the SetUserIDToCTX function
 func TestSetUserIDToCTX(t *testing.T) {
 	ctx := context.Background()
@@ -168,3 +203,27 @@ func TestGetUserIDFromCTX(t *testing.T) {
 	got = GetUserIDFromCTX(ctxWithUserID)
 	assert.Equal(t, userID, got, "UserID should be retrieved correctly from context")
 }
+
+func TestGenerateForSession(t *testing.T) {
+	keys := NewStaticKeyring("test-secret-key")
+
+	got, err := GenerateForSession(12345, "sid1", keys)
+	assert.NoError(t, err)
+
+	parsed, err := ValidateWithKeyring(got.Token, keys)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(12345), parsed.Claims.UserID)
+	assert.Equal(t, "sid1", parsed.Claims.SessionID)
+
+	// токены без сессии по-прежнему валидны, sid остается пустым
+	got, err = Generate(12345, "test-secret-key")
+	assert.NoError(t, err)
+	parsed, err = ValidateWithKeyring(got.Token, keys)
+	assert.NoError(t, err)
+	assert.Empty(t, parsed.Claims.SessionID)
+}
+
+func TestSessionIDCTX(t *testing.T) {
+	assert.Empty(t, GetSessionIDFromCTX(context.Background()))
+	assert.Equal(t, "sid1", GetSessionIDFromCTX(SetSessionIDToCTX(context.Background(), "sid1")))
+}
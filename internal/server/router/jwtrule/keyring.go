@@ -0,0 +1,215 @@
+package jwtrule
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/Arcadian-Sky/datakkeeper/internal/model"
+	"github.com/sirupsen/logrus"
+)
+
+// Keyring отдает ключ для подписи новых токенов и ключи для проверки по kid
+type Keyring interface {
+	Signing() (model.SigningKey, error)
+	Verification(kid string) ([]byte, error)
+}
+
+// StaticKeyring - один постоянный ключ (из переменной окружения или файла)
+type StaticKeyring struct {
+	key model.SigningKey
+}
+
+// NewStaticKeyring creates keyring with a single key, kid is derived from the key itself
+func NewStaticKeyring(secret string) *StaticKeyring {
+	sum := sha256.Sum256([]byte(secret))
+	return &StaticKeyring{
+		key: model.SigningKey{
+			ID:     hex.EncodeToString(sum[:4]),
+			Secret: []byte(secret),
+		},
+	}
+}
+
+func (k *StaticKeyring) Signing() (model.SigningKey, error) {
+	return k.key, nil
+}
+
+// Verification accepts tokens without kid, they were issued before kid was introduced.
+// Такие токены проверяются только единственным постоянным ключом, RotatingKeyring их отвергает.
+func (k *StaticKeyring) Verification(kid string) ([]byte, error) {
+	if kid != "" && kid != k.key.ID {
+		return nil, model.ErrUnknownKeyID
+	}
+	return k.key.Secret, nil
+}
+
+// KeyStore - хранилище ключей подписи (таблица в postgres).
+// GetKeys возвращает ключи от новых к старым.
+type KeyStore interface {
+	GetKeys(ctx context.Context, since time.Time) ([]model.SigningKey, error)
+	Rotate(ctx context.Context, key *model.SigningKey) error
+}
+
+// RotatingKeyring держит в памяти ключи из KeyStore и периодически их меняет.
+// Выведенный из оборота ключ остается валидным для проверки еще overlap,
+// чтобы выданные им токены доживали до своего exp.
+type RotatingKeyring struct {
+	store    KeyStore
+	rotation time.Duration
+	overlap  time.Duration
+	log      *logrus.Logger
+
+	mu   sync.RWMutex
+	keys []model.SigningKey
+	// missAt - время последней перезагрузки из-за незнакомого kid
+	missAt time.Time
+}
+
+// missReloadInterval ограничивает перезагрузки ключей по незнакомому kid:
+// kid берется из заголовка до проверки подписи, и без ограничения любой запрос стоил бы обращения к базе
+const missReloadInterval = 10 * time.Second
+
+func NewRotatingKeyring(store KeyStore, rotation, overlap time.Duration, lg *logrus.Logger) *RotatingKeyring {
+	return &RotatingKeyring{
+		store:    store,
+		rotation: rotation,
+		overlap:  overlap,
+		log:      lg,
+	}
+}
+
+// Load reloads keys from the store and rotates the active key when it is older than rotation period
+func (k *RotatingKeyring) Load(ctx context.Context) error {
+	if err := k.reload(ctx); err != nil {
+		return err
+	}
+
+	active, err := k.Signing()
+	if err == nil && time.Since(active.CreatedAt) < k.rotation {
+		return nil
+	}
+
+	return k.Rotate(ctx)
+}
+
+// Rotate creates a new signing key, previous ones are retired
+func (k *RotatingKeyring) Rotate(ctx context.Context) error {
+	key, err := newSigningKey()
+	if err != nil {
+		return err
+	}
+	if err := k.store.Rotate(ctx, &key); err != nil {
+		return err
+	}
+	k.log.Info("jwt signing key rotated, kid: ", key.ID)
+
+	return k.reload(ctx)
+}
+
+// Run checks the keys every interval until ctx is done
+func (k *RotatingKeyring) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := k.Load(ctx); err != nil {
+				k.log.WithError(err).Error("failed to refresh jwt signing keys")
+			}
+		}
+	}
+}
+
+func (k *RotatingKeyring) Signing() (model.SigningKey, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	for _, key := range k.keys {
+		if key.RetiredAt.IsZero() {
+			return key, nil
+		}
+	}
+	return model.SigningKey{}, model.ErrNoSigningKey
+}
+
+// Verification returns the key by kid, tokens without kid are rejected
+func (k *RotatingKeyring) Verification(kid string) ([]byte, error) {
+	if kid == "" {
+		return nil, model.ErrUnknownKeyID
+	}
+	if secret, ok := k.lookup(kid); ok {
+		return secret, nil
+	}
+
+	// ключ мог выпустить другой экземпляр сервера, но перечитываем не чаще missReloadInterval
+	if !k.allowMissReload() {
+		return nil, model.ErrUnknownKeyID
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := k.reload(ctx); err != nil {
+		return nil, err
+	}
+	if secret, ok := k.lookup(kid); ok {
+		return secret, nil
+	}
+
+	return nil, model.ErrUnknownKeyID
+}
+
+func (k *RotatingKeyring) allowMissReload() bool {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if time.Since(k.missAt) < missReloadInterval {
+		return false
+	}
+	k.missAt = time.Now()
+	return true
+}
+
+func (k *RotatingKeyring) lookup(kid string) ([]byte, bool) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	for _, key := range k.keys {
+		if key.ID != kid {
+			continue
+		}
+		if !key.RetiredAt.IsZero() && time.Since(key.RetiredAt) > k.overlap {
+			return nil, false
+		}
+		return key.Secret, true
+	}
+	return nil, false
+}
+
+func (k *RotatingKeyring) reload(ctx context.Context) error {
+	keys, err := k.store.GetKeys(ctx, time.Now().Add(-k.overlap))
+	if err != nil {
+		return err
+	}
+	k.mu.Lock()
+	k.keys = keys
+	k.mu.Unlock()
+	return nil
+}
+
+func newSigningKey() (model.SigningKey, error) {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return model.SigningKey{}, err
+	}
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return model.SigningKey{}, err
+	}
+	return model.SigningKey{
+		ID:        hex.EncodeToString(id),
+		Secret:    secret,
+		CreatedAt: time.Now(),
+	}, nil
+}
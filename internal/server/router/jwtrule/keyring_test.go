@@ -0,0 +1,157 @@
+package jwtrule
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Arcadian-Sky/datakkeeper/internal/model"
+	"github.com/Arcadian-Sky/datakkeeper/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaticKeyring(t *testing.T) {
+	keys := NewStaticKeyring("test-secret-key")
+
+	key, err := keys.Signing()
+	require.NoError(t, err)
+	assert.NotEmpty(t, key.ID)
+	assert.Equal(t, []byte("test-secret-key"), key.Secret)
+
+	// тот же ключ дает тот же kid на любом экземпляре сервера
+	assert.Equal(t, key.ID, NewStaticKeyring("test-secret-key").key.ID)
+
+	secret, err := keys.Verification(key.ID)
+	require.NoError(t, err)
+	assert.Equal(t, key.Secret, secret)
+
+	// токены без kid
+	secret, err = keys.Verification("")
+	require.NoError(t, err)
+	assert.Equal(t, key.Secret, secret)
+
+	_, err = keys.Verification("unknown")
+	assert.ErrorIs(t, err, model.ErrUnknownKeyID)
+}
+
+func TestRotatingKeyring_LoadRotatesWhenEmpty(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mocks.NewMockKeyRepository(ctrl)
+	keys := NewRotatingKeyring(store, time.Hour, time.Hour, logrus.New())
+
+	var stored model.SigningKey
+	gomock.InOrder(
+		store.EXPECT().GetKeys(gomock.Any(), gomock.Any()).Return(nil, nil),
+		store.EXPECT().Rotate(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, key *model.SigningKey) error {
+			stored = *key
+			return nil
+		}),
+		store.EXPECT().GetKeys(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, _ time.Time) ([]model.SigningKey, error) {
+			return []model.SigningKey{stored}, nil
+		}),
+	)
+
+	require.NoError(t, keys.Load(context.Background()))
+
+	key, err := keys.Signing()
+	require.NoError(t, err)
+	assert.Equal(t, stored.ID, key.ID)
+	assert.Len(t, key.Secret, 32)
+}
+
+func TestRotatingKeyring_LoadKeepsFreshKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mocks.NewMockKeyRepository(ctrl)
+	keys := NewRotatingKeyring(store, time.Hour, time.Hour, logrus.New())
+
+	fresh := model.SigningKey{ID: "fresh", Secret: []byte("secret"), CreatedAt: time.Now()}
+	store.EXPECT().GetKeys(gomock.Any(), gomock.Any()).Return([]model.SigningKey{fresh}, nil).Times(1)
+
+	require.NoError(t, keys.Load(context.Background()))
+
+	key, err := keys.Signing()
+	require.NoError(t, err)
+	assert.Equal(t, "fresh", key.ID)
+}
+
+func TestRotatingKeyring_Overlap(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mocks.NewMockKeyRepository(ctrl)
+	keys := NewRotatingKeyring(store, time.Hour, 10*time.Minute, logrus.New())
+
+	now := time.Now()
+	store.EXPECT().GetKeys(gomock.Any(), gomock.Any()).Return([]model.SigningKey{
+		{ID: "new", Secret: []byte("new-secret"), CreatedAt: now},
+		{ID: "old", Secret: []byte("old-secret"), CreatedAt: now.Add(-time.Hour), RetiredAt: now.Add(-time.Minute)},
+		{ID: "expired", Secret: []byte("expired-secret"), CreatedAt: now.Add(-2 * time.Hour), RetiredAt: now.Add(-time.Hour)},
+	}, nil).AnyTimes()
+
+	require.NoError(t, keys.Load(context.Background()))
+
+	// токен старым ключом в пределах окна
+	oldToken := generateTestTokenWithKid(12345, "old", "old-secret")
+	got, err := ValidateWithKeyring(oldToken, keys)
+	require.NoError(t, err)
+	assert.Equal(t, int64(12345), got.Claims.UserID)
+
+	// окно для этого ключа закончилось
+	expiredToken := generateTestTokenWithKid(12345, "expired", "expired-secret")
+	_, err = ValidateWithKeyring(expiredToken, keys)
+	assert.Error(t, err)
+
+	// новые токены подписываются активным ключом
+	jtoken, err := GenerateWithKeyring(12345, keys)
+	require.NoError(t, err)
+	_, err = ValidateWithKeyring(jtoken.Token, NewStaticKeyring("new-secret"))
+	assert.Error(t, err, "kid of the active key must be in the header")
+	_, err = ValidateWithKeyring(jtoken.Token, keys)
+	assert.NoError(t, err)
+}
+
+func TestRotatingKeyring_NoKeys(t *testing.T) {
+	keys := NewRotatingKeyring(nil, time.Hour, time.Hour, logrus.New())
+
+	_, err := keys.Signing()
+	assert.ErrorIs(t, err, model.ErrNoSigningKey)
+
+	_, err = GenerateWithKeyring(1, keys)
+	assert.ErrorIs(t, err, model.ErrNoSigningKey)
+}
+
+func TestRotatingKeyring_UnknownKid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mocks.NewMockKeyRepository(ctrl)
+	keys := NewRotatingKeyring(store, time.Hour, time.Hour, logrus.New())
+	keys.keys = []model.SigningKey{{ID: "active", Secret: []byte("secret"), CreatedAt: time.Now()}}
+
+	// без kid база не читается
+	_, err := keys.Verification("")
+	assert.ErrorIs(t, err, model.ErrUnknownKeyID)
+
+	// ключ другого экземпляра подгружается, но перезагрузок не больше одной за missReloadInterval
+	store.EXPECT().GetKeys(gomock.Any(), gomock.Any()).Return([]model.SigningKey{
+		{ID: "active", Secret: []byte("secret"), CreatedAt: time.Now()},
+		{ID: "other", Secret: []byte("other-secret"), CreatedAt: time.Now()},
+	}, nil).Times(1)
+	secret, err := keys.Verification("other")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("other-secret"), secret)
+	for i := 0; i < 3; i++ {
+		_, err = keys.Verification("forged")
+		assert.ErrorIs(t, err, model.ErrUnknownKeyID)
+	}
+	secret, err = keys.Verification("active")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("secret"), secret)
+}
@@ -2,10 +2,13 @@ package router
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net"
-	"os"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	pbservice "github.com/Arcadian-Sky/datakkeeper/gen/proto/api/service/v1"
@@ -14,12 +17,15 @@ import (
 
 	"github.com/Arcadian-Sky/datakkeeper/internal/model"
 	"github.com/Arcadian-Sky/datakkeeper/internal/server/repository"
+	"github.com/Arcadian-Sky/datakkeeper/internal/server/router/changefeed"
 	"github.com/Arcadian-Sky/datakkeeper/internal/server/router/interceptor"
 	"github.com/Arcadian-Sky/datakkeeper/internal/server/router/jwtrule"
 	"github.com/Arcadian-Sky/datakkeeper/internal/settings"
+	"github.com/bufbuild/protovalidate-go"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
 )
 
 type GRPCServer struct {
@@ -28,6 +34,12 @@ type GRPCServer struct {
 	reposervice repository.FileRepository
 	repouser    repository.UserRepository
 	repodata    repository.DataRepository
+	reposession repository.SessionRepository
+	repometa    repository.ItemMetaRepository
+	reposync    repository.SyncRepository
+	repoupload  repository.UploadRepository
+	keys        jwtrule.Keyring
+	changes     *changefeed.Hub
 	serv        *grpc.Server
 	// tokenKey
 	pbservice.UnimplementedDataKeeperServiceServer
@@ -35,11 +47,26 @@ type GRPCServer struct {
 }
 
 // InitGRPCServer initializes a new gRPC server.
-func InitGRPCServer(cf *settings.InitedFlags, lg *logrus.Logger, rs repository.FileRepository, ru repository.UserRepository, rd repository.DataRepository) (*GRPCServer, error) {
+// If keys is nil, tokens are signed with cf.SecretKey.
+func InitGRPCServer(cf *settings.InitedFlags, lg *logrus.Logger, keys jwtrule.Keyring, rs repository.FileRepository, ru repository.UserRepository, rd repository.DataRepository, rss repository.SessionRepository, rm repository.ItemMetaRepository, rsy repository.SyncRepository, rup repository.UploadRepository) (*GRPCServer, error) {
+	if keys == nil {
+		keys = jwtrule.NewStaticKeyring(cf.SecretKey)
+	}
+	// запросы проверяются по правилам buf.validate из proto после авторизации
+	validator, err := protovalidate.New()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request validator: %w", err)
+	}
 	// creates a gRPC server
 	s := grpc.NewServer(
-		grpc.UnaryInterceptor(interceptor.UnaryInterceptor(lg, cf.SecretKey)),
-		grpc.StreamInterceptor(interceptor.StreamInterceptor(lg, cf.SecretKey)),
+		grpc.ChainUnaryInterceptor(
+			interceptor.UnaryInterceptor(lg, keys, rss),
+			interceptor.ValidateUnaryInterceptor(validator),
+		),
+		grpc.ChainStreamInterceptor(
+			interceptor.StreamInterceptor(lg, keys, rss),
+			interceptor.ValidateStreamInterceptor(validator),
+		),
 	)
 
 	ob := &GRPCServer{
@@ -48,6 +75,12 @@ func InitGRPCServer(cf *settings.InitedFlags, lg *logrus.Logger, rs repository.F
 		repodata:    rd,
 		reposervice: rs,
 		repouser:    ru,
+		reposession: rss,
+		repometa:    rm,
+		reposync:    rsy,
+		repoupload:  rup,
+		keys:        keys,
+		changes:     changefeed.NewHub(),
 		serv:        s,
 	}
 	// register the service
@@ -87,7 +120,7 @@ func (s *GRPCServer) Register(ctx context.Context, in *pbuser.RegisterRequest) (
 	s.log.Info(str)
 
 	// generate JWT
-	userJWT, err := jwtrule.Generate(user.ID, s.cfg.SecretKey)
+	session, userJWT, refresh, err := s.openSession(ctx, user.ID, in.DeviceInfo)
 	if err != nil {
 		e := fmt.Sprintf("cant generate token: %s", err.Error())
 		s.log.Info(e)
@@ -99,7 +132,13 @@ func (s *GRPCServer) Register(ctx context.Context, in *pbuser.RegisterRequest) (
 		bSuccess = true
 	}
 
-	return &pbuser.RegisterResponse{Success: bSuccess, Message: r, AuthToken: userJWT.Token}, nil
+	return &pbuser.RegisterResponse{
+		Success:      bSuccess,
+		Message:      r,
+		AuthToken:    userJWT.Token,
+		RefreshToken: refresh,
+		SessionId:    session.ID,
+	}, nil
 }
 
 // Аутентификация пользователя.
@@ -120,7 +159,7 @@ func (s *GRPCServer) Authenticate(ctx context.Context, in *pbuser.AuthenticateRe
 	mess += fmt.Sprintf("authorized as userID: %v ", user.ID)
 
 	// generate JWT
-	userJWT, err := jwtrule.Generate(user.ID, s.cfg.SecretKey)
+	session, userJWT, refresh, err := s.openSession(ctx, user.ID, in.DeviceInfo)
 	if err != nil {
 		e := fmt.Sprintf("cant generate token: %s", err.Error())
 		s.log.Info(e)
@@ -137,9 +176,11 @@ func (s *GRPCServer) Authenticate(ctx context.Context, in *pbuser.AuthenticateRe
 	s.log.Trace(mess)
 
 	return &pbuser.AuthenticateResponse{
-		Success:   bSuccess,
-		AuthToken: userJWT.Token,
-		Message:   mess,
+		Success:      bSuccess,
+		AuthToken:    userJWT.Token,
+		Message:      mess,
+		RefreshToken: refresh,
+		SessionId:    session.ID,
 	}, nil
 }
 
@@ -157,11 +198,15 @@ func (s *GRPCServer) Start() error {
 }
 
 // ShutDown graceful stops the server.
+// Потоки WatchChanges не завершаются сами, поэтому подписки закрываются до GracefulStop.
 func (s *GRPCServer) ShutDown() error {
+	s.changes.Close()
 	s.serv.GracefulStop()
 	return nil
 }
 
+// UploadFile stores the file from the stream, chunks go to the storage through a pipe without a copy on disk.
+// Имя файла берется из первого чанка.
 func (s *GRPCServer) UploadFile(stream pbservice.DataKeeperService_UploadFileServer) error {
 	// Obtain context from the stream
 	ctx := stream.Context()
@@ -172,53 +217,54 @@ func (s *GRPCServer) UploadFile(stream pbservice.DataKeeperService_UploadFileSer
 		ID: uID,
 	}
 
-	// Create a temporary file to store uploaded data
-	tmpFile, err := os.CreateTemp("", "uploaded-*.tmp")
-	if err != nil {
-		return fmt.Errorf("failed to create temp file: %w", err)
+	first, err := stream.Recv()
+	if err == io.EOF {
+		return status.Error(codes.InvalidArgument, "no chunks received")
 	}
-	defer os.Remove(tmpFile.Name())
-
-	objectName := ""
-	// Read chunks from the stream and write to the temp file
-	for {
-		chunk, err := stream.Recv()
-		if err == io.EOF {
-			break
-		}
-		if err != nil {
-			return fmt.Errorf("failed to receive chunk: %w", err)
-		}
-
-		if _, err := tmpFile.Write(chunk.Data); err != nil {
-			return fmt.Errorf("failed to write chunk to temp file: %w", err)
-		}
-
-		objectName = chunk.Filename
+	if err != nil {
+		return fmt.Errorf("failed to receive chunk: %w", err)
 	}
-
-	// Close the temp file
-	if err := tmpFile.Close(); err != nil {
-		return fmt.Errorf("failed to close temp file: %w", err)
+	objectName := first.Filename
+	if err := model.ValidateFileName(objectName); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
 	}
-
-	// Upload the file to MinIO
-	file, err := os.Open(tmpFile.Name())
+	free, err := s.checkFileQuota(ctx, uID, objectName, -1)
 	if err != nil {
-		return fmt.Errorf("failed to open temp file: %w", err)
+		return err
 	}
-	defer file.Close()
 
-	err = s.reposervice.UploadFile(ctx, user, objectName, file)
-	if err != nil {
-		return fmt.Errorf("failed to upload file to MinIO: %w", err)
-	}
+	pr, pw := io.Pipe()
+	// Read chunks from the stream while the storage reads the pipe
+	go func() {
+		data := first.Data
+		for {
+			if _, err := pw.Write(data); err != nil {
+				// загрузка в хранилище прервана, pr закрыт с ее ошибкой
+				return
+			}
+			chunk, err := stream.Recv()
+			if err == io.EOF {
+				pw.Close()
+				return
+			}
+			if err != nil {
+				pw.CloseWithError(fmt.Errorf("failed to receive chunk: %w", err))
+				return
+			}
+			data = chunk.Data
+		}
+	}()
 
-	//Update User
-	user.LastUpdate = time.Now()
-	_, err = s.repouser.SetLastUpdate(ctx, user)
+	// размер заранее неизвестен, превышение квоты прерывает загрузку посреди потока
+	var content io.Reader = pr
+	if free >= 0 {
+		content = &quotaReader{r: pr, left: free}
+	}
+	err = s.storeFile(ctx, user, &model.FileItem{Name: objectName, Size: -1}, content)
+	// останавливает чтение потока, если хранилище вернуло ошибку раньше его конца
+	pr.CloseWithError(err)
 	if err != nil {
-		return fmt.Errorf("failed to SetLastUpdate: %v", err)
+		return quotaError(err)
 	}
 
 	// Send response to client
@@ -236,21 +282,49 @@ func (s *GRPCServer) GetFileList(ctx context.Context, in *pbservice.ListFileRequ
 		ID: uID,
 	}
 
-	var data []model.FileItem
-	data, err := s.reposervice.GetFileList(ctx, user)
+	if err := validateListFile(in); err != nil {
+		return nil, err
+	}
+	query, err := fileListQuery(in)
+	if err != nil {
+		return nil, err
+	}
+
+	// метаданные нужны до листинга, фильтр по тегам применяется при чтении бакета
+	metas, err := s.repometa.List(ctx, uID, model.MetaKindFile)
+	if err != nil {
+		s.log.Println(err)
+		return nil, status.Error(codes.Internal, "failed to get files metadata")
+	}
+	// хранилище с полнотекстовым индексом дополняет поиск по имени поиском по метаданным
+	if searcher, ok := s.repometa.(repository.ItemMetaSearcher); ok && query.Search != "" {
+		names, err := searcher.Search(ctx, uID, model.MetaKindFile, query.Search)
+		if err != nil {
+			s.log.Println(err)
+			return nil, status.Error(codes.Internal, "failed to search files metadata")
+		}
+		query.Matched = make(map[string]bool, len(names))
+		for _, name := range names {
+			query.Matched[name] = true
+		}
+	}
+	data, next, err := s.reposervice.GetFileList(ctx, user, query, metas)
 	if err != nil {
 		s.log.Println(err)
 		return nil, status.Error(codes.Internal, "failed to get user files")
 	}
+	revs, err := s.reposync.FileRevisions(ctx, uID)
+	if err != nil {
+		s.log.Println(err)
+		return nil, status.Error(codes.Internal, "failed to get files revisions")
+	}
 	var resp []*pbservice.FileItem
 	for _, it := range data {
-		resp = append(resp, &pbservice.FileItem{
-			Key:  it.Hash,
-			Name: it.Name,
-		})
+		it.Revision = revs[it.Name]
+		resp = append(resp, fileToProto(it))
 	}
 
-	return &pbservice.ListFileResponse{Fileitem: resp}, nil
+	return &pbservice.ListFileResponse{Fileitem: resp, NextPageToken: model.EncodePageToken(next)}, nil
 }
 
 func (s *GRPCServer) SaveData(ctx context.Context, in *pbservice.SaveDataRequest) (*pbservice.UploadStatus, error) {
@@ -261,22 +335,31 @@ func (s *GRPCServer) SaveData(ctx context.Context, in *pbservice.SaveDataRequest
 	}
 	s.log.Trace("uID: ", uID)
 
-	data := model.Data{
-		Type:     getType(in.Data.Type),
-		UserID:   uID,
-		Title:    in.Data.Title,
-		Card:     in.Data.Card,
-		Login:    in.Data.Login,
-		Password: in.Data.Password,
+	if err := validateData(in.Data); err != nil {
+		return nil, err
 	}
+	data := dataFromProto(in.Data)
+	data.UserID = uID
 
-	fmt.Printf("data: %v\n", data)
+	if err := s.checkKeyHash(ctx, uID, data.KeyHash); err != nil {
+		return nil, err
+	}
+	if err := s.checkRecordQuota(ctx, uID); err != nil {
+		return nil, err
+	}
 
-	_, err := s.repodata.Save(ctx, &data)
+	id, err := s.repodata.Save(ctx, &data)
 	if err != nil {
 		s.log.Println(err)
 		return nil, status.Error(codes.Internal, "failed to get user files")
 	}
+	if len(data.Meta) != 0 || len(data.Tags) != 0 {
+		meta := &model.ItemMeta{UserID: uID, Kind: model.MetaKindData, ItemID: strconv.FormatInt(id, 10), Fields: data.Meta, Tags: data.Tags}
+		if err := s.setMeta(ctx, meta); err != nil {
+			return nil, err
+		}
+	}
+	s.changes.Publish(uID, model.ChangeEvent{Op: model.ChangeCreated, DataID: id})
 
 	//Update User
 	user.LastUpdate = time.Now()
@@ -296,28 +379,381 @@ func (s *GRPCServer) GetDataList(ctx context.Context, in *pbservice.ListDataRequ
 	user := &model.User{
 		ID: uID,
 	}
-	data, err := s.repodata.GetList(ctx, user)
+	query, err := dataListQuery(in)
+	if err != nil {
+		return nil, err
+	}
+
+	data, next, err := s.repodata.GetList(ctx, user, query)
 	if err != nil {
 		e := fmt.Sprintf("failed to list pdata: %s", err.Error())
 		return nil, status.Error(codes.Aborted, e)
 	}
 
+	metas, err := s.repometa.List(ctx, uID, model.MetaKindData)
+	if err != nil {
+		e := fmt.Sprintf("failed to list pdata metadata: %s", err.Error())
+		return nil, status.Error(codes.Internal, e)
+	}
+
 	var pdataPointers []*pbservice.Data
 	for _, item := range data {
-		pdataPointers = append(pdataPointers,
-			&pbservice.Data{
-				Id:       item.ID,
-				Title:    item.Title,
-				Type:     getPType(item.Type),
-				Card:     item.Card,
-				Login:    item.Login,
-				Password: item.Password,
-			})
+		meta := metas[strconv.FormatInt(item.ID, 10)]
+		item.Meta, item.Tags = meta.Fields, meta.Tags
+		pdataPointers = append(pdataPointers, dataToProto(item))
+	}
+
+	return &pbservice.ListDataResponse{Data: pdataPointers, NextPageToken: model.EncodePageToken(next)}, nil
+
+}
+
+func (s *GRPCServer) GetData(ctx context.Context, in *pbservice.GetDataRequest) (*pbservice.GetDataResponse, error) {
+	uID := jwtrule.GetUserIDFromCTX(ctx)
+	s.log.Trace("uID: ", uID)
+
+	item, err := s.repodata.Get(ctx, &model.Data{ID: in.Dataid, UserID: uID})
+	if err != nil {
+		if errors.Is(err, model.ErrPdataNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		e := fmt.Sprintf("failed to get pdata: %v", err)
+		s.log.Info(e)
+		return nil, status.Error(codes.Internal, e)
 	}
 
-	return &pbservice.ListDataResponse{Data: pdataPointers}, nil
+	meta, err := s.repometa.Get(ctx, &model.ItemMeta{UserID: uID, Kind: model.MetaKindData, ItemID: strconv.FormatInt(item.ID, 10)})
+	if err != nil {
+		e := fmt.Sprintf("failed to get pdata metadata: %v", err)
+		s.log.Info(e)
+		return nil, status.Error(codes.Internal, e)
+	}
+	item.Meta, item.Tags = meta.Fields, meta.Tags
 
+	return &pbservice.GetDataResponse{Data: dataToProto(*item)}, nil
 }
+
+func (s *GRPCServer) UpdateData(ctx context.Context, in *pbservice.UpdateDataRequest) (*pbservice.UploadStatus, error) {
+	uID := jwtrule.GetUserIDFromCTX(ctx)
+	s.log.Trace("uID: ", uID)
+	user := &model.User{
+		ID: uID,
+	}
+
+	if err := validateData(in.Data); err != nil {
+		return nil, err
+	}
+	data := dataFromProto(in.Data)
+	data.UserID = uID
+	data.Revision = in.ExpectedRevision
+
+	if err := s.checkKeyHash(ctx, uID, data.KeyHash); err != nil {
+		return nil, err
+	}
+
+	err := s.repodata.Update(ctx, &data)
+	if err != nil {
+		if errors.Is(err, model.ErrPdataNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		if errors.Is(err, model.ErrVersionConflict) {
+			return nil, s.dataConflict(ctx, uID, data.ID)
+		}
+		e := fmt.Sprintf("failed to update pdata: %v", err)
+		s.log.Info(e)
+		return nil, status.Error(codes.Internal, e)
+	}
+	meta := &model.ItemMeta{UserID: uID, Kind: model.MetaKindData, ItemID: strconv.FormatInt(data.ID, 10), Fields: data.Meta, Tags: data.Tags}
+	if err := s.setMeta(ctx, meta); err != nil {
+		return nil, err
+	}
+	s.changes.Publish(uID, model.ChangeEvent{Op: model.ChangeUpdated, DataID: data.ID})
+
+	//Update User
+	user.LastUpdate = time.Now()
+	_, err = s.repouser.SetLastUpdate(ctx, user)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to SetLastUpdate: "+err.Error())
+	}
+
+	return &pbservice.UploadStatus{Success: true, Message: "data was updated"}, nil
+}
+
+// checkKeyHash - сервер хранит только шифротекст, запись принимается,
+// если она зашифрована тем же мастер-ключом, что и остальные данные пользователя
+func (s *GRPCServer) checkKeyHash(ctx context.Context, uID int64, keyHash string) error {
+	if keyHash == "" {
+		return status.Error(codes.InvalidArgument, "key hash is not set")
+	}
+	err := s.repouser.BindKeyHash(ctx, &model.User{ID: uID, KeyHash: keyHash})
+	if err != nil {
+		if errors.Is(err, model.ErrKeyHashMismatch) {
+			return status.Error(codes.FailedPrecondition, err.Error())
+		}
+		e := fmt.Sprintf("failed to check key hash: %v", err)
+		s.log.Info(e)
+		return status.Error(codes.Internal, e)
+	}
+	return nil
+}
+
+// setMeta replaces user metadata of the record or file
+func (s *GRPCServer) setMeta(ctx context.Context, meta *model.ItemMeta) error {
+	err := s.repometa.Set(ctx, meta)
+	if err != nil {
+		e := fmt.Sprintf("failed to save metadata: %v", err)
+		s.log.Info(e)
+		return status.Error(codes.Internal, e)
+	}
+	return nil
+}
+
+// dataConflict returns ABORTED with the current server copy of the record in details,
+// so the client can offer the user to choose between versions
+func (s *GRPCServer) dataConflict(ctx context.Context, uID, id int64) error {
+	item, err := s.repodata.Get(ctx, &model.Data{ID: id, UserID: uID})
+	if err != nil {
+		if errors.Is(err, model.ErrPdataNotFound) {
+			return status.Error(codes.NotFound, err.Error())
+		}
+		e := fmt.Sprintf("failed to get pdata: %v", err)
+		s.log.Info(e)
+		return status.Error(codes.Internal, e)
+	}
+	meta, err := s.repometa.Get(ctx, &model.ItemMeta{UserID: uID, Kind: model.MetaKindData, ItemID: strconv.FormatInt(id, 10)})
+	if err != nil {
+		e := fmt.Sprintf("failed to get pdata metadata: %v", err)
+		s.log.Info(e)
+		return status.Error(codes.Internal, e)
+	}
+	item.Meta, item.Tags = meta.Fields, meta.Tags
+	return conflictStatus(dataToProto(*item))
+}
+
+// checkFileRevision compares the expected file version with the current one, nil skips the check.
+// 0 - тоже версия: ее имеют файлы, загруженные до учета версий.
+// Проверка идет до изменения и не атомарна с ним: MinIO и БД не меняются в одной транзакции.
+func (s *GRPCServer) checkFileRevision(ctx context.Context, uID int64, name string, expected *int64) error {
+	if expected == nil {
+		return nil
+	}
+	rev, err := s.reposync.FileRevision(ctx, uID, name)
+	if err != nil {
+		e := fmt.Sprintf("failed to get file revision: %v", err)
+		s.log.Info(e)
+		return status.Error(codes.Internal, e)
+	}
+	if rev == *expected {
+		return nil
+	}
+	meta, err := s.repometa.Get(ctx, &model.ItemMeta{UserID: uID, Kind: model.MetaKindFile, ItemID: name})
+	if err != nil {
+		e := fmt.Sprintf("failed to get file metadata: %v", err)
+		s.log.Info(e)
+		return status.Error(codes.Internal, e)
+	}
+	return conflictStatus(fileToProto(model.FileItem{Name: name, Desc: meta.Description, Meta: meta.Fields, Tags: meta.Tags, Revision: rev}))
+}
+
+func conflictStatus(current protoadapt.MessageV1) error {
+	st, err := status.New(codes.Aborted, model.ErrVersionConflict.Error()).WithDetails(current)
+	if err != nil {
+		return status.Error(codes.Aborted, model.ErrVersionConflict.Error())
+	}
+	return st.Err()
+}
+
+// UpdateFileMeta replaces key/value metadata and tags of the file
+func (s *GRPCServer) UpdateFileMeta(ctx context.Context, in *pbservice.UpdateFileMetaRequest) (*pbservice.UploadStatus, error) {
+	uID := jwtrule.GetUserIDFromCTX(ctx)
+	s.log.Trace("uID: ", uID)
+
+	if err := model.ValidateFileName(in.Filename); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	tags := model.NormalizeTags(in.Tags)
+	if err := model.ValidateMeta(in.Meta, tags); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := model.ValidateDescription(in.Description); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := s.checkFileRevision(ctx, uID, in.Filename, in.ExpectedRevision); err != nil {
+		return nil, err
+	}
+	meta := &model.ItemMeta{UserID: uID, Kind: model.MetaKindFile, ItemID: in.Filename, Fields: in.Meta, Tags: tags, Description: in.Description}
+	if err := s.setMeta(ctx, meta); err != nil {
+		return nil, err
+	}
+	if _, err := s.reposync.TouchFile(ctx, uID, in.Filename); err != nil {
+		e := fmt.Sprintf("failed to set file revision: %v", err)
+		s.log.Info(e)
+		return nil, status.Error(codes.Internal, e)
+	}
+	s.changes.Publish(uID, model.ChangeEvent{Op: model.ChangeUpdated, FileName: in.Filename})
+
+	return &pbservice.UploadStatus{Success: true, Message: "file metadata was updated"}, nil
+}
+
+// ListFileVersions returns stored versions of the file, newest first
+func (s *GRPCServer) ListFileVersions(ctx context.Context, in *pbservice.ListFileVersionsRequest) (*pbservice.ListFileVersionsResponse, error) {
+	uID := jwtrule.GetUserIDFromCTX(ctx)
+	s.log.Trace("uID: ", uID)
+
+	if err := model.ValidateFileName(in.Filename); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	versions, err := s.reposervice.ListFileVersions(ctx, in.Filename, &model.User{ID: uID})
+	if err != nil {
+		e := fmt.Sprintf("failed to get file versions: %v", err)
+		s.log.Info(e)
+		return nil, status.Error(codes.Internal, e)
+	}
+
+	res := &pbservice.ListFileVersionsResponse{Versions: make([]*pbservice.FileVersion, 0, len(versions))}
+	for _, v := range versions {
+		res.Versions = append(res.Versions, &pbservice.FileVersion{
+			VersionId:  v.VersionID,
+			Key:        v.Hash,
+			Size:       v.Size,
+			ModifiedAt: v.ModifiedAt.Unix(),
+			Latest:     v.Latest,
+		})
+	}
+	return res, nil
+}
+
+// RestoreFileVersion makes the stored version the current content of the file
+func (s *GRPCServer) RestoreFileVersion(ctx context.Context, in *pbservice.RestoreFileVersionRequest) (*pbservice.UploadStatus, error) {
+	uID := jwtrule.GetUserIDFromCTX(ctx)
+	s.log.Trace("uID: ", uID)
+
+	if err := model.ValidateFileName(in.Filename); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if in.VersionId == "" {
+		return nil, status.Error(codes.InvalidArgument, "version_id is not set")
+	}
+	if err := s.checkFileRevision(ctx, uID, in.Filename, in.ExpectedRevision); err != nil {
+		return nil, err
+	}
+	err := s.reposervice.RestoreFileVersion(ctx, in.Filename, in.VersionId, &model.User{ID: uID})
+	if err != nil {
+		if errors.Is(err, model.ErrFileVersionMissing) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		e := fmt.Sprintf("failed to restore file version: %v", err)
+		s.log.Info(e)
+		return nil, status.Error(codes.Internal, e)
+	}
+	if _, err := s.reposync.TouchFile(ctx, uID, in.Filename); err != nil {
+		e := fmt.Sprintf("failed to set file revision: %v", err)
+		s.log.Info(e)
+		return nil, status.Error(codes.Internal, e)
+	}
+	s.changes.Publish(uID, model.ChangeEvent{Op: model.ChangeUpdated, FileName: in.Filename})
+
+	return &pbservice.UploadStatus{Success: true, Message: "file version was restored"}, nil
+}
+
+// DeleteData moves the record to the trash, metadata stays with it until purge
+func (s *GRPCServer) DeleteData(ctx context.Context, in *pbservice.DeleteDataRequest) (*pbservice.UploadStatus, error) {
+	uID := jwtrule.GetUserIDFromCTX(ctx)
+	s.log.Trace("uID: ", uID)
+	user := &model.User{
+		ID: uID,
+	}
+
+	data := model.Data{
+		ID:       in.Dataid,
+		UserID:   uID,
+		Revision: in.ExpectedRevision,
+	}
+
+	err := s.repodata.Delete(ctx, &data)
+	if err != nil {
+		if errors.Is(err, model.ErrPdataNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		if errors.Is(err, model.ErrVersionConflict) {
+			return nil, s.dataConflict(ctx, uID, data.ID)
+		}
+		e := fmt.Sprintf("failed to delete pdata: %v", err)
+		s.log.Info(e)
+		return nil, status.Error(codes.Internal, e)
+	}
+	s.changes.Publish(uID, model.ChangeEvent{Op: model.ChangeDeleted, DataID: data.ID})
+
+	//Update User
+	user.LastUpdate = time.Now()
+	_, err = s.repouser.SetLastUpdate(ctx, user)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to SetLastUpdate: "+err.Error())
+	}
+
+	return &pbservice.UploadStatus{Success: true, Message: "data was deleted"}, nil
+}
+
+// ListDataHistory returns previous versions of the record, newest first
+func (s *GRPCServer) ListDataHistory(ctx context.Context, in *pbservice.ListDataHistoryRequest) (*pbservice.ListDataHistoryResponse, error) {
+	uID := jwtrule.GetUserIDFromCTX(ctx)
+	s.log.Trace("uID: ", uID)
+
+	history, err := s.repodata.History(ctx, &model.Data{ID: in.Dataid, UserID: uID})
+	if err != nil {
+		e := fmt.Sprintf("failed to get pdata history: %v", err)
+		s.log.Info(e)
+		return nil, status.Error(codes.Internal, e)
+	}
+
+	res := &pbservice.ListDataHistoryResponse{Revisions: make([]*pbservice.DataRevision, 0, len(history))}
+	for _, h := range history {
+		res.Revisions = append(res.Revisions, &pbservice.DataRevision{
+			Data:      dataToProto(h.Data),
+			ChangedAt: h.ChangedAt.Unix(),
+		})
+	}
+	return res, nil
+}
+
+// RestoreDataRevision brings back a previous version of the record as its new version
+func (s *GRPCServer) RestoreDataRevision(ctx context.Context, in *pbservice.RestoreDataRevisionRequest) (*pbservice.UploadStatus, error) {
+	uID := jwtrule.GetUserIDFromCTX(ctx)
+	s.log.Trace("uID: ", uID)
+	user := &model.User{
+		ID: uID,
+	}
+
+	data := model.Data{
+		ID:       in.Dataid,
+		UserID:   uID,
+		Revision: in.ExpectedRevision,
+	}
+
+	err := s.repodata.Restore(ctx, &data, in.Revision)
+	if err != nil {
+		if errors.Is(err, model.ErrPdataNotFound) || errors.Is(err, model.ErrRevisionNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		if errors.Is(err, model.ErrVersionConflict) {
+			return nil, s.dataConflict(ctx, uID, data.ID)
+		}
+		e := fmt.Sprintf("failed to restore pdata: %v", err)
+		s.log.Info(e)
+		return nil, status.Error(codes.Internal, e)
+	}
+	s.changes.Publish(uID, model.ChangeEvent{Op: model.ChangeUpdated, DataID: data.ID})
+
+	//Update User
+	user.LastUpdate = time.Now()
+	_, err = s.repouser.SetLastUpdate(ctx, user)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to SetLastUpdate: "+err.Error())
+	}
+
+	return &pbservice.UploadStatus{Success: true, Message: "data was restored"}, nil
+}
+
+// DeleteFile moves the file to the trash, metadata stays with it until purge
 func (s *GRPCServer) DeleteFile(ctx context.Context, in *pbservice.DeleteFileRequest) (*pbservice.UploadStatus, error) {
 	uID := jwtrule.GetUserIDFromCTX(ctx)
 	s.log.Trace("uID: ", uID)
@@ -325,16 +761,120 @@ func (s *GRPCServer) DeleteFile(ctx context.Context, in *pbservice.DeleteFileReq
 		ID: uID,
 	}
 
+	if err := model.ValidateFileName(in.Filename); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := s.checkFileRevision(ctx, uID, in.Filename, in.ExpectedRevision); err != nil {
+		return nil, err
+	}
 	err := s.reposervice.DeleteFile(ctx, in.Filename, &user)
 	if err != nil {
+		if errors.Is(err, model.ErrFileNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
 		e := fmt.Sprintf("failed to delete file: %v", err)
 		s.log.Info(e)
 		return nil, status.Error(codes.Aborted, e)
 	}
+	// tombstone нужен другим клиентам пользователя, без него файл у них не удалится
+	if _, err := s.reposync.DeleteFile(ctx, uID, in.Filename); err != nil {
+		e := fmt.Sprintf("failed to set file revision: %v", err)
+		s.log.Info(e)
+		return nil, status.Error(codes.Internal, e)
+	}
+	s.changes.Publish(uID, model.ChangeEvent{Op: model.ChangeDeleted, FileName: in.Filename})
 
 	return &pbservice.UploadStatus{Success: true, Message: "data was deleted"}, nil
 }
 
+// Sync returns records and files changed after the client revision, deleted ones are returned by id and name
+func (s *GRPCServer) Sync(ctx context.Context, in *pbservice.SyncRequest) (*pbservice.SyncResponse, error) {
+	uID := jwtrule.GetUserIDFromCTX(ctx)
+	s.log.Trace("uID: ", uID)
+
+	changes, err := s.reposync.Changes(ctx, uID, in.SinceRevision, model.PageLimit(in.PageSize))
+	if err != nil {
+		e := fmt.Sprintf("failed to get changes: %v", err)
+		s.log.Info(e)
+		return nil, status.Error(codes.Internal, e)
+	}
+	resp := &pbservice.SyncResponse{Revision: changes.Revision, HasMore: changes.HasMore}
+
+	if len(changes.Data) > 0 {
+		metas, err := s.repometa.List(ctx, uID, model.MetaKindData)
+		if err != nil {
+			e := fmt.Sprintf("failed to list pdata metadata: %s", err.Error())
+			return nil, status.Error(codes.Internal, e)
+		}
+		for _, item := range changes.Data {
+			if item.Deleted {
+				resp.DeletedData = append(resp.DeletedData, item.ID)
+				continue
+			}
+			meta := metas[strconv.FormatInt(item.ID, 10)]
+			item.Meta, item.Tags = meta.Fields, meta.Tags
+			resp.Data = append(resp.Data, dataToProto(item))
+		}
+	}
+
+	if len(changes.Files) > 0 {
+		metas, err := s.repometa.List(ctx, uID, model.MetaKindFile)
+		if err != nil {
+			e := fmt.Sprintf("failed to list files metadata: %s", err.Error())
+			return nil, status.Error(codes.Internal, e)
+		}
+		for _, it := range changes.Files {
+			if it.Deleted {
+				resp.DeletedFiles = append(resp.DeletedFiles, it.Name)
+				continue
+			}
+			meta := metas[it.Name]
+			it.Desc, it.Meta, it.Tags = meta.Description, meta.Fields, meta.Tags
+			resp.Files = append(resp.Files, fileToProto(it))
+		}
+	}
+
+	return resp, nil
+}
+
+// WatchChanges streams change events of the user until the client disconnects.
+// Поток закрывается с Unavailable, если клиент отстал или сервер останавливается.
+func (s *GRPCServer) WatchChanges(_ *pbservice.WatchChangesRequest, stream pbservice.DataKeeperService_WatchChangesServer) error {
+	ctx := stream.Context()
+	uID := jwtrule.GetUserIDFromCTX(ctx)
+	s.log.Trace("uID: ", uID)
+
+	events, unsubscribe := s.changes.Subscribe(uID)
+	defer unsubscribe()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case ev, ok := <-events:
+			if !ok {
+				return status.Error(codes.Unavailable, "change feed is closed, resubscribe and sync")
+			}
+			if err := stream.Send(changeToProto(ev)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func changeToProto(ev model.ChangeEvent) *pbservice.ChangeEvent {
+	op := pbservice.ChangeOp_CHANGE_OP_UNSPECIFIED
+	switch ev.Op {
+	case model.ChangeCreated:
+		op = pbservice.ChangeOp_CHANGE_OP_CREATED
+	case model.ChangeUpdated:
+		op = pbservice.ChangeOp_CHANGE_OP_UPDATED
+	case model.ChangeDeleted:
+		op = pbservice.ChangeOp_CHANGE_OP_DELETED
+	}
+	return &pbservice.ChangeEvent{Op: op, DataId: ev.DataID, FileName: ev.FileName}
+}
+
 // func (s *GRPCServer) getUserID(ctx context.Context) (int64, error) {
 // 	md, ok := metadata.FromIncomingContext(ctx)
 // 	if !ok {
@@ -360,14 +900,34 @@ func (s *GRPCServer) GetFile(req *pbservice.GetFileRequest, stream pbservice.Dat
 		ID: uID,
 	}
 	fileID := req.GetName()
+	if err := model.ValidateFileName(fileID); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if req.GetOffset() < 0 {
+		return status.Error(codes.InvalidArgument, "offset must be non-negative")
+	}
 
-	// Получаем файл из MinIO
-	file, err := s.reposervice.GetFile(stream.Context(), fileID, user)
+	// Получаем файл из MinIO, прежнюю версию - по version_id
+	var file io.ReadSeekCloser
+	var err error
+	if req.GetVersionId() != "" {
+		file, err = s.reposervice.GetFileVersion(stream.Context(), fileID, req.GetVersionId(), user)
+	} else {
+		file, err = s.reposervice.GetFile(stream.Context(), fileID, user)
+	}
+	if errors.Is(err, model.ErrFileVersionMissing) {
+		return status.Error(codes.NotFound, err.Error())
+	}
 	if err != nil {
 		return err
 	}
 	defer file.Close()
 
+	// продолжение прерванного скачивания
+	if _, err := file.Seek(req.GetOffset(), io.SeekStart); err != nil {
+		return err
+	}
+
 	buffer := make([]byte, 1024*1024) // 1 MB buffer size
 
 	// Читаем файл по частям и отправляем через поток
@@ -393,14 +953,139 @@ func (s *GRPCServer) GetFile(req *pbservice.GetFileRequest, stream pbservice.Dat
 	return nil
 }
 
+// dataFromProto converts request data, UserID is taken from the token by the caller
+func dataFromProto(in *pbservice.Data) model.Data {
+	return model.Data{
+		ID:           in.Id,
+		Type:         getType(in.Type),
+		Title:        in.Title,
+		Card:         in.Card,
+		Login:        in.Login,
+		Password:     in.Password,
+		Note:         in.Note,
+		Binary:       in.Binary,
+		KeyHash:      in.KeyHash,
+		CardHolder:   in.CardHolder,
+		CardExpMonth: in.CardExpMonth,
+		CardExpYear:  in.CardExpYear,
+		CardCVV:      in.CardCvv,
+		CardBank:     in.CardBank,
+		CardPIN:      in.CardPin,
+		CardBrand:    in.CardBrand,
+		Meta:         in.Meta,
+		Tags:         model.NormalizeTags(in.Tags),
+	}
+}
+
+func dataToProto(item model.Data) *pbservice.Data {
+	return &pbservice.Data{
+		Id:           item.ID,
+		Title:        item.Title,
+		Type:         getPType(item.Type),
+		Card:         item.Card,
+		Login:        item.Login,
+		Password:     item.Password,
+		Note:         item.Note,
+		Binary:       item.Binary,
+		KeyHash:      item.KeyHash,
+		CardHolder:   item.CardHolder,
+		CardExpMonth: item.CardExpMonth,
+		CardExpYear:  item.CardExpYear,
+		CardCvv:      item.CardCVV,
+		CardBank:     item.CardBank,
+		CardPin:      item.CardPIN,
+		CardBrand:    item.CardBrand,
+		Meta:         item.Meta,
+		Tags:         item.Tags,
+		Revision:     item.Revision,
+	}
+}
+
+func fileToProto(item model.FileItem) *pbservice.FileItem {
+	res := &pbservice.FileItem{
+		Key:         item.Hash,
+		Name:        item.Name,
+		Meta:        item.Meta,
+		Tags:        item.Tags,
+		Revision:    item.Revision,
+		Size:        item.Size,
+		ContentType: item.ContentType,
+		Sha256:      item.SHA256,
+		Device:      item.Device,
+		Description: item.Desc,
+		IsFolder:    item.IsFolder,
+	}
+	if !item.UploadedAt.IsZero() {
+		res.UploadedAt = item.UploadedAt.Unix()
+	}
+	return res
+}
+
+// dataListQuery converts list request to repository query, legacy type filter is joined with types
+func dataListQuery(in *pbservice.ListDataRequest) (model.ListQuery, error) {
+	after, err := model.DecodePageToken(in.PageToken)
+	if err != nil {
+		return model.ListQuery{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	types := in.Types
+	if in.Type != pbservice.DataType_DATA_TYPE_UNSPECIFIED {
+		types = append(types, in.Type)
+	}
+	query := model.ListQuery{
+		Search: in.Search,
+		Tags:   model.NormalizeTags(in.Tags),
+		Sort:   listSort(in.Sort),
+		Desc:   in.Desc,
+		Limit:  model.PageLimit(in.PageSize),
+		After:  after,
+	}
+	for _, t := range types {
+		if st := getType(t); !slices.Contains(query.Types, st) {
+			query.Types = append(query.Types, st)
+		}
+	}
+	return query, nil
+}
+
+func fileListQuery(in *pbservice.ListFileRequest) (model.ListQuery, error) {
+	after, err := model.DecodePageToken(in.PageToken)
+	if err != nil {
+		return model.ListQuery{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	query := model.ListQuery{
+		Search: in.Search,
+		Tags:   model.NormalizeTags(in.Tags),
+		Sort:   listSort(in.Sort),
+		Desc:   in.Desc,
+		Limit:  model.PageLimit(in.PageSize),
+		After:  after,
+		Browse: in.Browse,
+		Parent: in.Parent,
+	}
+	for _, ext := range in.Extensions {
+		query.Types = append(query.Types, strings.TrimPrefix(ext, "."))
+	}
+	return query, nil
+}
+
+func listSort(sort pbservice.ListSort) string {
+	if sort == pbservice.ListSort_LIST_SORT_TITLE {
+		return model.ListSortTitle
+	}
+	return model.ListSortDefault
+}
+
 func getPType(stype string) pbservice.DataType {
 	switch stype {
 	case repository.DataTypeCARD:
 		return pbservice.DataType_DATA_TYPE_TYPE_CREDIT_CARD
 	case repository.DataTypeLOGPASS:
 		return pbservice.DataType_DATA_TYPE_TYPE_LOGIN_PASSWORD
+	case repository.DataTypeBINARY:
+		return pbservice.DataType_DATA_TYPE_TYPE_BINARY
 	}
 
+	// DATA_TYPE_UNSPECIFIED - текстовая заметка
 	return pbservice.DataType_DATA_TYPE_UNSPECIFIED
 }
 
@@ -410,7 +1095,9 @@ func getType(stype pbservice.DataType) string {
 		return repository.DataTypeCARD
 	case int(pbservice.DataType_DATA_TYPE_TYPE_LOGIN_PASSWORD):
 		return repository.DataTypeLOGPASS
+	case int(pbservice.DataType_DATA_TYPE_TYPE_BINARY):
+		return repository.DataTypeBINARY
 	}
 
-	return ""
+	return repository.DataTypeTEXT
 }
@@ -5,15 +5,19 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	pbservice "github.com/Arcadian-Sky/datakkeeper/gen/proto/api/service/v1"
 	pbuser "github.com/Arcadian-Sky/datakkeeper/gen/proto/api/user/v1"
 
 	"github.com/Arcadian-Sky/datakkeeper/internal/model"
 	"github.com/Arcadian-Sky/datakkeeper/internal/server/repository"
+	"github.com/Arcadian-Sky/datakkeeper/internal/server/router/changefeed"
 	"github.com/Arcadian-Sky/datakkeeper/internal/server/router/jwtrule"
 	"github.com/Arcadian-Sky/datakkeeper/internal/settings"
 	"github.com/Arcadian-Sky/datakkeeper/mocks"
@@ -21,6 +25,10 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	gomockuber "go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 )
 
 func TestInitGRPCServer(t *testing.T) {
@@ -30,6 +38,10 @@ func TestInitGRPCServer(t *testing.T) {
 	mockRepoUser := mocks.NewMockUserRepository(ctrl)
 	mockRepoFile := mocks.NewMockFileRepository(ctrl)
 	mockRepoData := mocks.NewMockDataRepository(ctrl)
+	mockRepoSession := mocks.NewMockSessionRepository(ctrl)
+	mockRepoMeta := mocks.NewMockItemMetaRepository(ctrl)
+	mockRepoSync := mocks.NewMockSyncRepository(ctrl)
+	mockRepoUpload := mocks.NewMockUploadRepository(ctrl)
 
 	// Define test settings
 	testCfg := &settings.InitedFlags{
@@ -38,7 +50,7 @@ func TestInitGRPCServer(t *testing.T) {
 	testLogger := logrus.New()
 
 	// Call the function
-	server, err := InitGRPCServer(testCfg, testLogger, mockRepoFile, mockRepoUser, mockRepoData)
+	server, err := InitGRPCServer(testCfg, testLogger, nil, mockRepoFile, mockRepoUser, mockRepoData, mockRepoSession, mockRepoMeta, mockRepoSync, mockRepoUpload)
 
 	// Assertions
 	assert.NoError(t, err, "Expected no error when initializing the GRPC server")
@@ -56,14 +68,24 @@ func createTestMockServer(t *testing.T) (server *GRPCServer) {
 	mockRepoUser := mocks.NewMockUserRepository(ctrl)
 	mockRepoFile := mocks.NewMockFileRepository(ctrl)
 	mockRepoData := mocks.NewMockDataRepository(ctrl)
+	mockRepoSession := mocks.NewMockSessionRepository(ctrl)
+	mockRepoMeta := mocks.NewMockItemMetaRepository(ctrl)
+	mockRepoSync := mocks.NewMockSyncRepository(ctrl)
+	mockRepoUpload := mocks.NewMockUploadRepository(ctrl)
 	mockLogger := logrus.New()
 
 	server = &GRPCServer{
 		reposervice: mockRepoFile,
 		repodata:    mockRepoData,
 		repouser:    mockRepoUser,
+		reposession: mockRepoSession,
+		repometa:    mockRepoMeta,
+		reposync:    mockRepoSync,
+		repoupload:  mockRepoUpload,
+		changes:     changefeed.NewHub(),
 		log:         mockLogger,
 		cfg:         &settings.InitedFlags{SecretKey: "test-secret"},
+		keys:        jwtrule.NewStaticKeyring("test-secret"),
 	}
 
 	return server
@@ -76,6 +98,7 @@ func TestGRPCServer_Register(t *testing.T) {
 
 	mockRepoUser := mocks.NewMockUserRepository(ctrl)
 	mockRepoService := mocks.NewMockFileRepository(ctrl)
+	mockRepoSession := mocks.NewMockSessionRepository(ctrl)
 	logg := logrus.New()
 	// logg.SetLevel(logrus.TraceLevel)
 	// logg.SetFormatter(&logrus.TextFormatter{})
@@ -84,7 +107,9 @@ func TestGRPCServer_Register(t *testing.T) {
 		log:         logg,
 		repouser:    mockRepoUser,
 		reposervice: mockRepoService,
+		reposession: mockRepoSession,
 		cfg:         &settings.InitedFlags{SecretKey: "test-secret"},
+		keys:        jwtrule.NewStaticKeyring("test-secret"),
 	}
 
 	tests := []struct {
@@ -97,8 +122,9 @@ func TestGRPCServer_Register(t *testing.T) {
 		{
 			name: "Success",
 			input: &pbuser.RegisterRequest{
-				Login:    "testuser",
-				Password: "password",
+				Login:      "testuser",
+				Password:   "password",
+				DeviceInfo: "laptop",
 			},
 			mockSetup: func() {
 				mockRepoUser.EXPECT().Register(gomock.Any(), gomock.Any()).Return(int64(1), nil)
@@ -112,7 +138,13 @@ func TestGRPCServer_Register(t *testing.T) {
 					return *u, nil
 				})
 
-				// _, _ := jwtrule.Generate(1, "test-secret")
+				mockRepoSession.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, ss *model.Session) error {
+					assert.Equal(t, int64(1), ss.UserID)
+					assert.Equal(t, "laptop", ss.DeviceInfo)
+					assert.NotEmpty(t, ss.ID)
+					assert.NotEmpty(t, ss.RefreshHash)
+					return nil
+				})
 			},
 			wantErr:  false,
 			wantResp: &pbuser.RegisterResponse{Success: true, Message: "user testuser (userid: 1) was created\nbucket container bucketuid1 (userid: 1) was created\n", AuthToken: "expected-jwt-token"},
@@ -140,6 +172,20 @@ func TestGRPCServer_Register(t *testing.T) {
 			wantErr:  true,
 			wantResp: nil,
 		},
+		{
+			name: "Create Session Error",
+			input: &pbuser.RegisterRequest{
+				Login:    "testuser",
+				Password: "password",
+			},
+			mockSetup: func() {
+				mockRepoUser.EXPECT().Register(gomock.Any(), gomock.Any()).Return(int64(1), nil)
+				mockRepoService.EXPECT().CreateContainer(gomock.Any(), gomock.Any()).Return(model.User{ID: 1}, nil)
+				mockRepoSession.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errors.New("session error"))
+			},
+			wantErr:  true,
+			wantResp: nil,
+		},
 		{
 			name: "Create Container Error",
 			input: &pbuser.RegisterRequest{
@@ -168,6 +214,8 @@ func TestGRPCServer_Register(t *testing.T) {
 				assert.Equal(t, tt.wantResp.Success, gotResp.Success)
 				assert.Equal(t, tt.wantResp.Message, gotResp.Message)
 				// assert.Equal(t, tt.wantResp.AuthToken, gotResp.AuthToken)
+				assert.NotEmpty(t, gotResp.RefreshToken)
+				assert.NotEmpty(t, gotResp.SessionId)
 			}
 		})
 	}
@@ -180,14 +228,17 @@ func TestGRPCServer_Authenticate(t *testing.T) {
 	mockRepoUser := mocks.NewMockUserRepository(ctrl)
 	mockRepoFile := mocks.NewMockFileRepository(ctrl)
 	mockRepoData := mocks.NewMockDataRepository(ctrl)
+	mockRepoSession := mocks.NewMockSessionRepository(ctrl)
 	mockLogger := logrus.New()
 
 	server := &GRPCServer{
 		reposervice: mockRepoFile,
 		repodata:    mockRepoData,
 		repouser:    mockRepoUser,
+		reposession: mockRepoSession,
 		log:         mockLogger,
 		cfg:         &settings.InitedFlags{SecretKey: "test-secret"},
+		keys:        jwtrule.NewStaticKeyring("test-secret"),
 	}
 
 	tests := []struct {
@@ -214,6 +265,8 @@ func TestGRPCServer_Authenticate(t *testing.T) {
 						assert.Equal(t, "password", u.Password)
 						return user, nil
 					})
+
+				mockRepoSession.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
 			},
 			wantErr: false,
 			wantResp: &pbuser.AuthenticateResponse{
@@ -261,6 +314,12 @@ func TestGRPCServer_Authenticate(t *testing.T) {
 				assert.Equal(t, tt.wantResp.Success, gotResp.Success)
 				// assert.Equal(t, tt.wantResp.AuthToken, gotResp.AuthToken)
 				assert.Equal(t, tt.wantResp.Message, gotResp.Message)
+				assert.NotEmpty(t, gotResp.RefreshToken)
+
+				// токен привязан к сеансу из ответа
+				jtoken, err := jwtrule.ValidateWithKeyring(gotResp.AuthToken, server.keys)
+				assert.NoError(t, err)
+				assert.Equal(t, gotResp.SessionId, jtoken.Claims.SessionID)
 			}
 		})
 	}
@@ -272,11 +331,15 @@ func TestGRPCServer_GetFileList(t *testing.T) {
 	ctx := context.Background()
 	ctx = jwtrule.SetUserIDToCTX(ctx, 1) // Assuming you have a way to set user ID in context
 
+	metas := map[string]model.ItemMeta{
+		"file2.jpg": {Fields: map[string]string{"site": "example.com"}, Tags: []string{"work"}},
+	}
+
 	tests := []struct {
 		name      string
 		input     *pbservice.ListFileRequest
 		mockSetup func()
-		wantErr   bool
+		wantCode  codes.Code
 		wantResp  *pbservice.ListFileResponse
 	}{
 		{
@@ -285,34 +348,105 @@ func TestGRPCServer_GetFileList(t *testing.T) {
 			mockSetup: func() {
 				mockFileItems := []model.FileItem{
 					{Hash: "hash1", Name: "file1"},
-					{Hash: "hash2", Name: "file2"},
+					{Hash: "hash2", Name: "file2.jpg", Desc: "photo", Meta: metas["file2.jpg"].Fields, Tags: metas["file2.jpg"].Tags,
+						Size: 20, ContentType: "image/jpeg", UploadedAt: time.Unix(1722506400, 0), SHA256: "abc", Device: "phone"},
 				}
 
+				server.repometa.(*mocks.MockItemMetaRepository).EXPECT().
+					List(gomock.Any(), int64(1), model.MetaKindFile).
+					Return(metas, nil).
+					Times(1)
+
 				mockRepoFile := server.reposervice.(*mocks.MockFileRepository)
 				mockRepoFile.EXPECT().
-					GetFileList(gomock.Any(), &model.User{ID: 1}).
-					Return(mockFileItems, nil).
+					GetFileList(gomock.Any(), &model.User{ID: 1}, model.ListQuery{Limit: model.DefaultPageSize}, metas).
+					Return(mockFileItems, nil, nil).
+					Times(1)
+				server.reposync.(*mocks.MockSyncRepository).EXPECT().
+					FileRevisions(gomock.Any(), int64(1)).
+					Return(map[string]int64{"file2.jpg": 4}, nil).
 					Times(1)
 			},
-			wantErr: false,
+			wantCode: codes.OK,
 			wantResp: &pbservice.ListFileResponse{
 				Fileitem: []*pbservice.FileItem{
 					{Key: "hash1", Name: "file1"},
-					{Key: "hash2", Name: "file2"},
+					{Key: "hash2", Name: "file2.jpg", Meta: map[string]string{"site": "example.com"}, Tags: []string{"work"}, Revision: 4,
+						Size: 20, ContentType: "image/jpeg", UploadedAt: 1722506400, Sha256: "abc", Device: "phone", Description: "photo"},
 				},
 			},
 		},
+		{
+			name: "FiltersAndPage",
+			input: &pbservice.ListFileRequest{
+				Extensions: []string{".jpg"},
+				Search:     "file",
+				Tags:       []string{"work"},
+				Sort:       pbservice.ListSort_LIST_SORT_TITLE,
+				Desc:       true,
+				PageSize:   1,
+				PageToken:  model.EncodePageToken(&model.ListCursor{Key: "file3.jpg"}),
+			},
+			mockSetup: func() {
+				server.repometa.(*mocks.MockItemMetaRepository).EXPECT().
+					List(gomock.Any(), int64(1), model.MetaKindFile).
+					Return(metas, nil).
+					Times(1)
+				server.reposervice.(*mocks.MockFileRepository).EXPECT().
+					GetFileList(gomock.Any(), &model.User{ID: 1}, model.ListQuery{
+						Types:  []string{"jpg"},
+						Search: "file",
+						Tags:   []string{"work"},
+						Sort:   model.ListSortTitle,
+						Desc:   true,
+						Limit:  1,
+						After:  &model.ListCursor{Key: "file3.jpg"},
+					}, metas).
+					Return([]model.FileItem{{Hash: "hash2", Name: "file2.jpg"}}, &model.ListCursor{Key: "file2.jpg"}, nil).
+					Times(1)
+				server.reposync.(*mocks.MockSyncRepository).EXPECT().
+					FileRevisions(gomock.Any(), int64(1)).
+					Return(map[string]int64{}, nil).
+					Times(1)
+			},
+			wantCode: codes.OK,
+			wantResp: &pbservice.ListFileResponse{
+				Fileitem:      []*pbservice.FileItem{{Key: "hash2", Name: "file2.jpg"}},
+				NextPageToken: model.EncodePageToken(&model.ListCursor{Key: "file2.jpg"}),
+			},
+		},
+		{
+			name:      "BadPageToken",
+			input:     &pbservice.ListFileRequest{PageToken: "%%%"},
+			mockSetup: func() {},
+			wantCode:  codes.InvalidArgument,
+		},
+		{
+			name:  "MetaError",
+			input: &pbservice.ListFileRequest{},
+			mockSetup: func() {
+				server.repometa.(*mocks.MockItemMetaRepository).EXPECT().
+					List(gomock.Any(), int64(1), model.MetaKindFile).
+					Return(nil, fmt.Errorf("db error")).
+					Times(1)
+			},
+			wantCode: codes.Internal,
+		},
 		{
 			name:  "Error",
 			input: &pbservice.ListFileRequest{},
 			mockSetup: func() {
+				server.repometa.(*mocks.MockItemMetaRepository).EXPECT().
+					List(gomock.Any(), int64(1), model.MetaKindFile).
+					Return(nil, nil).
+					Times(1)
 				mockRepoFile := server.reposervice.(*mocks.MockFileRepository)
 				mockRepoFile.EXPECT().
-					GetFileList(gomock.Any(), &model.User{ID: 1}).
-					Return(nil, fmt.Errorf("db error")).
+					GetFileList(gomock.Any(), &model.User{ID: 1}, gomock.Any(), gomock.Any()).
+					Return(nil, nil, fmt.Errorf("db error")).
 					Times(1)
 			},
-			wantErr:  true,
+			wantCode: codes.Internal,
 			wantResp: nil,
 		},
 	}
@@ -321,17 +455,66 @@ func TestGRPCServer_GetFileList(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockSetup()
 			gotResp, err := server.GetFileList(ctx, tt.input)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("GetFileList() error = %v, wantErr %v", err, tt.wantErr)
-				return
-			}
-			if !tt.wantErr && gotResp != nil {
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			if tt.wantCode == codes.OK {
 				assert.Equal(t, tt.wantResp.Fileitem, gotResp.Fileitem)
+				assert.Equal(t, tt.wantResp.NextPageToken, gotResp.NextPageToken)
 			}
 		})
 	}
 }
 
+// searchingMetaRepo - хранилище метаданных с полнотекстовым поиском, как MongoDB
+type searchingMetaRepo struct {
+	*mocks.MockItemMetaRepository
+	*mocks.MockItemMetaSearcher
+}
+
+func TestGRPCServer_GetFileList_MetaSearch(t *testing.T) {
+	server := createTestMockServer(t)
+	ctrl := gomock.NewController(t)
+	searcher := mocks.NewMockItemMetaSearcher(ctrl)
+	metaRepo := server.repometa.(*mocks.MockItemMetaRepository)
+	server.repometa = searchingMetaRepo{MockItemMetaRepository: metaRepo, MockItemMetaSearcher: searcher}
+	ctx := jwtrule.SetUserIDToCTX(context.Background(), 1)
+
+	t.Run("Success", func(t *testing.T) {
+		metaRepo.EXPECT().List(gomock.Any(), int64(1), model.MetaKindFile).Return(nil, nil)
+		searcher.EXPECT().Search(gomock.Any(), int64(1), model.MetaKindFile, "report").Return([]string{"q3.pdf"}, nil)
+		server.reposervice.(*mocks.MockFileRepository).EXPECT().
+			GetFileList(gomock.Any(), &model.User{ID: 1}, model.ListQuery{
+				Search:  "report",
+				Limit:   model.DefaultPageSize,
+				Matched: map[string]bool{"q3.pdf": true},
+			}, gomock.Any()).
+			Return([]model.FileItem{{Name: "q3.pdf"}}, nil, nil)
+		server.reposync.(*mocks.MockSyncRepository).EXPECT().FileRevisions(gomock.Any(), int64(1)).Return(nil, nil)
+
+		resp, err := server.GetFileList(ctx, &pbservice.ListFileRequest{Search: "report"})
+		assert.NoError(t, err)
+		assert.Len(t, resp.Fileitem, 1)
+	})
+
+	t.Run("NoSearch", func(t *testing.T) {
+		metaRepo.EXPECT().List(gomock.Any(), int64(1), model.MetaKindFile).Return(nil, nil)
+		server.reposervice.(*mocks.MockFileRepository).EXPECT().
+			GetFileList(gomock.Any(), &model.User{ID: 1}, model.ListQuery{Limit: model.DefaultPageSize}, gomock.Any()).
+			Return(nil, nil, nil)
+		server.reposync.(*mocks.MockSyncRepository).EXPECT().FileRevisions(gomock.Any(), int64(1)).Return(nil, nil)
+
+		_, err := server.GetFileList(ctx, &pbservice.ListFileRequest{})
+		assert.NoError(t, err)
+	})
+
+	t.Run("SearchError", func(t *testing.T) {
+		metaRepo.EXPECT().List(gomock.Any(), int64(1), model.MetaKindFile).Return(nil, nil)
+		searcher.EXPECT().Search(gomock.Any(), int64(1), model.MetaKindFile, "report").Return(nil, fmt.Errorf("mongo error"))
+
+		_, err := server.GetFileList(ctx, &pbservice.ListFileRequest{Search: "report"})
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+}
+
 func TestGRPCServer_SaveData(t *testing.T) {
 	server := createTestMockServer(t)
 
@@ -349,23 +532,36 @@ func TestGRPCServer_SaveData(t *testing.T) {
 			name: "Success",
 			input: &pbservice.SaveDataRequest{
 				Data: &pbservice.Data{
-					Type:     pbservice.DataType_DATA_TYPE_TYPE_CREDIT_CARD,
-					Title:    "test title",
-					Card:     "1234-5678-9012-3456",
-					Login:    "testuser",
-					Password: "password",
+					Type:         pbservice.DataType_DATA_TYPE_TYPE_CREDIT_CARD,
+					Title:        "test title",
+					Card:         "1234-5678-9012-3456",
+					Login:        "testuser",
+					Password:     "password",
+					KeyHash:      "hash1",
+					CardExpMonth: 3,
+					CardExpYear:  2027,
+					CardBrand:    model.CardBrandVisa,
 				},
 			},
 			mockSetup: func() {
 				mockData := model.Data{
-					Type:     repository.DataTypeCARD,
-					UserID:   1,
-					Title:    "test title",
-					Card:     "1234-5678-9012-3456",
-					Login:    "testuser",
-					Password: "password",
+					Type:         repository.DataTypeCARD,
+					UserID:       1,
+					Title:        "test title",
+					Card:         "1234-5678-9012-3456",
+					Login:        "testuser",
+					Password:     "password",
+					KeyHash:      "hash1",
+					CardExpMonth: 3,
+					CardExpYear:  2027,
+					CardBrand:    model.CardBrandVisa,
 				}
 
+				server.repouser.(*mocks.MockUserRepository).EXPECT().
+					BindKeyHash(gomock.Any(), &model.User{ID: 1, KeyHash: "hash1"}).
+					Return(nil).
+					Times(1)
+
 				mockRepoData := server.repodata.(*mocks.MockDataRepository)
 				mockRepoData.EXPECT().
 					Save(gomock.Any(), &mockData).
@@ -384,27 +580,82 @@ func TestGRPCServer_SaveData(t *testing.T) {
 				Message: "empty",
 			},
 		},
+		{
+			name: "WithMeta",
+			input: &pbservice.SaveDataRequest{
+				Data: &pbservice.Data{
+					Type:    pbservice.DataType_DATA_TYPE_TYPE_LOGIN_PASSWORD,
+					Title:   "mail",
+					Login:   "testuser",
+					KeyHash: "hash1",
+					Meta:    map[string]string{"site": "mail.example.com"},
+					Tags:    []string{" work ", "work"},
+				},
+			},
+			mockSetup: func() {
+				server.repouser.(*mocks.MockUserRepository).EXPECT().
+					BindKeyHash(gomock.Any(), &model.User{ID: 1, KeyHash: "hash1"}).
+					Return(nil).
+					Times(1)
+				server.repodata.(*mocks.MockDataRepository).EXPECT().
+					Save(gomock.Any(), gomock.Any()).
+					Return(int64(7), nil).
+					Times(1)
+				server.repometa.(*mocks.MockItemMetaRepository).EXPECT().
+					Set(gomock.Any(), &model.ItemMeta{
+						UserID: 1,
+						Kind:   model.MetaKindData,
+						ItemID: "7",
+						Fields: map[string]string{"site": "mail.example.com"},
+						Tags:   []string{"work"},
+					}).
+					Return(nil).
+					Times(1)
+				server.repouser.(*mocks.MockUserRepository).EXPECT().
+					SetLastUpdate(gomock.Any(), gomock.Any()).
+					Return(&model.User{}, nil).
+					Times(1)
+			},
+			wantErr: false,
+			wantResp: &pbservice.UploadStatus{
+				Success: true,
+				Message: "empty",
+			},
+		},
 		{
 			name: "SaveDataError",
 			input: &pbservice.SaveDataRequest{
 				Data: &pbservice.Data{
-					Type:     pbservice.DataType_DATA_TYPE_TYPE_CREDIT_CARD,
-					Title:    "test title",
-					Card:     "1234-5678-9012-3456",
-					Login:    "testuser",
-					Password: "password",
+					Type:         pbservice.DataType_DATA_TYPE_TYPE_CREDIT_CARD,
+					Title:        "test title",
+					Card:         "1234-5678-9012-3456",
+					Login:        "testuser",
+					Password:     "password",
+					KeyHash:      "hash1",
+					CardExpMonth: 3,
+					CardExpYear:  2027,
+					CardBrand:    model.CardBrandVisa,
 				},
 			},
 			mockSetup: func() {
 				mockData := model.Data{
-					Type:     repository.DataTypeCARD,
-					UserID:   1,
-					Title:    "test title",
-					Card:     "1234-5678-9012-3456",
-					Login:    "testuser",
-					Password: "password",
+					Type:         repository.DataTypeCARD,
+					UserID:       1,
+					Title:        "test title",
+					Card:         "1234-5678-9012-3456",
+					Login:        "testuser",
+					Password:     "password",
+					KeyHash:      "hash1",
+					CardExpMonth: 3,
+					CardExpYear:  2027,
+					CardBrand:    model.CardBrandVisa,
 				}
 
+				server.repouser.(*mocks.MockUserRepository).EXPECT().
+					BindKeyHash(gomock.Any(), &model.User{ID: 1, KeyHash: "hash1"}).
+					Return(nil).
+					Times(1)
+
 				mockRepoData := server.repodata.(*mocks.MockDataRepository)
 				mockRepoData.EXPECT().
 					Save(gomock.Any(), &mockData).
@@ -418,23 +669,36 @@ func TestGRPCServer_SaveData(t *testing.T) {
 			name: "SetLastUpdateError",
 			input: &pbservice.SaveDataRequest{
 				Data: &pbservice.Data{
-					Type:     pbservice.DataType_DATA_TYPE_TYPE_CREDIT_CARD,
-					Title:    "test title",
-					Card:     "1234-5678-9012-3456",
-					Login:    "testuser",
-					Password: "password",
+					Type:         pbservice.DataType_DATA_TYPE_TYPE_CREDIT_CARD,
+					Title:        "test title",
+					Card:         "1234-5678-9012-3456",
+					Login:        "testuser",
+					Password:     "password",
+					KeyHash:      "hash1",
+					CardExpMonth: 3,
+					CardExpYear:  2027,
+					CardBrand:    model.CardBrandVisa,
 				},
 			},
 			mockSetup: func() {
 				mockData := model.Data{
-					Type:     repository.DataTypeCARD,
-					UserID:   1,
-					Title:    "test title",
-					Card:     "1234-5678-9012-3456",
-					Login:    "testuser",
-					Password: "password",
+					Type:         repository.DataTypeCARD,
+					UserID:       1,
+					Title:        "test title",
+					Card:         "1234-5678-9012-3456",
+					Login:        "testuser",
+					Password:     "password",
+					KeyHash:      "hash1",
+					CardExpMonth: 3,
+					CardExpYear:  2027,
+					CardBrand:    model.CardBrandVisa,
 				}
 
+				server.repouser.(*mocks.MockUserRepository).EXPECT().
+					BindKeyHash(gomock.Any(), &model.User{ID: 1, KeyHash: "hash1"}).
+					Return(nil).
+					Times(1)
+
 				mockRepoData := server.repodata.(*mocks.MockDataRepository)
 				mockRepoData.EXPECT().
 					Save(gomock.Any(), &mockData).
@@ -450,6 +714,93 @@ func TestGRPCServer_SaveData(t *testing.T) {
 			wantErr:  true,
 			wantResp: nil,
 		},
+		{
+			name: "Note",
+			input: &pbservice.SaveDataRequest{
+				Data: &pbservice.Data{
+					Type:    pbservice.DataType_DATA_TYPE_UNSPECIFIED,
+					Title:   "note title",
+					Note:    "sealed note",
+					KeyHash: "hash1",
+				},
+			},
+			mockSetup: func() {
+				server.repouser.(*mocks.MockUserRepository).EXPECT().
+					BindKeyHash(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+				server.repodata.(*mocks.MockDataRepository).EXPECT().
+					Save(gomock.Any(), &model.Data{
+						Type:    repository.DataTypeTEXT,
+						UserID:  1,
+						Title:   "note title",
+						Note:    "sealed note",
+						KeyHash: "hash1",
+					}).
+					Return(int64(2), nil).
+					Times(1)
+				server.repouser.(*mocks.MockUserRepository).EXPECT().
+					SetLastUpdate(gomock.Any(), gomock.Any()).
+					Return(&model.User{}, nil).
+					Times(1)
+			},
+			wantErr: false,
+			wantResp: &pbservice.UploadStatus{
+				Success: true,
+				Message: "empty",
+			},
+		},
+		{
+			name: "BinaryTooLarge",
+			input: &pbservice.SaveDataRequest{
+				Data: &pbservice.Data{
+					Type:    pbservice.DataType_DATA_TYPE_TYPE_BINARY,
+					Title:   "blob",
+					Binary:  make([]byte, model.MaxBinarySize+1),
+					KeyHash: "hash1",
+				},
+			},
+			mockSetup: func() {},
+			wantErr:   true,
+		},
+		{
+			name:      "NilData",
+			input:     &pbservice.SaveDataRequest{},
+			mockSetup: func() {},
+			wantErr:   true,
+		},
+		{
+			name: "PlaintextRejected",
+			input: &pbservice.SaveDataRequest{
+				Data: &pbservice.Data{
+					Type:     pbservice.DataType_DATA_TYPE_TYPE_LOGIN_PASSWORD,
+					Title:    "test title",
+					Login:    "testuser",
+					Password: "password",
+				},
+			},
+			mockSetup: func() {},
+			wantErr:   true,
+		},
+		{
+			name: "WrongMasterKey",
+			input: &pbservice.SaveDataRequest{
+				Data: &pbservice.Data{
+					Type:     pbservice.DataType_DATA_TYPE_TYPE_LOGIN_PASSWORD,
+					Title:    "test title",
+					Login:    "testuser",
+					Password: "password",
+					KeyHash:  "hash2",
+				},
+			},
+			mockSetup: func() {
+				server.repouser.(*mocks.MockUserRepository).EXPECT().
+					BindKeyHash(gomock.Any(), &model.User{ID: 1, KeyHash: "hash2"}).
+					Return(model.ErrKeyHashMismatch).
+					Times(1)
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -506,8 +857,15 @@ func TestGRPCServer_GetDataList(t *testing.T) {
 
 				mockRepoData := server.repodata.(*mocks.MockDataRepository)
 				mockRepoData.EXPECT().
-					GetList(gomock.Any(), gomock.Any()).
-					Return(data, nil).
+					GetList(gomock.Any(), gomock.Any(), model.ListQuery{Limit: model.DefaultPageSize}).
+					Return(data, nil, nil).
+					Times(1)
+
+				server.repometa.(*mocks.MockItemMetaRepository).EXPECT().
+					List(gomock.Any(), int64(1), model.MetaKindData).
+					Return(map[string]model.ItemMeta{
+						"1": {Fields: map[string]string{"bank": "main"}, Tags: []string{"salary"}},
+					}, nil).
 					Times(1)
 			},
 			wantErr: false,
@@ -520,6 +878,8 @@ func TestGRPCServer_GetDataList(t *testing.T) {
 						Card:     "1234-5678-9012-3456",
 						Login:    "user1",
 						Password: "pass1",
+						Meta:     map[string]string{"bank": "main"},
+						Tags:     []string{"salary"},
 					},
 					{
 						Id:       2,
@@ -538,13 +898,52 @@ func TestGRPCServer_GetDataList(t *testing.T) {
 			mockSetup: func() {
 				mockRepoData := server.repodata.(*mocks.MockDataRepository)
 				mockRepoData.EXPECT().
-					GetList(gomock.Any(), gomock.Any()).
-					Return(nil, fmt.Errorf("db error")).
+					GetList(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, nil, fmt.Errorf("db error")).
 					Times(1)
 			},
 			wantErr:  true,
 			wantResp: nil,
 		},
+		{
+			name: "FiltersAndPage",
+			input: &pbservice.ListDataRequest{
+				Type:      pbservice.DataType_DATA_TYPE_TYPE_CREDIT_CARD,
+				Types:     []pbservice.DataType{pbservice.DataType_DATA_TYPE_UNSPECIFIED, pbservice.DataType_DATA_TYPE_TYPE_CREDIT_CARD},
+				Search:    "bank",
+				Tags:      []string{"salary", " salary"},
+				Sort:      pbservice.ListSort_LIST_SORT_TITLE,
+				PageSize:  1,
+				PageToken: model.EncodePageToken(&model.ListCursor{Key: "A", ID: 5}),
+			},
+			mockSetup: func() {
+				server.repodata.(*mocks.MockDataRepository).EXPECT().
+					GetList(gomock.Any(), &model.User{ID: 1}, model.ListQuery{
+						Types:  []string{repository.DataTypeTEXT, repository.DataTypeCARD},
+						Search: "bank",
+						Tags:   []string{"salary"},
+						Sort:   model.ListSortTitle,
+						Limit:  1,
+						After:  &model.ListCursor{Key: "A", ID: 5},
+					}).
+					Return([]model.Data{{ID: 6, Title: "B", Type: repository.DataTypeCARD}}, &model.ListCursor{Key: "B", ID: 6}, nil).
+					Times(1)
+				server.repometa.(*mocks.MockItemMetaRepository).EXPECT().
+					List(gomock.Any(), int64(1), model.MetaKindData).
+					Return(nil, nil).
+					Times(1)
+			},
+			wantResp: &pbservice.ListDataResponse{
+				Data:          []*pbservice.Data{{Id: 6, Title: "B", Type: pbservice.DataType_DATA_TYPE_TYPE_CREDIT_CARD}},
+				NextPageToken: model.EncodePageToken(&model.ListCursor{Key: "B", ID: 6}),
+			},
+		},
+		{
+			name:      "BadPageToken",
+			input:     &pbservice.ListDataRequest{PageToken: "%%%"},
+			mockSetup: func() {},
+			wantErr:   true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -557,6 +956,7 @@ func TestGRPCServer_GetDataList(t *testing.T) {
 			}
 			if !tt.wantErr && gotResp != nil {
 				assert.ElementsMatch(t, tt.wantResp.Data, gotResp.Data)
+				assert.Equal(t, tt.wantResp.NextPageToken, gotResp.NextPageToken)
 			}
 		})
 	}
@@ -586,6 +986,10 @@ func TestGRPCServer_DeleteFile(t *testing.T) {
 					DeleteFile(gomock.Any(), "file-to-delete.txt", gomock.Any()).
 					Return(nil).
 					Times(1)
+				server.reposync.(*mocks.MockSyncRepository).EXPECT().
+					DeleteFile(gomock.Any(), int64(1), "file-to-delete.txt").
+					Return(int64(12), nil).
+					Times(1)
 			},
 			wantErr: false,
 			wantResp: &pbservice.UploadStatus{
@@ -608,6 +1012,24 @@ func TestGRPCServer_DeleteFile(t *testing.T) {
 			wantErr:  true,
 			wantResp: nil,
 		},
+		{
+			name: "RevisionError",
+			input: &pbservice.DeleteFileRequest{
+				Filename: "file-to-delete.txt",
+			},
+			mockSetup: func() {
+				server.reposervice.(*mocks.MockFileRepository).EXPECT().
+					DeleteFile(gomock.Any(), "file-to-delete.txt", gomock.Any()).
+					Return(nil).
+					Times(1)
+				server.reposync.(*mocks.MockSyncRepository).EXPECT().
+					DeleteFile(gomock.Any(), int64(1), "file-to-delete.txt").
+					Return(int64(0), fmt.Errorf("db error")).
+					Times(1)
+			},
+			wantErr:  true,
+			wantResp: nil,
+		},
 	}
 
 	for _, tt := range tests {
@@ -624,59 +1046,501 @@ func TestGRPCServer_DeleteFile(t *testing.T) {
 			}
 		})
 	}
+
+	server.reposervice.(*mocks.MockFileRepository).EXPECT().
+		DeleteFile(gomock.Any(), "missing.txt", gomock.Any()).
+		Return(model.ErrFileNotFound)
+	_, err := server.DeleteFile(ctx, &pbservice.DeleteFileRequest{Filename: "missing.txt"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	// файлы корзины удаляются только через PurgeTrash
+	_, err = server.DeleteFile(ctx, &pbservice.DeleteFileRequest{Filename: model.TrashPrefix + "a.txt"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
 }
 
-func TestGetPType(t *testing.T) {
+func TestGRPCServer_UpdateFileMeta(t *testing.T) {
+	server := createTestMockServer(t)
+
+	ctx := context.Background()
+	ctx = jwtrule.SetUserIDToCTX(ctx, 1)
+
 	tests := []struct {
-		name     string
-		input    string
-		expected pbservice.DataType
+		name      string
+		input     *pbservice.UpdateFileMetaRequest
+		mockSetup func()
+		wantCode  codes.Code
 	}{
 		{
-			name:     "DataTypeCARD",
-			input:    repository.DataTypeCARD,
-			expected: pbservice.DataType_DATA_TYPE_TYPE_CREDIT_CARD,
+			name: "Success",
+			input: &pbservice.UpdateFileMetaRequest{
+				Filename:    "photo.jpg",
+				Meta:        map[string]string{"place": "Kazan"},
+				Tags:        []string{"trip", " trip", "2024"},
+				Description: "Summer trip",
+			},
+			mockSetup: func() {
+				server.repometa.(*mocks.MockItemMetaRepository).EXPECT().
+					Set(gomock.Any(), &model.ItemMeta{
+						UserID:      1,
+						Kind:        model.MetaKindFile,
+						ItemID:      "photo.jpg",
+						Fields:      map[string]string{"place": "Kazan"},
+						Tags:        []string{"2024", "trip"},
+						Description: "Summer trip",
+					}).
+					Return(nil).
+					Times(1)
+				server.reposync.(*mocks.MockSyncRepository).EXPECT().
+					TouchFile(gomock.Any(), int64(1), "photo.jpg").
+					Return(int64(3), nil).
+					Times(1)
+			},
+			wantCode: codes.OK,
 		},
 		{
-			name:     "DataTypeLOGPASS",
-			input:    repository.DataTypeLOGPASS,
-			expected: pbservice.DataType_DATA_TYPE_TYPE_LOGIN_PASSWORD,
+			name:      "EmptyFilename",
+			input:     &pbservice.UpdateFileMetaRequest{Tags: []string{"trip"}},
+			mockSetup: func() {},
+			wantCode:  codes.InvalidArgument,
 		},
 		{
-			name:     "UnknownType",
-			input:    "unknown",
-			expected: pbservice.DataType_DATA_TYPE_UNSPECIFIED,
+			name: "InvalidMeta",
+			input: &pbservice.UpdateFileMetaRequest{
+				Filename: "photo.jpg",
+				Meta:     map[string]string{"": "value"},
+			},
+			mockSetup: func() {},
+			wantCode:  codes.InvalidArgument,
+		},
+		{
+			name: "LongDescription",
+			input: &pbservice.UpdateFileMetaRequest{
+				Filename:    "photo.jpg",
+				Description: strings.Repeat("d", model.MaxDescLen+1),
+			},
+			mockSetup: func() {},
+			wantCode:  codes.InvalidArgument,
+		},
+		{
+			name:  "SetError",
+			input: &pbservice.UpdateFileMetaRequest{Filename: "photo.jpg", Tags: []string{"trip"}},
+			mockSetup: func() {
+				server.repometa.(*mocks.MockItemMetaRepository).EXPECT().
+					Set(gomock.Any(), gomock.Any()).
+					Return(fmt.Errorf("db error")).
+					Times(1)
+			},
+			wantCode: codes.Internal,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			actual := getPType(tt.input)
-			assert.Equal(t, tt.expected, actual)
+			tt.mockSetup()
+			resp, err := server.UpdateFileMeta(ctx, tt.input)
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			if tt.wantCode == codes.OK {
+				assert.True(t, resp.Success)
+			}
 		})
 	}
 }
 
-func TestGetType(t *testing.T) {
+func TestGRPCServer_GetData(t *testing.T) {
+	server := createTestMockServer(t)
+
+	ctx := context.Background()
+	ctx = jwtrule.SetUserIDToCTX(ctx, 1)
+
 	tests := []struct {
-		name     string
-		input    pbservice.DataType
-		expected string
+		name      string
+		input     *pbservice.GetDataRequest
+		mockSetup func()
+		wantCode  codes.Code
+		wantResp  *pbservice.Data
 	}{
 		{
-			name:     "DataTypeCARD",
-			input:    pbservice.DataType_DATA_TYPE_TYPE_CREDIT_CARD,
-			expected: repository.DataTypeCARD,
-		},
+			name:  "Success",
+			input: &pbservice.GetDataRequest{Dataid: 10},
+			mockSetup: func() {
+				server.repodata.(*mocks.MockDataRepository).EXPECT().
+					Get(gomock.Any(), &model.Data{ID: 10, UserID: 1}).
+					Return(&model.Data{ID: 10, UserID: 1, Type: repository.DataTypeCARD, Title: "title", Card: "4111"}, nil).
+					Times(1)
+				server.repometa.(*mocks.MockItemMetaRepository).EXPECT().
+					Get(gomock.Any(), &model.ItemMeta{UserID: 1, Kind: model.MetaKindData, ItemID: "10"}).
+					Return(&model.ItemMeta{Fields: map[string]string{"bank": "main"}, Tags: []string{"salary"}}, nil).
+					Times(1)
+			},
+			wantCode: codes.OK,
+			wantResp: &pbservice.Data{
+				Id:    10,
+				Type:  pbservice.DataType_DATA_TYPE_TYPE_CREDIT_CARD,
+				Title: "title",
+				Card:  "4111",
+				Meta:  map[string]string{"bank": "main"},
+				Tags:  []string{"salary"},
+			},
+		},
+		{
+			name:  "Binary",
+			input: &pbservice.GetDataRequest{Dataid: 12},
+			mockSetup: func() {
+				server.repodata.(*mocks.MockDataRepository).EXPECT().
+					Get(gomock.Any(), &model.Data{ID: 12, UserID: 1}).
+					Return(&model.Data{ID: 12, UserID: 1, Type: repository.DataTypeBINARY, Title: "key", Binary: []byte{0, 1, 2}}, nil).
+					Times(1)
+				server.repometa.(*mocks.MockItemMetaRepository).EXPECT().
+					Get(gomock.Any(), gomock.Any()).
+					Return(&model.ItemMeta{}, nil).
+					Times(1)
+			},
+			wantCode: codes.OK,
+			wantResp: &pbservice.Data{
+				Id:     12,
+				Type:   pbservice.DataType_DATA_TYPE_TYPE_BINARY,
+				Title:  "key",
+				Binary: []byte{0, 1, 2},
+			},
+		},
+		{
+			name:  "NotFound",
+			input: &pbservice.GetDataRequest{Dataid: 11},
+			mockSetup: func() {
+				server.repodata.(*mocks.MockDataRepository).EXPECT().
+					Get(gomock.Any(), &model.Data{ID: 11, UserID: 1}).
+					Return(nil, model.ErrPdataNotFound).
+					Times(1)
+			},
+			wantCode: codes.NotFound,
+		},
+		{
+			name:  "GetError",
+			input: &pbservice.GetDataRequest{Dataid: 10},
+			mockSetup: func() {
+				server.repodata.(*mocks.MockDataRepository).EXPECT().
+					Get(gomock.Any(), gomock.Any()).
+					Return(nil, fmt.Errorf("db error")).
+					Times(1)
+			},
+			wantCode: codes.Internal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			gotResp, err := server.GetData(ctx, tt.input)
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			if tt.wantResp != nil {
+				assert.Equal(t, tt.wantResp.Id, gotResp.Data.Id)
+				assert.Equal(t, tt.wantResp.Type, gotResp.Data.Type)
+				assert.Equal(t, tt.wantResp.Title, gotResp.Data.Title)
+				assert.Equal(t, tt.wantResp.Card, gotResp.Data.Card)
+			}
+		})
+	}
+}
+
+func TestGRPCServer_UpdateData(t *testing.T) {
+	server := createTestMockServer(t)
+
+	ctx := context.Background()
+	ctx = jwtrule.SetUserIDToCTX(ctx, 1)
+
+	input := &pbservice.UpdateDataRequest{
+		Data: &pbservice.Data{
+			Id:       10,
+			Type:     pbservice.DataType_DATA_TYPE_TYPE_LOGIN_PASSWORD,
+			Title:    "new title",
+			Login:    "newlogin",
+			Password: "newpass",
+			KeyHash:  "hash1",
+		},
+	}
+	mockData := &model.Data{
+		ID:       10,
+		UserID:   1,
+		Type:     repository.DataTypeLOGPASS,
+		Title:    "new title",
+		Login:    "newlogin",
+		Password: "newpass",
+		KeyHash:  "hash1",
+	}
+	bindKey := func() {
+		server.repouser.(*mocks.MockUserRepository).EXPECT().
+			BindKeyHash(gomock.Any(), &model.User{ID: 1, KeyHash: "hash1"}).
+			Return(nil).
+			Times(1)
+	}
+
+	tests := []struct {
+		name      string
+		input     *pbservice.UpdateDataRequest
+		mockSetup func()
+		wantCode  codes.Code
+		wantResp  *pbservice.UploadStatus
+	}{
+		{
+			name:  "Success",
+			input: input,
+			mockSetup: func() {
+				bindKey()
+				server.repodata.(*mocks.MockDataRepository).EXPECT().
+					Update(gomock.Any(), mockData).
+					Return(nil).
+					Times(1)
+				server.repometa.(*mocks.MockItemMetaRepository).EXPECT().
+					Set(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+
+				server.repouser.(*mocks.MockUserRepository).EXPECT().
+					SetLastUpdate(gomock.Any(), gomock.Any()).
+					Return(&model.User{}, nil).
+					Times(1)
+			},
+			wantCode: codes.OK,
+			wantResp: &pbservice.UploadStatus{
+				Success: true,
+				Message: "data was updated",
+			},
+		},
+		{
+			name:      "EmptyData",
+			input:     &pbservice.UpdateDataRequest{},
+			mockSetup: func() {},
+			wantCode:  codes.InvalidArgument,
+		},
+		{
+			name:  "NotFound",
+			input: input,
+			mockSetup: func() {
+				bindKey()
+				server.repodata.(*mocks.MockDataRepository).EXPECT().
+					Update(gomock.Any(), mockData).
+					Return(model.ErrPdataNotFound).
+					Times(1)
+			},
+			wantCode: codes.NotFound,
+		},
+		{
+			name:  "UpdateError",
+			input: input,
+			mockSetup: func() {
+				bindKey()
+				server.repodata.(*mocks.MockDataRepository).EXPECT().
+					Update(gomock.Any(), mockData).
+					Return(fmt.Errorf("db error")).
+					Times(1)
+			},
+			wantCode: codes.Internal,
+		},
+		{
+			name:  "SetLastUpdateError",
+			input: input,
+			mockSetup: func() {
+				bindKey()
+				server.repodata.(*mocks.MockDataRepository).EXPECT().
+					Update(gomock.Any(), mockData).
+					Return(nil).
+					Times(1)
+				server.repometa.(*mocks.MockItemMetaRepository).EXPECT().
+					Set(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+
+				server.repouser.(*mocks.MockUserRepository).EXPECT().
+					SetLastUpdate(gomock.Any(), gomock.Any()).
+					Return(nil, fmt.Errorf("update error")).
+					Times(1)
+			},
+			wantCode: codes.Internal,
+		},
+		{
+			name:  "WrongMasterKey",
+			input: input,
+			mockSetup: func() {
+				server.repouser.(*mocks.MockUserRepository).EXPECT().
+					BindKeyHash(gomock.Any(), gomock.Any()).
+					Return(model.ErrKeyHashMismatch).
+					Times(1)
+			},
+			wantCode: codes.FailedPrecondition,
+		},
+		{
+			name:      "PlaintextRejected",
+			input:     &pbservice.UpdateDataRequest{Data: &pbservice.Data{Id: 10, Login: "newlogin"}},
+			mockSetup: func() {},
+			wantCode:  codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			gotResp, err := server.UpdateData(ctx, tt.input)
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			if tt.wantResp != nil {
+				assert.Equal(t, tt.wantResp.Success, gotResp.Success)
+				assert.Equal(t, tt.wantResp.Message, gotResp.Message)
+			}
+		})
+	}
+}
+
+func TestGRPCServer_DeleteData(t *testing.T) {
+	server := createTestMockServer(t)
+
+	ctx := context.Background()
+	ctx = jwtrule.SetUserIDToCTX(ctx, 1)
+
+	tests := []struct {
+		name      string
+		input     *pbservice.DeleteDataRequest
+		mockSetup func()
+		wantCode  codes.Code
+		wantResp  *pbservice.UploadStatus
+	}{
+		{
+			name:  "Success",
+			input: &pbservice.DeleteDataRequest{Dataid: 10},
+			mockSetup: func() {
+				server.repodata.(*mocks.MockDataRepository).EXPECT().
+					Delete(gomock.Any(), &model.Data{ID: 10, UserID: 1}).
+					Return(nil).
+					Times(1)
+
+				server.repouser.(*mocks.MockUserRepository).EXPECT().
+					SetLastUpdate(gomock.Any(), gomock.Any()).
+					Return(&model.User{}, nil).
+					Times(1)
+			},
+			wantCode: codes.OK,
+			wantResp: &pbservice.UploadStatus{
+				Success: true,
+				Message: "data was deleted",
+			},
+		},
+		{
+			name:  "NotFound",
+			input: &pbservice.DeleteDataRequest{Dataid: 11},
+			mockSetup: func() {
+				server.repodata.(*mocks.MockDataRepository).EXPECT().
+					Delete(gomock.Any(), &model.Data{ID: 11, UserID: 1}).
+					Return(model.ErrPdataNotFound).
+					Times(1)
+			},
+			wantCode: codes.NotFound,
+		},
+		{
+			name:  "DeleteError",
+			input: &pbservice.DeleteDataRequest{Dataid: 10},
+			mockSetup: func() {
+				server.repodata.(*mocks.MockDataRepository).EXPECT().
+					Delete(gomock.Any(), gomock.Any()).
+					Return(fmt.Errorf("db error")).
+					Times(1)
+			},
+			wantCode: codes.Internal,
+		},
+		{
+			name:  "SetLastUpdateError",
+			input: &pbservice.DeleteDataRequest{Dataid: 10},
+			mockSetup: func() {
+				server.repodata.(*mocks.MockDataRepository).EXPECT().
+					Delete(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+
+				server.repouser.(*mocks.MockUserRepository).EXPECT().
+					SetLastUpdate(gomock.Any(), gomock.Any()).
+					Return(nil, fmt.Errorf("update error")).
+					Times(1)
+			},
+			wantCode: codes.Internal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			gotResp, err := server.DeleteData(ctx, tt.input)
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			if tt.wantResp != nil {
+				assert.Equal(t, tt.wantResp.Success, gotResp.Success)
+				assert.Equal(t, tt.wantResp.Message, gotResp.Message)
+			}
+		})
+	}
+}
+
+func TestGetPType(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected pbservice.DataType
+	}{
+		{
+			name:     "DataTypeCARD",
+			input:    repository.DataTypeCARD,
+			expected: pbservice.DataType_DATA_TYPE_TYPE_CREDIT_CARD,
+		},
+		{
+			name:     "DataTypeLOGPASS",
+			input:    repository.DataTypeLOGPASS,
+			expected: pbservice.DataType_DATA_TYPE_TYPE_LOGIN_PASSWORD,
+		},
+		{
+			name:     "DataTypeTEXT",
+			input:    repository.DataTypeTEXT,
+			expected: pbservice.DataType_DATA_TYPE_UNSPECIFIED,
+		},
+		{
+			name:     "DataTypeBINARY",
+			input:    repository.DataTypeBINARY,
+			expected: pbservice.DataType_DATA_TYPE_TYPE_BINARY,
+		},
+		{
+			name:     "UnknownType",
+			input:    "unknown",
+			expected: pbservice.DataType_DATA_TYPE_UNSPECIFIED,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := getPType(tt.input)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestGetType(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    pbservice.DataType
+		expected string
+	}{
+		{
+			name:     "DataTypeCARD",
+			input:    pbservice.DataType_DATA_TYPE_TYPE_CREDIT_CARD,
+			expected: repository.DataTypeCARD,
+		},
 		{
 			name:     "DataTypeLOGPASS",
 			input:    pbservice.DataType_DATA_TYPE_TYPE_LOGIN_PASSWORD,
 			expected: repository.DataTypeLOGPASS,
 		},
+		{
+			name:     "BinaryType",
+			input:    pbservice.DataType_DATA_TYPE_TYPE_BINARY,
+			expected: repository.DataTypeBINARY,
+		},
 		{
 			name:     "UnspecifiedType",
 			input:    pbservice.DataType_DATA_TYPE_UNSPECIFIED,
-			expected: "",
+			expected: repository.DataTypeTEXT,
 		},
 	}
 
@@ -688,6 +1552,105 @@ func TestGetType(t *testing.T) {
 	}
 }
 
+// fileChunkStream - поток UploadFile с заданными чанками, recvErr возвращается после них вместо io.EOF
+type fileChunkStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	chunks  []*pbservice.FileChunk
+	recvErr error
+	resp    *pbservice.UploadStatus
+}
+
+func (c *fileChunkStream) Context() context.Context { return c.ctx }
+
+func (c *fileChunkStream) Recv() (*pbservice.FileChunk, error) {
+	if len(c.chunks) == 0 {
+		if c.recvErr != nil {
+			return nil, c.recvErr
+		}
+		return nil, io.EOF
+	}
+	chunk := c.chunks[0]
+	c.chunks = c.chunks[1:]
+	return chunk, nil
+}
+
+func (c *fileChunkStream) SendAndClose(resp *pbservice.UploadStatus) error {
+	c.resp = resp
+	return nil
+}
+
+func TestGRPCServer_UploadFile(t *testing.T) {
+	server := createTestMockServer(t)
+	ctx := jwtrule.SetUserIDToCTX(context.Background(), 1)
+	events, unsubscribe := server.changes.Subscribe(1)
+	defer unsubscribe()
+	readAll := func(_ context.Context, _ *model.User, _ *model.FileItem, r io.Reader) error {
+		_, err := io.ReadAll(r)
+		return err
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		sctx := jwtrule.SetSessionIDToCTX(ctx, "s2")
+		stream := &fileChunkStream{ctx: sctx, chunks: []*pbservice.FileChunk{
+			{Filename: "a.txt", Data: []byte("part1 ")},
+			{Data: []byte("part2")},
+		}}
+		server.reposession.(*mocks.MockSessionRepository).EXPECT().
+			Get(gomock.Any(), &model.Session{ID: "s2", UserID: 1}).
+			Return(&model.Session{ID: "s2", UserID: 1, DeviceInfo: "phone"}, nil)
+		server.reposervice.(*mocks.MockFileRepository).EXPECT().
+			UploadFile(gomock.Any(), &model.User{ID: 1}, &model.FileItem{Name: "a.txt", Size: -1, Device: "phone"}, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ *model.User, _ *model.FileItem, r io.Reader) error {
+				got, err := io.ReadAll(r)
+				assert.NoError(t, err)
+				assert.Equal(t, "part1 part2", string(got))
+				return nil
+			})
+		server.reposync.(*mocks.MockSyncRepository).EXPECT().TouchFile(gomock.Any(), int64(1), "a.txt").Return(int64(3), nil)
+		server.repouser.(*mocks.MockUserRepository).EXPECT().SetLastUpdate(gomock.Any(), gomock.Any()).Return(&model.User{}, nil)
+
+		assert.NoError(t, server.UploadFile(stream))
+		assert.True(t, stream.resp.Success)
+		assert.Equal(t, model.ChangeEvent{Op: model.ChangeCreated, FileName: "a.txt"}, <-events)
+	})
+
+	t.Run("StreamBroken", func(t *testing.T) {
+		stream := &fileChunkStream{
+			ctx:     ctx,
+			chunks:  []*pbservice.FileChunk{{Filename: "a.txt", Data: []byte("part1")}},
+			recvErr: errors.New("connection lost"),
+		}
+		server.reposervice.(*mocks.MockFileRepository).EXPECT().
+			UploadFile(gomock.Any(), gomock.Any(), &model.FileItem{Name: "a.txt", Size: -1}, gomock.Any()).
+			DoAndReturn(readAll)
+
+		err := server.UploadFile(stream)
+		assert.ErrorContains(t, err, "connection lost")
+		assert.Nil(t, stream.resp)
+	})
+
+	t.Run("StorageError", func(t *testing.T) {
+		stream := &fileChunkStream{ctx: ctx, chunks: []*pbservice.FileChunk{
+			{Filename: "a.txt", Data: []byte("part1")},
+			{Data: []byte("part2")},
+		}}
+		// хранилище не дочитывает поток, чтение чанков должно остановиться
+		server.reposervice.(*mocks.MockFileRepository).EXPECT().
+			UploadFile(gomock.Any(), gomock.Any(), &model.FileItem{Name: "a.txt", Size: -1}, gomock.Any()).
+			Return(errors.New("minio error"))
+
+		assert.ErrorContains(t, server.UploadFile(stream), "minio error")
+	})
+
+	t.Run("InvalidName", func(t *testing.T) {
+		err := server.UploadFile(&fileChunkStream{ctx: ctx, chunks: []*pbservice.FileChunk{{Filename: ".trash/a.txt"}}})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		err = server.UploadFile(&fileChunkStream{ctx: ctx})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
 func TestGRPCServer_GetFile(t *testing.T) {
 	server := createTestMockServer(t)
 
@@ -739,6 +1702,24 @@ func TestGRPCServer_GetFile(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name:      "TrashName",
+			input:     &pbservice.GetFileRequest{Name: model.TrashPrefix + "file1"},
+			mockSetup: func() {},
+			wantErr:   true,
+		},
+		{
+			name:  "Version Not Found",
+			input: &pbservice.GetFileRequest{Name: "file1", VersionId: "v0"},
+			mockSetup: func() {
+				mockRepoFile := server.reposervice.(*mocks.MockFileRepository)
+				mockRepoFile.EXPECT().
+					GetFileVersion(gomock.Any(), "file1", "v0", &model.User{ID: 1}).
+					Return(nil, model.ErrFileVersionMissing).
+					Times(1)
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -748,10 +1729,17 @@ func TestGRPCServer_GetFile(t *testing.T) {
 			defer ctrlub.Finish()
 
 			stream := pbservice.NewMockDataKeeperService_GetFileServer(ctrlub)
+			// при неверном имени до репозитория не доходит
 			stream.EXPECT().
 				Context().
 				Return(ctx).
-				Times(2)
+				MinTimes(1).
+				MaxTimes(2)
+			// файл отдается с начала, даже если его позиция сдвинута
+			stream.EXPECT().
+				Send(&pbservice.FileChunk{Data: []byte("file content"), Filename: "file1"}).
+				Return(nil).
+				MaxTimes(1)
 
 			err := server.GetFile(tt.input, stream)
 			if (err != nil) != tt.wantErr {
@@ -760,3 +1748,311 @@ func TestGRPCServer_GetFile(t *testing.T) {
 		})
 	}
 }
+
+func TestGRPCServer_Sync(t *testing.T) {
+	server := createTestMockServer(t)
+
+	ctx := context.Background()
+	ctx = jwtrule.SetUserIDToCTX(ctx, 1)
+
+	t.Run("Success", func(t *testing.T) {
+		server.reposync.(*mocks.MockSyncRepository).EXPECT().
+			Changes(gomock.Any(), int64(1), int64(4), model.DefaultPageSize).
+			Return(&model.SyncChanges{
+				Revision: 9,
+				HasMore:  true,
+				Data: []model.Data{
+					{ID: 10, Type: repository.DataTypeTEXT, Title: "note", Revision: 5},
+					{ID: 11, Revision: 6, Deleted: true},
+				},
+				Files: []model.FileItem{
+					{Name: "a.txt", Revision: 7},
+					{Name: "b.txt", Revision: 9, Deleted: true},
+				},
+			}, nil).
+			Times(1)
+		server.repometa.(*mocks.MockItemMetaRepository).EXPECT().
+			List(gomock.Any(), int64(1), model.MetaKindData).
+			Return(map[string]model.ItemMeta{"10": {Tags: []string{"work"}}}, nil).
+			Times(1)
+		server.repometa.(*mocks.MockItemMetaRepository).EXPECT().
+			List(gomock.Any(), int64(1), model.MetaKindFile).
+			Return(map[string]model.ItemMeta{}, nil).
+			Times(1)
+
+		resp, err := server.Sync(ctx, &pbservice.SyncRequest{SinceRevision: 4})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(9), resp.Revision)
+		assert.True(t, resp.HasMore)
+		assert.Len(t, resp.Data, 1)
+		assert.Equal(t, int64(10), resp.Data[0].Id)
+		assert.Equal(t, int64(5), resp.Data[0].Revision)
+		assert.Equal(t, []string{"work"}, resp.Data[0].Tags)
+		assert.Equal(t, []int64{11}, resp.DeletedData)
+		assert.Len(t, resp.Files, 1)
+		assert.Equal(t, "a.txt", resp.Files[0].Name)
+		assert.Equal(t, []string{"b.txt"}, resp.DeletedFiles)
+	})
+
+	t.Run("NoChanges", func(t *testing.T) {
+		server.reposync.(*mocks.MockSyncRepository).EXPECT().
+			Changes(gomock.Any(), int64(1), int64(9), 10).
+			Return(&model.SyncChanges{Revision: 9}, nil).
+			Times(1)
+
+		resp, err := server.Sync(ctx, &pbservice.SyncRequest{SinceRevision: 9, PageSize: 10})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(9), resp.Revision)
+		assert.Empty(t, resp.Data)
+	})
+
+	t.Run("RepoError", func(t *testing.T) {
+		server.reposync.(*mocks.MockSyncRepository).EXPECT().
+			Changes(gomock.Any(), int64(1), int64(0), model.DefaultPageSize).
+			Return(nil, fmt.Errorf("db error")).
+			Times(1)
+
+		_, err := server.Sync(ctx, &pbservice.SyncRequest{})
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+}
+
+func TestGRPCServer_WatchChanges(t *testing.T) {
+	server := createTestMockServer(t)
+
+	t.Run("Events", func(t *testing.T) {
+		ctrlub := gomockuber.NewController(t)
+		defer ctrlub.Finish()
+
+		ctx, cancel := context.WithCancel(jwtrule.SetUserIDToCTX(context.Background(), 1))
+		defer cancel()
+
+		got := make(chan *pbservice.ChangeEvent, 100)
+		stream := pbservice.NewMockDataKeeperService_WatchChangesServer(ctrlub)
+		stream.EXPECT().Context().Return(ctx).AnyTimes()
+		stream.EXPECT().Send(gomockuber.Any()).DoAndReturn(func(ev *pbservice.ChangeEvent) error {
+			got <- ev
+			cancel()
+			return nil
+		}).AnyTimes()
+
+		done := make(chan error, 1)
+		go func() {
+			done <- server.WatchChanges(&pbservice.WatchChangesRequest{}, stream)
+		}()
+
+		// подписка появляется асинхронно, события до нее не доходят
+		assert.Eventually(t, func() bool {
+			server.changes.Publish(2, model.ChangeEvent{Op: model.ChangeCreated, DataID: 1})
+			server.changes.Publish(1, model.ChangeEvent{Op: model.ChangeDeleted, FileName: "a.txt"})
+			return len(got) > 0
+		}, time.Second, 10*time.Millisecond)
+
+		assert.NoError(t, <-done)
+		ev := <-got
+		assert.Equal(t, pbservice.ChangeOp_CHANGE_OP_DELETED, ev.Op)
+		assert.Equal(t, "a.txt", ev.FileName)
+	})
+
+	t.Run("Closed", func(t *testing.T) {
+		ctrlub := gomockuber.NewController(t)
+		defer ctrlub.Finish()
+
+		stream := pbservice.NewMockDataKeeperService_WatchChangesServer(ctrlub)
+		stream.EXPECT().Context().Return(jwtrule.SetUserIDToCTX(context.Background(), 1)).AnyTimes()
+
+		server.changes.Close()
+		err := server.WatchChanges(&pbservice.WatchChangesRequest{}, stream)
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+	})
+}
+
+func TestGRPCServer_DeleteData_PublishesChange(t *testing.T) {
+	server := createTestMockServer(t)
+	ctx := jwtrule.SetUserIDToCTX(context.Background(), 1)
+
+	events, unsubscribe := server.changes.Subscribe(1)
+	defer unsubscribe()
+
+	server.repodata.(*mocks.MockDataRepository).EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil)
+	server.repouser.(*mocks.MockUserRepository).EXPECT().SetLastUpdate(gomock.Any(), gomock.Any()).Return(&model.User{ID: 1}, nil)
+
+	_, err := server.DeleteData(ctx, &pbservice.DeleteDataRequest{Dataid: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, model.ChangeEvent{Op: model.ChangeDeleted, DataID: 10}, <-events)
+}
+
+func TestGRPCServer_UpdateData_Conflict(t *testing.T) {
+	server := createTestMockServer(t)
+	ctx := jwtrule.SetUserIDToCTX(context.Background(), 1)
+
+	server.repouser.(*mocks.MockUserRepository).EXPECT().BindKeyHash(gomock.Any(), gomock.Any()).Return(nil)
+	server.repodata.(*mocks.MockDataRepository).EXPECT().
+		Update(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, data *model.Data) error {
+			assert.Equal(t, int64(7), data.Revision, "expected version is passed to the repository")
+			return model.ErrVersionConflict
+		})
+	server.repodata.(*mocks.MockDataRepository).EXPECT().
+		Get(gomock.Any(), &model.Data{ID: 10, UserID: 1}).
+		Return(&model.Data{ID: 10, UserID: 1, Title: "server copy", Revision: 9}, nil)
+	server.repometa.(*mocks.MockItemMetaRepository).EXPECT().
+		Get(gomock.Any(), gomock.Any()).
+		Return(&model.ItemMeta{Tags: []string{"work"}}, nil)
+
+	_, err := server.UpdateData(ctx, &pbservice.UpdateDataRequest{
+		Data:             &pbservice.Data{Id: 10, Title: "my copy", KeyHash: "hash"},
+		ExpectedRevision: 7,
+	})
+	st := status.Convert(err)
+	assert.Equal(t, codes.Aborted, st.Code())
+	if assert.Len(t, st.Details(), 1) {
+		current := st.Details()[0].(*pbservice.Data)
+		assert.Equal(t, "server copy", current.Title)
+		assert.Equal(t, int64(9), current.Revision)
+		assert.Equal(t, []string{"work"}, current.Tags)
+	}
+}
+
+func TestGRPCServer_DeleteFile_Conflict(t *testing.T) {
+	server := createTestMockServer(t)
+	ctx := jwtrule.SetUserIDToCTX(context.Background(), 1)
+
+	server.reposync.(*mocks.MockSyncRepository).EXPECT().
+		FileRevision(gomock.Any(), int64(1), "a.txt").
+		Return(int64(5), nil)
+	server.repometa.(*mocks.MockItemMetaRepository).EXPECT().
+		Get(gomock.Any(), &model.ItemMeta{UserID: 1, Kind: model.MetaKindFile, ItemID: "a.txt"}).
+		Return(&model.ItemMeta{}, nil)
+
+	_, err := server.DeleteFile(ctx, &pbservice.DeleteFileRequest{Filename: "a.txt", ExpectedRevision: proto.Int64(4)})
+	st := status.Convert(err)
+	assert.Equal(t, codes.Aborted, st.Code())
+	if assert.Len(t, st.Details(), 1) {
+		current := st.Details()[0].(*pbservice.FileItem)
+		assert.Equal(t, "a.txt", current.Name)
+		assert.Equal(t, int64(5), current.Revision)
+	}
+
+	// 0 - версия файла, загруженного до учета версий, а не отсутствие проверки
+	server.reposync.(*mocks.MockSyncRepository).EXPECT().
+		FileRevision(gomock.Any(), int64(1), "a.txt").
+		Return(int64(5), nil)
+	server.repometa.(*mocks.MockItemMetaRepository).EXPECT().
+		Get(gomock.Any(), gomock.Any()).
+		Return(&model.ItemMeta{}, nil)
+
+	_, err = server.DeleteFile(ctx, &pbservice.DeleteFileRequest{Filename: "a.txt", ExpectedRevision: proto.Int64(0)})
+	assert.Equal(t, codes.Aborted, status.Code(err))
+}
+
+func TestGRPCServer_ListDataHistory(t *testing.T) {
+	server := createTestMockServer(t)
+	ctx := jwtrule.SetUserIDToCTX(context.Background(), 1)
+	changed := time.Unix(1714557600, 0)
+
+	server.repodata.(*mocks.MockDataRepository).EXPECT().
+		History(gomock.Any(), &model.Data{ID: 10, UserID: 1}).
+		Return([]model.DataRevision{
+			{Data: model.Data{ID: 10, Type: repository.DataTypeLOGPASS, Password: "old", Revision: 4}, ChangedAt: changed},
+		}, nil)
+
+	res, err := server.ListDataHistory(ctx, &pbservice.ListDataHistoryRequest{Dataid: 10})
+	assert.NoError(t, err)
+	if assert.Len(t, res.Revisions, 1) {
+		assert.Equal(t, "old", res.Revisions[0].Data.Password)
+		assert.Equal(t, int64(4), res.Revisions[0].Data.Revision)
+		assert.Equal(t, pbservice.DataType_DATA_TYPE_TYPE_LOGIN_PASSWORD, res.Revisions[0].Data.Type)
+		assert.Equal(t, changed.Unix(), res.Revisions[0].ChangedAt)
+	}
+
+	server.repodata.(*mocks.MockDataRepository).EXPECT().History(gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+	_, err = server.ListDataHistory(ctx, &pbservice.ListDataHistoryRequest{Dataid: 10})
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
+
+func TestGRPCServer_RestoreDataRevision(t *testing.T) {
+	server := createTestMockServer(t)
+	ctx := jwtrule.SetUserIDToCTX(context.Background(), 1)
+	events, unsubscribe := server.changes.Subscribe(1)
+	defer unsubscribe()
+
+	server.repodata.(*mocks.MockDataRepository).EXPECT().
+		Restore(gomock.Any(), &model.Data{ID: 10, UserID: 1, Revision: 6}, int64(4)).
+		DoAndReturn(func(_ context.Context, data *model.Data, _ int64) error {
+			data.Revision = 7
+			return nil
+		})
+	server.repouser.(*mocks.MockUserRepository).EXPECT().SetLastUpdate(gomock.Any(), gomock.Any()).Return(&model.User{}, nil)
+
+	res, err := server.RestoreDataRevision(ctx, &pbservice.RestoreDataRevisionRequest{Dataid: 10, Revision: 4, ExpectedRevision: 6})
+	assert.NoError(t, err)
+	assert.True(t, res.Success)
+	assert.Equal(t, model.ChangeEvent{Op: model.ChangeUpdated, DataID: 10}, <-events)
+
+	server.repodata.(*mocks.MockDataRepository).EXPECT().Restore(gomock.Any(), gomock.Any(), int64(3)).Return(model.ErrRevisionNotFound)
+	_, err = server.RestoreDataRevision(ctx, &pbservice.RestoreDataRevisionRequest{Dataid: 10, Revision: 3})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestGRPCServer_ListFileVersions(t *testing.T) {
+	server := createTestMockServer(t)
+	ctx := jwtrule.SetUserIDToCTX(context.Background(), 1)
+	modified := time.Unix(1714557600, 0)
+
+	server.reposervice.(*mocks.MockFileRepository).EXPECT().
+		ListFileVersions(gomock.Any(), "a.txt", &model.User{ID: 1}).
+		Return([]model.FileVersion{
+			{VersionID: "v2", Hash: "e2", Size: 20, ModifiedAt: modified, Latest: true},
+			{VersionID: "v1", Hash: "e1", Size: 10},
+		}, nil)
+
+	res, err := server.ListFileVersions(ctx, &pbservice.ListFileVersionsRequest{Filename: "a.txt"})
+	assert.NoError(t, err)
+	if assert.Len(t, res.Versions, 2) {
+		assert.Equal(t, "v2", res.Versions[0].VersionId)
+		assert.True(t, res.Versions[0].Latest)
+		assert.Equal(t, modified.Unix(), res.Versions[0].ModifiedAt)
+		assert.Equal(t, int64(10), res.Versions[1].Size)
+	}
+
+	_, err = server.ListFileVersions(ctx, &pbservice.ListFileVersionsRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = server.ListFileVersions(ctx, &pbservice.ListFileVersionsRequest{Filename: model.TrashPrefix + "a.txt"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	server.reposervice.(*mocks.MockFileRepository).EXPECT().
+		ListFileVersions(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("minio error"))
+	_, err = server.ListFileVersions(ctx, &pbservice.ListFileVersionsRequest{Filename: "a.txt"})
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
+
+func TestGRPCServer_RestoreFileVersion(t *testing.T) {
+	server := createTestMockServer(t)
+	ctx := jwtrule.SetUserIDToCTX(context.Background(), 1)
+	events, unsubscribe := server.changes.Subscribe(1)
+	defer unsubscribe()
+
+	server.reposync.(*mocks.MockSyncRepository).EXPECT().FileRevision(gomock.Any(), int64(1), "a.txt").Return(int64(5), nil)
+	server.reposervice.(*mocks.MockFileRepository).EXPECT().
+		RestoreFileVersion(gomock.Any(), "a.txt", "v1", &model.User{ID: 1}).
+		Return(nil)
+	server.reposync.(*mocks.MockSyncRepository).EXPECT().TouchFile(gomock.Any(), int64(1), "a.txt").Return(int64(6), nil)
+
+	res, err := server.RestoreFileVersion(ctx, &pbservice.RestoreFileVersionRequest{Filename: "a.txt", VersionId: "v1", ExpectedRevision: proto.Int64(5)})
+	assert.NoError(t, err)
+	assert.True(t, res.Success)
+	assert.Equal(t, model.ChangeEvent{Op: model.ChangeUpdated, FileName: "a.txt"}, <-events)
+
+	server.reposervice.(*mocks.MockFileRepository).EXPECT().
+		RestoreFileVersion(gomock.Any(), "a.txt", "v0", gomock.Any()).
+		Return(model.ErrFileVersionMissing)
+	_, err = server.RestoreFileVersion(ctx, &pbservice.RestoreFileVersionRequest{Filename: "a.txt", VersionId: "v0"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = server.RestoreFileVersion(ctx, &pbservice.RestoreFileVersionRequest{Filename: "a.txt"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = server.RestoreFileVersion(ctx, &pbservice.RestoreFileVersionRequest{Filename: model.TrashPrefix + "a.txt", VersionId: "v1"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,182 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	pbuser "github.com/Arcadian-Sky/datakkeeper/gen/proto/api/user/v1"
+	"github.com/Arcadian-Sky/datakkeeper/internal/model"
+	"github.com/Arcadian-Sky/datakkeeper/internal/server/router/jwtrule"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// openSession creates a session for the device and issues auth and refresh tokens for it
+func (s *GRPCServer) openSession(ctx context.Context, userID int64, device string) (*model.Session, model.Jtoken, string, error) {
+	sid, err := jwtrule.NewSessionID()
+	if err != nil {
+		return nil, model.Jtoken{}, "", err
+	}
+	refresh, hash, err := jwtrule.NewRefreshToken()
+	if err != nil {
+		return nil, model.Jtoken{}, "", err
+	}
+
+	session := &model.Session{
+		ID:          sid,
+		UserID:      userID,
+		RefreshHash: hash,
+		DeviceInfo:  device,
+		ExpiresAt:   time.Now().Add(jwtrule.RefreshTTL),
+	}
+	if err := s.reposession.Create(ctx, session); err != nil {
+		return nil, model.Jtoken{}, "", err
+	}
+
+	token, err := jwtrule.GenerateForSession(userID, sid, s.keys)
+	if err != nil {
+		return nil, model.Jtoken{}, "", err
+	}
+
+	return session, token, refresh, nil
+}
+
+// Создание нового сеанса для уже аутентифицированного пользователя.
+func (s *GRPCServer) CreateSession(ctx context.Context, in *pbuser.CreateSessionRequest) (*pbuser.CreateSessionResponse, error) {
+	uID := jwtrule.GetUserIDFromCTX(ctx)
+	s.log.Trace("uID: ", uID)
+
+	session, userJWT, refresh, err := s.openSession(ctx, uID, in.DeviceInfo)
+	if err != nil {
+		e := fmt.Sprintf("failed to create session: %v", err)
+		s.log.Info(e)
+		return nil, status.Error(codes.Internal, e)
+	}
+
+	return &pbuser.CreateSessionResponse{
+		Success:      true,
+		SessionId:    session.ID,
+		Message:      "session was created",
+		AuthToken:    userJWT.Token,
+		RefreshToken: refresh,
+	}, nil
+}
+
+// Обновление токена. Refresh-токен одноразовый: в ответе выдается новый.
+func (s *GRPCServer) RefreshSession(ctx context.Context, in *pbuser.RefreshSessionRequest) (*pbuser.RefreshSessionResponse, error) {
+	if in.RefreshToken == `` {
+		return nil, status.Error(codes.InvalidArgument, "refresh token is not set")
+	}
+
+	oldHash := jwtrule.HashRefreshToken(in.RefreshToken)
+	session, err := s.reposession.GetByRefresh(ctx, oldHash)
+	if err != nil {
+		if errors.Is(err, model.ErrSessionRevoked) {
+			s.revokeReused(ctx, oldHash)
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+		e := fmt.Sprintf("failed to get session: %v", err)
+		s.log.Info(e)
+		return nil, status.Error(codes.Internal, e)
+	}
+
+	refresh, hash, err := jwtrule.NewRefreshToken()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	session.RefreshHash = hash
+	session.ExpiresAt = time.Now().Add(jwtrule.RefreshTTL)
+
+	err = s.reposession.Refresh(ctx, session, oldHash)
+	if err != nil {
+		if errors.Is(err, model.ErrSessionRevoked) {
+			// тот же токен обменяли параллельно: одна из сторон его украла
+			s.revokeReused(ctx, oldHash)
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+		e := fmt.Sprintf("failed to refresh session: %v", err)
+		s.log.Info(e)
+		return nil, status.Error(codes.Internal, e)
+	}
+
+	userJWT, err := jwtrule.GenerateForSession(session.UserID, session.ID, s.keys)
+	if err != nil {
+		e := fmt.Sprintf("cant generate token: %s", err.Error())
+		s.log.Info(e)
+		return nil, status.Error(codes.Internal, e)
+	}
+
+	return &pbuser.RefreshSessionResponse{
+		Success:      true,
+		AuthToken:    userJWT.Token,
+		RefreshToken: refresh,
+		Message:      "session was refreshed",
+	}, nil
+}
+
+// revokeReused ends the session of the refresh token that was already exchanged: its reuse means it was stolen
+func (s *GRPCServer) revokeReused(ctx context.Context, refreshHash string) {
+	reused, err := s.reposession.RevokeReused(ctx, refreshHash)
+	if err != nil {
+		s.log.WithError(err).Error("failed to revoke session of reused refresh token")
+		return
+	}
+	if reused {
+		s.log.Warn("reused refresh token, session is revoked")
+	}
+}
+
+// Список активных сеансов пользователя.
+func (s *GRPCServer) ListSessions(ctx context.Context, in *pbuser.ListSessionsRequest) (*pbuser.ListSessionsResponse, error) {
+	uID := jwtrule.GetUserIDFromCTX(ctx)
+	s.log.Trace("uID: ", uID)
+	current := jwtrule.GetSessionIDFromCTX(ctx)
+
+	sessions, err := s.reposession.List(ctx, &model.User{ID: uID})
+	if err != nil {
+		e := fmt.Sprintf("failed to list sessions: %v", err)
+		s.log.Info(e)
+		return nil, status.Error(codes.Internal, e)
+	}
+
+	var resp []*pbuser.Session
+	for _, it := range sessions {
+		resp = append(resp, &pbuser.Session{
+			SessionId:  it.ID,
+			DeviceInfo: it.DeviceInfo,
+			CreatedAt:  it.CreatedAt.Unix(),
+			ExpiresAt:  it.ExpiresAt.Unix(),
+			Current:    it.ID == current,
+		})
+	}
+
+	return &pbuser.ListSessionsResponse{Sessions: resp}, nil
+}
+
+// Завершение сеанса. Без session_id завершается текущий сеанс (logout).
+func (s *GRPCServer) EndSession(ctx context.Context, in *pbuser.EndSessionRequest) (*pbuser.EndSessionResponse, error) {
+	uID := jwtrule.GetUserIDFromCTX(ctx)
+	s.log.Trace("uID: ", uID)
+
+	sid := in.SessionId
+	if sid == `` {
+		sid = jwtrule.GetSessionIDFromCTX(ctx)
+	}
+	if sid == `` {
+		return nil, status.Error(codes.InvalidArgument, "session is not set")
+	}
+
+	err := s.reposession.Revoke(ctx, &model.Session{ID: sid, UserID: uID})
+	if err != nil {
+		if errors.Is(err, model.ErrSessionNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		e := fmt.Sprintf("failed to end session: %v", err)
+		s.log.Info(e)
+		return nil, status.Error(codes.Internal, e)
+	}
+
+	return &pbuser.EndSessionResponse{Success: true, Message: "session was ended"}, nil
+}
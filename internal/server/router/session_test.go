@@ -0,0 +1,234 @@
+package router
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	pbuser "github.com/Arcadian-Sky/datakkeeper/gen/proto/api/user/v1"
+	"github.com/Arcadian-Sky/datakkeeper/internal/model"
+	"github.com/Arcadian-Sky/datakkeeper/internal/server/router/jwtrule"
+	"github.com/Arcadian-Sky/datakkeeper/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGRPCServer_CreateSession(t *testing.T) {
+	server := createTestMockServer(t)
+	ctx := jwtrule.SetUserIDToCTX(context.Background(), 1)
+
+	server.reposession.(*mocks.MockSessionRepository).EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, ss *model.Session) error {
+			assert.Equal(t, int64(1), ss.UserID)
+			assert.Equal(t, "phone", ss.DeviceInfo)
+			return nil
+		}).
+		Times(1)
+
+	resp, err := server.CreateSession(ctx, &pbuser.CreateSessionRequest{DeviceInfo: "phone"})
+	assert.NoError(t, err)
+	assert.True(t, resp.Success)
+	assert.NotEmpty(t, resp.SessionId)
+	assert.NotEmpty(t, resp.RefreshToken)
+
+	jtoken, err := jwtrule.ValidateWithKeyring(resp.AuthToken, server.keys)
+	assert.NoError(t, err)
+	assert.Equal(t, resp.SessionId, jtoken.Claims.SessionID)
+
+	server.reposession.(*mocks.MockSessionRepository).EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		Return(fmt.Errorf("db error")).
+		Times(1)
+
+	_, err = server.CreateSession(ctx, &pbuser.CreateSessionRequest{})
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
+
+func TestGRPCServer_RefreshSession(t *testing.T) {
+	server := createTestMockServer(t)
+	repo := server.reposession.(*mocks.MockSessionRepository)
+
+	refresh, hash, err := jwtrule.NewRefreshToken()
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		input     *pbuser.RefreshSessionRequest
+		mockSetup func()
+		wantCode  codes.Code
+	}{
+		{
+			name:  "Success",
+			input: &pbuser.RefreshSessionRequest{RefreshToken: refresh},
+			mockSetup: func() {
+				repo.EXPECT().GetByRefresh(gomock.Any(), hash).
+					Return(&model.Session{ID: "sid1", UserID: 1, RefreshHash: hash}, nil)
+				repo.EXPECT().Refresh(gomock.Any(), gomock.Any(), hash).
+					DoAndReturn(func(_ context.Context, ss *model.Session, _ string) error {
+						assert.NotEqual(t, hash, ss.RefreshHash)
+						assert.True(t, ss.ExpiresAt.After(time.Now()))
+						return nil
+					})
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name:      "Empty",
+			input:     &pbuser.RefreshSessionRequest{},
+			mockSetup: func() {},
+			wantCode:  codes.InvalidArgument,
+		},
+		{
+			name:  "Revoked",
+			input: &pbuser.RefreshSessionRequest{RefreshToken: refresh},
+			mockSetup: func() {
+				repo.EXPECT().GetByRefresh(gomock.Any(), hash).Return(nil, model.ErrSessionRevoked)
+				repo.EXPECT().RevokeReused(gomock.Any(), hash).Return(false, nil)
+			},
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			// обмененный ранее токен завершает сеанс
+			name:  "Reused",
+			input: &pbuser.RefreshSessionRequest{RefreshToken: refresh},
+			mockSetup: func() {
+				repo.EXPECT().GetByRefresh(gomock.Any(), hash).Return(nil, model.ErrSessionRevoked)
+				repo.EXPECT().RevokeReused(gomock.Any(), hash).Return(true, nil)
+			},
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:  "ReusedConcurrently",
+			input: &pbuser.RefreshSessionRequest{RefreshToken: refresh},
+			mockSetup: func() {
+				repo.EXPECT().GetByRefresh(gomock.Any(), hash).
+					Return(&model.Session{ID: "sid1", UserID: 1, RefreshHash: hash}, nil)
+				repo.EXPECT().Refresh(gomock.Any(), gomock.Any(), hash).Return(model.ErrSessionRevoked)
+				repo.EXPECT().RevokeReused(gomock.Any(), hash).Return(true, nil)
+			},
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:  "DBError",
+			input: &pbuser.RefreshSessionRequest{RefreshToken: refresh},
+			mockSetup: func() {
+				repo.EXPECT().GetByRefresh(gomock.Any(), hash).Return(nil, fmt.Errorf("db error"))
+			},
+			wantCode: codes.Internal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			resp, err := server.RefreshSession(context.Background(), tt.input)
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			if tt.wantCode == codes.OK {
+				assert.NotEqual(t, refresh, resp.RefreshToken)
+				jtoken, err := jwtrule.ValidateWithKeyring(resp.AuthToken, server.keys)
+				assert.NoError(t, err)
+				assert.Equal(t, "sid1", jtoken.Claims.SessionID)
+				assert.Equal(t, int64(1), jtoken.Claims.UserID)
+			}
+		})
+	}
+}
+
+func TestGRPCServer_ListSessions(t *testing.T) {
+	server := createTestMockServer(t)
+	ctx := jwtrule.SetUserIDToCTX(context.Background(), 1)
+	ctx = jwtrule.SetSessionIDToCTX(ctx, "sid2")
+
+	created := time.Unix(1722506400, 0)
+	server.reposession.(*mocks.MockSessionRepository).EXPECT().
+		List(gomock.Any(), &model.User{ID: 1}).
+		Return([]model.Session{
+			{ID: "sid1", DeviceInfo: "laptop", CreatedAt: created, ExpiresAt: created.Add(time.Hour)},
+			{ID: "sid2", DeviceInfo: "phone", CreatedAt: created, ExpiresAt: created.Add(time.Hour)},
+		}, nil)
+
+	resp, err := server.ListSessions(ctx, &pbuser.ListSessionsRequest{})
+	assert.NoError(t, err)
+	assert.Len(t, resp.Sessions, 2)
+	assert.False(t, resp.Sessions[0].Current)
+	assert.True(t, resp.Sessions[1].Current)
+	assert.Equal(t, created.Unix(), resp.Sessions[1].CreatedAt)
+
+	server.reposession.(*mocks.MockSessionRepository).EXPECT().
+		List(gomock.Any(), gomock.Any()).
+		Return(nil, fmt.Errorf("db error"))
+
+	_, err = server.ListSessions(ctx, &pbuser.ListSessionsRequest{})
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
+
+func TestGRPCServer_EndSession(t *testing.T) {
+	server := createTestMockServer(t)
+	repo := server.reposession.(*mocks.MockSessionRepository)
+	ctx := jwtrule.SetUserIDToCTX(context.Background(), 1)
+	ctx = jwtrule.SetSessionIDToCTX(ctx, "current")
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		input     *pbuser.EndSessionRequest
+		mockSetup func()
+		wantCode  codes.Code
+	}{
+		{
+			name:  "OtherDevice",
+			ctx:   ctx,
+			input: &pbuser.EndSessionRequest{SessionId: "stolen"},
+			mockSetup: func() {
+				repo.EXPECT().Revoke(gomock.Any(), &model.Session{ID: "stolen", UserID: 1}).Return(nil)
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name:  "Logout",
+			ctx:   ctx,
+			input: &pbuser.EndSessionRequest{},
+			mockSetup: func() {
+				repo.EXPECT().Revoke(gomock.Any(), &model.Session{ID: "current", UserID: 1}).Return(nil)
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name:      "NoSession",
+			ctx:       jwtrule.SetUserIDToCTX(context.Background(), 1),
+			input:     &pbuser.EndSessionRequest{},
+			mockSetup: func() {},
+			wantCode:  codes.InvalidArgument,
+		},
+		{
+			name:  "NotFound",
+			ctx:   ctx,
+			input: &pbuser.EndSessionRequest{SessionId: "other-user"},
+			mockSetup: func() {
+				repo.EXPECT().Revoke(gomock.Any(), gomock.Any()).Return(model.ErrSessionNotFound)
+			},
+			wantCode: codes.NotFound,
+		},
+		{
+			name:  "DBError",
+			ctx:   ctx,
+			input: &pbuser.EndSessionRequest{SessionId: "stolen"},
+			mockSetup: func() {
+				repo.EXPECT().Revoke(gomock.Any(), gomock.Any()).Return(fmt.Errorf("db error"))
+			},
+			wantCode: codes.Internal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			_, err := server.EndSession(tt.ctx, tt.input)
+			assert.Equal(t, tt.wantCode, status.Code(err))
+		})
+	}
+}
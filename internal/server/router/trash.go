@@ -0,0 +1,136 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	pbservice "github.com/Arcadian-Sky/datakkeeper/gen/proto/api/service/v1"
+	"github.com/Arcadian-Sky/datakkeeper/internal/model"
+	"github.com/Arcadian-Sky/datakkeeper/internal/server/router/jwtrule"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ListTrash returns deleted records and files of the user that are not purged yet, recently deleted first
+func (s *GRPCServer) ListTrash(ctx context.Context, _ *pbservice.ListTrashRequest) (*pbservice.ListTrashResponse, error) {
+	uID := jwtrule.GetUserIDFromCTX(ctx)
+	s.log.Trace("uID: ", uID)
+
+	data, err := s.repodata.ListTrash(ctx, uID)
+	if err != nil {
+		e := fmt.Sprintf("failed to get pdata trash: %v", err)
+		s.log.Info(e)
+		return nil, status.Error(codes.Internal, e)
+	}
+	files, err := s.reposync.ListTrash(ctx, uID)
+	if err != nil {
+		e := fmt.Sprintf("failed to get files trash: %v", err)
+		s.log.Info(e)
+		return nil, status.Error(codes.Internal, e)
+	}
+	// метаданные удаленных хранятся до очистки корзины
+	dataMetas, err := s.repometa.List(ctx, uID, model.MetaKindData)
+	if err != nil {
+		e := fmt.Sprintf("failed to list pdata metadata: %s", err.Error())
+		return nil, status.Error(codes.Internal, e)
+	}
+	fileMetas, err := s.repometa.List(ctx, uID, model.MetaKindFile)
+	if err != nil {
+		e := fmt.Sprintf("failed to list files metadata: %s", err.Error())
+		return nil, status.Error(codes.Internal, e)
+	}
+
+	items := append(data, files...)
+	model.SortTrash(items)
+
+	resp := &pbservice.ListTrashResponse{Items: make([]*pbservice.TrashItem, 0, len(items))}
+	for _, it := range items {
+		item := &pbservice.TrashItem{
+			DeletedAt: it.DeletedAt.Unix(),
+			PurgeAt:   it.DeletedAt.Add(s.cfg.Trash.Retention).Unix(),
+		}
+		if it.Data != nil {
+			meta := dataMetas[strconv.FormatInt(it.Data.ID, 10)]
+			it.Data.Meta, it.Data.Tags = meta.Fields, meta.Tags
+			item.Data = dataToProto(*it.Data)
+		}
+		if it.File != nil {
+			meta := fileMetas[it.File.Name]
+			it.File.Desc, it.File.Meta, it.File.Tags = meta.Description, meta.Fields, meta.Tags
+			item.File = fileToProto(*it.File)
+		}
+		resp.Items = append(resp.Items, item)
+	}
+	return resp, nil
+}
+
+// RestoreFromTrash brings the record or the file back, it syncs to other devices as created
+func (s *GRPCServer) RestoreFromTrash(ctx context.Context, in *pbservice.RestoreFromTrashRequest) (*pbservice.UploadStatus, error) {
+	uID := jwtrule.GetUserIDFromCTX(ctx)
+	s.log.Trace("uID: ", uID)
+	user := &model.User{
+		ID: uID,
+	}
+
+	if err := validateRestoreFromTrash(in); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if in.Dataid != 0 {
+		err = s.restoreData(ctx, uID, in.Dataid)
+	} else {
+		err = s.restoreFile(ctx, user, in.Filename)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	//Update User
+	user.LastUpdate = time.Now()
+	_, err = s.repouser.SetLastUpdate(ctx, user)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to SetLastUpdate: "+err.Error())
+	}
+
+	return &pbservice.UploadStatus{Success: true, Message: "item was restored"}, nil
+}
+
+func (s *GRPCServer) restoreData(ctx context.Context, uID, id int64) error {
+	err := s.repodata.RestoreDeleted(ctx, &model.Data{ID: id, UserID: uID})
+	if err != nil {
+		if errors.Is(err, model.ErrTrashItemNotFound) {
+			return status.Error(codes.NotFound, err.Error())
+		}
+		e := fmt.Sprintf("failed to restore pdata: %v", err)
+		s.log.Info(e)
+		return status.Error(codes.Internal, e)
+	}
+	s.changes.Publish(uID, model.ChangeEvent{Op: model.ChangeCreated, DataID: id})
+	return nil
+}
+
+func (s *GRPCServer) restoreFile(ctx context.Context, user *model.User, name string) error {
+	if err := s.reposervice.RestoreFile(ctx, name, user); err != nil {
+		if errors.Is(err, model.ErrTrashItemNotFound) {
+			return status.Error(codes.NotFound, err.Error())
+		}
+		// файл с тем же именем загружен после удаления, восстановление его бы перезаписало
+		if errors.Is(err, model.ErrFileExists) {
+			return status.Error(codes.AlreadyExists, "file with the same name exists")
+		}
+		e := fmt.Sprintf("failed to restore file: %v", err)
+		s.log.Info(e)
+		return status.Error(codes.Internal, e)
+	}
+	if _, err := s.reposync.TouchFile(ctx, user.ID, name); err != nil {
+		e := fmt.Sprintf("failed to set file revision: %v", err)
+		s.log.Info(e)
+		return status.Error(codes.Internal, e)
+	}
+	s.changes.Publish(user.ID, model.ChangeEvent{Op: model.ChangeCreated, FileName: name})
+	return nil
+}
@@ -0,0 +1,113 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	pbservice "github.com/Arcadian-Sky/datakkeeper/gen/proto/api/service/v1"
+	"github.com/Arcadian-Sky/datakkeeper/internal/model"
+	"github.com/Arcadian-Sky/datakkeeper/internal/server/router/jwtrule"
+	"github.com/Arcadian-Sky/datakkeeper/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGRPCServer_ListTrash(t *testing.T) {
+	server := createTestMockServer(t)
+	server.cfg.Trash.Retention = 24 * time.Hour
+	ctx := jwtrule.SetUserIDToCTX(context.Background(), 1)
+	older := time.Unix(1714557600, 0)
+	newer := older.Add(time.Hour)
+
+	server.repodata.(*mocks.MockDataRepository).EXPECT().
+		ListTrash(gomock.Any(), int64(1)).
+		Return([]model.TrashItem{{Data: &model.Data{ID: 10, Title: "card", Deleted: true}, DeletedAt: older}}, nil)
+	server.reposync.(*mocks.MockSyncRepository).EXPECT().
+		ListTrash(gomock.Any(), int64(1)).
+		Return([]model.TrashItem{{File: &model.FileItem{Name: "a.txt", Revision: 5, Deleted: true}, DeletedAt: newer}}, nil)
+	server.repometa.(*mocks.MockItemMetaRepository).EXPECT().
+		List(gomock.Any(), int64(1), model.MetaKindData).
+		Return(map[string]model.ItemMeta{"10": {Tags: []string{"bank"}}}, nil)
+	server.repometa.(*mocks.MockItemMetaRepository).EXPECT().
+		List(gomock.Any(), int64(1), model.MetaKindFile).
+		Return(map[string]model.ItemMeta{}, nil)
+
+	res, err := server.ListTrash(ctx, &pbservice.ListTrashRequest{})
+	assert.NoError(t, err)
+	if assert.Len(t, res.Items, 2) {
+		assert.Equal(t, "a.txt", res.Items[0].File.Name)
+		assert.Equal(t, int64(5), res.Items[0].File.Revision)
+		assert.Equal(t, newer.Unix(), res.Items[0].DeletedAt)
+		assert.Equal(t, int64(10), res.Items[1].Data.Id)
+		assert.Equal(t, []string{"bank"}, res.Items[1].Data.Tags)
+		assert.Equal(t, older.Add(24*time.Hour).Unix(), res.Items[1].PurgeAt)
+	}
+
+	server.repodata.(*mocks.MockDataRepository).EXPECT().ListTrash(gomock.Any(), int64(1)).Return(nil, errors.New("db error"))
+	_, err = server.ListTrash(ctx, &pbservice.ListTrashRequest{})
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
+
+func TestGRPCServer_RestoreFromTrash(t *testing.T) {
+	server := createTestMockServer(t)
+	ctx := jwtrule.SetUserIDToCTX(context.Background(), 1)
+	events, unsubscribe := server.changes.Subscribe(1)
+	defer unsubscribe()
+
+	t.Run("Data", func(t *testing.T) {
+		server.repodata.(*mocks.MockDataRepository).EXPECT().
+			RestoreDeleted(gomock.Any(), &model.Data{ID: 10, UserID: 1}).
+			Return(nil)
+		server.repouser.(*mocks.MockUserRepository).EXPECT().SetLastUpdate(gomock.Any(), gomock.Any()).Return(&model.User{}, nil)
+
+		res, err := server.RestoreFromTrash(ctx, &pbservice.RestoreFromTrashRequest{Dataid: 10})
+		assert.NoError(t, err)
+		assert.True(t, res.Success)
+		assert.Equal(t, model.ChangeEvent{Op: model.ChangeCreated, DataID: 10}, <-events)
+	})
+
+	t.Run("DataNotFound", func(t *testing.T) {
+		server.repodata.(*mocks.MockDataRepository).EXPECT().
+			RestoreDeleted(gomock.Any(), gomock.Any()).
+			Return(model.ErrTrashItemNotFound)
+
+		_, err := server.RestoreFromTrash(ctx, &pbservice.RestoreFromTrashRequest{Dataid: 11})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("File", func(t *testing.T) {
+		server.reposervice.(*mocks.MockFileRepository).EXPECT().RestoreFile(gomock.Any(), "a.txt", &model.User{ID: 1}).Return(nil)
+		server.reposync.(*mocks.MockSyncRepository).EXPECT().TouchFile(gomock.Any(), int64(1), "a.txt").Return(int64(8), nil)
+		server.repouser.(*mocks.MockUserRepository).EXPECT().SetLastUpdate(gomock.Any(), gomock.Any()).Return(&model.User{}, nil)
+
+		_, err := server.RestoreFromTrash(ctx, &pbservice.RestoreFromTrashRequest{Filename: "a.txt"})
+		assert.NoError(t, err)
+		assert.Equal(t, model.ChangeEvent{Op: model.ChangeCreated, FileName: "a.txt"}, <-events)
+	})
+
+	t.Run("FileNameTaken", func(t *testing.T) {
+		// имя занято и файлом, загруженным до учета версий
+		server.reposervice.(*mocks.MockFileRepository).EXPECT().RestoreFile(gomock.Any(), "b.txt", gomock.Any()).Return(model.ErrFileExists)
+
+		_, err := server.RestoreFromTrash(ctx, &pbservice.RestoreFromTrashRequest{Filename: "b.txt"})
+		assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	})
+
+	t.Run("FileNotFound", func(t *testing.T) {
+		server.reposervice.(*mocks.MockFileRepository).EXPECT().RestoreFile(gomock.Any(), "c.txt", gomock.Any()).Return(model.ErrTrashItemNotFound)
+
+		_, err := server.RestoreFromTrash(ctx, &pbservice.RestoreFromTrashRequest{Filename: "c.txt"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("InvalidRequest", func(t *testing.T) {
+		_, err := server.RestoreFromTrash(ctx, &pbservice.RestoreFromTrashRequest{})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		_, err = server.RestoreFromTrash(ctx, &pbservice.RestoreFromTrashRequest{Dataid: 1, Filename: "a.txt"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
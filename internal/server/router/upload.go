@@ -0,0 +1,229 @@
+package router
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	pbservice "github.com/Arcadian-Sky/datakkeeper/gen/proto/api/service/v1"
+	"github.com/Arcadian-Sky/datakkeeper/internal/model"
+	"github.com/Arcadian-Sky/datakkeeper/internal/server/router/jwtrule"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// InitUpload starts the upload session or continues the one of the same file
+func (s *GRPCServer) InitUpload(ctx context.Context, in *pbservice.InitUploadRequest) (*pbservice.InitUploadResponse, error) {
+	uID := jwtrule.GetUserIDFromCTX(ctx)
+	s.log.Trace("uID: ", uID)
+
+	sha := strings.ToLower(in.Sha256)
+	if err := model.ValidateUpload(in.Filename, in.Size, sha); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	session := &model.UploadSession{UserID: uID, FileName: in.Filename, Size: in.Size, SHA256: sha}
+	if err := s.checkUploadLimits(ctx, session); err != nil {
+		return nil, err
+	}
+	id, err := jwtrule.NewSessionID()
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to generate upload id")
+	}
+
+	session.ID = id
+	if err := s.repoupload.Create(ctx, session); err != nil {
+		e := fmt.Sprintf("failed to create upload session: %v", err)
+		s.log.Info(e)
+		return nil, status.Error(codes.Internal, e)
+	}
+
+	return &pbservice.InitUploadResponse{UploadId: session.ID, Offset: session.Offset}, nil
+}
+
+// checkUploadLimits checks the number of unfinished uploads of the user and that the file fits the quota
+// together with them, otherwise parallel uploads could fill the disk of the server past the quota.
+// Проверка не атомарна с Create: параллельные InitUpload могут ненадолго превысить ограничения.
+func (s *GRPCServer) checkUploadLimits(ctx context.Context, session *model.UploadSession) error {
+	count, pending, err := s.repoupload.Pending(ctx, session)
+	if err != nil {
+		e := fmt.Sprintf("failed to count upload sessions: %v", err)
+		s.log.Info(e)
+		return status.Error(codes.Internal, e)
+	}
+	if limit := s.cfg.Upload.MaxSessions; limit > 0 && count >= limit {
+		return status.Error(codes.ResourceExhausted, model.ErrUploadLimit.Error())
+	}
+	_, err = s.checkFileQuota(ctx, session.UserID, session.FileName, session.Size+pending)
+	return err
+}
+
+// UploadChunk appends parts of the file, each part must start where the received content ends
+func (s *GRPCServer) UploadChunk(stream pbservice.DataKeeperService_UploadChunkServer) error {
+	ctx := stream.Context()
+	uID := jwtrule.GetUserIDFromCTX(ctx)
+	s.log.Trace("uID: ", uID)
+
+	var session *model.UploadSession
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to receive chunk: %w", err)
+		}
+
+		if session == nil {
+			if session, err = s.getUpload(ctx, uID, chunk.UploadId); err != nil {
+				return err
+			}
+		}
+		if chunk.UploadId != session.ID {
+			return status.Error(codes.InvalidArgument, "chunks of different uploads in one stream")
+		}
+
+		if err := s.repoupload.Append(ctx, session, chunk.Offset, chunk.Data); err != nil {
+			switch {
+			case errors.Is(err, model.ErrUploadOffset):
+				return status.Error(codes.FailedPrecondition, err.Error())
+			case errors.Is(err, model.ErrUploadTooLarge):
+				return status.Error(codes.InvalidArgument, err.Error())
+			}
+			e := fmt.Sprintf("failed to save chunk: %v", err)
+			s.log.Info(e)
+			return status.Error(codes.Internal, e)
+		}
+	}
+	if session == nil {
+		return status.Error(codes.InvalidArgument, "no chunks received")
+	}
+
+	return stream.SendAndClose(&pbservice.UploadOffset{Offset: session.Offset})
+}
+
+// GetUploadOffset returns how many bytes of the upload are received
+func (s *GRPCServer) GetUploadOffset(ctx context.Context, in *pbservice.GetUploadOffsetRequest) (*pbservice.UploadOffset, error) {
+	uID := jwtrule.GetUserIDFromCTX(ctx)
+	s.log.Trace("uID: ", uID)
+
+	session, err := s.getUpload(ctx, uID, in.UploadId)
+	if err != nil {
+		return nil, err
+	}
+	return &pbservice.UploadOffset{Offset: session.Offset}, nil
+}
+
+// FinalizeUpload checks size and SHA-256 of the received content and stores the file.
+// Content with a wrong checksum is dropped, the upload has to start over.
+func (s *GRPCServer) FinalizeUpload(ctx context.Context, in *pbservice.FinalizeUploadRequest) (*pbservice.UploadStatus, error) {
+	uID := jwtrule.GetUserIDFromCTX(ctx)
+	s.log.Trace("uID: ", uID)
+
+	session, err := s.getUpload(ctx, uID, in.UploadId)
+	if err != nil {
+		return nil, err
+	}
+	if !session.Complete() {
+		e := fmt.Sprintf("%v: received %d of %d bytes", model.ErrUploadIncomplete, session.Offset, session.Size)
+		return nil, status.Error(codes.FailedPrecondition, e)
+	}
+	// место могли занять другие загрузки, сессия остается до освобождения места
+	if _, err := s.checkFileQuota(ctx, uID, session.FileName, session.Size); err != nil {
+		return nil, err
+	}
+
+	file, err := s.repoupload.Open(session)
+	if err != nil {
+		e := fmt.Sprintf("failed to open upload: %v", err)
+		s.log.Info(e)
+		return nil, status.Error(codes.Internal, e)
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return nil, status.Error(codes.Internal, "failed to read upload: "+err.Error())
+	}
+	if hex.EncodeToString(hash.Sum(nil)) != session.SHA256 {
+		if err := s.repoupload.Delete(ctx, session); err != nil {
+			s.log.WithError(err).Error("failed to delete upload session")
+		}
+		return nil, status.Error(codes.DataLoss, model.ErrUploadChecksum.Error())
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, status.Error(codes.Internal, "failed to read upload: "+err.Error())
+	}
+
+	item := &model.FileItem{Name: session.FileName, Size: session.Size, SHA256: session.SHA256}
+	if err := s.storeFile(ctx, &model.User{ID: uID}, item, file); err != nil {
+		s.log.Info(err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if err := s.repoupload.Delete(ctx, session); err != nil {
+		// файл уже сохранен, брошенную сессию уберет очистка
+		s.log.WithError(err).Error("failed to delete upload session")
+	}
+
+	return &pbservice.UploadStatus{Success: true, Message: "File uploaded successfully"}, nil
+}
+
+func (s *GRPCServer) getUpload(ctx context.Context, uID int64, id string) (*model.UploadSession, error) {
+	if id == "" {
+		return nil, status.Error(codes.InvalidArgument, "upload_id is not set")
+	}
+	session, err := s.repoupload.Get(ctx, id, uID)
+	if err != nil {
+		if errors.Is(err, model.ErrUploadNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		e := fmt.Sprintf("failed to get upload session: %v", err)
+		s.log.Info(e)
+		return nil, status.Error(codes.Internal, e)
+	}
+	return session, nil
+}
+
+// storeFile uploads the received file to the storage and notifies other devices, file.Size -1 if it's unknown.
+// Устройство загрузки берется из сессии запроса.
+func (s *GRPCServer) storeFile(ctx context.Context, user *model.User, file *model.FileItem, r io.Reader) error {
+	objectName := file.Name
+	file.Device = s.sessionDevice(ctx, user)
+	err := s.reposervice.UploadFile(ctx, user, file, r)
+	if err != nil {
+		return fmt.Errorf("failed to upload file to MinIO: %w", err)
+	}
+	if _, err := s.reposync.TouchFile(ctx, user.ID, objectName); err != nil {
+		return fmt.Errorf("failed to set file revision: %w", err)
+	}
+	s.changes.Publish(user.ID, model.ChangeEvent{Op: model.ChangeCreated, FileName: objectName})
+
+	//Update User
+	user.LastUpdate = time.Now()
+	_, err = s.repouser.SetLastUpdate(ctx, user)
+	if err != nil {
+		return fmt.Errorf("failed to SetLastUpdate: %v", err)
+	}
+	return nil
+}
+
+// sessionDevice returns the device of the request session, empty if it's unknown
+func (s *GRPCServer) sessionDevice(ctx context.Context, user *model.User) string {
+	sid := jwtrule.GetSessionIDFromCTX(ctx)
+	if sid == "" {
+		return ""
+	}
+	session, err := s.reposession.Get(ctx, &model.Session{ID: sid, UserID: user.ID})
+	if err != nil {
+		// без устройства файл все равно сохраняется
+		if !errors.Is(err, model.ErrSessionNotFound) {
+			s.log.WithError(err).Error("failed to get session")
+		}
+		return ""
+	}
+	return session.DeviceInfo
+}
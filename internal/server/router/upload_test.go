@@ -0,0 +1,243 @@
+package router
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	pbservice "github.com/Arcadian-Sky/datakkeeper/gen/proto/api/service/v1"
+	"github.com/Arcadian-Sky/datakkeeper/internal/model"
+	"github.com/Arcadian-Sky/datakkeeper/internal/server/router/jwtrule"
+	"github.com/Arcadian-Sky/datakkeeper/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	gomockuber "go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func sha256Hex(data string) string {
+	sum := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestGRPCServer_InitUpload(t *testing.T) {
+	server := createTestMockServer(t)
+	ctx := jwtrule.SetUserIDToCTX(context.Background(), 1)
+	sha := sha256Hex("content")
+
+	server.repoupload.(*mocks.MockUploadRepository).EXPECT().
+		Pending(gomock.Any(), &model.UploadSession{UserID: 1, FileName: "a.txt", Size: 7, SHA256: sha}).
+		Return(int64(0), int64(0), nil)
+	server.repoupload.(*mocks.MockUploadRepository).EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, session *model.UploadSession) error {
+			assert.Len(t, session.ID, 32)
+			assert.Equal(t, model.UploadSession{ID: session.ID, UserID: 1, FileName: "a.txt", Size: 7, SHA256: sha}, *session)
+			session.ID, session.Offset = "up1", 3
+			return nil
+		})
+
+	res, err := server.InitUpload(ctx, &pbservice.InitUploadRequest{Filename: "a.txt", Size: 7, Sha256: strings.ToUpper(sha)})
+	assert.NoError(t, err)
+	assert.Equal(t, "up1", res.UploadId)
+	assert.Equal(t, int64(3), res.Offset)
+
+	_, err = server.InitUpload(ctx, &pbservice.InitUploadRequest{Filename: "a.txt", Size: 7, Sha256: "bad"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = server.InitUpload(ctx, &pbservice.InitUploadRequest{Filename: ".trash/a.txt", Size: 7, Sha256: sha})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	server.repoupload.(*mocks.MockUploadRepository).EXPECT().Pending(gomock.Any(), gomock.Any()).Return(int64(0), int64(0), nil)
+	server.repoupload.(*mocks.MockUploadRepository).EXPECT().Create(gomock.Any(), gomock.Any()).Return(errors.New("db error"))
+	_, err = server.InitUpload(ctx, &pbservice.InitUploadRequest{Filename: "a.txt", Size: 7, Sha256: sha})
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
+
+// chunkStream - поток UploadChunk с заданными частями,
+// сгенерированный мок серверной стороны клиентского потока перепутал типы Recv и SendAndClose
+type chunkStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	chunks []*pbservice.UploadChunkRequest
+	resp   *pbservice.UploadOffset
+}
+
+func (c *chunkStream) Context() context.Context { return c.ctx }
+
+func (c *chunkStream) Recv() (*pbservice.UploadChunkRequest, error) {
+	if len(c.chunks) == 0 {
+		return nil, io.EOF
+	}
+	chunk := c.chunks[0]
+	c.chunks = c.chunks[1:]
+	return chunk, nil
+}
+
+func (c *chunkStream) SendAndClose(resp *pbservice.UploadOffset) error {
+	c.resp = resp
+	return nil
+}
+
+func TestGRPCServer_UploadChunk(t *testing.T) {
+	server := createTestMockServer(t)
+	ctx := jwtrule.SetUserIDToCTX(context.Background(), 1)
+	repo := server.repoupload.(*mocks.MockUploadRepository)
+	appendData := func(_ context.Context, s *model.UploadSession, _ int64, data []byte) error {
+		s.Offset += int64(len(data))
+		return nil
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		stream := &chunkStream{ctx: ctx, chunks: []*pbservice.UploadChunkRequest{
+			{UploadId: "up1", Offset: 3, Data: []byte("te")},
+			{UploadId: "up1", Offset: 5, Data: []byte("nt")},
+		}}
+		session := &model.UploadSession{ID: "up1", UserID: 1, Size: 7, Offset: 3}
+		repo.EXPECT().Get(gomock.Any(), "up1", int64(1)).Return(session, nil)
+		repo.EXPECT().Append(gomock.Any(), session, int64(3), []byte("te")).DoAndReturn(appendData)
+		repo.EXPECT().Append(gomock.Any(), session, int64(5), []byte("nt")).DoAndReturn(appendData)
+
+		assert.NoError(t, server.UploadChunk(stream))
+		assert.Equal(t, int64(7), stream.resp.Offset)
+	})
+
+	t.Run("WrongOffset", func(t *testing.T) {
+		stream := &chunkStream{ctx: ctx, chunks: []*pbservice.UploadChunkRequest{{UploadId: "up1", Offset: 0, Data: []byte("co")}}}
+		session := &model.UploadSession{ID: "up1", UserID: 1, Size: 7, Offset: 3}
+		repo.EXPECT().Get(gomock.Any(), "up1", int64(1)).Return(session, nil)
+		repo.EXPECT().Append(gomock.Any(), session, int64(0), []byte("co")).Return(model.ErrUploadOffset)
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(server.UploadChunk(stream)))
+	})
+
+	t.Run("OtherUpload", func(t *testing.T) {
+		stream := &chunkStream{ctx: ctx, chunks: []*pbservice.UploadChunkRequest{
+			{UploadId: "up1", Offset: 3, Data: []byte("te")},
+			{UploadId: "up2", Offset: 5, Data: []byte("nt")},
+		}}
+		session := &model.UploadSession{ID: "up1", UserID: 1, Size: 7, Offset: 3}
+		repo.EXPECT().Get(gomock.Any(), "up1", int64(1)).Return(session, nil)
+		repo.EXPECT().Append(gomock.Any(), session, int64(3), []byte("te")).DoAndReturn(appendData)
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(server.UploadChunk(stream)))
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		stream := &chunkStream{ctx: ctx, chunks: []*pbservice.UploadChunkRequest{{UploadId: "up2", Data: []byte("co")}}}
+		repo.EXPECT().Get(gomock.Any(), "up2", int64(1)).Return(nil, model.ErrUploadNotFound)
+
+		assert.Equal(t, codes.NotFound, status.Code(server.UploadChunk(stream)))
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		assert.Equal(t, codes.InvalidArgument, status.Code(server.UploadChunk(&chunkStream{ctx: ctx})))
+	})
+}
+
+func TestGRPCServer_GetUploadOffset(t *testing.T) {
+	server := createTestMockServer(t)
+	ctx := jwtrule.SetUserIDToCTX(context.Background(), 1)
+
+	server.repoupload.(*mocks.MockUploadRepository).EXPECT().
+		Get(gomock.Any(), "up1", int64(1)).
+		Return(&model.UploadSession{ID: "up1", Size: 7, Offset: 4}, nil)
+	res, err := server.GetUploadOffset(ctx, &pbservice.GetUploadOffsetRequest{UploadId: "up1"})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), res.Offset)
+
+	_, err = server.GetUploadOffset(ctx, &pbservice.GetUploadOffsetRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestGRPCServer_FinalizeUpload(t *testing.T) {
+	server := createTestMockServer(t)
+	ctx := jwtrule.SetUserIDToCTX(context.Background(), 1)
+	repo := server.repoupload.(*mocks.MockUploadRepository)
+	events, unsubscribe := server.changes.Subscribe(1)
+	defer unsubscribe()
+
+	openContent := func(t *testing.T, content string) *os.File {
+		path := filepath.Join(t.TempDir(), "up.part")
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		file, err := os.Open(path)
+		assert.NoError(t, err)
+		return file
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		session := &model.UploadSession{ID: "up1", UserID: 1, FileName: "a.txt", Size: 7, SHA256: sha256Hex("content"), Offset: 7}
+		file := openContent(t, "content")
+		repo.EXPECT().Get(gomock.Any(), "up1", int64(1)).Return(session, nil)
+		repo.EXPECT().Open(session).Return(file, nil)
+		server.reposervice.(*mocks.MockFileRepository).EXPECT().
+			UploadFile(gomock.Any(), gomock.Any(), &model.FileItem{Name: "a.txt", Size: 7, SHA256: sha256Hex("content")}, file).
+			DoAndReturn(func(_ context.Context, _ *model.User, _ *model.FileItem, r io.Reader) error {
+				got, err := io.ReadAll(r)
+				assert.NoError(t, err)
+				assert.Equal(t, "content", string(got))
+				return nil
+			})
+		server.reposync.(*mocks.MockSyncRepository).EXPECT().TouchFile(gomock.Any(), int64(1), "a.txt").Return(int64(4), nil)
+		server.repouser.(*mocks.MockUserRepository).EXPECT().SetLastUpdate(gomock.Any(), gomock.Any()).Return(&model.User{}, nil)
+		repo.EXPECT().Delete(gomock.Any(), session).Return(nil)
+
+		res, err := server.FinalizeUpload(ctx, &pbservice.FinalizeUploadRequest{UploadId: "up1"})
+		assert.NoError(t, err)
+		assert.True(t, res.Success)
+		assert.Equal(t, model.ChangeEvent{Op: model.ChangeCreated, FileName: "a.txt"}, <-events)
+	})
+
+	t.Run("Incomplete", func(t *testing.T) {
+		repo.EXPECT().Get(gomock.Any(), "up1", int64(1)).Return(&model.UploadSession{ID: "up1", Size: 7, Offset: 5}, nil)
+
+		_, err := server.FinalizeUpload(ctx, &pbservice.FinalizeUploadRequest{UploadId: "up1"})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("ChecksumMismatch", func(t *testing.T) {
+		session := &model.UploadSession{ID: "up1", UserID: 1, FileName: "a.txt", Size: 7, SHA256: sha256Hex("content"), Offset: 7}
+		repo.EXPECT().Get(gomock.Any(), "up1", int64(1)).Return(session, nil)
+		repo.EXPECT().Open(session).Return(openContent(t, "CONTENT"), nil)
+		repo.EXPECT().Delete(gomock.Any(), session).Return(nil)
+
+		_, err := server.FinalizeUpload(ctx, &pbservice.FinalizeUploadRequest{UploadId: "up1"})
+		assert.Equal(t, codes.DataLoss, status.Code(err))
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		repo.EXPECT().Get(gomock.Any(), "up2", int64(1)).Return(nil, model.ErrUploadNotFound)
+
+		_, err := server.FinalizeUpload(ctx, &pbservice.FinalizeUploadRequest{UploadId: "up2"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
+
+func TestGRPCServer_GetFileOffset(t *testing.T) {
+	server := createTestMockServer(t)
+	ctx := jwtrule.SetUserIDToCTX(context.Background(), 1)
+
+	path := filepath.Join(t.TempDir(), "file1")
+	assert.NoError(t, os.WriteFile(path, []byte("file content"), 0o600))
+	file, err := os.Open(path)
+	assert.NoError(t, err)
+
+	server.reposervice.(*mocks.MockFileRepository).EXPECT().GetFile(gomock.Any(), "file1", &model.User{ID: 1}).Return(file, nil)
+
+	ctrl := gomockuber.NewController(t)
+	stream := pbservice.NewMockDataKeeperService_GetFileServer(ctrl)
+	stream.EXPECT().Context().Return(ctx).AnyTimes()
+	stream.EXPECT().Send(&pbservice.FileChunk{Data: []byte("content"), Filename: "file1"}).Return(nil)
+
+	assert.NoError(t, server.GetFile(&pbservice.GetFileRequest{Name: "file1", Offset: 5}, stream))
+
+	err = server.GetFile(&pbservice.GetFileRequest{Name: "file1", Offset: -1}, stream)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,145 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	pbservice "github.com/Arcadian-Sky/datakkeeper/gen/proto/api/service/v1"
+	"github.com/Arcadian-Sky/datakkeeper/internal/model"
+	"github.com/Arcadian-Sky/datakkeeper/internal/server/router/jwtrule"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetUsage returns space used by the user and the quota
+func (s *GRPCServer) GetUsage(ctx context.Context, _ *pbservice.GetUsageRequest) (*pbservice.GetUsageResponse, error) {
+	uID := jwtrule.GetUserIDFromCTX(ctx)
+	s.log.Trace("uID: ", uID)
+
+	usage, err := s.usage(ctx, uID, true, true)
+	if err != nil {
+		return nil, err
+	}
+	quota := s.quota()
+	return &pbservice.GetUsageResponse{
+		Used:  &pbservice.Usage{Bytes: usage.Bytes, Files: usage.Files, Records: usage.Records},
+		Quota: &pbservice.Usage{Bytes: quota.Bytes, Files: quota.Files, Records: quota.Records},
+	}, nil
+}
+
+func (s *GRPCServer) quota() model.Quota {
+	return model.Quota(s.cfg.Quota)
+}
+
+// usage counts files and records of the user, only the requested parts are counted
+func (s *GRPCServer) usage(ctx context.Context, uID int64, files, records bool) (model.Usage, error) {
+	var usage model.Usage
+	var err error
+	if files {
+		usage, err = s.reposervice.Usage(ctx, &model.User{ID: uID})
+		if err != nil {
+			e := fmt.Sprintf("failed to get usage: %v", err)
+			s.log.Info(e)
+			return usage, status.Error(codes.Internal, e)
+		}
+	}
+	if records {
+		usage.Records, err = s.repodata.Count(ctx, uID)
+		if err != nil {
+			e := fmt.Sprintf("failed to get usage: %v", err)
+			s.log.Info(e)
+			return usage, status.Error(codes.Internal, e)
+		}
+	}
+	return usage, nil
+}
+
+// checkFileQuota checks that the file fileName of size fits the quota, size -1 if it's unknown.
+// Файл, заменяющий существующий или удаленный в корзину, не считается новым, см. ReplacedUsage.
+// Returns how many bytes can still be stored, -1 without limit.
+func (s *GRPCServer) checkFileQuota(ctx context.Context, uID int64, fileName string, size int64) (int64, error) {
+	quota := s.quota()
+	if quota.Bytes <= 0 && quota.Files <= 0 {
+		return -1, nil
+	}
+	usage, err := s.usage(ctx, uID, true, false)
+	if err != nil {
+		return 0, err
+	}
+	replaced, err := s.reposervice.ReplacedUsage(ctx, fileName, &model.User{ID: uID})
+	if err != nil {
+		e := fmt.Sprintf("failed to get usage: %v", err)
+		s.log.Info(e)
+		return 0, status.Error(codes.Internal, e)
+	}
+	usage.Bytes -= replaced.Bytes
+	usage.Files -= replaced.Files
+	if err := quota.CheckFile(usage, size); err != nil {
+		return 0, status.Error(codes.ResourceExhausted, err.Error())
+	}
+	return quota.FreeBytes(usage), nil
+}
+
+// checkMoveQuota checks that the content left under the old names by moving from fits the quota
+func (s *GRPCServer) checkMoveQuota(ctx context.Context, uID int64, from string) error {
+	quota := s.quota()
+	if quota.Bytes <= 0 {
+		return nil
+	}
+	usage, err := s.usage(ctx, uID, true, false)
+	if err != nil {
+		return err
+	}
+	moved, err := s.reposervice.MovedUsage(ctx, from, &model.User{ID: uID})
+	if err != nil {
+		e := fmt.Sprintf("failed to get usage: %v", err)
+		s.log.Info(e)
+		return status.Error(codes.Internal, e)
+	}
+	if err := quota.CheckBytes(usage, moved.Bytes); err != nil {
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+	return nil
+}
+
+// checkRecordQuota checks that one more record fits the quota
+func (s *GRPCServer) checkRecordQuota(ctx context.Context, uID int64) error {
+	quota := s.quota()
+	if quota.Records <= 0 {
+		return nil
+	}
+	usage, err := s.usage(ctx, uID, false, true)
+	if err != nil {
+		return err
+	}
+	if err := quota.CheckRecord(usage); err != nil {
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+	return nil
+}
+
+// quotaReader fails with ErrQuotaExceeded as soon as more than left bytes are read,
+// so the upload of unknown size stops in the middle of the stream
+type quotaReader struct {
+	r    io.Reader
+	left int64
+}
+
+func (q *quotaReader) Read(p []byte) (int, error) {
+	n, err := q.r.Read(p)
+	q.left -= int64(n)
+	if q.left < 0 {
+		return n, model.ErrQuotaExceeded
+	}
+	return n, err
+}
+
+// quotaError turns quota errors of the storage into ResourceExhausted
+func quotaError(err error) error {
+	if errors.Is(err, model.ErrQuotaExceeded) {
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+	return err
+}
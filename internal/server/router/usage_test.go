@@ -0,0 +1,168 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	pbservice "github.com/Arcadian-Sky/datakkeeper/gen/proto/api/service/v1"
+	"github.com/Arcadian-Sky/datakkeeper/internal/model"
+	"github.com/Arcadian-Sky/datakkeeper/internal/server/router/jwtrule"
+	"github.com/Arcadian-Sky/datakkeeper/internal/settings"
+	"github.com/Arcadian-Sky/datakkeeper/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGRPCServer_GetUsage(t *testing.T) {
+	server := createTestMockServer(t)
+	server.cfg.Quota = settings.Quota{Bytes: 100, Files: 10}
+	ctx := jwtrule.SetUserIDToCTX(context.Background(), 1)
+
+	server.reposervice.(*mocks.MockFileRepository).EXPECT().Usage(gomock.Any(), &model.User{ID: 1}).Return(model.Usage{Bytes: 40, Files: 2}, nil)
+	server.repodata.(*mocks.MockDataRepository).EXPECT().Count(gomock.Any(), int64(1)).Return(int64(5), nil)
+	res, err := server.GetUsage(ctx, &pbservice.GetUsageRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, &pbservice.Usage{Bytes: 40, Files: 2, Records: 5}, res.Used)
+	assert.Equal(t, &pbservice.Usage{Bytes: 100, Files: 10}, res.Quota)
+
+	server.reposervice.(*mocks.MockFileRepository).EXPECT().Usage(gomock.Any(), gomock.Any()).Return(model.Usage{}, errors.New("minio error"))
+	_, err = server.GetUsage(ctx, &pbservice.GetUsageRequest{})
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
+
+func TestGRPCServer_SaveData_Quota(t *testing.T) {
+	server := createTestMockServer(t)
+	server.cfg.Quota = settings.Quota{Records: 5}
+	ctx := jwtrule.SetUserIDToCTX(context.Background(), 1)
+
+	server.repouser.(*mocks.MockUserRepository).EXPECT().BindKeyHash(gomock.Any(), &model.User{ID: 1, KeyHash: "hash1"}).Return(nil)
+	server.repodata.(*mocks.MockDataRepository).EXPECT().Count(gomock.Any(), int64(1)).Return(int64(5), nil)
+	_, err := server.SaveData(ctx, &pbservice.SaveDataRequest{Data: &pbservice.Data{Title: "note", Note: "text", KeyHash: "hash1"}})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func TestGRPCServer_InitUpload_Quota(t *testing.T) {
+	server := createTestMockServer(t)
+	server.cfg.Quota = settings.Quota{Bytes: 100}
+	ctx := jwtrule.SetUserIDToCTX(context.Background(), 1)
+
+	server.repoupload.(*mocks.MockUploadRepository).EXPECT().Pending(gomock.Any(), gomock.Any()).Return(int64(0), int64(0), nil)
+	server.reposervice.(*mocks.MockFileRepository).EXPECT().Usage(gomock.Any(), &model.User{ID: 1}).Return(model.Usage{Bytes: 95}, nil)
+	server.reposervice.(*mocks.MockFileRepository).EXPECT().ReplacedUsage(gomock.Any(), "a.txt", &model.User{ID: 1}).Return(model.Usage{}, nil)
+	_, err := server.InitUpload(ctx, &pbservice.InitUploadRequest{Filename: "a.txt", Size: 7, Sha256: sha256Hex("content")})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// место занимают и незавершенные загрузки других файлов
+	server.repoupload.(*mocks.MockUploadRepository).EXPECT().Pending(gomock.Any(), gomock.Any()).Return(int64(2), int64(90), nil)
+	server.reposervice.(*mocks.MockFileRepository).EXPECT().Usage(gomock.Any(), &model.User{ID: 1}).Return(model.Usage{Bytes: 5}, nil)
+	server.reposervice.(*mocks.MockFileRepository).EXPECT().ReplacedUsage(gomock.Any(), "a.txt", &model.User{ID: 1}).Return(model.Usage{}, nil)
+	_, err = server.InitUpload(ctx, &pbservice.InitUploadRequest{Filename: "a.txt", Size: 7, Sha256: sha256Hex("content")})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func TestGRPCServer_InitUpload_SessionLimit(t *testing.T) {
+	server := createTestMockServer(t)
+	server.cfg.Upload.MaxSessions = 2
+	ctx := jwtrule.SetUserIDToCTX(context.Background(), 1)
+	repo := server.repoupload.(*mocks.MockUploadRepository)
+
+	repo.EXPECT().Pending(gomock.Any(), gomock.Any()).Return(int64(2), int64(14), nil)
+	_, err := server.InitUpload(ctx, &pbservice.InitUploadRequest{Filename: "a.txt", Size: 7, Sha256: sha256Hex("content")})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	repo.EXPECT().Pending(gomock.Any(), gomock.Any()).Return(int64(0), int64(0), errors.New("db error"))
+	_, err = server.InitUpload(ctx, &pbservice.InitUploadRequest{Filename: "a.txt", Size: 7, Sha256: sha256Hex("content")})
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
+
+func TestGRPCServer_UploadFile_Quota(t *testing.T) {
+	server := createTestMockServer(t)
+	server.cfg.Quota = settings.Quota{Bytes: 100, Files: 3}
+	ctx := jwtrule.SetUserIDToCTX(context.Background(), 1)
+	repo := server.reposervice.(*mocks.MockFileRepository)
+
+	t.Run("MidStream", func(t *testing.T) {
+		stream := &fileChunkStream{ctx: ctx, chunks: []*pbservice.FileChunk{
+			{Filename: "a.txt", Data: []byte("12345")},
+			{Data: []byte("67890")},
+		}}
+		repo.EXPECT().Usage(gomock.Any(), &model.User{ID: 1}).Return(model.Usage{Bytes: 92, Files: 1}, nil)
+		repo.EXPECT().ReplacedUsage(gomock.Any(), "a.txt", &model.User{ID: 1}).Return(model.Usage{}, nil)
+		repo.EXPECT().
+			UploadFile(gomock.Any(), gomock.Any(), &model.FileItem{Name: "a.txt", Size: -1}, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ *model.User, _ *model.FileItem, r io.Reader) error {
+				_, err := io.ReadAll(r)
+				return err
+			})
+
+		err := server.UploadFile(stream)
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+		assert.Nil(t, stream.resp)
+	})
+
+	t.Run("Files", func(t *testing.T) {
+		repo.EXPECT().Usage(gomock.Any(), &model.User{ID: 1}).Return(model.Usage{Files: 3}, nil)
+		repo.EXPECT().ReplacedUsage(gomock.Any(), "a.txt", &model.User{ID: 1}).Return(model.Usage{}, nil)
+		err := server.UploadFile(&fileChunkStream{ctx: ctx, chunks: []*pbservice.FileChunk{{Filename: "a.txt"}}})
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	})
+
+	t.Run("Overwrite", func(t *testing.T) {
+		stream := &fileChunkStream{ctx: ctx, chunks: []*pbservice.FileChunk{{Filename: "a.txt", Data: []byte("12345")}}}
+		// замена существующего файла не считается новым файлом, корзина освобождает место
+		repo.EXPECT().Usage(gomock.Any(), &model.User{ID: 1}).Return(model.Usage{Bytes: 100, Files: 3}, nil)
+		repo.EXPECT().ReplacedUsage(gomock.Any(), "a.txt", &model.User{ID: 1}).Return(model.Usage{Bytes: 10, Files: 1}, nil)
+		repo.EXPECT().UploadFile(gomock.Any(), gomock.Any(), &model.FileItem{Name: "a.txt", Size: -1}, gomock.Any()).Return(nil)
+		server.reposync.(*mocks.MockSyncRepository).EXPECT().TouchFile(gomock.Any(), int64(1), "a.txt").Return(int64(3), nil)
+		server.repouser.(*mocks.MockUserRepository).EXPECT().SetLastUpdate(gomock.Any(), gomock.Any()).Return(&model.User{}, nil)
+		assert.NoError(t, server.UploadFile(stream))
+		assert.True(t, stream.resp.Success)
+	})
+
+	t.Run("ReplacedError", func(t *testing.T) {
+		repo.EXPECT().Usage(gomock.Any(), &model.User{ID: 1}).Return(model.Usage{}, nil)
+		repo.EXPECT().ReplacedUsage(gomock.Any(), "a.txt", &model.User{ID: 1}).Return(model.Usage{}, errors.New("minio error"))
+		err := server.UploadFile(&fileChunkStream{ctx: ctx, chunks: []*pbservice.FileChunk{{Filename: "a.txt"}}})
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+}
+
+func TestGRPCServer_MoveFile_Quota(t *testing.T) {
+	server := createTestMockServer(t)
+	server.cfg.Quota = settings.Quota{Bytes: 100}
+	ctx := jwtrule.SetUserIDToCTX(context.Background(), 1)
+	repo := server.reposervice.(*mocks.MockFileRepository)
+
+	// перенесенное содержимое остается и прежней версией под старым именем
+	repo.EXPECT().Usage(gomock.Any(), &model.User{ID: 1}).Return(model.Usage{Bytes: 60, Files: 1}, nil)
+	repo.EXPECT().MovedUsage(gomock.Any(), "a.txt", &model.User{ID: 1}).Return(model.Usage{Bytes: 60}, nil)
+	_, err := server.MoveFile(ctx, &pbservice.MoveFileRequest{Filename: "a.txt", Folder: "work"})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	repo.EXPECT().Usage(gomock.Any(), gomock.Any()).Return(model.Usage{}, nil)
+	repo.EXPECT().MovedUsage(gomock.Any(), gomock.Any(), gomock.Any()).Return(model.Usage{}, errors.New("minio error"))
+	_, err = server.MoveFile(ctx, &pbservice.MoveFileRequest{Filename: "a.txt", Folder: "work"})
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
+
+func TestQuotaReader(t *testing.T) {
+	r := &quotaReader{r: io.LimitReader(zeroReader{}, 10), left: 10}
+	got, err := io.ReadAll(r)
+	assert.NoError(t, err)
+	assert.Len(t, got, 10)
+
+	r = &quotaReader{r: io.LimitReader(zeroReader{}, 11), left: 10}
+	_, err = io.ReadAll(r)
+	assert.ErrorIs(t, err, model.ErrQuotaExceeded)
+}
+
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	clear(p)
+	return len(p), nil
+}
@@ -0,0 +1,47 @@
+package router
+
+import (
+	pbservice "github.com/Arcadian-Sky/datakkeeper/gen/proto/api/service/v1"
+	"github.com/Arcadian-Sky/datakkeeper/internal/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Правила buf.validate из service.proto проверяет interceptor.ValidateUnaryInterceptor до вызова метода,
+// здесь остаются проверки, которые в proto не выражены.
+
+// validateData checks that the data is set and the binary fits the size limit.
+// Secret fields come encrypted, so only their presence and open fields can be checked.
+func validateData(d *pbservice.Data) error {
+	if d == nil {
+		return status.Error(codes.InvalidArgument, "data is not set")
+	}
+	if len(d.Binary) > model.MaxBinarySize {
+		return status.Error(codes.InvalidArgument, model.ErrBinaryTooLarge.Error())
+	}
+	return nil
+}
+
+// validateListFile checks that the parent folder of ListFileRequest is a valid file path
+func validateListFile(in *pbservice.ListFileRequest) error {
+	if in.Parent != "" {
+		if err := model.ValidateFileName(in.Parent); err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	return nil
+}
+
+// validateRestoreFromTrash checks that exactly one item of RestoreFromTrashRequest is set,
+// имя файла - исходное, без префикса корзины
+func validateRestoreFromTrash(in *pbservice.RestoreFromTrashRequest) error {
+	if (in.Dataid == 0) == (in.Filename == "") {
+		return status.Error(codes.InvalidArgument, "either dataid or filename must be set")
+	}
+	if in.Filename != "" {
+		if err := model.ValidateFileName(in.Filename); err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package router
+
+import (
+	"testing"
+
+	pbservice "github.com/Arcadian-Sky/datakkeeper/gen/proto/api/service/v1"
+	"github.com/Arcadian-Sky/datakkeeper/internal/model"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestValidateData(t *testing.T) {
+	card := func() *pbservice.Data {
+		return &pbservice.Data{
+			Type:         pbservice.DataType_DATA_TYPE_TYPE_CREDIT_CARD,
+			Card:         "sealed number",
+			CardExpMonth: 12,
+			CardExpYear:  2030,
+			CardBrand:    model.CardBrandMir,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		data    *pbservice.Data
+		wantErr bool
+	}{
+		{name: "Card", data: card()},
+		{name: "LoginPassword", data: &pbservice.Data{Type: pbservice.DataType_DATA_TYPE_TYPE_LOGIN_PASSWORD, Login: "l"}},
+		{name: "Nil", data: nil, wantErr: true},
+		{name: "BinaryTooLarge", data: &pbservice.Data{Binary: make([]byte, model.MaxBinarySize+1)}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateData(tt.data)
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	}
+}
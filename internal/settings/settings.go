@@ -3,20 +3,90 @@ package settings
 import (
 	"crypto/rand"
 	"encoding/hex"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
 // - адрес и порт запуска сервиса: переменная окружения ОС `RUN_ADDRESS` или флаг `-a`
-// - адрес подключения к базе данных: переменная окружения ОС `PG_DATABASE_URI` или флаг `-d`
+// - адрес подключения к базе данных: переменная окружения ОС `PG_DATABASE_URI` или флаг `-d`,
+//   `sqlite://путь/к/файлу.db` - встроенная SQLite вместо postgres
+// - метаданные файлов в MongoDB: `MG_DATABASE_URI`, без него они хранятся в базе `PG_DATABASE_URI`
+// - ключ подписи JWT: `DATAKEEPER_JWT_SECRET`, файл `DATAKEEPER_JWT_KEY_FILE`
+//   или таблица в postgres при `DATAKEEPER_JWT_KEY_SOURCE=db`
+// - срок хранения удаленного в корзине: `DATAKEEPER_TRASH_RETENTION`,
+//   период очистки корзины: `DATAKEEPER_TRASH_PURGE_INTERVAL`
+// - каталог недогруженных файлов: `DATAKEEPER_UPLOAD_DIR`,
+//   сколько хранится брошенная загрузка: `DATAKEEPER_UPLOAD_MAX_AGE`
+// - квоты пользователя: `DATAKEEPER_QUOTA_BYTES`, `DATAKEEPER_QUOTA_FILES`, `DATAKEEPER_QUOTA_RECORDS`,
+//   0 - без ограничения
+// - хранить одинаковое содержимое файлов один раз: `DATAKEEPER_DEDUP=true`, клиент шифрует одинаковые файлы
+//   пользователя одинаково, файлы разных пользователей не совпадают
+// - хранилище файлов: `DATAKEEPER_STORAGE=minio` (по умолчанию) или `local` - каталог `DATAKEEPER_STORAGE_DIR`
+
+// Источники ключа подписи JWT
+const (
+	JWTSourceGenerated = ""
+	JWTSourceEnv       = "env"
+	JWTSourceFile      = "file"
+	JWTSourceDB        = "db"
+)
+
+type JWT struct {
+	Source  string
+	KeyFile string
+	// как часто менять ключ (только для db)
+	Rotation time.Duration
+	// сколько выведенный ключ еще принимается для проверки, не меньше времени жизни токена
+	Overlap time.Duration
+}
+
+// Хранилища файлов
+const (
+	StorageMinIO = "minio"
+	StorageLocal = "local"
+)
 
 type Storage struct {
+	// StorageMinIO или StorageLocal
+	Backend string
+	// каталог файлов StorageLocal
+	Dir         string
 	Endpoint    string
 	AccessKeyID string
 	Secret      string
+	// новые файлы сохраняются по SHA-256 содержимого, одинаковые - один раз
+	Dedup bool
+}
+
+type Trash struct {
+	// сколько удаленное хранится в корзине до окончательного удаления
+	Retention time.Duration
+	// как часто искать просроченное
+	PurgeInterval time.Duration
+}
+
+type Upload struct {
+	// где копятся части файлов до завершения загрузки
+	Dir string
+	// через сколько после последней принятой части неоконченная загрузка удаляется
+	MaxAge time.Duration
+	// сколько незавершенных загрузок может быть у пользователя, 0 - без ограничения
+	MaxSessions int64
+}
+
+// Quota - ограничения на одного пользователя, 0 - без ограничения
+type Quota struct {
+	Bytes   int64
+	Files   int64
+	Records int64
 }
 
 type InitedFlags struct {
@@ -24,7 +94,11 @@ type InitedFlags struct {
 	DBPGSettings string
 	DBMGSettings string
 	SecretKey    string
+	JWT          JWT
 	Storage      Storage
+	Trash        Trash
+	Upload       Upload
+	Quota        Quota
 }
 
 func Parse() *InitedFlags {
@@ -54,21 +128,85 @@ func Parse() *InitedFlags {
 	envRunFileStorageAccKeyID := os.Getenv("FILE_DATABASE_ACCESS_KEY")
 	envRunFileStorageSecret := os.Getenv("FILE_DATABASE_SECRET")
 
-	// Длина ключа в байтах (например, 32 байта = 256 бит)
-	secretKey, err := GenerateSecretKey(32)
+	jwtSettings := JWT{
+		Source:   os.Getenv("DATAKEEPER_JWT_KEY_SOURCE"),
+		KeyFile:  os.Getenv("DATAKEEPER_JWT_KEY_FILE"),
+		Rotation: parseDuration(os.Getenv("DATAKEEPER_JWT_KEY_ROTATION"), 24*time.Hour),
+		Overlap:  parseDuration(os.Getenv("DATAKEEPER_JWT_KEY_OVERLAP"), time.Hour),
+	}
+	envSecret := os.Getenv("DATAKEEPER_JWT_SECRET")
+	if jwtSettings.Source == JWTSourceGenerated {
+		switch {
+		case envSecret != "":
+			jwtSettings.Source = JWTSourceEnv
+		case jwtSettings.KeyFile != "":
+			jwtSettings.Source = JWTSourceFile
+		}
+	}
+
+	var secretKey string
+	var err error
+	switch jwtSettings.Source {
+	case JWTSourceEnv:
+		secretKey = envSecret
+	case JWTSourceFile:
+		secretKey, err = LoadSecretKeyFile(jwtSettings.KeyFile)
+	}
 	if err != nil {
 		fmt.Print("parse err:", err)
 	}
+	if secretKey == "" {
+		// Длина ключа в байтах (например, 32 байта = 256 бит)
+		secretKey, err = GenerateSecretKey(32)
+		if err != nil {
+			fmt.Print("parse err:", err)
+		}
+	}
+
+	storageBackend := os.Getenv("DATAKEEPER_STORAGE")
+	if storageBackend != StorageLocal {
+		if storageBackend != "" && storageBackend != StorageMinIO {
+			fmt.Print("parse err: unknown storage ", storageBackend)
+		}
+		storageBackend = StorageMinIO
+	}
+	storageDir := os.Getenv("DATAKEEPER_STORAGE_DIR")
+	if storageDir == "" {
+		storageDir = filepath.Join("data", "files")
+	}
+
+	uploadDir := os.Getenv("DATAKEEPER_UPLOAD_DIR")
+	if uploadDir == "" {
+		uploadDir = filepath.Join(os.TempDir(), "datakeeper-uploads")
+	}
 
 	return &InitedFlags{
 		Endpoint:     endpoint,
 		DBPGSettings: dbSettings,
 		DBMGSettings: dbMdSettings,
 		SecretKey:    secretKey,
+		JWT:          jwtSettings,
 		Storage: Storage{
+			Backend:     storageBackend,
+			Dir:         storageDir,
 			Endpoint:    envRunFileStorageURI,
 			AccessKeyID: envRunFileStorageAccKeyID,
 			Secret:      envRunFileStorageSecret,
+			Dedup:       parseBool(os.Getenv("DATAKEEPER_DEDUP")),
+		},
+		Trash: Trash{
+			Retention:     parseDuration(os.Getenv("DATAKEEPER_TRASH_RETENTION"), 30*24*time.Hour),
+			PurgeInterval: parseDuration(os.Getenv("DATAKEEPER_TRASH_PURGE_INTERVAL"), time.Hour),
+		},
+		Upload: Upload{
+			Dir:         uploadDir,
+			MaxAge:      parseDuration(os.Getenv("DATAKEEPER_UPLOAD_MAX_AGE"), 24*time.Hour),
+			MaxSessions: parseLimit(os.Getenv("DATAKEEPER_UPLOAD_MAX_SESSIONS"), 16),
+		},
+		Quota: Quota{
+			Bytes:   parseLimit(os.Getenv("DATAKEEPER_QUOTA_BYTES"), 1<<30),
+			Files:   parseLimit(os.Getenv("DATAKEEPER_QUOTA_FILES"), 10000),
+			Records: parseLimit(os.Getenv("DATAKEEPER_QUOTA_RECORDS"), 10000),
 		},
 	}
 
@@ -83,3 +221,76 @@ func GenerateSecretKey(length int) (string, error) {
 	}
 	return hex.EncodeToString(bytes), nil
 }
+
+// LoadSecretKeyFile читает ключ из файла, при отсутствии файла создает его с новым ключом.
+func LoadSecretKeyFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err == nil {
+		key := strings.TrimSpace(string(data))
+		if key == "" {
+			return "", fmt.Errorf("jwt key file %s is empty", path)
+		}
+		return key, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return "", err
+	}
+
+	key, err := GenerateSecretKey(32)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return "", err
+	}
+	// O_EXCL: если файл одновременно создал другой экземпляр, берем его ключ
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if errors.Is(err, os.ErrExist) {
+		return LoadSecretKeyFile(path)
+	}
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	if _, err := f.WriteString(key + "\n"); err != nil {
+		return "", err
+	}
+
+	return key, nil
+}
+
+func parseDuration(value string, def time.Duration) time.Duration {
+	if value == "" {
+		return def
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		fmt.Print("parse err: invalid duration ", value)
+		return def
+	}
+	return d
+}
+
+func parseLimit(value string, def int64) int64 {
+	if value == "" {
+		return def
+	}
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || n < 0 {
+		fmt.Print("parse err: invalid limit ", value)
+		return def
+	}
+	return n
+}
+
+func parseBool(value string) bool {
+	if value == "" {
+		return false
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		fmt.Print("parse err: invalid bool ", value)
+		return false
+	}
+	return b
+}
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS jwt_key (
+	kid varchar NOT NULL,
+	secret text NOT NULL,
+	created_at timestamp without time zone NOT NULL DEFAULT now(),
+	retired_at timestamp without time zone NULL,
+	CONSTRAINT jwt_key_pk PRIMARY KEY (kid)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE jwt_key;
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS "session" (
+	id varchar NOT NULL,
+	user_id bigint NOT NULL,
+	refresh_hash varchar NOT NULL,
+	device_info text NOT NULL DEFAULT '',
+	created_at timestamp without time zone NOT NULL DEFAULT now(),
+	expires_at timestamp without time zone NOT NULL,
+	revoked_at timestamp without time zone NULL,
+	CONSTRAINT session_pk PRIMARY KEY (id),
+	CONSTRAINT session_refresh_unique UNIQUE (refresh_hash),
+	CONSTRAINT session_user_fk_1 FOREIGN KEY (user_id) REFERENCES "user"(id) ON DELETE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE "session";
+-- +goose StatementEnd
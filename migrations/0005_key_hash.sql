@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE "user" ADD COLUMN IF NOT EXISTS key_hash varchar NULL;
+ALTER TABLE metadata ADD COLUMN IF NOT EXISTS key_hash varchar NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE metadata DROP COLUMN IF EXISTS key_hash;
+ALTER TABLE "user" DROP COLUMN IF EXISTS key_hash;
+-- +goose StatementEnd
@@ -0,0 +1,12 @@
+-- +goose NO TRANSACTION
+-- +goose Up
+ALTER TYPE t_dtype ADD VALUE IF NOT EXISTS 'TEXT';
+ALTER TYPE t_dtype ADD VALUE IF NOT EXISTS 'BINARY';
+ALTER TABLE metadata ADD COLUMN IF NOT EXISTS note text NOT NULL DEFAULT '';
+ALTER TABLE metadata ADD COLUMN IF NOT EXISTS bin_data bytea NULL;
+
+-- +goose Down
+-- значения enum в postgres не удаляются, убираем только записи новых типов
+DELETE FROM metadata WHERE dtype IN ('TEXT', 'BINARY');
+ALTER TABLE metadata DROP COLUMN IF EXISTS bin_data;
+ALTER TABLE metadata DROP COLUMN IF EXISTS note;
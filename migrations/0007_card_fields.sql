@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE metadata ADD COLUMN IF NOT EXISTS card_holder text NOT NULL DEFAULT '';
+ALTER TABLE metadata ADD COLUMN IF NOT EXISTS card_exp_month smallint NOT NULL DEFAULT 0;
+ALTER TABLE metadata ADD COLUMN IF NOT EXISTS card_exp_year smallint NOT NULL DEFAULT 0;
+ALTER TABLE metadata ADD COLUMN IF NOT EXISTS card_cvv text NOT NULL DEFAULT '';
+ALTER TABLE metadata ADD COLUMN IF NOT EXISTS card_bank text NOT NULL DEFAULT '';
+ALTER TABLE metadata ADD COLUMN IF NOT EXISTS card_pin text NOT NULL DEFAULT '';
+ALTER TABLE metadata ADD COLUMN IF NOT EXISTS card_brand text NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE metadata DROP COLUMN IF EXISTS card_brand;
+ALTER TABLE metadata DROP COLUMN IF EXISTS card_pin;
+ALTER TABLE metadata DROP COLUMN IF EXISTS card_bank;
+ALTER TABLE metadata DROP COLUMN IF EXISTS card_cvv;
+ALTER TABLE metadata DROP COLUMN IF EXISTS card_exp_year;
+ALTER TABLE metadata DROP COLUMN IF EXISTS card_exp_month;
+ALTER TABLE metadata DROP COLUMN IF EXISTS card_holder;
+-- +goose StatementEnd
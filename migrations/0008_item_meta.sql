@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Пользовательские метаданные записей и файлов, item_id - id записи metadata или имя файла
+CREATE TABLE IF NOT EXISTS item_meta (
+	user_id bigint NOT NULL,
+	kind varchar NOT NULL,
+	item_id varchar NOT NULL,
+	fields jsonb NOT NULL DEFAULT '{}',
+	tags jsonb NOT NULL DEFAULT '[]',
+
+	CONSTRAINT item_meta_pk PRIMARY KEY (user_id, kind, item_id),
+	CONSTRAINT item_meta_user_fk FOREIGN KEY (user_id) REFERENCES "user"(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS item_meta_tags_idx ON item_meta USING gin (tags);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS item_meta;
+-- +goose StatementEnd
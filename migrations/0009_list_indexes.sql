@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Постраничный вывод списка записей по id и по названию
+CREATE INDEX IF NOT EXISTS metadata_user_id_idx ON metadata (user_id, id);
+CREATE INDEX IF NOT EXISTS metadata_user_title_idx ON metadata (user_id, title, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS metadata_user_title_idx;
+DROP INDEX IF EXISTS metadata_user_id_idx;
+-- +goose StatementEnd
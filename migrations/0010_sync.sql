@@ -0,0 +1,38 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Ревизии для синхронизации: счетчик пользователя увеличивается при каждом изменении,
+-- запись получает номер ревизии, удаленная запись остается tombstone с deleted_at
+ALTER TABLE "user" ADD COLUMN IF NOT EXISTS revision bigint NOT NULL DEFAULT 0;
+ALTER TABLE metadata
+	ADD COLUMN IF NOT EXISTS revision bigint NOT NULL DEFAULT 0,
+	ADD COLUMN IF NOT EXISTS deleted_at timestamp without time zone NULL;
+
+-- существующие записи получают ревизии по порядку создания
+UPDATE metadata m SET revision = r.rn
+FROM (SELECT id, row_number() OVER (PARTITION BY user_id ORDER BY id) AS rn FROM metadata) r
+WHERE m.id = r.id;
+UPDATE "user" u SET revision = COALESCE((SELECT max(revision) FROM metadata WHERE user_id = u.id), 0);
+
+CREATE INDEX IF NOT EXISTS metadata_user_revision_idx ON metadata (user_id, revision);
+
+-- Ревизии файлов, файлы в MinIO не имеют своей строки в БД
+CREATE TABLE IF NOT EXISTS file_revision (
+	user_id bigint NOT NULL,
+	name varchar NOT NULL,
+	revision bigint NOT NULL,
+	deleted boolean NOT NULL DEFAULT false,
+
+	CONSTRAINT file_revision_pk PRIMARY KEY (user_id, name),
+	CONSTRAINT file_revision_user_fk FOREIGN KEY (user_id) REFERENCES "user"(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS file_revision_user_revision_idx ON file_revision (user_id, revision);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS file_revision;
+DROP INDEX IF EXISTS metadata_user_revision_idx;
+DELETE FROM metadata WHERE deleted_at IS NOT NULL;
+ALTER TABLE metadata DROP COLUMN IF EXISTS deleted_at, DROP COLUMN IF EXISTS revision;
+ALTER TABLE "user" DROP COLUMN IF EXISTS revision;
+-- +goose StatementEnd
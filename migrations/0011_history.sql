@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Прежние версии записей: при изменении старое содержимое переносится сюда,
+-- revision - версия, которую содержимое имело, changed_at - когда его заменили
+CREATE TABLE IF NOT EXISTS metadata_history (
+	metadata_id bigint NOT NULL,
+	user_id bigint NOT NULL,
+	revision bigint NOT NULL,
+	title text NOT NULL,
+	card_number text NULL,
+	login text NULL,
+	password text NULL,
+	note text NOT NULL DEFAULT '',
+	bin_data bytea NULL,
+	key_hash varchar NOT NULL DEFAULT '',
+	card_holder text NOT NULL DEFAULT '',
+	card_exp_month smallint NOT NULL DEFAULT 0,
+	card_exp_year smallint NOT NULL DEFAULT 0,
+	card_cvv text NOT NULL DEFAULT '',
+	card_bank text NOT NULL DEFAULT '',
+	card_pin text NOT NULL DEFAULT '',
+	card_brand text NOT NULL DEFAULT '',
+	changed_at timestamp without time zone NOT NULL DEFAULT now(),
+
+	CONSTRAINT metadata_history_pk PRIMARY KEY (metadata_id, revision),
+	CONSTRAINT metadata_history_metadata_fk FOREIGN KEY (metadata_id) REFERENCES metadata(id) ON DELETE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS metadata_history;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Корзина: удаленная запись сохраняет содержимое, пока его не сотрет очистка (purged),
+-- tombstone остается для синхронизации
+ALTER TABLE metadata ADD COLUMN IF NOT EXISTS purged boolean NOT NULL DEFAULT false;
+-- до появления корзины удаление сразу стирало содержимое
+UPDATE metadata SET purged = true WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS metadata_trash_idx ON metadata (deleted_at) WHERE deleted_at IS NOT NULL AND NOT purged;
+
+ALTER TABLE file_revision
+	ADD COLUMN IF NOT EXISTS deleted_at timestamp without time zone NULL,
+	ADD COLUMN IF NOT EXISTS purged boolean NOT NULL DEFAULT false;
+UPDATE file_revision SET purged = true WHERE deleted;
+CREATE INDEX IF NOT EXISTS file_revision_trash_idx ON file_revision (deleted_at) WHERE deleted AND NOT purged;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS file_revision_trash_idx;
+ALTER TABLE file_revision DROP COLUMN IF EXISTS purged, DROP COLUMN IF EXISTS deleted_at;
+DROP INDEX IF EXISTS metadata_trash_idx;
+ALTER TABLE metadata DROP COLUMN IF EXISTS purged;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Загрузки файлов по частям: принятые байты лежат в каталоге загрузок под id сессии,
+-- повторная инициализация того же файла продолжает существующую сессию
+CREATE TABLE IF NOT EXISTS upload_session (
+	id varchar NOT NULL,
+	user_id bigint NOT NULL,
+	filename text NOT NULL,
+	size bigint NOT NULL,
+	sha256 varchar(64) NOT NULL,
+	created_at timestamp without time zone NOT NULL DEFAULT now(),
+
+	CONSTRAINT upload_session_pk PRIMARY KEY (id),
+	CONSTRAINT upload_session_file_uniq UNIQUE (user_id, filename, size, sha256),
+	CONSTRAINT upload_session_user_fk FOREIGN KEY (user_id) REFERENCES "user"(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS upload_session_created_idx ON upload_session (created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS upload_session;
+-- +goose StatementEnd
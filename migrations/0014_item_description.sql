@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Описание, которое пользователь задает файлу вместе с метаданными
+ALTER TABLE item_meta ADD COLUMN IF NOT EXISTS description text NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE item_meta DROP COLUMN IF EXISTS description;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Содержимое файлов при дедупликации: объект в общем бакете под SHA-256 и число версий файлов,
+-- которые на него ссылаются. Содержимое без ссылок удаляется вместе со строкой
+CREATE TABLE IF NOT EXISTS blob (
+	sha256 varchar(64) NOT NULL,
+	size bigint NOT NULL,
+	refs bigint NOT NULL DEFAULT 0,
+
+	CONSTRAINT blob_pk PRIMARY KEY (sha256)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS blob;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Хеши уже обмененных refresh-токенов: повторное предъявление такого токена значит, что он украден,
+-- и сеанс завершается
+CREATE TABLE IF NOT EXISTS session_refresh_used (
+	refresh_hash varchar NOT NULL,
+	session_id varchar NOT NULL,
+
+	CONSTRAINT session_refresh_used_pk PRIMARY KEY (refresh_hash),
+	CONSTRAINT session_refresh_used_session_fk FOREIGN KEY (session_id) REFERENCES "session"(id) ON DELETE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS session_refresh_used;
+-- +goose StatementEnd
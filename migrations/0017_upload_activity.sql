@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Брошенные загрузки удаляются по времени последней принятой части, а не по времени начала,
+-- иначе долгая загрузка большого файла удалялась бы посреди передачи
+ALTER TABLE upload_session ADD COLUMN IF NOT EXISTS updated_at timestamp without time zone NOT NULL DEFAULT now();
+UPDATE upload_session SET updated_at = created_at;
+DROP INDEX IF EXISTS upload_session_created_idx;
+CREATE INDEX IF NOT EXISTS upload_session_updated_idx ON upload_session (updated_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS upload_session_updated_idx;
+CREATE INDEX IF NOT EXISTS upload_session_created_idx ON upload_session (created_at);
+ALTER TABLE upload_session DROP COLUMN IF EXISTS updated_at;
+-- +goose StatementEnd
@@ -6,3 +6,8 @@ import (
 
 //go:embed "*.sql"
 var Migrations embed.FS
+
+// SQLite - отдельный набор миграций встроенной SQLite, лежит в каталоге sqlite
+//
+//go:embed "sqlite/*.sql"
+var SQLite embed.FS
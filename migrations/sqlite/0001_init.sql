@@ -0,0 +1,136 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Схема для встроенной SQLite, соответствует postgres-миграциям 0001-0015.
+-- Тип записи проверяется CHECK вместо enum, метаданные хранятся JSON-текстом вместо jsonb,
+-- время пишет сервер в UTC
+CREATE TABLE IF NOT EXISTS "user" (
+	id integer PRIMARY KEY AUTOINCREMENT,
+	login varchar NOT NULL UNIQUE,
+	password text NOT NULL,
+	last_update timestamp DEFAULT CURRENT_TIMESTAMP,
+	key_hash varchar NULL,
+	revision bigint NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS metadata (
+	id integer PRIMARY KEY AUTOINCREMENT,
+	user_id bigint NOT NULL REFERENCES "user"(id) ON DELETE CASCADE,
+	title text NOT NULL,
+	card_number text NULL,
+	login text NULL,
+	password text NULL,
+	dtype varchar NOT NULL CHECK (dtype IN ('CARD', 'LOGPASS', 'FILE', 'TEXT', 'BINARY')),
+	key_hash varchar NOT NULL DEFAULT '',
+	note text NOT NULL DEFAULT '',
+	bin_data blob NULL,
+	card_holder text NOT NULL DEFAULT '',
+	card_exp_month smallint NOT NULL DEFAULT 0,
+	card_exp_year smallint NOT NULL DEFAULT 0,
+	card_cvv text NOT NULL DEFAULT '',
+	card_bank text NOT NULL DEFAULT '',
+	card_pin text NOT NULL DEFAULT '',
+	card_brand text NOT NULL DEFAULT '',
+	revision bigint NOT NULL DEFAULT 0,
+	deleted_at timestamp NULL,
+	purged boolean NOT NULL DEFAULT false
+);
+CREATE INDEX IF NOT EXISTS metadata_user_id_idx ON metadata (user_id, id);
+CREATE INDEX IF NOT EXISTS metadata_user_title_idx ON metadata (user_id, title, id);
+CREATE INDEX IF NOT EXISTS metadata_user_revision_idx ON metadata (user_id, revision);
+CREATE INDEX IF NOT EXISTS metadata_trash_idx ON metadata (deleted_at) WHERE deleted_at IS NOT NULL AND NOT purged;
+
+CREATE TABLE IF NOT EXISTS metadata_history (
+	metadata_id bigint NOT NULL REFERENCES metadata(id) ON DELETE CASCADE,
+	user_id bigint NOT NULL,
+	revision bigint NOT NULL,
+	title text NOT NULL,
+	card_number text NULL,
+	login text NULL,
+	password text NULL,
+	note text NOT NULL DEFAULT '',
+	bin_data blob NULL,
+	key_hash varchar NOT NULL DEFAULT '',
+	card_holder text NOT NULL DEFAULT '',
+	card_exp_month smallint NOT NULL DEFAULT 0,
+	card_exp_year smallint NOT NULL DEFAULT 0,
+	card_cvv text NOT NULL DEFAULT '',
+	card_bank text NOT NULL DEFAULT '',
+	card_pin text NOT NULL DEFAULT '',
+	card_brand text NOT NULL DEFAULT '',
+	changed_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+	PRIMARY KEY (metadata_id, revision)
+);
+
+CREATE TABLE IF NOT EXISTS jwt_key (
+	kid varchar PRIMARY KEY,
+	secret text NOT NULL,
+	created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	retired_at timestamp NULL
+);
+
+CREATE TABLE IF NOT EXISTS "session" (
+	id varchar PRIMARY KEY,
+	user_id bigint NOT NULL REFERENCES "user"(id) ON DELETE CASCADE,
+	refresh_hash varchar NOT NULL UNIQUE,
+	device_info text NOT NULL DEFAULT '',
+	created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	expires_at timestamp NOT NULL,
+	revoked_at timestamp NULL
+);
+
+CREATE TABLE IF NOT EXISTS item_meta (
+	user_id bigint NOT NULL REFERENCES "user"(id) ON DELETE CASCADE,
+	kind varchar NOT NULL,
+	item_id varchar NOT NULL,
+	fields text NOT NULL DEFAULT '{}',
+	tags text NOT NULL DEFAULT '[]',
+	description text NOT NULL DEFAULT '',
+
+	PRIMARY KEY (user_id, kind, item_id)
+);
+
+CREATE TABLE IF NOT EXISTS file_revision (
+	user_id bigint NOT NULL REFERENCES "user"(id) ON DELETE CASCADE,
+	name varchar NOT NULL,
+	revision bigint NOT NULL,
+	deleted boolean NOT NULL DEFAULT false,
+	deleted_at timestamp NULL,
+	purged boolean NOT NULL DEFAULT false,
+
+	PRIMARY KEY (user_id, name)
+);
+CREATE INDEX IF NOT EXISTS file_revision_user_revision_idx ON file_revision (user_id, revision);
+CREATE INDEX IF NOT EXISTS file_revision_trash_idx ON file_revision (deleted_at) WHERE deleted AND NOT purged;
+
+CREATE TABLE IF NOT EXISTS upload_session (
+	id varchar PRIMARY KEY,
+	user_id bigint NOT NULL REFERENCES "user"(id) ON DELETE CASCADE,
+	filename text NOT NULL,
+	size bigint NOT NULL,
+	sha256 varchar(64) NOT NULL,
+	created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+	UNIQUE (user_id, filename, size, sha256)
+);
+CREATE INDEX IF NOT EXISTS upload_session_created_idx ON upload_session (created_at);
+
+CREATE TABLE IF NOT EXISTS blob (
+	sha256 varchar(64) PRIMARY KEY,
+	size bigint NOT NULL,
+	refs bigint NOT NULL DEFAULT 0
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS blob;
+DROP TABLE IF EXISTS upload_session;
+DROP TABLE IF EXISTS file_revision;
+DROP TABLE IF EXISTS item_meta;
+DROP TABLE IF EXISTS "session";
+DROP TABLE IF EXISTS jwt_key;
+DROP TABLE IF EXISTS metadata_history;
+DROP TABLE IF EXISTS metadata;
+DROP TABLE IF EXISTS "user";
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+-- соответствует postgres-миграции 0016
+CREATE TABLE IF NOT EXISTS session_refresh_used (
+	refresh_hash varchar PRIMARY KEY,
+	session_id varchar NOT NULL REFERENCES "session"(id) ON DELETE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS session_refresh_used;
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+-- соответствует postgres-миграции 0017
+-- ALTER TABLE в SQLite не принимает CURRENT_TIMESTAMP по умолчанию, время переносится из created_at
+ALTER TABLE upload_session ADD COLUMN updated_at timestamp NOT NULL DEFAULT '1970-01-01 00:00:00';
+UPDATE upload_session SET updated_at = created_at;
+DROP INDEX IF EXISTS upload_session_created_idx;
+CREATE INDEX IF NOT EXISTS upload_session_updated_idx ON upload_session (updated_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS upload_session_updated_idx;
+CREATE INDEX IF NOT EXISTS upload_session_created_idx ON upload_session (created_at);
+ALTER TABLE upload_session DROP COLUMN updated_at;
+-- +goose StatementEnd
@@ -12,6 +12,7 @@ import (
 	client "github.com/Arcadian-Sky/datakkeeper/tools/client"
 	gomock "github.com/golang/mock/gomock"
 	minio "github.com/minio/minio-go/v7"
+	lifecycle "github.com/minio/minio-go/v7/pkg/lifecycle"
 )
 
 // MockMinioClient is a mock of MinioClient interface.
@@ -52,6 +53,35 @@ func (mr *MockMinioClientMockRecorder) BucketExists(ctx, bucketName interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BucketExists", reflect.TypeOf((*MockMinioClient)(nil).BucketExists), ctx, bucketName)
 }
 
+// CopyObject mocks base method.
+func (m *MockMinioClient) CopyObject(ctx context.Context, dst minio.CopyDestOptions, src minio.CopySrcOptions) (minio.UploadInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CopyObject", ctx, dst, src)
+	ret0, _ := ret[0].(minio.UploadInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CopyObject indicates an expected call of CopyObject.
+func (mr *MockMinioClientMockRecorder) CopyObject(ctx, dst, src interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CopyObject", reflect.TypeOf((*MockMinioClient)(nil).CopyObject), ctx, dst, src)
+}
+
+// EnableVersioning mocks base method.
+func (m *MockMinioClient) EnableVersioning(ctx context.Context, bucketName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableVersioning", ctx, bucketName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnableVersioning indicates an expected call of EnableVersioning.
+func (mr *MockMinioClientMockRecorder) EnableVersioning(ctx, bucketName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableVersioning", reflect.TypeOf((*MockMinioClient)(nil).EnableVersioning), ctx, bucketName)
+}
+
 // GetObject mocks base method.
 func (m *MockMinioClient) GetObject(ctx context.Context, bucketName, objectName string, opts minio.GetObjectOptions) (client.MinioObject, error) {
 	m.ctrl.T.Helper()
@@ -139,6 +169,20 @@ func (mr *MockMinioClientMockRecorder) RemoveObject(ctx, bucketName, objectName,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveObject", reflect.TypeOf((*MockMinioClient)(nil).RemoveObject), ctx, bucketName, objectName, opts)
 }
 
+// SetBucketLifecycle mocks base method.
+func (m *MockMinioClient) SetBucketLifecycle(ctx context.Context, bucketName string, config *lifecycle.Configuration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBucketLifecycle", ctx, bucketName, config)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetBucketLifecycle indicates an expected call of SetBucketLifecycle.
+func (mr *MockMinioClientMockRecorder) SetBucketLifecycle(ctx, bucketName, config interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBucketLifecycle", reflect.TypeOf((*MockMinioClient)(nil).SetBucketLifecycle), ctx, bucketName, config)
+}
+
 // MockMinioObject is a mock of MinioObject interface.
 type MockMinioObject struct {
 	ctrl     *gomock.Controller
@@ -205,3 +249,33 @@ func (mr *MockMinioObjectMockRecorder) ReadAt(b, offset interface{}) *gomock.Cal
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadAt", reflect.TypeOf((*MockMinioObject)(nil).ReadAt), b, offset)
 }
+
+// Seek mocks base method.
+func (m *MockMinioObject) Seek(offset int64, whence int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Seek", offset, whence)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Seek indicates an expected call of Seek.
+func (mr *MockMinioObjectMockRecorder) Seek(offset, whence interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Seek", reflect.TypeOf((*MockMinioObject)(nil).Seek), offset, whence)
+}
+
+// Stat mocks base method.
+func (m *MockMinioObject) Stat() (minio.ObjectInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stat")
+	ret0, _ := ret[0].(minio.ObjectInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Stat indicates an expected call of Stat.
+func (mr *MockMinioObjectMockRecorder) Stat() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stat", reflect.TypeOf((*MockMinioObject)(nil).Stat))
+}
@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/server/repository/blob.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	model "github.com/Arcadian-Sky/datakkeeper/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockBlobRepository is a mock of BlobRepository interface.
+type MockBlobRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockBlobRepositoryMockRecorder
+}
+
+// MockBlobRepositoryMockRecorder is the mock recorder for MockBlobRepository.
+type MockBlobRepositoryMockRecorder struct {
+	mock *MockBlobRepository
+}
+
+// NewMockBlobRepository creates a new mock instance.
+func NewMockBlobRepository(ctrl *gomock.Controller) *MockBlobRepository {
+	mock := &MockBlobRepository{ctrl: ctrl}
+	mock.recorder = &MockBlobRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBlobRepository) EXPECT() *MockBlobRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockBlobRepository) Get(ctx context.Context, sha256 string) (io.ReadSeekCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, sha256)
+	ret0, _ := ret[0].(io.ReadSeekCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockBlobRepositoryMockRecorder) Get(ctx, sha256 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockBlobRepository)(nil).Get), ctx, sha256)
+}
+
+// Put mocks base method.
+func (m *MockBlobRepository) Put(ctx context.Context, sha256 string, size int64, r io.Reader) (model.Blob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Put", ctx, sha256, size, r)
+	ret0, _ := ret[0].(model.Blob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Put indicates an expected call of Put.
+func (mr *MockBlobRepositoryMockRecorder) Put(ctx, sha256, size, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockBlobRepository)(nil).Put), ctx, sha256, size, r)
+}
+
+// Ref mocks base method.
+func (m *MockBlobRepository) Ref(ctx context.Context, sha256 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ref", ctx, sha256)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ref indicates an expected call of Ref.
+func (mr *MockBlobRepositoryMockRecorder) Ref(ctx, sha256 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ref", reflect.TypeOf((*MockBlobRepository)(nil).Ref), ctx, sha256)
+}
+
+// Unref mocks base method.
+func (m *MockBlobRepository) Unref(ctx context.Context, sha256 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unref", ctx, sha256)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unref indicates an expected call of Unref.
+func (mr *MockBlobRepositoryMockRecorder) Unref(ctx, sha256 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unref", reflect.TypeOf((*MockBlobRepository)(nil).Unref), ctx, sha256)
+}
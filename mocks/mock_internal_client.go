@@ -5,8 +5,10 @@
 package mocks
 
 import (
+	context "context"
 	reflect "reflect"
 
+	client "github.com/Arcadian-Sky/datakkeeper/internal/client"
 	model "github.com/Arcadian-Sky/datakkeeper/internal/model"
 	gomock "github.com/golang/mock/gomock"
 )
@@ -48,32 +50,75 @@ func (mr *MockGRPCClientInterfaceMockRecorder) Authenticate(login, password inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockGRPCClientInterface)(nil).Authenticate), login, password)
 }
 
+// CreateFolder mocks base method.
+func (m *MockGRPCClientInterface) CreateFolder(path string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFolder", path)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateFolder indicates an expected call of CreateFolder.
+func (mr *MockGRPCClientInterfaceMockRecorder) CreateFolder(path interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFolder", reflect.TypeOf((*MockGRPCClientInterface)(nil).CreateFolder), path)
+}
+
 // Delete mocks base method.
-func (m *MockGRPCClientInterface) Delete(id int64) error {
+func (m *MockGRPCClientInterface) Delete(id, revision int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", id)
+	ret := m.ctrl.Call(m, "Delete", id, revision)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockGRPCClientInterfaceMockRecorder) Delete(id interface{}) *gomock.Call {
+func (mr *MockGRPCClientInterfaceMockRecorder) Delete(id, revision interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockGRPCClientInterface)(nil).Delete), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockGRPCClientInterface)(nil).Delete), id, revision)
 }
 
 // DeleteFile mocks base method.
-func (m *MockGRPCClientInterface) DeleteFile(fileName string) error {
+func (m *MockGRPCClientInterface) DeleteFile(fileName string, revision int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteFile", fileName)
+	ret := m.ctrl.Call(m, "DeleteFile", fileName, revision)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteFile indicates an expected call of DeleteFile.
-func (mr *MockGRPCClientInterfaceMockRecorder) DeleteFile(fileName interface{}) *gomock.Call {
+func (mr *MockGRPCClientInterfaceMockRecorder) DeleteFile(fileName, revision interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFile", reflect.TypeOf((*MockGRPCClientInterface)(nil).DeleteFile), fileName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFile", reflect.TypeOf((*MockGRPCClientInterface)(nil).DeleteFile), fileName, revision)
+}
+
+// EndSession mocks base method.
+func (m *MockGRPCClientInterface) EndSession(sessionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EndSession", sessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EndSession indicates an expected call of EndSession.
+func (mr *MockGRPCClientInterfaceMockRecorder) EndSession(sessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EndSession", reflect.TypeOf((*MockGRPCClientInterface)(nil).EndSession), sessionID)
+}
+
+// GetData mocks base method.
+func (m *MockGRPCClientInterface) GetData(id int64) (model.Data, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetData", id)
+	ret0, _ := ret[0].(model.Data)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetData indicates an expected call of GetData.
+func (mr *MockGRPCClientInterfaceMockRecorder) GetData(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetData", reflect.TypeOf((*MockGRPCClientInterface)(nil).GetData), id)
 }
 
 // GetDataList mocks base method.
@@ -120,6 +165,156 @@ func (mr *MockGRPCClientInterfaceMockRecorder) GetFileList() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFileList", reflect.TypeOf((*MockGRPCClientInterface)(nil).GetFileList))
 }
 
+// GetFileVersion mocks base method.
+func (m *MockGRPCClientInterface) GetFileVersion(fileName, versionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFileVersion", fileName, versionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetFileVersion indicates an expected call of GetFileVersion.
+func (mr *MockGRPCClientInterfaceMockRecorder) GetFileVersion(fileName, versionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFileVersion", reflect.TypeOf((*MockGRPCClientInterface)(nil).GetFileVersion), fileName, versionID)
+}
+
+// GetUsage mocks base method.
+func (m *MockGRPCClientInterface) GetUsage() (model.Usage, model.Quota, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsage")
+	ret0, _ := ret[0].(model.Usage)
+	ret1, _ := ret[1].(model.Quota)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetUsage indicates an expected call of GetUsage.
+func (mr *MockGRPCClientInterfaceMockRecorder) GetUsage() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsage", reflect.TypeOf((*MockGRPCClientInterface)(nil).GetUsage))
+}
+
+// ListData mocks base method.
+func (m *MockGRPCClientInterface) ListData(opts client.ListOptions) ([]model.Data, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListData", opts)
+	ret0, _ := ret[0].([]model.Data)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListData indicates an expected call of ListData.
+func (mr *MockGRPCClientInterfaceMockRecorder) ListData(opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListData", reflect.TypeOf((*MockGRPCClientInterface)(nil).ListData), opts)
+}
+
+// ListDataHistory mocks base method.
+func (m *MockGRPCClientInterface) ListDataHistory(id int64) ([]model.DataRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDataHistory", id)
+	ret0, _ := ret[0].([]model.DataRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDataHistory indicates an expected call of ListDataHistory.
+func (mr *MockGRPCClientInterfaceMockRecorder) ListDataHistory(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDataHistory", reflect.TypeOf((*MockGRPCClientInterface)(nil).ListDataHistory), id)
+}
+
+// ListFileVersions mocks base method.
+func (m *MockGRPCClientInterface) ListFileVersions(fileName string) ([]model.FileVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFileVersions", fileName)
+	ret0, _ := ret[0].([]model.FileVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFileVersions indicates an expected call of ListFileVersions.
+func (mr *MockGRPCClientInterfaceMockRecorder) ListFileVersions(fileName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFileVersions", reflect.TypeOf((*MockGRPCClientInterface)(nil).ListFileVersions), fileName)
+}
+
+// ListFiles mocks base method.
+func (m *MockGRPCClientInterface) ListFiles(opts client.ListOptions) ([]model.FileItem, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFiles", opts)
+	ret0, _ := ret[0].([]model.FileItem)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListFiles indicates an expected call of ListFiles.
+func (mr *MockGRPCClientInterfaceMockRecorder) ListFiles(opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFiles", reflect.TypeOf((*MockGRPCClientInterface)(nil).ListFiles), opts)
+}
+
+// ListSessions mocks base method.
+func (m *MockGRPCClientInterface) ListSessions() ([]model.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSessions")
+	ret0, _ := ret[0].([]model.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSessions indicates an expected call of ListSessions.
+func (mr *MockGRPCClientInterfaceMockRecorder) ListSessions() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSessions", reflect.TypeOf((*MockGRPCClientInterface)(nil).ListSessions))
+}
+
+// ListTrash mocks base method.
+func (m *MockGRPCClientInterface) ListTrash() ([]model.TrashItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTrash")
+	ret0, _ := ret[0].([]model.TrashItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTrash indicates an expected call of ListTrash.
+func (mr *MockGRPCClientInterfaceMockRecorder) ListTrash() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrash", reflect.TypeOf((*MockGRPCClientInterface)(nil).ListTrash))
+}
+
+// MoveFile mocks base method.
+func (m *MockGRPCClientInterface) MoveFile(fileName, folder string, revision int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveFile", fileName, folder, revision)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveFile indicates an expected call of MoveFile.
+func (mr *MockGRPCClientInterfaceMockRecorder) MoveFile(fileName, folder, revision interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveFile", reflect.TypeOf((*MockGRPCClientInterface)(nil).MoveFile), fileName, folder, revision)
+}
+
+// RefreshSession mocks base method.
+func (m *MockGRPCClientInterface) RefreshSession() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshSession")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RefreshSession indicates an expected call of RefreshSession.
+func (mr *MockGRPCClientInterfaceMockRecorder) RefreshSession() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshSession", reflect.TypeOf((*MockGRPCClientInterface)(nil).RefreshSession))
+}
+
 // Register mocks base method.
 func (m *MockGRPCClientInterface) Register(login, password string) error {
 	m.ctrl.T.Helper()
@@ -134,18 +329,88 @@ func (mr *MockGRPCClientInterfaceMockRecorder) Register(login, password interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockGRPCClientInterface)(nil).Register), login, password)
 }
 
+// RenameFile mocks base method.
+func (m *MockGRPCClientInterface) RenameFile(fileName, newName string, revision int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameFile", fileName, newName, revision)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RenameFile indicates an expected call of RenameFile.
+func (mr *MockGRPCClientInterfaceMockRecorder) RenameFile(fileName, newName, revision interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameFile", reflect.TypeOf((*MockGRPCClientInterface)(nil).RenameFile), fileName, newName, revision)
+}
+
+// RestoreDataRevision mocks base method.
+func (m *MockGRPCClientInterface) RestoreDataRevision(id, revision, expected int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreDataRevision", id, revision, expected)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreDataRevision indicates an expected call of RestoreDataRevision.
+func (mr *MockGRPCClientInterfaceMockRecorder) RestoreDataRevision(id, revision, expected interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreDataRevision", reflect.TypeOf((*MockGRPCClientInterface)(nil).RestoreDataRevision), id, revision, expected)
+}
+
+// RestoreFileVersion mocks base method.
+func (m *MockGRPCClientInterface) RestoreFileVersion(fileName, versionID string, revision int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreFileVersion", fileName, versionID, revision)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreFileVersion indicates an expected call of RestoreFileVersion.
+func (mr *MockGRPCClientInterfaceMockRecorder) RestoreFileVersion(fileName, versionID, revision interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreFileVersion", reflect.TypeOf((*MockGRPCClientInterface)(nil).RestoreFileVersion), fileName, versionID, revision)
+}
+
+// RestoreFromTrash mocks base method.
+func (m *MockGRPCClientInterface) RestoreFromTrash(item model.TrashItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreFromTrash", item)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreFromTrash indicates an expected call of RestoreFromTrash.
+func (mr *MockGRPCClientInterfaceMockRecorder) RestoreFromTrash(item interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreFromTrash", reflect.TypeOf((*MockGRPCClientInterface)(nil).RestoreFromTrash), item)
+}
+
+// SaveBinary mocks base method.
+func (m *MockGRPCClientInterface) SaveBinary(title string, data []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveBinary", title, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveBinary indicates an expected call of SaveBinary.
+func (mr *MockGRPCClientInterfaceMockRecorder) SaveBinary(title, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveBinary", reflect.TypeOf((*MockGRPCClientInterface)(nil).SaveBinary), title, data)
+}
+
 // SaveCard mocks base method.
-func (m *MockGRPCClientInterface) SaveCard(title, card string) error {
+func (m *MockGRPCClientInterface) SaveCard(card model.Data) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SaveCard", title, card)
+	ret := m.ctrl.Call(m, "SaveCard", card)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // SaveCard indicates an expected call of SaveCard.
-func (mr *MockGRPCClientInterfaceMockRecorder) SaveCard(title, card interface{}) *gomock.Call {
+func (mr *MockGRPCClientInterfaceMockRecorder) SaveCard(card interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveCard", reflect.TypeOf((*MockGRPCClientInterface)(nil).SaveCard), title, card)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveCard", reflect.TypeOf((*MockGRPCClientInterface)(nil).SaveCard), card)
 }
 
 // SaveLoginPass mocks base method.
@@ -162,16 +427,87 @@ func (mr *MockGRPCClientInterfaceMockRecorder) SaveLoginPass(domain, login, pass
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveLoginPass", reflect.TypeOf((*MockGRPCClientInterface)(nil).SaveLoginPass), domain, login, pass)
 }
 
+// SaveNote mocks base method.
+func (m *MockGRPCClientInterface) SaveNote(title, note string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveNote", title, note)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveNote indicates an expected call of SaveNote.
+func (mr *MockGRPCClientInterfaceMockRecorder) SaveNote(title, note interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveNote", reflect.TypeOf((*MockGRPCClientInterface)(nil).SaveNote), title, note)
+}
+
+// Sync mocks base method.
+func (m *MockGRPCClientInterface) Sync(since int64) (*model.SyncChanges, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sync", since)
+	ret0, _ := ret[0].(*model.SyncChanges)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Sync indicates an expected call of Sync.
+func (mr *MockGRPCClientInterfaceMockRecorder) Sync(since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sync", reflect.TypeOf((*MockGRPCClientInterface)(nil).Sync), since)
+}
+
+// UpdateData mocks base method.
+func (m *MockGRPCClientInterface) UpdateData(data model.Data) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateData", data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateData indicates an expected call of UpdateData.
+func (mr *MockGRPCClientInterfaceMockRecorder) UpdateData(data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateData", reflect.TypeOf((*MockGRPCClientInterface)(nil).UpdateData), data)
+}
+
+// UpdateFileMeta mocks base method.
+func (m *MockGRPCClientInterface) UpdateFileMeta(fileName string, revision int64, desc string, meta map[string]string, tags []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFileMeta", fileName, revision, desc, meta, tags)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateFileMeta indicates an expected call of UpdateFileMeta.
+func (mr *MockGRPCClientInterfaceMockRecorder) UpdateFileMeta(fileName, revision, desc, meta, tags interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFileMeta", reflect.TypeOf((*MockGRPCClientInterface)(nil).UpdateFileMeta), fileName, revision, desc, meta, tags)
+}
+
 // UploadFile mocks base method.
-func (m *MockGRPCClientInterface) UploadFile(filePath string) error {
+func (m *MockGRPCClientInterface) UploadFile(filePath, folder string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UploadFile", filePath)
+	ret := m.ctrl.Call(m, "UploadFile", filePath, folder)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UploadFile indicates an expected call of UploadFile.
-func (mr *MockGRPCClientInterfaceMockRecorder) UploadFile(filePath interface{}) *gomock.Call {
+func (mr *MockGRPCClientInterfaceMockRecorder) UploadFile(filePath, folder interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadFile", reflect.TypeOf((*MockGRPCClientInterface)(nil).UploadFile), filePath, folder)
+}
+
+// WatchChanges mocks base method.
+func (m *MockGRPCClientInterface) WatchChanges(ctx context.Context, onEvent func(model.ChangeEvent)) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchChanges", ctx, onEvent)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WatchChanges indicates an expected call of WatchChanges.
+func (mr *MockGRPCClientInterfaceMockRecorder) WatchChanges(ctx, onEvent interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadFile", reflect.TypeOf((*MockGRPCClientInterface)(nil).UploadFile), filePath)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchChanges", reflect.TypeOf((*MockGRPCClientInterface)(nil).WatchChanges), ctx, onEvent)
 }
@@ -0,0 +1,146 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/server/repository/itemmeta.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/Arcadian-Sky/datakkeeper/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockItemMetaRepository is a mock of ItemMetaRepository interface.
+type MockItemMetaRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockItemMetaRepositoryMockRecorder
+}
+
+// MockItemMetaRepositoryMockRecorder is the mock recorder for MockItemMetaRepository.
+type MockItemMetaRepositoryMockRecorder struct {
+	mock *MockItemMetaRepository
+}
+
+// NewMockItemMetaRepository creates a new mock instance.
+func NewMockItemMetaRepository(ctrl *gomock.Controller) *MockItemMetaRepository {
+	mock := &MockItemMetaRepository{ctrl: ctrl}
+	mock.recorder = &MockItemMetaRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockItemMetaRepository) EXPECT() *MockItemMetaRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockItemMetaRepository) Delete(ctx context.Context, meta *model.ItemMeta) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, meta)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockItemMetaRepositoryMockRecorder) Delete(ctx, meta interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockItemMetaRepository)(nil).Delete), ctx, meta)
+}
+
+// Get mocks base method.
+func (m *MockItemMetaRepository) Get(ctx context.Context, meta *model.ItemMeta) (*model.ItemMeta, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, meta)
+	ret0, _ := ret[0].(*model.ItemMeta)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockItemMetaRepositoryMockRecorder) Get(ctx, meta interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockItemMetaRepository)(nil).Get), ctx, meta)
+}
+
+// List mocks base method.
+func (m *MockItemMetaRepository) List(ctx context.Context, userID int64, kind string) (map[string]model.ItemMeta, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, userID, kind)
+	ret0, _ := ret[0].(map[string]model.ItemMeta)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockItemMetaRepositoryMockRecorder) List(ctx, userID, kind interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockItemMetaRepository)(nil).List), ctx, userID, kind)
+}
+
+// Rename mocks base method.
+func (m *MockItemMetaRepository) Rename(ctx context.Context, userID int64, kind, from, to string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rename", ctx, userID, kind, from, to)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Rename indicates an expected call of Rename.
+func (mr *MockItemMetaRepositoryMockRecorder) Rename(ctx, userID, kind, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rename", reflect.TypeOf((*MockItemMetaRepository)(nil).Rename), ctx, userID, kind, from, to)
+}
+
+// Set mocks base method.
+func (m *MockItemMetaRepository) Set(ctx context.Context, meta *model.ItemMeta) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", ctx, meta)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockItemMetaRepositoryMockRecorder) Set(ctx, meta interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockItemMetaRepository)(nil).Set), ctx, meta)
+}
+
+// MockItemMetaSearcher is a mock of ItemMetaSearcher interface.
+type MockItemMetaSearcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockItemMetaSearcherMockRecorder
+}
+
+// MockItemMetaSearcherMockRecorder is the mock recorder for MockItemMetaSearcher.
+type MockItemMetaSearcherMockRecorder struct {
+	mock *MockItemMetaSearcher
+}
+
+// NewMockItemMetaSearcher creates a new mock instance.
+func NewMockItemMetaSearcher(ctrl *gomock.Controller) *MockItemMetaSearcher {
+	mock := &MockItemMetaSearcher{ctrl: ctrl}
+	mock.recorder = &MockItemMetaSearcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockItemMetaSearcher) EXPECT() *MockItemMetaSearcherMockRecorder {
+	return m.recorder
+}
+
+// Search mocks base method.
+func (m *MockItemMetaSearcher) Search(ctx context.Context, userID int64, kind, text string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, userID, kind, text)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockItemMetaSearcherMockRecorder) Search(ctx, userID, kind, text interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockItemMetaSearcher)(nil).Search), ctx, userID, kind, text)
+}
@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/server/repository/keys.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	model "github.com/Arcadian-Sky/datakkeeper/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockKeyRepository is a mock of KeyRepository interface.
+type MockKeyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockKeyRepositoryMockRecorder
+}
+
+// MockKeyRepositoryMockRecorder is the mock recorder for MockKeyRepository.
+type MockKeyRepositoryMockRecorder struct {
+	mock *MockKeyRepository
+}
+
+// NewMockKeyRepository creates a new mock instance.
+func NewMockKeyRepository(ctrl *gomock.Controller) *MockKeyRepository {
+	mock := &MockKeyRepository{ctrl: ctrl}
+	mock.recorder = &MockKeyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKeyRepository) EXPECT() *MockKeyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetKeys mocks base method.
+func (m *MockKeyRepository) GetKeys(ctx context.Context, since time.Time) ([]model.SigningKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKeys", ctx, since)
+	ret0, _ := ret[0].([]model.SigningKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKeys indicates an expected call of GetKeys.
+func (mr *MockKeyRepositoryMockRecorder) GetKeys(ctx, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKeys", reflect.TypeOf((*MockKeyRepository)(nil).GetKeys), ctx, since)
+}
+
+// Rotate mocks base method.
+func (m *MockKeyRepository) Rotate(ctx context.Context, key *model.SigningKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rotate", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Rotate indicates an expected call of Rotate.
+func (mr *MockKeyRepositoryMockRecorder) Rotate(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rotate", reflect.TypeOf((*MockKeyRepository)(nil).Rotate), ctx, key)
+}
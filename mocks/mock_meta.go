@@ -7,6 +7,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	model "github.com/Arcadian-Sky/datakkeeper/internal/model"
 	gomock "github.com/golang/mock/gomock"
@@ -35,19 +36,137 @@ func (m *MockDataRepository) EXPECT() *MockDataRepositoryMockRecorder {
 	return m.recorder
 }
 
+// Count mocks base method.
+func (m *MockDataRepository) Count(ctx context.Context, userID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockDataRepositoryMockRecorder) Count(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockDataRepository)(nil).Count), ctx, userID)
+}
+
+// Delete mocks base method.
+func (m *MockDataRepository) Delete(ctx context.Context, data *model.Data) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockDataRepositoryMockRecorder) Delete(ctx, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockDataRepository)(nil).Delete), ctx, data)
+}
+
+// Get mocks base method.
+func (m *MockDataRepository) Get(ctx context.Context, data *model.Data) (*model.Data, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, data)
+	ret0, _ := ret[0].(*model.Data)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockDataRepositoryMockRecorder) Get(ctx, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockDataRepository)(nil).Get), ctx, data)
+}
+
 // GetList mocks base method.
-func (m *MockDataRepository) GetList(ctx context.Context, user *model.User) ([]model.Data, error) {
+func (m *MockDataRepository) GetList(ctx context.Context, user *model.User, query model.ListQuery) ([]model.Data, *model.ListCursor, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetList", ctx, user)
+	ret := m.ctrl.Call(m, "GetList", ctx, user, query)
 	ret0, _ := ret[0].([]model.Data)
+	ret1, _ := ret[1].(*model.ListCursor)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetList indicates an expected call of GetList.
+func (mr *MockDataRepositoryMockRecorder) GetList(ctx, user, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetList", reflect.TypeOf((*MockDataRepository)(nil).GetList), ctx, user, query)
+}
+
+// History mocks base method.
+func (m *MockDataRepository) History(ctx context.Context, data *model.Data) ([]model.DataRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "History", ctx, data)
+	ret0, _ := ret[0].([]model.DataRevision)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetList indicates an expected call of GetList.
-func (mr *MockDataRepositoryMockRecorder) GetList(ctx, user interface{}) *gomock.Call {
+// History indicates an expected call of History.
+func (mr *MockDataRepositoryMockRecorder) History(ctx, data interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetList", reflect.TypeOf((*MockDataRepository)(nil).GetList), ctx, user)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "History", reflect.TypeOf((*MockDataRepository)(nil).History), ctx, data)
+}
+
+// ListTrash mocks base method.
+func (m *MockDataRepository) ListTrash(ctx context.Context, userID int64) ([]model.TrashItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTrash", ctx, userID)
+	ret0, _ := ret[0].([]model.TrashItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTrash indicates an expected call of ListTrash.
+func (mr *MockDataRepositoryMockRecorder) ListTrash(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrash", reflect.TypeOf((*MockDataRepository)(nil).ListTrash), ctx, userID)
+}
+
+// Purge mocks base method.
+func (m *MockDataRepository) Purge(ctx context.Context, before time.Time) ([]model.PurgedItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx, before)
+	ret0, _ := ret[0].([]model.PurgedItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockDataRepositoryMockRecorder) Purge(ctx, before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockDataRepository)(nil).Purge), ctx, before)
+}
+
+// Restore mocks base method.
+func (m *MockDataRepository) Restore(ctx context.Context, data *model.Data, revision int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, data, revision)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockDataRepositoryMockRecorder) Restore(ctx, data, revision interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockDataRepository)(nil).Restore), ctx, data, revision)
+}
+
+// RestoreDeleted mocks base method.
+func (m *MockDataRepository) RestoreDeleted(ctx context.Context, data *model.Data) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreDeleted", ctx, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreDeleted indicates an expected call of RestoreDeleted.
+func (mr *MockDataRepositoryMockRecorder) RestoreDeleted(ctx, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreDeleted", reflect.TypeOf((*MockDataRepository)(nil).RestoreDeleted), ctx, data)
 }
 
 // Save mocks base method.
@@ -64,3 +183,17 @@ func (mr *MockDataRepositoryMockRecorder) Save(ctx, data interface{}) *gomock.Ca
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockDataRepository)(nil).Save), ctx, data)
 }
+
+// Update mocks base method.
+func (m *MockDataRepository) Update(ctx context.Context, data *model.Data) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockDataRepositoryMockRecorder) Update(ctx, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockDataRepository)(nil).Update), ctx, data)
+}
@@ -6,8 +6,9 @@ package mocks
 
 import (
 	context "context"
-	os "os"
+	io "io"
 	reflect "reflect"
+	time "time"
 
 	model "github.com/Arcadian-Sky/datakkeeper/internal/model"
 	gomock "github.com/golang/mock/gomock"
@@ -51,6 +52,20 @@ func (mr *MockFileRepositoryMockRecorder) CreateContainer(ctx, user interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateContainer", reflect.TypeOf((*MockFileRepository)(nil).CreateContainer), ctx, user)
 }
 
+// CreateFolder mocks base method.
+func (m *MockFileRepository) CreateFolder(ctx context.Context, folder string, user *model.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFolder", ctx, folder, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateFolder indicates an expected call of CreateFolder.
+func (mr *MockFileRepositoryMockRecorder) CreateFolder(ctx, folder, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFolder", reflect.TypeOf((*MockFileRepository)(nil).CreateFolder), ctx, folder, user)
+}
+
 // DeleteFile mocks base method.
 func (m *MockFileRepository) DeleteFile(ctx context.Context, fileID string, user *model.User) error {
 	m.ctrl.T.Helper()
@@ -65,11 +80,25 @@ func (mr *MockFileRepositoryMockRecorder) DeleteFile(ctx, fileID, user interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFile", reflect.TypeOf((*MockFileRepository)(nil).DeleteFile), ctx, fileID, user)
 }
 
+// ExpireVersions mocks base method.
+func (m *MockFileRepository) ExpireVersions(ctx context.Context, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireVersions", ctx, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExpireVersions indicates an expected call of ExpireVersions.
+func (mr *MockFileRepositoryMockRecorder) ExpireVersions(ctx, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireVersions", reflect.TypeOf((*MockFileRepository)(nil).ExpireVersions), ctx, now)
+}
+
 // GetFile mocks base method.
-func (m *MockFileRepository) GetFile(ctx context.Context, fileID string, user *model.User) (*os.File, error) {
+func (m *MockFileRepository) GetFile(ctx context.Context, fileID string, user *model.User) (io.ReadSeekCloser, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetFile", ctx, fileID, user)
-	ret0, _ := ret[0].(*os.File)
+	ret0, _ := ret[0].(io.ReadSeekCloser)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -81,30 +110,163 @@ func (mr *MockFileRepositoryMockRecorder) GetFile(ctx, fileID, user interface{})
 }
 
 // GetFileList mocks base method.
-func (m *MockFileRepository) GetFileList(ctx context.Context, user *model.User) ([]model.FileItem, error) {
+func (m *MockFileRepository) GetFileList(ctx context.Context, user *model.User, query model.ListQuery, meta map[string]model.ItemMeta) ([]model.FileItem, *model.ListCursor, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetFileList", ctx, user)
+	ret := m.ctrl.Call(m, "GetFileList", ctx, user, query, meta)
 	ret0, _ := ret[0].([]model.FileItem)
+	ret1, _ := ret[1].(*model.ListCursor)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetFileList indicates an expected call of GetFileList.
+func (mr *MockFileRepositoryMockRecorder) GetFileList(ctx, user, query, meta interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFileList", reflect.TypeOf((*MockFileRepository)(nil).GetFileList), ctx, user, query, meta)
+}
+
+// GetFileVersion mocks base method.
+func (m *MockFileRepository) GetFileVersion(ctx context.Context, fileID, versionID string, user *model.User) (io.ReadSeekCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFileVersion", ctx, fileID, versionID, user)
+	ret0, _ := ret[0].(io.ReadSeekCloser)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetFileList indicates an expected call of GetFileList.
-func (mr *MockFileRepositoryMockRecorder) GetFileList(ctx, user interface{}) *gomock.Call {
+// GetFileVersion indicates an expected call of GetFileVersion.
+func (mr *MockFileRepositoryMockRecorder) GetFileVersion(ctx, fileID, versionID, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFileVersion", reflect.TypeOf((*MockFileRepository)(nil).GetFileVersion), ctx, fileID, versionID, user)
+}
+
+// ListFileVersions mocks base method.
+func (m *MockFileRepository) ListFileVersions(ctx context.Context, fileID string, user *model.User) ([]model.FileVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFileVersions", ctx, fileID, user)
+	ret0, _ := ret[0].([]model.FileVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFileVersions indicates an expected call of ListFileVersions.
+func (mr *MockFileRepositoryMockRecorder) ListFileVersions(ctx, fileID, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFileVersions", reflect.TypeOf((*MockFileRepository)(nil).ListFileVersions), ctx, fileID, user)
+}
+
+// MoveFile mocks base method.
+func (m *MockFileRepository) MoveFile(ctx context.Context, from, to string, user *model.User) ([]model.FileMove, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveFile", ctx, from, to, user)
+	ret0, _ := ret[0].([]model.FileMove)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveFile indicates an expected call of MoveFile.
+func (mr *MockFileRepositoryMockRecorder) MoveFile(ctx, from, to, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveFile", reflect.TypeOf((*MockFileRepository)(nil).MoveFile), ctx, from, to, user)
+}
+
+// MovedUsage mocks base method.
+func (m *MockFileRepository) MovedUsage(ctx context.Context, from string, user *model.User) (model.Usage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MovedUsage", ctx, from, user)
+	ret0, _ := ret[0].(model.Usage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MovedUsage indicates an expected call of MovedUsage.
+func (mr *MockFileRepositoryMockRecorder) MovedUsage(ctx, from, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MovedUsage", reflect.TypeOf((*MockFileRepository)(nil).MovedUsage), ctx, from, user)
+}
+
+// PurgeFile mocks base method.
+func (m *MockFileRepository) PurgeFile(ctx context.Context, fileID string, user *model.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeFile", ctx, fileID, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeFile indicates an expected call of PurgeFile.
+func (mr *MockFileRepositoryMockRecorder) PurgeFile(ctx, fileID, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeFile", reflect.TypeOf((*MockFileRepository)(nil).PurgeFile), ctx, fileID, user)
+}
+
+// ReplacedUsage mocks base method.
+func (m *MockFileRepository) ReplacedUsage(ctx context.Context, fileName string, user *model.User) (model.Usage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplacedUsage", ctx, fileName, user)
+	ret0, _ := ret[0].(model.Usage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplacedUsage indicates an expected call of ReplacedUsage.
+func (mr *MockFileRepositoryMockRecorder) ReplacedUsage(ctx, fileName, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplacedUsage", reflect.TypeOf((*MockFileRepository)(nil).ReplacedUsage), ctx, fileName, user)
+}
+
+// RestoreFile mocks base method.
+func (m *MockFileRepository) RestoreFile(ctx context.Context, fileID string, user *model.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreFile", ctx, fileID, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreFile indicates an expected call of RestoreFile.
+func (mr *MockFileRepositoryMockRecorder) RestoreFile(ctx, fileID, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreFile", reflect.TypeOf((*MockFileRepository)(nil).RestoreFile), ctx, fileID, user)
+}
+
+// RestoreFileVersion mocks base method.
+func (m *MockFileRepository) RestoreFileVersion(ctx context.Context, fileID, versionID string, user *model.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreFileVersion", ctx, fileID, versionID, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreFileVersion indicates an expected call of RestoreFileVersion.
+func (mr *MockFileRepositoryMockRecorder) RestoreFileVersion(ctx, fileID, versionID, user interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFileList", reflect.TypeOf((*MockFileRepository)(nil).GetFileList), ctx, user)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreFileVersion", reflect.TypeOf((*MockFileRepository)(nil).RestoreFileVersion), ctx, fileID, versionID, user)
 }
 
 // UploadFile mocks base method.
-func (m *MockFileRepository) UploadFile(ctx context.Context, user *model.User, objectName string, file *os.File) error {
+func (m *MockFileRepository) UploadFile(ctx context.Context, user *model.User, file *model.FileItem, r io.Reader) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UploadFile", ctx, user, objectName, file)
+	ret := m.ctrl.Call(m, "UploadFile", ctx, user, file, r)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UploadFile indicates an expected call of UploadFile.
-func (mr *MockFileRepositoryMockRecorder) UploadFile(ctx, user, objectName, file interface{}) *gomock.Call {
+func (mr *MockFileRepositoryMockRecorder) UploadFile(ctx, user, file, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadFile", reflect.TypeOf((*MockFileRepository)(nil).UploadFile), ctx, user, file, r)
+}
+
+// Usage mocks base method.
+func (m *MockFileRepository) Usage(ctx context.Context, user *model.User) (model.Usage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Usage", ctx, user)
+	ret0, _ := ret[0].(model.Usage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Usage indicates an expected call of Usage.
+func (mr *MockFileRepositoryMockRecorder) Usage(ctx, user interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadFile", reflect.TypeOf((*MockFileRepository)(nil).UploadFile), ctx, user, objectName, file)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Usage", reflect.TypeOf((*MockFileRepository)(nil).Usage), ctx, user)
 }
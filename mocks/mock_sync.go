@@ -0,0 +1,171 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/server/repository/sync.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	model "github.com/Arcadian-Sky/datakkeeper/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSyncRepository is a mock of SyncRepository interface.
+type MockSyncRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSyncRepositoryMockRecorder
+}
+
+// MockSyncRepositoryMockRecorder is the mock recorder for MockSyncRepository.
+type MockSyncRepositoryMockRecorder struct {
+	mock *MockSyncRepository
+}
+
+// NewMockSyncRepository creates a new mock instance.
+func NewMockSyncRepository(ctrl *gomock.Controller) *MockSyncRepository {
+	mock := &MockSyncRepository{ctrl: ctrl}
+	mock.recorder = &MockSyncRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSyncRepository) EXPECT() *MockSyncRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Changes mocks base method.
+func (m *MockSyncRepository) Changes(ctx context.Context, userID, since int64, limit int) (*model.SyncChanges, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Changes", ctx, userID, since, limit)
+	ret0, _ := ret[0].(*model.SyncChanges)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Changes indicates an expected call of Changes.
+func (mr *MockSyncRepositoryMockRecorder) Changes(ctx, userID, since, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Changes", reflect.TypeOf((*MockSyncRepository)(nil).Changes), ctx, userID, since, limit)
+}
+
+// DeleteFile mocks base method.
+func (m *MockSyncRepository) DeleteFile(ctx context.Context, userID int64, name string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFile", ctx, userID, name)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteFile indicates an expected call of DeleteFile.
+func (mr *MockSyncRepositoryMockRecorder) DeleteFile(ctx, userID, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFile", reflect.TypeOf((*MockSyncRepository)(nil).DeleteFile), ctx, userID, name)
+}
+
+// ExpiredFiles mocks base method.
+func (m *MockSyncRepository) ExpiredFiles(ctx context.Context, before time.Time) ([]model.PurgedItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpiredFiles", ctx, before)
+	ret0, _ := ret[0].([]model.PurgedItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpiredFiles indicates an expected call of ExpiredFiles.
+func (mr *MockSyncRepositoryMockRecorder) ExpiredFiles(ctx, before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpiredFiles", reflect.TypeOf((*MockSyncRepository)(nil).ExpiredFiles), ctx, before)
+}
+
+// FileRevision mocks base method.
+func (m *MockSyncRepository) FileRevision(ctx context.Context, userID int64, name string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FileRevision", ctx, userID, name)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FileRevision indicates an expected call of FileRevision.
+func (mr *MockSyncRepositoryMockRecorder) FileRevision(ctx, userID, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FileRevision", reflect.TypeOf((*MockSyncRepository)(nil).FileRevision), ctx, userID, name)
+}
+
+// FileRevisions mocks base method.
+func (m *MockSyncRepository) FileRevisions(ctx context.Context, userID int64) (map[string]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FileRevisions", ctx, userID)
+	ret0, _ := ret[0].(map[string]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FileRevisions indicates an expected call of FileRevisions.
+func (mr *MockSyncRepositoryMockRecorder) FileRevisions(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FileRevisions", reflect.TypeOf((*MockSyncRepository)(nil).FileRevisions), ctx, userID)
+}
+
+// ListTrash mocks base method.
+func (m *MockSyncRepository) ListTrash(ctx context.Context, userID int64) ([]model.TrashItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTrash", ctx, userID)
+	ret0, _ := ret[0].([]model.TrashItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTrash indicates an expected call of ListTrash.
+func (mr *MockSyncRepositoryMockRecorder) ListTrash(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrash", reflect.TypeOf((*MockSyncRepository)(nil).ListTrash), ctx, userID)
+}
+
+// MarkFilePurged mocks base method.
+func (m *MockSyncRepository) MarkFilePurged(ctx context.Context, userID int64, name string, before time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkFilePurged", ctx, userID, name, before)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkFilePurged indicates an expected call of MarkFilePurged.
+func (mr *MockSyncRepositoryMockRecorder) MarkFilePurged(ctx, userID, name, before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkFilePurged", reflect.TypeOf((*MockSyncRepository)(nil).MarkFilePurged), ctx, userID, name, before)
+}
+
+// MoveFile mocks base method.
+func (m *MockSyncRepository) MoveFile(ctx context.Context, userID int64, from, to string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveFile", ctx, userID, from, to)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveFile indicates an expected call of MoveFile.
+func (mr *MockSyncRepositoryMockRecorder) MoveFile(ctx, userID, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveFile", reflect.TypeOf((*MockSyncRepository)(nil).MoveFile), ctx, userID, from, to)
+}
+
+// TouchFile mocks base method.
+func (m *MockSyncRepository) TouchFile(ctx context.Context, userID int64, name string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchFile", ctx, userID, name)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TouchFile indicates an expected call of TouchFile.
+func (mr *MockSyncRepositoryMockRecorder) TouchFile(ctx, userID, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchFile", reflect.TypeOf((*MockSyncRepository)(nil).TouchFile), ctx, userID, name)
+}
@@ -0,0 +1,141 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/server/repository/upload.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	os "os"
+	reflect "reflect"
+	time "time"
+
+	model "github.com/Arcadian-Sky/datakkeeper/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUploadRepository is a mock of UploadRepository interface.
+type MockUploadRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockUploadRepositoryMockRecorder
+}
+
+// MockUploadRepositoryMockRecorder is the mock recorder for MockUploadRepository.
+type MockUploadRepositoryMockRecorder struct {
+	mock *MockUploadRepository
+}
+
+// NewMockUploadRepository creates a new mock instance.
+func NewMockUploadRepository(ctrl *gomock.Controller) *MockUploadRepository {
+	mock := &MockUploadRepository{ctrl: ctrl}
+	mock.recorder = &MockUploadRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUploadRepository) EXPECT() *MockUploadRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Append mocks base method.
+func (m *MockUploadRepository) Append(ctx context.Context, session *model.UploadSession, offset int64, data []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Append", ctx, session, offset, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Append indicates an expected call of Append.
+func (mr *MockUploadRepositoryMockRecorder) Append(ctx, session, offset, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Append", reflect.TypeOf((*MockUploadRepository)(nil).Append), ctx, session, offset, data)
+}
+
+// Create mocks base method.
+func (m *MockUploadRepository) Create(ctx context.Context, session *model.UploadSession) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, session)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockUploadRepositoryMockRecorder) Create(ctx, session interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUploadRepository)(nil).Create), ctx, session)
+}
+
+// Delete mocks base method.
+func (m *MockUploadRepository) Delete(ctx context.Context, session *model.UploadSession) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, session)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockUploadRepositoryMockRecorder) Delete(ctx, session interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUploadRepository)(nil).Delete), ctx, session)
+}
+
+// Get mocks base method.
+func (m *MockUploadRepository) Get(ctx context.Context, id string, userID int64) (*model.UploadSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id, userID)
+	ret0, _ := ret[0].(*model.UploadSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockUploadRepositoryMockRecorder) Get(ctx, id, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockUploadRepository)(nil).Get), ctx, id, userID)
+}
+
+// Open mocks base method.
+func (m *MockUploadRepository) Open(session *model.UploadSession) (*os.File, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Open", session)
+	ret0, _ := ret[0].(*os.File)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Open indicates an expected call of Open.
+func (mr *MockUploadRepositoryMockRecorder) Open(session interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Open", reflect.TypeOf((*MockUploadRepository)(nil).Open), session)
+}
+
+// Pending mocks base method.
+func (m *MockUploadRepository) Pending(ctx context.Context, session *model.UploadSession) (int64, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pending", ctx, session)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Pending indicates an expected call of Pending.
+func (mr *MockUploadRepositoryMockRecorder) Pending(ctx, session interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pending", reflect.TypeOf((*MockUploadRepository)(nil).Pending), ctx, session)
+}
+
+// PurgeExpired mocks base method.
+func (m *MockUploadRepository) PurgeExpired(ctx context.Context, before time.Time) ([]model.UploadSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeExpired", ctx, before)
+	ret0, _ := ret[0].([]model.UploadSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeExpired indicates an expected call of PurgeExpired.
+func (mr *MockUploadRepositoryMockRecorder) PurgeExpired(ctx, before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeExpired", reflect.TypeOf((*MockUploadRepository)(nil).PurgeExpired), ctx, before)
+}
@@ -50,6 +50,20 @@ func (mr *MockUserRepositoryMockRecorder) Auth(ctx, user interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Auth", reflect.TypeOf((*MockUserRepository)(nil).Auth), ctx, user)
 }
 
+// BindKeyHash mocks base method.
+func (m *MockUserRepository) BindKeyHash(ctx context.Context, user *model.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BindKeyHash", ctx, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BindKeyHash indicates an expected call of BindKeyHash.
+func (mr *MockUserRepositoryMockRecorder) BindKeyHash(ctx, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BindKeyHash", reflect.TypeOf((*MockUserRepository)(nil).BindKeyHash), ctx, user)
+}
+
 // Register mocks base method.
 func (m *MockUserRepository) Register(ctx context.Context, user *model.User) (int64, error) {
 	m.ctrl.T.Helper()
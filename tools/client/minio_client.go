@@ -5,6 +5,7 @@ import (
 	"io"
 
 	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/lifecycle"
 )
 
 // MinioClient интерфейс для MinIO клиента
@@ -13,8 +14,11 @@ type MinioClient interface {
 	ListBuckets(ctx context.Context) ([]minio.BucketInfo, error)
 	MakeBucket(ctx context.Context, bucketName string, opts minio.MakeBucketOptions) (err error)
 	BucketExists(ctx context.Context, bucketName string) (bool, error)
+	EnableVersioning(ctx context.Context, bucketName string) error
+	SetBucketLifecycle(ctx context.Context, bucketName string, config *lifecycle.Configuration) error
 	GetObject(ctx context.Context, bucketName, objectName string, opts minio.GetObjectOptions) (MinioObject, error)
 	RemoveObject(ctx context.Context, bucketName, objectName string, opts minio.RemoveObjectOptions) error
+	CopyObject(ctx context.Context, dst minio.CopyDestOptions, src minio.CopySrcOptions) (minio.UploadInfo, error)
 	ListObjects(ctx context.Context, bucketName string, opts minio.ListObjectsOptions) <-chan minio.ObjectInfo
 	PutObject(ctx context.Context, bucketName, objectName string, reader io.Reader, objectSize int64,
 		opts minio.PutObjectOptions,
@@ -24,7 +28,9 @@ type MinioClient interface {
 // *minio.Object
 type MinioObject interface {
 	io.ReadCloser
+	io.Seeker
 	ReadAt(b []byte, offset int64) (n int, err error)
+	Stat() (minio.ObjectInfo, error)
 }
 
 func NewMinioClient(st *minio.Client) *MinioClientWrapper {
@@ -50,6 +56,14 @@ func (m *MinioClientWrapper) BucketExists(ctx context.Context, bucketName string
 	return m.client.BucketExists(ctx, bucketName)
 }
 
+func (m *MinioClientWrapper) EnableVersioning(ctx context.Context, bucketName string) error {
+	return m.client.EnableVersioning(ctx, bucketName)
+}
+
+func (m *MinioClientWrapper) SetBucketLifecycle(ctx context.Context, bucketName string, config *lifecycle.Configuration) error {
+	return m.client.SetBucketLifecycle(ctx, bucketName, config)
+}
+
 func (m *MinioClientWrapper) GetObject(ctx context.Context, bucketName, objectName string, opts minio.GetObjectOptions) (MinioObject, error) {
 	return m.client.GetObject(ctx, bucketName, objectName, opts)
 }
@@ -58,6 +72,10 @@ func (m *MinioClientWrapper) RemoveObject(ctx context.Context, bucketName, objec
 	return m.client.RemoveObject(ctx, bucketName, objectName, opts)
 }
 
+func (m *MinioClientWrapper) CopyObject(ctx context.Context, dst minio.CopyDestOptions, src minio.CopySrcOptions) (minio.UploadInfo, error) {
+	return m.client.CopyObject(ctx, dst, src)
+}
+
 func (m *MinioClientWrapper) ListObjects(ctx context.Context, bucketName string, opts minio.ListObjectsOptions) <-chan minio.ObjectInfo {
 	return m.client.ListObjects(ctx, bucketName, opts)
 }